@@ -284,6 +284,8 @@ func (c *controlServer) Run(args []string) int {
 	hubSecret := os.Getenv("HUB_SECRET_KEY")
 	hubTag := os.Getenv("HUB_IMAGE_TAG")
 
+	activityCompressor := os.Getenv("ACTIVITY_COMPRESSOR")
+
 	port := os.Getenv("PORT")
 
 	go StartHealthz(L)
@@ -315,6 +317,8 @@ func (c *controlServer) Run(args []string) int {
 		HubAccessKey: hubAccess,
 		HubSecretKey: hubSecret,
 		HubImageTag:  hubTag,
+
+		ActivityCompressor: activityCompressor,
 	})
 	if err != nil {
 		log.Fatal(err)
@@ -332,6 +336,15 @@ func (c *controlServer) Run(args []string) int {
 
 	s.SetHubTLS(cert, key, hubDomain)
 
+	// Publish every account's routing up front so the first requests after a
+	// deploy don't wait on it.
+	go func() {
+		err := s.WarmRouting(ctx)
+		if err != nil {
+			L.Error("error warming account routing", "error", err)
+		}
+	}()
+
 	// So that when they are refreshed by the background job, we eventually pick
 	// them up. Hubs are also refreshing their config on an hourly basis so they'll
 	// end up picking up the new TLS material that way too.
@@ -344,7 +357,7 @@ func (c *controlServer) Run(args []string) int {
 		}
 	})
 
-	gs := grpc.NewServer()
+	gs := grpc.NewServer(s.GRPCServerOptions()...)
 	pb.RegisterControlServicesServer(gs, s)
 	pb.RegisterControlManagementServer(gs, s)
 	pb.RegisterFlowTopReporterServer(gs, s)
@@ -672,7 +685,7 @@ func (c *devServer) Run(args []string) int {
 
 	s.SetHubTLS(cert, key, hubDomain)
 
-	gs := grpc.NewServer()
+	gs := grpc.NewServer(s.GRPCServerOptions()...)
 	pb.RegisterControlServicesServer(gs, s)
 	pb.RegisterControlManagementServer(gs, s)
 	pb.RegisterFlowTopReporterServer(gs, s)
@@ -4,6 +4,7 @@ go 1.13
 
 require (
 	cirello.io/dynamolock v1.3.3
+	github.com/DataDog/datadog-go v3.2.0+incompatible
 	github.com/armon/go-metrics v0.3.3
 	github.com/aws/aws-sdk-go v1.25.41
 	github.com/caddyserver/certmagic v0.10.3
@@ -21,6 +22,7 @@ require (
 	github.com/hashicorp/go-rootcerts v1.0.2 // indirect
 	github.com/hashicorp/go-uuid v1.0.2 // indirect
 	github.com/hashicorp/golang-lru v0.5.3
+	github.com/hashicorp/hcl v1.0.0
 	github.com/hashicorp/vault/api v1.0.5-0.20190909201928-35325e2c3262
 	github.com/hashicorp/yamux v0.0.0-20190923154419-df201c70410d
 	github.com/imdario/mergo v0.3.8 // indirect
@@ -46,7 +48,7 @@ require (
 	go.etcd.io/bbolt v1.3.3
 	golang.org/x/crypto v0.0.0-20200317142112-1b76d66859c6
 	golang.org/x/net v0.0.0-20200324143707-d3edc9973b7e
-	golang.org/x/sync v0.0.0-20200317015054-43a5402ce75a // indirect
+	golang.org/x/sync v0.0.0-20200317015054-43a5402ce75a
 	golang.org/x/sys v0.0.0-20200413165638-669c56c373c4 // indirect
 	golang.org/x/time v0.0.0-20200630173020-3af7569d3a1e
 	google.golang.org/genproto v0.0.0-20200416231807-8751e049a2a0 // indirect
@@ -505,9 +505,26 @@ func (a *Agent) handleStream(ctx context.Context, L hclog.Logger, session *yamux
 		return
 	}
 
+	proto, encoding := wire.SplitProtocol(req.ProtocolId)
+
+	wctx, err := wire.WithEncoding(wire.NewContext(nil, fr, fw), encoding)
+	if err != nil {
+		L.Error("request received with unknown encoding", "protocol", req.ProtocolId)
+
+		var resp pb.Response
+		resp.Error = fmt.Sprintf("unknown encoding: %s", encoding)
+
+		_, err = fw.WriteMarshal(255, &resp)
+		if err != nil {
+			L.Error("error marshaling response", "error", err)
+		}
+
+		return
+	}
+
 	sctx := &serviceContext{
-		Context:    wire.NewContext(nil, fr, fw),
-		protocolId: req.ProtocolId,
+		Context:    wctx,
+		protocolId: proto,
 		fr:         fr,
 		stream:     w,
 	}
@@ -0,0 +1,376 @@
+package control
+
+import (
+	"bytes"
+	context "context"
+	"database/sql"
+
+	"github.com/hashicorp/horizon/pkg/dbx"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+// ExportAccountConfig returns the limits, label links, and services of an
+// account, so they can be loaded into another environment with
+// ImportAccountConfig.
+func (s *Server) ExportAccountConfig(ctx context.Context, req *pb.ExportRequest) (*pb.AccountConfig, error) {
+	L := s.requestLogger(ctx).Named("export-account-config")
+
+	caller, err := s.checkMgmtAllowed(ctx)
+	if err != nil {
+		L.Error("error checking mgmt token", "err", err)
+		return nil, err
+	}
+
+	err = s.checkAccountAllowed(L, caller, req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := accountKey(req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pb.AccountConfig{
+		Account: req.Account,
+	}
+
+	var ao Account
+
+	err = dbx.Check(s.db.First(&ao, key))
+	switch err {
+	case nil:
+		var limits pb.Account_Limits
+		ao.Data.Get("limits", &limits)
+
+		out.Limits = &limits
+	case gorm.ErrRecordNotFound:
+		// Services can be registered before the account is, so there may
+		// be nothing but them to export.
+	default:
+		return nil, err
+	}
+
+	var links []*LabelLink
+
+	err = dbx.Check(s.db.Where("account_id = ?", key).Order("id").Find(&links))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ll := range links {
+		splits, err := decodeSplits(ll.Splits)
+		if err != nil {
+			return nil, err
+		}
+
+		out.LabelLinks = append(out.LabelLinks, &pb.LabelLink{
+			Account: req.Account,
+			Labels:  ExplodeLabels(ll.Labels),
+			Target:  ExplodeLabels(ll.Target),
+			Limits:  out.Limits,
+			Splits:  splits,
+		})
+	}
+
+	var services []*Service
+
+	err = dbx.Check(s.db.Where("account_id = ?", key).Order("id").Find(&services))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, svc := range services {
+		var ls pb.LabelSet
+
+		err = ls.Scan(svc.Labels)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Services = append(out.Services, &pb.ServiceRequest{
+			Account: req.Account,
+			Hub:     pb.ULIDFromBytes(svc.HubId),
+			Id:      pb.ULIDFromBytes(svc.ServiceId),
+			Type:    svc.Type,
+			Labels:  &ls,
+		})
+	}
+
+	L.Info("exported account config",
+		"account", req.Account.SpecString(),
+		"label-links", len(out.LabelLinks),
+		"services", len(out.Services),
+	)
+
+	return out, nil
+}
+
+// ImportAccountConfig applies a config from ExportAccountConfig. The account,
+// label links, and services in the config are created or replaced in a single
+// transaction, so importing the same config again changes nothing. Label links
+// and services the account already has that aren't in the config are left
+// alone.
+func (s *Server) ImportAccountConfig(ctx context.Context, req *pb.ImportRequest) (*pb.Noop, error) {
+	L := s.requestLogger(ctx).Named("import-account-config")
+
+	caller, err := s.checkMgmtAllowed(ctx)
+	if err != nil {
+		L.Error("error checking mgmt token", "err", err)
+		return nil, err
+	}
+
+	cfg := req.Config
+	if cfg == nil {
+		return nil, errors.Wrapf(ErrInvalidRequest, "no config specified")
+	}
+
+	err = s.checkAccountAllowed(L, caller, cfg.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := accountKey(cfg.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ll := range cfg.LabelLinks {
+		err = checkConfigAccount(key, ll.Account)
+		if err != nil {
+			return nil, err
+		}
+
+		if ll.Labels == nil || ll.Target == nil {
+			return nil, errors.Wrapf(ErrInvalidRequest, "label-link missing labels or target")
+		}
+
+		err = s.checkLabelLimits("label-link labels", ll.Labels)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.checkLabelLimits("label-link target", ll.Target)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkLabelLink(ll.Labels, ll.Target)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.checkSplits(ll.Labels, ll.Splits)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, svc := range cfg.Services {
+		err = checkConfigAccount(key, svc.Account)
+		if err != nil {
+			return nil, err
+		}
+
+		if svc.Id == nil || svc.Hub == nil {
+			return nil, errors.Wrapf(ErrInvalidRequest, "service missing id or hub")
+		}
+	}
+
+	L.Info("importing account config",
+		"account", cfg.Account.SpecString(),
+		"label-links", len(cfg.LabelLinks),
+		"services", len(cfg.Services),
+	)
+
+	tx := s.db.Begin()
+
+	err = importAccountConfig(tx, key, cfg)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = dbx.Check(tx.Commit())
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the limits back, since an existing account keeps its limits if the
+	// config doesn't have any.
+	var ao Account
+
+	err = dbx.Check(s.db.First(&ao, key))
+	if err != nil {
+		return nil, err
+	}
+
+	var limits pb.Account_Limits
+	ao.Data.Get("limits", &limits)
+
+	var act pb.CentralActivity
+
+	if len(cfg.LabelLinks) > 0 {
+		var out pb.LabelLinks
+
+		for _, ll := range cfg.LabelLinks {
+			out.LabelLinks = append(out.LabelLinks, &pb.LabelLink{
+				Account: cfg.Account,
+				Labels:  ll.Labels,
+				Target:  ll.Target,
+				Limits:  &limits,
+				Splits:  ll.Splits,
+			})
+		}
+
+		act.NewLabelLinks = &out
+	}
+
+	// The services of a suspended account are withheld from hubs.
+	if len(cfg.Services) > 0 && !ao.Suspended {
+		var routes []*pb.ServiceRoute
+
+		for _, svc := range cfg.Services {
+			routes = append(routes, &pb.ServiceRoute{
+				Hub:    svc.Hub,
+				Id:     svc.Id,
+				Type:   svc.Type,
+				Labels: svc.Labels,
+			})
+		}
+
+		err = s.annotateRoutes(s.db, routes)
+		if err != nil {
+			return nil, err
+		}
+
+		act.AccountServices = []*pb.AccountServices{
+			{
+				Account:  cfg.Account,
+				Services: routes,
+			},
+		}
+	}
+
+	if act.NewLabelLinks != nil || act.AccountServices != nil {
+		s.broadcastActivity(&act)
+	}
+
+	if len(cfg.Services) > 0 {
+		err = s.updateAccountRouting(ctx, s.db, cfg.Account)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(cfg.LabelLinks) > 0 {
+		err = s.updateLabelLinks(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &pb.Noop{}, nil
+}
+
+// checkConfigAccount verifies that an item in an account config either
+// doesn't name an account or names the one the config is for.
+func checkConfigAccount(key []byte, account *pb.Account) error {
+	if account == nil {
+		return nil
+	}
+
+	itemKey, err := accountKey(account)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(itemKey, key) {
+		return errors.Wrapf(ErrInvalidRequest, "config contains a different account: %s", account.SpecString())
+	}
+
+	return nil
+}
+
+func importAccountConfig(tx *gorm.DB, key []byte, cfg *pb.AccountConfig) error {
+	var ao Account
+	ao.ID = key
+	ao.Namespace = cfg.Account.Namespace
+
+	conflict := "ON CONFLICT (id) DO UPDATE SET namespace = EXCLUDED.namespace"
+
+	if cfg.Limits != nil {
+		err := ao.Data.Set("limits", cfg.Limits)
+		if err != nil {
+			return errors.Wrapf(ErrInvalidRequest, "error parsing limits: %s", err)
+		}
+
+		conflict += ", data = EXCLUDED.data"
+	}
+
+	err := dbx.Check(tx.Set("gorm:insert_option", conflict).Create(&ao))
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return errors.Wrapf(err, "creating account record")
+		}
+	}
+
+	for _, ll := range cfg.LabelLinks {
+		labels := FlattenLabels(ll.Labels)
+
+		err = dbx.Check(tx.
+			Where("account_id = ?", key).
+			Where("labels = ?", labels).
+			Delete(&LabelLink{}),
+		)
+		if err != nil {
+			return err
+		}
+
+		splits, err := encodeSplits(ll.Splits)
+		if err != nil {
+			return err
+		}
+
+		err = dbx.Check(tx.Create(&LabelLink{
+			AccountID: key,
+			Labels:    labels,
+			Target:    FlattenLabels(ll.Target),
+			Splits:    splits,
+		}))
+		if err != nil {
+			return errors.Wrapf(err, "creating label-link record")
+		}
+	}
+
+	for _, svc := range cfg.Services {
+		err = dbx.Check(tx.
+			Where("account_id = ?", key).
+			Where("service_id = ?", svc.Id.Bytes()).
+			Delete(&Service{}),
+		)
+		if err != nil {
+			return err
+		}
+
+		var labels []string
+		if svc.Labels != nil {
+			labels = svc.Labels.AsStringArray()
+		}
+
+		err = dbx.Check(tx.Create(&Service{
+			AccountId: key,
+			HubId:     svc.Hub.Bytes(),
+			ServiceId: svc.Id.Bytes(),
+			Type:      svc.Type,
+			Labels:    labels,
+		}))
+		if err != nil {
+			return errors.Wrapf(err, "creating service record")
+		}
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/horizon/pkg/dbx"
 	"github.com/jinzhu/gorm"
 	"github.com/lib/pq"
+	"github.com/pkg/errors"
 )
 
 // A quick note. This activity system is different than the one used between the hubs and control.
@@ -22,12 +23,45 @@ type ActivityLog struct {
 	CreatedAt time.Time
 }
 
+// ActivityCursor records the last activity log entry a reader has
+// acknowledged, so a reader opened with the same cursor after a restart picks
+// up where the last one left off.
+type ActivityCursor struct {
+	Name      string `gorm:"primary_key"`
+	LastEntry int64
+	UpdatedAt time.Time
+}
+
+// Each entry is added in its own transaction, so one can commit after an
+// entry with a higher id, leaving a gap in the ids read until it does. How
+// long a reader waits for a gap to be filled before giving up on it, such as
+// when the transaction that took the id rolled back.
+var ActivityGapTimeout = time.Minute
+
+// The most ids a reader waits for at once. Ids skipped beyond this, such as
+// by a jump in the sequence, aren't waited for.
+var ActivityMaxGaps = 1000
+
 type ActivityReader struct {
 	db       *gorm.DB
 	listener *pq.Listener
 
+	cursor string
+	cancel func()
+
+	// Guards lastEntry, gaps and acked, which Ack uses.
+	mu sync.Mutex
+
+	// The highest id read, and the ids below it that weren't there when it
+	// was read, with when they were first missed.
 	lastEntry int64
-	cancel    func()
+	gaps      map[int64]time.Time
+
+	// The highest id acknowledged.
+	acked int64
+
+	// Closed once the listener first connects.
+	connected chan struct{}
 
 	C chan []*ActivityLog
 
@@ -36,6 +70,27 @@ type ActivityReader struct {
 
 var pgActivityChannel = "activaty_added"
 
+type activityCursorKey struct{}
+
+// WithActivityCursor returns ctx set up so that activity sources opened with it
+// track the entries they've delivered under the cursor name. Sources that
+// support it, such as the ActivityReader, then only advance the cursor once
+// entries are acknowledged and redeliver the rest when reopened.
+func WithActivityCursor(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, activityCursorKey{}, name)
+}
+
+// ActivityCursorFromContext returns the cursor name set with
+// WithActivityCursor, or "" if there isn't one.
+func ActivityCursorFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(activityCursorKey{}).(string)
+	return name
+}
+
+// NewActivityReader returns a reader for the entries added to the activity log
+// after it's opened. If ctx has a cursor set with WithActivityCursor, it
+// instead starts after the last entry acknowledged with it, and entries are
+// only considered read once passed to Ack.
 func NewActivityReader(ctx context.Context, dbtype, conn string) (*ActivityReader, error) {
 	db, err := gorm.Open(dbtype, conn)
 	if err != nil {
@@ -44,10 +99,19 @@ func NewActivityReader(ctx context.Context, dbtype, conn string) (*ActivityReade
 
 	L := hclog.FromContext(ctx)
 
+	var (
+		connected     = make(chan struct{})
+		connectedOnce sync.Once
+	)
+
 	reportProblem := func(ev pq.ListenerEventType, err error) {
 		if err != nil {
 			L.Error("problem observed while listen on postgres channel", "error", err)
 		}
+
+		if ev == pq.ListenerEventConnected {
+			connectedOnce.Do(func() { close(connected) })
+		}
 	}
 
 	minReconn := 10 * time.Second
@@ -60,13 +124,13 @@ func NewActivityReader(ctx context.Context, dbtype, conn string) (*ActivityReade
 		return nil, err
 	}
 
-	var entry ActivityLog
+	cursor := ActivityCursorFromContext(ctx)
 
-	err = dbx.Check(db.Last(&entry))
+	lastEntry, err := activityStart(db, cursor)
 	if err != nil {
-		if err != gorm.ErrRecordNotFound {
-			return nil, err
-		}
+		listener.Close()
+		db.Close()
+		return nil, err
 	}
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -75,8 +139,11 @@ func NewActivityReader(ctx context.Context, dbtype, conn string) (*ActivityReade
 		db:        db,
 		listener:  listener,
 		C:         make(chan []*ActivityLog),
-		lastEntry: entry.Id,
+		lastEntry: lastEntry,
+		gaps:      make(map[int64]time.Time),
+		cursor:    cursor,
 		cancel:    cancel,
+		connected: connected,
 	}
 
 	ar.wg.Add(1)
@@ -85,12 +152,100 @@ func NewActivityReader(ctx context.Context, dbtype, conn string) (*ActivityReade
 	return ar, nil
 }
 
+// activityStart returns the id of the entry a reader should start after. That's
+// the one last acknowledged with cursor, if there is one. Otherwise it's the
+// newest entry, which is recorded as the start of the cursor.
+func activityStart(db *gorm.DB, cursor string) (int64, error) {
+	if cursor != "" {
+		var ac ActivityCursor
+
+		err := dbx.Check(db.Where("name = ?", cursor).First(&ac))
+		if err == nil {
+			return ac.LastEntry, nil
+		}
+
+		if err != gorm.ErrRecordNotFound {
+			return 0, errors.Wrapf(err, "reading activity cursor")
+		}
+	}
+
+	var entry ActivityLog
+
+	err := dbx.Check(db.Last(&entry))
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			return 0, err
+		}
+	}
+
+	if cursor != "" {
+		err = saveActivityCursor(db, cursor, entry.Id)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return entry.Id, nil
+}
+
+// saveActivityCursor moves cursor forward to id. It never moves it back, so
+// acknowledging entries that were redelivered is harmless.
+func saveActivityCursor(db *gorm.DB, cursor string, id int64) error {
+	ac := ActivityCursor{
+		Name:      cursor,
+		LastEntry: id,
+	}
+
+	err := dbx.Check(db.Set("gorm:insert_option",
+		"ON CONFLICT (name) DO UPDATE SET last_entry = GREATEST(activity_cursors.last_entry, EXCLUDED.last_entry), updated_at = EXCLUDED.updated_at",
+	).Create(&ac))
+	if err != nil {
+		return errors.Wrapf(err, "saving activity cursor")
+	}
+
+	return nil
+}
+
+// Ack records that entries have been handled, advancing the reader's cursor
+// past them. The cursor is never advanced past an id the reader is still
+// waiting for, so an entry that commits late is still read after a restart.
+// Without a cursor it does nothing.
+func (ar *ActivityReader) Ack(entries []*ActivityLog) error {
+	if ar.cursor == "" || len(entries) == 0 {
+		return nil
+	}
+
+	ar.mu.Lock()
+
+	for _, entry := range entries {
+		if entry.Id > ar.acked {
+			ar.acked = entry.Id
+		}
+	}
+
+	last := ar.acked
+
+	for id := range ar.gaps {
+		if id <= last {
+			last = id - 1
+		}
+	}
+
+	ar.mu.Unlock()
+
+	return saveActivityCursor(ar.db, ar.cursor, last)
+}
+
 func (ar *ActivityReader) watch(ctx context.Context, L hclog.Logger) {
 	defer ar.wg.Done()
 
 	ticker := time.NewTicker(time.Minute)
 	defer ticker.Stop()
 
+	// Deliver anything left over from before the reader was opened, which
+	// there only is when resuming a cursor.
+	ar.checkLog(ctx, L)
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -106,10 +261,19 @@ func (ar *ActivityReader) watch(ctx context.Context, L hclog.Logger) {
 }
 
 func (ar *ActivityReader) checkLog(ctx context.Context, L hclog.Logger) {
+	ar.expireGaps(L)
+
 	for {
 		var entries []*ActivityLog
 
-		err := dbx.Check(ar.db.Where("id > ?", ar.lastEntry).Limit(100).Find(&entries))
+		last, gaps := ar.position()
+
+		q := ar.db.Where("id > ?", last)
+		if len(gaps) > 0 {
+			q = ar.db.Where("id > ? OR id IN (?)", last, gaps)
+		}
+
+		err := dbx.Check(q.Order("id ASC").Limit(100).Find(&entries))
 		if err != nil {
 			if err != gorm.ErrRecordNotFound {
 				L.Error("error looking for new activity log entries", "error", err)
@@ -129,10 +293,78 @@ func (ar *ActivityReader) checkLog(ctx context.Context, L hclog.Logger) {
 			// ok
 		}
 
-		ar.lastEntry = entries[len(entries)-1].Id
+		ar.advance(L, entries)
+	}
+}
+
+// position returns the highest id read and the ids below it being waited for.
+func (ar *ActivityReader) position() (int64, []int64) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	gaps := make([]int64, 0, len(ar.gaps))
+
+	for id := range ar.gaps {
+		gaps = append(gaps, id)
+	}
+
+	return ar.lastEntry, gaps
+}
+
+// advance records that entries, in order of their ids, have been read. Ids
+// they skip over are waited for, and ids being waited for are done with.
+func (ar *ActivityReader) advance(L hclog.Logger, entries []*ActivityLog) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	now := time.Now()
+
+	for _, entry := range entries {
+		if entry.Id <= ar.lastEntry {
+			delete(ar.gaps, entry.Id)
+			continue
+		}
+
+		for id := ar.lastEntry + 1; id < entry.Id; id++ {
+			if len(ar.gaps) >= ActivityMaxGaps {
+				L.Warn("too many gaps in activity log, not waiting for the rest", "from", id, "to", entry.Id-1)
+				break
+			}
+
+			ar.gaps[id] = now
+		}
+
+		ar.lastEntry = entry.Id
 	}
 }
 
+// expireGaps stops waiting for ids that have been missing for longer than
+// ActivityGapTimeout.
+func (ar *ActivityReader) expireGaps(L hclog.Logger) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	cutoff := time.Now().Add(-ActivityGapTimeout)
+
+	for id, missed := range ar.gaps {
+		if missed.Before(cutoff) {
+			L.Debug("giving up waiting for activity log entry", "id", id)
+			delete(ar.gaps, id)
+		}
+	}
+}
+
+// Connected returns a channel that's closed once the reader is listening for
+// new entries.
+func (ar *ActivityReader) Connected() <-chan struct{} {
+	return ar.connected
+}
+
+// Entries returns the channel the new log entries are delivered on.
+func (ar *ActivityReader) Entries() <-chan []*ActivityLog {
+	return ar.C
+}
+
 func (ar *ActivityReader) Close() error {
 	ar.cancel()
 	ar.wg.Wait()
@@ -0,0 +1,129 @@
+package control
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// How old activity can be and still be replayed to a hub that connects, when
+// ServerConfig.ActivityReplayWindow isn't set.
+var DefaultActivityReplayWindow = time.Minute
+
+// activityReplay keeps the most recent activity broadcast to the hubs, so a
+// hub that reconnects can be sent what it missed while it was away rather
+// than waiting for its next full refresh.
+type activityReplay struct {
+	mu sync.Mutex
+
+	// A ring of up to size entries, with next being where the next one goes
+	// and so the oldest once the ring is full.
+	entries []replayEntry
+	next    int
+	size    int
+}
+
+type replayEntry struct {
+	act *pb.CentralActivity
+	at  time.Time
+}
+
+func newActivityReplay(size int) *activityReplay {
+	return &activityReplay{
+		entries: make([]replayEntry, 0, size),
+		size:    size,
+	}
+}
+
+// add records act as broadcast at the given time, replacing the oldest
+// activity if the ring is full.
+func (r *activityReplay) add(act *pb.CentralActivity, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	re := replayEntry{act: act, at: at}
+
+	if len(r.entries) < r.size {
+		r.entries = append(r.entries, re)
+		return
+	}
+
+	r.entries[r.next] = re
+	r.next = (r.next + 1) % r.size
+}
+
+// since returns the activity broadcast at or after t, oldest first.
+func (r *activityReplay) since(t time.Time) []*pb.CentralActivity {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []*pb.CentralActivity
+
+	for i := 0; i < len(r.entries); i++ {
+		re := r.entries[(r.next+i)%len(r.entries)]
+
+		if !re.at.Before(t) {
+			out = append(out, re.act)
+		}
+	}
+
+	return out
+}
+
+// covers reports if every activity added at or after t is still kept, which
+// it is unless the ring has since replaced one of them.
+func (r *activityReplay) covers(t time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.entries) < r.size {
+		return true
+	}
+
+	return r.entries[r.next].at.Before(t)
+}
+
+// recordActivity adds act to the activity replayed to hubs that connect, if
+// replaying is enabled. Requests for stats are only meant for the hubs
+// connected at the time, so they aren't kept. Neither is news of rotated S3
+// credentials, which hubs that connect get in FetchConfig anyway, and
+// credentials are never kept, so they can't be handed out after they're
+// rotated.
+func (s *Server) recordActivity(act *pb.CentralActivity) {
+	if s.replay == nil || act.RequestStats || act.S3CredentialsRotated || act.S3Credentials != nil {
+		return
+	}
+
+	s.replay.add(act, s.now())
+}
+
+// replayActivity returns the recent activity to send to a hub that just
+// connected.
+func (s *Server) replayActivity() []*pb.CentralActivity {
+	if s.replay == nil {
+		return nil
+	}
+
+	return s.replay.since(s.now().Add(-s.replayWindow()))
+}
+
+// replayCovers reports if replaying is enabled and the replay still has all
+// the activity from its window, so a hub that connects is caught up by it.
+// Once the ring has had to replace activity inside the window to make room,
+// a hub that connects could miss it.
+func (s *Server) replayCovers() bool {
+	if s.replay == nil {
+		return false
+	}
+
+	return s.replay.covers(s.now().Add(-s.replayWindow()))
+}
+
+func (s *Server) replayWindow() time.Duration {
+	if s.cfg.ActivityReplayWindow <= 0 {
+		return DefaultActivityReplayWindow
+	}
+
+	return s.cfg.ActivityReplayWindow
+}
@@ -0,0 +1,272 @@
+package control
+
+import (
+	context "context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/pkg/errors"
+)
+
+// ActivitySource delivers the activity log entries written by any of the
+// control servers. The ActivityReader, which reads them from postgresql, is
+// the only one included. Deployments that already run a message bus, such as
+// NATS or Kafka, can register a source that reads from it with
+// RegisterActivitySource, but have to get the entries onto the bus
+// themselves, since the control servers only write them to the activity_logs
+// table.
+//
+// A source closes the channel returned by Entries when it can no longer
+// deliver entries, such as when its connection is lost. The server then
+// closes it and opens a new one.
+type ActivitySource interface {
+	Entries() <-chan []*ActivityLog
+	Close() error
+}
+
+// ActivityAcker is implemented by ActivitySources that can redeliver entries
+// that weren't handled. The server calls Ack with each batch of entries once
+// they've been broadcast, so entries received but not broadcast before a
+// restart are delivered again. Broadcasting an entry twice is harmless, as
+// hubs replace the routes to services they already know about.
+type ActivityAcker interface {
+	Ack(entries []*ActivityLog) error
+}
+
+// ActivityConnector is implemented by ActivitySources that connect in the
+// background after they're opened, such as the ActivityReader. The channel
+// returned by Connected is closed once the source has connected, so it won't
+// miss entries written from then on. Sources that don't implement it are
+// considered connected once they're opened.
+type ActivityConnector interface {
+	Connected() <-chan struct{}
+}
+
+// ActivitySourceOpener opens an ActivitySource, with conn being the
+// source specific connection string.
+type ActivitySourceOpener func(ctx context.Context, conn string) (ActivitySource, error)
+
+var ErrUnknownActivitySource = errors.New("unknown activity source")
+
+var (
+	activitySourcesMu sync.RWMutex
+	activitySources   = map[string]ActivitySourceOpener{
+		"postgres": func(ctx context.Context, conn string) (ActivitySource, error) {
+			return NewActivityReader(ctx, "postgres", conn)
+		},
+	}
+)
+
+// RegisterActivitySource makes a type of ActivitySource available to
+// StartActivityReader under name, replacing any already registered with it.
+// Only "postgres" is registered by default.
+func RegisterActivitySource(name string, open ActivitySourceOpener) {
+	activitySourcesMu.Lock()
+	defer activitySourcesMu.Unlock()
+
+	activitySources[name] = open
+}
+
+func activitySourceOpener(name string) (ActivitySourceOpener, error) {
+	activitySourcesMu.RLock()
+	defer activitySourcesMu.RUnlock()
+
+	open, ok := activitySources[name]
+	if !ok {
+		var known []string
+		for k := range activitySources {
+			known = append(known, k)
+		}
+
+		sort.Strings(known)
+
+		return nil, errors.Wrapf(ErrUnknownActivitySource, "%s (known: %s)", name, strings.Join(known, ", "))
+	}
+
+	return open, nil
+}
+
+// How long to wait before reopening an activity source that failed,
+// doubling on each consecutive failure up to the max.
+var (
+	ActivitySourceRetryDelay    = time.Second
+	ActivitySourceMaxRetryDelay = time.Minute
+)
+
+// StartActivitySource opens a source with open and broadcasts the activity it
+// delivers to the hubs until ctx is done. Whenever the source fails, it's
+// closed and a new one opened. The first open has to succeed, so that a
+// misconfigured source is reported right away.
+//
+// Until a source has connected, Ready reports that the server isn't ready, as
+// it would miss the routing changes made by other control servers.
+func (s *Server) StartActivitySource(ctx context.Context, open func(ctx context.Context) (ActivitySource, error)) error {
+	s.activityMu.Lock()
+	s.activityStarted = true
+	s.activityMu.Unlock()
+
+	src, err := open(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.waitActivityConnected(ctx, src)
+
+	go s.superviseActivitySource(ctx, src, open)
+
+	return nil
+}
+
+func (s *Server) superviseActivitySource(ctx context.Context, src ActivitySource, open func(ctx context.Context) (ActivitySource, error)) {
+	L := s.L.Named("activity-source")
+
+	delay := ActivitySourceRetryDelay
+
+	for {
+		if src != nil {
+			if s.readActivitySource(ctx, src) {
+				// Entries were delivered, so the source worked for a while
+				// and the next failure starts the backoff over.
+				delay = ActivitySourceRetryDelay
+			}
+
+			err := src.Close()
+			if err != nil {
+				L.Error("error closing activity source", "error", err)
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			L.Warn("activity source failed, reopening", "delay", delay)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		var err error
+
+		src, err = open(ctx)
+		if err != nil {
+			L.Error("error reopening activity source", "error", err)
+			src = nil
+		} else {
+			s.waitActivityConnected(ctx, src)
+		}
+
+		delay *= 2
+		if delay > ActivitySourceMaxRetryDelay {
+			delay = ActivitySourceMaxRetryDelay
+		}
+	}
+}
+
+// waitActivityConnected marks the server as having an activity source
+// connected once src connects, unless one already has.
+func (s *Server) waitActivityConnected(ctx context.Context, src ActivitySource) {
+	s.activityMu.Lock()
+	connected := s.activityConnected
+	s.activityMu.Unlock()
+
+	if connected {
+		return
+	}
+
+	ac, ok := src.(ActivityConnector)
+	if !ok {
+		s.setActivityConnected()
+		return
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-ac.Connected():
+			s.setActivityConnected()
+		}
+	}()
+}
+
+func (s *Server) setActivityConnected() {
+	s.activityMu.Lock()
+	defer s.activityMu.Unlock()
+
+	if !s.activityConnected {
+		s.L.Info("activity source connected, server is ready")
+	}
+
+	s.activityConnected = true
+}
+
+// Ready returns true once the server can see the changes made by every
+// control server: either no activity source was started, or one has
+// connected at least once. It stays ready while a failed source is reopened.
+func (s *Server) Ready() bool {
+	s.activityMu.Lock()
+	defer s.activityMu.Unlock()
+
+	return !s.activityStarted || s.activityConnected
+}
+
+// readActivitySource broadcasts the entries from src until it fails or ctx is
+// done, reporting if any were delivered.
+func (s *Server) readActivitySource(ctx context.Context, src ActivitySource) bool {
+	var delivered bool
+
+	for {
+		select {
+		case <-ctx.Done():
+			return delivered
+		case ev, ok := <-src.Entries():
+			if !ok {
+				return delivered
+			}
+
+			delivered = true
+
+			s.broadcastActivityLog(ev)
+
+			if acker, ok := src.(ActivityAcker); ok {
+				err := acker.Ack(ev)
+				if err != nil {
+					// The entries will be delivered again if the source is
+					// reopened before a later ack succeeds.
+					s.L.Error("error acknowledging activity log entries", "error", err)
+				}
+			}
+		}
+	}
+}
+
+// broadcastActivityLog sends the routes added in entries to the hubs.
+func (s *Server) broadcastActivityLog(entries []*ActivityLog) {
+	L := s.L
+
+	L.Info("detected activity")
+
+	var adds []*pb.AccountServices
+
+	for _, act := range entries {
+		var ae pb.ActivityEntry
+
+		err := json.Unmarshal(act.Event, &ae)
+		if err != nil {
+			L.Error("error unmarshaling activity log entry", "error", err)
+			continue
+		}
+
+		adds = append(adds, ae.RouteAdded)
+	}
+
+	s.broadcastActivity(&pb.CentralActivity{
+		AccountServices: adds,
+	})
+}
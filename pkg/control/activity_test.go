@@ -2,11 +2,17 @@ package control
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/horizon/internal/testsql"
 	"github.com/hashicorp/horizon/pkg/dbx"
+	"github.com/hashicorp/horizon/pkg/pb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -53,6 +59,324 @@ func TestActivity(t *testing.T) {
 		}
 	})
 
+	t.Run("rereads entries that weren't acknowledged before a restart", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, testDbName)
+		defer db.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		ctx = WithActivityCursor(ctx, "central-1")
+
+		ar, err := NewActivityReader(ctx, "postgres",
+			testsql.TestPostgresDBString(t, testDbName))
+		require.NoError(t, err)
+
+		time.Sleep(time.Second)
+
+		ai, err := NewActivityInjector(db)
+		require.NoError(t, err)
+
+		read := func(ar *ActivityReader) []*ActivityLog {
+			select {
+			case <-ctx.Done():
+				require.NoError(t, ctx.Err())
+				return nil
+			case entries := <-ar.C:
+				return entries
+			}
+		}
+
+		err = ai.Inject(ctx, []byte(`"broadcast"`))
+		require.NoError(t, err)
+
+		entries := read(ar)
+		require.Len(t, entries, 1)
+		assert.Equal(t, []byte(`"broadcast"`), entries[0].Event)
+
+		require.NoError(t, ar.Ack(entries))
+
+		err = ai.Inject(ctx, []byte(`"received"`))
+		require.NoError(t, err)
+
+		entries = read(ar)
+		require.Len(t, entries, 1)
+		assert.Equal(t, []byte(`"received"`), entries[0].Event)
+
+		// Crash after receiving the entry but before it's broadcast and
+		// acknowledged.
+		require.NoError(t, ar.Close())
+
+		ar, err = NewActivityReader(ctx, "postgres",
+			testsql.TestPostgresDBString(t, testDbName))
+		require.NoError(t, err)
+
+		defer ar.Close()
+
+		entries = read(ar)
+		require.Len(t, entries, 1)
+		assert.Equal(t, []byte(`"received"`), entries[0].Event)
+
+		require.NoError(t, ar.Ack(entries))
+
+		var ac ActivityCursor
+		err = dbx.Check(db.Where("name = ?", "central-1").First(&ac))
+		require.NoError(t, err)
+
+		assert.Equal(t, entries[0].Id, ac.LastEntry)
+
+		// Acknowledging older entries again doesn't move the cursor back.
+		require.NoError(t, ar.Ack([]*ActivityLog{{Id: entries[0].Id - 1}}))
+
+		err = dbx.Check(db.Where("name = ?", "central-1").First(&ac))
+		require.NoError(t, err)
+
+		assert.Equal(t, entries[0].Id, ac.LastEntry)
+	})
+
+	t.Run("reads entries that commit after later ones", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, testDbName)
+		defer db.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		ctx = WithActivityCursor(ctx, "central-1")
+
+		ar, err := NewActivityReader(ctx, "postgres",
+			testsql.TestPostgresDBString(t, testDbName))
+		require.NoError(t, err)
+
+		defer ar.Close()
+
+		time.Sleep(time.Second)
+
+		ai, err := NewActivityInjector(db)
+		require.NoError(t, err)
+
+		read := func() []*ActivityLog {
+			select {
+			case <-ctx.Done():
+				require.NoError(t, ctx.Err())
+				return nil
+			case entries := <-ar.C:
+				return entries
+			}
+		}
+
+		cursor := func() int64 {
+			var ac ActivityCursor
+			err := dbx.Check(db.Where("name = ?", "central-1").First(&ac))
+			require.NoError(t, err)
+
+			return ac.LastEntry
+		}
+
+		// Takes its id first, but commits last.
+		tx := db.Begin()
+
+		slow := ActivityLog{Event: []byte(`"slow"`)}
+		require.NoError(t, dbx.Check(tx.Create(&slow)))
+
+		err = ai.Inject(ctx, []byte(`"fast"`))
+		require.NoError(t, err)
+
+		entries := read()
+		require.Len(t, entries, 1)
+		assert.Equal(t, []byte(`"fast"`), entries[0].Event)
+
+		fast := entries[0]
+
+		// The cursor stays behind the entry that's still to come.
+		require.NoError(t, ar.Ack(entries))
+		assert.Equal(t, slow.Id-1, cursor())
+
+		tx.Exec("NOTIFY " + pgActivityChannel)
+		require.NoError(t, dbx.Check(tx.Commit()))
+
+		entries = read()
+		require.Len(t, entries, 1)
+		assert.Equal(t, []byte(`"slow"`), entries[0].Event)
+
+		require.NoError(t, ar.Ack(entries))
+		assert.Equal(t, fast.Id, cursor())
+	})
+
+	t.Run("acknowledges entries once they're broadcast", func(t *testing.T) {
+		var s Server
+		s.L = hclog.L()
+		s.connectedHubs = map[string]*connectedHub{
+			"hub": {
+				xmit: make(chan *pb.CentralActivity, 10),
+			},
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		src := &fakeActivitySource{
+			C:    make(chan []*ActivityLog),
+			acks: make(chan []*ActivityLog, 1),
+		}
+
+		err := s.StartActivitySource(ctx, func(ctx context.Context) (ActivitySource, error) {
+			return src, nil
+		})
+		require.NoError(t, err)
+
+		event, err := json.Marshal(&pb.ActivityEntry{
+			RouteAdded: &pb.AccountServices{
+				Account: &pb.Account{
+					Namespace: "/",
+					AccountId: pb.NewULID(),
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		entries := []*ActivityLog{{Id: 7, Event: event}}
+
+		src.C <- entries
+
+		select {
+		case <-ctx.Done():
+			require.NoError(t, ctx.Err())
+		case <-s.connectedHubs["hub"].xmit:
+		}
+
+		select {
+		case <-ctx.Done():
+			require.NoError(t, ctx.Err())
+		case acked := <-src.acks:
+			assert.Equal(t, entries, acked)
+		}
+	})
+
+	t.Run("broadcasts activity from any source, reopening failed ones", func(t *testing.T) {
+		defer func(d time.Duration) {
+			ActivitySourceRetryDelay = d
+		}(ActivitySourceRetryDelay)
+
+		ActivitySourceRetryDelay = 10 * time.Millisecond
+
+		var s Server
+		s.L = hclog.L()
+		s.connectedHubs = map[string]*connectedHub{
+			"hub": {
+				xmit: make(chan *pb.CentralActivity, 10),
+			},
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		sources := make(chan *fakeActivitySource, 2)
+
+		opened := 0
+
+		RegisterActivitySource("fake", func(ctx context.Context, conn string) (ActivitySource, error) {
+			assert.Equal(t, "fake://", conn)
+
+			opened++
+
+			src := &fakeActivitySource{C: make(chan []*ActivityLog)}
+			sources <- src
+
+			return src, nil
+		})
+
+		err := s.StartActivityReader(ctx, "fake", "fake://")
+		require.NoError(t, err)
+
+		account := &pb.Account{
+			Namespace: "/",
+			AccountId: pb.NewULID(),
+		}
+
+		event, err := json.Marshal(&pb.ActivityEntry{
+			RouteAdded: &pb.AccountServices{
+				Account: account,
+			},
+		})
+		require.NoError(t, err)
+
+		expectBroadcast := func() {
+			select {
+			case <-ctx.Done():
+				require.NoError(t, ctx.Err())
+			case act := <-s.connectedHubs["hub"].xmit:
+				require.Len(t, act.AccountServices, 1)
+				assert.True(t, account.Equal(act.AccountServices[0].Account))
+			}
+		}
+
+		first := <-sources
+
+		first.C <- []*ActivityLog{{Event: event}}
+		expectBroadcast()
+
+		// The source fails, so it should be closed and a new one opened.
+		close(first.C)
+
+		second := <-sources
+
+		assert.True(t, first.closed)
+		assert.Equal(t, 2, opened)
+
+		second.C <- []*ActivityLog{{Event: event}}
+		expectBroadcast()
+
+		err = s.StartActivityReader(ctx, "unknown", "")
+		assert.True(t, errors.Is(err, ErrUnknownActivitySource))
+	})
+
+	t.Run("isn't ready until the activity source connects", func(t *testing.T) {
+		var s Server
+		s.L = hclog.L()
+		s.mux = http.NewServeMux()
+		s.setupRoutes()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		readyz := func() int {
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+
+			return w.Code
+		}
+
+		// Without an activity source there is nothing to wait for.
+		assert.True(t, s.Ready())
+		assert.Equal(t, 200, readyz())
+
+		src := &connectingActivitySource{
+			fakeActivitySource: fakeActivitySource{C: make(chan []*ActivityLog)},
+			connected:          make(chan struct{}),
+		}
+
+		err := s.StartActivitySource(ctx, func(ctx context.Context) (ActivitySource, error) {
+			return src, nil
+		})
+		require.NoError(t, err)
+
+		assert.False(t, s.Ready())
+		assert.Equal(t, http.StatusServiceUnavailable, readyz())
+
+		close(src.connected)
+
+		require.Eventually(t, s.Ready, time.Second, 10*time.Millisecond)
+		assert.Equal(t, 200, readyz())
+
+		// A source failing after it connected doesn't make the server
+		// unready again.
+		close(src.C)
+
+		time.Sleep(10 * time.Millisecond)
+
+		assert.True(t, s.Ready())
+	})
+
 	t.Run("prunes old logs", func(t *testing.T) {
 		db := testsql.TestPostgresDB(t, testDbName)
 		defer db.Close()
@@ -74,3 +398,40 @@ func TestActivity(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+type fakeActivitySource struct {
+	C      chan []*ActivityLog
+	closed bool
+
+	// If set, the source is an ActivityAcker and the entries acked are sent
+	// on it.
+	acks chan []*ActivityLog
+}
+
+func (f *fakeActivitySource) Entries() <-chan []*ActivityLog {
+	return f.C
+}
+
+func (f *fakeActivitySource) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeActivitySource) Ack(entries []*ActivityLog) error {
+	if f.acks != nil {
+		f.acks <- entries
+	}
+
+	return nil
+}
+
+// connectingActivitySource is a fakeActivitySource that connects once
+// connected is closed.
+type connectingActivitySource struct {
+	fakeActivitySource
+	connected chan struct{}
+}
+
+func (c *connectingActivitySource) Connected() <-chan struct{} {
+	return c.connected
+}
@@ -0,0 +1,211 @@
+package control
+
+import (
+	context "context"
+	"crypto/subtle"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/hashicorp/horizon/pkg/token"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AnyRole can be passed to authFromContext to accept a valid token of any
+// role.
+const AnyRole pb.TokenRole = -1
+
+type validTokenKey struct{}
+
+// methodRoles lists the token roles each RPC accepts, keyed by its full gRPC
+// method name. The auth interceptors reject a token of any other role before
+// the handler runs, so a handler that forgets its own check can't be reached
+// with the wrong kind of token. RPCs with no roles are authorized by the
+// register or ops secrets, or not at all, and are left to the handler.
+// Calls to RPCs that aren't listed are rejected, so each new RPC has to be
+// added here.
+var methodRoles = map[string][]pb.TokenRole{
+	"/pb.ControlServices/AddService":          {pb.HUB},
+	"/pb.ControlServices/RemoveService":       {pb.HUB},
+	"/pb.ControlServices/ListServices":        nil,
+	"/pb.ControlServices/StreamServices":      {pb.MANAGE},
+	"/pb.ControlServices/FetchConfig":         {pb.HUB},
+	"/pb.ControlServices/StreamActivity":      {pb.HUB},
+	"/pb.ControlServices/SyncHub":             {pb.HUB},
+	"/pb.ControlServices/HubDisconnect":       {pb.HUB},
+	"/pb.ControlServices/AllHubs":             {pb.HUB},
+	"/pb.ControlServices/RequestServiceToken": {pb.HUB},
+
+	"/pb.ControlManagement/Register":                   nil,
+	"/pb.ControlManagement/AddAccount":                 {pb.MANAGE},
+	"/pb.ControlManagement/CreateAccount":              {pb.MANAGE},
+	"/pb.ControlManagement/AddLabelLink":               {pb.MANAGE},
+	"/pb.ControlManagement/RemoveLabelLink":            {pb.MANAGE},
+	"/pb.ControlManagement/CreateToken":                {pb.MANAGE},
+	"/pb.ControlManagement/CreateTokens":               {pb.MANAGE},
+	"/pb.ControlManagement/IssueHubToken":              nil,
+	"/pb.ControlManagement/GetTokenPublicKey":          nil,
+	"/pb.ControlManagement/ListAccounts":               {pb.MANAGE},
+	"/pb.ControlManagement/ListManagementClients":      nil,
+	"/pb.ControlManagement/UnregisterManagementClient": nil,
+	"/pb.ControlManagement/WhoAmI":                     {AnyRole},
+	"/pb.ControlManagement/TokenStatus":                {AnyRole},
+	"/pb.ControlManagement/RenewToken":                 {AnyRole},
+	"/pb.ControlManagement/ExportAccountConfig":        {pb.MANAGE},
+	"/pb.ControlManagement/ImportAccountConfig":        {pb.MANAGE},
+	"/pb.ControlManagement/ConnectedHubs":              nil,
+	"/pb.ControlManagement/FlowCounters":               nil,
+	"/pb.ControlManagement/ResetFlowCounters":          nil,
+	"/pb.ControlManagement/SetAccountSuspended":        nil,
+	"/pb.ControlManagement/ListIssuedTokens":           {pb.MANAGE},
+	"/pb.ControlManagement/ResolveDebug":               nil,
+	"/pb.ControlManagement/SetLabelLinkSplits":         {pb.MANAGE},
+	"/pb.ControlManagement/InvalidateAccountRouting":   nil,
+
+	"/pb.FlowTopReporter/CurrentFlowTop": nil,
+}
+
+// authorizationFromContext returns the authorization the request was made
+// with, which is either a token or one of the register and ops secrets.
+func authorizationFromContext(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", ErrBadAuthentication
+	}
+
+	auth := md["authorization"]
+
+	if len(auth) < 1 || auth[0] == "" {
+		return "", ErrBadAuthentication
+	}
+
+	return auth[0], nil
+}
+
+// checkSecret verifies the request was made with secret, such as the register
+// token.
+func checkSecret(ctx context.Context, secret string) error {
+	auth, err := authorizationFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if secret == "" || subtle.ConstantTimeCompare([]byte(auth), []byte(secret)) != 1 {
+		return ErrBadAuthentication
+	}
+
+	return nil
+}
+
+// authFromContext validates the token the request was made with, requiring it
+// to have the given role unless role is AnyRole. If the token was already
+// validated by the auth interceptor, that result is used.
+func (s *Server) authFromContext(ctx context.Context, role pb.TokenRole) (*token.ValidToken, error) {
+	vt, ok := ctx.Value(validTokenKey{}).(*token.ValidToken)
+	if !ok {
+		auth, err := authorizationFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		vt, err = token.CheckTokenED25519(auth, s.pubKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if role != AnyRole && vt.Body.Role != role {
+		return nil, errors.Wrapf(ErrBadAuthentication, "role was: %s", vt.Body.Role)
+	}
+
+	return vt, nil
+}
+
+// withValidToken returns ctx with the token the request was made with, if it
+// has a valid one, stored for authFromContext. Requests without one, including
+// those authorized by the register or ops secrets, are passed on as is and
+// left for the handler to reject.
+func (s *Server) withValidToken(ctx context.Context) context.Context {
+	auth, err := authorizationFromContext(ctx)
+	if err != nil {
+		return ctx
+	}
+
+	vt, err := token.CheckTokenED25519(auth, s.pubKey)
+	if err != nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, validTokenKey{}, vt)
+}
+
+// authorizeMethod validates the token of a call to method and checks it has
+// one of the roles the method accepts, according to methodRoles.
+func (s *Server) authorizeMethod(ctx context.Context, method string) (context.Context, error) {
+	roles, ok := methodRoles[method]
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "no roles declared for %s", method)
+	}
+
+	ctx = s.withValidToken(ctx)
+
+	if len(roles) == 0 {
+		return ctx, nil
+	}
+
+	vt, ok := ctx.Value(validTokenKey{}).(*token.ValidToken)
+	if !ok {
+		return nil, ErrBadAuthentication
+	}
+
+	for _, role := range roles {
+		if role == AnyRole || vt.Body.Role == role {
+			return ctx, nil
+		}
+	}
+
+	return nil, errors.Wrapf(ErrBadAuthentication, "role %s not allowed for %s", vt.Body.Role, method)
+}
+
+// UnaryAuthInterceptor validates the token of each unary request once, before
+// the handler runs, so the handler can use authFromContext without validating
+// it again. Requests with a token of a role the method doesn't accept are
+// rejected. It also gives each request an id, see RequestIDHeader, which the
+// handler's logs include. Pass it to grpc.UnaryInterceptor.
+func (s *Server) UnaryAuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx = s.unaryRequestID(ctx, info.FullMethod)
+
+	ctx, err := s.authorizeMethod(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// StreamAuthInterceptor is the streaming version of UnaryAuthInterceptor.
+// Pass it to grpc.StreamInterceptor.
+func (s *Server) StreamAuthInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := s.streamRequestID(ss, info.FullMethod)
+
+	ctx, err := s.authorizeMethod(ctx, info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authedStream{
+		ServerStream: ss,
+		ctx:          ctx,
+	})
+}
+
+type authedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (a *authedStream) Context() context.Context {
+	return a.ctx
+}
@@ -0,0 +1,349 @@
+package control
+
+import (
+	context "context"
+	"sort"
+	"sync/atomic"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/horizon/pkg/dbx"
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// Activity for the hubs is queued on each hub's own buffered channel rather
+// than handed over by the request that generated it. That way neither a
+// request that is canceled part way through nor a hub that is slow to read
+// holds up delivery to the rest of the fleet.
+
+// How many activity messages can be waiting to be sent to a single hub.
+var HubActivityQueueSize = 100
+
+// How many activity messages a hub can miss before it's logged as a slow
+// consumer. It's logged again each time it misses that many more.
+var HubSlowConsumerDrops int64 = 10
+
+// How many activity messages in a row a hub can miss before it's considered
+// dead and removed from the connected hubs. This cleans up after a hub whose
+// stream has gone away without removing itself. If the hub is in fact still
+// connected, its stream is ended so it reconnects, and it's caught up by the
+// replay or a resync when it does. Hubs that miss activity that changes their
+// routing are handled sooner, see routingActivity.
+var HubDeadConsumerDrops int64 = 1000
+
+// broadcastActivity queues act to be sent to all connected hubs. It never
+// blocks: a hub whose queue is full misses act, which is logged and counted
+// so that a hub that can't keep up is visible.
+func (s *Server) broadcastActivity(act *pb.CentralActivity) {
+	prune := s.queueActivity(act)
+	if len(prune) > 0 {
+		s.pruneHubs(prune)
+	}
+}
+
+// routingActivity reports if act changes where hubs route requests. A hub
+// that misses it would route on a stale view of the accounts until they
+// change again. If the replay has everything since, the hub's stream is
+// ended so it reconnects and is sent it in the replay. Otherwise the stream
+// is left alone, and once the hub has caught up with what's queued for it,
+// it's sent the resolved routes of all its accounts, see resyncHub.
+func routingActivity(act *pb.CentralActivity) bool {
+	return len(act.AccountServices) > 0 ||
+		len(act.ResolvedRoutes) > 0 ||
+		act.NewLabelLinks != nil ||
+		act.RemovedLabelLinks != nil
+}
+
+// queueActivity does the work of broadcastActivity, returning the hubs to
+// prune: those that have missed too many messages in a row, or missed act
+// when it's routing activity that the replay covers.
+func (s *Server) queueActivity(act *pb.CentralActivity) map[string]*connectedHub {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var prune map[string]*connectedHub
+
+	s.L.Debug("broadcasting activity to hubs", "hubs", len(s.connectedHubs))
+
+	// Recorded while holding mu so that a hub connecting now either has act
+	// replayed to it or has it queued below, never both or neither.
+	s.recordActivity(act)
+
+	var accounts []string
+
+	if s.cfg.SelectiveBroadcast {
+		accounts = activityAccounts(act)
+	}
+
+	routing := routingActivity(act)
+
+	// act has just been recorded, so it's in the replay if replaying is
+	// enabled. Whether the replay still has it when the hub reconnects is
+	// checked again then.
+	cutOff := routing && s.replayCovers()
+
+	for key, hub := range s.connectedHubs {
+		if accounts != nil && !hub.servesAny(accounts) {
+			continue
+		}
+
+		select {
+		case hub.xmit <- act:
+			atomic.StoreInt64(&hub.missed, 0)
+			s.noteQueueDepth(key, hub, int64(len(hub.xmit)))
+		default:
+			s.noteQueueDepth(key, hub, int64(cap(hub.xmit)))
+
+			dropped := atomic.AddInt64(&hub.dropped, 1)
+
+			missed := atomic.AddInt64(&hub.missed, 1)
+
+			if missed >= HubDeadConsumerDrops || cutOff {
+				if prune == nil {
+					prune = make(map[string]*connectedHub)
+				}
+
+				prune[key] = hub
+			} else if routing {
+				atomic.StoreInt32(&hub.resync, 1)
+			}
+
+			s.L.Warn("dropping activity, hub is not keeping up", "hub", key, "routing", routing)
+
+			if s.m != nil {
+				s.m.IncrCounterWithLabels([]string{"broadcast", "dropped"}, 1, []metrics.Label{
+					{
+						Name:  "hub",
+						Value: hubOfConnKey(key),
+					},
+				})
+			}
+
+			if dropped%HubSlowConsumerDrops == 0 {
+				s.L.Error("hub is a slow consumer",
+					"hub", key,
+					"dropped", dropped,
+					"max-queue-depth", atomic.LoadInt64(&hub.maxQueueDepth),
+				)
+			}
+		}
+	}
+
+	return prune
+}
+
+// pruneHubs removes hubs from the connected hubs and ends their streams, if
+// they're still running, so hubs that are still connected reconnect and are
+// caught up when they do. Entries that have since been replaced by a new
+// connection from the same hub are left alone.
+func (s *Server) pruneHubs(hubs map[string]*connectedHub) {
+	s.mu.Lock()
+
+	for key, hub := range hubs {
+		if !s.removeHubConn(key, hub) {
+			delete(hubs, key)
+		}
+	}
+
+	s.mu.Unlock()
+
+	for key, hub := range hubs {
+		s.L.Error("removing hub that has missed activity",
+			"hub", key,
+			"missed", atomic.LoadInt64(&hub.missed),
+		)
+
+		if s.m != nil {
+			s.m.IncrCounterWithLabels([]string{"broadcast", "pruned"}, 1, []metrics.Label{
+				{
+					Name:  "hub",
+					Value: hubOfConnKey(key),
+				},
+			})
+		}
+
+		if hub.cancel != nil {
+			hub.cancel()
+		}
+	}
+}
+
+// activityAccounts returns the accounts, by StringKey, that act is only of
+// interest to, or nil if every hub should get it. Only activity made up of
+// nothing but account services and resolved routes is account specific.
+func activityAccounts(act *pb.CentralActivity) []string {
+	rest := *act
+	rest.AccountServices = nil
+	rest.ResolvedRoutes = nil
+
+	if rest.Size() != 0 {
+		return nil
+	}
+
+	var accounts []string
+
+	for _, as := range act.AccountServices {
+		accounts = append(accounts, as.Account.StringKey())
+	}
+
+	for _, as := range act.ResolvedRoutes {
+		accounts = append(accounts, as.Account.StringKey())
+	}
+
+	return accounts
+}
+
+// servesAny reports if the hub has services for, or has cached, any of
+// accounts. Hubs whose accounts haven't been loaded are assumed to.
+func (ch *connectedHub) servesAny(accounts []string) bool {
+	ch.accountsMu.RLock()
+	defer ch.accountsMu.RUnlock()
+
+	if !ch.accountsLoaded {
+		return true
+	}
+
+	for _, key := range accounts {
+		if _, ok := ch.accounts[key]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// loadHubAccounts sets the accounts of ch to those the hub with the given
+// instance id has services for.
+func (s *Server) loadHubAccounts(ch *connectedHub, hubId *pb.ULID) error {
+	var keys [][]byte
+
+	err := dbx.Check(s.db.Model(&Service{}).
+		Where("hub_id = ?", hubId.Bytes()).
+		Pluck("DISTINCT account_id", &keys))
+	if err != nil {
+		return err
+	}
+
+	accounts := make([]*pb.Account, 0, len(keys))
+
+	for _, key := range keys {
+		account, err := pb.AccountFromKey(key)
+		if err != nil {
+			return err
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	// Services the hub added and accounts it cached while these were being
+	// loaded have already been noted, so add to the accounts rather than
+	// replacing them.
+	ch.noteAccounts(accounts...)
+
+	ch.accountsMu.Lock()
+	ch.accountsLoaded = true
+	ch.accountsMu.Unlock()
+
+	return nil
+}
+
+// noteAccounts adds accounts to those ch is sent the activity of.
+func (ch *connectedHub) noteAccounts(accounts ...*pb.Account) {
+	if len(accounts) == 0 {
+		return
+	}
+
+	ch.accountsMu.Lock()
+	defer ch.accountsMu.Unlock()
+
+	if ch.accounts == nil {
+		ch.accounts = make(map[string]*pb.Account)
+	}
+
+	for _, account := range accounts {
+		if account == nil {
+			continue
+		}
+
+		ch.accounts[account.StringKey()] = account
+	}
+}
+
+// noteHubAccount records that the hub with the given instance id has services
+// for account, on each of its activity streams.
+func (s *Server) noteHubAccount(hubId *pb.ULID, account *pb.Account) {
+	if !s.cfg.SelectiveBroadcast {
+		return
+	}
+
+	for _, ch := range s.hubConns(hubId.SpecString()) {
+		ch.noteAccounts(account)
+	}
+}
+
+// hubConns returns the activity streams of the hub with the given instance id.
+func (s *Server) hubConns(hub string) []*connectedHub {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []*connectedHub
+
+	for key, ch := range s.connectedHubs {
+		if hubOfConnKey(key) == hub {
+			out = append(out, ch)
+		}
+	}
+
+	return out
+}
+
+// noteQueueDepth records depth as the hub's max queue depth if it's the
+// highest seen.
+func (s *Server) noteQueueDepth(key string, hub *connectedHub, depth int64) {
+	for {
+		cur := atomic.LoadInt64(&hub.maxQueueDepth)
+		if depth <= cur {
+			return
+		}
+
+		if atomic.CompareAndSwapInt64(&hub.maxQueueDepth, cur, depth) {
+			break
+		}
+	}
+
+	if s.m != nil {
+		s.m.SetGaugeWithLabels([]string{"broadcast", "max_queue_depth"}, float32(depth), []metrics.Label{
+			{
+				Name:  "hub",
+				Value: hubOfConnKey(key),
+			},
+		})
+	}
+}
+
+// ConnectedHubs reports how well each connected hub is keeping up with the
+// activity broadcast to it.
+func (s *Server) ConnectedHubs(ctx context.Context, _ *pb.Noop) (*pb.ConnectedHubsResponse, error) {
+	if !s.checkOpsAllowed(ctx) {
+		return nil, ErrBadAuthentication
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out pb.ConnectedHubsResponse
+
+	for key, hub := range s.connectedHubs {
+		out.Hubs = append(out.Hubs, &pb.ConnectedHub{
+			Hub:           hubOfConnKey(key),
+			Dropped:       atomic.LoadInt64(&hub.dropped),
+			QueueDepth:    int64(len(hub.xmit)),
+			MaxQueueDepth: atomic.LoadInt64(&hub.maxQueueDepth),
+		})
+	}
+
+	sort.Slice(out.Hubs, func(i, j int) bool {
+		return out.Hubs[i].Hub < out.Hubs[j].Hub
+	})
+
+	return &out, nil
+}
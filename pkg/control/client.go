@@ -7,12 +7,13 @@ import (
 	"encoding/hex"
 	io "io"
 	"io/ioutil"
-	"math/rand"
 	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/net/http2"
@@ -26,12 +27,15 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/horizon/pkg/grpc/lz4"
 	grpctoken "github.com/hashicorp/horizon/pkg/grpc/token"
+	_ "github.com/hashicorp/horizon/pkg/grpc/zstd"
 	"github.com/hashicorp/horizon/pkg/netloc"
 	"github.com/hashicorp/horizon/pkg/pb"
 	"github.com/hashicorp/horizon/pkg/periodic"
+	"github.com/hashicorp/horizon/pkg/token"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	gcreds "google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/encoding"
 	"google.golang.org/grpc/status"
 )
 
@@ -43,6 +47,7 @@ var accountIdleExpire = 2 * time.Hour
 
 type accountInfo struct {
 	Mu       sync.RWMutex
+	Account  *pb.Account
 	MapKey   string
 	LastUse  time.Time
 	S3Key    string
@@ -69,6 +74,7 @@ type Client struct {
 
 	accountServices map[string]*accountInfo
 
+	s3Mu   sync.RWMutex
 	bucket string
 	s3api  *s3.S3
 
@@ -92,9 +98,39 @@ type Client struct {
 
 	hubActivity chan *pb.HubActivity
 
+	// Accounts that have started being cached since they were last reported
+	// to central, and a signal that there are some to report.
+	cachedMu   sync.Mutex
+	newCached  []*pb.Account
+	newCachedC chan struct{}
+
 	netloc []*pb.NetworkLocation
 
+	activityCompressor string
+
+	// Held while a flow record is numbered and queued, so records are queued
+	// in the order of their sequence. The server skips any record that
+	// arrives behind a higher sequence as already counted.
+	flowMu  sync.Mutex
+	flowSeq *int64
+
+	// How many flow records TrySendFlow has dropped because the queue to
+	// central was full.
+	flowDrops *int64
+
 	clientset *client.Clientset
+
+	// The accounts, by StringKey, that are over their traffic quota, and
+	// whether this hub is over its own.
+	quotaMu      sync.RWMutex
+	overQuota    map[string]struct{}
+	hubOverQuota bool
+
+	// The hubs last listed by AllHubs and their version, so they're only
+	// sent again when they've changed.
+	hubsMu      sync.Mutex
+	hubs        []*pb.HubInfo
+	hubsVersion string
 }
 
 type ClientConfig struct {
@@ -114,6 +150,11 @@ type ClientConfig struct {
 
 	// Where hub integrates it's handler for the hzn protocol
 	NextProto map[string]func(hs *http.Server, tlsConn *tls.Conn, h http.Handler)
+
+	// The grpc compressor to use for the activity stream, such as zstd for hubs
+	// on constrained links. When empty, the compressor advertised by the server
+	// is used, falling back to the connection default.
+	ActivityCompressor string
 }
 
 func NewClient(ctx context.Context, cfg ClientConfig) (*Client, error) {
@@ -166,6 +207,11 @@ func NewClient(ctx context.Context, cfg ClientConfig) (*Client, error) {
 		bucket:          cfg.S3Bucket,
 		cancel:          cancel,
 		hubActivity:     make(chan *pb.HubActivity, 10),
+		newCachedC:      make(chan struct{}, 1),
+
+		activityCompressor: cfg.ActivityCompressor,
+		flowSeq:            new(int64),
+		flowDrops:          new(int64),
 	}
 
 	if cfg.Session != nil {
@@ -245,29 +291,27 @@ func (c *Client) BootstrapConfig(ctx context.Context) error {
 	c.tlsCert = &cert
 
 	if resp.S3AccessKey != "" {
-		L := c.L
-
-		L.Info("reconfiguring s3 access to use server provided credentials",
+		c.L.Info("reconfiguring s3 access to use server provided credentials",
 			"bucket", resp.S3Bucket,
 			"access-key", resp.S3AccessKey,
 			"token-pub", hex.EncodeToString(c.tokenPub),
 		)
 
-		var cfg aws.Config
-
-		cfg.WithCredentials(credentials.NewStaticCredentials(resp.S3AccessKey, resp.S3SecretKey, ""))
-
-		c.cfg.Session = session.New(&cfg)
-		c.s3api = s3.New(c.cfg.Session)
-
-		c.bucket = resp.S3Bucket
-		c.cfg.S3Bucket = resp.S3Bucket
+		c.setS3Credentials(resp.S3AccessKey, resp.S3SecretKey, resp.S3Bucket)
 	}
 
 	if resp.ImageTag != "" {
 		c.checkImageTag(ctx, resp.ImageTag, true)
 	}
 
+	if c.cfg.ActivityCompressor == "" && resp.ActivityCompressor != "" {
+		if encoding.GetCompressor(resp.ActivityCompressor) == nil {
+			c.L.Warn("ignoring unknown activity compressor advertised by server", "compressor", resp.ActivityCompressor)
+		} else {
+			c.activityCompressor = resp.ActivityCompressor
+		}
+	}
+
 	return nil
 }
 
@@ -391,68 +435,99 @@ func (c *RouteCalculation) Empty() bool {
 	return len(c.Best) == 0 && len(c.All) == 0
 }
 
-func (c *RouteCalculation) shuffle(in []*pb.ServiceRoute) []*pb.ServiceRoute {
-	if len(in) < 2 {
-		return in
+// Services returns the routes to try, in the order to try them: the best
+// ones if there are any, otherwise all of them, shuffled according to their
+// weights.
+func (c *RouteCalculation) Services() []*pb.ServiceRoute {
+	if len(c.Best) > 0 {
+		return weightedShuffle(c.Best)
 	}
 
-	rand.Shuffle(len(in), func(i, j int) {
-		in[i], in[j] = in[j], in[i]
-	})
+	return weightedShuffle(c.All)
+}
 
-	return in
+// appendNewRoutes adds the routes in add to routes, replacing those for
+// services already in it rather than adding them again. Control can send the
+// same activity more than once.
+func appendNewRoutes(routes, add []*pb.ServiceRoute) []*pb.ServiceRoute {
+outer:
+	for _, route := range add {
+		for i, existing := range routes {
+			if existing.Id.Equal(route.Id) {
+				routes[i] = route
+				continue outer
+			}
+		}
+
+		routes = append(routes, route)
+	}
+
+	return routes
 }
 
-func (c *RouteCalculation) Services() []*pb.ServiceRoute {
-	if len(c.Best) > 0 {
-		return c.shuffle(c.Best)
+// appendNewLabelLinks adds the label links in add to links, replacing those
+// already in it for the same account and labels, such as when a label link's
+// splits change, so the newest version is the one found.
+func appendNewLabelLinks(links, add []*pb.LabelLink) []*pb.LabelLink {
+outer:
+	for _, ll := range add {
+		for i, existing := range links {
+			if existing.Account.Equal(ll.Account) && existing.Labels.Equal(ll.Labels) {
+				links[i] = ll
+				continue outer
+			}
+		}
+
+		links = append(links, ll)
+	}
+
+	return links
+}
+
+// The label on the target of a label link that further limits the services it
+// routes to those whose labels satisfy the label's value, a pb.LabelExpr, such
+// as :match=env=prod and (region=us or region=eu).
+const MatchLabel = ":match"
+
+// targetMatcher returns a func that reports if a service's labels match
+// target. Those are all of target's labels, and if target has a MatchLabel,
+// its expression. A target with an expression that doesn't parse matches
+// nothing.
+func targetMatcher(L hclog.Logger, target *pb.LabelSet) func(*pb.LabelSet) bool {
+	src, ok := target.GetLabel(MatchLabel)
+	if !ok {
+		return target.Matches
+	}
+
+	expr, err := pb.ParseLabelExpr(src)
+	if err != nil {
+		L.Error("ignoring services for target with bad match expression", "target", target.SpecString(), "error", err)
+		return func(*pb.LabelSet) bool { return false }
 	}
 
-	return c.shuffle(c.All)
+	rest := withoutLabel(target, MatchLabel)
+
+	return func(labels *pb.LabelSet) bool {
+		return rest.Matches(labels) && expr.Matches(labels)
+	}
 }
 
 func (c *Client) LookupService(ctx context.Context, account *pb.Account, labels *pb.LabelSet) (*RouteCalculation, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	var (
-		out       []*pb.ServiceRoute
-		best      []*pb.ServiceRoute
-		bestOrder string
-		rest      []*pb.ServiceRoute
-	)
+	matches := targetMatcher(c.L, labels)
 
-	maintainBest := func(route *pb.ServiceRoute) {
-		order, ok := route.Labels.GetLabel(deploymentOrder)
-		if ok {
-			if best != nil {
-				if order > bestOrder {
-					best = []*pb.ServiceRoute{route}
-					bestOrder = order
-				} else if order == bestOrder {
-					best = append(best, route)
-				}
-			} else {
-				best = []*pb.ServiceRoute{route}
-				bestOrder = order
-			}
-		} else if best != nil {
-			rest = append(rest, route)
-		}
-	}
+	var rc routeCalculator
 
 	for _, reg := range c.localServices {
-		if reg.Account.Equal(account) && labels.Matches(reg.Labels) {
-			route := &pb.ServiceRoute{
+		if reg.Account.Equal(account) && matches(reg.Labels) {
+			rc.add(&pb.ServiceRoute{
 				Id:     reg.Id,
 				Hub:    reg.Hub,
 				Type:   reg.Type,
 				Labels: reg.Labels,
-			}
-
-			out = append(out, route)
-
-			maintainBest(route)
+			})
 		}
 	}
 
@@ -461,6 +536,7 @@ func (c *Client) LookupService(ctx context.Context, account *pb.Account, labels
 	info, ok := c.accountServices[accStr]
 	if !ok {
 		info = &accountInfo{
+			Account:  account,
 			MapKey:   accStr,
 			S3Key:    "account_services/" + account.HashKey(),
 			LastUse:  time.Now(),
@@ -470,18 +546,31 @@ func (c *Client) LookupService(ctx context.Context, account *pb.Account, labels
 
 		c.accountServices[accStr] = info
 
+		// Reported before it's fetched, so central starts sending its
+		// routing activity as soon as it can.
+		c.noteCachedAccount(account)
+
 		c.refreshAcconut(c.L, info)
 	}
 
+	// Activity from central replaces these as it arrives.
+	info.Mu.RLock()
+	defer info.Mu.RUnlock()
+
+	// A suspended account has nothing to route to, not even the services
+	// connected to this hub.
+	if info.Services.GetSuspended() {
+		return &RouteCalculation{}, nil
+	}
+
 	for _, service := range info.Recent {
 		// Skip yourself, you already got those.
 		if service.Hub.Equal(c.instanceId) {
 			continue
 		}
 
-		if labels.Matches(service.Labels) {
-			out = append(out, service)
-			maintainBest(service)
+		if matches(service.Labels) {
+			rc.add(service)
 		}
 	}
 
@@ -492,22 +581,102 @@ func (c *Client) LookupService(ctx context.Context, account *pb.Account, labels
 				continue
 			}
 
-			if labels.Matches(service.Labels) {
-				out = append(out, service)
-				maintainBest(service)
+			if matches(service.Labels) {
+				rc.add(service)
+			}
+		}
+	}
+
+	return rc.result(), nil
+}
+
+// routeCalculator builds a RouteCalculation from the routes matching a
+// target. The best routes are those of the newest deployment, by their
+// deploymentOrder label, along with any routes without one seen after it.
+type routeCalculator struct {
+	all       []*pb.ServiceRoute
+	best      []*pb.ServiceRoute
+	bestOrder string
+	rest      []*pb.ServiceRoute
+}
+
+func (r *routeCalculator) add(route *pb.ServiceRoute) {
+	r.all = append(r.all, route)
+
+	order, ok := route.Labels.GetLabel(deploymentOrder)
+	if ok {
+		if r.best != nil {
+			if order > r.bestOrder {
+				r.best = []*pb.ServiceRoute{route}
+				r.bestOrder = order
+			} else if order == r.bestOrder {
+				r.best = append(r.best, route)
 			}
+		} else {
+			r.best = []*pb.ServiceRoute{route}
+			r.bestOrder = order
 		}
+	} else if r.best != nil {
+		r.rest = append(r.rest, route)
 	}
+}
 
+func (r *routeCalculator) result() *RouteCalculation {
 	ret := &RouteCalculation{
-		All: out,
+		All: preferHealthy(r.all),
 	}
 
-	if len(best) > 0 {
-		ret.Best = append(best, rest...)
+	if len(r.best) > 0 {
+		ret.Best = preferHealthy(append(r.best, r.rest...))
 	}
 
-	return ret, nil
+	return ret
+}
+
+// preferHealthy drops any routes that control has flagged as being on an
+// unhealthy hub, unless that's all of them, in which case they're the best
+// we've got.
+func preferHealthy(routes []*pb.ServiceRoute) []*pb.ServiceRoute {
+	var healthy []*pb.ServiceRoute
+
+	for _, route := range routes {
+		if !route.Unhealthy {
+			healthy = append(healthy, route)
+		}
+	}
+
+	if len(healthy) == 0 {
+		return routes
+	}
+
+	return healthy
+}
+
+// setS3Credentials switches the client to fetching account data and label
+// links from bucket using the given credentials.
+func (c *Client) setS3Credentials(access, secret, bucket string) {
+	var cfg aws.Config
+
+	cfg.WithCredentials(credentials.NewStaticCredentials(access, secret, ""))
+
+	sess := session.New(&cfg)
+
+	c.s3Mu.Lock()
+	defer c.s3Mu.Unlock()
+
+	c.cfg.Session = sess
+	c.s3api = s3.New(sess)
+
+	c.bucket = bucket
+	c.cfg.S3Bucket = bucket
+}
+
+// s3Access returns the bucket and client to use for S3 requests.
+func (c *Client) s3Access() (string, *s3.S3) {
+	c.s3Mu.RLock()
+	defer c.s3Mu.RUnlock()
+
+	return c.bucket, c.s3api
 }
 
 func (c *Client) refreshAcconut(L hclog.Logger, info *accountInfo) {
@@ -519,8 +688,10 @@ func (c *Client) refreshAcconut(L hclog.Logger, info *accountInfo) {
 
 	defer os.Remove(tmp.Name())
 
+	bucket, s3api := c.s3Access()
+
 	obj := &s3.GetObjectInput{
-		Bucket: aws.String(c.bucket),
+		Bucket: aws.String(bucket),
 		Key:    &info.S3Key,
 	}
 
@@ -528,7 +699,7 @@ func (c *Client) refreshAcconut(L hclog.Logger, info *accountInfo) {
 		obj.IfNoneMatch = aws.String(info.LastMD5)
 	}
 
-	resp, err := c.s3api.GetObject(obj)
+	resp, err := s3api.GetObject(obj)
 	if err != nil {
 		if rf, ok := err.(awserr.RequestFailure); ok {
 			if rf.StatusCode() == 304 {
@@ -541,7 +712,7 @@ func (c *Client) refreshAcconut(L hclog.Logger, info *accountInfo) {
 				return
 			}
 		}
-		L.Error("error fetching account data", "error", err, "key", info.S3Key, "bucket", c.bucket)
+		L.Error("error fetching account data", "error", err, "key", info.S3Key, "bucket", bucket)
 		return
 	}
 
@@ -594,6 +765,48 @@ func (c *Client) refreshAcconut(L hclog.Logger, info *accountInfo) {
 	info.Services = &ac
 }
 
+// noteCachedAccount queues account to be reported to central as cached, so
+// central sends this hub the account's routing activity.
+func (c *Client) noteCachedAccount(account *pb.Account) {
+	c.cachedMu.Lock()
+	c.newCached = append(c.newCached, account)
+	c.cachedMu.Unlock()
+
+	select {
+	case c.newCachedC <- struct{}{}:
+	default:
+	}
+}
+
+// takeNewCached returns the accounts queued by noteCachedAccount, and clears
+// them.
+func (c *Client) takeNewCached() []*pb.Account {
+	c.cachedMu.Lock()
+	defer c.cachedMu.Unlock()
+
+	accounts := c.newCached
+	c.newCached = nil
+
+	return accounts
+}
+
+// cachedAccounts returns all the accounts cached, to report to central when
+// the activity stream connects.
+func (c *Client) cachedAccounts() []*pb.Account {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var out []*pb.Account
+
+	for _, info := range c.accountServices {
+		if info.Account != nil {
+			out = append(out, info.Account)
+		}
+	}
+
+	return out
+}
+
 func (c *Client) checkAccounts(L hclog.Logger) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -612,18 +825,31 @@ func (c *Client) streamActivity(
 ) (
 	pb.ControlServices_StreamActivityClient, error,
 ) {
-	activity, err := c.client.StreamActivity(ctx)
+	var opts []grpc.CallOption
+
+	if c.activityCompressor != "" {
+		L.Debug("using compressor for activity stream", "compressor", c.activityCompressor)
+		opts = append(opts, grpc.UseCompressor(c.activityCompressor))
+	}
+
+	activity, err := c.client.StreamActivity(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
 
+	// Every account cached is reported again, since this may be a different
+	// server, or the same one after it's forgotten them. Any still queued to
+	// be reported are included, so they can be dropped.
+	c.takeNewCached()
+
 	err = activity.Send(&pb.HubActivity{
 		HubReg: &pb.HubActivity_HubRegistration{
 			Hub:       c.instanceId,
 			StableHub: c.cfg.Id,
 			Locations: c.netloc,
 		},
-		SentAt: pb.NewTimestamp(time.Now()),
+		SentAt:         pb.NewTimestamp(time.Now()),
+		CachedAccounts: c.cachedAccounts(),
 	})
 
 	if err != nil {
@@ -725,6 +951,14 @@ func (c *Client) Run(ctx context.Context) error {
 			if activity != nil {
 				activity.Send(act)
 			}
+		case <-c.newCachedC:
+			accounts := c.takeNewCached()
+
+			if activity != nil && len(accounts) > 0 {
+				activity.Send(&pb.HubActivity{
+					CachedAccounts: accounts,
+				})
+			}
 		}
 	}
 }
@@ -747,27 +981,219 @@ func (c *Client) processCentralActivity(ctx context.Context, L hclog.Logger, ev
 			continue
 		}
 
-		info.Recent = append(info.Recent, acc.Services...)
+		info.Mu.Lock()
+		info.Recent = appendNewRoutes(info.Recent, acc.Services)
+		info.Mu.Unlock()
 	}
 
 	if ev.NewLabelLinks != nil {
 		L.Debug("updating recent label links")
-		c.recentLabelLinks = append(c.recentLabelLinks, ev.NewLabelLinks.LabelLinks...)
+		c.labelMu.Lock()
+		c.recentLabelLinks = appendNewLabelLinks(c.recentLabelLinks, ev.NewLabelLinks.LabelLinks)
+		c.labelMu.Unlock()
+	}
+
+	// Resolved routes are the full set of services for an account that just
+	// got a new label link. They replace whatever view we have of the account,
+	// so we're ready for traffic before the first request looks it up.
+	for _, acc := range ev.ResolvedRoutes {
+		u := acc.Account.StringKey()
+
+		L.Debug("warming account services from resolved routes", "account", u, "services", len(acc.Services))
+
+		c.mu.Lock()
+		info, ok := c.accountServices[u]
+		if !ok {
+			info = &accountInfo{
+				Account:  acc.Account,
+				MapKey:   u,
+				S3Key:    "account_services/" + acc.Account.HashKey(),
+				FileName: acc.Account.HashKey(),
+				Process:  make(chan struct{}),
+			}
+
+			c.accountServices[u] = info
+
+			c.noteCachedAccount(acc.Account)
+		}
+		info.LastUse = time.Now()
+		c.mu.Unlock()
+
+		info.Mu.Lock()
+		info.Services = acc
+		info.Recent = nil
+		info.Mu.Unlock()
+	}
+
+	if ev.RemovedLabelLinks != nil {
+		L.Debug("removing withdrawn label links")
+		c.removeLabelLinks(ev.RemovedLabelLinks.LabelLinks)
+	}
+
+	if len(ev.Quotas) > 0 {
+		c.updateQuotas(L, ev.Quotas)
+	}
+
+	// Sent by older servers, which broadcast the credentials themselves.
+	if creds := ev.S3Credentials; creds != nil && creds.AccessKey != "" {
+		L.Info("rotating s3 credentials", "bucket", creds.Bucket, "access-key", creds.AccessKey)
+		c.setS3Credentials(creds.AccessKey, creds.SecretKey, creds.Bucket)
+	}
+
+	// Fetched in the background so activity keeps being processed meanwhile.
+	if ev.S3CredentialsRotated {
+		go c.refreshS3Credentials(ctx, L)
+	}
+}
+
+// refreshS3Credentials fetches the config again to pick up rotated S3
+// credentials, which are only handed out in FetchConfig's response.
+func (c *Client) refreshS3Credentials(ctx context.Context, L hclog.Logger) {
+	resp, err := c.client.FetchConfig(ctx, &pb.ConfigRequest{
+		StableId:   c.StableId(),
+		InstanceId: c.instanceId,
+		Locations:  c.netloc,
+	})
+	if err != nil {
+		L.Error("error fetching rotated s3 credentials", "error", err)
+		return
+	}
+
+	if resp.S3AccessKey == "" {
+		return
+	}
+
+	L.Info("rotating s3 credentials", "bucket", resp.S3Bucket, "access-key", resp.S3AccessKey)
+	c.setS3Credentials(resp.S3AccessKey, resp.S3SecretKey, resp.S3Bucket)
+}
+
+func (c *Client) updateQuotas(L hclog.Logger, quotas []*pb.QuotaStatus) {
+	c.quotaMu.Lock()
+	defer c.quotaMu.Unlock()
+
+	for _, qs := range quotas {
+		switch {
+		case qs.Account != nil:
+			L.Info("account quota status changed", "account", qs.Account, "exceeded", qs.Exceeded)
+
+			if c.overQuota == nil {
+				c.overQuota = make(map[string]struct{})
+			}
+
+			if qs.Exceeded {
+				c.overQuota[qs.Account.StringKey()] = struct{}{}
+			} else {
+				delete(c.overQuota, qs.Account.StringKey())
+			}
+		case qs.Hub != nil && qs.Hub.Equal(c.instanceId):
+			L.Info("hub quota status changed", "exceeded", qs.Exceeded)
+			c.hubOverQuota = qs.Exceeded
+		}
+	}
+}
+
+// AccountOverQuota returns true if account has gone over its traffic quota,
+// in which case new streams for it should be turned away.
+func (c *Client) AccountOverQuota(account *pb.Account) bool {
+	c.quotaMu.RLock()
+	defer c.quotaMu.RUnlock()
+
+	_, ok := c.overQuota[account.StringKey()]
+	return ok
+}
+
+// OverQuota returns true if this hub has gone over its traffic quota, in
+// which case new streams should be turned away.
+func (c *Client) OverQuota() bool {
+	c.quotaMu.RLock()
+	defer c.quotaMu.RUnlock()
+
+	return c.hubOverQuota
+}
+
+// removeLabelLinks drops the given links from all the generations of label
+// links we know about. A link is matched on its account and labels.
+func (c *Client) removeLabelLinks(removed []*pb.LabelLink) {
+	for _, ll := range removed {
+		ll.Labels.Finalize()
+	}
+
+	keep := func(links []*pb.LabelLink) []*pb.LabelLink {
+		var out []*pb.LabelLink
+
+	outer:
+		for _, ll := range links {
+			for _, rm := range removed {
+				if ll.Account.Equal(rm.Account) && ll.Labels.Equal(rm.Labels) {
+					continue outer
+				}
+			}
+
+			out = append(out, ll)
+		}
+
+		return out
+	}
+
+	c.labelMu.Lock()
+	defer c.labelMu.Unlock()
+
+	c.recentLabelLinks = keep(c.recentLabelLinks)
+	c.lessRecentLabelLinks = keep(c.lessRecentLabelLinks)
+
+	if c.labelLinks != nil {
+		c.labelLinks = &pb.LabelLinks{
+			LabelLinks: keep(c.labelLinks.LabelLinks),
+		}
 	}
 }
 
 func (c *Client) SendFlow(rec *pb.FlowRecord) {
+	// The sequence lets the server skip records it has already counted if
+	// they're sent again.
+	c.flowMu.Lock()
+	defer c.flowMu.Unlock()
+
+	rec.Sequence = atomic.AddInt64(c.flowSeq, 1)
+
 	c.hubActivity <- &pb.HubActivity{
 		Flow: []*pb.FlowRecord{rec},
 	}
 }
 
+// TrySendFlow queues rec to be sent to central like SendFlow, but never
+// blocks. If the queue is full, rec is dropped and counted, see FlowsDropped,
+// and false is returned. It's for callers on the request path, which would
+// rather lose a record than hold up the request.
+func (c *Client) TrySendFlow(rec *pb.FlowRecord) bool {
+	c.flowMu.Lock()
+	defer c.flowMu.Unlock()
+
+	rec.Sequence = atomic.AddInt64(c.flowSeq, 1)
+
+	select {
+	case c.hubActivity <- &pb.HubActivity{Flow: []*pb.FlowRecord{rec}}:
+		return true
+	default:
+		drops := atomic.AddInt64(c.flowDrops, 1)
+		c.L.Warn("dropping flow record, queue to central is full", "dropped", drops)
+		return false
+	}
+}
+
+// FlowsDropped returns how many flow records TrySendFlow has dropped.
+func (c *Client) FlowsDropped() int64 {
+	return atomic.LoadInt64(c.flowDrops)
+}
+
 func (c *Client) ForceLabelLinkUpdate(ctx context.Context, L hclog.Logger) error {
 	return c.updateLabelLinks(ctx, L)
 }
 
 func (c *Client) updateLabelLinks(ctx context.Context, L hclog.Logger) error {
-	if c.bucket == "" {
+	bucket, s3api := c.s3Access()
+
+	if bucket == "" {
 		L.Debug("no bucket configured, not updating label links")
 		return nil
 	}
@@ -787,7 +1213,7 @@ func (c *Client) updateLabelLinks(ctx context.Context, L hclog.Logger) error {
 	defer os.Remove(tmp.Name())
 
 	obj := &s3.GetObjectInput{
-		Bucket: aws.String(c.bucket),
+		Bucket: aws.String(bucket),
 		Key:    aws.String("label_links"),
 	}
 
@@ -795,7 +1221,7 @@ func (c *Client) updateLabelLinks(ctx context.Context, L hclog.Logger) error {
 		obj.IfNoneMatch = aws.String(c.lastLabelMD5)
 	}
 
-	resp, err := c.s3api.GetObjectWithContext(ctx, obj)
+	resp, err := s3api.GetObjectWithContext(ctx, obj)
 	if err != nil {
 		if rf, ok := err.(awserr.RequestFailure); ok {
 			if rf.StatusCode() == 304 {
@@ -869,64 +1295,207 @@ func (c *Client) ResolveLabelLink(label *pb.LabelSet) (*pb.Account, *pb.LabelSet
 	c.labelMu.RLock()
 	defer c.labelMu.RUnlock()
 
+	ll := c.labelLink(label)
+	if ll == nil {
+		return nil, nil, nil, nil
+	}
+
+	return ll.Account, ll.Target, ll.Limits, nil
+}
+
+// The label on a label link that limits it to requests whose path falls under
+// the label's value, such as :path-prefix=/api.
+const PathPrefixLabel = ":path-prefix"
+
+// ResolvePathLabelLink resolves the label link for a request to path. Label
+// links made up of label plus a path prefix that path falls under are
+// considered first, with the longest prefix winning. If there are none, the
+// label link for label alone is used, the same as ResolveLabelLink. Label links
+// are resolved from memory, so ctx is only checked before starting.
+func (c *Client) ResolvePathLabelLink(ctx context.Context, label *pb.LabelSet, path string) (*pb.Account, *pb.LabelSet, *pb.Account_Limits, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	c.labelMu.RLock()
+	defer c.labelMu.RUnlock()
+
+	ll := c.pathLabelLink(label, path)
+	if ll == nil {
+		return nil, nil, nil, nil
+	}
+
+	return ll.Account, ll.Target, ll.Limits, nil
+}
+
+// LabelLinkSplits returns the weighted splits of the label link that
+// ResolvePathLabelLink resolves label and path to, if it has any.
+func (c *Client) LabelLinkSplits(label *pb.LabelSet, path string) []*pb.TargetSplit {
+	c.labelMu.RLock()
+	defer c.labelMu.RUnlock()
+
+	ll := c.pathLabelLink(label, path)
+	if ll == nil {
+		return nil
+	}
+
+	return ll.Splits
+}
+
+// LabelLinkPathPrefix returns the path prefix of the label link that
+// ResolvePathLabelLink resolves label and path to, or "" if it isn't limited to
+// one or there isn't one.
+func (c *Client) LabelLinkPathPrefix(label *pb.LabelSet, path string) string {
+	c.labelMu.RLock()
+	defer c.labelMu.RUnlock()
+
+	ll := c.pathLabelLink(label, path)
+	if ll == nil {
+		return ""
+	}
+
+	prefix, _ := ll.Labels.GetLabel(PathPrefixLabel)
+
+	return prefix
+}
+
+// pathLabelLink finds the label link for ResolvePathLabelLink. Must be called
+// with labelMu held.
+func (c *Client) pathLabelLink(label *pb.LabelSet, path string) *pb.LabelLink {
 	label.Finalize()
 
-	mature := 0
+	var mature []*pb.LabelLink
 
 	if c.labelLinks != nil {
-		mature = len(c.labelLinks.LabelLinks)
+		mature = c.labelLinks.LabelLinks
 	}
 
-	c.L.Debug("label-links to consider",
-		"recent", len(c.recentLabelLinks),
-		"less-recent", len(c.lessRecentLabelLinks),
-		"mature", mature,
+	best := findPathLabelLink(label, path, c.recentLabelLinks, c.lessRecentLabelLinks, mature)
+	if best != nil {
+		return best
+	}
+
+	return c.labelLink(label)
+}
+
+// findPathLabelLink returns the label link in sets made up of label plus the
+// longest path prefix that path falls under, or nil if there isn't one.
+// Among those with the same prefix, the one in the earliest set wins.
+func findPathLabelLink(label *pb.LabelSet, path string, sets ...[]*pb.LabelLink) *pb.LabelLink {
+	var (
+		best    *pb.LabelLink
+		bestLen int
 	)
 
-	for _, ll := range c.recentLabelLinks {
-		if ll.Labels.Equal(label) {
-			return ll.Account, ll.Target, ll.Limits, nil
+	for _, links := range sets {
+		for _, ll := range links {
+			prefix, ok := ll.Labels.GetLabel(PathPrefixLabel)
+			if !ok || len(prefix) <= bestLen || !pathHasPrefix(path, prefix) {
+				continue
+			}
+
+			if withoutLabel(ll.Labels, PathPrefixLabel).Equal(label) {
+				best = ll
+				bestLen = len(prefix)
+			}
 		}
 	}
 
-	// We move the recent to lessRecent when we update all the label links.
-	// This 2 layer technique means we have no gaps where we might miss an
-	// immediate update.
-	for _, ll := range c.lessRecentLabelLinks {
-		if ll.Labels.Equal(label) {
-			return ll.Account, ll.Target, ll.Limits, nil
+	return best
+}
+
+// findLabelLink returns the first label link in sets whose labels are label,
+// or nil if there isn't one.
+func findLabelLink(label *pb.LabelSet, sets ...[]*pb.LabelLink) *pb.LabelLink {
+	for _, links := range sets {
+		for _, ll := range links {
+			if ll.Labels.Equal(label) {
+				return ll
+			}
 		}
 	}
 
-	if c.labelLinks == nil {
-		return nil, nil, nil, nil
+	return nil
+}
+
+// pathHasPrefix reports if path is prefix or falls under it. Prefixes only
+// match whole path segments, so /api matches /api/users but not /apis. Label
+// values are lowercased, so the comparison ignores case.
+func pathHasPrefix(path, prefix string) bool {
+	if len(path) < len(prefix) || !strings.EqualFold(path[:len(prefix)], prefix) {
+		return false
 	}
 
-	for _, ll := range c.labelLinks.LabelLinks {
-		if ll.Labels.Equal(label) {
-			return ll.Account, ll.Target, ll.Limits, nil
+	if len(path) == len(prefix) || strings.HasSuffix(prefix, "/") {
+		return true
+	}
+
+	return path[len(prefix)] == '/'
+}
+
+func withoutLabel(ls *pb.LabelSet, name string) *pb.LabelSet {
+	out := &pb.LabelSet{}
+
+	for _, lbl := range ls.Labels {
+		if lbl.Name != name {
+			out.Labels = append(out.Labels, lbl)
 		}
 	}
 
-	return nil, nil, nil, nil
+	return out
 }
 
+// labelLink finds the label link whose labels are label. Must be called with
+// labelMu held.
+func (c *Client) labelLink(label *pb.LabelSet) *pb.LabelLink {
+	label.Finalize()
+
+	var mature []*pb.LabelLink
+
+	if c.labelLinks != nil {
+		mature = c.labelLinks.LabelLinks
+	}
+
+	c.L.Debug("label-links to consider",
+		"recent", len(c.recentLabelLinks),
+		"less-recent", len(c.lessRecentLabelLinks),
+		"mature", len(mature),
+	)
+
+	// We move the recent to lessRecent when we update all the label links.
+	// This 2 layer technique means we have no gaps where we might miss an
+	// immediate update.
+	return findLabelLink(label, c.recentLabelLinks, c.lessRecentLabelLinks, mature)
+}
+
+// AllHubs returns every hub. The previous list is reused when the server
+// reports that it hasn't changed.
 func (c *Client) AllHubs(ctx context.Context) ([]*pb.HubInfo, error) {
-	list, err := c.client.AllHubs(ctx, &pb.Noop{})
+	c.hubsMu.Lock()
+	defer c.hubsMu.Unlock()
+
+	list, err := c.client.AllHubs(ctx, &pb.AllHubsRequest{Version: c.hubsVersion})
 	if err != nil {
 		return nil, err
 	}
 
+	if list.NotModified {
+		return c.hubs, nil
+	}
+
+	c.hubs = list.Hubs
+	c.hubsVersion = list.Version
+
 	return list.Hubs, nil
 }
 
 func (c *Client) GetHubAddresses(ctx context.Context, id *pb.ULID) ([]*pb.NetworkLocation, error) {
-	list, err := c.client.AllHubs(ctx, &pb.Noop{})
+	hubs, err := c.AllHubs(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, hub := range list.Hubs {
+	for _, hub := range hubs {
 		if hub.Id.Equal(id) {
 			return hub.Locations, nil
 		}
@@ -935,6 +1504,42 @@ func (c *Client) GetHubAddresses(ctx context.Context, id *pb.ULID) ([]*pb.Networ
 	return nil, nil
 }
 
+// RoutesInScope returns the routes that are to services on hubs with a
+// location matching scope, for connections made with a token limited to those
+// hubs. A nil scope returns routes unchanged.
+func (c *Client) RoutesInScope(ctx context.Context, routes []*pb.ServiceRoute, scope *pb.LabelSet) ([]*pb.ServiceRoute, error) {
+	if scope == nil {
+		return routes, nil
+	}
+
+	hubs, err := c.AllHubs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return routesInScope(routes, hubs, scope), nil
+}
+
+func routesInScope(routes []*pb.ServiceRoute, hubs []*pb.HubInfo, scope *pb.LabelSet) []*pb.ServiceRoute {
+	inScope := make(map[string]bool)
+
+	for _, hub := range hubs {
+		if token.HubInScope(scope, hub.Locations) {
+			inScope[hub.Id.SpecString()] = true
+		}
+	}
+
+	var out []*pb.ServiceRoute
+
+	for _, route := range routes {
+		if inScope[route.Hub.SpecString()] {
+			out = append(out, route)
+		}
+	}
+
+	return out
+}
+
 func (c *Client) RequestServiceToken(ctx context.Context, namespace string) (string, error) {
 	resp, err := c.client.RequestServiceToken(ctx, &pb.ServiceTokenRequest{
 		Namespace: namespace,
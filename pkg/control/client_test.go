@@ -17,6 +17,8 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -36,9 +38,24 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+// configClient answers FetchConfig with resp, closing fetched once it has.
+type configClient struct {
+	pb.ControlServicesClient
+
+	resp    *pb.ConfigResponse
+	fetched chan struct{}
+}
+
+func (c *configClient) FetchConfig(ctx context.Context, req *pb.ConfigRequest, opts ...grpc.CallOption) (*pb.ConfigResponse, error) {
+	close(c.fetched)
+	return c.resp, nil
+}
+
 func TestClient(t *testing.T) {
 	vc := testutils.SetupVault()
 	sess := testutils.AWSSession(t)
@@ -712,6 +729,227 @@ func TestClient(t *testing.T) {
 		assert.Equal(t, target, labelTarget)
 	})
 
+	t.Run("warms accounts from resolved routes and drops removed label links", func(t *testing.T) {
+		L := hclog.L()
+
+		client := &Client{
+			L:               L,
+			instanceId:      pb.NewULID(),
+			accountServices: make(map[string]*accountInfo),
+		}
+
+		account := &pb.Account{
+			AccountId: pb.NewULID(),
+			Namespace: "/",
+		}
+
+		label := pb.ParseLabelSet(":hostname=foo.com")
+		target := pb.ParseLabelSet("service=www,env=prod")
+
+		routes := &pb.AccountServices{
+			Account: account,
+			Services: []*pb.ServiceRoute{
+				{
+					Hub:    pb.NewULID(),
+					Id:     pb.NewULID(),
+					Type:   "http",
+					Labels: target,
+				},
+			},
+		}
+
+		act := &pb.CentralActivity{
+			NewLabelLinks: &pb.LabelLinks{
+				LabelLinks: []*pb.LabelLink{{
+					Account: account,
+					Labels:  label,
+					Target:  target,
+				}},
+			},
+			ResolvedRoutes: []*pb.AccountServices{routes},
+		}
+
+		ctx := context.Background()
+
+		// Delivering the same routes twice must not duplicate them.
+		client.processCentralActivity(ctx, L, act)
+		client.processCentralActivity(ctx, L, act)
+
+		calc, err := client.LookupService(ctx, account, target)
+		require.NoError(t, err)
+
+		services := calc.Services()
+		require.Equal(t, 1, len(services))
+
+		assert.Equal(t, routes.Services[0].Id, services[0].Id)
+
+		// The account is reported to central once, so it keeps getting the
+		// account's routing activity.
+		assert.Equal(t, []*pb.Account{account}, client.takeNewCached())
+		assert.Equal(t, []*pb.Account{account}, client.cachedAccounts())
+
+		labelAccount, _, _, err := client.ResolveLabelLink(label)
+		require.NoError(t, err)
+
+		assert.Equal(t, account, labelAccount)
+
+		client.processCentralActivity(ctx, L, &pb.CentralActivity{
+			RemovedLabelLinks: &pb.LabelLinks{
+				LabelLinks: []*pb.LabelLink{{
+					Account: account,
+					Labels:  label,
+				}},
+			},
+		})
+
+		labelAccount, _, _, err = client.ResolveLabelLink(label)
+		require.NoError(t, err)
+
+		assert.Nil(t, labelAccount)
+	})
+
+	t.Run("resolves label links by the longest path prefix", func(t *testing.T) {
+		L := hclog.L()
+
+		client := &Client{
+			L: L,
+		}
+
+		account := &pb.Account{
+			AccountId: pb.NewULID(),
+			Namespace: "/",
+		}
+
+		host := pb.ParseLabelSet(":hostname=foo.com")
+
+		link := func(labels, target string) *pb.LabelLink {
+			return &pb.LabelLink{
+				Account: account,
+				Labels:  pb.ParseLabelSet(labels),
+				Target:  pb.ParseLabelSet(target),
+			}
+		}
+
+		client.processCentralActivity(context.Background(), L, &pb.CentralActivity{
+			NewLabelLinks: &pb.LabelLinks{
+				LabelLinks: []*pb.LabelLink{
+					link(":hostname=foo.com", "service=www"),
+					link(":hostname=foo.com,:path-prefix=/api", "service=api"),
+					link(":hostname=foo.com,:path-prefix=/api/v2", "service=api-v2"),
+					link(":hostname=foo.com,:path-prefix=/admin/", "service=admin"),
+					link(":hostname=bar.com,:path-prefix=/api/v2/users", "service=other"),
+				},
+			},
+		})
+
+		for path, target := range map[string]string{
+			"/api":             "service=api",
+			"/api/users":       "service=api",
+			"/api/v2":          "service=api-v2",
+			"/api/v2/users":    "service=api-v2",
+			"/api/v3":          "service=api",
+			"/apis":            "service=www",
+			"/admin/dashboard": "service=admin",
+			"/admin":           "service=www",
+			"/":                "service=www",
+		} {
+			_, labelTarget, _, err := client.ResolvePathLabelLink(context.Background(), host, path)
+			require.NoError(t, err)
+
+			assert.Equal(t, pb.ParseLabelSet(target), labelTarget, "path: %s", path)
+		}
+
+		// Without a host level link, only paths under a prefix resolve.
+		other := pb.ParseLabelSet(":hostname=bar.com")
+
+		_, labelTarget, _, err := client.ResolvePathLabelLink(context.Background(), other, "/api/v2/users/1")
+		require.NoError(t, err)
+
+		assert.Equal(t, pb.ParseLabelSet("service=other"), labelTarget)
+
+		labelAccount, labelTarget, _, err := client.ResolvePathLabelLink(context.Background(), other, "/api/v2")
+		require.NoError(t, err)
+
+		assert.Nil(t, labelAccount)
+		assert.Nil(t, labelTarget)
+
+		// The prefix of the label link a path resolves to can be looked up
+		// too.
+		assert.Equal(t, "/api/v2", client.LabelLinkPathPrefix(host, "/api/v2/users"))
+		assert.Equal(t, "/admin/", client.LabelLinkPathPrefix(host, "/admin/dashboard"))
+		assert.Equal(t, "", client.LabelLinkPathPrefix(host, "/apis"))
+		assert.Equal(t, "", client.LabelLinkPathPrefix(other, "/api/v2"))
+	})
+
+	t.Run("only uses services on unhealthy hubs as a last resort", func(t *testing.T) {
+		L := hclog.L()
+
+		client := &Client{
+			L:               L,
+			instanceId:      pb.NewULID(),
+			accountServices: make(map[string]*accountInfo),
+		}
+
+		account := &pb.Account{
+			AccountId: pb.NewULID(),
+			Namespace: "/",
+		}
+
+		web := pb.ParseLabelSet("service=www,env=prod")
+		api := pb.ParseLabelSet("service=api,env=prod")
+
+		healthy := &pb.ServiceRoute{
+			Hub:    pb.NewULID(),
+			Id:     pb.NewULID(),
+			Type:   "http",
+			Labels: web,
+		}
+
+		stale := &pb.ServiceRoute{
+			Hub:       pb.NewULID(),
+			Id:        pb.NewULID(),
+			Type:      "http",
+			Labels:    web,
+			Unhealthy: true,
+		}
+
+		onlyStale := &pb.ServiceRoute{
+			Hub:       pb.NewULID(),
+			Id:        pb.NewULID(),
+			Type:      "http",
+			Labels:    api,
+			Unhealthy: true,
+		}
+
+		ctx := context.Background()
+
+		client.processCentralActivity(ctx, L, &pb.CentralActivity{
+			ResolvedRoutes: []*pb.AccountServices{
+				{
+					Account:  account,
+					Services: []*pb.ServiceRoute{healthy, stale, onlyStale},
+				},
+			},
+		})
+
+		calc, err := client.LookupService(ctx, account, web)
+		require.NoError(t, err)
+
+		services := calc.Services()
+		require.Equal(t, 1, len(services))
+
+		assert.Equal(t, healthy.Id, services[0].Id)
+
+		calc, err = client.LookupService(ctx, account, api)
+		require.NoError(t, err)
+
+		services = calc.Services()
+		require.Equal(t, 1, len(services))
+
+		assert.Equal(t, onlyStale.Id, services[0].Id)
+		assert.True(t, services[0].Unhealthy)
+	})
+
 	t.Run("bootstraps configuration from the server", func(t *testing.T) {
 		db := testsql.TestPostgresDB(t, "periodic")
 		defer db.Close()
@@ -1014,6 +1252,472 @@ func TestClient(t *testing.T) {
 		assert.Equal(t, flowId, snap.Records[0].FlowId)
 	})
 
+	t.Run("queues flow records in the order of their sequence", func(t *testing.T) {
+		var c Client
+		c.hubActivity = make(chan *pb.HubActivity, 1000)
+		c.flowSeq = new(int64)
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for j := 0; j < 100; j++ {
+					c.SendFlow(&pb.FlowRecord{})
+				}
+			}()
+		}
+
+		wg.Wait()
+		close(c.hubActivity)
+
+		var last int64
+
+		for act := range c.hubActivity {
+			seq := act.Flow[0].Sequence
+			require.Equal(t, last+1, seq)
+
+			last = seq
+		}
+
+		assert.Equal(t, int64(1000), last)
+	})
+
+	t.Run("drops flow records rather than block when the queue is full", func(t *testing.T) {
+		c := Client{L: hclog.L()}
+		c.hubActivity = make(chan *pb.HubActivity, 1)
+		c.flowSeq = new(int64)
+		c.flowDrops = new(int64)
+
+		assert.True(t, c.TrySendFlow(&pb.FlowRecord{}))
+		assert.False(t, c.TrySendFlow(&pb.FlowRecord{}))
+
+		assert.Equal(t, int64(1), c.FlowsDropped())
+
+		act := <-c.hubActivity
+		assert.Equal(t, int64(1), act.Flow[0].Sequence)
+
+		// A dropped record's sequence is skipped, which the server allows.
+		assert.True(t, c.TrySendFlow(&pb.FlowRecord{}))
+
+		act = <-c.hubActivity
+		assert.Equal(t, int64(3), act.Flow[0].Sequence)
+	})
+
+	t.Run("can run without the in-memory metrics sink", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "periodic")
+		defer db.Close()
+
+		cfg := scfg
+		cfg.DB = db
+		cfg.DisableInmemSink = true
+
+		s, err := NewServer(cfg)
+		require.NoError(t, err)
+
+		defer s.Close()
+
+		assert.Nil(t, s.msink)
+
+		// Metrics are still recorded, just not kept in memory.
+		s.processFlows(&connectedHub{
+			messages:    new(int64),
+			bytes:       new(int64),
+			lastFlowSeq: new(int64),
+		}, []*pb.FlowRecord{
+			{
+				Stream: &pb.FlowStream{
+					FlowId:      pb.NewULID(),
+					HubId:       pb.NewULID(),
+					NumMessages: 1,
+					NumBytes:    10,
+				},
+			},
+		})
+
+		cfg.DisableInmemSink = false
+		cfg.InmemInterval = 10 * time.Second
+		cfg.InmemRetention = time.Minute
+
+		s2, err := NewServer(cfg)
+		require.NoError(t, err)
+
+		defer s2.Close()
+
+		require.NotNil(t, s2.msink)
+
+		s2.m.IncrCounter([]string{"test"}, 1)
+
+		data := s2.msink.(*metrics.InmemSink).Data()
+		require.Equal(t, 1, len(data))
+
+		assert.Equal(t, 1, data[0].Counters["control.test"].Count)
+	})
+
+	t.Run("flushes and closes the metric sinks when closed", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "periodic")
+		defer db.Close()
+
+		var sink closingSink
+
+		cfg := scfg
+		cfg.DB = db
+		cfg.MetricSinks = []metrics.MetricSink{&sink}
+
+		s, err := NewServer(cfg)
+		require.NoError(t, err)
+
+		s.m.IncrCounter([]string{"test"}, 1)
+
+		assert.True(t, atomic.LoadInt64(&sink.counters) >= 1)
+		assert.Equal(t, int64(0), atomic.LoadInt64(&sink.flushed))
+		assert.Equal(t, int64(0), atomic.LoadInt64(&sink.closed))
+
+		require.NoError(t, s.Close())
+
+		assert.Equal(t, int64(1), atomic.LoadInt64(&sink.flushed))
+		assert.Equal(t, int64(1), atomic.LoadInt64(&sink.closed))
+
+		// Closing again doesn't close the sinks again.
+		require.NoError(t, s.Close())
+
+		assert.Equal(t, int64(1), atomic.LoadInt64(&sink.closed))
+	})
+
+	t.Run("switches to rotated s3 credentials from central", func(t *testing.T) {
+		var c Client
+		c.bucket = "bucket1"
+
+		c.processCentralActivity(context.Background(), hclog.L(), &pb.CentralActivity{
+			S3Credentials: &pb.S3Credentials{
+				AccessKey: "access2",
+				SecretKey: "secret2",
+				Bucket:    "bucket2",
+			},
+		})
+
+		bucket, s3api := c.s3Access()
+		assert.Equal(t, "bucket2", bucket)
+		require.NotNil(t, s3api)
+
+		creds, err := c.cfg.Session.Config.Credentials.Get()
+		require.NoError(t, err)
+
+		assert.Equal(t, "access2", creds.AccessKeyID)
+		assert.Equal(t, "secret2", creds.SecretAccessKey)
+	})
+
+	t.Run("fetches rotated s3 credentials when central says they changed", func(t *testing.T) {
+		fetched := make(chan struct{})
+
+		var c Client
+		c.bucket = "bucket1"
+		c.client = &configClient{
+			resp: &pb.ConfigResponse{
+				S3AccessKey: "access2",
+				S3SecretKey: "secret2",
+				S3Bucket:    "bucket1",
+			},
+			fetched: fetched,
+		}
+
+		c.processCentralActivity(context.Background(), hclog.L(), &pb.CentralActivity{
+			S3CredentialsRotated: true,
+		})
+
+		select {
+		case <-fetched:
+		case <-time.After(time.Second):
+			t.Fatal("config was not fetched")
+		}
+
+		require.Eventually(t, func() bool {
+			_, s3api := c.s3Access()
+			return s3api != nil
+		}, time.Second, 10*time.Millisecond)
+
+		c.s3Mu.RLock()
+		sess := c.cfg.Session
+		c.s3Mu.RUnlock()
+
+		creds, err := sess.Config.Credentials.Get()
+		require.NoError(t, err)
+
+		assert.Equal(t, "access2", creds.AccessKeyID)
+		assert.Equal(t, "secret2", creds.SecretAccessKey)
+	})
+
+	t.Run("limits routes to those matching a target's match expression", func(t *testing.T) {
+		var c Client
+		c.L = hclog.L()
+		c.accountServices = make(map[string]*accountInfo)
+
+		account := &pb.Account{
+			Namespace: "/",
+			AccountId: pb.NewULID(),
+		}
+
+		route := func(labels string) *pb.ServiceRoute {
+			return &pb.ServiceRoute{
+				Hub:    pb.NewULID(),
+				Id:     pb.NewULID(),
+				Type:   "http",
+				Labels: pb.ParseLabelSet(labels),
+			}
+		}
+
+		us := route("service=www,env=prod,region=us")
+		eu := route("service=www,env=prod,region=eu")
+		ap := route("service=www,env=prod,region=ap")
+		test := route("service=www,env=test,region=us")
+		api := route("service=api,env=prod,region=us")
+
+		c.processCentralActivity(context.Background(), c.L, &pb.CentralActivity{
+			ResolvedRoutes: []*pb.AccountServices{
+				{
+					Account:  account,
+					Services: []*pb.ServiceRoute{us, eu, ap, test, api},
+				},
+			},
+		})
+
+		lookup := func(target string) []*pb.ULID {
+			calc, err := c.LookupService(context.Background(), account, pb.ParseLabelSet(target))
+			require.NoError(t, err)
+
+			var ids []*pb.ULID
+			for _, r := range calc.All {
+				ids = append(ids, r.Id)
+			}
+
+			return ids
+		}
+
+		// Exact label matching is unchanged.
+		assert.ElementsMatch(t, []*pb.ULID{us.Id, eu.Id, ap.Id, test.Id}, lookup("service=www"))
+
+		// The expression is applied on top of the target's other labels.
+		assert.ElementsMatch(t, []*pb.ULID{us.Id, eu.Id},
+			lookup("service=www,:match=env=prod and (region=us or region=eu)"))
+
+		assert.ElementsMatch(t, []*pb.ULID{us.Id, eu.Id, ap.Id},
+			lookup("service=www,:match=not env=test"))
+
+		assert.ElementsMatch(t, []*pb.ULID{us.Id, api.Id},
+			lookup(":match=env=prod and region=us"))
+
+		// A bad expression routes nowhere rather than everywhere.
+		assert.Empty(t, lookup("service=www,:match=env=prod and"))
+	})
+
+	t.Run("routes nothing to a suspended account, even on the hub", func(t *testing.T) {
+		L := hclog.L()
+
+		var c Client
+		c.accountServices = make(map[string]*accountInfo)
+
+		account := &pb.Account{
+			Namespace: "/",
+			AccountId: pb.NewULID(),
+		}
+
+		serviceId := pb.NewULID()
+
+		c.localServices = map[string]*pb.ServiceRequest{
+			serviceId.SpecString(): {
+				Account: account,
+				Hub:     pb.NewULID(),
+				Id:      serviceId,
+				Type:    "http",
+				Labels:  pb.ParseLabelSet("service=www"),
+			},
+		}
+
+		lookup := func() *RouteCalculation {
+			calc, err := c.LookupService(context.Background(), account, pb.ParseLabelSet("service=www"))
+			require.NoError(t, err)
+
+			return calc
+		}
+
+		c.processCentralActivity(context.Background(), L, &pb.CentralActivity{
+			ResolvedRoutes: []*pb.AccountServices{
+				{Account: account, Suspended: true},
+			},
+		})
+
+		assert.True(t, lookup().Empty())
+
+		c.processCentralActivity(context.Background(), L, &pb.CentralActivity{
+			ResolvedRoutes: []*pb.AccountServices{
+				{Account: account},
+			},
+		})
+
+		services := lookup().Services()
+		require.Equal(t, 1, len(services))
+		assert.Equal(t, serviceId, services[0].Id)
+	})
+
+	t.Run("can look up services while routes are resolved", func(t *testing.T) {
+		L := hclog.L()
+
+		var c Client
+		c.accountServices = make(map[string]*accountInfo)
+
+		account := &pb.Account{
+			Namespace: "/",
+			AccountId: pb.NewULID(),
+		}
+
+		routes := func() *pb.AccountServices {
+			return &pb.AccountServices{
+				Account: account,
+				Services: []*pb.ServiceRoute{
+					{
+						Hub:    pb.NewULID(),
+						Id:     pb.NewULID(),
+						Type:   "http",
+						Labels: pb.ParseLabelSet("service=www"),
+					},
+				},
+			}
+		}
+
+		c.processCentralActivity(context.Background(), L, &pb.CentralActivity{
+			ResolvedRoutes: []*pb.AccountServices{routes()},
+		})
+
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+
+			for i := 0; i < 100; i++ {
+				c.processCentralActivity(context.Background(), L, &pb.CentralActivity{
+					ResolvedRoutes: []*pb.AccountServices{routes()},
+				})
+			}
+		}()
+
+		for i := 0; i < 100; i++ {
+			calc, err := c.LookupService(context.Background(), account, pb.ParseLabelSet("service=www"))
+			require.NoError(t, err)
+			assert.Equal(t, 1, len(calc.Services()))
+		}
+
+		<-done
+	})
+
+	t.Run("only routes to services on hubs in a token's scope", func(t *testing.T) {
+		hub := func(labels string) *pb.HubInfo {
+			return &pb.HubInfo{
+				Id: pb.NewULID(),
+				Locations: []*pb.NetworkLocation{
+					{Labels: pb.ParseLabelSet(labels)},
+				},
+			}
+		}
+
+		route := func(h *pb.HubInfo) *pb.ServiceRoute {
+			return &pb.ServiceRoute{
+				Hub:  h.Id,
+				Id:   pb.NewULID(),
+				Type: "http",
+			}
+		}
+
+		east := hub("region=us-east,zone=a")
+		west := hub("region=us-west,zone=a")
+
+		// A hub that doesn't report any location labels.
+		bare := &pb.HubInfo{Id: pb.NewULID()}
+
+		eastRoute := route(east)
+		westRoute := route(west)
+		bareRoute := route(bare)
+
+		hubs := []*pb.HubInfo{east, west, bare}
+		routes := []*pb.ServiceRoute{eastRoute, westRoute, bareRoute}
+
+		assert.Equal(t,
+			[]*pb.ServiceRoute{eastRoute},
+			routesInScope(routes, hubs, pb.ParseLabelSet("region=us-east")),
+		)
+
+		assert.Equal(t,
+			[]*pb.ServiceRoute{westRoute},
+			routesInScope(routes, hubs, pb.ParseLabelSet("region=us-west")),
+		)
+
+		assert.Empty(t, routesInScope(routes, hubs, pb.ParseLabelSet("region=eu-central")))
+
+		// Routes to hubs control doesn't know about are never in scope.
+		assert.Empty(t, routesInScope(routes, nil, pb.ParseLabelSet("region=us-east")))
+
+		// A token without a hub scope gets the routes to every hub, even
+		// those without location labels, which no scoped token gets.
+		var c Client
+
+		all, err := c.RoutesInScope(context.Background(), routes, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, routes, all)
+	})
+
+	t.Run("picks services in proportion to their weights", func(t *testing.T) {
+		route := func(weight uint32) *pb.ServiceRoute {
+			return &pb.ServiceRoute{
+				Hub:    pb.NewULID(),
+				Id:     pb.NewULID(),
+				Type:   "http",
+				Weight: weight,
+			}
+		}
+
+		// Zero weights share equally, the same as a weight of 1.
+		light := route(0)
+		lightToo := route(1)
+		heavy := route(8)
+
+		calc := &RouteCalculation{
+			All: []*pb.ServiceRoute{light, lightToo, heavy},
+		}
+
+		const rounds = 10000
+
+		first := make(map[*pb.ServiceRoute]int)
+
+		for i := 0; i < rounds; i++ {
+			services := calc.Services()
+			require.Len(t, services, 3)
+
+			first[services[0]]++
+		}
+
+		assert.InDelta(t, 0.8, float64(first[heavy])/rounds, 0.03)
+		assert.InDelta(t, 0.1, float64(first[light])/rounds, 0.03)
+		assert.InDelta(t, 0.1, float64(first[lightToo])/rounds, 0.03)
+
+		// Equal weights are an even shuffle.
+		calc = &RouteCalculation{
+			All: []*pb.ServiceRoute{route(0), route(0)},
+		}
+
+		first = make(map[*pb.ServiceRoute]int)
+
+		for i := 0; i < rounds; i++ {
+			first[calc.Services()[0]]++
+		}
+
+		for _, rs := range calc.All {
+			assert.InDelta(t, 0.5, float64(first[rs])/rounds, 0.03)
+		}
+	})
+
 	t.Run("can get a list of all hubs and locations", func(t *testing.T) {
 		db := testsql.TestPostgresDB(t, "periodic")
 		defer db.Close()
@@ -1107,6 +1811,90 @@ func TestClient(t *testing.T) {
 
 	})
 
+	t.Run("rejects oversized messages from hubs", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "periodic")
+		defer db.Close()
+
+		cfg := scfg
+		cfg.DB = db
+		cfg.MaxRecvMsgSize = 4096
+		cfg.MaxHubServices = 10
+
+		s, err := NewServer(cfg)
+		require.NoError(t, err)
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(top, md)
+
+		ctr, err := s.IssueHubToken(ctx, &pb.Noop{})
+		require.NoError(t, err)
+
+		gs := grpc.NewServer(s.GRPCServerOptions()...)
+		pb.RegisterControlServicesServer(gs, s)
+
+		li, err := net.Listen("tcp", ":0")
+		require.NoError(t, err)
+
+		defer li.Close()
+
+		go gs.Serve(li)
+
+		gcc, err := grpc.Dial(li.Addr().String(),
+			grpc.WithInsecure(),
+			grpc.WithPerRPCCredentials(grpctoken.Token(ctr.Token)),
+		)
+
+		require.NoError(t, err)
+
+		defer gcc.Close()
+
+		gClient := pb.NewControlServicesClient(gcc)
+
+		_, err = gClient.FetchConfig(top, &pb.ConfigRequest{
+			StableId:   pb.NewULID(),
+			InstanceId: pb.NewULID(),
+			Locations: []*pb.NetworkLocation{
+				{
+					Addresses: []string{strings.Repeat("1", 8192)},
+				},
+			},
+		})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+		var locs []*pb.NetworkLocation
+
+		for i := 0; i <= DefaultMaxHubLocations; i++ {
+			locs = append(locs, &pb.NetworkLocation{
+				Addresses: []string{"1.1.1.1"},
+			})
+		}
+
+		_, err = gClient.FetchConfig(top, &pb.ConfigRequest{
+			StableId:   pb.NewULID(),
+			InstanceId: pb.NewULID(),
+			Locations:  locs,
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		var hr Hub
+		assert.Error(t, dbx.Check(s.db.First(&hr)))
+
+		var services []*pb.ServiceRequest
+
+		for i := 0; i <= 10; i++ {
+			services = append(services, &pb.ServiceRequest{})
+		}
+
+		_, err = gClient.SyncHub(top, &pb.HubSync{
+			Services: services,
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
 	t.Run("removes a old hubs services connecting with the same stable id", func(t *testing.T) {
 		db := testsql.TestPostgresDB(t, "periodic")
 		defer db.Close()
@@ -1347,3 +2135,29 @@ func TestClient(t *testing.T) {
 	})
 
 }
+
+// closingSink counts the counters it's sent and the times it's flushed and
+// closed.
+type closingSink struct {
+	counters, flushed, closed int64
+
+	metrics.BlackholeSink
+}
+
+func (c *closingSink) IncrCounter(key []string, val float32) {
+	atomic.AddInt64(&c.counters, 1)
+}
+
+func (c *closingSink) IncrCounterWithLabels(key []string, val float32, labels []metrics.Label) {
+	atomic.AddInt64(&c.counters, 1)
+}
+
+func (c *closingSink) Flush() error {
+	atomic.AddInt64(&c.flushed, 1)
+	return nil
+}
+
+func (c *closingSink) Close() error {
+	atomic.AddInt64(&c.closed, 1)
+	return nil
+}
@@ -0,0 +1,151 @@
+package control
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// The path the server's live state is served on, for diagnosing routing.
+// Requests need the ops token as their Authorization header.
+const DebugStatePath = "/debug/state"
+
+// How many flows the debug state includes when the request doesn't say.
+const DefaultDebugStateFlows = 20
+
+type debugState struct {
+	Hubs  []*debugHub      `json:"hubs"`
+	Flows []*pb.FlowStream `json:"flows"`
+}
+
+type debugHub struct {
+	Hub           string `json:"hub"`
+	Messages      int64  `json:"messages"`
+	Bytes         int64  `json:"bytes"`
+	Dropped       int64  `json:"dropped"`
+	Missed        int64  `json:"missed"`
+	QueueDepth    int    `json:"queue_depth"`
+	MaxQueueDepth int64  `json:"max_queue_depth"`
+
+	// The accounts the hub is sent activity for, when SelectiveBroadcast is
+	// enabled and they've been loaded.
+	Accounts []string `json:"accounts,omitempty"`
+}
+
+// checkOpsRequest reports if req was made with the ops token, either bare or
+// as a bearer token.
+func (s *Server) checkOpsRequest(req *http.Request) bool {
+	auth := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	secret := s.currentOpsToken()
+
+	return secret != "" && subtle.ConstantTimeCompare([]byte(auth), []byte(secret)) == 1
+}
+
+// httpDebugState serves a JSON snapshot of the connected hubs and the most
+// recent flows. Only the counters are copied while holding the lock, so it
+// doesn't hold up broadcasts for long. The number of flows can be set with
+// the flows query parameter.
+func (s *Server) httpDebugState(w http.ResponseWriter, req *http.Request) {
+	if !s.checkOpsRequest(req) {
+		http.Error(w, "bad authentication information presented", http.StatusUnauthorized)
+		return
+	}
+
+	var state debugState
+
+	state.Hubs = s.debugHubs()
+
+	max := DefaultDebugStateFlows
+
+	if v := req.URL.Query().Get("flows"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			http.Error(w, "invalid flows parameter", http.StatusBadRequest)
+			return
+		}
+
+		max = n
+	}
+
+	if s.flowTop != nil {
+		entries, err := s.flowTop.Export()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// The most recent flows are at the end.
+		if len(entries) > max {
+			entries = entries[len(entries)-max:]
+		}
+
+		for _, e := range entries {
+			state.Flows = append(state.Flows, e.agg)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(&state)
+	if err != nil {
+		s.L.Error("error encoding debug state", "error", err)
+	}
+}
+
+func (s *Server) debugHubs() []*debugHub {
+	s.mu.RLock()
+
+	hubs := make([]*debugHub, 0, len(s.connectedHubs))
+	conns := make([]*connectedHub, 0, len(s.connectedHubs))
+
+	for key, ch := range s.connectedHubs {
+		dh := &debugHub{
+			Hub:           hubOfConnKey(key),
+			Dropped:       atomic.LoadInt64(&ch.dropped),
+			Missed:        atomic.LoadInt64(&ch.missed),
+			QueueDepth:    len(ch.xmit),
+			MaxQueueDepth: atomic.LoadInt64(&ch.maxQueueDepth),
+		}
+
+		if ch.messages != nil {
+			dh.Messages = atomic.LoadInt64(ch.messages)
+		}
+
+		if ch.bytes != nil {
+			dh.Bytes = atomic.LoadInt64(ch.bytes)
+		}
+
+		hubs = append(hubs, dh)
+		conns = append(conns, ch)
+	}
+
+	s.mu.RUnlock()
+
+	// Each hub's accounts have their own lock, so they're copied after
+	// letting go of the server's.
+	for i, ch := range conns {
+		ch.accountsMu.RLock()
+
+		if ch.accountsLoaded {
+			for key := range ch.accounts {
+				hubs[i].Accounts = append(hubs[i].Accounts, key)
+			}
+		}
+
+		ch.accountsMu.RUnlock()
+
+		sort.Strings(hubs[i].Accounts)
+	}
+
+	sort.Slice(hubs, func(i, j int) bool {
+		return hubs[i].Hub < hubs[j].Hub
+	})
+
+	return hubs
+}
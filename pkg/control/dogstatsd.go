@@ -0,0 +1,101 @@
+package control
+
+import (
+	"strings"
+
+	"github.com/DataDog/datadog-go/statsd"
+	"github.com/armon/go-metrics"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// dogStatsdSink sends metrics to a dogstatsd agent. It does the same as the
+// go-metrics datadog sink, but keeps hold of the client so the metrics it has
+// buffered can be flushed when the server is closed.
+type dogStatsdSink struct {
+	client *statsd.Client
+}
+
+func newDogStatsdSink(addr string) (*dogStatsdSink, error) {
+	client, err := statsd.New(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dogStatsdSink{client: client}, nil
+}
+
+var dogStatsdReplacer = strings.NewReplacer(":", "_", " ", "_")
+
+func (d *dogStatsdSink) flatten(key []string, labels []metrics.Label) (string, []string) {
+	var tags []string
+
+	for _, l := range labels {
+		tags = append(tags, l.Name+":"+dogStatsdReplacer.Replace(l.Value))
+	}
+
+	return dogStatsdReplacer.Replace(strings.Join(key, ".")), tags
+}
+
+func (d *dogStatsdSink) SetGauge(key []string, val float32) {
+	d.SetGaugeWithLabels(key, val, nil)
+}
+
+func (d *dogStatsdSink) SetGaugeWithLabels(key []string, val float32, labels []metrics.Label) {
+	name, tags := d.flatten(key, labels)
+	d.client.Gauge(name, float64(val), tags, 1)
+}
+
+func (d *dogStatsdSink) EmitKey(key []string, val float32) {
+	name, tags := d.flatten(key, nil)
+	d.client.Histogram(name, float64(val), tags, 1)
+}
+
+func (d *dogStatsdSink) IncrCounter(key []string, val float32) {
+	d.IncrCounterWithLabels(key, val, nil)
+}
+
+func (d *dogStatsdSink) IncrCounterWithLabels(key []string, val float32, labels []metrics.Label) {
+	name, tags := d.flatten(key, labels)
+	d.client.Count(name, int64(val), tags, 1)
+}
+
+func (d *dogStatsdSink) AddSample(key []string, val float32) {
+	d.AddSampleWithLabels(key, val, nil)
+}
+
+func (d *dogStatsdSink) AddSampleWithLabels(key []string, val float32, labels []metrics.Label) {
+	name, tags := d.flatten(key, labels)
+	d.client.TimeInMilliseconds(name, float64(val), tags, 1)
+}
+
+// Flush sends any metrics the client has buffered.
+func (d *dogStatsdSink) Flush() error {
+	return d.client.Flush()
+}
+
+// Close flushes the client and closes its connection.
+func (d *dogStatsdSink) Close() error {
+	return d.client.Close()
+}
+
+// closeSinks flushes, then closes, each of sinks that can be, so that metrics
+// they've buffered aren't lost when the server exits.
+func closeSinks(sinks []metrics.MetricSink) error {
+	var result error
+
+	for _, sink := range sinks {
+		if f, ok := sink.(interface{ Flush() error }); ok {
+			if err := f.Flush(); err != nil {
+				result = multierror.Append(result, err)
+			}
+		}
+
+		if c, ok := sink.(interface{ Close() error }); ok {
+			if err := c.Close(); err != nil {
+				result = multierror.Append(result, err)
+			}
+		}
+	}
+
+	return result
+}
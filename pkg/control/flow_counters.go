@@ -0,0 +1,62 @@
+package control
+
+import (
+	context "context"
+	"sort"
+	"sync/atomic"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// FlowCounters reports the messages and bytes of the flows of each connected
+// hub.
+func (s *Server) FlowCounters(ctx context.Context, _ *pb.Noop) (*pb.FlowCountersResponse, error) {
+	if !s.checkOpsAllowed(ctx) {
+		return nil, ErrBadAuthentication
+	}
+
+	return s.flowCounters(atomic.LoadInt64), nil
+}
+
+// ResetFlowCounters zeroes the flow counters of each connected hub, returning
+// the values they had. Each counter is swapped atomically, so no flows are
+// lost or counted twice, though flows processed while resetting may be split
+// between the values returned and the counters after.
+func (s *Server) ResetFlowCounters(ctx context.Context, _ *pb.Noop) (*pb.FlowCountersResponse, error) {
+	if !s.checkOpsAllowed(ctx) {
+		return nil, ErrBadAuthentication
+	}
+
+	s.L.Info("resetting flow counters")
+
+	return s.flowCounters(func(addr *int64) int64 {
+		return atomic.SwapInt64(addr, 0)
+	}), nil
+}
+
+// flowCounters returns the flow counters of each connected hub, reading them
+// with read.
+func (s *Server) flowCounters(read func(addr *int64) int64) *pb.FlowCountersResponse {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out pb.FlowCountersResponse
+
+	for key, hub := range s.connectedHubs {
+		if hub.messages == nil || hub.bytes == nil {
+			continue
+		}
+
+		out.Hubs = append(out.Hubs, &pb.HubFlowCounters{
+			Hub:      hubOfConnKey(key),
+			Messages: read(hub.messages),
+			Bytes:    read(hub.bytes),
+		})
+	}
+
+	sort.Slice(out.Hubs, func(i, j int) bool {
+		return out.Hubs[i].Hub < out.Hubs[j].Hub
+	})
+
+	return &out
+}
@@ -0,0 +1,147 @@
+package control
+
+import (
+	context "context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// How often the smoothed flow rate gauges are updated when
+// ServerConfig.FlowRateInterval isn't set.
+var DefaultFlowRateInterval = 10 * time.Second
+
+// Rates below this, in messages or bytes per second, are reported as zero
+// and the account is forgotten until it has traffic again.
+const flowRateFloor = 0.001
+
+// flowRates keeps an exponentially decaying rate of the messages and bytes of
+// each account's streams. Unlike the raw counters, whose rates jump with
+// every batch of flow records, the rates move smoothly, with the weight of
+// past traffic falling off by 1/e every decay.
+type flowRates struct {
+	decay time.Duration
+
+	mu       sync.Mutex
+	accounts map[string]*flowRate
+}
+
+// flowRate decays the messages and bytes added to it, so that divided by the
+// decay constant they're the rate of traffic.
+type flowRate struct {
+	messages float64
+	bytes    float64
+	updated  time.Time
+}
+
+func newFlowRates(decay time.Duration) *flowRates {
+	return &flowRates{
+		decay:    decay,
+		accounts: make(map[string]*flowRate),
+	}
+}
+
+// decayTo decays fr from when it was last updated to now.
+func (f *flowRates) decayTo(fr *flowRate, now time.Time) {
+	dt := now.Sub(fr.updated)
+	if dt <= 0 {
+		return
+	}
+
+	factor := math.Exp(-float64(dt) / float64(f.decay))
+
+	fr.messages *= factor
+	fr.bytes *= factor
+	fr.updated = now
+}
+
+// add adds the traffic of streams seen at now to the rates of their accounts.
+func (f *flowRates) add(now time.Time, streams []*pb.FlowStream) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, stream := range streams {
+		key := stream.Account.SpecString()
+
+		fr, ok := f.accounts[key]
+		if !ok {
+			fr = &flowRate{updated: now}
+			f.accounts[key] = fr
+		} else {
+			f.decayTo(fr, now)
+		}
+
+		fr.messages += float64(stream.NumMessages)
+		fr.bytes += float64(stream.NumBytes)
+	}
+}
+
+// flowRateValue is the rate of an account's traffic, per second.
+type flowRateValue struct {
+	Messages float64
+	Bytes    float64
+}
+
+// rates returns the rate of each account's traffic as of now. Accounts whose
+// rates have decayed below flowRateFloor are included one last time as zero,
+// then forgotten.
+func (f *flowRates) rates(now time.Time) map[string]flowRateValue {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	secs := f.decay.Seconds()
+
+	out := make(map[string]flowRateValue, len(f.accounts))
+
+	for key, fr := range f.accounts {
+		f.decayTo(fr, now)
+
+		rv := flowRateValue{
+			Messages: fr.messages / secs,
+			Bytes:    fr.bytes / secs,
+		}
+
+		if rv.Messages < flowRateFloor && rv.Bytes < flowRateFloor {
+			rv = flowRateValue{}
+			delete(f.accounts, key)
+		}
+
+		out[key] = rv
+	}
+
+	return out
+}
+
+// runFlowRateReporter periodically sets the gauges of the smoothed rate of
+// each account's traffic until ctx is canceled. They're set on a timer rather
+// than as flow records arrive so they decay when an account goes idle.
+func (s *Server) runFlowRateReporter(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.reportFlowRates()
+		}
+	}
+}
+
+func (s *Server) reportFlowRates() {
+	for account, rv := range s.flowRates.rates(s.now()) {
+		labels := []metrics.Label{
+			{
+				Name:  "account",
+				Value: account,
+			},
+		}
+
+		s.m.SetGaugeWithLabels([]string{"stream", "rate", "messages"}, float32(rv.Messages), labels)
+		s.m.SetGaugeWithLabels([]string{"stream", "rate", "bytes"}, float32(rv.Bytes), labels)
+	}
+}
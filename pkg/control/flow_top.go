@@ -2,22 +2,32 @@ package control
 
 import (
 	context "context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"sort"
+	"sync"
 	"time"
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/hashicorp/horizon/pkg/pb"
-	"google.golang.org/grpc/metadata"
+	"github.com/pkg/errors"
 )
 
+// FlowTop keeps the most recently updated flows, for CurrentFlowTop. It's an
+// LRU bounded by the size it was created with, so flows that churn quickly
+// evict the ones that have gone quiet rather than growing it, and a flow that
+// keeps getting updated stays in it.
 type FlowTop struct {
-	entries *lru.ARCCache
+	mu      sync.Mutex
+	entries *lru.Cache
 }
 
 const DefaultFlowTopSize = 100
 
 func NewFlowTop(count int) (*FlowTop, error) {
-	ent, err := lru.NewARC(count)
+	ent, err := lru.New(count)
 	if err != nil {
 		return nil, err
 	}
@@ -32,7 +42,13 @@ type FlowTopEntry struct {
 	updated time.Time
 }
 
+// Add adds rec to the flow it's for, making the flow the most recently used.
+// If the flow is new and the FlowTop is full, the least recently used flow is
+// evicted.
 func (f *FlowTop) Add(rec *pb.FlowStream) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	key := rec.FlowId.String()
 	v, ok := f.entries.Get(key)
 	if !ok {
@@ -48,7 +64,15 @@ func (f *FlowTop) Add(rec *pb.FlowStream) {
 	}
 }
 
+// Len returns how many flows are in the FlowTop.
+func (f *FlowTop) Len() int {
+	return f.entries.Len()
+}
+
 func (f *FlowTop) Export() ([]*FlowTopEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	entries := make([]*FlowTopEntry, 0, f.entries.Len())
 
 	keys := f.entries.Keys()
@@ -81,19 +105,124 @@ func (f *FlowTop) Export() ([]*FlowTopEntry, error) {
 	return entries, nil
 }
 
-func (s *Server) checkOpsAllowed(ctx context.Context) bool {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return false
+// The version of the format Snapshot writes. Restore rejects snapshots of any
+// other version.
+const flowTopSnapshotVersion = 1
+
+var ErrUnknownFlowTopSnapshot = errors.New("unknown flow top snapshot version")
+
+type flowTopSnapshot struct {
+	Version int                    `json:"version"`
+	Entries []flowTopSnapshotEntry `json:"entries"`
+}
+
+type flowTopSnapshotEntry struct {
+	Flow    *pb.FlowStream `json:"flow"`
+	Updated time.Time      `json:"updated"`
+}
+
+// Snapshot returns the flows in the FlowTop, from least to most recently
+// used, so they can be given to Restore after a restart.
+func (f *FlowTop) Snapshot() ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	snap := flowTopSnapshot{
+		Version: flowTopSnapshotVersion,
+		Entries: make([]flowTopSnapshotEntry, 0, f.entries.Len()),
 	}
 
-	auth := md["authorization"]
+	for _, k := range f.entries.Keys() {
+		if v, ok := f.entries.Peek(k); ok {
+			entry := v.(*FlowTopEntry)
 
-	if len(auth) < 1 {
-		return false
+			snap.Entries = append(snap.Entries, flowTopSnapshotEntry{
+				Flow:    entry.agg,
+				Updated: entry.updated,
+			})
+		}
+	}
+
+	return json.Marshal(&snap)
+}
+
+// Restore adds the flows from a Snapshot, keeping the order they were used
+// in. The FlowTop doesn't need to be the same size as the one the snapshot was
+// taken from: if it's smaller, only the most recently used flows are kept.
+func (f *FlowTop) Restore(data []byte) error {
+	var snap flowTopSnapshot
+
+	err := json.Unmarshal(data, &snap)
+	if err != nil {
+		return err
 	}
 
-	return auth[0] == s.opsToken
+	if snap.Version != flowTopSnapshotVersion {
+		return errors.Wrapf(ErrUnknownFlowTopSnapshot, "%d", snap.Version)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, se := range snap.Entries {
+		if se.Flow == nil || se.Flow.FlowId == nil {
+			continue
+		}
+
+		f.entries.Add(se.Flow.FlowId.String(), &FlowTopEntry{
+			agg:     se.Flow,
+			updated: se.Updated,
+		})
+	}
+
+	return nil
+}
+
+// loadFlowTop restores ft from the snapshot in path, if there is one.
+func loadFlowTop(ft *FlowTop, path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	return ft.Restore(data)
+}
+
+// saveFlowTop writes a snapshot of ft to path. It's written to a temporary
+// file first so a crash midway doesn't leave a partial snapshot behind.
+func saveFlowTop(ft *FlowTop, path string) error {
+	data, err := ft.Snapshot()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), "flow-top")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *Server) checkOpsAllowed(ctx context.Context) bool {
+	return checkSecret(ctx, s.currentOpsToken()) == nil
 }
 
 func (s *Server) CurrentFlowTop(ctx context.Context, req *pb.FlowTopRequest) (*pb.FlowTopSnapshot, error) {
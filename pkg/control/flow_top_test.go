@@ -0,0 +1,153 @@
+package control
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlowTop(t *testing.T) {
+	t.Run("stays bounded as flows churn", func(t *testing.T) {
+		ft, err := NewFlowTop(10)
+		require.NoError(t, err)
+
+		var hot []*pb.ULID
+
+		for i := 0; i < 3; i++ {
+			hot = append(hot, pb.NewULID())
+		}
+
+		for i := 0; i < 1000; i++ {
+			ft.Add(&pb.FlowStream{
+				FlowId:      pb.NewULID(),
+				NumMessages: 1,
+			})
+
+			for _, id := range hot {
+				ft.Add(&pb.FlowStream{
+					FlowId:      id,
+					NumMessages: 1,
+				})
+			}
+
+			assert.True(t, ft.Len() <= 10)
+		}
+
+		assert.Equal(t, 10, ft.Len())
+
+		entries, err := ft.Export()
+		require.NoError(t, err)
+
+		require.Equal(t, 10, len(entries))
+
+		seen := make(map[string]int64)
+
+		for _, e := range entries {
+			seen[e.agg.FlowId.String()] = e.agg.NumMessages
+		}
+
+		for _, id := range hot {
+			assert.Equal(t, int64(1000), seen[id.String()])
+		}
+	})
+
+	t.Run("restores a snapshot", func(t *testing.T) {
+		ft, err := NewFlowTop(10)
+		require.NoError(t, err)
+
+		start := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+		for i := 0; i < 10; i++ {
+			ft.Add(&pb.FlowStream{
+				FlowId:      pb.NewULID(),
+				NumMessages: int64(i),
+				NumBytes:    int64(i * 100),
+				EndedAt:     pb.NewTimestamp(start.Add(time.Duration(i) * time.Second)),
+			})
+		}
+
+		flowIds := func(ft *FlowTop) []string {
+			entries, err := ft.Export()
+			require.NoError(t, err)
+
+			var ids []string
+
+			for _, e := range entries {
+				ids = append(ids, e.agg.FlowId.String())
+			}
+
+			return ids
+		}
+
+		data, err := ft.Snapshot()
+		require.NoError(t, err)
+
+		restored, err := NewFlowTop(10)
+		require.NoError(t, err)
+
+		require.NoError(t, restored.Restore(data))
+
+		assert.Equal(t, flowIds(ft), flowIds(restored))
+
+		entries, err := restored.Export()
+		require.NoError(t, err)
+
+		for i, e := range entries {
+			assert.Equal(t, int64(i), e.agg.NumMessages)
+			assert.Equal(t, int64(i*100), e.agg.NumBytes)
+		}
+
+		smaller, err := NewFlowTop(4)
+		require.NoError(t, err)
+
+		require.NoError(t, smaller.Restore(data))
+
+		assert.Equal(t, flowIds(ft)[6:], flowIds(smaller))
+
+		err = restored.Restore([]byte(`{"version": 2, "entries": []}`))
+		assert.True(t, errors.Is(err, ErrUnknownFlowTopSnapshot))
+	})
+
+	t.Run("saves and loads a snapshot file", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "hzn-flow-top")
+		require.NoError(t, err)
+
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "flow-top")
+
+		ft, err := NewFlowTop(10)
+		require.NoError(t, err)
+
+		require.NoError(t, loadFlowTop(ft, path))
+		assert.Equal(t, 0, ft.Len())
+
+		id := pb.NewULID()
+
+		ft.Add(&pb.FlowStream{
+			FlowId:      id,
+			NumMessages: 3,
+		})
+
+		require.NoError(t, saveFlowTop(ft, path))
+
+		loaded, err := NewFlowTop(10)
+		require.NoError(t, err)
+
+		require.NoError(t, loadFlowTop(loaded, path))
+
+		entries, err := loaded.Export()
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(entries))
+		assert.Equal(t, id.String(), entries[0].agg.FlowId.String())
+		assert.Equal(t, int64(3), entries[0].agg.NumMessages)
+	})
+}
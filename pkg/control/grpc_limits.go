@@ -0,0 +1,86 @@
+package control
+
+import (
+	"github.com/hashicorp/horizon/pkg/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultMaxRecvMsgSize and DefaultMaxSendMsgSize limit the size of the
+// messages the server's grpc.Server receives and sends, when
+// ServerConfig.MaxRecvMsgSize and MaxSendMsgSize aren't set. Hubs only send
+// small messages, but the routing and config sent to them grow with the
+// number of accounts, so more room is left for sending.
+const (
+	DefaultMaxRecvMsgSize = 4 << 20
+	DefaultMaxSendMsgSize = 32 << 20
+)
+
+// DefaultMaxHubLocations and DefaultMaxHubServices limit the network locations
+// a hub can report in FetchConfig and the services it can sync in SyncHub,
+// when ServerConfig.MaxHubLocations and MaxHubServices aren't set.
+const (
+	DefaultMaxHubLocations = 64
+	DefaultMaxHubServices  = 10000
+)
+
+// GRPCServerOptions returns the options to create the grpc.Server that the
+// server's services are registered with: the auth interceptors and the limits
+// on the size of messages. A hub sending a larger message than allowed has
+// the rpc fail with ResourceExhausted before the message is decoded.
+func (s *Server) GRPCServerOptions() []grpc.ServerOption {
+	recv := s.cfg.MaxRecvMsgSize
+	if recv <= 0 {
+		recv = DefaultMaxRecvMsgSize
+	}
+
+	send := s.cfg.MaxSendMsgSize
+	if send <= 0 {
+		send = DefaultMaxSendMsgSize
+	}
+
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(s.UnaryAuthInterceptor),
+		grpc.StreamInterceptor(s.StreamAuthInterceptor),
+		grpc.MaxRecvMsgSize(recv),
+		grpc.MaxSendMsgSize(send),
+	}
+}
+
+// checkHubLocations rejects more network locations than a hub is allowed to
+// report, or locations with labels over the label limits.
+func (s *Server) checkHubLocations(locs []*pb.NetworkLocation) error {
+	max := s.cfg.MaxHubLocations
+	if max <= 0 {
+		max = DefaultMaxHubLocations
+	}
+
+	if len(locs) > max {
+		return status.Errorf(codes.InvalidArgument, "too many locations: %d, limit is %d", len(locs), max)
+	}
+
+	for _, loc := range locs {
+		err := s.checkLabelLimits("location", loc.Labels)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkHubServices rejects more services than a hub is allowed to sync at
+// once.
+func (s *Server) checkHubServices(services []*pb.ServiceRequest) error {
+	max := s.cfg.MaxHubServices
+	if max <= 0 {
+		max = DefaultMaxHubServices
+	}
+
+	if len(services) > max {
+		return status.Errorf(codes.InvalidArgument, "too many services: %d, limit is %d", len(services), max)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package control
+
+import (
+	context "context"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrHubNotAllowed = errors.New("hub not in allow list")
+
+// HubAuthorizer decides whether a hub, identified by its stable id, may fetch
+// its config and stream activity. It's consulted after the hub's token has
+// been validated, so it only needs to decide about hubs that hold a valid HUB
+// token.
+type HubAuthorizer interface {
+	AuthorizeHub(ctx context.Context, stableId *pb.ULID) error
+}
+
+// HubAllowList is a HubAuthorizer that only allows the hubs with the listed
+// stable ids.
+type HubAllowList []*pb.ULID
+
+func (l HubAllowList) AuthorizeHub(ctx context.Context, stableId *pb.ULID) error {
+	if stableId == nil {
+		return errors.Wrapf(ErrHubNotAllowed, "no stable id")
+	}
+
+	for _, id := range l {
+		if id.Equal(stableId) {
+			return nil
+		}
+	}
+
+	return errors.Wrapf(ErrHubNotAllowed, "%s", stableId)
+}
+
+// authorizeHub checks the hub with the given stable id against the configured
+// HubAuthorizer, if there is one, returning a PermissionDenied error if it
+// isn't allowed.
+func (s *Server) authorizeHub(ctx context.Context, stableId *pb.ULID) error {
+	if s.cfg.HubAuthorizer == nil {
+		return nil
+	}
+
+	err := s.cfg.HubAuthorizer.AuthorizeHub(ctx, stableId)
+	if err != nil {
+		s.L.Warn("rejected unauthorized hub", "error", err)
+		return status.Errorf(codes.PermissionDenied, "hub not authorized: %s", err)
+	}
+
+	return nil
+}
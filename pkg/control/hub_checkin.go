@@ -0,0 +1,162 @@
+package control
+
+import (
+	context "context"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/horizon/pkg/dbx"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/jinzhu/gorm"
+)
+
+var (
+	// How often the hub checkin gauges are updated.
+	HubCheckinInterval = time.Minute
+
+	// Hubs that haven't checked in for longer than this are counted as stale.
+	HubStaleThreshold = 10 * time.Minute
+)
+
+// runHubCheckinCollector periodically updates the hub checkin gauges until
+// ctx is canceled.
+func (s *Server) runHubCheckinCollector(ctx context.Context) {
+	ticker := time.NewTicker(HubCheckinInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.collectHubCheckins()
+			if err != nil {
+				s.L.Error("error collecting hub checkins", "error", err)
+			}
+		}
+	}
+}
+
+// collectHubCheckins sets a gauge of the seconds since each hub last checked
+// in, along with the number of hubs that are past HubStaleThreshold. There are
+// few enough hubs that labeling by hub keeps the cardinality reasonable.
+func (s *Server) collectHubCheckins() error {
+	var hubs []*Hub
+
+	err := dbx.Check(s.db.Find(&hubs))
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+
+	var stale int
+
+	for _, h := range hubs {
+		since := now.Sub(h.LastCheckin)
+
+		if since > HubStaleThreshold {
+			stale++
+		}
+
+		s.m.SetGaugeWithLabels([]string{"hub", "since_checkin"}, float32(since.Seconds()), []metrics.Label{
+			{
+				Name:  "hub",
+				Value: h.StableIdULID().SpecString(),
+			},
+		})
+	}
+
+	s.m.SetGauge([]string{"hubs", "stale"}, float32(stale))
+
+	return nil
+}
+
+// touchHubCheckin updates the last checkin time of the hub with the given
+// instance id, right away and then every HubCheckinInterval until ctx is
+// canceled. It runs for as long as the hub is streaming activity, so that
+// LastCheckin reflects when control last heard from the hub rather than
+// just when it started.
+func (s *Server) touchHubCheckin(ctx context.Context, instanceId *pb.ULID) {
+	ticker := time.NewTicker(HubCheckinInterval)
+	defer ticker.Stop()
+
+	for {
+		err := dbx.Check(
+			s.db.Model(&Hub{}).
+				Where("instance_id = ?", instanceId.Bytes()).
+				Update("last_checkin", s.now()),
+		)
+
+		if err != nil {
+			s.L.Error("error updating hub checkin", "error", err, "hub", instanceId)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// annotateRoutes fills in what hubs need to know about routes to pick
+// between them: their health and their weight.
+func (s *Server) annotateRoutes(db *gorm.DB, routes []*pb.ServiceRoute) error {
+	err := s.flagUnhealthyRoutes(db, routes)
+	if err != nil {
+		return err
+	}
+
+	s.weighRoutes(routes)
+
+	return nil
+}
+
+// flagUnhealthyRoutes sets Unhealthy on any of the routes whose hub hasn't
+// checked in within HubStaleThreshold, or has no record at all. Health is
+// judged only by the checkins in the database, which every control server
+// updates for the hubs streaming to it, so it doesn't depend on which server
+// computed the routes. The routes are flagged rather than removed so that
+// when every candidate for a request is unhealthy, they can still be tried as
+// a last resort.
+func (s *Server) flagUnhealthyRoutes(db *gorm.DB, routes []*pb.ServiceRoute) error {
+	if len(routes) == 0 {
+		return nil
+	}
+
+	var ids [][]byte
+
+	seen := make(map[string]bool)
+
+	for _, route := range routes {
+		key := route.Hub.SpecString()
+		if !seen[key] {
+			seen[key] = true
+			ids = append(ids, route.Hub.Bytes())
+		}
+	}
+
+	var hubs []*Hub
+
+	err := dbx.Check(db.Where("instance_id IN (?)", ids).Find(&hubs))
+	if err != nil {
+		return err
+	}
+
+	cutoff := s.now().Add(-HubStaleThreshold)
+
+	healthy := make(map[string]bool)
+
+	for _, h := range hubs {
+		if h.LastCheckin.After(cutoff) {
+			healthy[pb.ULIDFromBytes(h.InstanceID).SpecString()] = true
+		}
+	}
+
+	for _, route := range routes {
+		route.Unhealthy = !healthy[route.Hub.SpecString()]
+	}
+
+	return nil
+}
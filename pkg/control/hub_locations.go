@@ -0,0 +1,160 @@
+package control
+
+import (
+	context "context"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/dbx"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+)
+
+// HubLocation is one of the network locations a hub reported in FetchConfig,
+// stored so hubs can be queried by their locations. The hub's ConnectionInfo
+// remains the source of truth, these are rewritten from it on every
+// FetchConfig.
+type HubLocation struct {
+	ID int64 `gorm:"primary_key"`
+
+	StableID []byte
+
+	Name      string
+	Addresses pq.StringArray
+	Labels    pq.StringArray
+
+	CreatedAt time.Time
+}
+
+// replaceHubLocations swaps the stored locations of the hub with locs.
+func replaceHubLocations(tx *gorm.DB, stableId []byte, locs []*pb.NetworkLocation) error {
+	err := dbx.Check(tx.Where("stable_id = ?", stableId).Delete(&HubLocation{}))
+	if err != nil {
+		return err
+	}
+
+	for _, loc := range locs {
+		hl := HubLocation{
+			StableID:  stableId,
+			Name:      loc.Name,
+			Addresses: pq.StringArray(loc.Addresses),
+			Labels:    pq.StringArray{},
+		}
+
+		if hl.Addresses == nil {
+			hl.Addresses = pq.StringArray{}
+		}
+
+		if loc.Labels != nil {
+			hl.Labels = loc.Labels.AsStringArray()
+		}
+
+		err = dbx.Check(tx.Create(&hl))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (hl *HubLocation) NetworkLocation() (*pb.NetworkLocation, error) {
+	var labels pb.LabelSet
+
+	err := labels.Scan(hl.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.NetworkLocation{
+		Addresses: hl.Addresses,
+		Labels:    &labels,
+		Name:      hl.Name,
+	}, nil
+}
+
+// hubLocations returns the stored locations of the hubs db is scoped to, by
+// stable id.
+func hubLocations(db *gorm.DB) (map[string][]*pb.NetworkLocation, error) {
+	var rows []*HubLocation
+
+	err := dbx.Check(db.Order("id").Find(&rows))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string][]*pb.NetworkLocation)
+
+	for _, hl := range rows {
+		loc, err := hl.NetworkLocation()
+		if err != nil {
+			return nil, err
+		}
+
+		key := string(hl.StableID)
+		out[key] = append(out[key], loc)
+	}
+
+	return out, nil
+}
+
+// hubInfo builds the HubInfo for h, using its stored locations if it has any
+// and otherwise falling back to its ConnectionInfo. Hubs that haven't fetched
+// their config since the locations started being stored only have the latter.
+func hubInfo(h *Hub, locs map[string][]*pb.NetworkLocation) (*pb.HubInfo, error) {
+	hlocs, ok := locs[string(h.StableID)]
+	if !ok {
+		err := json.Unmarshal(h.ConnectionInfo, &hlocs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &pb.HubInfo{
+		Id:        pb.ULIDFromBytes(h.InstanceID),
+		Locations: hlocs,
+	}, nil
+}
+
+// FindHubsByLocationLabel returns the hubs that have a location with the given
+// label. Names and values are matched exactly.
+func (s *Server) FindHubsByLocationLabel(ctx context.Context, label *pb.Label) ([]*pb.HubInfo, error) {
+	var hubs []*Hub
+
+	err := dbx.Check(s.db.
+		Where("stable_id IN (SELECT stable_id FROM hub_locations WHERE labels @> ?)",
+			pq.StringArray{label.Name + "=" + label.Value}).
+		Find(&hubs))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(hubs) == 0 {
+		return nil, nil
+	}
+
+	var ids [][]byte
+
+	for _, h := range hubs {
+		ids = append(ids, h.StableID)
+	}
+
+	locs, err := hubLocations(s.db.Where("stable_id IN (?)", ids))
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*pb.HubInfo
+
+	for _, h := range hubs {
+		info, err := hubInfo(h, locs)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, info)
+	}
+
+	return out, nil
+}
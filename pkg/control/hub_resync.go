@@ -0,0 +1,93 @@
+package control
+
+import (
+	context "context"
+	"sync/atomic"
+
+	"github.com/hashicorp/horizon/pkg/dbx"
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// resyncHub sends the hub the resolved routes of every account it has
+// services for or has cached, replacing its view of them. It's used when the
+// hub may have missed routing activity that the replay can't make up for,
+// either because it connected when the replay didn't cover its window or
+// because it missed some while connected.
+func (s *Server) resyncHub(
+	ctx context.Context,
+	stream pb.ControlServices_StreamActivityServer,
+	ch *connectedHub,
+	hubId *pb.ULID,
+) error {
+	// Cleared before the accounts are read, so routing activity the hub
+	// misses while they're sent leads to another resync.
+	if !atomic.CompareAndSwapInt32(&ch.resync, 1, 0) {
+		return nil
+	}
+
+	accounts, err := s.hubAccounts(ch, hubId)
+	if err != nil {
+		return err
+	}
+
+	for _, account := range accounts {
+		routes, err := s.accountServices(ctx, s.db, account)
+		if err != nil {
+			return err
+		}
+
+		routes.Account = account
+
+		err = stream.Send(&pb.CentralActivity{
+			ResolvedRoutes: []*pb.AccountServices{routes},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(accounts) > 0 {
+		s.L.Info("resynced the routing of hub", "hub", hubId.SpecString(), "accounts", len(accounts))
+	}
+
+	return nil
+}
+
+// hubAccounts returns the accounts the hub with the given instance id has
+// services for, along with those noted on ch, such as the ones it has
+// reported caching.
+func (s *Server) hubAccounts(ch *connectedHub, hubId *pb.ULID) ([]*pb.Account, error) {
+	var keys [][]byte
+
+	err := dbx.Check(s.db.Model(&Service{}).
+		Where("hub_id = ?", hubId.Bytes()).
+		Pluck("DISTINCT account_id", &keys))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{})
+
+	var out []*pb.Account
+
+	for _, key := range keys {
+		account, err := pb.AccountFromKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		seen[account.StringKey()] = struct{}{}
+		out = append(out, account)
+	}
+
+	ch.accountsMu.RLock()
+	defer ch.accountsMu.RUnlock()
+
+	for key, account := range ch.accounts {
+		if _, ok := seen[key]; !ok {
+			out = append(out, account)
+		}
+	}
+
+	return out, nil
+}
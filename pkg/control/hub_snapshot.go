@@ -0,0 +1,166 @@
+package control
+
+import (
+	context "context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/dbx"
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// How often the hub snapshot AllHubs serves from is rebuilt, to pick up
+// changes made by other control servers. Changes made through this server
+// are picked up by the next AllHubs call.
+var HubSnapshotInterval = time.Minute
+
+// hubSnapshot is the decoded list of hubs, so AllHubs doesn't have to decode
+// every hub's locations each time it's called.
+type hubSnapshot struct {
+	// A hash of the hubs, so it's the same on every control server that has
+	// the same hubs.
+	version string
+
+	hubs    []*pb.HubInfo
+	skipped int32
+
+	// The value of Server.hubsGen when the snapshot started being built, so
+	// it's known to be stale once this server changes the hubs again.
+	gen uint64
+}
+
+// runHubSnapshotRefresher rebuilds the hub snapshot every HubSnapshotInterval
+// until ctx is canceled.
+func (s *Server) runHubSnapshotRefresher(ctx context.Context) {
+	ticker := time.NewTicker(HubSnapshotInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := s.refreshHubSnapshot()
+			if err != nil {
+				s.L.Error("error refreshing hub snapshot", "error", err)
+			}
+		}
+	}
+}
+
+// currentHubSnapshot returns the hub snapshot, building it if there isn't one
+// yet or this server has changed the hubs since it was built. Callers that
+// find it stale at the same time share a single rebuild.
+func (s *Server) currentHubSnapshot() (*hubSnapshot, error) {
+	snap := s.freshHubSnapshot()
+	if snap != nil {
+		return snap, nil
+	}
+
+	s.hubsRefreshMu.Lock()
+	defer s.hubsRefreshMu.Unlock()
+
+	// Rebuilt by another caller while we waited.
+	snap = s.freshHubSnapshot()
+	if snap != nil {
+		return snap, nil
+	}
+
+	return s.buildHubSnapshot()
+}
+
+// freshHubSnapshot returns the hub snapshot if there is one and this server
+// hasn't changed the hubs since it was built.
+func (s *Server) freshHubSnapshot() *hubSnapshot {
+	s.hubsMu.RLock()
+	snap := s.hubs
+	s.hubsMu.RUnlock()
+
+	if snap == nil || snap.gen != atomic.LoadUint64(&s.hubsGen) {
+		return nil
+	}
+
+	return snap
+}
+
+// refreshHubSnapshot rebuilds the hub snapshot from the database, whether or
+// not it's stale.
+func (s *Server) refreshHubSnapshot() (*hubSnapshot, error) {
+	// Only one rebuild runs at a time, so an older snapshot can't replace a
+	// newer one.
+	s.hubsRefreshMu.Lock()
+	defer s.hubsRefreshMu.Unlock()
+
+	return s.buildHubSnapshot()
+}
+
+// buildHubSnapshot builds the hub snapshot from the database and stores it.
+// A hub with connection info we can't decode is left out rather than failing
+// the whole snapshot, so one bad row doesn't hide every other hub. The caller
+// must hold hubsRefreshMu.
+func (s *Server) buildHubSnapshot() (*hubSnapshot, error) {
+	// Read before the hubs are, so changes made while they're read leave the
+	// snapshot stale.
+	gen := atomic.LoadUint64(&s.hubsGen)
+
+	var hubs []*Hub
+
+	err := dbx.Check(s.db.Order("stable_id").Find(&hubs))
+	if err != nil {
+		return nil, err
+	}
+
+	locs, err := hubLocations(s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := hubSnapshot{
+		gen: gen,
+	}
+
+	h := sha256.New()
+
+	for _, hub := range hubs {
+		info, err := hubInfo(hub, locs)
+		if err != nil {
+			s.L.Error("skipping hub with bad connection info", "hub", hub.StableIdULID(), "error", err)
+			if s.m != nil {
+				s.m.IncrCounter([]string{"hub", "bad_connection_info"}, 1)
+			}
+			snap.skipped++
+			continue
+		}
+
+		data, err := info.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		binary.Write(h, binary.BigEndian, uint32(len(data)))
+		h.Write(data)
+
+		snap.hubs = append(snap.hubs, info)
+	}
+
+	binary.Write(h, binary.BigEndian, snap.skipped)
+
+	snap.version = hex.EncodeToString(h.Sum(nil)[:16])
+
+	s.hubsMu.Lock()
+	s.hubs = &snap
+	s.hubsMu.Unlock()
+
+	return &snap, nil
+}
+
+// hubsChanged marks the hub snapshot stale after this server changes the
+// hubs, so the next AllHubs call rebuilds it. It's called on every hub
+// connect and disconnect, so it doesn't rebuild the snapshot itself; when a
+// whole fleet reconnects, that would mean reading every hub once per hub.
+func (s *Server) hubsChanged() {
+	atomic.AddUint64(&s.hubsGen, 1)
+}
@@ -0,0 +1,49 @@
+package control
+
+import (
+	context "context"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// InvalidateAccountRouting recomputes the routing of an account from the
+// database and publishes it, replacing both the copy in S3 and the view the
+// hubs have cached. Changes made through the API do this as they're made,
+// so it's only needed after the account's records were changed some other
+// way, such as by hand.
+func (s *Server) InvalidateAccountRouting(ctx context.Context, req *pb.InvalidateRequest) (*pb.Noop, error) {
+	if !s.checkOpsAllowed(ctx) {
+		return nil, ErrBadAuthentication
+	}
+
+	_, err := accountKey(req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	L := s.requestLogger(ctx).Named("invalidate-account-routing")
+
+	L.Warn("invalidating account routing", "account", req.Account.SpecString())
+
+	// S3 is written first, so hubs that go to it once the broadcast
+	// arrives find the same routing.
+	err = s.updateAccountRouting(ctx, s.db, req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	routes, err := s.accountServices(ctx, s.db, req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	routes.Account = req.Account
+
+	// Resolved routes replace the hubs' view of the account, rather than
+	// being merged into it, so services removed by hand go away too.
+	s.broadcastActivity(&pb.CentralActivity{
+		ResolvedRoutes: []*pb.AccountServices{routes},
+	})
+
+	return &pb.Noop{}, nil
+}
@@ -0,0 +1,159 @@
+package control
+
+import (
+	context "context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/dbx"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/hashicorp/horizon/pkg/token"
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// IssuedToken records what a token created by the server grants, when
+// ServerConfig.RecordIssuedTokens is set. The token itself isn't kept, so the
+// record can't be used to authenticate.
+type IssuedToken struct {
+	ID        []byte `gorm:"primary_key"`
+	AccountID []byte
+	Namespace string
+
+	Role         int32
+	Capabilities pq.StringArray
+	ValidUntil   *time.Time
+
+	CreatedAt time.Time
+}
+
+// recordIssuedToken saves the metadata of tok, which was just minted, if
+// issued tokens are being recorded.
+func (s *Server) recordIssuedToken(tok string) error {
+	if !s.cfg.RecordIssuedTokens {
+		return nil
+	}
+
+	vt, err := token.CheckTokenED25519(tok, s.pubKey)
+	if err != nil {
+		return errors.Wrapf(err, "decoding minted token")
+	}
+
+	body := vt.Body
+
+	rec := IssuedToken{
+		ID:   body.Id.Bytes(),
+		Role: int32(body.Role),
+	}
+
+	if body.Account != nil {
+		rec.AccountID = body.Account.Key()
+		rec.Namespace = body.Account.Namespace
+	}
+
+	for _, cb := range body.Capabilities {
+		rec.Capabilities = append(rec.Capabilities, cb.Capability.String()+"="+cb.Value)
+	}
+
+	if body.ValidUntil != nil {
+		t := body.ValidUntil.Time()
+		rec.ValidUntil = &t
+	}
+
+	err = dbx.Check(s.db.Create(&rec))
+	if err != nil {
+		return errors.Wrapf(err, "recording issued token")
+	}
+
+	return nil
+}
+
+func (rec *IssuedToken) toPB() (*pb.IssuedToken, error) {
+	acc, err := pb.AccountFromKey(rec.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pb.IssuedToken{
+		TokenId:  pb.ULIDFromBytes(rec.ID),
+		Role:     pb.TokenRole(rec.Role),
+		Account:  acc,
+		IssuedAt: pb.NewTimestamp(rec.CreatedAt),
+	}
+
+	for _, str := range rec.Capabilities {
+		name, value := str, ""
+
+		if idx := strings.IndexByte(str, '='); idx != -1 {
+			name, value = str[:idx], str[idx+1:]
+		}
+
+		out.Capabilities = append(out.Capabilities, pb.TokenCapability{
+			Capability: pb.Capability(pb.Capability_value[name]),
+			Value:      value,
+		})
+	}
+
+	if rec.ValidUntil != nil {
+		out.ValidUntil = pb.NewTimestamp(*rec.ValidUntil)
+	}
+
+	return out, nil
+}
+
+const DefaultListIssuedTokensLimit = 100
+
+// ListIssuedTokens returns what the recorded tokens of the accounts in the
+// caller's namespace grant, oldest first. Tokens are only recorded when
+// ServerConfig.RecordIssuedTokens is set.
+func (s *Server) ListIssuedTokens(ctx context.Context, req *pb.ListTokensRequest) (*pb.ListTokensResponse, error) {
+	caller, err := s.checkMgmtAllowed(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, ns := caller.HasCapability(pb.ACCESS)
+	if !ok {
+		return nil, ErrInvalidRequest
+	}
+
+	s.L.Info("list issued tokens request", "namespace", ns)
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultListIssuedTokensLimit
+	}
+
+	q := namespaceScope(s.db, ns)
+
+	if len(req.Marker) > 0 {
+		q = q.Where("id > ?", req.Marker)
+	}
+
+	var recs []*IssuedToken
+
+	err = dbx.Check(q.Limit(limit).Order("id ASC").Find(&recs))
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	var resp pb.ListTokensResponse
+
+	if len(recs) == 0 {
+		return &resp, nil
+	}
+
+	resp.NextMarker = recs[len(recs)-1].ID
+
+	for _, rec := range recs {
+		it, err := rec.toPB()
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Tokens = append(resp.Tokens, it)
+	}
+
+	return &resp, nil
+}
@@ -6,6 +6,9 @@ import (
 	"strings"
 
 	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func FlattenLabels(labels *pb.LabelSet) string {
@@ -61,3 +64,59 @@ func ExplodeLabels(list string) *pb.LabelSet {
 
 	return &set
 }
+
+// checkLabelLink verifies the labels and target of a new label link: a path
+// prefix has to start with /, and a match expression has to parse.
+func checkLabelLink(labels, target *pb.LabelSet) error {
+	if prefix, ok := labels.GetLabel(PathPrefixLabel); ok && !strings.HasPrefix(prefix, "/") {
+		return errors.Wrapf(ErrInvalidRequest, "path prefix must start with /: %s", prefix)
+	}
+
+	if src, ok := target.GetLabel(MatchLabel); ok {
+		_, err := pb.ParseLabelExpr(src)
+		if err != nil {
+			return errors.Wrapf(ErrInvalidRequest, "bad match expression: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// DefaultMaxLabels and DefaultMaxLabelLength limit the labels of services and
+// label links when ServerConfig.MaxLabels and MaxLabelLength aren't set.
+const (
+	DefaultMaxLabels      = 64
+	DefaultMaxLabelLength = 256
+)
+
+// checkLabelLimits rejects labels with more entries than the server allows, or
+// with an entry longer than it allows as stored, which is name=value. This
+// keeps hubs and management clients from bloating the rows and the routing
+// computed from them.
+func (s *Server) checkLabelLimits(what string, labels *pb.LabelSet) error {
+	if labels == nil {
+		return nil
+	}
+
+	max := s.cfg.MaxLabels
+	if max <= 0 {
+		max = DefaultMaxLabels
+	}
+
+	if len(labels.Labels) > max {
+		return status.Errorf(codes.InvalidArgument, "too many %s: %d, limit is %d", what, len(labels.Labels), max)
+	}
+
+	maxLen := s.cfg.MaxLabelLength
+	if maxLen <= 0 {
+		maxLen = DefaultMaxLabelLength
+	}
+
+	for _, lbl := range labels.Labels {
+		if sz := len(lbl.Name) + 1 + len(lbl.Value); sz > maxLen {
+			return status.Errorf(codes.InvalidArgument, "%s label %q is too long: %d, limit is %d", what, lbl.Name, sz, maxLen)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package control
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrLockLost = errors.New("lock lease lost")
+
+const (
+	// How long a lock in dynamodb is held without a heartbeat, and how often
+	// the heartbeats are sent. The lease outlives a couple of missed heartbeats.
+	lockLeaseDuration   = 20 * time.Second
+	lockHeartbeatPeriod = 5 * time.Second
+
+	// How often a held lock checks that its lease is still current.
+	lockCheckPeriod = time.Second
+
+	// The longest to wait between attempts to acquire a lock, however many
+	// have failed.
+	lockMaxRetryDelay = 5 * time.Second
+)
+
+// How long to keep trying to acquire a lock that's held elsewhere, and how
+// long to wait after the first failed attempt, when ServerConfig.LockWait and
+// LockRetryDelay aren't set.
+const (
+	DefaultLockWait       = 30 * time.Second
+	DefaultLockRetryDelay = 250 * time.Millisecond
+)
+
+// lockBackoff retries acquiring a lock, doubling the delay between attempts up
+// to a max and adding jitter so that contending servers don't retry in step.
+type lockBackoff struct {
+	delay    time.Duration
+	maxDelay time.Duration
+	wait     time.Duration
+}
+
+func (s *Server) lockBackoff() lockBackoff {
+	b := lockBackoff{
+		delay:    s.cfg.LockRetryDelay,
+		maxDelay: lockMaxRetryDelay,
+		wait:     s.cfg.LockWait,
+	}
+
+	if b.delay <= 0 {
+		b.delay = DefaultLockRetryDelay
+	}
+
+	if b.wait <= 0 {
+		b.wait = DefaultLockWait
+	}
+
+	return b
+}
+
+// retry calls try until it reports it's done or returns an error, backing off
+// between calls. If it's not done within the wait, retry returns an
+// Unavailable error so the caller can try again later.
+func (b lockBackoff) retry(ctx context.Context, name string, try func(attempt int) (bool, error)) error {
+	deadline := time.Now().Add(b.wait)
+	delay := b.delay
+
+	for attempt := 0; ; attempt++ {
+		done, err := try(attempt)
+		if err != nil || done {
+			return err
+		}
+
+		left := time.Until(deadline)
+		if left <= 0 {
+			return status.Errorf(codes.Unavailable, "unable to acquire lock %s within %s", name, b.wait)
+		}
+
+		// Wait somewhere between half the delay and all of it.
+		sleep := delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+		if sleep > left {
+			sleep = left
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(sleep):
+		}
+
+		delay *= 2
+		if delay > b.maxDelay {
+			delay = b.maxDelay
+		}
+	}
+}
+
+// lease is the part of a dynamolock.Lock used to see if it's still held.
+type lease interface {
+	IsExpired() bool
+}
+
+// heldLock watches the lease of a lock while an operation runs under it. If
+// the lease can't be maintained, because the heartbeats aren't getting
+// through, another worker could acquire the lock, so the operation's context
+// is canceled and Check reports ErrLockLost.
+type heldLock struct {
+	lease  lease
+	ctx    context.Context
+	cancel context.CancelFunc
+	lost   chan struct{}
+}
+
+func holdLock(ctx context.Context, l lease, period time.Duration) *heldLock {
+	ctx, cancel := context.WithCancel(ctx)
+
+	h := &heldLock{
+		lease:  l,
+		ctx:    ctx,
+		cancel: cancel,
+		lost:   make(chan struct{}),
+	}
+
+	go h.watch(period)
+
+	return h
+}
+
+func (h *heldLock) watch(period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.ctx.Done():
+			return
+		case <-ticker.C:
+			if h.lease.IsExpired() {
+				close(h.lost)
+				h.cancel()
+				return
+			}
+		}
+	}
+}
+
+// Context returns a context that is canceled if the lease is lost.
+func (h *heldLock) Context() context.Context {
+	return h.ctx
+}
+
+// Check returns ErrLockLost if the lease is no longer held. It should be
+// called before committing anything the lock protects.
+func (h *heldLock) Check() error {
+	select {
+	case <-h.lost:
+		return ErrLockLost
+	default:
+	}
+
+	if h.lease.IsExpired() {
+		return ErrLockLost
+	}
+
+	return h.ctx.Err()
+}
+
+// Release stops watching the lease. It doesn't release the lock itself.
+func (h *heldLock) Release() {
+	h.cancel()
+}
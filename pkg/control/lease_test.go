@@ -0,0 +1,210 @@
+package control
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeLease struct {
+	expired int32
+}
+
+func (f *fakeLease) IsExpired() bool {
+	return atomic.LoadInt32(&f.expired) == 1
+}
+
+func (f *fakeLease) expire() {
+	atomic.StoreInt32(&f.expired, 1)
+}
+
+func TestHeldLock(t *testing.T) {
+	t.Run("stays valid while the lease is held", func(t *testing.T) {
+		var l fakeLease
+
+		held := holdLock(context.Background(), &l, 10*time.Millisecond)
+		defer held.Release()
+
+		time.Sleep(50 * time.Millisecond)
+
+		assert.NoError(t, held.Check())
+		assert.NoError(t, held.Context().Err())
+	})
+
+	t.Run("aborts the operation when the lease can't heartbeat", func(t *testing.T) {
+		var l fakeLease
+
+		held := holdLock(context.Background(), &l, 10*time.Millisecond)
+		defer held.Release()
+
+		committed := make(chan struct{})
+		done := make(chan error, 1)
+
+		// A long operation that would commit once it finishes, checking the
+		// lease first like updateAccountRouting does.
+		go func() {
+			select {
+			case <-held.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+
+			err := held.Check()
+			if err == nil {
+				close(committed)
+			}
+
+			done <- err
+		}()
+
+		// The heartbeats stop getting through and the lease runs out.
+		l.expire()
+
+		select {
+		case err := <-done:
+			assert.Equal(t, ErrLockLost, err)
+		case <-time.After(time.Second):
+			t.Fatal("operation was not aborted")
+		}
+
+		select {
+		case <-committed:
+			t.Fatal("operation committed under a lost lock")
+		default:
+		}
+
+		require.Error(t, held.Context().Err())
+	})
+
+	t.Run("reports an expired lease before the watcher notices", func(t *testing.T) {
+		var l fakeLease
+
+		held := holdLock(context.Background(), &l, time.Hour)
+		defer held.Release()
+
+		l.expire()
+
+		assert.Equal(t, ErrLockLost, held.Check())
+	})
+}
+
+func TestLockBackoff(t *testing.T) {
+	// A lock two servers contend for, where acquiring fails if it's held.
+	var holder int32
+
+	acquire := func(id int32) func(int) (bool, error) {
+		return func(int) (bool, error) {
+			return atomic.CompareAndSwapInt32(&holder, 0, id), nil
+		}
+	}
+
+	release := func(id int32) {
+		atomic.CompareAndSwapInt32(&holder, id, 0)
+	}
+
+	contend := func(t *testing.T, b lockBackoff, hold time.Duration) (int, error) {
+		require.NoError(t, b.retry(context.Background(), "test", acquire(1)))
+
+		var (
+			attempts int
+			err      error
+			done     = make(chan struct{})
+		)
+
+		go func() {
+			defer close(done)
+
+			err = b.retry(context.Background(), "test", func(attempt int) (bool, error) {
+				attempts = attempt + 1
+				return acquire(2)(attempt)
+			})
+		}()
+
+		time.Sleep(hold)
+		release(1)
+
+		<-done
+
+		return attempts, err
+	}
+
+	t.Run("acquires the lock once the holder releases it", func(t *testing.T) {
+		defer release(2)
+
+		b := lockBackoff{
+			delay:    5 * time.Millisecond,
+			maxDelay: 20 * time.Millisecond,
+			wait:     5 * time.Second,
+		}
+
+		attempts, err := contend(t, b, 100*time.Millisecond)
+		require.NoError(t, err)
+
+		assert.True(t, attempts > 1, "attempts: %d", attempts)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&holder))
+	})
+
+	t.Run("gives up with unavailable once the wait runs out", func(t *testing.T) {
+		b := lockBackoff{
+			delay:    5 * time.Millisecond,
+			maxDelay: 20 * time.Millisecond,
+			wait:     50 * time.Millisecond,
+		}
+
+		start := time.Now()
+
+		attempts, err := contend(t, b, 200*time.Millisecond)
+		require.Error(t, err)
+
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.True(t, attempts > 1, "attempts: %d", attempts)
+		assert.True(t, time.Since(start) < time.Second)
+	})
+
+	t.Run("backs off exponentially with jitter", func(t *testing.T) {
+		b := lockBackoff{
+			delay:    10 * time.Millisecond,
+			maxDelay: 40 * time.Millisecond,
+			wait:     300 * time.Millisecond,
+		}
+
+		var at []time.Time
+
+		err := b.retry(context.Background(), "test", func(int) (bool, error) {
+			at = append(at, time.Now())
+			return false, nil
+		})
+		require.Error(t, err)
+
+		require.True(t, len(at) > 4, "attempts: %d", len(at))
+
+		// Each wait is between half the delay and all of it, and the delay
+		// doubles up to the max.
+		assert.True(t, at[1].Sub(at[0]) >= 5*time.Millisecond)
+		assert.True(t, at[2].Sub(at[1]) >= 10*time.Millisecond)
+		assert.True(t, at[3].Sub(at[2]) >= 20*time.Millisecond)
+		assert.True(t, at[4].Sub(at[3]) >= 20*time.Millisecond)
+	})
+
+	t.Run("stops when the context is canceled", func(t *testing.T) {
+		b := lockBackoff{
+			delay:    10 * time.Millisecond,
+			maxDelay: 10 * time.Millisecond,
+			wait:     time.Minute,
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := b.retry(ctx, "test", func(int) (bool, error) {
+			return false, nil
+		})
+
+		assert.Equal(t, context.DeadlineExceeded, err)
+	})
+}
@@ -0,0 +1,159 @@
+package control
+
+import (
+	"sort"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/armon/go-metrics/prometheus"
+	"github.com/hashicorp/go-hclog"
+)
+
+// The service name metrics are prefixed with when ServerConfig.MetricsPrefix
+// isn't set.
+const DefaultMetricsPrefix = "control"
+
+// How long each interval of the in-memory sink covers and how long it keeps
+// them, when ServerConfig.InmemInterval and InmemRetention aren't set.
+const (
+	DefaultInmemInterval  = time.Minute
+	DefaultInmemRetention = time.Hour
+)
+
+// inmemSink returns the in-memory sink for the server.
+func (cfg *ServerConfig) inmemSink() *metrics.InmemSink {
+	interval := cfg.InmemInterval
+	if interval <= 0 {
+		interval = DefaultInmemInterval
+	}
+
+	retention := cfg.InmemRetention
+	if retention <= 0 {
+		retention = DefaultInmemRetention
+	}
+
+	return metrics.NewInmemSink(interval, retention)
+}
+
+// metricsConfig returns the go-metrics configuration for the server.
+func (cfg *ServerConfig) metricsConfig() *metrics.Config {
+	prefix := cfg.MetricsPrefix
+	if prefix == "" {
+		prefix = DefaultMetricsPrefix
+	}
+
+	mcfg := metrics.DefaultConfig(prefix)
+	mcfg.EnableHostname = false
+	mcfg.EnableRuntimeMetrics = false
+
+	return mcfg
+}
+
+// newPrometheusSink creates the sink the prometheus endpoint is served from.
+var newPrometheusSink = func() (metrics.MetricSink, error) {
+	return prometheus.NewPrometheusSinkFrom(prometheus.PrometheusOpts{
+		Expiration: time.Hour,
+	})
+}
+
+// setupMetrics creates the configured sinks and the metrics that fan out to
+// them. It returns the in-memory sink, or nil if it's disabled, and the sinks
+// to flush and close when the server is.
+func (cfg *ServerConfig) setupMetrics(L hclog.Logger) (*metrics.Metrics, metrics.MetricSink, []metrics.MetricSink, error) {
+	var fanout metrics.FanoutSink
+
+	if !cfg.DisablePrometheus {
+		psink, err := newPrometheusSink()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		fanout = append(fanout, psink)
+	}
+
+	// Left as a nil interface when disabled, so it can be checked for.
+	var msink metrics.MetricSink
+
+	if !cfg.DisableInmemSink {
+		isink := cfg.inmemSink()
+		fanout = append(fanout, isink)
+		msink = isink
+	}
+
+	var sinks []metrics.MetricSink
+
+	if cfg.DataDogAddr != "" {
+		L.Info("configured to send stats to datadog")
+		dsink, err := newDogStatsdSink(cfg.DataDogAddr)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		fanout = append(fanout, dsink)
+		sinks = append(sinks, dsink)
+	}
+
+	fanout = append(fanout, cfg.MetricSinks...)
+	sinks = append(sinks, cfg.MetricSinks...)
+
+	me, err := metrics.New(cfg.metricsConfig(), withGlobalLabels(fanout, cfg.MetricsLabels))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return me, msink, sinks, nil
+}
+
+// withGlobalLabels wraps sink so that every metric sent to it has labels
+// added, which lets metrics from several clusters share a backend.
+func withGlobalLabels(sink metrics.MetricSink, labels map[string]string) metrics.MetricSink {
+	if len(labels) == 0 {
+		return sink
+	}
+
+	ls := &labelSink{MetricSink: sink}
+
+	for name, value := range labels {
+		ls.labels = append(ls.labels, metrics.Label{Name: name, Value: value})
+	}
+
+	sort.Slice(ls.labels, func(i, j int) bool {
+		return ls.labels[i].Name < ls.labels[j].Name
+	})
+
+	return ls
+}
+
+type labelSink struct {
+	metrics.MetricSink
+	labels []metrics.Label
+}
+
+func (l *labelSink) with(labels []metrics.Label) []metrics.Label {
+	out := make([]metrics.Label, 0, len(labels)+len(l.labels))
+	out = append(out, labels...)
+	return append(out, l.labels...)
+}
+
+func (l *labelSink) SetGauge(key []string, val float32) {
+	l.MetricSink.SetGaugeWithLabels(key, val, l.labels)
+}
+
+func (l *labelSink) SetGaugeWithLabels(key []string, val float32, labels []metrics.Label) {
+	l.MetricSink.SetGaugeWithLabels(key, val, l.with(labels))
+}
+
+func (l *labelSink) IncrCounter(key []string, val float32) {
+	l.MetricSink.IncrCounterWithLabels(key, val, l.labels)
+}
+
+func (l *labelSink) IncrCounterWithLabels(key []string, val float32, labels []metrics.Label) {
+	l.MetricSink.IncrCounterWithLabels(key, val, l.with(labels))
+}
+
+func (l *labelSink) AddSample(key []string, val float32) {
+	l.MetricSink.AddSampleWithLabels(key, val, l.labels)
+}
+
+func (l *labelSink) AddSampleWithLabels(key []string, val float32, labels []metrics.Label) {
+	l.MetricSink.AddSampleWithLabels(key, val, l.with(labels))
+}
@@ -0,0 +1,49 @@
+package control
+
+import (
+	"testing"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsConfig(t *testing.T) {
+	emit := func(t *testing.T, cfg ServerConfig) *metrics.IntervalMetrics {
+		sink := metrics.NewInmemSink(time.Minute, time.Hour)
+
+		m, err := metrics.New(cfg.metricsConfig(), withGlobalLabels(sink, cfg.MetricsLabels))
+		require.NoError(t, err)
+
+		m.IncrCounter([]string{"hubs", "connected"}, 1)
+		m.SetGaugeWithLabels([]string{"routing", "services"}, 3, []metrics.Label{
+			{Name: "account", Value: "a"},
+		})
+
+		data := sink.Data()
+		require.Len(t, data, 1)
+
+		return data[0]
+	}
+
+	t.Run("prefixes metrics with control by default", func(t *testing.T) {
+		data := emit(t, ServerConfig{})
+
+		assert.Contains(t, data.Counters, "control.hubs.connected")
+		assert.Contains(t, data.Gauges, "control.routing.services;account=a")
+	})
+
+	t.Run("uses the configured prefix and labels", func(t *testing.T) {
+		data := emit(t, ServerConfig{
+			MetricsPrefix: "hzn-east",
+			MetricsLabels: map[string]string{
+				"region":  "us-east-1",
+				"cluster": "east",
+			},
+		})
+
+		assert.Contains(t, data.Counters, "hzn-east.hubs.connected;cluster=east;region=us-east-1")
+		assert.Contains(t, data.Gauges, "hzn-east.routing.services;account=a;cluster=east;region=us-east-1")
+	})
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS hub_location_labels;
+DROP INDEX IF EXISTS hub_location_stable_id;
+DROP TABLE IF EXISTS hub_locations;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS hub_locations (
+  id serial PRIMARY KEY,
+  stable_id bytea NOT NULL REFERENCES hubs (stable_id) ON DELETE CASCADE,
+  name text NOT NULL DEFAULT '',
+  addresses text[] NOT NULL DEFAULT '{}',
+  labels text[] NOT NULL DEFAULT '{}',
+  created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS hub_location_stable_id ON hub_locations (stable_id);
+CREATE INDEX IF NOT EXISTS hub_location_labels ON hub_locations USING gin (labels);
@@ -0,0 +1 @@
+ALTER TABLE accounts ADD COLUMN suspended boolean NOT NULL DEFAULT false;
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS activity_cursors (
+  name text PRIMARY KEY,
+  last_entry bigint NOT NULL DEFAULT 0,
+  updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
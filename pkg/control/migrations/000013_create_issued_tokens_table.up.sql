@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS issued_tokens (
+  id bytea PRIMARY KEY,
+  account_id bytea NOT NULL,
+  namespace text NOT NULL,
+  role integer NOT NULL,
+  capabilities text[],
+  valid_until timestamp with time zone,
+
+  created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS issued_tokens_namespace ON issued_tokens (namespace);
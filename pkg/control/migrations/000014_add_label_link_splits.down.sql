@@ -0,0 +1 @@
+ALTER TABLE label_links DROP COLUMN splits;
@@ -0,0 +1 @@
+ALTER TABLE label_links ADD COLUMN splits bytea NULL;
@@ -0,0 +1,304 @@
+package control
+
+import (
+	context "context"
+	"sort"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// Traffic quotas are tallied here from the flow records the hubs send, and
+// enforced by the hubs: when an account or hub goes over its quota, all the
+// connected hubs are told so they can turn away its new streams, and told
+// again once it's back under. Each server only tallies the flow records of
+// the hubs connected to it, so with several servers an account's usage is
+// split between them.
+
+var (
+	// The window quotas are measured over when ServerConfig.QuotaWindow
+	// isn't set.
+	DefaultQuotaWindow = time.Hour
+
+	// How often usage is rechecked, so that accounts and hubs that are no
+	// longer sending traffic are noticed dropping back under their quota.
+	QuotaCheckInterval = time.Minute
+)
+
+// How many slots the quota window is divided into. Usage expires from the
+// window a slot at a time.
+const quotaSlots = 12
+
+// Quota limits the traffic of an account or hub over the quota window. A zero
+// limit means no limit.
+type Quota struct {
+	Bytes    int64
+	Messages int64
+}
+
+func (q Quota) enabled() bool {
+	return q.Bytes > 0 || q.Messages > 0
+}
+
+func (q Quota) exceededBy(bytes, messages int64) bool {
+	return (q.Bytes > 0 && bytes > q.Bytes) ||
+		(q.Messages > 0 && messages > q.Messages)
+}
+
+type quotaSlot struct {
+	n        int64
+	bytes    int64
+	messages int64
+}
+
+// quotaUsage is the traffic of one account or hub over the quota window.
+type quotaUsage struct {
+	account *pb.Account
+	hub     *pb.ULID
+	quota   Quota
+
+	slots    [quotaSlots]quotaSlot
+	exceeded bool
+}
+
+func (u *quotaUsage) add(n, bytes, messages int64) {
+	slot := &u.slots[n%quotaSlots]
+
+	if slot.n != n {
+		*slot = quotaSlot{n: n}
+	}
+
+	slot.bytes += bytes
+	slot.messages += messages
+}
+
+func (u *quotaUsage) total(n int64) (bytes, messages int64) {
+	for _, slot := range u.slots {
+		if slot.n > n-quotaSlots && slot.n <= n {
+			bytes += slot.bytes
+			messages += slot.messages
+		}
+	}
+
+	return bytes, messages
+}
+
+func (u *quotaUsage) status() *pb.QuotaStatus {
+	return &pb.QuotaStatus{
+		Account:  u.account,
+		Hub:      u.hub,
+		Exceeded: u.exceeded,
+	}
+}
+
+func (s *Server) quotasEnabled() bool {
+	return s.cfg.AccountQuota.enabled() ||
+		len(s.cfg.AccountQuotas) > 0 ||
+		s.cfg.HubQuota.enabled()
+}
+
+// quotaSlot returns the number of the slot of the quota window that t is in.
+func (s *Server) quotaSlot(t time.Time) int64 {
+	window := s.cfg.QuotaWindow
+	if window <= 0 {
+		window = DefaultQuotaWindow
+	}
+
+	return t.UnixNano() / int64(window/quotaSlots)
+}
+
+// accountQuota returns the quota of account, which is its own from
+// AccountQuotas if it has one, otherwise AccountQuota.
+func (s *Server) accountQuota(account *pb.Account) Quota {
+	if q, ok := s.cfg.AccountQuotas[account.SpecString()]; ok {
+		return q
+	}
+
+	return s.cfg.AccountQuota
+}
+
+// trackQuota adds the traffic of a flow to the usage of its account and hub,
+// and broadcasts the status of any that it puts over their quota.
+func (s *Server) trackQuota(now time.Time, flows []*pb.FlowStream) {
+	n := s.quotaSlot(now)
+
+	var changed []*pb.QuotaStatus
+
+	s.quotaMu.Lock()
+
+	if s.quotaUsage == nil {
+		s.quotaUsage = make(map[string]*quotaUsage)
+	}
+
+	for _, fs := range flows {
+		if fs.Account != nil {
+			if q := s.accountQuota(fs.Account); q.enabled() {
+				key := "account:" + fs.Account.SpecString()
+
+				u, ok := s.quotaUsage[key]
+				if !ok {
+					u = &quotaUsage{account: fs.Account, quota: q}
+					s.quotaUsage[key] = u
+				}
+
+				if st := s.addQuotaUsage(u, n, fs); st != nil {
+					changed = append(changed, st)
+				}
+			}
+		}
+
+		if fs.HubId != nil && s.cfg.HubQuota.enabled() {
+			key := "hub:" + fs.HubId.SpecString()
+
+			u, ok := s.quotaUsage[key]
+			if !ok {
+				u = &quotaUsage{hub: fs.HubId, quota: s.cfg.HubQuota}
+				s.quotaUsage[key] = u
+			}
+
+			if st := s.addQuotaUsage(u, n, fs); st != nil {
+				changed = append(changed, st)
+			}
+		}
+	}
+
+	s.quotaMu.Unlock()
+
+	if len(changed) > 0 {
+		s.broadcastActivity(&pb.CentralActivity{Quotas: changed})
+	}
+}
+
+// addQuotaUsage adds the traffic of fs to u, returning the status to
+// broadcast if it put u over its quota.
+func (s *Server) addQuotaUsage(u *quotaUsage, n int64, fs *pb.FlowStream) *pb.QuotaStatus {
+	u.add(n, fs.NumBytes, fs.NumMessages)
+
+	if u.exceeded {
+		return nil
+	}
+
+	bytes, messages := u.total(n)
+	if !u.quota.exceededBy(bytes, messages) {
+		return nil
+	}
+
+	u.exceeded = true
+
+	s.L.Warn("quota exceeded",
+		"account", u.account,
+		"hub", u.hub,
+		"bytes", bytes,
+		"messages", messages,
+	)
+
+	if s.m != nil {
+		s.m.IncrCounterWithLabels([]string{"quota", "exceeded"}, 1, quotaLabels(u))
+	}
+
+	st := u.status()
+	st.Bytes = bytes
+	st.Messages = messages
+
+	return st
+}
+
+func quotaLabels(u *quotaUsage) []metrics.Label {
+	if u.account != nil {
+		return []metrics.Label{
+			{
+				Name:  "account",
+				Value: u.account.SpecString(),
+			},
+		}
+	}
+
+	return []metrics.Label{
+		{
+			Name:  "hub",
+			Value: u.hub.SpecString(),
+		},
+	}
+}
+
+// checkQuotas broadcasts the status of the accounts and hubs that have
+// dropped back under their quota since they exceeded it, and forgets those
+// with no usage left in the window.
+func (s *Server) checkQuotas(now time.Time) {
+	n := s.quotaSlot(now)
+
+	var changed []*pb.QuotaStatus
+
+	s.quotaMu.Lock()
+
+	for key, u := range s.quotaUsage {
+		bytes, messages := u.total(n)
+
+		if u.exceeded && !u.quota.exceededBy(bytes, messages) {
+			u.exceeded = false
+
+			s.L.Info("back under quota", "account", u.account, "hub", u.hub)
+
+			st := u.status()
+			st.Bytes = bytes
+			st.Messages = messages
+
+			changed = append(changed, st)
+		}
+
+		if !u.exceeded && bytes == 0 && messages == 0 {
+			delete(s.quotaUsage, key)
+		}
+	}
+
+	s.quotaMu.Unlock()
+
+	if len(changed) > 0 {
+		s.broadcastActivity(&pb.CentralActivity{Quotas: changed})
+	}
+}
+
+// exceededQuotas returns the activity that tells a newly connected hub which
+// accounts and hubs are over their quota, or nil if none are.
+func (s *Server) exceededQuotas() *pb.CentralActivity {
+	s.quotaMu.Lock()
+	defer s.quotaMu.Unlock()
+
+	var keys []string
+
+	for key, u := range s.quotaUsage {
+		if u.exceeded {
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	sort.Strings(keys)
+
+	var act pb.CentralActivity
+
+	for _, key := range keys {
+		act.Quotas = append(act.Quotas, s.quotaUsage[key].status())
+	}
+
+	return &act
+}
+
+// runQuotaChecker periodically calls checkQuotas until ctx is canceled.
+func (s *Server) runQuotaChecker(ctx context.Context) {
+	ticker := time.NewTicker(QuotaCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.checkQuotas(now)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package control
+
+import (
+	context "context"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDHeader is the metadata key of the id that ties together the log
+// lines of one rpc. A client can set it to use its own id, such as one it
+// already logs. Either way, the id used is sent back in the response header.
+const RequestIDHeader = "x-request-id"
+
+// maxRequestIDLength bounds ids set by clients, so they can't bloat every log
+// line of the request.
+const maxRequestIDLength = 128
+
+type requestLoggerKey struct{}
+
+// withRequestID returns ctx with a logger that includes the request's id,
+// along with the id itself. The id comes from the request's metadata if it
+// has one, otherwise a new one is generated.
+func (s *Server) withRequestID(ctx context.Context, method string) (context.Context, string) {
+	var id string
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get(RequestIDHeader); len(vals) > 0 && len(vals[0]) <= maxRequestIDLength {
+			id = vals[0]
+		}
+	}
+
+	if id == "" {
+		id = s.newID().SpecString()
+	}
+
+	L := s.L.With("request_id", id, "method", method)
+
+	return context.WithValue(ctx, requestLoggerKey{}, L), id
+}
+
+// requestLogger returns the logger for the rpc ctx is from, which includes its
+// request id, or the server's logger if it isn't from one.
+func (s *Server) requestLogger(ctx context.Context) hclog.Logger {
+	if L, ok := ctx.Value(requestLoggerKey{}).(hclog.Logger); ok {
+		return L
+	}
+
+	return s.L
+}
+
+// unaryRequestID sets up the request id of a unary rpc and sends it back in
+// the response header.
+func (s *Server) unaryRequestID(ctx context.Context, method string) context.Context {
+	ctx, id := s.withRequestID(ctx, method)
+
+	// This only fails when there is no rpc to send it back on, such as when
+	// the interceptor is called directly.
+	grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, id))
+
+	s.requestLogger(ctx).Trace("handling request")
+
+	return ctx
+}
+
+// streamRequestID is the streaming version of unaryRequestID.
+func (s *Server) streamRequestID(ss grpc.ServerStream, method string) context.Context {
+	ctx, id := s.withRequestID(ss.Context(), method)
+
+	// This only fails once the header has been sent, which it can't have
+	// been before the handler runs.
+	ss.SetHeader(metadata.Pairs(RequestIDHeader, id))
+
+	s.requestLogger(ctx).Trace("handling request")
+
+	return ctx
+}
@@ -0,0 +1,89 @@
+package control
+
+import (
+	context "context"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ResolveDebug reports what the web frontend would do with a request for a
+// hostname, without connecting to anything: the label link it resolves to and
+// the services its target is routed to. The label links and services are read
+// from the database rather than the copies hubs route from, so this also shows
+// what hubs will do once they've caught up.
+func (s *Server) ResolveDebug(ctx context.Context, req *pb.ResolveDebugRequest) (*pb.ResolveDebugResponse, error) {
+	if !s.checkOpsAllowed(ctx) {
+		return nil, ErrBadAuthentication
+	}
+
+	if req.Hostname == "" {
+		return nil, status.Error(codes.InvalidArgument, "hostname is required")
+	}
+
+	links, err := s.allLabelLinks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	label := &pb.LabelSet{
+		Labels: []*pb.Label{
+			{
+				Name:  ":hostname",
+				Value: req.Hostname,
+			},
+		},
+	}
+
+	var resp pb.ResolveDebugResponse
+
+	ll := findPathLabelLink(label, req.Path, links.LabelLinks)
+	if ll != nil {
+		resp.PathPrefix, _ = ll.Labels.GetLabel(PathPrefixLabel)
+	} else {
+		ll = findLabelLink(label, links.LabelLinks)
+	}
+
+	if ll == nil || ll.Target == nil {
+		return nil, status.Errorf(codes.NotFound, "no label link for hostname: %s", req.Hostname)
+	}
+
+	resp.Account = ll.Account
+	resp.Target = ll.Target
+
+	if req.DeploymentId != "" {
+		resp.Target = resp.Target.Add(":deployment", req.DeploymentId)
+	}
+
+	services, err := s.accountServices(ctx, s.db, ll.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	if services.Suspended {
+		resp.Suspended = true
+		return &resp, nil
+	}
+
+	matches := targetMatcher(s.L, resp.Target)
+
+	var rc routeCalculator
+
+	for _, service := range services.Services {
+		if matches(service.Labels) {
+			rc.add(service)
+		}
+	}
+
+	calc := rc.result()
+
+	if len(calc.Best) > 0 {
+		resp.NewestDeployment = true
+		resp.Services = calc.Best
+	} else {
+		resp.Services = calc.All
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,107 @@
+package control
+
+import (
+	context "context"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/horizon/pkg/dbx"
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+var (
+	// How often the routing state gauges are updated when
+	// ServerConfig.RoutingStatsInterval isn't set.
+	DefaultRoutingStatsInterval = 5 * time.Minute
+
+	// How many of the accounts with the most rows get a gauge of their own.
+	// The rest are added together under the "other" account, which keeps the
+	// cardinality bounded no matter how many accounts there are.
+	RoutingStatsTopAccounts = 10
+)
+
+// runRoutingStatsCollector periodically updates the routing state gauges
+// until ctx is canceled.
+func (s *Server) runRoutingStatsCollector(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.collectRoutingStats()
+			if err != nil {
+				s.L.Error("error collecting routing stats", "error", err)
+			}
+		}
+	}
+}
+
+// collectRoutingStats sets gauges of the total number of label links and
+// services, and of how many of them belong to each of the accounts with the
+// most.
+func (s *Server) collectRoutingStats() error {
+	err := s.collectAccountRows("label_links", &LabelLink{})
+	if err != nil {
+		return err
+	}
+
+	return s.collectAccountRows("services", &Service{})
+}
+
+type accountRows struct {
+	AccountId []byte
+	Total     int
+}
+
+func (s *Server) collectAccountRows(name string, model interface{}) error {
+	var total int
+
+	err := dbx.Check(s.db.Model(model).Count(&total))
+	if err != nil {
+		return err
+	}
+
+	var top []accountRows
+
+	err = dbx.Check(s.db.Model(model).
+		Select("account_id, count(*) AS total").
+		Group("account_id").
+		Order("total DESC").
+		Limit(RoutingStatsTopAccounts).
+		Scan(&top))
+	if err != nil {
+		return err
+	}
+
+	s.m.SetGauge([]string{"routing", name}, float32(total))
+
+	other := total
+
+	for _, ar := range top {
+		other -= ar.Total
+
+		account, err := pb.AccountFromKey(ar.AccountId)
+		if err != nil {
+			return err
+		}
+
+		s.m.SetGaugeWithLabels([]string{"routing", name, "by_account"}, float32(ar.Total), []metrics.Label{
+			{
+				Name:  "account",
+				Value: account.StringKey(),
+			},
+		})
+	}
+
+	s.m.SetGaugeWithLabels([]string{"routing", name, "by_account"}, float32(other), []metrics.Label{
+		{
+			Name:  "account",
+			Value: "other",
+		},
+	})
+
+	return nil
+}
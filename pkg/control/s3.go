@@ -7,7 +7,6 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	fmt "fmt"
-	"time"
 
 	"cirello.io/dynamolock"
 	"github.com/aws/aws-sdk-go/aws"
@@ -20,8 +19,31 @@ import (
 )
 
 func (s *Server) calculateAccountRouting(ctx context.Context, db *gorm.DB, account *pb.Account) ([]byte, error) {
+	accountServices, err := s.accountServices(ctx, db, account)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := accountServices.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return zstdCompress(data)
+}
+
+func (s *Server) accountServices(ctx context.Context, db *gorm.DB, account *pb.Account) (*pb.AccountServices, error) {
 	key := account.Key()
 
+	suspended, err := accountSuspended(db, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if suspended {
+		return &pb.AccountServices{Suspended: true}, nil
+	}
+
 	var lastId int64
 
 	services := make([]*Service, 0, 100)
@@ -72,12 +94,12 @@ func (s *Server) calculateAccountRouting(ctx context.Context, db *gorm.DB, accou
 		services = services[:0]
 	}
 
-	data, err := accountServices.Marshal()
+	err = s.annotateRoutes(db, accountServices.Services)
 	if err != nil {
 		return nil, err
 	}
 
-	return zstdCompress(data)
+	return &accountServices, nil
 }
 
 func (s *Server) updateAccountRouting(ctx context.Context, db *gorm.DB, account *pb.Account) error {
@@ -98,8 +120,29 @@ func (s *Server) updateAccountRouting(ctx context.Context, db *gorm.DB, account
 
 	strMD5 := base64.StdEncoding.EncodeToString(sum)
 
-	for {
-		lock, err := s.lockMgr.AcquireLock(lockKey,
+	var (
+		lock     *dynamolock.Lock
+		upToDate bool
+	)
+
+	err = s.lockBackoff().retry(ctx, lockKey, func(attempt int) (bool, error) {
+		if attempt > 0 {
+			// Whoever held the lock may have changed the routing, so start
+			// from the current records.
+			data, err := s.calculateAccountRouting(ctx, db, account)
+			if err != nil {
+				return false, err
+			}
+
+			h := md5.New()
+			h.Write(data)
+
+			outData = data
+			sum = h.Sum(nil)
+			strMD5 = base64.StdEncoding.EncodeToString(sum)
+		}
+
+		l, err := s.lockMgr.AcquireLock(lockKey,
 			dynamolock.WithAdditionalAttributes(
 				map[string]*dynamodb.AttributeValue{
 					"md5": {S: &strMD5},
@@ -108,38 +151,40 @@ func (s *Server) updateAccountRouting(ctx context.Context, db *gorm.DB, account
 		)
 
 		if err == nil {
-			defer lock.Close()
-			break
+			lock = l
+			return true, nil
 		}
 
 		info, err := s.lockMgr.Get(lockKey)
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		attrs := info.AdditionalAttributes()
 		if val, ok := attrs["md5"]; ok {
 			if val.S != nil && *val.S == strMD5 {
 				// Ok, someone else got all the records, PEACE OUT.
-				return nil
+				upToDate = true
+				return true, nil
 			}
 		}
 
-		time.Sleep(5 * time.Second)
+		return false, nil
+	})
 
-		outData, err := s.calculateAccountRouting(ctx, db, account)
-		if err != nil {
-			return err
-		}
-
-		h := md5.New()
-		h.Write(outData)
-		sum := h.Sum(nil)
+	if err != nil {
+		return err
+	}
 
-		strMD5 = base64.StdEncoding.EncodeToString(sum)
-		continue
+	if upToDate {
+		return nil
 	}
 
+	defer lock.Close()
+
+	held := holdLock(ctx, lock, lockCheckPeriod)
+	defer held.Release()
+
 	s3obj := s3.New(s.awsSess)
 
 	inputEtag := base64.StdEncoding.EncodeToString(sum)
@@ -159,8 +204,19 @@ func (s *Server) updateAccountRouting(ctx context.Context, db *gorm.DB, account
 		putIn.ServerSideEncryption = aws.String("aws:kms")
 	}
 
-	putOut, err := s3obj.PutObject(putIn)
+	// If the lease on the lock was lost, another worker may be updating the
+	// same account, so don't race it with what could be stale routing.
+	err = held.Check()
 	if err != nil {
+		return errors.Wrapf(err, "updating routing for account %s", account)
+	}
+
+	putOut, err := s3obj.PutObjectWithContext(held.Context(), putIn)
+	if err != nil {
+		if held.Check() == ErrLockLost {
+			return errors.Wrapf(ErrLockLost, "updating routing for account %s", account)
+		}
+
 		return errors.Wrapf(err, "unable to upload object")
 	}
 
@@ -179,58 +235,9 @@ func (s *Server) updateAccountRouting(ctx context.Context, db *gorm.DB, account
 }
 
 func (s *Server) updateLabelLinks(ctx context.Context) error {
-	lastId := 0
-
-	lls := make([]*LabelLink, 0, 100)
-
-	var out pb.LabelLinks
-
-	for {
-		// Gotta poll the context since database/sql and gorm don't expose a context
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-		}
-
-		err := dbx.Check(s.db.Where("id > ?", lastId).Limit(100).Find(&lls))
-		if err != nil {
-			if err == gorm.ErrRecordNotFound {
-				break
-			}
-		}
-
-		if len(lls) == 0 {
-			break
-		}
-
-		for _, ll := range lls {
-			account, err := pb.AccountFromKey(ll.AccountID)
-			if err != nil {
-				return err
-			}
-
-			var acc Account
-
-			err = dbx.Check(s.db.First(&acc, ll.AccountID))
-			if err != nil {
-				return err
-			}
-
-			var pblimit pb.Account_Limits
-			acc.Data.Get("limits", &pblimit)
-
-			out.LabelLinks = append(out.LabelLinks, &pb.LabelLink{
-				Account: account,
-				Labels:  ExplodeLabels(ll.Labels),
-				Target:  ExplodeLabels(ll.Target),
-				Limits:  &pblimit,
-			})
-		}
-
-		lastId = lls[len(lls)-1].ID
-
-		lls = lls[:0]
+	out, err := s.allLabelLinks(ctx)
+	if err != nil {
+		return err
 	}
 
 	data, err := out.Marshal()
@@ -284,3 +291,69 @@ func (s *Server) updateLabelLinks(ctx context.Context) error {
 
 	return nil
 }
+
+// allLabelLinks reads every label link from the database, with the limits of
+// its account.
+func (s *Server) allLabelLinks(ctx context.Context) (*pb.LabelLinks, error) {
+	lastId := 0
+
+	lls := make([]*LabelLink, 0, 100)
+
+	var out pb.LabelLinks
+
+	for {
+		// Gotta poll the context since database/sql and gorm don't expose a context
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		err := dbx.Check(s.db.Where("id > ?", lastId).Limit(100).Find(&lls))
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				break
+			}
+		}
+
+		if len(lls) == 0 {
+			break
+		}
+
+		for _, ll := range lls {
+			account, err := pb.AccountFromKey(ll.AccountID)
+			if err != nil {
+				return nil, err
+			}
+
+			var acc Account
+
+			err = dbx.Check(s.db.First(&acc, ll.AccountID))
+			if err != nil {
+				return nil, err
+			}
+
+			var pblimit pb.Account_Limits
+			acc.Data.Get("limits", &pblimit)
+
+			splits, err := decodeSplits(ll.Splits)
+			if err != nil {
+				return nil, err
+			}
+
+			out.LabelLinks = append(out.LabelLinks, &pb.LabelLink{
+				Account: account,
+				Labels:  ExplodeLabels(ll.Labels),
+				Target:  ExplodeLabels(ll.Target),
+				Limits:  &pblimit,
+				Splits:  splits,
+			})
+		}
+
+		lastId = lls[len(lls)-1].ID
+
+		lls = lls[:0]
+	}
+
+	return &out, nil
+}
@@ -8,21 +8,23 @@ import (
 	"encoding/json"
 	fmt "fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"cirello.io/dynamolock"
 	"github.com/armon/go-metrics"
-	"github.com/armon/go-metrics/datadog"
-	"github.com/armon/go-metrics/prometheus"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/horizon/internal/sqljson"
+	"github.com/hashicorp/horizon/pkg/clock"
 	"github.com/hashicorp/horizon/pkg/dbx"
 	_ "github.com/hashicorp/horizon/pkg/grpc/lz4"
+	_ "github.com/hashicorp/horizon/pkg/grpc/zstd"
 	"github.com/hashicorp/horizon/pkg/pb"
 	"github.com/hashicorp/horizon/pkg/token"
 	"github.com/hashicorp/vault/api"
@@ -30,13 +32,103 @@ import (
 	"github.com/lib/pq"
 	"github.com/oschwald/geoip2-golang"
 	"github.com/pkg/errors"
-	"google.golang.org/grpc/metadata"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type connectedHub struct {
+	// Updated atomically by broadcastActivity. They're first so they're
+	// 64-bit aligned. missed is how many messages in a row were dropped.
+	dropped       int64
+	maxQueueDepth int64
+	missed        int64
+
+	// Set to 1 when the hub has to be sent the resolved routes of all its
+	// accounts, see resyncHub.
+	resync int32
+
 	xmit     chan *pb.CentralActivity
 	messages *int64
 	bytes    *int64
+
+	// The highest flow record sequence counted for the hub. This is shared
+	// by all the hub's connections so that records resent after a reconnect
+	// aren't counted twice.
+	lastFlowSeq *int64
+
+	// The accounts, by StringKey, that the hub has services for or has
+	// reported caching the routes of. Only used once the hub's existing
+	// services have been loaded.
+	accountsMu     sync.RWMutex
+	accounts       map[string]*pb.Account
+	accountsLoaded bool
+
+	// Ends the hub's activity stream, used when the hub is pruned.
+	cancel context.CancelFunc
+}
+
+// hubConnKey returns the key in connectedHubs of a hub's conn'th activity
+// stream to this server. Each stream gets its own entry, so that when a hub
+// reconnects, such as during a rolling restart, its old and new streams both
+// get activity until the old one ends.
+func hubConnKey(hub string, conn int64) string {
+	return hub + "/" + strconv.FormatInt(conn, 10)
+}
+
+// hubOfConnKey returns the hub that the connectedHubs entry with the given
+// key is a stream of.
+func hubOfConnKey(key string) string {
+	if i := strings.IndexByte(key, '/'); i >= 0 {
+		return key[:i]
+	}
+
+	return key
+}
+
+// removeHubConn removes the connectedHubs entry at key if it's still ch,
+// reporting if it was. Once the hub has no streams left, its flow sequence is
+// forgotten too, so hubs that go away without calling HubDisconnect, such as
+// by crashing or being pruned, don't leave it behind. Must be called with mu
+// held.
+func (s *Server) removeHubConn(key string, ch *connectedHub) bool {
+	if s.connectedHubs[key] != ch {
+		return false
+	}
+
+	delete(s.connectedHubs, key)
+
+	hub := hubOfConnKey(key)
+
+	for other := range s.connectedHubs {
+		if hubOfConnKey(other) == hub {
+			return true
+		}
+	}
+
+	delete(s.flowSeqs, hub)
+
+	return true
+}
+
+// newFlow reports if the flow record with the given sequence hasn't been
+// counted yet, and marks it as counted. Records without a sequence come from
+// hubs that don't send one and are always counted.
+func (ch *connectedHub) newFlow(seq int64) bool {
+	if seq == 0 || ch.lastFlowSeq == nil {
+		return true
+	}
+
+	for {
+		last := atomic.LoadInt64(ch.lastFlowSeq)
+		if seq <= last {
+			return false
+		}
+
+		if atomic.CompareAndSwapInt64(ch.lastFlowSeq, last, seq) {
+			return true
+		}
+	}
 }
 
 type Server struct {
@@ -50,9 +142,13 @@ type Server struct {
 	privKey  ed25519.PrivateKey
 	pubKey   ed25519.PublicKey
 
+	tokenMu       sync.RWMutex
 	registerToken string
 	opsToken      string
 
+	hubS3Mu    sync.RWMutex
+	hubS3Creds *pb.S3Credentials
+
 	lockMgr   *dynamolock.Client
 	lockTable string
 
@@ -66,15 +162,56 @@ type Server struct {
 
 	mu            sync.RWMutex
 	connectedHubs map[string]*connectedHub
+	flowSeqs      map[string]*int64
+
+	// Numbers the activity streams of hubs for their connectedHubs keys.
+	// Guarded by mu.
+	lastHubConn int64
+
+	// Coalesces identical concurrent FetchConfig calls.
+	fetchConfigs singleflight.Group
+
+	// The hubs AllHubs lists, rebuilt when they're next listed after
+	// hubsGen, the count of changes this server has made to them, moves on.
+	hubsMu        sync.RWMutex
+	hubsRefreshMu sync.Mutex
+	hubs          *hubSnapshot
+	hubsGen       uint64
+
+	// The traffic of each account and hub with a quota, by "account:" or
+	// "hub:" and its spec string.
+	quotaMu    sync.Mutex
+	quotaUsage map[string]*quotaUsage
 
 	m *metrics.Metrics
 
+	// The in-memory sink, or nil if it's disabled.
 	msink metrics.MetricSink
 
+	// Sinks to flush and close when the server is closed, only once.
+	sinks       []metrics.MetricSink
+	sinksClosed sync.Once
+
+	// Whether an activity source was started, and whether one has connected
+	// since. See Ready.
+	activityMu        sync.Mutex
+	activityStarted   bool
+	activityConnected bool
+
 	flowTop *FlowTop
 
+	// The smoothed rate of each account's traffic, or nil if
+	// ServerConfig.FlowRateDecay isn't set.
+	flowRates *flowRates
+
+	// The recent activity replayed to hubs that connect, or nil if
+	// ServerConfig.ActivityReplaySize isn't set.
+	replay *activityReplay
+
 	mux   *http.ServeMux
 	asnDB *geoip2.Reader
+
+	cancel func()
 }
 
 type ServerConfig struct {
@@ -102,49 +239,177 @@ type ServerConfig struct {
 	// so they can act on it.
 	HubImageTag string
 
+	// The grpc compressor hubs should use for their activity stream, advertised
+	// to them when they fetch their config. Hubs that don't know the name keep
+	// using their default (lz4).
+	ActivityCompressor string
+
+	// The name of the cursor the activity reader tracks the log entries it
+	// has broadcast with, so that entries not broadcast before a restart are
+	// read again. It must be unique to each control server. Without it,
+	// readers start at the newest entry when opened.
+	ActivityCursor string
+
 	DataDogAddr       string
 	DisablePrometheus bool
+
+	// Skip the in-memory sink, which keeps recent metrics in memory for
+	// debugging. Otherwise it aggregates them over InmemInterval and keeps
+	// them for InmemRetention, which default to DefaultInmemInterval and
+	// DefaultInmemRetention.
+	DisableInmemSink bool
+	InmemInterval    time.Duration
+	InmemRetention   time.Duration
+
+	// The service name all metrics are prefixed with, so several clusters
+	// can share a metrics backend. Defaults to DefaultMetricsPrefix.
+	MetricsPrefix string
+
+	// Labels added to every metric, such as the cluster name or region.
+	MetricsLabels map[string]string
+
+	// Generates the ids the server assigns, such as those of management
+	// clients. Defaults to pb.NewULID; tests can set it to get known ids.
+	NewID func() *pb.ULID
+
+	// Tells the time for anything that depends on it, such as hub checkins,
+	// staleness, quotas, and token renewal. Defaults to clock.Real; tests can
+	// set a clock.Fake to move time along without sleeping.
+	Clock clock.Clock
+
+	// Other sinks to send metrics to. Sinks with a Flush or Close method, as
+	// well as the datadog sink, are flushed and closed by Server.Close.
+	MetricSinks []metrics.MetricSink
+
+	// If set, NewServer logs a warning and continues without metrics when
+	// they can't be set up, rather than failing.
+	MetricsBestEffort bool
+
+	// How often to update the gauges of label links and services. Defaults
+	// to DefaultRoutingStatsInterval.
+	RoutingStatsInterval time.Duration
+
+	// If set, only hubs it allows can fetch their config and stream activity,
+	// even if they have a valid hub token. See HubAllowList for a simple one.
+	HubAuthorizer HubAuthorizer
+
+	// If set, activity about the services of specific accounts is only sent
+	// to the hubs that have services for one of those accounts, or that have
+	// reported caching the account's routes, rather than to every hub.
+	// Activity that isn't account specific, such as label link changes, is
+	// always sent to every hub.
+	SelectiveBroadcast bool
+
+	// How long before a token expires that RenewToken will renew it. Defaults
+	// to the last third of the token's lifetime.
+	TokenRenewalWindow time.Duration
+
+	// The longest a token renewed by RenewToken is valid for. Zero means
+	// renewed tokens are valid for as long as the originals were.
+	TokenMaxTTL time.Duration
+
+	// The most capabilities a token created by CreateToken or CreateTokens
+	// can carry. Defaults to DefaultMaxTokenCapabilities.
+	MaxTokenCapabilities int
+
+	// The most labels a service or either side of a label link can have, and
+	// the longest any of them can be, counting the name, "=", and value.
+	// Default to DefaultMaxLabels and DefaultMaxLabelLength.
+	MaxLabels      int
+	MaxLabelLength int
+
+	// If set, what each token created by CreateToken, CreateTokens, and
+	// RequestServiceToken grants is recorded, so it can be listed with
+	// ListIssuedTokens. The tokens themselves aren't kept.
+	RecordIssuedTokens bool
+
+	// The traffic quota of every account, unless it has its own in
+	// AccountQuotas, keyed by the account's spec string. When an account goes
+	// over its quota, the hubs turn away its new streams until it's back
+	// under.
+	AccountQuota  Quota
+	AccountQuotas map[string]Quota
+
+	// The traffic quota of every hub.
+	HubQuota Quota
+
+	// The window quotas are measured over. Defaults to DefaultQuotaWindow.
+	QuotaWindow time.Duration
+
+	// How long to keep trying to acquire the lock on an account's routing
+	// while another server holds it, and how long to wait after the first
+	// attempt fails. The wait doubles after each attempt. Default to
+	// DefaultLockWait and DefaultLockRetryDelay.
+	LockWait       time.Duration
+	LockRetryDelay time.Duration
+
+	// Weights given to the routes of services, so hubs send them a larger or
+	// smaller share of requests. A route gets the weight of the first entry
+	// whose labels match its own; routes that match none share equally.
+	ServiceWeights []ServiceWeight
+
+	// If set, the flows of CurrentFlowTop are saved to this file by
+	// Server.Close and loaded from it by NewServer, so they survive a
+	// restart.
+	FlowTopPath string
+
+	// The largest messages the grpc.Server made with GRPCServerOptions
+	// receives and sends. Default to DefaultMaxRecvMsgSize and
+	// DefaultMaxSendMsgSize.
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+
+	// The most network locations a hub can report when it fetches its
+	// config, and the most services it can sync at once. Default to
+	// DefaultMaxHubLocations and DefaultMaxHubServices.
+	MaxHubLocations int
+	MaxHubServices  int
+
+	// If set, the rate of each account's traffic is also reported as the
+	// stream.rate.messages and stream.rate.bytes gauges, smoothed with an
+	// exponential decay of this time constant. They're updated every
+	// FlowRateInterval, which defaults to DefaultFlowRateInterval.
+	FlowRateDecay    time.Duration
+	FlowRateInterval time.Duration
+
+	// If set, up to this many of the most recent activity messages are kept
+	// and sent to each hub when it connects its activity stream, before any
+	// new activity, so it catches up on what it missed while it was
+	// reconnecting. Only activity from the last ActivityReplayWindow is
+	// sent, which defaults to DefaultActivityReplayWindow.
+	ActivityReplaySize   int
+	ActivityReplayWindow time.Duration
 }
 
+// DefaultMaxTokenCapabilities is the most capabilities a token can be created
+// with when ServerConfig.MaxTokenCapabilities isn't set.
+const DefaultMaxTokenCapabilities = 32
+
 func NewServer(cfg ServerConfig) (*Server, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	L := cfg.Logger
 	if L == nil {
 		L = hclog.L()
 	}
 
-	mcfg := metrics.DefaultConfig("control")
-	mcfg.EnableHostname = false
-	mcfg.EnableRuntimeMetrics = false
-
-	var fanout metrics.FanoutSink
-
-	if !cfg.DisablePrometheus {
-		psink, err := prometheus.NewPrometheusSinkFrom(prometheus.PrometheusOpts{
-			Expiration: time.Hour,
-		})
-
-		if err != nil {
+	me, msink, sinks, err := cfg.setupMetrics(L)
+	if err != nil {
+		if !cfg.MetricsBestEffort {
 			return nil, err
 		}
 
-		fanout = append(fanout, psink)
-	}
-
-	msink := metrics.NewInmemSink(time.Minute, time.Hour)
-	fanout = append(fanout, msink)
+		L.Warn("error setting up metrics, continuing without them", "error", err)
 
-	if cfg.DataDogAddr != "" {
-		L.Info("configured to send stats to datadog")
-		msink, err := datadog.NewDogStatsdSink(cfg.DataDogAddr, mcfg.HostName)
+		me, err = metrics.New(cfg.metricsConfig(), &metrics.BlackholeSink{})
 		if err != nil {
 			return nil, err
 		}
-		fanout = append(fanout, msink)
-	}
 
-	me, err := metrics.New(mcfg, fanout)
-	if err != nil {
-		return nil, err
+		msink, sinks = nil, nil
 	}
 
 	flowTop, err := NewFlowTop(DefaultFlowTopSize)
@@ -152,6 +417,13 @@ func NewServer(cfg ServerConfig) (*Server, error) {
 		return nil, err
 	}
 
+	if cfg.FlowTopPath != "" {
+		err = loadFlowTop(flowTop, cfg.FlowTopPath)
+		if err != nil {
+			L.Warn("unable to restore flow top, starting empty", "error", err, "path", cfg.FlowTopPath)
+		}
+	}
+
 	s := &Server{
 		cfg:           cfg,
 		L:             L,
@@ -168,10 +440,15 @@ func NewServer(cfg ServerConfig) (*Server, error) {
 		connectedHubs: make(map[string]*connectedHub),
 		m:             me,
 		msink:         msink,
+		sinks:         sinks,
 		flowTop:       flowTop,
 		mux:           http.NewServeMux(),
 	}
 
+	if cfg.ActivityReplaySize > 0 {
+		s.replay = newActivityReplay(cfg.ActivityReplaySize)
+	}
+
 	L.Debug("setting up routes")
 
 	s.setupRoutes()
@@ -186,7 +463,10 @@ func NewServer(cfg ServerConfig) (*Server, error) {
 	}
 
 	L.Debug("configuring lock in dynamodb")
-	s.lockMgr, err = dynamolock.New(dynamodb.New(s.awsSess), s.lockTable)
+	s.lockMgr, err = dynamolock.New(dynamodb.New(s.awsSess), s.lockTable,
+		dynamolock.WithLeaseDuration(lockLeaseDuration),
+		dynamolock.WithHeartbeatPeriod(lockHeartbeatPeriod),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -204,9 +484,64 @@ func NewServer(cfg ServerConfig) (*Server, error) {
 
 	s.L.Info("vault configured for token signing", "pubkey", hex.EncodeToString(pub))
 
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	go s.runHubCheckinCollector(ctx)
+	go s.runHubSnapshotRefresher(ctx)
+
+	statsInterval := cfg.RoutingStatsInterval
+	if statsInterval == 0 {
+		statsInterval = DefaultRoutingStatsInterval
+	}
+
+	go s.runRoutingStatsCollector(ctx, statsInterval)
+
+	if s.quotasEnabled() {
+		go s.runQuotaChecker(ctx)
+	}
+
+	if cfg.FlowRateDecay > 0 {
+		s.flowRates = newFlowRates(cfg.FlowRateDecay)
+
+		rateInterval := cfg.FlowRateInterval
+		if rateInterval == 0 {
+			rateInterval = DefaultFlowRateInterval
+		}
+
+		go s.runFlowRateReporter(ctx, rateInterval)
+	}
+
 	return s, nil
 }
 
+// Close stops the server's background tasks, and saves the flow top if
+// ServerConfig.FlowTopPath is set.
+func (s *Server) Close() error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+
+	if s.cfg.FlowTopPath != "" && s.flowTop != nil {
+		err := saveFlowTop(s.flowTop, s.cfg.FlowTopPath)
+		if err != nil {
+			s.L.Error("error saving flow top", "error", err, "path", s.cfg.FlowTopPath)
+		}
+	}
+
+	var err error
+
+	s.sinksClosed.Do(func() {
+		err = closeSinks(s.sinks)
+	})
+
+	if err != nil {
+		s.L.Error("error flushing metrics", "error", err)
+	}
+
+	return err
+}
+
 func (s *Server) TokenPub() ed25519.PublicKey {
 	return s.pubKey
 }
@@ -230,6 +565,10 @@ type Account struct {
 
 	Data sqljson.Data
 
+	// Suspended accounts keep their configuration, but none of their
+	// services are routed to and no tokens can be created for them.
+	Suspended bool
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -253,33 +592,22 @@ type Service struct {
 }
 
 func (s *Server) checkFromHub(ctx context.Context) (*token.ValidToken, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return nil, ErrBadAuthentication
-	}
-
-	auth := md["authorization"]
-
-	if len(auth) < 1 {
-		return nil, ErrBadAuthentication
-	}
-
-	token, err := token.CheckTokenED25519(auth[0], s.pubKey)
+	token, err := s.authFromContext(ctx, pb.HUB)
 	if err != nil {
-		// s.L.Error("error checking token signature", "error", err, "token", auth[0], "pubkey", hex.EncodeToString(s.pubKey))
 		return nil, err
 	}
 
-	if token.Body.Role != pb.HUB {
-		return nil, errors.Wrapf(ErrBadAuthentication, "role was: %s", token.Body.Role)
-	}
-
 	s.L.Info("authentication from hub successful")
 
 	return token, nil
 }
 
 func (s *Server) SyncHub(ctx context.Context, sync *pb.HubSync) (*pb.HubSyncResponse, error) {
+	err := s.checkHubServices(sync.Services)
+	if err != nil {
+		return nil, err
+	}
+
 	return nil, nil
 }
 
@@ -289,8 +617,18 @@ func (s *Server) AddService(ctx context.Context, service *pb.ServiceRequest) (*p
 		return nil, err
 	}
 
+	err = s.checkLabelLimits("service", service.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := accountKey(service.Account)
+	if err != nil {
+		return nil, err
+	}
+
 	var so Service
-	so.AccountId = service.Account.Key()
+	so.AccountId = key
 	so.HubId = service.Hub.Bytes()
 	so.ServiceId = service.Id.Bytes()
 	so.Type = service.Type
@@ -301,21 +639,41 @@ func (s *Server) AddService(ctx context.Context, service *pb.ServiceRequest) (*p
 		return nil, err
 	}
 
-	s.broadcastActivity(ctx, &pb.CentralActivity{
-		AccountServices: []*pb.AccountServices{
-			{
-				Account: service.Account,
-				Services: []*pb.ServiceRoute{
-					{
-						Hub:    service.Hub,
-						Id:     service.Id,
-						Type:   service.Type,
-						Labels: service.Labels,
-					},
+	routes := []*pb.ServiceRoute{
+		{
+			Hub:    service.Hub,
+			Id:     service.Id,
+			Type:   service.Type,
+			Labels: service.Labels,
+		},
+	}
+
+	// Annotate the route the same way the account's full set of routes is, so
+	// hubs don't prefer it over the others just because it's new.
+	err = s.annotateRoutes(s.db, routes)
+	if err != nil {
+		return nil, err
+	}
+
+	s.noteHubAccount(service.Hub, service.Account)
+
+	suspended, err := accountSuspended(s.db, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// The services of a suspended account are withheld from hubs until it's
+	// unsuspended, which publishes them all.
+	if !suspended {
+		s.broadcastActivity(&pb.CentralActivity{
+			AccountServices: []*pb.AccountServices{
+				{
+					Account:  service.Account,
+					Services: routes,
 				},
 			},
-		},
-	})
+		})
+	}
 
 	err = s.updateAccountRouting(ctx, s.db, service.Account)
 	if err != nil {
@@ -345,8 +703,13 @@ func (s *Server) RemoveService(ctx context.Context, service *pb.ServiceRequest)
 }
 
 func (s *Server) ListServices(ctx context.Context, req *pb.ListServicesRequest) (*pb.ListServicesResponse, error) {
+	key, err := accountKey(req.Account)
+	if err != nil {
+		return nil, err
+	}
+
 	var services []*Service
-	err := dbx.Check(s.db.Where("account_id = ?", req.Account.Key()).Find(&services))
+	err = dbx.Check(s.db.Where("account_id = ?", key).Find(&services))
 	if err != nil {
 		return nil, err
 	}
@@ -369,6 +732,109 @@ func (s *Server) ListServices(ctx context.Context, req *pb.ListServicesRequest)
 	return &resp, nil
 }
 
+// The number of services sent in each StreamServices response when the
+// request doesn't specify a batch size.
+const DefaultStreamServicesBatch = 100
+
+// StreamServices sends all the services that match req to stream, in batches.
+// The services are scanned in primary key order so a stream that is started
+// while services are being added or removed still sees a consistent ordering.
+func (s *Server) StreamServices(req *pb.StreamServicesRequest, stream pb.ControlServices_StreamServicesServer) error {
+	ctx := stream.Context()
+
+	caller, err := s.checkMgmtAllowed(ctx)
+	if err != nil {
+		return err
+	}
+
+	ns := req.Namespace
+	if ns == "" {
+		ns = callerNamespace(caller)
+	}
+
+	if !caller.AllowAccount(ns) {
+		return errors.Wrapf(ErrInvalidRequest, "invalid namespace requested")
+	}
+
+	limit := int(req.BatchSize)
+	if limit <= 0 {
+		limit = DefaultStreamServicesBatch
+	}
+
+	if req.Labels != nil {
+		req.Labels.Finalize()
+	}
+
+	var lastId int64
+
+	services := make([]*Service, 0, limit)
+
+	for {
+		// Gotta poll the context since database/sql and gorm don't expose a context
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		q := accountKeyScope(s.db, ns).Where("id > ?", lastId)
+
+		if req.Type != "" {
+			q = q.Where("type = ?", req.Type)
+		}
+
+		err := dbx.Check(q.Order("id ASC").Limit(limit).Find(&services))
+		if err != nil {
+			if err != gorm.ErrRecordNotFound {
+				return err
+			}
+		}
+
+		if len(services) == 0 {
+			return nil
+		}
+
+		var resp pb.ListServicesResponse
+
+		for _, svc := range services {
+			acc, err := pb.AccountFromKey(svc.AccountId)
+			if err != nil {
+				return err
+			}
+
+			var labelSet pb.LabelSet
+			if err := labelSet.Scan(svc.Labels); err != nil {
+				return err
+			}
+
+			if req.Labels != nil && !req.Labels.Matches(&labelSet) {
+				continue
+			}
+
+			resp.Services = append(resp.Services, &pb.Service{
+				Id:      pb.ULIDFromBytes(svc.ServiceId),
+				Hub:     pb.ULIDFromBytes(svc.HubId),
+				Type:    svc.Type,
+				Labels:  &labelSet,
+				Account: acc,
+			})
+		}
+
+		lastId = services[len(services)-1].ID
+
+		services = services[:0]
+
+		if len(resp.Services) == 0 {
+			continue
+		}
+
+		err = stream.Send(&resp)
+		if err != nil {
+			return err
+		}
+	}
+}
+
 func (s *Server) removeHubServices(ctx context.Context, db *gorm.DB, hubId *pb.ULID) error {
 	var sos []*Service
 
@@ -417,20 +883,54 @@ func (s *Server) FetchConfig(ctx context.Context, req *pb.ConfigRequest) (*pb.Co
 		return nil, err
 	}
 
-	L := s.L
+	err = s.authorizeHub(ctx, req.StableId)
+	if err != nil {
+		return nil, err
+	}
 
-	L.Info("fetching configuration", "hub", req.StableId.SpecString())
+	err = s.checkHubLocations(req.Locations)
+	if err != nil {
+		return nil, err
+	}
 
 	data, err := json.Marshal(req.Locations)
 	if err != nil {
 		return nil, err
 	}
 
+	// Identical requests, from a hub retrying or racing with itself, share
+	// one update rather than queueing up on the hub's row to repeat it.
+	// Requests from different instances are never identical, so each
+	// replacement of a hub's instance is still handled on its own.
+	key := req.StableId.SpecString() + "/" + req.InstanceId.SpecString() + "/" + string(data)
+
+	v, err, shared := s.fetchConfigs.Do(key, func() (interface{}, error) {
+		return s.fetchConfig(ctx, req, data)
+	})
+
+	if shared && s.m != nil {
+		s.m.IncrCounter([]string{"fetch_config", "coalesced"}, 1)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*pb.ConfigResponse), nil
+}
+
+// fetchConfig records the hub making req, whose locations are encoded in data,
+// and returns its config.
+func (s *Server) fetchConfig(ctx context.Context, req *pb.ConfigRequest, data []byte) (*pb.ConfigResponse, error) {
+	L := s.requestLogger(ctx)
+
+	L.Info("fetching configuration", "hub", req.StableId.SpecString())
+
 	var hr Hub
 
 	tx := s.db.Begin()
 
-	err = dbx.Check(
+	err := dbx.Check(
 		tx.Set("gorm:query_options", "FOR UPDATE").
 			Where("stable_id = ?", req.StableId.Bytes()).
 			First(&hr),
@@ -441,7 +941,7 @@ func (s *Server) FetchConfig(ctx context.Context, req *pb.ConfigRequest) (*pb.Co
 		hr.InstanceID = req.InstanceId.Bytes()
 
 		hr.ConnectionInfo = data
-		hr.LastCheckin = time.Now()
+		hr.LastCheckin = s.now()
 
 		err = dbx.Check(tx.Create(&hr))
 		if err != nil {
@@ -460,6 +960,10 @@ func (s *Server) FetchConfig(ctx context.Context, req *pb.ConfigRequest) (*pb.Co
 				tx.Rollback()
 				return nil, err
 			}
+
+			if s.m != nil {
+				s.m.IncrCounter([]string{"hub", "replaced"}, 1)
+			}
 		}
 
 		err = dbx.Check(
@@ -467,7 +971,7 @@ func (s *Server) FetchConfig(ctx context.Context, req *pb.ConfigRequest) (*pb.Co
 				Updates(map[string]interface{}{
 					"connection_info": data,
 					"instance_id":     req.InstanceId.Bytes(),
-					"last_checkin":    time.Now(),
+					"last_checkin":    s.now(),
 				}),
 		)
 
@@ -477,56 +981,104 @@ func (s *Server) FetchConfig(ctx context.Context, req *pb.ConfigRequest) (*pb.Co
 		}
 	}
 
+	err = replaceHubLocations(tx, req.StableId.Bytes(), req.Locations)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	err = dbx.Check(tx.Commit())
 	if err != nil {
 		return nil, err
 	}
 
+	s.hubsChanged()
+
+	creds := s.hubS3Credentials()
+
 	resp := &pb.ConfigResponse{
 		TlsKey:      s.hubKey,
 		TlsCert:     s.hubCert,
 		TokenPub:    s.pubKey,
-		S3AccessKey: s.cfg.HubAccessKey,
-		S3SecretKey: s.cfg.HubSecretKey,
-		S3Bucket:    s.cfg.Bucket,
+		S3AccessKey: creds.AccessKey,
+		S3SecretKey: creds.SecretKey,
+		S3Bucket:    creds.Bucket,
 		ImageTag:    s.cfg.HubImageTag,
+
+		ActivityCompressor: s.cfg.ActivityCompressor,
 	}
 
 	return resp, nil
 }
 
+// HubDisconnect removes the services of a hub that's shutting down, along with
+// the hub itself, in a single transaction. Any of them may already be gone,
+// such as when a hub disconnects twice, so it's fine for there to be nothing
+// to remove.
 func (s *Server) HubDisconnect(ctx context.Context, req *pb.HubDisconnectRequest) (*pb.Noop, error) {
 	_, err := s.checkFromHub(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	tx := s.db.Begin()
+
 	s.L.Info("removing hub services", "id", req.StableId)
 
-	serr := s.removeHubServices(ctx, s.db, req.InstanceId)
+	var result error
+
+	err = s.removeHubServices(ctx, tx, req.InstanceId)
 	if err != nil {
-		err = multierror.Append(err, serr)
+		result = multierror.Append(result, errors.Wrapf(err, "removing hub services"))
 	}
 
 	s.L.Info("removing hub", "id", req.StableId)
 
-	serr = dbx.Check(s.db.Where("stable_id = ?", req.StableId.Bytes()).Delete(&Hub{}))
-	if serr != nil {
-		err = multierror.Append(err, serr)
+	err = dbx.Check(tx.Where("stable_id = ?", req.StableId.Bytes()).Delete(&Hub{}))
+	if err != nil {
+		result = multierror.Append(result, errors.Wrapf(err, "removing hub"))
+	}
+
+	if result != nil {
+		tx.Rollback()
+		s.L.Error("error cleaning up hub", "id", req.StableId, "error", result)
+		return nil, result
+	}
+
+	err = dbx.Check(tx.Commit())
+	if err != nil {
+		return nil, err
 	}
 
-	s.L.Info("hub cleaned up", "possible-error", err)
+	s.mu.Lock()
+	delete(s.flowSeqs, req.InstanceId.SpecString())
+	s.mu.Unlock()
+
+	s.hubsChanged()
+
+	s.L.Info("hub cleaned up", "id", req.StableId)
 
-	return &pb.Noop{}, err
+	return &pb.Noop{}, nil
 }
 
 func (s *Server) processFlows(ch *connectedHub, flows []*pb.FlowRecord) {
-	var mdiff, bdiff int64
+	var (
+		mdiff, bdiff int64
+		counted      []*pb.FlowStream
+	)
 
 	for _, rec := range flows {
 		if rec.Stream != nil {
-			mdiff += rec.Stream.NumMessages
-			bdiff += rec.Stream.NumBytes
+			// Resent records still update flow top, but their messages and
+			// bytes have already been counted.
+			dup := !ch.newFlow(rec.Sequence)
+			if dup {
+				s.L.Trace("skipping counters for duplicate flow record", "sequence", rec.Sequence)
+			} else {
+				mdiff += rec.Stream.NumMessages
+				bdiff += rec.Stream.NumBytes
+				counted = append(counted, rec.Stream)
+			}
 
 			labels := []metrics.Label{
 				{
@@ -551,8 +1103,10 @@ func (s *Server) processFlows(ch *connectedHub, flows []*pb.FlowRecord) {
 				},
 			}
 
-			s.m.IncrCounterWithLabels([]string{"stream", "messages"}, float32(rec.Stream.NumMessages), labels)
-			s.m.IncrCounterWithLabels([]string{"stream", "bytes"}, float32(rec.Stream.NumBytes), labels)
+			if !dup {
+				s.m.IncrCounterWithLabels([]string{"stream", "messages"}, float32(rec.Stream.NumMessages), labels)
+				s.m.IncrCounterWithLabels([]string{"stream", "bytes"}, float32(rec.Stream.NumBytes), labels)
+			}
 
 			s.flowTop.Add(rec.Stream)
 		}
@@ -593,6 +1147,15 @@ func (s *Server) processFlows(ch *connectedHub, flows []*pb.FlowRecord) {
 
 	s.m.IncrCounter([]string{"total", "messages"}, float32(mdiff))
 	s.m.IncrCounter([]string{"total", "bytes"}, float32(bdiff))
+	s.m.SetGauge([]string{"flow_top", "entries"}, float32(s.flowTop.Len()))
+
+	if len(counted) > 0 && s.quotasEnabled() {
+		s.trackQuota(s.now(), counted)
+	}
+
+	if len(counted) > 0 && s.flowRates != nil {
+		s.flowRates.add(s.now(), counted)
+	}
 }
 
 func (s *Server) StreamActivity(stream pb.ControlServices_StreamActivityServer) error {
@@ -608,44 +1171,86 @@ func (s *Server) StreamActivity(stream pb.ControlServices_StreamActivityServer)
 		return err
 	}
 
+	// The hub has to say who it is before anything else, otherwise there's
+	// nothing to send it activity under.
 	if msg.HubReg == nil {
-		s.L.Debug("acvitity stream request did not contain a hub reg record")
-		return nil
+		s.L.Warn("activity stream did not start with a hub registration")
+		return status.Error(codes.FailedPrecondition, "first activity message must be a hub registration")
+	}
+
+	if emptyULID(msg.HubReg.Hub) {
+		s.L.Warn("activity stream hub registration is missing the hub id")
+		return status.Error(codes.InvalidArgument, "hub registration is missing the hub id")
 	}
 
-	key := msg.HubReg.Hub.SpecString()
+	err = s.authorizeHub(ctx, msg.HubReg.StableHub)
+	if err != nil {
+		return err
+	}
+
+	hubId := msg.HubReg.Hub
+	hubKey := hubId.SpecString()
 
-	s.L.Info("streaming activity to and from hub", "hub", key)
+	s.L.Info("streaming activity to and from hub", "hub", hubKey)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
 	ch := &connectedHub{
-		xmit:     make(chan *pb.CentralActivity),
+		xmit:     make(chan *pb.CentralActivity, HubActivityQueueSize),
 		messages: new(int64),
 		bytes:    new(int64),
+		accounts: make(map[string]*pb.Account),
+		cancel:   cancel,
 	}
 
-	s.mu.Lock()
-	s.connectedHubs[key] = ch
-	s.mu.Unlock()
+	// The accounts the hub already has cached when it connects are noted
+	// before it's added, so it never misses their activity.
+	ch.noteAccounts(msg.CachedAccounts...)
 
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+	// Let the hub know who is already over their quota. Nothing else can
+	// queue activity for it until it's added below, so there's room.
+	if act := s.exceededQuotas(); act != nil {
+		ch.xmit <- act
+	}
 
-	go func() {
-		for {
-			msg, err := stream.Recv()
-			if err != nil {
-				return
-			}
+	s.mu.Lock()
+	if s.flowSeqs == nil {
+		s.flowSeqs = make(map[string]*int64)
+	}
 
-			s.processFlows(ch, msg.Flow)
-		}
-	}()
+	seq, ok := s.flowSeqs[hubKey]
+	if !ok {
+		seq = new(int64)
+		s.flowSeqs[hubKey] = seq
+	}
+
+	s.lastHubConn++
+	key := hubConnKey(hubKey, s.lastHubConn)
+
+	ch.lastFlowSeq = seq
+	s.connectedHubs[key] = ch
+
+	// Taken while holding mu, so it has all the activity broadcast before the
+	// hub was added and none of what's queued for it after.
+	replay := s.replayActivity()
 
+	// The hub may be reconnecting after missing activity, so if the replay
+	// can't make up for it, its accounts are resynced instead.
+	if !s.replayCovers() {
+		atomic.StoreInt32(&ch.resync, 1)
+	}
+	s.mu.Unlock()
+
+	// Registered straight after adding the hub so that it's removed however
+	// we exit, including by a panic.
 	defer func() {
 		s.L.Debug("hub disconnecting", "hub", key)
 
+		// The stream may have been pruned, in which case the entry isn't
+		// ours to remove.
 		s.mu.Lock()
-		delete(s.connectedHubs, key)
+		s.removeHubConn(key, ch)
 		s.mu.Unlock()
 
 		// drain the xmit channel in the case that the sender saw
@@ -662,7 +1267,57 @@ func (s *Server) StreamActivity(stream pb.ControlServices_StreamActivityServer)
 		}
 	}()
 
+	if s.cfg.SelectiveBroadcast {
+		err = s.loadHubAccounts(ch, msg.HubReg.Hub)
+		if err != nil {
+			s.L.Error("error loading the accounts of hub, sending it all activity", "hub", hubKey, "error", err)
+		}
+	}
+
+	// Sent before anything queued since the hub was added, so the hub sees
+	// the activity in the order it was broadcast.
+	for _, act := range replay {
+		if s.cfg.SelectiveBroadcast {
+			if accounts := activityAccounts(act); accounts != nil && !ch.servesAny(accounts) {
+				continue
+			}
+		}
+
+		err = stream.Send(act)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(replay) > 0 {
+		s.L.Debug("replayed recent activity to hub", "hub", key, "activity", len(replay))
+	}
+
+	go s.touchHubCheckin(ctx, msg.HubReg.Hub)
+
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				return
+			}
+
+			ch.noteAccounts(msg.CachedAccounts...)
+			s.processFlows(ch, msg.Flow)
+		}
+	}()
+
 	for {
+		// Only once everything queued has been sent, so nothing older than
+		// the resync is sent after it.
+		if atomic.LoadInt32(&ch.resync) == 1 && len(ch.xmit) == 0 {
+			err = s.resyncHub(ctx, stream, ch, hubId)
+			if err != nil {
+				s.L.Error("error resyncing hub", "hub", key, "error", err)
+				return err
+			}
+		}
+
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -671,7 +1326,7 @@ func (s *Server) StreamActivity(stream pb.ControlServices_StreamActivityServer)
 				return nil
 			}
 
-			s.L.Debug("sending data to hub", "hub", key, "activity", act.String())
+			s.L.Debug("sending data to hub", "hub", key, "activity", redactActivity(act).String())
 
 			err = stream.Send(act)
 			if err != nil {
@@ -681,86 +1336,167 @@ func (s *Server) StreamActivity(stream pb.ControlServices_StreamActivityServer)
 	}
 }
 
-func (s *Server) StartActivityReader(ctx context.Context, dbtype, conn string) error {
-	ar, err := NewActivityReader(ctx, dbtype, conn)
+// emptyULID reports if id is unset or all zeros.
+func emptyULID(id *pb.ULID) bool {
+	if id == nil {
+		return true
+	}
+
+	if id.Timestamp != 0 {
+		return false
+	}
+
+	for _, b := range id.Entropy {
+		if b != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// StartActivityReader starts broadcasting the activity from the source of the
+// given type. Only "postgres" is built in, any other type has to have been
+// registered with RegisterActivitySource. conn is the connection string for
+// the source.
+func (s *Server) StartActivityReader(ctx context.Context, sourceType, conn string) error {
+	open, err := activitySourceOpener(sourceType)
 	if err != nil {
 		return err
 	}
 
-	go func() {
-		L := s.L
+	if s.cfg.ActivityCursor != "" {
+		ctx = WithActivityCursor(ctx, s.cfg.ActivityCursor)
+	}
 
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case ev, ok := <-ar.C:
-				if !ok {
-					return
-				}
+	return s.StartActivitySource(ctx, func(ctx context.Context) (ActivitySource, error) {
+		return open(ctx, conn)
+	})
+}
 
-				L.Info("detected activity")
+type ManagementClient struct {
+	ID        []byte `gorm:"primary_key"`
+	Namespace string
+}
 
-				var adds []*pb.AccountServices
+var ErrBadAuthentication = errors.New("bad authentication information presented")
 
-				for _, act := range ev {
-					var ae pb.ActivityEntry
+// ReloadTokens replaces the register and ops tokens, allowing them to be
+// rotated without restarting the server. Requests checked after it returns
+// see the new values.
+func (s *Server) ReloadTokens(register, ops string) {
+	s.tokenMu.Lock()
+	defer s.tokenMu.Unlock()
 
-					err := json.Unmarshal(act.Event, &ae)
-					if err != nil {
-						L.Error("error unmarshaling activity log entry", "error", err)
-						continue
-					}
+	s.registerToken = register
+	s.opsToken = ops
+}
 
-					adds = append(adds, ae.RouteAdded)
-				}
+// SetHubS3Credentials replaces the S3 credentials handed to hubs in
+// FetchConfig and tells the connected hubs, so they fetch them without waiting
+// to reconnect. The credentials themselves only go out in FetchConfig's
+// authenticated response, never in activity, which is logged and replayed.
+// An empty bucket keeps the current one.
+func (s *Server) SetHubS3Credentials(access, secret, bucket string) {
+	s.hubS3Mu.Lock()
 
-				s.broadcastActivity(ctx, &pb.CentralActivity{
-					AccountServices: adds,
-				})
-			}
-		}
-	}()
+	if bucket == "" {
+		bucket = s.hubS3CredentialsLocked().Bucket
+	}
 
-	return nil
+	creds := &pb.S3Credentials{
+		AccessKey: access,
+		SecretKey: secret,
+		Bucket:    bucket,
+	}
+
+	s.hubS3Creds = creds
+	s.hubS3Mu.Unlock()
+
+	s.broadcastActivity(&pb.CentralActivity{
+		S3CredentialsRotated: true,
+	})
 }
 
-func (s *Server) broadcastActivity(ctx context.Context, act *pb.CentralActivity) error {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+// redactActivity returns act with any S3 secret key blanked out, for logging.
+func redactActivity(act *pb.CentralActivity) *pb.CentralActivity {
+	if act.S3Credentials == nil || act.S3Credentials.SecretKey == "" {
+		return act
+	}
 
-	s.L.Debug("broadcasting activity to hubs", "hubs", len(s.connectedHubs))
+	creds := *act.S3Credentials
+	creds.SecretKey = "<redacted>"
 
-	for key, hub := range s.connectedHubs {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case hub.xmit <- act:
-			// ok
-		case <-time.After(5 * time.Second):
-			s.L.Debug("time out sending activity to hub channel", "hub", key)
-		}
+	redacted := *act
+	redacted.S3Credentials = &creds
+
+	return &redacted
+}
+
+// hubS3Credentials returns the S3 credentials to hand to hubs, falling back
+// to the configured ones if SetHubS3Credentials hasn't been called.
+func (s *Server) hubS3Credentials() *pb.S3Credentials {
+	s.hubS3Mu.RLock()
+	defer s.hubS3Mu.RUnlock()
+
+	return s.hubS3CredentialsLocked()
+}
+
+func (s *Server) hubS3CredentialsLocked() *pb.S3Credentials {
+	if s.hubS3Creds != nil {
+		return s.hubS3Creds
 	}
 
-	return nil
+	return &pb.S3Credentials{
+		AccessKey: s.cfg.HubAccessKey,
+		SecretKey: s.cfg.HubSecretKey,
+		Bucket:    s.cfg.Bucket,
+	}
 }
 
-type ManagementClient struct {
-	ID        []byte `gorm:"primary_key"`
-	Namespace string
+func (s *Server) currentRegisterToken() string {
+	s.tokenMu.RLock()
+	defer s.tokenMu.RUnlock()
+
+	return s.registerToken
 }
 
-var ErrBadAuthentication = errors.New("bad authentication information presented")
+// newID returns a new id from ServerConfig.NewID, or pb.NewULID if it isn't
+// set.
+func (s *Server) newID() *pb.ULID {
+	if s.cfg.NewID != nil {
+		return s.cfg.NewID()
+	}
+
+	return pb.NewULID()
+}
+
+// now returns the current time from ServerConfig.Clock, or clock.Real if it
+// isn't set.
+func (s *Server) now() time.Time {
+	if s.cfg.Clock != nil {
+		return s.cfg.Clock.Now()
+	}
+
+	return clock.Real.Now()
+}
+
+func (s *Server) currentOpsToken() string {
+	s.tokenMu.RLock()
+	defer s.tokenMu.RUnlock()
+
+	return s.opsToken
+}
 
 func (s *Server) GetManagementToken(ctx context.Context, namespace string) (string, error) {
 	var rec ManagementClient
 
-	err := dbx.Check(s.db.Where("namespace LIKE ?", namespace+"%").First(&rec))
+	err := dbx.Check(namespaceScope(s.db, namespace).First(&rec))
 	if err != nil {
 		if err != gorm.ErrRecordNotFound {
 			return "", err
 		}
-		rec.ID = pb.NewULID().Bytes()
+		rec.ID = s.newID().Bytes()
 		rec.Namespace = namespace
 
 		err = dbx.Check(s.db.Create(&rec))
@@ -784,24 +1520,14 @@ func (s *Server) GetManagementToken(ctx context.Context, namespace string) (stri
 }
 
 func (s *Server) Register(ctx context.Context, reg *pb.ControlRegister) (*pb.ControlToken, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return nil, ErrBadAuthentication
-	}
-
-	auth := md["authorization"]
-
-	if len(auth) < 1 {
-		return nil, ErrBadAuthentication
-	}
-
-	if auth[0] != s.registerToken {
-		return nil, ErrBadAuthentication
+	err := checkSecret(ctx, s.currentRegisterToken())
+	if err != nil {
+		return nil, err
 	}
 
 	var rec ManagementClient
 
-	err := dbx.Check(s.db.Where("namespace LIKE ?", reg.Namespace+"%").First(&rec))
+	err = dbx.Check(namespaceConflicts(s.db, reg.Namespace).First(&rec))
 	if err != nil {
 		if err != gorm.ErrRecordNotFound {
 			return nil, err
@@ -810,7 +1536,7 @@ func (s *Server) Register(ctx context.Context, reg *pb.ControlRegister) (*pb.Con
 		return nil, fmt.Errorf("namespace already in use")
 	}
 
-	rec.ID = pb.NewULID().Bytes()
+	rec.ID = s.newID().Bytes()
 	rec.Namespace = reg.Namespace
 
 	err = dbx.Check(s.db.Create(&rec))
@@ -833,19 +1559,9 @@ func (s *Server) Register(ctx context.Context, reg *pb.ControlRegister) (*pb.Con
 }
 
 func (s *Server) IssueHubToken(ctx context.Context, _ *pb.Noop) (*pb.CreateTokenResponse, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return nil, ErrBadAuthentication
-	}
-
-	auth := md["authorization"]
-
-	if len(auth) < 1 {
-		return nil, ErrBadAuthentication
-	}
-
-	if auth[0] != s.registerToken {
-		return nil, ErrBadAuthentication
+	err := checkSecret(ctx, s.currentRegisterToken())
+	if err != nil {
+		return nil, err
 	}
 
 	var tc token.TokenCreator
@@ -856,35 +1572,92 @@ func (s *Server) IssueHubToken(ctx context.Context, _ *pb.Noop) (*pb.CreateToken
 		return nil, err
 	}
 
-	return &pb.CreateTokenResponse{Token: token}, nil
+	return s.tokenResponse(token)
+}
+
+// checkToken validates the token the request was made with, whatever its role.
+func (s *Server) checkToken(ctx context.Context) (*token.ValidToken, error) {
+	return s.authFromContext(ctx, AnyRole)
 }
 
 func (s *Server) checkMgmtAllowed(ctx context.Context) (*token.ValidToken, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return nil, ErrBadAuthentication
+	return s.authFromContext(ctx, pb.MANAGE)
+}
+
+// callerNamespace returns the namespace that requests from caller default to
+// when they don't specify one. Management tokens don't always carry an account,
+// so fall back to the namespace they've been given access to.
+func callerNamespace(caller *token.ValidToken) string {
+	if acc := caller.Account(); acc != nil && acc.Namespace != "" {
+		return acc.Namespace
 	}
 
-	auth := md["authorization"]
+	_, ns := caller.HasCapability(pb.ACCESS)
+	return ns
+}
 
-	if len(auth) < 1 {
-		return nil, ErrBadAuthentication
+// checkAccountAllowed defaults the namespace of account to the callers if
+// it's unset and then verifies the caller is allowed to access the namespace.
+// All management requests that operate on an account should use this so that
+// the namespace hierarchy is applied the same way everywhere.
+func (s *Server) checkAccountAllowed(L hclog.Logger, caller *token.ValidToken, account *pb.Account) error {
+	if account == nil {
+		return errors.Wrapf(ErrInvalidRequest, "no account specified")
 	}
 
-	token, err := token.CheckTokenED25519(auth[0], s.pubKey)
-	if err != nil {
-		return nil, err
+	if account.Namespace == "" {
+		account.Namespace = callerNamespace(caller)
 	}
 
-	if token.Body.Role != pb.MANAGE {
-		return nil, ErrBadAuthentication
+	if !caller.AllowAccount(account.Namespace) {
+		L.Error(
+			"rejected access to account based on caller namespace",
+			"caller-namespace", callerNamespace(caller),
+			"requested-namespace", account.Namespace,
+		)
+
+		return errors.Wrapf(ErrInvalidRequest, "invalid namespace requested")
 	}
 
-	return token, nil
+	return nil
+}
+
+// namespaceScope limits db to rows whose namespace column is ns or is a
+// namespace below it.
+func namespaceScope(db *gorm.DB, ns string) *gorm.DB {
+	return db.Where("namespace = ? OR starts_with(namespace, ?)", ns, token.NamespaceChildPrefix(ns))
+}
+
+// namespaceConflicts limits db to rows whose namespace overlaps with ns. That
+// is ns itself, any namespace below it, and any namespace above it.
+func namespaceConflicts(db *gorm.DB, ns string) *gorm.DB {
+	return namespaceScope(db, ns).Or("starts_with(?, rtrim(namespace, '/') || '/')", ns)
+}
+
+// accountKeyScope limits db to rows whose account_id belongs to an account in
+// ns or a namespace below it. Account keys begin with the account's namespace,
+// so this is a pair of range checks that can use the account_id indexes.
+func accountKeyScope(db *gorm.DB, ns string) *gorm.DB {
+	exact := []byte(ns + "!")
+	child := []byte(token.NamespaceChildPrefix(ns))
+
+	return db.Where(
+		"(account_id >= ? AND account_id < ?) OR (account_id >= ? AND account_id < ?)",
+		exact, prefixEnd(exact), child, prefixEnd(child),
+	)
+}
+
+// prefixEnd returns the smallest key that sorts after every key starting with
+// prefix. The prefixes used here always end in '!' or '/', so the last byte
+// never overflows.
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte(nil), prefix...)
+	end[len(end)-1]++
+	return end
 }
 
 func (s *Server) AddAccount(ctx context.Context, req *pb.AddAccountRequest) (*pb.Noop, error) {
-	L := s.L.Named("add-account")
+	L := s.requestLogger(ctx).Named("add-account")
 
 	L.Info("adding new account",
 		"account", req.Account.SpecString(),
@@ -897,22 +1670,18 @@ func (s *Server) AddAccount(ctx context.Context, req *pb.AddAccountRequest) (*pb
 		return nil, err
 	}
 
-	if req.Account.Namespace == "" {
-		req.Account.Namespace = caller.Account().Namespace
+	err = s.checkAccountAllowed(L, caller, req.Account)
+	if err != nil {
+		return nil, err
 	}
 
-	if !caller.AllowAccount(req.Account.Namespace) {
-		L.Error(
-			"rejected access to account based on caller namespace",
-			"caller-namespace", caller.Account().Namespace,
-			"requested-namespace", req.Account.Namespace,
-		)
-
-		return nil, errors.Wrapf(ErrInvalidRequest, "invalid namespace requested")
+	key, err := accountKey(req.Account)
+	if err != nil {
+		return nil, err
 	}
 
 	var ao Account
-	ao.ID = req.Account.Key()
+	ao.ID = key
 	ao.Namespace = req.Account.Namespace
 	err = ao.Data.Set("limits", req.Limits)
 	if err != nil {
@@ -930,6 +1699,70 @@ func (s *Server) AddAccount(ctx context.Context, req *pb.AddAccountRequest) (*pb
 	return &pb.Noop{}, nil
 }
 
+// CreateAccount provisions an account without issuing a token for it, so
+// things like routing can be set up ahead of time. Creating an account that
+// already exists is not an error. The limits are only updated if the request
+// includes them.
+func (s *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.CreateAccountResponse, error) {
+	L := s.requestLogger(ctx).Named("create-account")
+
+	caller, err := s.checkMgmtAllowed(ctx)
+	if err != nil {
+		L.Error("error checking mgmt token", "err", err)
+		return nil, err
+	}
+
+	err = s.checkAccountAllowed(L, caller, req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := accountKey(req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	L.Info("creating account", "account", req.Account.SpecString())
+
+	var ao Account
+	ao.ID = key
+	ao.Namespace = req.Account.Namespace
+
+	conflict := "ON CONFLICT (id) DO UPDATE SET namespace = EXCLUDED.namespace"
+
+	if req.Limits != nil {
+		err = ao.Data.Set("limits", req.Limits)
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidRequest, "error parsing limits: %s", err)
+		}
+
+		conflict += ", data = EXCLUDED.data"
+	}
+
+	err = dbx.Check(s.db.Set("gorm:insert_option", conflict).Create(&ao))
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return nil, errors.Wrapf(err, "creating account record")
+		}
+	}
+
+	// Read the record back, since an existing account keeps its limits.
+	var saved Account
+
+	err = dbx.Check(s.db.First(&saved, key))
+	if err != nil {
+		return nil, err
+	}
+
+	var limits pb.Account_Limits
+	saved.Data.Get("limits", &limits)
+
+	return &pb.CreateAccountResponse{
+		Account: req.Account,
+		Limits:  &limits,
+	}, nil
+}
+
 type LabelLink struct {
 	ID int `gorm:"primary_key"`
 
@@ -939,12 +1772,16 @@ type LabelLink struct {
 	Labels string
 	Target string
 
+	// The targets requests are split between instead of going to Target, as
+	// a marshaled pb.TargetSplits. Nil when there aren't any.
+	Splits []byte
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
 func (s *Server) AddLabelLink(ctx context.Context, req *pb.AddLabelLinkRequest) (*pb.Noop, error) {
-	L := s.L.Named("add-label-link")
+	L := s.requestLogger(ctx).Named("add-label-link")
 
 	L.Info("adding new label-link",
 		"account", req.Account.SpecString(),
@@ -958,23 +1795,34 @@ func (s *Server) AddLabelLink(ctx context.Context, req *pb.AddLabelLinkRequest)
 		return nil, err
 	}
 
-	if req.Account.Namespace == "" {
-		req.Account.Namespace = caller.Account().Namespace
+	err = s.checkAccountAllowed(L, caller, req.Account)
+	if err != nil {
+		return nil, err
 	}
 
-	if !caller.AllowAccount(req.Account.Namespace) {
-		L.Error(
-			"rejected access to account based on caller namespace",
-			"caller-namespace", caller.Account().Namespace,
-			"requested-namespace", req.Account.Namespace,
-		)
+	err = s.checkLabelLimits("label-link labels", req.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkLabelLimits("label-link target", req.Target)
+	if err != nil {
+		return nil, err
+	}
 
-		return nil, errors.Wrapf(ErrInvalidRequest, "invalid namespace requested")
+	err = checkLabelLink(req.Labels, req.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := accountKey(req.Account)
+	if err != nil {
+		return nil, err
 	}
 
 	var ao Account
 
-	de := s.db.First(&ao, req.Account.Key())
+	de := s.db.First(&ao, key)
 
 	err = dbx.Check(de)
 	if err != nil {
@@ -985,7 +1833,7 @@ func (s *Server) AddLabelLink(ctx context.Context, req *pb.AddLabelLinkRequest)
 	L.Trace("account for label-link initialized correctly")
 
 	var llr LabelLink
-	llr.AccountID = req.Account.Key()
+	llr.AccountID = key
 	llr.Labels = FlattenLabels(req.Labels)
 	llr.Target = FlattenLabels(req.Target)
 
@@ -1008,10 +1856,24 @@ func (s *Server) AddLabelLink(ctx context.Context, req *pb.AddLabelLinkRequest)
 		Limits:  &pblimit,
 	}}
 
-	L.Trace("broadcasting new label-link activity")
-	s.broadcastActivity(ctx, &pb.CentralActivity{
+	act := &pb.CentralActivity{
 		NewLabelLinks: &out,
-	})
+	}
+
+	// Send the account's current services along with the label link, so any
+	// hub that can now route to the account warms its view of it rather than
+	// having to fetch it on the first request. Every hub gets the label link,
+	// so every hub gets these too.
+	routes, err := s.accountServices(ctx, s.db, req.Account)
+	if err != nil {
+		L.Error("error resolving routes for label-link", "error", err)
+	} else {
+		routes.Account = req.Account
+		act.ResolvedRoutes = []*pb.AccountServices{routes}
+	}
+
+	L.Trace("broadcasting new label-link activity")
+	s.broadcastActivity(act)
 
 	err = s.updateLabelLinks(ctx)
 	if err != nil {
@@ -1027,12 +1889,18 @@ func (s *Server) RemoveLabelLink(ctx context.Context, req *pb.RemoveLabelLinkReq
 		return nil, err
 	}
 
-	if !caller.AllowAccount(req.Account.Namespace) {
-		return nil, errors.Wrapf(ErrInvalidRequest, "invalid namespace requested")
+	err = s.checkAccountAllowed(s.L, caller, req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := accountKey(req.Account)
+	if err != nil {
+		return nil, err
 	}
 
 	var llr LabelLink
-	llr.AccountID = req.Account.Key()
+	llr.AccountID = key
 	llr.Labels = FlattenLabels(req.Labels)
 
 	err = dbx.Check(s.db.
@@ -1045,6 +1913,17 @@ func (s *Server) RemoveLabelLink(ctx context.Context, req *pb.RemoveLabelLinkReq
 		return nil, err
 	}
 
+	// Tell the hubs the link has been withdrawn so they stop routing it
+	// without waiting for the next label link sync.
+	s.broadcastActivity(&pb.CentralActivity{
+		RemovedLabelLinks: &pb.LabelLinks{
+			LabelLinks: []*pb.LabelLink{{
+				Account: req.Account,
+				Labels:  req.Labels,
+			}},
+		},
+	})
+
 	err = s.updateLabelLinks(ctx)
 	if err != nil {
 		return nil, err
@@ -1055,56 +1934,242 @@ func (s *Server) RemoveLabelLink(ctx context.Context, req *pb.RemoveLabelLinkReq
 
 var ErrInvalidRequest = errors.New("invalid request")
 
+// accountKey returns the key account is stored under, rejecting accounts
+// whose id and namespace can't be collapsed into a key unambiguously.
+func accountKey(account *pb.Account) ([]byte, error) {
+	err := account.Validate()
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidRequest, "%s", err)
+	}
+
+	return account.Key(), nil
+}
+
 func (s *Server) CreateToken(ctx context.Context, req *pb.CreateTokenRequest) (*pb.CreateTokenResponse, error) {
 	caller, err := s.checkMgmtAllowed(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if !caller.AllowAccount(req.Account.Namespace) {
-		return nil, errors.Wrapf(ErrInvalidRequest, "invalid namespace requested")
+	key, err := s.checkTokenRequest(caller, req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = upsertAccount(s.db, key, req.Account.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.mintToken(req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.recordIssuedToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.tokenResponse(token)
+}
+
+// checkTokenRequest checks that caller is allowed to create the token
+// described by req, returning the key of the account it's for.
+func (s *Server) checkTokenRequest(caller *token.ValidToken, req *pb.CreateTokenRequest) ([]byte, error) {
+	if req == nil {
+		return nil, errors.Wrapf(ErrInvalidRequest, "no token request specified")
+	}
+
+	err := s.checkAccountAllowed(s.L, caller, req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := accountKey(req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	suspended, err := accountSuspended(s.db, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if suspended {
+		return nil, ErrAccountSuspended
+	}
+
+	max := s.cfg.MaxTokenCapabilities
+	if max <= 0 {
+		max = DefaultMaxTokenCapabilities
+	}
+
+	if len(req.Capabilities) > max {
+		return nil, status.Errorf(codes.InvalidArgument, "too many capabilities requested: %d, limit is %d", len(req.Capabilities), max)
 	}
 
 	// If the caller is requesting access capability, make sure it's under the callers namespace
 	for _, cb := range req.Capabilities {
+		if _, ok := pb.Capability_name[int32(cb.Capability)]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown capability requested: %d", cb.Capability)
+		}
+
+		if cb.Capability == pb.HUB_SCOPE && cb.Value == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "hub scope capability requested without labels")
+		}
+
 		if cb.Capability == pb.ACCESS {
+			if cb.Value == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "access capability requested without a namespace")
+			}
+
 			if !caller.AllowAccount(cb.Value) {
 				return nil, errors.Wrapf(ErrInvalidRequest, "invalid namespace requested in access capability")
 			}
 		}
 	}
 
-	var dur time.Duration
-
-	if req.ValidDuration != nil {
-		dur = req.ValidDuration.ToDuration()
-	}
+	return key, nil
+}
 
+// upsertAccount makes sure there is an account record for key, leaving an
+// existing record's data alone.
+func upsertAccount(db *gorm.DB, key []byte, namespace string) error {
 	var ao Account
-	ao.ID = req.Account.Key()
-	ao.Namespace = req.Account.Namespace
+	ao.ID = key
+	ao.Namespace = namespace
 
-	de := s.db.Set("gorm:insert_option", "ON CONFLICT (id) DO UPDATE SET namespace = EXCLUDED.namespace").Create(&ao)
+	de := db.Set("gorm:insert_option", "ON CONFLICT (id) DO UPDATE SET namespace = EXCLUDED.namespace").Create(&ao)
 
-	err = dbx.Check(de)
+	err := dbx.Check(de)
 	if err != nil {
 		if err != sql.ErrNoRows {
-			return nil, errors.Wrapf(err, "creating account record")
+			return errors.Wrapf(err, "creating account record")
 		}
 	}
 
+	return nil
+}
+
+func (s *Server) mintToken(req *pb.CreateTokenRequest) (string, error) {
+	var dur time.Duration
+
+	if req.ValidDuration != nil {
+		dur = req.ValidDuration.ToDuration()
+	}
+
 	var tc token.TokenCreator
 	tc.AccountId = req.Account.AccountId
 	tc.AccuntNamespace = req.Account.Namespace
 	tc.RawCapabilities = req.Capabilities
 	tc.ValidDuration = dur
 
-	token, err := tc.EncodeED25519WithVault(s.vaultClient, s.vaultPath, s.keyId)
+	return tc.EncodeED25519WithVault(s.vaultClient, s.vaultPath, s.keyId)
+}
+
+// tokenResponse returns the response for a token that was just minted,
+// describing what it grants.
+func (s *Server) tokenResponse(tok string) (*pb.CreateTokenResponse, error) {
+	vt, err := token.CheckTokenED25519(tok, s.pubKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decoding minted token")
+	}
+
+	return &pb.CreateTokenResponse{
+		Token:        tok,
+		Role:         vt.Body.Role,
+		TokenId:      vt.Body.Id,
+		Account:      vt.Body.Account,
+		Capabilities: vt.Body.Capabilities,
+		ValidUntil:   vt.Body.ValidUntil,
+	}, nil
+}
+
+// CreateTokens creates a batch of tokens in one call, for provisioning many
+// accounts at once. Every request is checked first, then the accounts of the
+// valid ones are created in a single transaction, and then the tokens are
+// signed. The results are in the same order as the requests. Unless
+// AllOrNothing is set, a request that can't be satisfied only has its own
+// result marked with the error.
+func (s *Server) CreateTokens(ctx context.Context, req *pb.CreateTokensRequest) (*pb.CreateTokensResponse, error) {
+	L := s.requestLogger(ctx).Named("create-tokens")
+
+	caller, err := s.checkMgmtAllowed(ctx)
+	if err != nil {
+		L.Error("error checking mgmt token", "err", err)
+		return nil, err
+	}
+
+	results := make([]*pb.CreateTokenResult, len(req.Tokens))
+	keys := make([][]byte, len(req.Tokens))
+
+	for i, treq := range req.Tokens {
+		key, err := s.checkTokenRequest(caller, treq)
+		if err != nil {
+			if req.AllOrNothing {
+				return nil, errors.Wrapf(err, "token %d", i)
+			}
+
+			results[i] = &pb.CreateTokenResult{Error: err.Error()}
+			continue
+		}
+
+		keys[i] = key
+	}
+
+	L.Info("creating tokens", "requested", len(req.Tokens))
+
+	tx := s.db.Begin()
+
+	for i, key := range keys {
+		if key == nil {
+			continue
+		}
+
+		err = upsertAccount(tx, key, req.Tokens[i].Account.Namespace)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	err = dbx.Check(tx.Commit())
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.CreateTokenResponse{Token: token}, nil
+	for i, key := range keys {
+		if key == nil {
+			continue
+		}
+
+		token, err := s.mintToken(req.Tokens[i])
+		if err != nil {
+			if req.AllOrNothing {
+				return nil, errors.Wrapf(err, "token %d", i)
+			}
+
+			L.Error("error creating token", "account", req.Tokens[i].Account.SpecString(), "error", err)
+			results[i] = &pb.CreateTokenResult{Error: err.Error()}
+			continue
+		}
+
+		err = s.recordIssuedToken(token)
+		if err != nil {
+			if req.AllOrNothing {
+				return nil, errors.Wrapf(err, "token %d", i)
+			}
+
+			L.Error("error recording token", "account", req.Tokens[i].Account.SpecString(), "error", err)
+			results[i] = &pb.CreateTokenResult{Error: err.Error()}
+			continue
+		}
+
+		results[i] = &pb.CreateTokenResult{Token: token}
+	}
+
+	return &pb.CreateTokensResponse{Results: results}, nil
 }
 
 const DefaultListAccountsLimit = 100
@@ -1131,15 +2196,13 @@ func (s *Server) ListAccounts(ctx context.Context, req *pb.ListAccountsRequest)
 
 	if len(req.Marker) > 0 {
 		err = dbx.Check(
-			s.db.Where("id > ?", req.Marker).
-				Where("namespace = ? OR starts_with(namespace, ?)", ns, ns+"/").
+			namespaceScope(s.db.Where("id > ?", req.Marker), ns).
 				Limit(limit).Order("id ASC").
 				Find(&accounts),
 		)
 	} else {
 		err = dbx.Check(
-			s.db.
-				Where("namespace = ? OR starts_with(namespace, ?)", ns, ns+"/").
+			namespaceScope(s.db, ns).
 				Limit(limit).Order("id ASC").
 				Find(&accounts),
 		)
@@ -1170,31 +2233,199 @@ func (s *Server) ListAccounts(ctx context.Context, req *pb.ListAccountsRequest)
 	return &resp, nil
 }
 
-func (s *Server) AllHubs(ctx context.Context, _ *pb.Noop) (*pb.ListOfHubs, error) {
-	var hubs []*Hub
+const DefaultListManagementClientsLimit = 100
+
+// ListManagementClients returns the namespaces that have been registered. The
+// creation time is taken from the client's id, which is a ULID generated at
+// registration. Only callers presenting the ops token are allowed.
+func (s *Server) ListManagementClients(ctx context.Context, req *pb.ListManagementClientsRequest) (*pb.ListManagementClientsResponse, error) {
+	if !s.checkOpsAllowed(ctx) {
+		return nil, ErrBadAuthentication
+	}
+
+	var clients []*ManagementClient
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultListManagementClientsLimit
+	}
+
+	db := s.db
+
+	if len(req.Marker) > 0 {
+		db = db.Where("id > ?", req.Marker)
+	}
+
+	err := dbx.Check(db.Limit(limit).Order("id ASC").Find(&clients))
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			return nil, err
+		}
+	}
+
+	var resp pb.ListManagementClientsResponse
+	if len(clients) == 0 {
+		return &resp, nil
+	}
+
+	resp.NextMarker = clients[len(clients)-1].ID
+
+	for _, mc := range clients {
+		id := pb.ULIDFromBytes(mc.ID)
+
+		resp.Clients = append(resp.Clients, &pb.ManagementClient{
+			Id:        id,
+			Namespace: mc.Namespace,
+			CreatedAt: pb.NewTimestamp(id.Time()),
+		})
+	}
+
+	return &resp, nil
+}
+
+// UnregisterManagementClient removes the management client for a namespace,
+// allowing the namespace to be registered again. If the namespace still has
+// accounts, services, or label links the request is refused unless cascade is
+// set, in which case they are deleted along with the client. Only callers
+// presenting the ops token are allowed.
+//
+// Management tokens are self contained and there is no revocation list, so
+// tokens already issued for the namespace remain valid until they expire.
+func (s *Server) UnregisterManagementClient(ctx context.Context, req *pb.UnregisterRequest) (*pb.Noop, error) {
+	if !s.checkOpsAllowed(ctx) {
+		return nil, ErrBadAuthentication
+	}
+
+	L := s.requestLogger(ctx).Named("unregister")
+
+	L.Info("unregistering management client", "namespace", req.Namespace, "cascade", req.Cascade)
+
+	tx := s.db.Begin()
+
+	var rec ManagementClient
+
+	err := dbx.Check(tx.Where("namespace = ?", req.Namespace).First(&rec))
+	if err != nil {
+		tx.Rollback()
+
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.Wrapf(ErrInvalidRequest, "namespace not registered: %s", req.Namespace)
+		}
+
+		return nil, err
+	}
+
+	var accounts, services, links int
+
+	err = dbx.Check(namespaceScope(tx.Model(&Account{}), req.Namespace).Count(&accounts))
+	if err == nil {
+		err = dbx.Check(accountKeyScope(tx.Model(&Service{}), req.Namespace).Count(&services))
+	}
+
+	if err == nil {
+		err = dbx.Check(accountKeyScope(tx.Model(&LabelLink{}), req.Namespace).Count(&links))
+	}
 
-	err := dbx.Check(s.db.Find(&hubs))
 	if err != nil {
+		tx.Rollback()
 		return nil, err
 	}
 
-	var out pb.ListOfHubs
+	if accounts+services+links > 0 {
+		if !req.Cascade {
+			tx.Rollback()
+			return nil, errors.Wrapf(ErrInvalidRequest,
+				"namespace still in use: %d accounts, %d services, %d label-links",
+				accounts, services, links)
+		}
+
+		L.Info("removing namespace data", "accounts", accounts, "services", services, "label-links", links)
 
-	for _, h := range hubs {
-		var locs []*pb.NetworkLocation
+		err = dbx.Check(accountKeyScope(tx, req.Namespace).Delete(&LabelLink{}))
+		if err == nil {
+			err = dbx.Check(accountKeyScope(tx, req.Namespace).Delete(&Service{}))
+		}
+
+		if err == nil {
+			err = dbx.Check(namespaceScope(tx, req.Namespace).Delete(&Account{}))
+		}
 
-		err = json.Unmarshal(h.ConnectionInfo, &locs)
 		if err != nil {
+			tx.Rollback()
 			return nil, err
 		}
+	}
 
-		out.Hubs = append(out.Hubs, &pb.HubInfo{
-			Id:        pb.ULIDFromBytes(h.InstanceID),
-			Locations: locs,
-		})
+	err = dbx.Check(tx.Delete(&rec))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = dbx.Check(tx.Commit())
+	if err != nil {
+		return nil, err
+	}
+
+	// Without the label links, hubs no longer route any traffic to the
+	// namespace's services.
+	if links > 0 {
+		err = s.updateLabelLinks(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return &out, nil
+	return &pb.Noop{}, nil
+}
+
+// WhoAmI describes the token the request was made with, so a client can see
+// what it's allowed to do without having to decode the token itself. Any valid
+// token can call it, regardless of its role.
+func (s *Server) WhoAmI(ctx context.Context, _ *pb.Noop) (*pb.WhoAmIResponse, error) {
+	caller, err := s.checkToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, ns := caller.HasCapability(pb.ACCESS)
+
+	return &pb.WhoAmIResponse{
+		Role:            caller.Body.Role,
+		TokenId:         caller.Body.Id,
+		Account:         caller.Account(),
+		Capabilities:    caller.Body.Capabilities,
+		AccessNamespace: ns,
+		ValidUntil:      caller.Body.ValidUntil,
+	}, nil
+}
+
+// AllHubs lists the hubs and their locations. When req.Version matches the
+// current list, it's left out and NotModified is set instead, so that callers
+// polling for changes don't have to receive the whole list each time.
+func (s *Server) AllHubs(ctx context.Context, req *pb.AllHubsRequest) (*pb.ListOfHubs, error) {
+	_, err := s.checkFromHub(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	snap, err := s.currentHubSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Version != "" && req.Version == snap.version {
+		return &pb.ListOfHubs{
+			Version:     snap.version,
+			NotModified: true,
+		}, nil
+	}
+
+	return &pb.ListOfHubs{
+		Hubs:    snap.hubs,
+		Skipped: snap.skipped,
+		Version: snap.version,
+	}, nil
 }
 
 func (s *Server) RequestServiceToken(ctx context.Context, req *pb.ServiceTokenRequest) (*pb.ServiceTokenResponse, error) {
@@ -1216,10 +2447,22 @@ func (s *Server) RequestServiceToken(ctx context.Context, req *pb.ServiceTokenRe
 		},
 	}
 
+	if req.HubScope != nil && len(req.HubScope.Labels) > 0 {
+		tc.RawCapabilities = append(tc.RawCapabilities, pb.TokenCapability{
+			Capability: pb.HUB_SCOPE,
+			Value:      req.HubScope.SpecString(),
+		})
+	}
+
 	token, err := tc.EncodeED25519WithVault(s.vaultClient, s.vaultPath, s.keyId)
 	if err != nil {
 		return nil, err
 	}
 
+	err = s.recordIssuedToken(token)
+	if err != nil {
+		return nil, err
+	}
+
 	return &pb.ServiceTokenResponse{Token: token}, nil
 }
@@ -0,0 +1,216 @@
+package control
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/vault/api"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+var ErrMissingConfig = errors.New("missing required configuration")
+
+// Validate checks that all the values NewServer requires are set, returning
+// an error that lists every one that isn't.
+func (cfg *ServerConfig) Validate() error {
+	var err error
+
+	for _, req := range []struct {
+		name string
+		set  bool
+	}{
+		{"DB", cfg.DB != nil},
+		{"RegisterToken", cfg.RegisterToken != ""},
+		{"VaultClient", cfg.VaultClient != nil},
+		{"VaultPath", cfg.VaultPath != ""},
+		{"KeyId", cfg.KeyId != ""},
+		{"AwsSession", cfg.AwsSession != nil},
+		{"Bucket", cfg.Bucket != ""},
+		{"LockTable", cfg.LockTable != ""},
+	} {
+		if !req.set {
+			err = multierror.Append(err, errors.Wrapf(ErrMissingConfig, "%s", req.name))
+		}
+	}
+
+	return err
+}
+
+// serverFileConfig is the form of ServerConfig that is read from a file. The
+// database, vault, and AWS values are used to create the connections that
+// ServerConfig holds.
+type serverFileConfig struct {
+	DatabaseURL string `hcl:"database_url"`
+
+	RegisterToken string `hcl:"register_token"`
+	OpsToken      string `hcl:"ops_token"`
+
+	VaultAddr string `hcl:"vault_addr"`
+	VaultPath string `hcl:"vault_path"`
+	KeyId     string `hcl:"key_id"`
+
+	Bucket    string `hcl:"bucket"`
+	LockTable string `hcl:"lock_table"`
+
+	ASNDB string `hcl:"asn_db"`
+
+	HubAccessKey string `hcl:"hub_access_key"`
+	HubSecretKey string `hcl:"hub_secret_key"`
+	HubImageTag  string `hcl:"hub_image_tag"`
+
+	ActivityCompressor string `hcl:"activity_compressor"`
+
+	DataDogAddr       string `hcl:"datadog_addr"`
+	DisablePrometheus bool   `hcl:"disable_prometheus"`
+	DisableInmemSink  bool   `hcl:"disable_inmem_sink"`
+	MetricsBestEffort bool   `hcl:"metrics_best_effort"`
+
+	MetricsPrefix string            `hcl:"metrics_prefix"`
+	MetricsLabels map[string]string `hcl:"metrics_labels"`
+}
+
+// The environment variables that override values in the file. These are the
+// same variables the hzn control command reads.
+func (c *serverFileConfig) envOverrides() map[string]*string {
+	return map[string]*string{
+		"DATABASE_URL":        &c.DatabaseURL,
+		"REGISTER_TOKEN":      &c.RegisterToken,
+		"OPS_TOKEN":           &c.OpsToken,
+		"VAULT_ADDR":          &c.VaultAddr,
+		"VAULT_PATH":          &c.VaultPath,
+		"KEY_ID":              &c.KeyId,
+		"S3_BUCKET":           &c.Bucket,
+		"DYNAMO_TABLE":        &c.LockTable,
+		"ASN_DB_PATH":         &c.ASNDB,
+		"HUB_ACCESS_KEY":      &c.HubAccessKey,
+		"HUB_SECRET_KEY":      &c.HubSecretKey,
+		"HUB_IMAGE_TAG":       &c.HubImageTag,
+		"ACTIVITY_COMPRESSOR": &c.ActivityCompressor,
+		"DATADOG_ADDR":        &c.DataDogAddr,
+		"METRICS_PREFIX":      &c.MetricsPrefix,
+	}
+}
+
+func (c *serverFileConfig) validate() error {
+	var missing []string
+
+	for _, req := range []struct {
+		name, env, value string
+	}{
+		{"database_url", "DATABASE_URL", c.DatabaseURL},
+		{"register_token", "REGISTER_TOKEN", c.RegisterToken},
+		{"ops_token", "OPS_TOKEN", c.OpsToken},
+		{"vault_path", "VAULT_PATH", c.VaultPath},
+		{"key_id", "KEY_ID", c.KeyId},
+		{"bucket", "S3_BUCKET", c.Bucket},
+		{"lock_table", "DYNAMO_TABLE", c.LockTable},
+	} {
+		if req.value == "" {
+			missing = append(missing, req.name+" (or "+req.env+")")
+		}
+	}
+
+	if len(missing) > 0 {
+		return errors.Wrapf(ErrMissingConfig, "%s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// LoadServerConfig reads a ServerConfig from the HCL or JSON file at path,
+// with any of the matching environment variables taking precedence over the
+// file. If path is empty, only the environment is used. All the required
+// values are checked before connecting to the database, so a config with
+// several missing values reports them all at once.
+func LoadServerConfig(path string) (ServerConfig, error) {
+	fc := serverFileConfig{
+		VaultPath: "hzn-k1",
+		KeyId:     "k1",
+	}
+
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return ServerConfig{}, err
+		}
+
+		err = hcl.Decode(&fc, string(data))
+		if err != nil {
+			return ServerConfig{}, errors.Wrapf(err, "parsing config file %s", path)
+		}
+	}
+
+	for env, field := range fc.envOverrides() {
+		if val := os.Getenv(env); val != "" {
+			*field = val
+		}
+	}
+
+	if os.Getenv("DISABLE_PROMETHEUS") != "" {
+		fc.DisablePrometheus = true
+	}
+
+	if os.Getenv("DISABLE_INMEM_SINK") != "" {
+		fc.DisableInmemSink = true
+	}
+
+	if os.Getenv("METRICS_BEST_EFFORT") != "" {
+		fc.MetricsBestEffort = true
+	}
+
+	err := fc.validate()
+	if err != nil {
+		return ServerConfig{}, err
+	}
+
+	vcfg := api.DefaultConfig()
+	if fc.VaultAddr != "" {
+		vcfg.Address = fc.VaultAddr
+	}
+
+	vc, err := api.NewClient(vcfg)
+	if err != nil {
+		return ServerConfig{}, err
+	}
+
+	db, err := gorm.Open("postgres", fc.DatabaseURL)
+	if err != nil {
+		return ServerConfig{}, err
+	}
+
+	return ServerConfig{
+		DB: db,
+
+		RegisterToken: fc.RegisterToken,
+		OpsToken:      fc.OpsToken,
+
+		VaultClient: vc,
+		VaultPath:   fc.VaultPath,
+		KeyId:       fc.KeyId,
+
+		AwsSession: session.New(),
+		Bucket:     fc.Bucket,
+		LockTable:  fc.LockTable,
+
+		ASNDB: fc.ASNDB,
+
+		HubAccessKey: fc.HubAccessKey,
+		HubSecretKey: fc.HubSecretKey,
+		HubImageTag:  fc.HubImageTag,
+
+		ActivityCompressor: fc.ActivityCompressor,
+
+		DataDogAddr:       fc.DataDogAddr,
+		DisablePrometheus: fc.DisablePrometheus,
+		DisableInmemSink:  fc.DisableInmemSink,
+		MetricsBestEffort: fc.MetricsBestEffort,
+
+		MetricsPrefix: fc.MetricsPrefix,
+		MetricsLabels: fc.MetricsLabels,
+	}, nil
+}
@@ -0,0 +1,202 @@
+package control
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/horizon/internal/testsql"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadServerConfig(t *testing.T) {
+	// Make sure nothing in the environment leaks into the tests.
+	var fc serverFileConfig
+	for env := range fc.envOverrides() {
+		if val, ok := os.LookupEnv(env); ok {
+			os.Unsetenv(env)
+			defer os.Setenv(env, val)
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "hzn")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	writeConfig := func(t *testing.T, name, data string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(data), 0644))
+		return path
+	}
+
+	t.Run("loads a valid hcl config", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		path := writeConfig(t, "valid.hcl", `
+database_url = "`+testsql.TestPostgresDBString(t, "hzn")+`"
+register_token = "aabbcc"
+ops_token = "ddeeff"
+bucket = "hzn-test"
+lock_table = "hzntest"
+hub_image_tag = "hzn:test"
+disable_prometheus = true
+metrics_best_effort = true
+`)
+
+		cfg, err := LoadServerConfig(path)
+		require.NoError(t, err)
+
+		defer cfg.DB.Close()
+
+		assert.Equal(t, "aabbcc", cfg.RegisterToken)
+		assert.Equal(t, "ddeeff", cfg.OpsToken)
+		assert.Equal(t, "hzn-test", cfg.Bucket)
+		assert.Equal(t, "hzntest", cfg.LockTable)
+		assert.Equal(t, "hzn:test", cfg.HubImageTag)
+		assert.True(t, cfg.DisablePrometheus)
+		assert.True(t, cfg.MetricsBestEffort)
+
+		// Defaults that match what hzn control has always used.
+		assert.Equal(t, "hzn-k1", cfg.VaultPath)
+		assert.Equal(t, "k1", cfg.KeyId)
+
+		assert.NotNil(t, cfg.VaultClient)
+		assert.NotNil(t, cfg.AwsSession)
+		assert.NoError(t, cfg.DB.DB().Ping())
+	})
+
+	t.Run("loads json and applies env overrides", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		path := writeConfig(t, "valid.json", `{
+  "database_url": "`+testsql.TestPostgresDBString(t, "hzn")+`",
+  "register_token": "aabbcc",
+  "ops_token": "ddeeff",
+  "bucket": "hzn-test",
+  "lock_table": "hzntest"
+}`)
+
+		os.Setenv("S3_BUCKET", "hzn-override")
+		defer os.Unsetenv("S3_BUCKET")
+
+		cfg, err := LoadServerConfig(path)
+		require.NoError(t, err)
+
+		defer cfg.DB.Close()
+
+		assert.Equal(t, "aabbcc", cfg.RegisterToken)
+		assert.Equal(t, "hzn-override", cfg.Bucket)
+	})
+
+	t.Run("reports a missing required field", func(t *testing.T) {
+		path := writeConfig(t, "no-db.hcl", `
+register_token = "aabbcc"
+ops_token = "ddeeff"
+bucket = "hzn-test"
+lock_table = "hzntest"
+`)
+
+		_, err := LoadServerConfig(path)
+		require.Error(t, err)
+
+		assert.Equal(t, ErrMissingConfig, errors.Cause(err))
+		assert.Contains(t, err.Error(), "database_url (or DATABASE_URL)")
+		assert.NotContains(t, err.Error(), "bucket")
+	})
+
+	t.Run("reports every missing required field at once", func(t *testing.T) {
+		path := writeConfig(t, "partial.hcl", `
+database_url = "postgres://localhost/hzn"
+register_token = "aabbcc"
+`)
+
+		_, err := LoadServerConfig(path)
+		require.Error(t, err)
+
+		assert.Equal(t, ErrMissingConfig, errors.Cause(err))
+
+		for _, name := range []string{"ops_token", "bucket", "lock_table"} {
+			assert.Contains(t, err.Error(), name)
+		}
+
+		assert.NotContains(t, err.Error(), "database_url")
+		assert.NotContains(t, err.Error(), "register_token")
+	})
+
+	t.Run("uses only the environment without a file", func(t *testing.T) {
+		os.Setenv("REGISTER_TOKEN", "aabbcc")
+		defer os.Unsetenv("REGISTER_TOKEN")
+
+		_, err := LoadServerConfig("")
+		require.Error(t, err)
+
+		assert.Equal(t, ErrMissingConfig, errors.Cause(err))
+		assert.Contains(t, err.Error(), "database_url")
+		assert.NotContains(t, err.Error(), "register_token")
+	})
+
+	t.Run("errors on a file that doesn't parse", func(t *testing.T) {
+		path := writeConfig(t, "bad.hcl", `database_url = `)
+
+		_, err := LoadServerConfig(path)
+		require.Error(t, err)
+
+		assert.NotEqual(t, ErrMissingConfig, errors.Cause(err))
+	})
+}
+
+func TestServerConfigValidate(t *testing.T) {
+	t.Run("lists every missing field of an empty config", func(t *testing.T) {
+		var cfg ServerConfig
+
+		err := cfg.Validate()
+		require.Error(t, err)
+
+		merr, ok := err.(*multierror.Error)
+		require.True(t, ok)
+
+		var missing []string
+
+		for _, err := range merr.Errors {
+			assert.Equal(t, ErrMissingConfig, errors.Cause(err))
+			missing = append(missing, err.Error())
+		}
+
+		assert.Equal(t, []string{
+			"DB: missing required configuration",
+			"RegisterToken: missing required configuration",
+			"VaultClient: missing required configuration",
+			"VaultPath: missing required configuration",
+			"KeyId: missing required configuration",
+			"AwsSession: missing required configuration",
+			"Bucket: missing required configuration",
+			"LockTable: missing required configuration",
+		}, missing)
+	})
+
+	t.Run("NewServer fails before doing any work", func(t *testing.T) {
+		s, err := NewServer(ServerConfig{
+			RegisterToken: "aabbcc",
+			VaultPath:     "hzn-k1",
+			KeyId:         "k1",
+			LockTable:     "hzntest",
+		})
+		require.Error(t, err)
+
+		assert.Nil(t, s)
+
+		merr, ok := err.(*multierror.Error)
+		require.True(t, ok)
+
+		assert.Equal(t, 4, len(merr.Errors))
+		assert.Contains(t, err.Error(), "Bucket")
+		assert.NotContains(t, err.Error(), "LockTable")
+	})
+}
@@ -42,8 +42,10 @@ func (s *Server) GetAllNetworkLocations() ([]*pb.NetworkLocation, error) {
 
 func (s *Server) setupRoutes() {
 	s.mux.HandleFunc("/healthz", s.httpHealthz)
+	s.mux.HandleFunc("/readyz", s.httpReadyz)
 	s.mux.HandleFunc("/ip-info", s.httpIPInfo)
 	s.mux.HandleFunc("/ulid", s.genUlid)
+	s.mux.HandleFunc(DebugStatePath, s.httpDebugState)
 
 	var wk discovery.WellKnown
 	wk.GetNetlocs = s
@@ -59,8 +61,19 @@ func (s *Server) httpHealthz(w http.ResponseWriter, req *http.Request) {
 	w.WriteHeader(200)
 }
 
+// httpReadyz reports whether the server is ready for traffic, so a load
+// balancer only sends it requests once it has an activity source connected.
+func (s *Server) httpReadyz(w http.ResponseWriter, req *http.Request) {
+	if !s.Ready() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	w.WriteHeader(200)
+}
+
 func (s *Server) genUlid(w http.ResponseWriter, req *http.Request) {
-	u := pb.NewULID()
+	u := s.newID()
 
 	if req.Header.Get("Accept") == "application/json" {
 		json.NewEncoder(w).Encode(map[string]string{
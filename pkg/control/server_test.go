@@ -1,31 +1,50 @@
 package control
 
 import (
+	"bytes"
 	context "context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"cirello.io/dynamolock"
+	"github.com/armon/go-metrics"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/horizon/internal/testsql"
+	"github.com/hashicorp/horizon/pkg/clock"
 	"github.com/hashicorp/horizon/pkg/dbx"
+	grpctoken "github.com/hashicorp/horizon/pkg/grpc/token"
+	"github.com/hashicorp/horizon/pkg/grpc/zstd"
 	"github.com/hashicorp/horizon/pkg/pb"
 	"github.com/hashicorp/horizon/pkg/testutils"
 	"github.com/hashicorp/horizon/pkg/token"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type staticServerStream struct {
-	ctx   context.Context
-	SendC chan *pb.CentralActivity
-	RecvC chan *pb.HubActivity
+	ctx    context.Context
+	SendC  chan *pb.CentralActivity
+	RecvC  chan *pb.HubActivity
+	header metadata.MD
 }
 
 func (s *staticServerStream) Send(act *pb.CentralActivity) error {
@@ -37,8 +56,9 @@ func (s *staticServerStream) Recv() (*pb.HubActivity, error) {
 	return <-s.RecvC, nil
 }
 
-func (s *staticServerStream) SetHeader(_ metadata.MD) error {
-	panic("not implemented")
+func (s *staticServerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
 }
 
 func (s *staticServerStream) SendHeader(_ metadata.MD) error {
@@ -61,6 +81,44 @@ func (s *staticServerStream) RecvMsg(m interface{}) error {
 	panic("not implemented")
 }
 
+type staticServiceStream struct {
+	ctx  context.Context
+	sent []*pb.ListServicesResponse
+	cb   func()
+}
+
+func (s *staticServiceStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *staticServiceStream) Send(resp *pb.ListServicesResponse) error {
+	s.sent = append(s.sent, resp)
+	if s.cb != nil {
+		s.cb()
+	}
+	return nil
+}
+
+func (s *staticServiceStream) SetHeader(_ metadata.MD) error {
+	panic("not implemented")
+}
+
+func (s *staticServiceStream) SendHeader(_ metadata.MD) error {
+	panic("not implemented")
+}
+
+func (s *staticServiceStream) SetTrailer(_ metadata.MD) {
+	panic("not implemented")
+}
+
+func (s *staticServiceStream) SendMsg(m interface{}) error {
+	panic("not implemented")
+}
+
+func (s *staticServiceStream) RecvMsg(m interface{}) error {
+	panic("not implemented")
+}
+
 func TestServer(t *testing.T) {
 	vc := testutils.SetupVault()
 	sess := testutils.AWSSession(t)
@@ -146,7 +204,7 @@ func TestServer(t *testing.T) {
 		assert.True(t, errors.Is(ErrBadAuthentication, err))
 	})
 
-	t.Run("can create a new agent token using a management token", func(t *testing.T) {
+	t.Run("scopes tokens to the hubs with matching locations", func(t *testing.T) {
 		db := testsql.TestPostgresDB(t, "hzn")
 		defer db.Close()
 
@@ -165,49 +223,85 @@ func TestServer(t *testing.T) {
 
 		top := context.Background()
 
-		md := make(metadata.MD)
-		md.Set("authorization", "aabbcc")
-
-		ctx := metadata.NewIncomingContext(top, md)
+		authCtx := func(auth string) context.Context {
+			md := make(metadata.MD)
+			md.Set("authorization", auth)
+			return metadata.NewIncomingContext(top, md)
+		}
 
-		ct, err := s.Register(ctx, &pb.ControlRegister{
+		ct, err := s.Register(authCtx("aabbcc"), &pb.ControlRegister{
 			Namespace: "/",
 		})
+		require.NoError(t, err)
 
+		ctr, err := s.IssueHubToken(authCtx("aabbcc"), &pb.Noop{})
 		require.NoError(t, err)
 
-		md2 := make(metadata.MD)
-		md2.Set("authorization", ct.Token)
+		east := []*pb.NetworkLocation{
+			{Labels: pb.ParseLabelSet("region=us-east,zone=a")},
+		}
 
-		accountId := pb.NewULID()
+		west := []*pb.NetworkLocation{
+			{Labels: pb.ParseLabelSet("region=us-west,zone=a")},
+		}
 
-		ctr, err := s.CreateToken(
-			metadata.NewIncomingContext(top, md2),
-			&pb.CreateTokenRequest{
-				Account: &pb.Account{
-					Namespace: "/",
-					AccountId: accountId,
-				},
-				Capabilities: []pb.TokenCapability{
-					{
-						Capability: pb.SERVE,
-					},
-				},
-				ValidDuration: pb.TimestampFromDuration(6 * time.Hour),
+		_, err = s.CreateToken(authCtx(ct.Token), &pb.CreateTokenRequest{
+			Account: &pb.Account{
+				Namespace: "/",
+				AccountId: pb.NewULID(),
 			},
-		)
+			Capabilities: []pb.TokenCapability{
+				{Capability: pb.CONNECT},
+				{Capability: pb.HUB_SCOPE},
+			},
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		atr, err := s.CreateToken(authCtx(ct.Token), &pb.CreateTokenRequest{
+			Account: &pb.Account{
+				Namespace: "/",
+				AccountId: pb.NewULID(),
+			},
+			Capabilities: []pb.TokenCapability{
+				{Capability: pb.CONNECT},
+				{Capability: pb.HUB_SCOPE, Value: "region=us-east"},
+			},
+		})
 		require.NoError(t, err)
 
-		ht, err := token.CheckTokenED25519(ctr.Token, pub)
+		vt, err := token.CheckTokenED25519(atr.Token, pub)
 		require.NoError(t, err)
 
-		assert.Equal(t, 1, len(ht.Body.Capabilities))
+		assert.True(t, vt.AllowHub(east))
+		assert.False(t, vt.AllowHub(west))
 
-		ok, _ := ht.HasCapability(pb.SERVE)
-		require.True(t, ok)
+		str, err := s.RequestServiceToken(authCtx(ctr.Token), &pb.ServiceTokenRequest{
+			Namespace: "/",
+			HubScope:  pb.ParseLabelSet("region=us-west"),
+		})
+		require.NoError(t, err)
+
+		vt, err = token.CheckTokenED25519(str.Token, pub)
+		require.NoError(t, err)
+
+		assert.False(t, vt.AllowHub(east))
+		assert.True(t, vt.AllowHub(west))
+
+		str, err = s.RequestServiceToken(authCtx(ctr.Token), &pb.ServiceTokenRequest{
+			Namespace: "/",
+		})
+		require.NoError(t, err)
+
+		vt, err = token.CheckTokenED25519(str.Token, pub)
+		require.NoError(t, err)
+
+		assert.Nil(t, vt.HubScope())
+		assert.True(t, vt.AllowHub(east))
+		assert.True(t, vt.AllowHub(west))
 	})
 
-	t.Run("disallows creating an agent token in a different namespace", func(t *testing.T) {
+	t.Run("authenticates each role through the shared helper", func(t *testing.T) {
 		db := testsql.TestPostgresDB(t, "hzn")
 		defer db.Close()
 
@@ -218,6 +312,7 @@ func TestServer(t *testing.T) {
 		s.vaultPath = pb.NewULID().SpecString()
 		s.keyId = "k1"
 		s.registerToken = "aabbcc"
+		s.opsToken = "opsrocks"
 
 		pub, err := token.SetupVault(vc, s.vaultPath)
 		require.NoError(t, err)
@@ -226,41 +321,95 @@ func TestServer(t *testing.T) {
 
 		top := context.Background()
 
-		md := make(metadata.MD)
-		md.Set("authorization", "aabbcc")
-
-		ctx := metadata.NewIncomingContext(top, md)
+		authCtx := func(auth string) context.Context {
+			md := make(metadata.MD)
+			md.Set("authorization", auth)
+			return metadata.NewIncomingContext(top, md)
+		}
 
-		ct, err := s.Register(ctx, &pb.ControlRegister{
-			Namespace: "/foo",
+		ct, err := s.Register(authCtx("aabbcc"), &pb.ControlRegister{
+			Namespace: "/",
 		})
+		require.NoError(t, err)
 
+		ctr, err := s.IssueHubToken(authCtx("aabbcc"), &pb.Noop{})
 		require.NoError(t, err)
 
-		md2 := make(metadata.MD)
-		md2.Set("authorization", ct.Token)
+		atr, err := s.CreateToken(authCtx(ct.Token), &pb.CreateTokenRequest{
+			Account: &pb.Account{
+				Namespace: "/",
+				AccountId: pb.NewULID(),
+			},
+		})
+		require.NoError(t, err)
 
-		accountId := pb.NewULID()
+		tokens := map[pb.TokenRole]string{
+			pb.MANAGE: ct.Token,
+			pb.HUB:    ctr.Token,
+			pb.AGENT:  atr.Token,
+		}
 
-		_, err = s.CreateToken(
-			metadata.NewIncomingContext(top, md2),
-			&pb.CreateTokenRequest{
-				Account: &pb.Account{
-					Namespace: "/bar",
-					AccountId: accountId,
-				},
-				Capabilities: []pb.TokenCapability{
-					{
-						Capability: pb.SERVE,
-					},
-				},
-				ValidDuration: pb.TimestampFromDuration(6 * time.Hour),
-			},
-		)
-		require.Error(t, err)
+		for role, tok := range tokens {
+			for _, want := range []pb.TokenRole{pb.MANAGE, pb.HUB, pb.AGENT, AnyRole} {
+				vt, err := s.authFromContext(authCtx(tok), want)
+
+				if want == role || want == AnyRole {
+					require.NoError(t, err, "role: %s, want: %s", role, want)
+					assert.Equal(t, role, vt.Body.Role)
+				} else {
+					assert.True(t, errors.Is(err, ErrBadAuthentication), "role: %s, want: %s", role, want)
+				}
+			}
+		}
+
+		for _, ctx := range []context.Context{top, authCtx(""), authCtx("aabbcc"), authCtx("opsrocks")} {
+			_, err = s.authFromContext(ctx, AnyRole)
+			assert.Error(t, err)
+		}
+
+		// The secrets are only accepted where they're expected.
+		assert.NoError(t, checkSecret(authCtx("aabbcc"), s.currentRegisterToken()))
+		assert.Error(t, checkSecret(authCtx(ct.Token), s.currentRegisterToken()))
+		assert.Error(t, checkSecret(authCtx(""), ""))
+		assert.True(t, s.checkOpsAllowed(authCtx("opsrocks")))
+		assert.False(t, s.checkOpsAllowed(authCtx("aabbcc")))
+
+		_, err = s.AllHubs(top, &pb.AllHubsRequest{})
+		assert.Error(t, err)
+
+		_, err = s.AllHubs(authCtx(ct.Token), &pb.AllHubsRequest{})
+		assert.Error(t, err)
+
+		_, err = s.AllHubs(authCtx(ctr.Token), &pb.AllHubsRequest{})
+		assert.NoError(t, err)
+
+		// The interceptor validates the token once and hands it to the
+		// handler through the context.
+		var seen *token.ValidToken
+
+		_, err = s.UnaryAuthInterceptor(authCtx(ctr.Token), nil,
+			&grpc.UnaryServerInfo{FullMethod: "/pb.ControlServices/AllHubs"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				seen, _ = ctx.Value(validTokenKey{}).(*token.ValidToken)
+				return s.checkFromHub(ctx)
+			})
+		require.NoError(t, err)
+
+		require.NotNil(t, seen)
+		assert.Equal(t, pb.HUB, seen.Body.Role)
+
+		// Requests without a valid token are passed along for the handler to
+		// reject.
+		_, err = s.UnaryAuthInterceptor(authCtx("aabbcc"), nil,
+			&grpc.UnaryServerInfo{FullMethod: "/pb.ControlManagement/IssueHubToken"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				assert.Nil(t, ctx.Value(validTokenKey{}))
+				return s.IssueHubToken(ctx, &pb.Noop{})
+			})
+		require.NoError(t, err)
 	})
 
-	t.Run("allows creating a token in a sub-namespace", func(t *testing.T) {
+	t.Run("enforces the roles declared for each rpc", func(t *testing.T) {
 		db := testsql.TestPostgresDB(t, "hzn")
 		defer db.Close()
 
@@ -279,49 +428,179 @@ func TestServer(t *testing.T) {
 
 		top := context.Background()
 
-		md := make(metadata.MD)
-		md.Set("authorization", "aabbcc")
-
-		ctx := metadata.NewIncomingContext(top, md)
+		authCtx := func(auth string) context.Context {
+			md := make(metadata.MD)
+			md.Set("authorization", auth)
+			return metadata.NewIncomingContext(top, md)
+		}
 
-		ct, err := s.Register(ctx, &pb.ControlRegister{
-			Namespace: "/foo",
+		ct, err := s.Register(authCtx("aabbcc"), &pb.ControlRegister{
+			Namespace: "/",
 		})
+		require.NoError(t, err)
 
+		ctr, err := s.IssueHubToken(authCtx("aabbcc"), &pb.Noop{})
 		require.NoError(t, err)
 
-		md2 := make(metadata.MD)
-		md2.Set("authorization", ct.Token)
+		// Every rpc the server registers has its roles declared.
+		gs := grpc.NewServer()
+		pb.RegisterControlServicesServer(gs, &s)
+		pb.RegisterControlManagementServer(gs, &s)
+		pb.RegisterFlowTopReporterServer(gs, &s)
 
-		accountId := pb.NewULID()
+		for name, info := range gs.GetServiceInfo() {
+			for _, m := range info.Methods {
+				method := "/" + name + "/" + m.Name
 
-		ctr, err := s.CreateToken(
-			metadata.NewIncomingContext(top, md2),
-			&pb.CreateTokenRequest{
-				Account: &pb.Account{
-					Namespace: "/foo/bar",
-					AccountId: accountId,
-				},
-				Capabilities: []pb.TokenCapability{
-					{
-						Capability: pb.SERVE,
-					},
-				},
-				ValidDuration: pb.TimestampFromDuration(6 * time.Hour),
-			},
-		)
-		require.NoError(t, err)
+				_, ok := methodRoles[method]
+				assert.True(t, ok, "no roles declared for %s", method)
+			}
+		}
 
-		ht, err := token.CheckTokenED25519(ctr.Token, pub)
+		tokens := map[pb.TokenRole]string{
+			pb.MANAGE: ct.Token,
+			pb.HUB:    ctr.Token,
+		}
+
+		var checked int
+
+		for method, roles := range methodRoles {
+			if len(roles) != 1 || roles[0] == AnyRole {
+				continue
+			}
+
+			for role, tok := range tokens {
+				_, err := s.authorizeMethod(authCtx(tok), method)
+
+				if role == roles[0] {
+					assert.NoError(t, err, "method: %s, role: %s", method, role)
+				} else {
+					assert.True(t, errors.Is(err, ErrBadAuthentication), "method: %s, role: %s", method, role)
+					checked++
+				}
+			}
+		}
+
+		assert.True(t, checked > 0)
+
+		called := func(ctx context.Context, req interface{}) (interface{}, error) {
+			t.Error("handler called with the wrong role")
+			return nil, nil
+		}
+
+		// A hub token can't reach a management rpc.
+		_, err = s.UnaryAuthInterceptor(authCtx(ctr.Token), nil,
+			&grpc.UnaryServerInfo{FullMethod: "/pb.ControlManagement/CreateToken"}, called)
+		assert.True(t, errors.Is(err, ErrBadAuthentication))
+
+		// Nor can a management token reach a hub rpc.
+		_, err = s.UnaryAuthInterceptor(authCtx(ct.Token), nil,
+			&grpc.UnaryServerInfo{FullMethod: "/pb.ControlServices/FetchConfig"}, called)
+		assert.True(t, errors.Is(err, ErrBadAuthentication))
+
+		err = s.StreamAuthInterceptor(&s, &staticServerStream{ctx: authCtx(ct.Token)},
+			&grpc.StreamServerInfo{FullMethod: "/pb.ControlServices/StreamActivity"},
+			func(srv interface{}, ss grpc.ServerStream) error {
+				t.Error("handler called with the wrong role")
+				return nil
+			})
+		assert.True(t, errors.Is(err, ErrBadAuthentication))
+
+		// Any role can ask who it is.
+		_, err = s.UnaryAuthInterceptor(authCtx(ctr.Token), nil,
+			&grpc.UnaryServerInfo{FullMethod: "/pb.ControlManagement/WhoAmI"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return s.WhoAmI(ctx, &pb.Noop{})
+			})
+		assert.NoError(t, err)
+
+		// Rpcs without declared roles are refused outright.
+		_, err = s.UnaryAuthInterceptor(authCtx(ct.Token), nil,
+			&grpc.UnaryServerInfo{FullMethod: "/pb.ControlManagement/Undeclared"}, called)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("ties the logs of a request together with its id", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		var s Server
+		s.L = hclog.New(&hclog.LoggerOptions{
+			Output:     &buf,
+			Level:      hclog.Trace,
+			JSONFormat: true,
+		})
+
+		top := context.Background()
+
+		logged := func() map[string][]string {
+			ids := make(map[string][]string)
+
+			dec := json.NewDecoder(&buf)
+
+			for dec.More() {
+				var line map[string]interface{}
+				require.NoError(t, dec.Decode(&line))
+
+				id, _ := line["request_id"].(string)
+				ids[id] = append(ids[id], line["@message"].(string))
+			}
+
+			return ids
+		}
+
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			L := s.requestLogger(ctx)
+
+			L.Info("first")
+			L.Named("nested").Info("second")
+
+			return nil, nil
+		}
+
+		info := &grpc.UnaryServerInfo{FullMethod: "/pb.ControlManagement/ResolveDebug"}
+
+		// The client's id is used when it sends one.
+		_, err := s.UnaryAuthInterceptor(
+			metadata.NewIncomingContext(top, metadata.Pairs(RequestIDHeader, "req-1")),
+			nil, info, handler)
 		require.NoError(t, err)
 
-		assert.Equal(t, 1, len(ht.Body.Capabilities))
+		assert.Equal(t, map[string][]string{
+			"req-1": {"handling request", "first", "second"},
+		}, logged())
 
-		ok, _ := ht.HasCapability(pb.SERVE)
-		require.True(t, ok)
+		// Otherwise each request gets its own.
+		for i := 0; i < 2; i++ {
+			_, err = s.UnaryAuthInterceptor(top, nil, info, handler)
+			require.NoError(t, err)
+		}
+
+		ids := logged()
+		require.Equal(t, 2, len(ids))
+
+		for id, lines := range ids {
+			assert.NotEqual(t, "", id)
+			assert.Equal(t, []string{"handling request", "first", "second"}, lines)
+		}
+
+		// Streams send the id back in their header, even when they're
+		// rejected.
+		ss := &staticServerStream{
+			ctx: metadata.NewIncomingContext(top, metadata.Pairs(RequestIDHeader, "req-2")),
+		}
+
+		err = s.StreamAuthInterceptor(&s, ss,
+			&grpc.StreamServerInfo{FullMethod: "/pb.ControlServices/StreamActivity"},
+			func(srv interface{}, ss grpc.ServerStream) error {
+				t.Error("handler called without a token")
+				return nil
+			})
+		assert.Error(t, err)
+
+		assert.Equal(t, []string{"req-2"}, ss.header.Get(RequestIDHeader))
 	})
 
-	t.Run("disallows creating an agent token in a common prefix but without separater", func(t *testing.T) {
+	t.Run("can create a new agent token using a management token", func(t *testing.T) {
 		db := testsql.TestPostgresDB(t, "hzn")
 		defer db.Close()
 
@@ -346,7 +625,7 @@ func TestServer(t *testing.T) {
 		ctx := metadata.NewIncomingContext(top, md)
 
 		ct, err := s.Register(ctx, &pb.ControlRegister{
-			Namespace: "/foo",
+			Namespace: "/",
 		})
 
 		require.NoError(t, err)
@@ -356,11 +635,11 @@ func TestServer(t *testing.T) {
 
 		accountId := pb.NewULID()
 
-		_, err = s.CreateToken(
+		ctr, err := s.CreateToken(
 			metadata.NewIncomingContext(top, md2),
 			&pb.CreateTokenRequest{
 				Account: &pb.Account{
-					Namespace: "/foobar",
+					Namespace: "/",
 					AccountId: accountId,
 				},
 				Capabilities: []pb.TokenCapability{
@@ -371,10 +650,18 @@ func TestServer(t *testing.T) {
 				ValidDuration: pb.TimestampFromDuration(6 * time.Hour),
 			},
 		)
-		require.Error(t, err)
+		require.NoError(t, err)
+
+		ht, err := token.CheckTokenED25519(ctr.Token, pub)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, len(ht.Body.Capabilities))
+
+		ok, _ := ht.HasCapability(pb.SERVE)
+		require.True(t, ok)
 	})
 
-	t.Run("can list all accounts in the namespace for a mgmt token", func(t *testing.T) {
+	t.Run("records issued tokens for listing within a namespace", func(t *testing.T) {
 		db := testsql.TestPostgresDB(t, "hzn")
 		defer db.Close()
 
@@ -393,117 +680,5370 @@ func TestServer(t *testing.T) {
 
 		top := context.Background()
 
-		md := make(metadata.MD)
-		md.Set("authorization", "aabbcc")
+		authCtx := func(auth string) context.Context {
+			md := make(metadata.MD)
+			md.Set("authorization", auth)
+			return metadata.NewIncomingContext(top, md)
+		}
 
-		ctx := metadata.NewIncomingContext(top, md)
+		cta, err := s.Register(authCtx("aabbcc"), &pb.ControlRegister{
+			Namespace: "/a",
+		})
+		require.NoError(t, err)
 
-		ct, err := s.Register(ctx, &pb.ControlRegister{
-			Namespace: "/foo",
+		ctb, err := s.Register(authCtx("aabbcc"), &pb.ControlRegister{
+			Namespace: "/b",
 		})
+		require.NoError(t, err)
 
+		hub, err := s.IssueHubToken(authCtx("aabbcc"), &pb.Noop{})
 		require.NoError(t, err)
 
-		md2 := make(metadata.MD)
-		md2.Set("authorization", ct.Token)
+		createToken := func(mgmt, ns string) *pb.CreateTokenResponse {
+			resp, err := s.CreateToken(authCtx(mgmt), &pb.CreateTokenRequest{
+				Account: &pb.Account{
+					Namespace: ns,
+					AccountId: pb.NewULID(),
+				},
+				Capabilities: []pb.TokenCapability{
+					{Capability: pb.SERVE},
+				},
+				ValidDuration: pb.TimestampFromDuration(time.Hour),
+			})
+			require.NoError(t, err)
 
-		accountId := pb.NewULID()
-		accountId2 := pb.NewULID()
-		accountId3 := pb.NewULID()
+			return resp
+		}
 
-		mgmtCtx := metadata.NewIncomingContext(top, md2)
+		list := func(mgmt string) []*pb.IssuedToken {
+			resp, err := s.ListIssuedTokens(authCtx(mgmt), &pb.ListTokensRequest{})
+			require.NoError(t, err)
 
-		_, err = s.CreateToken(
-			mgmtCtx,
-			&pb.CreateTokenRequest{
-				Account: &pb.Account{
-					Namespace: "/foo",
-					AccountId: accountId,
-				},
-			},
-		)
+			return resp.Tokens
+		}
+
+		ids := func(tokens []*pb.IssuedToken) []*pb.ULID {
+			var out []*pb.ULID
+			for _, it := range tokens {
+				out = append(out, it.TokenId)
+			}
+
+			return out
+		}
+
+		// Nothing is recorded unless it's enabled.
+		createToken(cta.Token, "/a")
+		assert.Empty(t, list(cta.Token))
+
+		s.cfg.RecordIssuedTokens = true
+
+		a1 := createToken(cta.Token, "/a")
+		a2 := createToken(cta.Token, "/a/child")
+		b1 := createToken(ctb.Token, "/b")
+
+		str, err := s.RequestServiceToken(authCtx(hub.Token), &pb.ServiceTokenRequest{
+			Namespace: "/a",
+		})
 		require.NoError(t, err)
 
+		svc, err := token.CheckTokenED25519(str.Token, pub)
+		require.NoError(t, err)
+
+		aTokens := list(cta.Token)
+		assert.ElementsMatch(t, []*pb.ULID{a1.TokenId, a2.TokenId, svc.Body.Id}, ids(aTokens))
+		assert.ElementsMatch(t, []*pb.ULID{b1.TokenId}, ids(list(ctb.Token)))
+
+		var found bool
+
+		for _, it := range aTokens {
+			if !it.TokenId.Equal(a1.TokenId) {
+				continue
+			}
+
+			found = true
+
+			assert.Equal(t, pb.AGENT, it.Role)
+			assert.Equal(t, a1.Account, it.Account)
+			assert.Equal(t, a1.Capabilities, it.Capabilities)
+
+			require.NotNil(t, it.ValidUntil)
+			assert.WithinDuration(t, a1.ValidUntil.Time(), it.ValidUntil.Time(), time.Second)
+
+			require.NotNil(t, it.IssuedAt)
+			assert.WithinDuration(t, time.Now(), it.IssuedAt.Time(), time.Minute)
+		}
+
+		assert.True(t, found)
+
+		// Only management tokens can list them.
+		_, err = s.ListIssuedTokens(authCtx(a1.Token), &pb.ListTokensRequest{})
+		assert.Error(t, err)
+
+		_, err = s.ListIssuedTokens(authCtx(hub.Token), &pb.ListTokensRequest{})
+		assert.Error(t, err)
+
+		// And they're paged by marker.
+		page1, err := s.ListIssuedTokens(authCtx(cta.Token), &pb.ListTokensRequest{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, page1.Tokens, 2)
+
+		page2, err := s.ListIssuedTokens(authCtx(cta.Token), &pb.ListTokensRequest{
+			Limit:  2,
+			Marker: page1.NextMarker,
+		})
+		require.NoError(t, err)
+		require.Len(t, page2.Tokens, 1)
+
+		assert.ElementsMatch(t, ids(aTokens), ids(append(page1.Tokens, page2.Tokens...)))
+	})
+
+	t.Run("describes what a created token grants", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ct, err := s.Register(metadata.NewIncomingContext(top, md), &pb.ControlRegister{
+			Namespace: "/",
+		})
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		account := &pb.Account{
+			Namespace: "/sub",
+			AccountId: pb.NewULID(),
+		}
+
+		caps := []pb.TokenCapability{
+			{Capability: pb.SERVE},
+			{Capability: pb.ACCESS, Value: "/sub"},
+		}
+
+		ctr, err := s.CreateToken(
+			metadata.NewIncomingContext(top, md2),
+			&pb.CreateTokenRequest{
+				Account:       account,
+				Capabilities:  caps,
+				ValidDuration: pb.TimestampFromDuration(time.Hour),
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, pb.AGENT, ctr.Role)
+		assert.Equal(t, account, ctr.Account)
+		assert.Equal(t, caps, ctr.Capabilities)
+
+		require.NotNil(t, ctr.ValidUntil)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), ctr.ValidUntil.Time(), time.Minute)
+
+		// It matches the token itself.
+		ht, err := token.CheckTokenED25519(ctr.Token, pub)
+		require.NoError(t, err)
+
+		assert.Equal(t, ht.Body.Id, ctr.TokenId)
+		assert.Equal(t, ht.Body.Capabilities, ctr.Capabilities)
+		assert.Equal(t, ht.Body.ValidUntil, ctr.ValidUntil)
+	})
+
+	t.Run("limits the capabilities a token can be created with", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.cfg.MaxTokenCapabilities = 3
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ct, err := s.Register(metadata.NewIncomingContext(top, md), &pb.ControlRegister{
+			Namespace: "/",
+		})
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		ctx := metadata.NewIncomingContext(top, md2)
+
+		create := func(caps ...pb.TokenCapability) (*pb.CreateTokenResponse, error) {
+			return s.CreateToken(ctx, &pb.CreateTokenRequest{
+				Account: &pb.Account{
+					Namespace: "/",
+					AccountId: pb.NewULID(),
+				},
+				Capabilities:  caps,
+				ValidDuration: pb.TimestampFromDuration(time.Hour),
+			})
+		}
+
+		t.Run("at the limit", func(t *testing.T) {
+			ctr, err := create(
+				pb.TokenCapability{Capability: pb.CONNECT},
+				pb.TokenCapability{Capability: pb.SERVE},
+				pb.TokenCapability{Capability: pb.ACCESS, Value: "/"},
+			)
+			require.NoError(t, err)
+
+			ht, err := token.CheckTokenED25519(ctr.Token, pub)
+			require.NoError(t, err)
+
+			assert.Equal(t, 3, len(ht.Body.Capabilities))
+		})
+
+		t.Run("over the limit", func(t *testing.T) {
+			_, err := create(
+				pb.TokenCapability{Capability: pb.CONNECT},
+				pb.TokenCapability{Capability: pb.SERVE},
+				pb.TokenCapability{Capability: pb.ACCESS, Value: "/"},
+				pb.TokenCapability{Capability: pb.CONFIG},
+			)
+			require.Error(t, err)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+
+		t.Run("with an access capability without a namespace", func(t *testing.T) {
+			_, err := create(
+				pb.TokenCapability{Capability: pb.ACCESS},
+			)
+			require.Error(t, err)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+
+		t.Run("with an unknown capability", func(t *testing.T) {
+			_, err := create(
+				pb.TokenCapability{Capability: pb.Capability(99)},
+			)
+			require.Error(t, err)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+
+	t.Run("disallows creating an agent token in a different namespace", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(top, md)
+
+		ct, err := s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/foo",
+		})
+
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		accountId := pb.NewULID()
+
 		_, err = s.CreateToken(
-			mgmtCtx,
+			metadata.NewIncomingContext(top, md2),
 			&pb.CreateTokenRequest{
 				Account: &pb.Account{
-					Namespace: "/foo/bar",
-					AccountId: accountId2,
+					Namespace: "/bar",
+					AccountId: accountId,
+				},
+				Capabilities: []pb.TokenCapability{
+					{
+						Capability: pb.SERVE,
+					},
 				},
+				ValidDuration: pb.TimestampFromDuration(6 * time.Hour),
 			},
 		)
+		require.Error(t, err)
+	})
+
+	t.Run("allows creating a token in a sub-namespace", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
 		require.NoError(t, err)
 
-		ct2, err := s.Register(ctx, &pb.ControlRegister{
-			Namespace: "/qux",
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(top, md)
+
+		ct, err := s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/foo",
 		})
 
 		require.NoError(t, err)
 
-		md3 := make(metadata.MD)
-		md3.Set("authorization", ct2.Token)
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
 
-		_, err = s.CreateToken(
-			metadata.NewIncomingContext(top, md3),
+		accountId := pb.NewULID()
+
+		ctr, err := s.CreateToken(
+			metadata.NewIncomingContext(top, md2),
 			&pb.CreateTokenRequest{
 				Account: &pb.Account{
-					Namespace: "/qux",
-					AccountId: accountId3,
+					Namespace: "/foo/bar",
+					AccountId: accountId,
+				},
+				Capabilities: []pb.TokenCapability{
+					{
+						Capability: pb.SERVE,
+					},
 				},
+				ValidDuration: pb.TimestampFromDuration(6 * time.Hour),
 			},
 		)
 		require.NoError(t, err)
 
-		list, err := s.ListAccounts(
-			mgmtCtx,
-			&pb.ListAccountsRequest{},
-		)
+		ht, err := token.CheckTokenED25519(ctr.Token, pub)
 		require.NoError(t, err)
 
-		require.Equal(t, 2, len(list.Accounts))
+		assert.Equal(t, 1, len(ht.Body.Capabilities))
 
-		assert.Equal(t, accountId, list.Accounts[0].AccountId)
-		assert.Equal(t, "/foo", list.Accounts[0].Namespace)
-		assert.Equal(t, accountId2, list.Accounts[1].AccountId)
-		assert.Equal(t, "/foo/bar", list.Accounts[1].Namespace)
+		ok, _ := ht.HasCapability(pb.SERVE)
+		require.True(t, ok)
+	})
 
-		list, err = s.ListAccounts(
-			mgmtCtx,
-			&pb.ListAccountsRequest{Limit: 1},
-		)
+	t.Run("defaults the account namespace to the callers", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(top, md)
+
+		ct, err := s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/foo",
+		})
+
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		ctr, err := s.CreateToken(
+			metadata.NewIncomingContext(top, md2),
+			&pb.CreateTokenRequest{
+				Account: &pb.Account{
+					AccountId: pb.NewULID(),
+				},
+				Capabilities: []pb.TokenCapability{
+					{
+						Capability: pb.SERVE,
+					},
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		ht, err := token.CheckTokenED25519(ctr.Token, pub)
+		require.NoError(t, err)
+
+		assert.Equal(t, "/foo", ht.Account().Namespace)
+	})
+
+	t.Run("disallows registering a namespace that overlaps an existing one", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+
+		_, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+
+		_, err = s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/foo/bar",
+		})
+		require.NoError(t, err)
+
+		_, err = s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/foo",
+		})
+		require.Error(t, err)
+
+		_, err = s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/foo/bar/baz",
+		})
+		require.Error(t, err)
+
+		_, err = s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/fo",
+		})
+		require.NoError(t, err)
+
+		_, err = s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/foo/barn",
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("disallows creating an agent token in a common prefix but without separater", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(top, md)
+
+		ct, err := s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/foo",
+		})
+
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		accountId := pb.NewULID()
+
+		_, err = s.CreateToken(
+			metadata.NewIncomingContext(top, md2),
+			&pb.CreateTokenRequest{
+				Account: &pb.Account{
+					Namespace: "/foobar",
+					AccountId: accountId,
+				},
+				Capabilities: []pb.TokenCapability{
+					{
+						Capability: pb.SERVE,
+					},
+				},
+				ValidDuration: pb.TimestampFromDuration(6 * time.Hour),
+			},
+		)
+		require.Error(t, err)
+	})
+
+	t.Run("can create an account without a token", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(top, md)
+
+		ct, err := s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/foo",
+		})
+
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		mgmtCtx := metadata.NewIncomingContext(top, md2)
+
+		account := &pb.Account{
+			Namespace: "/foo",
+			AccountId: pb.NewULID(),
+		}
+
+		resp, err := s.CreateAccount(mgmtCtx, &pb.CreateAccountRequest{
+			Account: account,
+			Limits: &pb.Account_Limits{
+				HttpRequests: 5,
+				Bandwidth:    100,
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, account, resp.Account)
+		assert.Equal(t, float64(5), resp.Limits.HttpRequests)
+
+		var ao Account
+		require.NoError(t, dbx.Check(db.First(&ao, account.Key())))
+
+		assert.Equal(t, "/foo", ao.Namespace)
+
+		// Creating it again without limits leaves the limits alone.
+		resp, err = s.CreateAccount(mgmtCtx, &pb.CreateAccountRequest{
+			Account: account,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, float64(5), resp.Limits.HttpRequests)
+		assert.Equal(t, float64(100), resp.Limits.Bandwidth)
+
+		resp, err = s.CreateAccount(mgmtCtx, &pb.CreateAccountRequest{
+			Account: account,
+			Limits: &pb.Account_Limits{
+				HttpRequests: 10,
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, float64(10), resp.Limits.HttpRequests)
+
+		var count int
+		require.NoError(t, dbx.Check(db.Model(&Account{}).Count(&count)))
+
+		assert.Equal(t, 1, count)
+
+		list, err := s.ListAccounts(mgmtCtx, &pb.ListAccountsRequest{})
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(list.Accounts))
+		assert.Equal(t, account.AccountId, list.Accounts[0].AccountId)
+
+		_, err = s.CreateAccount(mgmtCtx, &pb.CreateAccountRequest{
+			Account: &pb.Account{
+				Namespace: "/bar",
+				AccountId: pb.NewULID(),
+			},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("rejects accounts whose key doesn't match the account", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(top, md)
+
+		ct, err := s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/foo",
+		})
+
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		mgmtCtx := metadata.NewIncomingContext(top, md2)
+
+		account := &pb.Account{
+			Namespace: "/foo/bar",
+			AccountId: pb.NewULID(),
+		}
+
+		_, err = s.CreateToken(mgmtCtx, &pb.CreateTokenRequest{
+			Account: account,
+		})
+		require.NoError(t, err)
+
+		var ao Account
+		require.NoError(t, dbx.Check(db.First(&ao, account.Key())))
+
+		back, err := pb.AccountFromKey(ao.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, account.Namespace, back.Namespace)
+		assert.Equal(t, account.AccountId.Bytes(), back.AccountId.Bytes())
+
+		for _, bad := range []*pb.Account{
+			{Namespace: "/foo/bar!baz", AccountId: pb.NewULID()},
+			{Namespace: "/foo/bar"},
+		} {
+			_, err = s.CreateToken(mgmtCtx, &pb.CreateTokenRequest{
+				Account: bad,
+			})
+			require.Error(t, err)
+
+			assert.True(t, errors.Is(err, ErrInvalidRequest))
+
+			_, err = s.CreateAccount(mgmtCtx, &pb.CreateAccountRequest{
+				Account: bad,
+			})
+			require.Error(t, err)
+
+			assert.True(t, errors.Is(err, ErrInvalidRequest))
+		}
+
+		var count int
+		require.NoError(t, dbx.Check(db.Model(&Account{}).Count(&count)))
+
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("can create a batch of tokens, reporting each failure", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(top, md)
+
+		ct, err := s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/foo",
+		})
+
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		mgmtCtx := metadata.NewIncomingContext(top, md2)
+
+		accounts := []*pb.Account{
+			{Namespace: "/foo/a", AccountId: pb.NewULID()},
+			{Namespace: "/bar", AccountId: pb.NewULID()},
+			{Namespace: "/foo/b", AccountId: pb.NewULID()},
+		}
+
+		var req pb.CreateTokensRequest
+
+		for _, acc := range accounts {
+			req.Tokens = append(req.Tokens, &pb.CreateTokenRequest{
+				Account: acc,
+				Capabilities: []pb.TokenCapability{
+					{
+						Capability: pb.SERVE,
+					},
+				},
+			})
+		}
+
+		resp, err := s.CreateTokens(mgmtCtx, &req)
+		require.NoError(t, err)
+
+		require.Equal(t, 3, len(resp.Results))
+
+		assert.Empty(t, resp.Results[1].Token)
+		assert.Contains(t, resp.Results[1].Error, "invalid namespace")
+
+		for _, i := range []int{0, 2} {
+			require.Empty(t, resp.Results[i].Error)
+
+			ht, err := token.CheckTokenED25519(resp.Results[i].Token, pub)
+			require.NoError(t, err)
+
+			assert.Equal(t, accounts[i].StringKey(), ht.Account().StringKey())
+
+			var ao Account
+			require.NoError(t, dbx.Check(db.First(&ao, accounts[i].Key())))
+		}
+
+		var count int
+		require.NoError(t, dbx.Check(db.Model(&Account{}).Count(&count)))
+
+		assert.Equal(t, 2, count)
+
+		// With all_or_nothing the disallowed namespace fails the whole batch
+		// before any more accounts are created.
+		for _, acc := range accounts {
+			acc.AccountId = pb.NewULID()
+		}
+
+		req.AllOrNothing = true
+
+		_, err = s.CreateTokens(mgmtCtx, &req)
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, ErrInvalidRequest))
+
+		require.NoError(t, dbx.Check(db.Model(&Account{}).Count(&count)))
+
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("describes the caller's own token", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(top, md)
+
+		_, err = s.WhoAmI(ctx, &pb.Noop{})
+		require.Error(t, err)
+
+		ct, err := s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/foo",
+		})
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		resp, err := s.WhoAmI(metadata.NewIncomingContext(top, md2), &pb.Noop{})
+		require.NoError(t, err)
+
+		mt, err := token.CheckTokenED25519(ct.Token, pub)
+		require.NoError(t, err)
+
+		assert.Equal(t, pb.MANAGE, resp.Role)
+		assert.Equal(t, mt.Body.Id, resp.TokenId)
+		assert.Equal(t, "/foo", resp.AccessNamespace)
+		assert.Nil(t, resp.ValidUntil)
+
+		ok, val := mt.HasCapability(pb.ACCESS)
+		require.True(t, ok)
+
+		assert.Equal(t, "/foo", val)
+		assert.Equal(t, mt.Body.Capabilities, resp.Capabilities)
+
+		ctr, err := s.IssueHubToken(ctx, &pb.Noop{})
+		require.NoError(t, err)
+
+		md3 := make(metadata.MD)
+		md3.Set("authorization", ctr.Token)
+
+		resp, err = s.WhoAmI(metadata.NewIncomingContext(top, md3), &pb.Noop{})
+		require.NoError(t, err)
+
+		assert.Equal(t, pb.HUB, resp.Role)
+		assert.Equal(t, "", resp.AccessNamespace)
+		assert.Empty(t, resp.Capabilities)
+	})
+
+	t.Run("renews tokens within their renewal window", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.cfg.TokenRenewalWindow = time.Hour
+		s.cfg.TokenMaxTTL = 2 * time.Hour
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ct, err := s.Register(metadata.NewIncomingContext(top, md), &pb.ControlRegister{
+			Namespace: "/",
+		})
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		mctx := metadata.NewIncomingContext(top, md2)
+
+		// Management tokens don't expire, so there's nothing to renew.
+		ts, err := s.TokenStatus(mctx, &pb.Noop{})
+		require.NoError(t, err)
+
+		assert.False(t, ts.Renewable)
+		assert.Nil(t, ts.ValidUntil)
+
+		_, err = s.RenewToken(mctx, &pb.Noop{})
+		require.Error(t, err)
+
+		accountId := pb.NewULID()
+
+		createToken := func(dur time.Duration) context.Context {
+			ctr, err := s.CreateToken(mctx, &pb.CreateTokenRequest{
+				Account: &pb.Account{
+					Namespace: "/",
+					AccountId: accountId,
+				},
+				Capabilities: []pb.TokenCapability{
+					{
+						Capability: pb.SERVE,
+					},
+				},
+				ValidDuration: pb.TimestampFromDuration(dur),
+			})
+			require.NoError(t, err)
+
+			md := make(metadata.MD)
+			md.Set("authorization", ctr.Token)
+
+			return metadata.NewIncomingContext(top, md)
+		}
+
+		t.Run("too early", func(t *testing.T) {
+			ctx := createToken(6 * time.Hour)
+
+			ts, err := s.TokenStatus(ctx, &pb.Noop{})
+			require.NoError(t, err)
+
+			assert.True(t, ts.Renewable)
+			assert.False(t, ts.WithinRenewalWindow)
+			assert.True(t, ts.NotBefore.Time().Before(time.Now().Add(time.Second)))
+			assert.True(t, ts.ValidUntil.Time().Add(-time.Hour).Equal(ts.RenewAfter.Time()))
+
+			_, err = s.RenewToken(ctx, &pb.Noop{})
+			assert.True(t, errors.Is(err, ErrTooEarlyToRenew))
+		})
+
+		t.Run("within the window", func(t *testing.T) {
+			ctx := createToken(30 * time.Minute)
+
+			ts, err := s.TokenStatus(ctx, &pb.Noop{})
+			require.NoError(t, err)
+
+			assert.True(t, ts.Renewable)
+			assert.True(t, ts.WithinRenewalWindow)
+
+			resp, err := s.RenewToken(ctx, &pb.Noop{})
+			require.NoError(t, err)
+
+			vt, err := token.CheckTokenED25519(resp.Token, pub)
+			require.NoError(t, err)
+
+			assert.True(t, vt.Body.Account.AccountId.Equal(accountId))
+			assert.NotEqual(t, ts.TokenId, vt.Body.Id)
+
+			ok, _ := vt.HasCapability(pb.SERVE)
+			assert.True(t, ok)
+
+			ttl := time.Until(vt.Body.ValidUntil.Time())
+			assert.True(t, ttl > 29*time.Minute && ttl <= 30*time.Minute, "ttl: %s", ttl)
+		})
+
+		t.Run("capped at the max ttl", func(t *testing.T) {
+			s.cfg.TokenRenewalWindow = 24 * time.Hour
+
+			ctx := createToken(6 * time.Hour)
+
+			resp, err := s.RenewToken(ctx, &pb.Noop{})
+			require.NoError(t, err)
+
+			vt, err := token.CheckTokenED25519(resp.Token, pub)
+			require.NoError(t, err)
+
+			ttl := time.Until(vt.Body.ValidUntil.Time())
+			assert.True(t, ttl > 119*time.Minute && ttl <= 2*time.Hour, "ttl: %s", ttl)
+		})
+	})
+
+	t.Run("only gives config to hubs the authorizer allows", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		allowed := pb.NewULID()
+		denied := pb.NewULID()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.connectedHubs = make(map[string]*connectedHub)
+		s.cfg.HubAuthorizer = HubAllowList{allowed}
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctr, err := s.IssueHubToken(metadata.NewIncomingContext(top, md), &pb.Noop{})
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ctr.Token)
+
+		hubCtx := metadata.NewIncomingContext(top, md2)
+
+		resp, err := s.FetchConfig(hubCtx, &pb.ConfigRequest{
+			StableId:   allowed,
+			InstanceId: pb.NewULID(),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []byte(pub), resp.TokenPub)
+
+		_, err = s.FetchConfig(hubCtx, &pb.ConfigRequest{
+			StableId:   denied,
+			InstanceId: pb.NewULID(),
+		})
+		require.Error(t, err)
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		var hubs []*Hub
+		require.NoError(t, dbx.Check(db.Find(&hubs)))
+
+		require.Equal(t, 1, len(hubs))
+		assert.Equal(t, allowed.Bytes(), hubs[0].StableID)
+
+		var stream staticServerStream
+		stream.ctx = hubCtx
+		stream.SendC = make(chan *pb.CentralActivity, 1)
+		stream.RecvC = make(chan *pb.HubActivity, 1)
+
+		stream.RecvC <- &pb.HubActivity{
+			HubReg: &pb.HubActivity_HubRegistration{
+				Hub:       pb.NewULID(),
+				StableHub: denied,
+			},
+		}
+
+		err = s.StreamActivity(&stream)
+		require.Error(t, err)
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Equal(t, 0, len(s.connectedHubs))
+
+		// Without an authorizer, any hub with a valid token is allowed.
+		s.cfg.HubAuthorizer = nil
+
+		_, err = s.FetchConfig(hubCtx, &pb.ConfigRequest{
+			StableId:   denied,
+			InstanceId: pb.NewULID(),
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects activity streams without a valid hub registration", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.connectedHubs = make(map[string]*connectedHub)
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctr, err := s.IssueHubToken(metadata.NewIncomingContext(top, md), &pb.Noop{})
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ctr.Token)
+
+		hubCtx := metadata.NewIncomingContext(top, md2)
+
+		for _, tc := range []struct {
+			name string
+			msg  *pb.HubActivity
+			code codes.Code
+		}{
+			{
+				name: "missing registration",
+				msg: &pb.HubActivity{
+					Flow: []*pb.FlowRecord{{}},
+				},
+				code: codes.FailedPrecondition,
+			},
+			{
+				name: "missing hub id",
+				msg: &pb.HubActivity{
+					HubReg: &pb.HubActivity_HubRegistration{
+						StableHub: pb.NewULID(),
+					},
+				},
+				code: codes.InvalidArgument,
+			},
+			{
+				name: "empty hub id",
+				msg: &pb.HubActivity{
+					HubReg: &pb.HubActivity_HubRegistration{
+						Hub:       &pb.ULID{},
+						StableHub: pb.NewULID(),
+					},
+				},
+				code: codes.InvalidArgument,
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				var stream staticServerStream
+				stream.ctx = hubCtx
+				stream.RecvC = make(chan *pb.HubActivity, 1)
+
+				stream.RecvC <- tc.msg
+
+				err := s.StreamActivity(&stream)
+				require.Error(t, err)
+
+				assert.Equal(t, tc.code, status.Code(err))
+				assert.Equal(t, 0, len(s.connectedHubs))
+			})
+		}
+	})
+
+	t.Run("sends activity to every stream of a hub until it ends", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.connectedHubs = make(map[string]*connectedHub)
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctr, err := s.IssueHubToken(metadata.NewIncomingContext(top, md), &pb.Noop{})
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ctr.Token)
+
+		hubCtx := metadata.NewIncomingContext(top, md2)
+
+		hub := pb.NewULID()
+
+		// The old and new streams of a hub that's being restarted.
+		var (
+			streams [2]*staticServerStream
+			cancels [2]context.CancelFunc
+			done    [2]chan error
+		)
+
+		for i := range streams {
+			ctx, cancel := context.WithCancel(hubCtx)
+			defer cancel()
+
+			stream := &staticServerStream{
+				ctx:   ctx,
+				SendC: make(chan *pb.CentralActivity, 1),
+				RecvC: make(chan *pb.HubActivity, 1),
+			}
+
+			stream.RecvC <- &pb.HubActivity{
+				HubReg: &pb.HubActivity_HubRegistration{
+					Hub:       hub,
+					StableHub: hub,
+				},
+			}
+
+			streams[i] = stream
+			cancels[i] = cancel
+			done[i] = make(chan error, 1)
+
+			go func(i int) {
+				done[i] <- s.StreamActivity(streams[i])
+			}(i)
+		}
+
+		connected := func() int {
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+
+			return len(s.connectedHubs)
+		}
+
+		require.Eventually(t, func() bool {
+			return connected() == 2
+		}, 5*time.Second, 10*time.Millisecond)
+
+		received := func(stream *staticServerStream) bool {
+			select {
+			case <-stream.SendC:
+				return true
+			case <-time.After(time.Second):
+				return false
+			}
+		}
+
+		s.broadcastActivity(&pb.CentralActivity{NewLabelLinks: &pb.LabelLinks{}})
+
+		assert.True(t, received(streams[0]))
+		assert.True(t, received(streams[1]))
+
+		cancels[0]()
+
+		select {
+		case <-done[0]:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the old stream didn't end")
+		}
+
+		assert.Equal(t, 1, connected())
+
+		s.broadcastActivity(&pb.CentralActivity{NewLabelLinks: &pb.LabelLinks{}})
+
+		assert.True(t, received(streams[1]))
+		assert.False(t, received(streams[0]))
+	})
+
+	t.Run("rotates the s3 credentials given to hubs", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		hub := &connectedHub{xmit: make(chan *pb.CentralActivity, 10)}
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.connectedHubs = map[string]*connectedHub{"hub": hub}
+		s.cfg.HubAccessKey = "access1"
+		s.cfg.HubSecretKey = "secret1"
+		s.cfg.Bucket = "bucket1"
+		s.replay = newActivityReplay(10)
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctr, err := s.IssueHubToken(metadata.NewIncomingContext(top, md), &pb.Noop{})
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ctr.Token)
+
+		hubCtx := metadata.NewIncomingContext(top, md2)
+
+		req := &pb.ConfigRequest{
+			StableId:   pb.NewULID(),
+			InstanceId: pb.NewULID(),
+		}
+
+		resp, err := s.FetchConfig(hubCtx, req)
+		require.NoError(t, err)
+
+		assert.Equal(t, "access1", resp.S3AccessKey)
+		assert.Equal(t, "secret1", resp.S3SecretKey)
+		assert.Equal(t, "bucket1", resp.S3Bucket)
+
+		s.SetHubS3Credentials("access2", "secret2", "")
+
+		resp, err = s.FetchConfig(hubCtx, req)
+		require.NoError(t, err)
+
+		assert.Equal(t, "access2", resp.S3AccessKey)
+		assert.Equal(t, "secret2", resp.S3SecretKey)
+		assert.Equal(t, "bucket1", resp.S3Bucket)
+
+		// Hubs are only told to fetch them, so the secret doesn't end up in
+		// logged or replayed activity.
+		select {
+		case act := <-hub.xmit:
+			assert.True(t, act.S3CredentialsRotated)
+			assert.Nil(t, act.S3Credentials)
+		default:
+			t.Fatal("rotation of the credentials was not broadcast")
+		}
+
+		assert.Empty(t, s.replayActivity())
+
+		// Credentials from elsewhere aren't logged or replayed either.
+		act := &pb.CentralActivity{
+			S3Credentials: &pb.S3Credentials{
+				AccessKey: "access2",
+				SecretKey: "secret2",
+			},
+		}
+
+		assert.NotContains(t, redactActivity(act).String(), "secret2")
+		assert.Equal(t, "secret2", act.S3Credentials.SecretKey)
+
+		s.recordActivity(act)
+		assert.Empty(t, s.replayActivity())
+
+		s.SetHubS3Credentials("access3", "secret3", "bucket2")
+
+		resp, err = s.FetchConfig(hubCtx, req)
+		require.NoError(t, err)
+
+		assert.Equal(t, "access3", resp.S3AccessKey)
+		assert.Equal(t, "bucket2", resp.S3Bucket)
+	})
+
+	t.Run("stores hub locations for querying", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctr, err := s.IssueHubToken(metadata.NewIncomingContext(top, md), &pb.Noop{})
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ctr.Token)
+
+		hubCtx := metadata.NewIncomingContext(top, md2)
+
+		east := pb.NewULID()
+		west := pb.NewULID()
+
+		eastLocs := []*pb.NetworkLocation{
+			{
+				Name:      "public",
+				Addresses: []string{"1.1.1.1", "1.1.1.2"},
+				Labels:    pb.ParseLabelSet("dc=east,type=public"),
+			},
+			{
+				Name:      "private",
+				Addresses: []string{"10.0.0.1"},
+				Labels:    pb.ParseLabelSet("dc=east,type=private"),
+			},
+		}
+
+		westLocs := []*pb.NetworkLocation{
+			{
+				Name:      "public",
+				Addresses: []string{"2.2.2.2"},
+				Labels:    pb.ParseLabelSet("dc=west,type=public"),
+			},
+		}
+
+		for id, locs := range map[*pb.ULID][]*pb.NetworkLocation{
+			east: eastLocs,
+			west: westLocs,
+		} {
+			_, err = s.FetchConfig(hubCtx, &pb.ConfigRequest{
+				StableId:   id,
+				InstanceId: pb.NewULID(),
+				Locations:  locs,
+			})
+			require.NoError(t, err)
+		}
+
+		var rows []*HubLocation
+		require.NoError(t, dbx.Check(db.Where("stable_id = ?", east.Bytes()).Order("id").Find(&rows)))
+
+		require.Equal(t, 2, len(rows))
+
+		assert.Equal(t, "public", rows[0].Name)
+		assert.Equal(t, pq.StringArray{"1.1.1.1", "1.1.1.2"}, rows[0].Addresses)
+		assert.Equal(t, pq.StringArray{"dc=east", "type=public"}, rows[0].Labels)
+		assert.Equal(t, "private", rows[1].Name)
+
+		infos, err := s.FindHubsByLocationLabel(top, &pb.Label{Name: "type", Value: "public"})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, len(infos))
+
+		infos, err = s.FindHubsByLocationLabel(top, &pb.Label{Name: "type", Value: "private"})
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(infos))
+		assert.Equal(t, eastLocs, infos[0].Locations)
+
+		infos, err = s.FindHubsByLocationLabel(top, &pb.Label{Name: "dc", Value: "north"})
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, len(infos))
+
+		// Fetching config again replaces the hub's locations.
+		_, err = s.FetchConfig(hubCtx, &pb.ConfigRequest{
+			StableId:   east,
+			InstanceId: pb.NewULID(),
+			Locations:  eastLocs[:1],
+		})
+		require.NoError(t, err)
+
+		infos, err = s.FindHubsByLocationLabel(top, &pb.Label{Name: "type", Value: "private"})
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, len(infos))
+
+		all, err := s.AllHubs(hubCtx, &pb.AllHubsRequest{})
+		require.NoError(t, err)
+
+		require.Equal(t, 2, len(all.Hubs))
+
+		for _, info := range all.Hubs {
+			assert.Equal(t, 1, len(info.Locations))
+		}
+	})
+
+	t.Run("coalesces identical concurrent config fetches", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+
+		sink := metrics.NewInmemSink(time.Minute, time.Hour)
+
+		mcfg := metrics.DefaultConfig("control")
+		mcfg.EnableHostname = false
+		mcfg.EnableRuntimeMetrics = false
+
+		m, err := metrics.New(mcfg, sink)
+		require.NoError(t, err)
+
+		s.m = m
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctr, err := s.IssueHubToken(metadata.NewIncomingContext(top, md), &pb.Noop{})
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ctr.Token)
+
+		hubCtx := metadata.NewIncomingContext(top, md2)
+
+		stableId := pb.NewULID()
+
+		hr := Hub{
+			StableID:       stableId.Bytes(),
+			InstanceID:     pb.NewULID().Bytes(),
+			ConnectionInfo: []byte("[]"),
+			LastCheckin:    time.Now(),
+		}
+
+		require.NoError(t, dbx.Check(db.Create(&hr)))
+
+		fetch := func(instances ...*pb.ULID) []error {
+			var wg sync.WaitGroup
+
+			errs := make([]error, len(instances))
+
+			for i, inst := range instances {
+				wg.Add(1)
+
+				go func(i int, inst *pb.ULID) {
+					defer wg.Done()
+
+					_, errs[i] = s.FetchConfig(hubCtx, &pb.ConfigRequest{
+						StableId:   stableId,
+						InstanceId: inst,
+					})
+				}(i, inst)
+			}
+
+			wg.Wait()
+
+			return errs
+		}
+
+		replaced := func() int {
+			return sink.Data()[0].Counters["control.hub.replaced"].Count
+		}
+
+		// A new instance retrying rapidly replaces the old one once.
+		inst := pb.NewULID()
+
+		var same []*pb.ULID
+
+		for i := 0; i < 10; i++ {
+			same = append(same, inst)
+		}
+
+		for _, err := range fetch(same...) {
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 1, replaced())
+
+		// Different instances racing aren't coalesced, so each replaces the
+		// one before it.
+		inst2 := pb.NewULID()
+		inst3 := pb.NewULID()
+
+		for _, err := range fetch(inst2, inst3) {
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 3, replaced())
+
+		require.NoError(t, dbx.Check(db.Where("stable_id = ?", stableId.Bytes()).First(&hr)))
+
+		final := pb.ULIDFromBytes(hr.InstanceID)
+		assert.True(t, final.Equal(inst2) || final.Equal(inst3))
+	})
+
+	t.Run("skips hubs with bad connection info when listing all hubs", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+
+		sink := metrics.NewInmemSink(time.Minute, time.Hour)
+
+		mcfg := metrics.DefaultConfig("control")
+		mcfg.EnableHostname = false
+		mcfg.EnableRuntimeMetrics = false
+
+		m, err := metrics.New(mcfg, sink)
+		require.NoError(t, err)
+
+		s.m = m
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctr, err := s.IssueHubToken(metadata.NewIncomingContext(top, md), &pb.Noop{})
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ctr.Token)
+
+		hubCtx := metadata.NewIncomingContext(top, md2)
+
+		good := pb.NewULID()
+		bad := pb.NewULID()
+
+		for id, info := range map[*pb.ULID]string{
+			good: `[{"name": "public", "addresses": ["1.1.1.1"]}]`,
+			bad:  `[{"name": `,
+		} {
+			hr := Hub{
+				StableID:       id.Bytes(),
+				InstanceID:     id.Bytes(),
+				ConnectionInfo: []byte(info),
+				LastCheckin:    time.Now(),
+			}
+
+			require.NoError(t, dbx.Check(db.Create(&hr)))
+		}
+
+		all, err := s.AllHubs(hubCtx, &pb.AllHubsRequest{})
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(all.Hubs))
+		assert.Equal(t, good, all.Hubs[0].Id)
+		assert.Equal(t, int32(1), all.Skipped)
+
+		data := sink.Data()
+
+		assert.Equal(t, 1, data[0].Counters["control.hub.bad_connection_info"].Count)
+	})
+
+	t.Run("serves hubs from a snapshot versioned by their contents", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctr, err := s.IssueHubToken(metadata.NewIncomingContext(top, md), &pb.Noop{})
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ctr.Token)
+
+		hubCtx := metadata.NewIncomingContext(top, md2)
+
+		all, err := s.AllHubs(hubCtx, &pb.AllHubsRequest{})
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, len(all.Hubs))
+		require.NotEmpty(t, all.Version)
+
+		empty := all.Version
+
+		hub := pb.NewULID()
+		instance := pb.NewULID()
+
+		_, err = s.FetchConfig(hubCtx, &pb.ConfigRequest{
+			StableId:   hub,
+			InstanceId: instance,
+			Locations: []*pb.NetworkLocation{
+				{
+					Name:      "public",
+					Addresses: []string{"1.1.1.1"},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		// Fetching the config only marks the snapshot stale rather than
+		// rebuilding it, the next listing does that.
+		assert.Equal(t, empty, s.hubs.version)
+
+		// The hub is listed as soon as it fetches its config.
+		all, err = s.AllHubs(hubCtx, &pb.AllHubsRequest{Version: empty})
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(all.Hubs))
+		assert.Equal(t, instance, all.Hubs[0].Id)
+		assert.False(t, all.NotModified)
+		assert.NotEqual(t, empty, all.Version)
+
+		current := all.Version
+
+		// Asking with the current version leaves the hubs out.
+		all, err = s.AllHubs(hubCtx, &pb.AllHubsRequest{Version: current})
+		require.NoError(t, err)
+
+		assert.True(t, all.NotModified)
+		assert.Equal(t, current, all.Version)
+		assert.Equal(t, 0, len(all.Hubs))
+
+		// Rows changed by another server are picked up by the next refresh.
+		require.NoError(t, dbx.Check(db.Model(&HubLocation{}).
+			Where("stable_id = ?", hub.Bytes()).
+			Update("addresses", pq.StringArray{"1.1.1.2"})))
+
+		all, err = s.AllHubs(hubCtx, &pb.AllHubsRequest{Version: current})
+		require.NoError(t, err)
+
+		assert.True(t, all.NotModified)
+
+		_, err = s.refreshHubSnapshot()
+		require.NoError(t, err)
+
+		all, err = s.AllHubs(hubCtx, &pb.AllHubsRequest{Version: current})
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(all.Hubs))
+		assert.Equal(t, []string{"1.1.1.2"}, all.Hubs[0].Locations[0].Addresses)
+
+		_, err = s.HubDisconnect(hubCtx, &pb.HubDisconnectRequest{
+			StableId:   hub,
+			InstanceId: instance,
+		})
+		require.NoError(t, err)
+
+		// With the hub gone, the list is back to the empty one.
+		all, err = s.AllHubs(hubCtx, &pb.AllHubsRequest{Version: current})
+		require.NoError(t, err)
+
+		assert.False(t, all.NotModified)
+		assert.Equal(t, 0, len(all.Hubs))
+		assert.Equal(t, empty, all.Version)
+	})
+
+	t.Run("can list all accounts in the namespace for a mgmt token", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(top, md)
+
+		ct, err := s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/foo",
+		})
+
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		accountId := pb.NewULID()
+		accountId2 := pb.NewULID()
+		accountId3 := pb.NewULID()
+
+		mgmtCtx := metadata.NewIncomingContext(top, md2)
+
+		_, err = s.CreateToken(
+			mgmtCtx,
+			&pb.CreateTokenRequest{
+				Account: &pb.Account{
+					Namespace: "/foo",
+					AccountId: accountId,
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = s.CreateToken(
+			mgmtCtx,
+			&pb.CreateTokenRequest{
+				Account: &pb.Account{
+					Namespace: "/foo/bar",
+					AccountId: accountId2,
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		ct2, err := s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/qux",
+		})
+
+		require.NoError(t, err)
+
+		md3 := make(metadata.MD)
+		md3.Set("authorization", ct2.Token)
+
+		_, err = s.CreateToken(
+			metadata.NewIncomingContext(top, md3),
+			&pb.CreateTokenRequest{
+				Account: &pb.Account{
+					Namespace: "/qux",
+					AccountId: accountId3,
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		list, err := s.ListAccounts(
+			mgmtCtx,
+			&pb.ListAccountsRequest{},
+		)
+		require.NoError(t, err)
+
+		require.Equal(t, 2, len(list.Accounts))
+
+		assert.Equal(t, accountId, list.Accounts[0].AccountId)
+		assert.Equal(t, "/foo", list.Accounts[0].Namespace)
+		assert.Equal(t, accountId2, list.Accounts[1].AccountId)
+		assert.Equal(t, "/foo/bar", list.Accounts[1].Namespace)
+
+		list, err = s.ListAccounts(
+			mgmtCtx,
+			&pb.ListAccountsRequest{Limit: 1},
+		)
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(list.Accounts))
+
+		assert.Equal(t, accountId, list.Accounts[0].AccountId)
+
+		list, err = s.ListAccounts(
+			mgmtCtx,
+			&pb.ListAccountsRequest{
+				Limit:  1,
+				Marker: list.NextMarker,
+			},
+		)
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(list.Accounts))
+
+		assert.Equal(t, accountId2, list.Accounts[0].AccountId)
+
+		list, err = s.ListAccounts(
+			mgmtCtx,
+			&pb.ListAccountsRequest{
+				Limit:  1,
+				Marker: list.NextMarker,
+			},
+		)
+		require.NoError(t, err)
+
+		require.Equal(t, 0, len(list.Accounts))
+	})
+
+	t.Run("assigns ids from the configured source", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		ids := []*pb.ULID{pb.NewULID(), pb.NewULID()}
+
+		var next int
+
+		s.cfg.NewID = func() *pb.ULID {
+			id := ids[next]
+			next++
+			return id
+		}
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+
+		_, err = s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/fixed",
+		})
+		require.NoError(t, err)
+
+		var mc ManagementClient
+		err = dbx.Check(db.Where("namespace = ?", "/fixed").First(&mc))
+		require.NoError(t, err)
+
+		assert.Equal(t, ids[0].Bytes(), mc.ID)
+
+		w := httptest.NewRecorder()
+		s.genUlid(w, httptest.NewRequest("GET", "/ulid", nil))
+
+		assert.Equal(t, ids[1].String(), strings.TrimSpace(w.Body.String()))
+	})
+
+	t.Run("can list all management clients with the ops token", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.opsToken = "opsrocks"
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(top, md)
+
+		for _, ns := range []string{"/foo", "/bar", "/qux"} {
+			_, err = s.Register(ctx, &pb.ControlRegister{
+				Namespace: ns,
+			})
+			require.NoError(t, err)
+		}
+
+		_, err = s.ListManagementClients(ctx, &pb.ListManagementClientsRequest{})
+		require.Error(t, err)
+
+		opsMD := make(metadata.MD)
+		opsMD.Set("authorization", "opsrocks")
+
+		opsCtx := metadata.NewIncomingContext(top, opsMD)
+
+		list, err := s.ListManagementClients(opsCtx, &pb.ListManagementClientsRequest{})
+		require.NoError(t, err)
+
+		require.Equal(t, 3, len(list.Clients))
+
+		var namespaces []string
+
+		for _, mc := range list.Clients {
+			namespaces = append(namespaces, mc.Namespace)
+
+			assert.NotNil(t, mc.Id)
+			assert.WithinDuration(t, time.Now(), mc.CreatedAt.Time(), time.Minute)
+		}
+
+		assert.ElementsMatch(t, []string{"/foo", "/bar", "/qux"}, namespaces)
+
+		list, err = s.ListManagementClients(opsCtx, &pb.ListManagementClientsRequest{Limit: 2})
+		require.NoError(t, err)
+
+		require.Equal(t, 2, len(list.Clients))
+
+		list, err = s.ListManagementClients(opsCtx, &pb.ListManagementClientsRequest{
+			Limit:  2,
+			Marker: list.NextMarker,
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(list.Clients))
+	})
+
+	t.Run("can unregister a management client and register it again", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.opsToken = "opsrocks"
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(top, md)
+
+		_, err = s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/foo",
+		})
+		require.NoError(t, err)
+
+		opsMD := make(metadata.MD)
+		opsMD.Set("authorization", "opsrocks")
+
+		opsCtx := metadata.NewIncomingContext(top, opsMD)
+
+		_, err = s.UnregisterManagementClient(ctx, &pb.UnregisterRequest{
+			Namespace: "/foo",
+		})
+		require.Error(t, err)
+
+		account := &pb.Account{
+			AccountId: pb.NewULID(),
+			Namespace: "/foo/bar",
+		}
+
+		ao := Account{
+			ID:        account.Key(),
+			Namespace: account.Namespace,
+		}
+
+		require.NoError(t, dbx.Check(db.Create(&ao)))
+
+		so := Service{
+			AccountId: account.Key(),
+			HubId:     pb.NewULID().Bytes(),
+			ServiceId: pb.NewULID().Bytes(),
+			Type:      "http",
+			Labels:    pb.ParseLabelSet("env=test").AsStringArray(),
+		}
+
+		require.NoError(t, dbx.Check(db.Create(&so)))
+
+		_, err = s.UnregisterManagementClient(opsCtx, &pb.UnregisterRequest{
+			Namespace: "/foo",
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrInvalidRequest))
+
+		_, err = s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/foo",
+		})
+		require.Error(t, err)
+
+		_, err = s.UnregisterManagementClient(opsCtx, &pb.UnregisterRequest{
+			Namespace: "/foo",
+			Cascade:   true,
+		})
+		require.NoError(t, err)
+
+		var count int
+
+		require.NoError(t, dbx.Check(db.Model(&Account{}).Count(&count)))
+		assert.Equal(t, 0, count)
+
+		require.NoError(t, dbx.Check(db.Model(&Service{}).Count(&count)))
+		assert.Equal(t, 0, count)
+
+		_, err = s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/foo",
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("uses reloaded register and ops tokens on the next call", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.opsToken = "opsrocks"
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		authCtx := func(tok string) context.Context {
+			md := make(metadata.MD)
+			md.Set("authorization", tok)
+			return metadata.NewIncomingContext(top, md)
+		}
+
+		// Check the ops token concurrently with the reload so the race
+		// detector can see any unsynchronized access.
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+
+			for i := 0; i < 100; i++ {
+				s.checkOpsAllowed(authCtx("opsrocks"))
+			}
+		}()
+
+		s.ReloadTokens("ddeeff", "opsrule")
+
+		<-done
+
+		_, err = s.Register(authCtx("aabbcc"), &pb.ControlRegister{
+			Namespace: "/foo",
+		})
+		require.Error(t, err)
+
+		_, err = s.Register(authCtx("ddeeff"), &pb.ControlRegister{
+			Namespace: "/foo",
+		})
+		require.NoError(t, err)
+
+		assert.False(t, s.checkOpsAllowed(authCtx("opsrocks")))
+		assert.True(t, s.checkOpsAllowed(authCtx("opsrule")))
+	})
+
+	t.Run("can create and remove a labellink for an account", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.awsSess = sess
+		s.bucket = bucket
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(top, md)
+
+		ct, err := s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/",
+		})
+
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		accountId := pb.NewULID()
+
+		label := pb.ParseLabelSet(":hostname=foo.com")
+		target := pb.ParseLabelSet("service=emp,env=test")
+
+		_, err = s.AddAccount(
+			metadata.NewIncomingContext(top, md2),
+			&pb.AddAccountRequest{
+				Account: &pb.Account{
+					AccountId: accountId,
+					Namespace: "/",
+				},
+
+				Limits: &pb.Account_Limits{
+					HttpRequests: 1000,
+					Bandwidth:    872,
+				},
+			},
+		)
+
+		require.NoError(t, err)
+
+		_, err = s.AddLabelLink(
+			metadata.NewIncomingContext(top, md2),
+			&pb.AddLabelLinkRequest{
+				Labels: label,
+				Account: &pb.Account{
+					AccountId: accountId,
+					Namespace: "/",
+				},
+				Target: target,
+			},
+		)
+
+		require.NoError(t, err)
+
+		// Check the labe link payload written to s3
+
+		s3api := s3.New(sess)
+
+		resp, err := s3api.GetObject(&s3.GetObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String("label_links"),
+		})
+
+		require.NoError(t, err)
+
+		compressedData, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		data, err := zstdDecompress(compressedData)
+		require.NoError(t, err)
+
+		var lls pb.LabelLinks
+
+		err = lls.Unmarshal(data)
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(lls.LabelLinks))
+
+		ll := lls.LabelLinks[0]
+
+		assert.Equal(t, accountId, ll.Account.AccountId)
+		assert.Equal(t, label, ll.Labels)
+		assert.Equal(t, target, ll.Target)
+
+		require.NotNil(t, ll.Limits)
+
+		assert.Equal(t, float64(1000), ll.Limits.HttpRequests)
+		assert.Equal(t, float64(872), ll.Limits.Bandwidth)
+
+		_, err = s.RemoveLabelLink(
+			metadata.NewIncomingContext(top, md2),
+			&pb.RemoveLabelLinkRequest{
+				Labels: label,
+				Account: &pb.Account{
+					AccountId: accountId,
+					Namespace: "/",
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		var llr LabelLink
+		err = dbx.Check(db.First(&llr))
+
+		assert.Error(t, err)
+
+		resp, err = s3api.GetObject(&s3.GetObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String("label_links"),
+		})
+
+		require.NoError(t, err)
+
+		compressedData, err = ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		data, err = zstdDecompress(compressedData)
+		require.NoError(t, err)
+
+		var lls2 pb.LabelLinks
+
+		err = lls2.Unmarshal(data)
+		require.NoError(t, err)
+
+		require.Equal(t, 0, len(lls2.LabelLinks))
+	})
+
+	t.Run("can remove a labellink added without a namespace", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.awsSess = sess
+		s.bucket = bucket
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(top, md)
+
+		ct, err := s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/",
+		})
+
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		mgmtCtx := metadata.NewIncomingContext(top, md2)
+
+		accountId := pb.NewULID()
+
+		label := pb.ParseLabelSet(":hostname=foo.com")
+		target := pb.ParseLabelSet("service=emp,env=test")
+
+		_, err = s.AddAccount(mgmtCtx, &pb.AddAccountRequest{
+			Account: &pb.Account{
+				AccountId: accountId,
+			},
+			Limits: &pb.Account_Limits{},
+		})
+		require.NoError(t, err)
+
+		_, err = s.AddLabelLink(mgmtCtx, &pb.AddLabelLinkRequest{
+			Labels: label,
+			Account: &pb.Account{
+				AccountId: accountId,
+			},
+			Target: pb.ParseLabelSet("service=emp,:match=env=test and ("),
+		})
+		assert.True(t, errors.Is(err, ErrInvalidRequest))
+
+		_, err = s.AddLabelLink(mgmtCtx, &pb.AddLabelLinkRequest{
+			Labels: label,
+			Account: &pb.Account{
+				AccountId: accountId,
+			},
+			Target: target,
+		})
+		require.NoError(t, err)
+
+		var llr LabelLink
+		err = dbx.Check(db.First(&llr))
+		require.NoError(t, err)
+
+		_, err = s.RemoveLabelLink(mgmtCtx, &pb.RemoveLabelLinkRequest{
+			Labels: label,
+			Account: &pb.Account{
+				AccountId: accountId,
+			},
+		})
+		require.NoError(t, err)
+
+		err = dbx.Check(db.First(&llr))
+		assert.Error(t, err)
+	})
+
+	t.Run("replays recent activity to a hub that connects", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		fake := clock.NewFake(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC))
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.connectedHubs = make(map[string]*connectedHub)
+		s.cfg.Clock = fake
+		s.cfg.SelectiveBroadcast = true
+		s.cfg.ActivityReplaySize = 10
+		s.replay = newActivityReplay(s.cfg.ActivityReplaySize)
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctr, err := s.IssueHubToken(metadata.NewIncomingContext(top, md), &pb.Noop{})
+		require.NoError(t, err)
+
+		hubId := pb.NewULID()
+
+		mine := &pb.Account{
+			AccountId: pb.NewULID(),
+			Namespace: "/",
+		}
+
+		other := &pb.Account{
+			AccountId: pb.NewULID(),
+			Namespace: "/",
+		}
+
+		cached := &pb.Account{
+			AccountId: pb.NewULID(),
+			Namespace: "/",
+		}
+
+		so := Service{
+			AccountId: mine.Key(),
+			HubId:     hubId.Bytes(),
+			ServiceId: pb.NewULID().Bytes(),
+			Type:      "http",
+			Labels:    pb.ParseLabelSet("service=www").AsStringArray(),
+		}
+
+		require.NoError(t, dbx.Check(db.Create(&so)))
+
+		routes := func(account *pb.Account) *pb.CentralActivity {
+			return &pb.CentralActivity{
+				AccountServices: []*pb.AccountServices{
+					{Account: account},
+				},
+			}
+		}
+
+		// Too old to be replayed.
+		s.broadcastActivity(routes(mine))
+
+		fake.Advance(2 * DefaultActivityReplayWindow)
+
+		recent := routes(mine)
+		s.broadcastActivity(recent)
+
+		// Not for the hub's accounts.
+		s.broadcastActivity(routes(other))
+
+		// For an account the hub reports having cached.
+		recentCached := routes(cached)
+		s.broadcastActivity(recentCached)
+
+		// Only for the hubs connected at the time.
+		s.broadcastActivity(&pb.CentralActivity{RequestStats: true})
+
+		md3 := make(metadata.MD)
+		md3.Set("authorization", ctr.Token)
+
+		var stream staticServerStream
+		stream.ctx = metadata.NewIncomingContext(top, md3)
+		stream.SendC = make(chan *pb.CentralActivity, 10)
+		stream.RecvC = make(chan *pb.HubActivity, 1)
+
+		stream.RecvC <- &pb.HubActivity{
+			HubReg: &pb.HubActivity_HubRegistration{
+				Hub: hubId,
+			},
+			CachedAccounts: []*pb.Account{cached},
+		}
+
+		go s.StreamActivity(&stream)
+
+		receive := func() *pb.CentralActivity {
+			select {
+			case <-time.After(5 * time.Second):
+				t.Fatal("activity was not delivered to the hub")
+				return nil
+			case ca := <-stream.SendC:
+				return ca
+			}
+		}
+
+		assert.Equal(t, recent, receive())
+		assert.Equal(t, recentCached, receive())
+
+		require.Eventually(t, func() bool {
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+
+			return len(s.connectedHubs) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+
+		live := routes(mine)
+		s.broadcastActivity(live)
+
+		assert.Equal(t, live, receive())
+
+		// Accounts the hub caches once connected are picked up from the
+		// stream.
+		stream.RecvC <- &pb.HubActivity{
+			CachedAccounts: []*pb.Account{other},
+		}
+
+		require.Eventually(t, func() bool {
+			for _, ch := range s.hubConns(hubId.SpecString()) {
+				if ch.servesAny([]string{other.StringKey()}) {
+					return true
+				}
+			}
+
+			return false
+		}, 5*time.Second, 10*time.Millisecond)
+
+		live = routes(other)
+		s.broadcastActivity(live)
+
+		assert.Equal(t, live, receive())
+
+		assert.Equal(t, 0, len(stream.SendC))
+	})
+
+	t.Run("broadcasts the resolved routes for a new labellink and its removal", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.awsSess = sess
+		s.bucket = bucket
+		s.connectedHubs = make(map[string]*connectedHub)
+
+		// The hub serves nothing of the account's, and still gets its
+		// routes with the label link.
+		s.cfg.SelectiveBroadcast = true
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(top, md)
+
+		ct, err := s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/",
+		})
+		require.NoError(t, err)
+
+		ctr, err := s.IssueHubToken(ctx, &pb.Noop{})
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		mgmtCtx := metadata.NewIncomingContext(top, md2)
+
+		md3 := make(metadata.MD)
+		md3.Set("authorization", ctr.Token)
+
+		var stream staticServerStream
+		stream.ctx = metadata.NewIncomingContext(top, md3)
+		stream.SendC = make(chan *pb.CentralActivity, 1)
+		stream.RecvC = make(chan *pb.HubActivity, 1)
+
+		stream.RecvC <- &pb.HubActivity{
+			HubReg: &pb.HubActivity_HubRegistration{
+				Hub: pb.NewULID(),
+			},
+		}
+
+		go s.StreamActivity(&stream)
+
+		require.Eventually(t, func() bool {
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+
+			return len(s.connectedHubs) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+
+		account := &pb.Account{
+			AccountId: pb.NewULID(),
+			Namespace: "/",
+		}
+
+		target := pb.ParseLabelSet("service=emp,env=test")
+
+		for _, labels := range []*pb.LabelSet{
+			pb.ParseLabelSet("service=emp,env=test,:deployment=aabb"),
+			pb.ParseLabelSet("service=emp,env=prod"),
+		} {
+			so := Service{
+				AccountId: account.Key(),
+				HubId:     pb.NewULID().Bytes(),
+				ServiceId: pb.NewULID().Bytes(),
+				Type:      "http",
+				Labels:    labels.AsStringArray(),
+			}
+
+			require.NoError(t, dbx.Check(db.Create(&so)))
+		}
+
+		_, err = s.AddAccount(mgmtCtx, &pb.AddAccountRequest{
+			Account: account,
+			Limits:  &pb.Account_Limits{},
+		})
+		require.NoError(t, err)
+
+		_, err = s.AddLabelLink(mgmtCtx, &pb.AddLabelLinkRequest{
+			Labels:  pb.ParseLabelSet(":hostname=foo.com"),
+			Account: account,
+			Target:  target,
+		})
+		require.NoError(t, err)
+
+		// The account's routes come with the label link, so every hub that
+		// gets the link gets them too.
+		select {
+		case <-time.After(5 * time.Second):
+			t.Fatal("activity was not delivered to the hub")
+		case ca := <-stream.SendC:
+			require.NotNil(t, ca.NewLabelLinks)
+			assert.Equal(t, 0, len(ca.AccountServices))
+			require.Equal(t, 1, len(ca.ResolvedRoutes))
+
+			routes := ca.ResolvedRoutes[0]
+
+			assert.Equal(t, account.AccountId, routes.Account.AccountId)
+			require.Equal(t, 2, len(routes.Services))
+
+			var matched int
+
+			for _, serv := range routes.Services {
+				assert.Equal(t, "http", serv.Type)
+
+				if target.Matches(serv.Labels) {
+					matched++
+				}
+			}
+
+			assert.Equal(t, 1, matched)
+		}
+
+		_, err = s.RemoveLabelLink(mgmtCtx, &pb.RemoveLabelLinkRequest{
+			Labels:  pb.ParseLabelSet(":hostname=foo.com"),
+			Account: account,
+		})
+		require.NoError(t, err)
+
+		select {
+		case <-time.After(5 * time.Second):
+			t.Fatal("removal was not delivered to the hub")
+		case ca := <-stream.SendC:
+			require.NotNil(t, ca.RemovedLabelLinks)
+			require.Equal(t, 1, len(ca.RemovedLabelLinks.LabelLinks))
+
+			ll := ca.RemovedLabelLinks.LabelLinks[0]
+
+			assert.Equal(t, account, ll.Account)
+			assert.Equal(t, pb.ParseLabelSet(":hostname=foo.com"), ll.Labels)
+		}
+	})
+
+	t.Run("streams activity to a hub using zstd", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.connectedHubs = make(map[string]*connectedHub)
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctr, err := s.IssueHubToken(metadata.NewIncomingContext(top, md), &pb.Noop{})
+		require.NoError(t, err)
+
+		gs := grpc.NewServer()
+		pb.RegisterControlServicesServer(gs, &s)
+
+		li, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		defer li.Close()
+
+		go gs.Serve(li)
+		defer gs.Stop()
+
+		gcc, err := grpc.Dial(li.Addr().String(),
+			grpc.WithInsecure(),
+			grpc.WithPerRPCCredentials(grpctoken.Token(ctr.Token)),
+		)
+		require.NoError(t, err)
+
+		defer gcc.Close()
+
+		ctx, cancel := context.WithCancel(top)
+		defer cancel()
+
+		activity, err := pb.NewControlServicesClient(gcc).StreamActivity(ctx, grpc.UseCompressor(zstd.Name))
+		require.NoError(t, err)
+
+		err = activity.Send(&pb.HubActivity{
+			HubReg: &pb.HubActivity_HubRegistration{
+				Hub: pb.NewULID(),
+			},
+		})
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+
+			return len(s.connectedHubs) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+
+		act := &pb.CentralActivity{
+			AccountServices: []*pb.AccountServices{
+				{
+					Account: &pb.Account{
+						AccountId: pb.NewULID(),
+						Namespace: "/",
+					},
+					Services: []*pb.ServiceRoute{
+						{
+							Hub:    pb.NewULID(),
+							Id:     pb.NewULID(),
+							Type:   "http",
+							Labels: pb.ParseLabelSet("service=emp,env=test"),
+						},
+					},
+				},
+			},
+		}
+
+		s.broadcastActivity(act)
+
+		ca, err := activity.Recv()
+		require.NoError(t, err)
+
+		assert.True(t, act.Equal(ca))
+	})
+
+	t.Run("does not count resent flow records twice", func(t *testing.T) {
+		var s Server
+		s.L = L
+
+		sink := metrics.NewInmemSink(time.Minute, time.Hour)
+
+		m, err := metrics.New(metrics.DefaultConfig("control"), sink)
+		require.NoError(t, err)
+
+		s.m = m
+
+		s.flowTop, err = NewFlowTop(DefaultFlowTopSize)
+		require.NoError(t, err)
+
+		ch := &connectedHub{
+			messages:    new(int64),
+			bytes:       new(int64),
+			lastFlowSeq: new(int64),
+		}
+
+		batch := func(start int64) []*pb.FlowRecord {
+			var flows []*pb.FlowRecord
+
+			for i := int64(0); i < 3; i++ {
+				flows = append(flows, &pb.FlowRecord{
+					Sequence: start + i,
+					Stream: &pb.FlowStream{
+						FlowId:      pb.NewULID(),
+						HubId:       pb.NewULID(),
+						NumMessages: 1,
+						NumBytes:    100,
+					},
+				})
+			}
+
+			return flows
+		}
+
+		first := batch(1)
+
+		s.processFlows(ch, first)
+		s.processFlows(ch, first)
+
+		assert.Equal(t, int64(3), atomic.LoadInt64(ch.messages))
+		assert.Equal(t, int64(300), atomic.LoadInt64(ch.bytes))
+
+		s.processFlows(ch, batch(4))
+
+		assert.Equal(t, int64(6), atomic.LoadInt64(ch.messages))
+		assert.Equal(t, int64(600), atomic.LoadInt64(ch.bytes))
+
+		// Records without a sequence are always counted.
+		s.processFlows(ch, batch(0)[:1])
+		s.processFlows(ch, batch(0)[:1])
+
+		assert.Equal(t, int64(8), atomic.LoadInt64(ch.messages))
+	})
+
+	t.Run("forgets a hub's flow sequence once its last stream is gone", func(t *testing.T) {
+		var s Server
+		s.L = L
+
+		first := &connectedHub{xmit: make(chan *pb.CentralActivity, 1)}
+		second := &connectedHub{xmit: make(chan *pb.CentralActivity, 1)}
+		other := &connectedHub{xmit: make(chan *pb.CentralActivity, 1)}
+
+		s.connectedHubs = map[string]*connectedHub{
+			hubConnKey("hub", 1):   first,
+			hubConnKey("hub", 2):   second,
+			hubConnKey("other", 3): other,
+		}
+
+		s.flowSeqs = map[string]*int64{
+			"hub":   new(int64),
+			"other": new(int64),
+		}
+
+		// Another stream of the hub is still connected.
+		assert.True(t, s.removeHubConn(hubConnKey("hub", 1), first))
+		assert.Contains(t, s.flowSeqs, "hub")
+
+		// An entry that's since been replaced isn't removed.
+		assert.False(t, s.removeHubConn(hubConnKey("hub", 2), first))
+		assert.Contains(t, s.connectedHubs, hubConnKey("hub", 2))
+
+		// Pruning the hub's last stream forgets it, like the stream ending.
+		s.pruneHubs(map[string]*connectedHub{hubConnKey("hub", 2): second})
+
+		assert.NotContains(t, s.flowSeqs, "hub")
+		assert.Contains(t, s.flowSeqs, "other")
+
+		s.removeHubConn(hubConnKey("other", 3), other)
+
+		assert.Empty(t, s.flowSeqs)
+		assert.Empty(t, s.connectedHubs)
+	})
+
+	t.Run("reports a smoothed rate of each account's traffic", func(t *testing.T) {
+		var s Server
+		s.L = L
+
+		fake := clock.NewFake(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC))
+		s.cfg.Clock = fake
+
+		sink := metrics.NewInmemSink(time.Hour, time.Hour)
+
+		mcfg := metrics.DefaultConfig("control")
+		mcfg.EnableHostname = false
+		mcfg.EnableRuntimeMetrics = false
+
+		m, err := metrics.New(mcfg, sink)
+		require.NoError(t, err)
+
+		s.m = m
+
+		s.flowTop, err = NewFlowTop(DefaultFlowTopSize)
+		require.NoError(t, err)
+
+		s.flowRates = newFlowRates(time.Minute)
+
+		ch := &connectedHub{
+			messages:    new(int64),
+			bytes:       new(int64),
+			lastFlowSeq: new(int64),
+		}
+
+		account := &pb.Account{
+			AccountId: pb.NewULID(),
+			Namespace: "/",
+		}
+
+		// A burst of traffic, all at once.
+		var flows []*pb.FlowRecord
+
+		for i := int64(1); i <= 10; i++ {
+			flows = append(flows, &pb.FlowRecord{
+				Sequence: i,
+				Stream: &pb.FlowStream{
+					FlowId:      pb.NewULID(),
+					HubId:       pb.NewULID(),
+					AgentId:     pb.NewULID(),
+					ServiceId:   pb.NewULID(),
+					Account:     account,
+					NumMessages: 60,
+					NumBytes:    6000,
+				},
+			})
+		}
+
+		s.processFlows(ch, flows)
+
+		// The raw counters are exact.
+		assert.Equal(t, int64(600), atomic.LoadInt64(ch.messages))
+
+		rates := s.flowRates.rates(s.now())
+
+		rv := rates[account.SpecString()]
+		assert.InDelta(t, 10, rv.Messages, 0.001)
+		assert.InDelta(t, 1000, rv.Bytes, 0.001)
+
+		key := "control.stream.rate.messages;account=" + account.SpecString()
+
+		last := rv.Messages
+
+		// Then nothing, so the rate decays toward zero with every report.
+		for i := 0; i < 5; i++ {
+			fake.Advance(time.Minute)
+
+			s.reportFlowRates()
+
+			gauge := sink.Data()[0].Gauges[key]
+
+			assert.True(t, float64(gauge.Value) < last, "rate didn't decay: %f", gauge.Value)
+			assert.InDelta(t, 10*math.Exp(-float64(i+1)), gauge.Value, 0.001)
+
+			last = float64(gauge.Value)
+		}
+
+		// Eventually the account is reported as idle, then forgotten.
+		fake.Advance(time.Hour)
+
+		s.reportFlowRates()
+
+		assert.Equal(t, float32(0), sink.Data()[0].Gauges[key].Value)
+
+		assert.Equal(t, 0, len(s.flowRates.rates(s.now())))
+	})
+
+	t.Run("signals when flow volume crosses a quota", func(t *testing.T) {
+		var s Server
+		s.L = L
+		s.connectedHubs = make(map[string]*connectedHub)
+		s.cfg.AccountQuota = Quota{Bytes: 1000}
+		s.cfg.HubQuota = Quota{Messages: 5}
+		s.cfg.QuotaWindow = time.Minute
+
+		sink := metrics.NewInmemSink(time.Minute, time.Hour)
+
+		mcfg := metrics.DefaultConfig("control")
+		mcfg.EnableHostname = false
+		mcfg.EnableRuntimeMetrics = false
+
+		m, err := metrics.New(mcfg, sink)
+		require.NoError(t, err)
+
+		s.m = m
+
+		s.flowTop, err = NewFlowTop(DefaultFlowTopSize)
+		require.NoError(t, err)
+
+		listener := &connectedHub{
+			xmit: make(chan *pb.CentralActivity, 10),
+		}
+
+		s.connectedHubs["listener"] = listener
+
+		ch := &connectedHub{
+			messages:    new(int64),
+			bytes:       new(int64),
+			lastFlowSeq: new(int64),
+		}
+
+		account := &pb.Account{
+			AccountId: pb.NewULID(),
+			Namespace: "/",
+		}
+
+		hubId := pb.NewULID()
+
+		var seq int64
+
+		flow := func() []*pb.FlowRecord {
+			seq++
+
+			return []*pb.FlowRecord{
+				{
+					Sequence: seq,
+					Stream: &pb.FlowStream{
+						FlowId:      pb.NewULID(),
+						HubId:       hubId,
+						Account:     account,
+						NumMessages: 3,
+						NumBytes:    600,
+					},
+				},
+			}
+		}
+
+		s.processFlows(ch, flow())
+
+		assert.Equal(t, 0, len(listener.xmit))
+
+		// Both the account and the hub go over.
+		s.processFlows(ch, flow())
+
+		require.Equal(t, 1, len(listener.xmit))
+
+		act := <-listener.xmit
+
+		assert.Equal(t, []*pb.QuotaStatus{
+			{
+				Account:  account,
+				Exceeded: true,
+				Bytes:    1200,
+				Messages: 6,
+			},
+			{
+				Hub:      hubId,
+				Exceeded: true,
+				Bytes:    1200,
+				Messages: 6,
+			},
+		}, act.Quotas)
+
+		data := sink.Data()
+
+		assert.Equal(t, 1, data[0].Counters["control.quota.exceeded;account="+account.SpecString()].Count)
+		assert.Equal(t, 1, data[0].Counters["control.quota.exceeded;hub="+hubId.SpecString()].Count)
+
+		// They're only signaled once.
+		s.processFlows(ch, flow())
+
+		assert.Equal(t, 0, len(listener.xmit))
+
+		// Hubs that connect later are told who is over.
+		exceeded := s.exceededQuotas()
+		require.NotNil(t, exceeded)
+
+		require.Equal(t, 2, len(exceeded.Quotas))
+		assert.Equal(t, account, exceeded.Quotas[0].Account)
+		assert.Equal(t, hubId, exceeded.Quotas[1].Hub)
+
+		// The hubs track the quotas for their accounts and themselves.
+		var c Client
+		c.instanceId = hubId
+
+		c.updateQuotas(L, act.Quotas)
+
+		assert.True(t, c.AccountOverQuota(account))
+		assert.True(t, c.OverQuota())
+
+		// Once the usage has left the window, they're signaled as back under.
+		s.checkQuotas(time.Now().Add(2 * time.Minute))
+
+		require.Equal(t, 1, len(listener.xmit))
+
+		act = <-listener.xmit
+
+		require.Equal(t, 2, len(act.Quotas))
+
+		for _, qs := range act.Quotas {
+			assert.False(t, qs.Exceeded)
+		}
+
+		assert.Nil(t, s.exceededQuotas())
+
+		c.updateQuotas(L, act.Quotas)
+
+		assert.False(t, c.AccountOverQuota(account))
+		assert.False(t, c.OverQuota())
+	})
+
+	t.Run("reports the time since each hub last checked in", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+
+		sink := metrics.NewInmemSink(time.Minute, time.Hour)
+
+		mcfg := metrics.DefaultConfig("control")
+		mcfg.EnableHostname = false
+		mcfg.EnableRuntimeMetrics = false
+
+		m, err := metrics.New(mcfg, sink)
+		require.NoError(t, err)
+
+		s.m = m
+
+		fresh := pb.NewULID()
+		old := pb.NewULID()
+
+		for id, checkin := range map[*pb.ULID]time.Time{
+			fresh: time.Now(),
+			old:   time.Now().Add(-20 * time.Minute),
+		} {
+			hr := Hub{
+				StableID:       id.Bytes(),
+				InstanceID:     pb.NewULID().Bytes(),
+				ConnectionInfo: []byte("[]"),
+				LastCheckin:    checkin,
+			}
+
+			require.NoError(t, dbx.Check(db.Create(&hr)))
+		}
+
+		require.NoError(t, s.collectHubCheckins())
+
+		data := sink.Data()
+
+		since := data[0].Gauges["control.hub.since_checkin;hub="+old.SpecString()]
+		assert.InDelta(t, 1200, since.Value, 60)
+
+		since = data[0].Gauges["control.hub.since_checkin;hub="+fresh.SpecString()]
+		assert.InDelta(t, 0, since.Value, 60)
+
+		assert.Equal(t, float32(1), data[0].Gauges["control.hubs.stale"].Value)
+	})
+
+	t.Run("reports the label links and services of the largest accounts", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+
+		sink := metrics.NewInmemSink(time.Minute, time.Hour)
+
+		mcfg := metrics.DefaultConfig("control")
+		mcfg.EnableHostname = false
+		mcfg.EnableRuntimeMetrics = false
+
+		m, err := metrics.New(mcfg, sink)
+		require.NoError(t, err)
+
+		s.m = m
+
+		defer func(n int) {
+			RoutingStatsTopAccounts = n
+		}(RoutingStatsTopAccounts)
+
+		RoutingStatsTopAccounts = 2
+
+		var accounts []*pb.Account
+
+		for i := 0; i < 3; i++ {
+			accounts = append(accounts, &pb.Account{
+				Namespace: "/",
+				AccountId: pb.NewULID(),
+			})
+		}
+
+		for i, seed := range []struct{ links, services int }{
+			{3, 1},
+			{2, 2},
+			{1, 0},
+		} {
+			for j := 0; j < seed.links; j++ {
+				require.NoError(t, dbx.Check(db.Create(&LabelLink{
+					AccountID: accounts[i].Key(),
+					Labels:    fmt.Sprintf(":hostname=%d-%d.com", i, j),
+					Target:    "service=www",
+				})))
+			}
+
+			for j := 0; j < seed.services; j++ {
+				require.NoError(t, dbx.Check(db.Create(&Service{
+					AccountId: accounts[i].Key(),
+					HubId:     pb.NewULID().Bytes(),
+					ServiceId: pb.NewULID().Bytes(),
+					Type:      "test",
+				})))
+			}
+		}
+
+		require.NoError(t, s.collectRoutingStats())
+
+		gauges := sink.Data()[0].Gauges
+
+		byAccount := func(table, account string) float32 {
+			return gauges["control.routing."+table+".by_account;account="+account].Value
+		}
+
+		assert.Equal(t, float32(6), gauges["control.routing.label_links"].Value)
+		assert.Equal(t, float32(3), byAccount("label_links", accounts[0].StringKey()))
+		assert.Equal(t, float32(2), byAccount("label_links", accounts[1].StringKey()))
+		assert.Equal(t, float32(1), byAccount("label_links", "other"))
+
+		assert.NotContains(t, gauges, "control.routing.label_links.by_account;account="+accounts[2].StringKey())
+
+		assert.Equal(t, float32(3), gauges["control.routing.services"].Value)
+		assert.Equal(t, float32(1), byAccount("services", accounts[0].StringKey()))
+		assert.Equal(t, float32(2), byAccount("services", accounts[1].StringKey()))
+		assert.Equal(t, float32(0), byAccount("services", "other"))
+	})
+
+	t.Run("flags routes on hubs that are gone or stale", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.connectedHubs = make(map[string]*connectedHub)
+
+		healthy := pb.NewULID()
+		stale := pb.NewULID()
+		elsewhere := pb.NewULID()
+		gone := pb.NewULID()
+
+		old := time.Now().Add(-20 * time.Minute)
+
+		// The gone hub has no record at all.
+		for id, checkin := range map[*pb.ULID]time.Time{
+			healthy:   old,
+			stale:     old,
+			elsewhere: time.Now(),
+		} {
+			hr := Hub{
+				StableID:       pb.NewULID().Bytes(),
+				InstanceID:     id.Bytes(),
+				ConnectionInfo: []byte("[]"),
+				LastCheckin:    checkin,
+			}
+
+			require.NoError(t, dbx.Check(db.Create(&hr)))
+		}
+
+		// Only the healthy hub is checking in with this server, and the stale
+		// one is connected but hasn't been heard from. The hub streaming to
+		// another control server is healthy too, since its checkins are in
+		// the database all the servers share.
+		s.connectedHubs[healthy.SpecString()] = &connectedHub{}
+		s.connectedHubs[stale.SpecString()] = &connectedHub{}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go s.touchHubCheckin(ctx, healthy)
+
+		require.Eventually(t, func() bool {
+			var hr Hub
+
+			err := dbx.Check(db.Where("instance_id = ?", healthy.Bytes()).First(&hr))
+			return err == nil && time.Since(hr.LastCheckin) < time.Minute
+		}, 5*time.Second, 10*time.Millisecond)
+
+		account := &pb.Account{
+			AccountId: pb.NewULID(),
+			Namespace: "/",
+		}
+
+		labels := pb.ParseLabelSet("service=emp,env=test")
+
+		services := make(map[string]*pb.ULID)
+
+		for _, hub := range []*pb.ULID{healthy, stale, elsewhere, gone} {
+			so := Service{
+				AccountId: account.Key(),
+				HubId:     hub.Bytes(),
+				ServiceId: pb.NewULID().Bytes(),
+				Type:      "http",
+				Labels:    labels.AsStringArray(),
+			}
+
+			require.NoError(t, dbx.Check(db.Create(&so)))
+
+			services[hub.SpecString()] = pb.ULIDFromBytes(so.ServiceId)
+		}
+
+		routes, err := s.accountServices(ctx, db, account)
+		require.NoError(t, err)
+
+		require.Equal(t, 4, len(routes.Services))
+
+		for _, route := range routes.Services {
+			assert.Equal(t, services[route.Hub.SpecString()], route.Id)
+
+			unhealthy := route.Hub.Equal(stale) || route.Hub.Equal(gone)
+			assert.Equal(t, unhealthy, route.Unhealthy, "hub: %s", route.Hub)
+		}
+	})
+
+	t.Run("marks hubs stale as the clock passes the threshold", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		fake := clock.NewFake(time.Now().Truncate(time.Second))
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.cfg.Clock = fake
+		s.connectedHubs = make(map[string]*connectedHub)
+
+		sink := metrics.NewInmemSink(time.Minute, time.Hour)
+
+		mcfg := metrics.DefaultConfig("control")
+		mcfg.EnableHostname = false
+		mcfg.EnableRuntimeMetrics = false
+
+		m, err := metrics.New(mcfg, sink)
+		require.NoError(t, err)
+
+		s.m = m
+
+		hub := pb.NewULID()
+		stableId := pb.NewULID()
+
+		require.NoError(t, dbx.Check(db.Create(&Hub{
+			StableID:       stableId.Bytes(),
+			InstanceID:     hub.Bytes(),
+			ConnectionInfo: []byte("[]"),
+			LastCheckin:    fake.Now(),
+		})))
+
+		s.connectedHubs[hub.SpecString()] = &connectedHub{}
+
+		check := func() (bool, map[string]metrics.GaugeValue) {
+			routes := []*pb.ServiceRoute{{Hub: hub}}
+			require.NoError(t, s.flagUnhealthyRoutes(db, routes))
+
+			require.NoError(t, s.collectHubCheckins())
+
+			return routes[0].Unhealthy, sink.Data()[0].Gauges
+		}
+
+		unhealthy, gauges := check()
+		assert.False(t, unhealthy)
+		assert.Equal(t, float32(0), gauges["control.hubs.stale"].Value)
+
+		fake.Advance(HubStaleThreshold + time.Second)
+
+		unhealthy, gauges = check()
+		assert.True(t, unhealthy)
+		assert.Equal(t, float32(1), gauges["control.hubs.stale"].Value)
+		assert.Equal(t,
+			float32((HubStaleThreshold + time.Second).Seconds()),
+			gauges["control.hub.since_checkin;hub="+stableId.SpecString()].Value,
+		)
+
+		// Checking in again brings it back.
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		s.touchHubCheckin(ctx, hub)
+
+		unhealthy, gauges = check()
+		assert.False(t, unhealthy)
+		assert.Equal(t, float32(0), gauges["control.hubs.stale"].Value)
+	})
+
+	t.Run("can create and remove a service for an account", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.awsSess = sess
+		s.bucket = bucket
+		s.lockTable = "hzntest"
+
+		var err error
+		s.lockMgr, err = dynamolock.New(dynamodb.New(sess), s.lockTable)
+		require.NoError(t, err)
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(top, md)
+
+		ct, err := s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/",
+		})
+
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		accountId := pb.NewULID()
+		account := &pb.Account{
+			Namespace: "/",
+			AccountId: accountId,
+		}
+
+		ctr, err := s.IssueHubToken(ctx, &pb.Noop{})
+		require.NoError(t, err)
+
+		md3 := make(metadata.MD)
+		md3.Set("authorization", ctr.Token)
+
+		labels := pb.ParseLabelSet("service=www,env=prod")
+
+		hubId := pb.NewULID()
+		serviceId := pb.NewULID()
+
+		_, err = s.AddService(
+			metadata.NewIncomingContext(top, md3),
+			&pb.ServiceRequest{
+				Account: account,
+				Hub:     hubId,
+				Id:      serviceId,
+				Type:    "test",
+				Labels:  labels,
+				Metadata: []*pb.KVPair{
+					{
+						Key:   "version",
+						Value: "0.1x",
+					},
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		// Check the account payload written to s3
+
+		s3api := s3.New(sess)
+
+		resp, err := s3api.GetObject(&s3.GetObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String("account_services/" + account.HashKey()),
+		})
+
+		require.NoError(t, err)
+
+		compressedData, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		data, err := zstdDecompress(compressedData)
+		require.NoError(t, err)
+
+		var accs pb.AccountServices
+
+		err = accs.Unmarshal(data)
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(accs.Services))
+
+		sr := accs.Services[0]
+
+		assert.Equal(t, hubId, sr.Hub)
+		assert.Equal(t, serviceId, sr.Id)
+		assert.Equal(t, labels, sr.Labels)
+		assert.Equal(t, "test", sr.Type)
+
+		{
+			// Verify we have the service
+			resp, err := s.ListServices(
+				metadata.NewIncomingContext(top, md3),
+				&pb.ListServicesRequest{
+					Account: account,
+				},
+			)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			require.Len(t, resp.Services, 1)
+			require.Equal(t, resp.Services[0].Id, serviceId)
+		}
+
+		_, err = s.RemoveService(
+			metadata.NewIncomingContext(top, md3),
+			&pb.ServiceRequest{
+				Account: account,
+				Hub:     hubId,
+				Id:      serviceId,
+			},
+		)
+		require.NoError(t, err)
+
+		var so Service
+		err = dbx.Check(db.First(&so))
+
+		assert.Error(t, err)
+
+		resp, err = s3api.GetObject(&s3.GetObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String("account_services/" + account.HashKey()),
+		})
+
+		require.NoError(t, err)
+
+		compressedData, err = ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		data, err = zstdDecompress(compressedData)
+		require.NoError(t, err)
+
+		var accs2 pb.AccountServices
+
+		err = accs2.Unmarshal(data)
+		require.NoError(t, err)
+
+		require.Equal(t, 0, len(accs2.Services))
+	})
+
+	t.Run("limits the labels of services and label links", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.awsSess = sess
+		s.bucket = bucket
+		s.lockTable = "hzntest"
+
+		s.cfg.MaxLabels = 3
+		s.cfg.MaxLabelLength = 12
+
+		var err error
+		s.lockMgr, err = dynamolock.New(dynamodb.New(sess), s.lockTable)
+		require.NoError(t, err)
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		authCtx := func(auth string) context.Context {
+			md := make(metadata.MD)
+			md.Set("authorization", auth)
+			return metadata.NewIncomingContext(top, md)
+		}
+
+		ct, err := s.Register(authCtx("aabbcc"), &pb.ControlRegister{
+			Namespace: "/",
+		})
+		require.NoError(t, err)
+
+		hub, err := s.IssueHubToken(authCtx("aabbcc"), &pb.Noop{})
+		require.NoError(t, err)
+
+		account := &pb.Account{
+			Namespace: "/",
+			AccountId: pb.NewULID(),
+		}
+
+		_, err = s.AddAccount(authCtx(ct.Token), &pb.AddAccountRequest{
+			Account: account,
+			Limits: &pb.Account_Limits{
+				HttpRequests: 1000,
+			},
+		})
+		require.NoError(t, err)
+
+		addService := func(labels string) error {
+			_, err := s.AddService(authCtx(hub.Token), &pb.ServiceRequest{
+				Account: account,
+				Hub:     pb.NewULID(),
+				Id:      pb.NewULID(),
+				Type:    "test",
+				Labels:  pb.ParseLabelSet(labels),
+			})
+			return err
+		}
+
+		addLabelLink := func(labels, target string) error {
+			_, err := s.AddLabelLink(authCtx(ct.Token), &pb.AddLabelLinkRequest{
+				Account: account,
+				Labels:  pb.ParseLabelSet(labels),
+				Target:  pb.ParseLabelSet(target),
+			})
+			return err
+		}
+
+		t.Run("at the limits", func(t *testing.T) {
+			require.NoError(t, addService("a=1,b=2,service=abcd"))
+			require.NoError(t, addLabelLink(":hostname=ab", "a=1,b=2,c=3"))
+		})
+
+		t.Run("over the label count", func(t *testing.T) {
+			err := addService("a=1,b=2,c=3,d=4")
+			require.Error(t, err)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+			err = addLabelLink("a=1,b=2,c=3,d=4", "service=x")
+			require.Error(t, err)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+			err = addLabelLink(":hostname=a", "a=1,b=2,c=3,d=4")
+			require.Error(t, err)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+
+		t.Run("over the label length", func(t *testing.T) {
+			err := addService("service=abcde")
+			require.Error(t, err)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+			err = addLabelLink(":hostname=abc", "service=abcd")
+			require.Error(t, err)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+
+		var count int
+		require.NoError(t, dbx.Check(db.Model(&Service{}).Count(&count)))
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("warms the routing of every account", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.awsSess = sess
+		s.bucket = bucket
+		s.lockTable = "hzntest"
+
+		var err error
+		s.lockMgr, err = dynamolock.New(dynamodb.New(sess), s.lockTable)
+		require.NoError(t, err)
+
+		var accounts []*pb.Account
+
+		for i := 0; i < 3; i++ {
+			account := &pb.Account{
+				Namespace: "/",
+				AccountId: pb.NewULID(),
+			}
+
+			accounts = append(accounts, account)
+
+			require.NoError(t, dbx.Check(db.Create(&Account{
+				ID:        account.Key(),
+				Namespace: account.Namespace,
+			})))
+		}
+
+		serviceId := pb.NewULID()
+
+		require.NoError(t, dbx.Check(db.Create(&Service{
+			ServiceId: serviceId.Bytes(),
+			HubId:     pb.NewULID().Bytes(),
+			AccountId: accounts[0].Key(),
+			Type:      "test",
+			Labels:    pb.ParseLabelSet("service=www").AsStringArray(),
+		})))
+
+		// A canceled context stops before doing any work.
+		cctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.Equal(t, context.Canceled, s.WarmRouting(cctx))
+
+		require.NoError(t, s.WarmRouting(context.Background()))
+
+		s3api := s3.New(sess)
+
+		for i, account := range accounts {
+			resp, err := s3api.GetObject(&s3.GetObjectInput{
+				Bucket: aws.String(s.bucket),
+				Key:    aws.String("account_services/" + account.HashKey()),
+			})
+			require.NoError(t, err)
+
+			compressedData, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			require.NoError(t, err)
+
+			data, err := zstdDecompress(compressedData)
+			require.NoError(t, err)
+
+			var accs pb.AccountServices
+			require.NoError(t, accs.Unmarshal(data))
+
+			if i == 0 {
+				require.Equal(t, 1, len(accs.Services))
+				assert.Equal(t, serviceId, accs.Services[0].Id)
+			} else {
+				assert.Equal(t, 0, len(accs.Services))
+			}
+		}
+	})
+
+	t.Run("weighs routes by the configured service weights", func(t *testing.T) {
+		var s Server
+		s.L = L
+		s.cfg.ServiceWeights = []ServiceWeight{
+			{Labels: pb.ParseLabelSet("env=canary"), Weight: 1},
+			{Labels: pb.ParseLabelSet("env=prod"), Weight: 9},
+		}
+
+		route := func(labels string) *pb.ServiceRoute {
+			return &pb.ServiceRoute{
+				Hub:    pb.NewULID(),
+				Id:     pb.NewULID(),
+				Type:   "http",
+				Labels: pb.ParseLabelSet(labels),
+			}
+		}
+
+		canary := route("service=www,env=canary")
+		prod := route("service=www,env=prod")
+		other := route("service=www,env=test")
+
+		// A stale weight is cleared when no entry matches.
+		other.Weight = 5
+
+		s.weighRoutes([]*pb.ServiceRoute{canary, prod, other})
+
+		assert.Equal(t, uint32(1), canary.Weight)
+		assert.Equal(t, uint32(9), prod.Weight)
+		assert.Equal(t, uint32(0), other.Weight)
+	})
+
+	t.Run("disconnects hubs whatever state they're left in", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.awsSess = sess
+		s.bucket = bucket
+		s.lockTable = "hzntest"
+
+		var err error
+		s.lockMgr, err = dynamolock.New(dynamodb.New(sess), s.lockTable)
+		require.NoError(t, err)
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctr, err := s.IssueHubToken(metadata.NewIncomingContext(top, md), &pb.Noop{})
+		require.NoError(t, err)
+
+		md3 := make(metadata.MD)
+		md3.Set("authorization", ctr.Token)
+
+		hctx := metadata.NewIncomingContext(top, md3)
+
+		account := &pb.Account{
+			Namespace: "/",
+			AccountId: pb.NewULID(),
+		}
+
+		addService := func(hubId *pb.ULID) {
+			_, err := s.AddService(hctx, &pb.ServiceRequest{
+				Account: account,
+				Hub:     hubId,
+				Id:      pb.NewULID(),
+				Type:    "test",
+				Labels:  pb.ParseLabelSet("service=www"),
+			})
+			require.NoError(t, err)
+		}
+
+		countRows := func(model interface{}) int {
+			var n int
+			require.NoError(t, dbx.Check(db.Model(model).Count(&n)))
+			return n
+		}
+
+		t.Run("with services but no hub", func(t *testing.T) {
+			stableId := pb.NewULID()
+			instanceId := pb.NewULID()
+
+			addService(instanceId)
+
+			_, err := s.HubDisconnect(hctx, &pb.HubDisconnectRequest{
+				StableId:   stableId,
+				InstanceId: instanceId,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, 0, countRows(&Service{}))
+		})
+
+		t.Run("with a hub but no services", func(t *testing.T) {
+			stableId := pb.NewULID()
+			instanceId := pb.NewULID()
+
+			_, err := s.FetchConfig(hctx, &pb.ConfigRequest{
+				StableId:   stableId,
+				InstanceId: instanceId,
+			})
+			require.NoError(t, err)
+
+			_, err = s.HubDisconnect(hctx, &pb.HubDisconnectRequest{
+				StableId:   stableId,
+				InstanceId: instanceId,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, 0, countRows(&Hub{}))
+		})
+
+		t.Run("twice", func(t *testing.T) {
+			stableId := pb.NewULID()
+			instanceId := pb.NewULID()
+
+			_, err := s.FetchConfig(hctx, &pb.ConfigRequest{
+				StableId:   stableId,
+				InstanceId: instanceId,
+			})
+			require.NoError(t, err)
+
+			addService(instanceId)
+
+			for i := 0; i < 2; i++ {
+				_, err = s.HubDisconnect(hctx, &pb.HubDisconnectRequest{
+					StableId:   stableId,
+					InstanceId: instanceId,
+				})
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, 0, countRows(&Hub{}))
+			assert.Equal(t, 0, countRows(&Service{}))
+		})
+
+		t.Run("only removes the hub if its services are removed", func(t *testing.T) {
+			stableId := pb.NewULID()
+			instanceId := pb.NewULID()
+
+			_, err := s.FetchConfig(hctx, &pb.ConfigRequest{
+				StableId:   stableId,
+				InstanceId: instanceId,
+			})
+			require.NoError(t, err)
+
+			addService(instanceId)
+
+			// Without s3 the account routing can't be updated, so removing
+			// the services fails and nothing should be removed.
+			s.bucket = "hzn-missing-bucket"
+			defer func() { s.bucket = bucket }()
+
+			_, err = s.HubDisconnect(hctx, &pb.HubDisconnectRequest{
+				StableId:   stableId,
+				InstanceId: instanceId,
+			})
+			require.Error(t, err)
+
+			// The service removal error has to make it back to the hub,
+			// rather than being dropped in favor of the hub removal's.
+			assert.Contains(t, err.Error(), "removing hub services")
+
+			assert.Equal(t, 1, countRows(&Hub{}))
+			assert.Equal(t, 1, countRows(&Service{}))
+		})
+	})
+
+	t.Run("can export an account's config and import it elsewhere", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.awsSess = sess
+		s.bucket = bucket
+		s.lockTable = "hzntest"
+
+		var err error
+		s.lockMgr, err = dynamolock.New(dynamodb.New(sess), s.lockTable)
+		require.NoError(t, err)
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctx := metadata.NewIncomingContext(top, md)
+
+		ct, err := s.Register(ctx, &pb.ControlRegister{
+			Namespace: "/foo",
+		})
+
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		mgmtCtx := metadata.NewIncomingContext(top, md2)
+
+		ctr, err := s.IssueHubToken(ctx, &pb.Noop{})
+		require.NoError(t, err)
+
+		md3 := make(metadata.MD)
+		md3.Set("authorization", ctr.Token)
+
+		hubCtx := metadata.NewIncomingContext(top, md3)
+
+		account := &pb.Account{
+			Namespace: "/foo",
+			AccountId: pb.NewULID(),
+		}
+
+		_, err = s.CreateAccount(mgmtCtx, &pb.CreateAccountRequest{
+			Account: account,
+			Limits: &pb.Account_Limits{
+				HttpRequests: 5,
+				Bandwidth:    100,
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = s.AddLabelLink(mgmtCtx, &pb.AddLabelLinkRequest{
+			Account: account,
+			Labels:  pb.ParseLabelSet(":hostname=foo.com"),
+			Target:  pb.ParseLabelSet("service=www,env=prod"),
+		})
+		require.NoError(t, err)
+
+		hubId := pb.NewULID()
+
+		for _, labels := range []string{"service=www,env=prod", "service=www,env=test"} {
+			_, err = s.AddService(hubCtx, &pb.ServiceRequest{
+				Account: account,
+				Hub:     hubId,
+				Id:      pb.NewULID(),
+				Type:    "test",
+				Labels:  pb.ParseLabelSet(labels),
+			})
+			require.NoError(t, err)
+		}
+
+		cfg, err := s.ExportAccountConfig(mgmtCtx, &pb.ExportRequest{
+			Account: account,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, float64(5), cfg.Limits.HttpRequests)
+		assert.Equal(t, 1, len(cfg.LabelLinks))
+		assert.Equal(t, 2, len(cfg.Services))
+
+		// Round trip the config through its wire form, as a CLI would.
+		data, err := cfg.Marshal()
+		require.NoError(t, err)
+
+		var loaded pb.AccountConfig
+		require.NoError(t, loaded.Unmarshal(data))
+
+		db2 := testsql.TestPostgresDB(t, "hzn_import")
+		defer db2.Close()
+
+		var s2 Server
+		s2.L = L
+		s2.db = db2
+		s2.vaultClient = vc
+		s2.vaultPath = s.vaultPath
+		s2.keyId = "k1"
+		s2.pubKey = pub
+		s2.awsSess = sess
+		s2.bucket = bucket
+		s2.lockTable = "hzntest"
+		s2.lockMgr = s.lockMgr
+
+		// Apply it twice, the second import should change nothing.
+		for i := 0; i < 2; i++ {
+			_, err = s2.ImportAccountConfig(mgmtCtx, &pb.ImportRequest{
+				Config: &loaded,
+			})
+			require.NoError(t, err)
+		}
+
+		imported, err := s2.ExportAccountConfig(mgmtCtx, &pb.ExportRequest{
+			Account: account,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, cfg, imported)
+
+		var links, services int
+		require.NoError(t, dbx.Check(db2.Model(&LabelLink{}).Count(&links)))
+		require.NoError(t, dbx.Check(db2.Model(&Service{}).Count(&services)))
+
+		assert.Equal(t, 1, links)
+		assert.Equal(t, 2, services)
+
+		// The config can't be used to write into another namespace.
+		other := loaded
+		other.Account = &pb.Account{
+			Namespace: "/bar",
+			AccountId: account.AccountId,
+		}
+
+		_, err = s2.ImportAccountConfig(mgmtCtx, &pb.ImportRequest{
+			Config: &other,
+		})
+		require.Error(t, err)
+
+		// Nor can the items in it name a different account than the config.
+		mixed := loaded
+		mixed.Services = []*pb.ServiceRequest{
+			{
+				Account: &pb.Account{
+					Namespace: "/foo",
+					AccountId: pb.NewULID(),
+				},
+				Hub:    hubId,
+				Id:     pb.NewULID(),
+				Type:   "test",
+				Labels: pb.ParseLabelSet("service=www,env=dev"),
+			},
+		}
+
+		_, err = s2.ImportAccountConfig(mgmtCtx, &pb.ImportRequest{
+			Config: &mixed,
+		})
+		require.Error(t, err)
+
+		assert.True(t, errors.Is(err, ErrInvalidRequest))
+
+		require.NoError(t, dbx.Check(db2.Model(&Service{}).Count(&services)))
+
+		assert.Equal(t, 2, services)
+	})
+
+	t.Run("streams services in batches", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ct, err := s.Register(metadata.NewIncomingContext(top, md), &pb.ControlRegister{
+			Namespace: "/foo",
+		})
+
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		mgmtCtx := metadata.NewIncomingContext(top, md2)
+
+		account := &pb.Account{
+			Namespace: "/foo/bar",
+			AccountId: pb.NewULID(),
+		}
+
+		other := &pb.Account{
+			Namespace: "/foobar",
+			AccountId: pb.NewULID(),
+		}
+
+		labels := pb.ParseLabelSet("service=www,env=prod")
+
+		for i := 0; i < 300; i++ {
+			for _, acc := range []*pb.Account{account, other} {
+				so := Service{
+					AccountId: acc.Key(),
+					HubId:     pb.NewULID().Bytes(),
+					ServiceId: pb.NewULID().Bytes(),
+					Type:      "test",
+					Labels:    labels.AsStringArray(),
+				}
+
+				require.NoError(t, dbx.Check(db.Create(&so)))
+			}
+		}
+
+		stream := &staticServiceStream{ctx: mgmtCtx}
+
+		err = s.StreamServices(&pb.StreamServicesRequest{
+			Labels:    pb.ParseLabelSet("service=www"),
+			BatchSize: 50,
+		}, stream)
+		require.NoError(t, err)
+
+		seen := map[string]struct{}{}
+
+		for _, resp := range stream.sent {
+			assert.Equal(t, 50, len(resp.Services))
+
+			for _, svc := range resp.Services {
+				assert.True(t, account.Equal(svc.Account))
+				seen[svc.Id.SpecString()] = struct{}{}
+			}
+		}
+
+		assert.Equal(t, 300, len(seen))
+		assert.Equal(t, 6, len(stream.sent))
+
+		ctx, cancel := context.WithCancel(mgmtCtx)
+		defer cancel()
+
+		stream = &staticServiceStream{ctx: ctx, cb: cancel}
+
+		err = s.StreamServices(&pb.StreamServicesRequest{
+			BatchSize: 50,
+		}, stream)
+		require.Error(t, err)
+
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, 1, len(stream.sent))
+	})
+
+	t.Run("broadcasts service activity for a canceled request past a stuck hub", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.awsSess = sess
+		s.bucket = bucket
+		s.lockTable = "hzntest"
+		s.connectedHubs = make(map[string]*connectedHub)
+
+		var err error
+		s.lockMgr, err = dynamolock.New(dynamodb.New(sess), s.lockTable)
+		require.NoError(t, err)
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctr, err := s.IssueHubToken(metadata.NewIncomingContext(top, md), &pb.Noop{})
+		require.NoError(t, err)
+
+		md3 := make(metadata.MD)
+		md3.Set("authorization", ctr.Token)
+
+		hubCtx := metadata.NewIncomingContext(top, md3)
+
+		var stream staticServerStream
+		stream.ctx = hubCtx
+		stream.SendC = make(chan *pb.CentralActivity, 1)
+		stream.RecvC = make(chan *pb.HubActivity, 1)
+
+		stream.RecvC <- &pb.HubActivity{
+			HubReg: &pb.HubActivity_HubRegistration{
+				Hub: pb.NewULID(),
+			},
+		}
+
+		go s.StreamActivity(&stream)
+
+		require.Eventually(t, func() bool {
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+
+			return len(s.connectedHubs) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+
+		// Hubs that have stopped reading and whose queues are already full.
+		// There are several so that, whatever order the hubs are visited in,
+		// the live hub is likely to come after one of them.
+		var stuck []*connectedHub
+
+		s.mu.Lock()
+
+		for i := 0; i < 5; i++ {
+			ch := &connectedHub{
+				xmit:     make(chan *pb.CentralActivity, 1),
+				messages: new(int64),
+				bytes:    new(int64),
+			}
+
+			ch.xmit <- &pb.CentralActivity{}
+
+			s.connectedHubs[fmt.Sprintf("stuck-%d", i)] = ch
+			stuck = append(stuck, ch)
+		}
+
+		s.mu.Unlock()
+
+		reqCtx, cancel := context.WithCancel(hubCtx)
+		cancel()
+
+		serviceId := pb.NewULID()
+
+		_, err = s.AddService(
+			reqCtx,
+			&pb.ServiceRequest{
+				Account: &pb.Account{
+					Namespace: "/",
+					AccountId: pb.NewULID(),
+				},
+				Hub:    pb.NewULID(),
+				Id:     serviceId,
+				Type:   "test",
+				Labels: pb.ParseLabelSet("service=www,env=prod"),
+			},
+		)
+		require.NoError(t, err)
+
+		for _, ch := range stuck {
+			assert.Equal(t, 1, len(ch.xmit))
+		}
+
+		select {
+		case <-time.After(time.Second):
+			t.Fatal("activity was not delivered to the hub")
+		case ca := <-stream.SendC:
+			require.Equal(t, 1, len(ca.AccountServices))
+			require.Equal(t, 1, len(ca.AccountServices[0].Services))
+
+			assert.Equal(t, serviceId, ca.AccountServices[0].Services[0].Id)
+		}
+	})
+
+	t.Run("only sends account activity to the hubs serving the account", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.cfg.SelectiveBroadcast = true
+		s.connectedHubs = make(map[string]*connectedHub)
+
+		account := &pb.Account{
+			Namespace: "/",
+			AccountId: pb.NewULID(),
+		}
+
+		other := &pb.Account{
+			Namespace: "/",
+			AccountId: pb.NewULID(),
+		}
+
+		serving := pb.NewULID()
+		idle := pb.NewULID()
+		unloaded := pb.NewULID()
+
+		err := dbx.Check(db.Create(&Service{
+			AccountId: account.Key(),
+			HubId:     serving.Bytes(),
+			ServiceId: pb.NewULID().Bytes(),
+			Type:      "test",
+			Labels:    pq.StringArray{"service=www"},
+		}))
+		require.NoError(t, err)
+
+		hubs := map[*pb.ULID]*connectedHub{}
+
+		for _, id := range []*pb.ULID{serving, idle, unloaded} {
+			ch := &connectedHub{
+				xmit:     make(chan *pb.CentralActivity, 10),
+				accounts: make(map[string]*pb.Account),
+			}
+
+			if id != unloaded {
+				require.NoError(t, s.loadHubAccounts(ch, id))
+			}
+
+			s.connectedHubs[id.SpecString()] = ch
+			hubs[id] = ch
+		}
+
+		received := func(id *pb.ULID) int {
+			n := len(hubs[id].xmit)
+
+			for i := 0; i < n; i++ {
+				<-hubs[id].xmit
+			}
+
+			return n
+		}
+
+		s.broadcastActivity(&pb.CentralActivity{
+			AccountServices: []*pb.AccountServices{
+				{Account: account},
+			},
+		})
+
+		assert.Equal(t, 1, received(serving))
+		assert.Equal(t, 0, received(idle))
+
+		// A hub whose accounts haven't been loaded could be serving any of
+		// them, so it gets everything.
+		assert.Equal(t, 1, received(unloaded))
+
+		// Resolved routes replace the account's view on the hubs serving
+		// it, so they're of no interest to the rest either.
+		s.broadcastActivity(&pb.CentralActivity{
+			ResolvedRoutes: []*pb.AccountServices{
+				{Account: account},
+			},
+		})
+
+		assert.Equal(t, 1, received(serving))
+		assert.Equal(t, 0, received(idle))
+		assert.Equal(t, 1, received(unloaded))
+
+		s.broadcastActivity(&pb.CentralActivity{
+			NewLabelLinks: &pb.LabelLinks{},
+		})
+
+		assert.Equal(t, 1, received(serving))
+		assert.Equal(t, 1, received(idle))
+		assert.Equal(t, 1, received(unloaded))
+
+		// Anything else alongside account activity goes to every hub.
+		s.broadcastActivity(&pb.CentralActivity{
+			ResolvedRoutes: []*pb.AccountServices{
+				{Account: account},
+			},
+			NewLabelLinks: &pb.LabelLinks{},
+		})
+
+		assert.Equal(t, 1, received(serving))
+		assert.Equal(t, 1, received(idle))
+		assert.Equal(t, 1, received(unloaded))
+
+		// Once the idle hub adds a service for the other account, it gets
+		// that account's activity.
+		s.noteHubAccount(idle, other)
+
+		s.broadcastActivity(&pb.CentralActivity{
+			AccountServices: []*pb.AccountServices{
+				{Account: other},
+			},
+		})
+
+		assert.Equal(t, 0, received(serving))
+		assert.Equal(t, 1, received(idle))
+
+		// Edge hubs that have cached the account's routes keep getting its
+		// activity, without serving it.
+		cached := &pb.Account{
+			Namespace: "/",
+			AccountId: pb.NewULID(),
+		}
+
+		hubs[idle].noteAccounts(cached)
+
+		s.broadcastActivity(&pb.CentralActivity{
+			AccountServices: []*pb.AccountServices{
+				{Account: cached},
+			},
+		})
+
+		assert.Equal(t, 0, received(serving))
+		assert.Equal(t, 1, received(idle))
+
+		s.broadcastActivity(&pb.CentralActivity{
+			ResolvedRoutes: []*pb.AccountServices{
+				{Account: cached},
+			},
+		})
+
+		assert.Equal(t, 0, received(serving))
+		assert.Equal(t, 1, received(idle))
+	})
+
+	t.Run("can read and reset the flow counters of the connected hubs", func(t *testing.T) {
+		var s Server
+		s.L = L
+		s.opsToken = "opsrocks"
+		s.connectedHubs = make(map[string]*connectedHub)
+
+		sink := metrics.NewInmemSink(time.Minute, time.Hour)
+
+		m, err := metrics.New(metrics.DefaultConfig("control"), sink)
+		require.NoError(t, err)
+
+		s.m = m
+
+		s.flowTop, err = NewFlowTop(DefaultFlowTopSize)
+		require.NoError(t, err)
+
+		ch := &connectedHub{
+			xmit:        make(chan *pb.CentralActivity, 1),
+			messages:    new(int64),
+			bytes:       new(int64),
+			lastFlowSeq: new(int64),
+		}
+
+		s.connectedHubs["hub1"] = ch
+
+		var seq int64
+
+		flow := func() []*pb.FlowRecord {
+			seq++
+
+			return []*pb.FlowRecord{
+				{
+					Sequence: seq,
+					Stream: &pb.FlowStream{
+						FlowId:      pb.NewULID(),
+						HubId:       pb.NewULID(),
+						NumMessages: 2,
+						NumBytes:    100,
+					},
+				},
+			}
+		}
+
+		s.processFlows(ch, flow())
+		s.processFlows(ch, flow())
+
+		_, err = s.FlowCounters(context.Background(), &pb.Noop{})
+		require.Error(t, err)
+
+		_, err = s.ResetFlowCounters(context.Background(), &pb.Noop{})
+		require.Error(t, err)
+
+		md := make(metadata.MD)
+		md.Set("authorization", "opsrocks")
+
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+
+		expected := []*pb.HubFlowCounters{
+			{
+				Hub:      "hub1",
+				Messages: 4,
+				Bytes:    200,
+			},
+		}
+
+		resp, err := s.FlowCounters(ctx, &pb.Noop{})
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, resp.Hubs)
+
+		// Resetting returns the values from before the reset.
+		resp, err = s.ResetFlowCounters(ctx, &pb.Noop{})
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, resp.Hubs)
+
+		resp, err = s.FlowCounters(ctx, &pb.Noop{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []*pb.HubFlowCounters{{Hub: "hub1"}}, resp.Hubs)
+
+		s.processFlows(ch, flow())
+
+		resp, err = s.FlowCounters(ctx, &pb.Noop{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []*pb.HubFlowCounters{{Hub: "hub1", Messages: 2, Bytes: 100}}, resp.Hubs)
+
+		// Resets don't lose or double count flows processed at the same time.
+		var (
+			wg    sync.WaitGroup
+			total int64
+		)
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 100; i++ {
+				s.processFlows(ch, flow())
+			}
+		}()
+
+		for i := 0; i < 10; i++ {
+			resp, err := s.ResetFlowCounters(ctx, &pb.Noop{})
+			require.NoError(t, err)
+
+			total += resp.Hubs[0].Messages
+		}
+
+		wg.Wait()
+
+		resp, err = s.ResetFlowCounters(ctx, &pb.Noop{})
+		require.NoError(t, err)
+
+		total += resp.Hubs[0].Messages
+
+		assert.Equal(t, int64(2+2*100), total)
+	})
+
+	t.Run("tracks drops and queue depth for slow hubs", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		var s Server
+		s.L = hclog.New(&hclog.LoggerOptions{
+			Output: &logs,
+		})
+		s.opsToken = "opsrocks"
+		s.connectedHubs = make(map[string]*connectedHub)
+
+		sink := metrics.NewInmemSink(time.Minute, time.Hour)
+
+		mcfg := metrics.DefaultConfig("control")
+		mcfg.EnableHostname = false
+		mcfg.EnableRuntimeMetrics = false
+
+		m, err := metrics.New(mcfg, sink)
+		require.NoError(t, err)
+
+		s.m = m
+
+		defer func(n int64) {
+			HubSlowConsumerDrops = n
+		}(HubSlowConsumerDrops)
+
+		HubSlowConsumerDrops = 2
+
+		// Neither hub is reading, but only the slow one runs out of room.
+		slow := &connectedHub{
+			xmit: make(chan *pb.CentralActivity, 2),
+		}
+
+		fast := &connectedHub{
+			xmit: make(chan *pb.CentralActivity, 10),
+		}
+
+		s.connectedHubs["slow"] = slow
+		s.connectedHubs["fast"] = fast
+
+		for i := 0; i < 5; i++ {
+			s.broadcastActivity(&pb.CentralActivity{})
+		}
+
+		assert.Equal(t, int64(3), atomic.LoadInt64(&slow.dropped))
+		assert.Equal(t, int64(2), atomic.LoadInt64(&slow.maxQueueDepth))
+
+		assert.Equal(t, int64(0), atomic.LoadInt64(&fast.dropped))
+		assert.Equal(t, int64(5), atomic.LoadInt64(&fast.maxQueueDepth))
+
+		data := sink.Data()
+
+		assert.Equal(t, 3, data[0].Counters["control.broadcast.dropped;hub=slow"].Count)
+		assert.NotContains(t, data[0].Counters, "control.broadcast.dropped;hub=fast")
+
+		assert.Equal(t, float32(2), data[0].Gauges["control.broadcast.max_queue_depth;hub=slow"].Value)
+		assert.Equal(t, float32(5), data[0].Gauges["control.broadcast.max_queue_depth;hub=fast"].Value)
+
+		assert.Contains(t, logs.String(), "hub is a slow consumer")
+
+		_, err = s.ConnectedHubs(context.Background(), &pb.Noop{})
+		require.Error(t, err)
+
+		md := make(metadata.MD)
+		md.Set("authorization", "opsrocks")
+
+		resp, err := s.ConnectedHubs(metadata.NewIncomingContext(context.Background(), md), &pb.Noop{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []*pb.ConnectedHub{
+			{
+				Hub:           "fast",
+				QueueDepth:    5,
+				MaxQueueDepth: 5,
+			},
+			{
+				Hub:           "slow",
+				Dropped:       3,
+				QueueDepth:    2,
+				MaxQueueDepth: 2,
+			},
+		}, resp.Hubs)
+	})
+
+	t.Run("prunes hubs that have stopped receiving activity", func(t *testing.T) {
+		var s Server
+		s.L = L
+		s.connectedHubs = make(map[string]*connectedHub)
+
+		defer func(n int64) {
+			HubDeadConsumerDrops = n
+		}(HubDeadConsumerDrops)
+
+		HubDeadConsumerDrops = 3
+
+		// The orphan's stream has gone away without removing it, so nothing
+		// reads its queue.
+		orphan := &connectedHub{
+			xmit: make(chan *pb.CentralActivity, 2),
+		}
+
+		var canceled bool
+
+		// The live hub drops some activity, but reads enough that it never
+		// misses 3 in a row.
+		live := &connectedHub{
+			xmit: make(chan *pb.CentralActivity, 1),
+			cancel: func() {
+				canceled = true
+			},
+		}
+
+		s.connectedHubs["orphan"] = orphan
+		s.connectedHubs["live"] = live
+
+		for i := 0; i < 4; i++ {
+			s.broadcastActivity(&pb.CentralActivity{})
+			s.broadcastActivity(&pb.CentralActivity{})
+			<-live.xmit
+		}
+
+		// The orphan's queue filled after 2 broadcasts, and it was removed
+		// once it had missed 3 more.
+		assert.Equal(t, int64(3), atomic.LoadInt64(&orphan.dropped))
+		assert.NotContains(t, s.connectedHubs, "orphan")
+
+		assert.Contains(t, s.connectedHubs, "live")
+		assert.False(t, canceled)
+
+		// A hub that stops reading is pruned too, once its queue is full and
+		// it has missed 3, and its stream ended.
+		for i := 0; i < 4; i++ {
+			s.broadcastActivity(&pb.CentralActivity{})
+		}
+
+		assert.NotContains(t, s.connectedHubs, "live")
+		assert.True(t, canceled)
+
+		// Pruning leaves a replacement connection from the same hub alone.
+		replaced := &connectedHub{
+			xmit: make(chan *pb.CentralActivity, 1),
+		}
+
+		s.connectedHubs["live"] = replaced
+
+		s.pruneHubs(map[string]*connectedHub{"live": live})
+
+		assert.True(t, s.connectedHubs["live"] == replaced)
+	})
+
+	t.Run("catches up a hub that misses routing activity", func(t *testing.T) {
+		var s Server
+		s.L = L
+		s.connectedHubs = make(map[string]*connectedHub)
+
+		var canceled bool
+
+		full := &connectedHub{
+			xmit: make(chan *pb.CentralActivity, 1),
+			cancel: func() {
+				canceled = true
+			},
+		}
+
+		s.connectedHubs["full"] = full
+
+		s.broadcastActivity(&pb.CentralActivity{})
+
+		// Missing activity that doesn't change routing is only counted.
+		s.broadcastActivity(&pb.CentralActivity{RequestStats: true})
+
+		assert.Equal(t, int64(1), atomic.LoadInt64(&full.dropped))
+		assert.Contains(t, s.connectedHubs, "full")
+		assert.Equal(t, int32(0), atomic.LoadInt32(&full.resync))
+
+		// Without a replay to catch the hub up on reconnecting, its stream
+		// is left alone and it's resynced once it has caught up.
+		s.broadcastActivity(&pb.CentralActivity{
+			NewLabelLinks: &pb.LabelLinks{},
+		})
+
+		assert.Equal(t, int64(2), atomic.LoadInt64(&full.dropped))
+		assert.Contains(t, s.connectedHubs, "full")
+		assert.False(t, canceled)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&full.resync))
+
+		// With one, the stream is ended straight away, so the hub
+		// reconnects and gets the activity in the replay.
+		s.replay = newActivityReplay(10)
+
+		s.broadcastActivity(&pb.CentralActivity{
+			NewLabelLinks: &pb.LabelLinks{},
+		})
+
+		assert.Equal(t, int64(3), atomic.LoadInt64(&full.dropped))
+		assert.NotContains(t, s.connectedHubs, "full")
+		assert.True(t, canceled)
+	})
+
+	t.Run("resyncs the routes of a hub the replay can't catch up", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.connectedHubs = make(map[string]*connectedHub)
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctr, err := s.IssueHubToken(metadata.NewIncomingContext(top, md), &pb.Noop{})
+		require.NoError(t, err)
+
+		hubId := pb.NewULID()
+
+		serving := &pb.Account{
+			AccountId: pb.NewULID(),
+			Namespace: "/",
+		}
+
+		cached := &pb.Account{
+			AccountId: pb.NewULID(),
+			Namespace: "/",
+		}
+
+		so := Service{
+			AccountId: serving.Key(),
+			HubId:     hubId.Bytes(),
+			ServiceId: pb.NewULID().Bytes(),
+			Type:      "http",
+			Labels:    pb.ParseLabelSet("service=www").AsStringArray(),
+		}
+
+		require.NoError(t, dbx.Check(db.Create(&so)))
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ctr.Token)
+
+		var stream staticServerStream
+		stream.ctx = metadata.NewIncomingContext(top, md2)
+		stream.SendC = make(chan *pb.CentralActivity, 10)
+		stream.RecvC = make(chan *pb.HubActivity, 1)
+
+		stream.RecvC <- &pb.HubActivity{
+			HubReg: &pb.HubActivity_HubRegistration{
+				Hub: hubId,
+			},
+			CachedAccounts: []*pb.Account{cached},
+		}
+
+		go s.StreamActivity(&stream)
+
+		receive := func() *pb.CentralActivity {
+			select {
+			case <-time.After(5 * time.Second):
+				t.Fatal("activity was not delivered to the hub")
+				return nil
+			case ca := <-stream.SendC:
+				return ca
+			}
+		}
+
+		resynced := map[string]*pb.AccountServices{}
+
+		for i := 0; i < 2; i++ {
+			ca := receive()
+			require.Equal(t, 1, len(ca.ResolvedRoutes))
+
+			routes := ca.ResolvedRoutes[0]
+			resynced[routes.Account.StringKey()] = routes
+		}
+
+		require.Contains(t, resynced, serving.StringKey())
+		require.Contains(t, resynced, cached.StringKey())
+
+		require.Equal(t, 1, len(resynced[serving.StringKey()].Services))
+		assert.Equal(t, pb.ULIDFromBytes(so.ServiceId), resynced[serving.StringKey()].Services[0].Id)
+
+		assert.Equal(t, 0, len(resynced[cached.StringKey()].Services))
+
+		// Once resynced, it's only sent activity as it's broadcast.
+		require.Eventually(t, func() bool {
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+
+			return len(s.connectedHubs) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+
+		live := &pb.CentralActivity{NewLabelLinks: &pb.LabelLinks{}}
+		s.broadcastActivity(live)
+
+		assert.Equal(t, live, receive())
+		assert.Equal(t, 0, len(stream.SendC))
+	})
+
+	t.Run("withholds a suspended account's services until it's unsuspended", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.opsToken = "ddeeff"
+		s.awsSess = sess
+		s.bucket = bucket
+		s.lockTable = "hzntest"
+		s.connectedHubs = make(map[string]*connectedHub)
+
+		var err error
+		s.lockMgr, err = dynamolock.New(dynamodb.New(sess), s.lockTable)
+		require.NoError(t, err)
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctr, err := s.IssueHubToken(metadata.NewIncomingContext(top, md), &pb.Noop{})
+		require.NoError(t, err)
+
+		ct, err := s.Register(metadata.NewIncomingContext(top, md), &pb.ControlRegister{
+			Namespace: "/",
+		})
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ct.Token)
+
+		mgmtCtx := metadata.NewIncomingContext(top, md2)
+
+		md3 := make(metadata.MD)
+		md3.Set("authorization", ctr.Token)
+
+		hubCtx := metadata.NewIncomingContext(top, md3)
+
+		md4 := make(metadata.MD)
+		md4.Set("authorization", "ddeeff")
+
+		opsCtx := metadata.NewIncomingContext(top, md4)
+
+		hubId := pb.NewULID()
+
+		var stream staticServerStream
+		stream.ctx = hubCtx
+		stream.SendC = make(chan *pb.CentralActivity, 10)
+		stream.RecvC = make(chan *pb.HubActivity, 1)
+
+		stream.RecvC <- &pb.HubActivity{
+			HubReg: &pb.HubActivity_HubRegistration{
+				Hub: hubId,
+			},
+		}
+
+		go s.StreamActivity(&stream)
+
+		require.Eventually(t, func() bool {
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+
+			return len(s.connectedHubs) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+
+		account := &pb.Account{
+			Namespace: "/",
+			AccountId: pb.NewULID(),
+		}
+
+		next := func() *pb.CentralActivity {
+			select {
+			case <-time.After(time.Second):
+				t.Fatal("activity was not delivered to the hub")
+				return nil
+			case ca := <-stream.SendC:
+				return ca
+			}
+		}
+
+		addService := func() *pb.ULID {
+			id := pb.NewULID()
+
+			_, err := s.AddService(hubCtx, &pb.ServiceRequest{
+				Account: account,
+				Hub:     hubId,
+				Id:      id,
+				Type:    "test",
+				Labels:  pb.ParseLabelSet("service=www,env=prod"),
+			})
+			require.NoError(t, err)
+
+			return id
+		}
+
+		createToken := func() error {
+			_, err := s.CreateToken(mgmtCtx, &pb.CreateTokenRequest{
+				Account:      account,
+				Capabilities: []pb.TokenCapability{{Capability: pb.CONNECT}},
+			})
+
+			return err
+		}
+
+		first := addService()
+
+		ca := next()
+		require.Equal(t, 1, len(ca.AccountServices))
+		assert.Equal(t, first, ca.AccountServices[0].Services[0].Id)
+
+		_, err = s.SetAccountSuspended(hubCtx, &pb.SetSuspendedRequest{
+			Account:   account,
+			Suspended: true,
+		})
+		assert.Equal(t, ErrBadAuthentication, err)
+
+		_, err = s.SetAccountSuspended(opsCtx, &pb.SetSuspendedRequest{
+			Account:   account,
+			Suspended: true,
+		})
+		require.NoError(t, err)
+
+		ca = next()
+		require.Equal(t, 1, len(ca.ResolvedRoutes))
+		assert.True(t, ca.ResolvedRoutes[0].Suspended)
+		assert.True(t, ca.ResolvedRoutes[0].Account.Equal(account))
+		assert.Equal(t, 0, len(ca.ResolvedRoutes[0].Services))
+
+		routes, err := s.accountServices(top, s.db, account)
+		require.NoError(t, err)
+
+		assert.True(t, routes.Suspended)
+		assert.Equal(t, 0, len(routes.Services))
+
+		// Services added while suspended are withheld too.
+		second := addService()
+
+		select {
+		case ca := <-stream.SendC:
+			t.Fatalf("service of suspended account was broadcast: %s", ca)
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		err = createToken()
+		require.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		_, err = s.SetAccountSuspended(opsCtx, &pb.SetSuspendedRequest{
+			Account:   account,
+			Suspended: false,
+		})
+		require.NoError(t, err)
+
+		ca = next()
+		require.Equal(t, 1, len(ca.ResolvedRoutes))
+		assert.False(t, ca.ResolvedRoutes[0].Suspended)
+
+		var ids []*pb.ULID
+
+		for _, rs := range ca.ResolvedRoutes[0].Services {
+			ids = append(ids, rs.Id)
+		}
+
+		assert.ElementsMatch(t, []*pb.ULID{first, second}, ids)
+
+		require.NoError(t, createToken())
+	})
+
+	t.Run("recomputes an account's routing when it's invalidated", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.opsToken = "ddeeff"
+		s.awsSess = sess
+		s.bucket = bucket
+		s.lockTable = "hzntest"
+		s.connectedHubs = make(map[string]*connectedHub)
+
+		var err error
+		s.lockMgr, err = dynamolock.New(dynamodb.New(sess), s.lockTable)
+		require.NoError(t, err)
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		md := make(metadata.MD)
+		md.Set("authorization", "aabbcc")
+
+		ctr, err := s.IssueHubToken(metadata.NewIncomingContext(top, md), &pb.Noop{})
+		require.NoError(t, err)
+
+		md2 := make(metadata.MD)
+		md2.Set("authorization", ctr.Token)
+
+		hubCtx := metadata.NewIncomingContext(top, md2)
+
+		md3 := make(metadata.MD)
+		md3.Set("authorization", "ddeeff")
+
+		opsCtx := metadata.NewIncomingContext(top, md3)
+
+		hubId := pb.NewULID()
+
+		var stream staticServerStream
+		stream.ctx = hubCtx
+		stream.SendC = make(chan *pb.CentralActivity, 10)
+		stream.RecvC = make(chan *pb.HubActivity, 1)
+
+		stream.RecvC <- &pb.HubActivity{
+			HubReg: &pb.HubActivity_HubRegistration{
+				Hub: hubId,
+			},
+		}
+
+		go s.StreamActivity(&stream)
+
+		require.Eventually(t, func() bool {
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+
+			return len(s.connectedHubs) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+
+		account := &pb.Account{
+			Namespace: "/",
+			AccountId: pb.NewULID(),
+		}
+
+		next := func() *pb.CentralActivity {
+			select {
+			case <-time.After(time.Second):
+				t.Fatal("activity was not delivered to the hub")
+				return nil
+			case ca := <-stream.SendC:
+				return ca
+			}
+		}
+
+		addService := func() *pb.ULID {
+			id := pb.NewULID()
+
+			_, err := s.AddService(hubCtx, &pb.ServiceRequest{
+				Account: account,
+				Hub:     hubId,
+				Id:      id,
+				Type:    "test",
+				Labels:  pb.ParseLabelSet("service=www,env=prod"),
+			})
+			require.NoError(t, err)
+
+			next()
+
+			return id
+		}
+
+		s3api := s3.New(sess)
+
+		published := func() []*pb.ULID {
+			resp, err := s3api.GetObject(&s3.GetObjectInput{
+				Bucket: aws.String(s.bucket),
+				Key:    aws.String("account_services/" + account.HashKey()),
+			})
+			require.NoError(t, err)
+
+			compressedData, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			data, err := zstdDecompress(compressedData)
+			require.NoError(t, err)
+
+			var accs pb.AccountServices
+
+			err = accs.Unmarshal(data)
+			require.NoError(t, err)
+
+			var ids []*pb.ULID
+
+			for _, rs := range accs.Services {
+				ids = append(ids, rs.Id)
+			}
+
+			return ids
+		}
+
+		kept := addService()
+		removed := addService()
+
+		assert.ElementsMatch(t, []*pb.ULID{kept, removed}, published())
+
+		// Removed by hand, so the routing in S3 and at the hubs is stale.
+		err = dbx.Check(db.Where("service_id = ?", removed.Bytes()).Delete(Service{}))
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []*pb.ULID{kept, removed}, published())
+
+		_, err = s.InvalidateAccountRouting(hubCtx, &pb.InvalidateRequest{
+			Account: account,
+		})
+		assert.Equal(t, ErrBadAuthentication, err)
+
+		_, err = s.InvalidateAccountRouting(opsCtx, &pb.InvalidateRequest{})
+		require.Error(t, err)
+
+		_, err = s.InvalidateAccountRouting(opsCtx, &pb.InvalidateRequest{
+			Account: account,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []*pb.ULID{kept}, published())
+
+		ca := next()
+		require.Equal(t, 1, len(ca.ResolvedRoutes))
+		assert.True(t, ca.ResolvedRoutes[0].Account.Equal(account))
+		require.Equal(t, 1, len(ca.ResolvedRoutes[0].Services))
+		assert.Equal(t, kept, ca.ResolvedRoutes[0].Services[0].Id)
+	})
+
+	t.Run("resolves a hostname the way the web frontend would", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
+
+		var s Server
+		s.L = L
+		s.db = db
+		s.vaultClient = vc
+		s.vaultPath = pb.NewULID().SpecString()
+		s.keyId = "k1"
+		s.registerToken = "aabbcc"
+		s.opsToken = "ddeeff"
+		s.awsSess = sess
+		s.bucket = bucket
+		s.lockTable = "hzntest"
+		s.connectedHubs = make(map[string]*connectedHub)
+
+		var err error
+		s.lockMgr, err = dynamolock.New(dynamodb.New(sess), s.lockTable)
+		require.NoError(t, err)
+
+		pub, err := token.SetupVault(vc, s.vaultPath)
+		require.NoError(t, err)
+
+		s.pubKey = pub
+
+		top := context.Background()
+
+		authCtx := func(auth string) context.Context {
+			md := make(metadata.MD)
+			md.Set("authorization", auth)
+			return metadata.NewIncomingContext(top, md)
+		}
+
+		ctr, err := s.IssueHubToken(authCtx("aabbcc"), &pb.Noop{})
+		require.NoError(t, err)
+
+		ct, err := s.Register(authCtx("aabbcc"), &pb.ControlRegister{
+			Namespace: "/",
+		})
+		require.NoError(t, err)
+
+		mgmtCtx := authCtx(ct.Token)
+		hubCtx := authCtx(ctr.Token)
+		opsCtx := authCtx("ddeeff")
+
+		account := &pb.Account{
+			Namespace: "/",
+			AccountId: pb.NewULID(),
+		}
+
+		_, err = s.AddAccount(mgmtCtx, &pb.AddAccountRequest{
+			Account: account,
+			Limits:  &pb.Account_Limits{},
+		})
+		require.NoError(t, err)
+
+		for _, ll := range []*pb.AddLabelLinkRequest{
+			{
+				Labels: pb.ParseLabelSet(":hostname=app.example.com"),
+				Target: pb.ParseLabelSet("service=www"),
+			},
+			{
+				Labels: pb.ParseLabelSet(":hostname=app.example.com,:path-prefix=/api"),
+				Target: pb.ParseLabelSet("service=api"),
+			},
+		} {
+			ll.Account = account
+
+			_, err = s.AddLabelLink(mgmtCtx, ll)
+			require.NoError(t, err)
+		}
+
+		hubId := pb.NewULID()
+
+		addService := func(labels string) *pb.ULID {
+			id := pb.NewULID()
+
+			_, err := s.AddService(hubCtx, &pb.ServiceRequest{
+				Account: account,
+				Hub:     hubId,
+				Id:      id,
+				Type:    "http",
+				Labels:  pb.ParseLabelSet(labels),
+			})
+			require.NoError(t, err)
+
+			return id
+		}
+
+		older := addService("service=www,:deployment=d1,:deployment-order=01")
+		newer := addService("service=www,:deployment=d2,:deployment-order=02")
+		api := addService("service=api")
+
+		ids := func(resp *pb.ResolveDebugResponse) []*pb.ULID {
+			var out []*pb.ULID
+
+			for _, rs := range resp.Services {
+				assert.Equal(t, hubId, rs.Hub)
+				out = append(out, rs.Id)
+			}
+
+			return out
+		}
+
+		_, err = s.ResolveDebug(hubCtx, &pb.ResolveDebugRequest{Hostname: "app.example.com"})
+		assert.Equal(t, ErrBadAuthentication, err)
+
+		resp, err := s.ResolveDebug(opsCtx, &pb.ResolveDebugRequest{Hostname: "app.example.com", Path: "/"})
 		require.NoError(t, err)
 
-		require.Equal(t, 1, len(list.Accounts))
+		assert.True(t, account.Equal(resp.Account))
+		assert.Equal(t, "service=www", resp.Target.SpecString())
+		assert.Equal(t, "", resp.PathPrefix)
+		assert.True(t, resp.NewestDeployment)
+		assert.Equal(t, []*pb.ULID{newer}, ids(resp))
 
-		assert.Equal(t, accountId, list.Accounts[0].AccountId)
+		resp, err = s.ResolveDebug(opsCtx, &pb.ResolveDebugRequest{
+			Hostname:     "app.example.com",
+			DeploymentId: "d1",
+		})
+		require.NoError(t, err)
 
-		list, err = s.ListAccounts(
-			mgmtCtx,
-			&pb.ListAccountsRequest{
-				Limit:  1,
-				Marker: list.NextMarker,
-			},
-		)
+		assert.Equal(t, ":deployment=d1,service=www", resp.Target.SpecString())
+		assert.Equal(t, []*pb.ULID{older}, ids(resp))
+
+		resp, err = s.ResolveDebug(opsCtx, &pb.ResolveDebugRequest{Hostname: "app.example.com", Path: "/api/users"})
 		require.NoError(t, err)
 
-		require.Equal(t, 1, len(list.Accounts))
+		assert.Equal(t, "/api", resp.PathPrefix)
+		assert.Equal(t, "service=api", resp.Target.SpecString())
+		assert.False(t, resp.NewestDeployment)
+		assert.Equal(t, []*pb.ULID{api}, ids(resp))
 
-		assert.Equal(t, accountId2, list.Accounts[0].AccountId)
+		_, err = s.ResolveDebug(opsCtx, &pb.ResolveDebugRequest{Hostname: "other.example.com"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
 
-		list, err = s.ListAccounts(
-			mgmtCtx,
-			&pb.ListAccountsRequest{
-				Limit:  1,
-				Marker: list.NextMarker,
-			},
-		)
+		_, err = s.SetAccountSuspended(opsCtx, &pb.SetSuspendedRequest{
+			Account:   account,
+			Suspended: true,
+		})
 		require.NoError(t, err)
 
-		require.Equal(t, 0, len(list.Accounts))
+		resp, err = s.ResolveDebug(opsCtx, &pb.ResolveDebugRequest{Hostname: "app.example.com"})
+		require.NoError(t, err)
+
+		assert.True(t, resp.Suspended)
+		assert.Equal(t, 0, len(resp.Services))
 	})
 
-	t.Run("can create and remove a labellink for an account", func(t *testing.T) {
+	t.Run("updates the splits of a label link", func(t *testing.T) {
 		db := testsql.TestPostgresDB(t, "hzn")
 		defer db.Close()
 
@@ -516,6 +6056,12 @@ func TestServer(t *testing.T) {
 		s.registerToken = "aabbcc"
 		s.awsSess = sess
 		s.bucket = bucket
+		s.lockTable = "hzntest"
+		s.connectedHubs = make(map[string]*connectedHub)
+
+		var err error
+		s.lockMgr, err = dynamolock.New(dynamodb.New(sess), s.lockTable)
+		require.NoError(t, err)
 
 		pub, err := token.SetupVault(vc, s.vaultPath)
 		require.NoError(t, err)
@@ -524,130 +6070,108 @@ func TestServer(t *testing.T) {
 
 		top := context.Background()
 
-		md := make(metadata.MD)
-		md.Set("authorization", "aabbcc")
-
-		ctx := metadata.NewIncomingContext(top, md)
+		authCtx := func(auth string) context.Context {
+			md := make(metadata.MD)
+			md.Set("authorization", auth)
+			return metadata.NewIncomingContext(top, md)
+		}
 
-		ct, err := s.Register(ctx, &pb.ControlRegister{
+		ct, err := s.Register(authCtx("aabbcc"), &pb.ControlRegister{
 			Namespace: "/",
 		})
-
 		require.NoError(t, err)
 
-		md2 := make(metadata.MD)
-		md2.Set("authorization", ct.Token)
-
-		accountId := pb.NewULID()
-
-		label := pb.ParseLabelSet(":hostname=foo.com")
-		target := pb.ParseLabelSet("service=emp,env=test")
-
-		_, err = s.AddAccount(
-			metadata.NewIncomingContext(top, md2),
-			&pb.AddAccountRequest{
-				Account: &pb.Account{
-					AccountId: accountId,
-					Namespace: "/",
-				},
+		mgmtCtx := authCtx(ct.Token)
 
-				Limits: &pb.Account_Limits{
-					HttpRequests: 1000,
-					Bandwidth:    872,
-				},
-			},
-		)
+		account := &pb.Account{
+			Namespace: "/",
+			AccountId: pb.NewULID(),
+		}
 
+		_, err = s.AddAccount(mgmtCtx, &pb.AddAccountRequest{
+			Account: account,
+			Limits:  &pb.Account_Limits{},
+		})
 		require.NoError(t, err)
 
-		_, err = s.AddLabelLink(
-			metadata.NewIncomingContext(top, md2),
-			&pb.AddLabelLinkRequest{
-				Labels: label,
-				Account: &pb.Account{
-					AccountId: accountId,
-					Namespace: "/",
-				},
-				Target: target,
-			},
-		)
+		label := pb.ParseLabelSet(":hostname=app.example.com")
 
+		_, err = s.AddLabelLink(mgmtCtx, &pb.AddLabelLinkRequest{
+			Account: account,
+			Labels:  label,
+			Target:  pb.ParseLabelSet("env=stable"),
+		})
 		require.NoError(t, err)
 
-		// Check the labe link payload written to s3
+		splits := []*pb.TargetSplit{
+			{Target: pb.ParseLabelSet("env=stable"), Weight: 95},
+			{Target: pb.ParseLabelSet("env=canary"), Weight: 5},
+		}
 
-		s3api := s3.New(sess)
+		listener := &connectedHub{
+			xmit: make(chan *pb.CentralActivity, 10),
+		}
 
-		resp, err := s3api.GetObject(&s3.GetObjectInput{
-			Bucket: aws.String(s.bucket),
-			Key:    aws.String("label_links"),
-		})
+		s.connectedHubs["listener"] = listener
 
+		_, err = s.SetLabelLinkSplits(mgmtCtx, &pb.SetLabelLinkSplitsRequest{
+			Account: account,
+			Labels:  label,
+			Splits:  splits,
+		})
 		require.NoError(t, err)
 
-		compressedData, err := ioutil.ReadAll(resp.Body)
-		require.NoError(t, err)
+		select {
+		case ca := <-listener.xmit:
+			require.NotNil(t, ca.NewLabelLinks)
+			require.Equal(t, 1, len(ca.NewLabelLinks.LabelLinks))
+			assert.Equal(t, splits, ca.NewLabelLinks.LabelLinks[0].Splits)
+			assert.Equal(t, "env=stable", ca.NewLabelLinks.LabelLinks[0].Target.SpecString())
+		case <-time.After(time.Second):
+			t.Fatal("new splits were not sent to the hub")
+		}
 
-		data, err := zstdDecompress(compressedData)
+		links, err := s.allLabelLinks(top)
 		require.NoError(t, err)
 
-		var lls pb.LabelLinks
+		require.Equal(t, 1, len(links.LabelLinks))
+		assert.Equal(t, splits, links.LabelLinks[0].Splits)
 
-		err = lls.Unmarshal(data)
+		cfg, err := s.ExportAccountConfig(mgmtCtx, &pb.ExportRequest{Account: account})
 		require.NoError(t, err)
 
-		require.Equal(t, 1, len(lls.LabelLinks))
-
-		ll := lls.LabelLinks[0]
-
-		assert.Equal(t, accountId, ll.Account.AccountId)
-		assert.Equal(t, label, ll.Labels)
-		assert.Equal(t, target, ll.Target)
-
-		require.NotNil(t, ll.Limits)
-
-		assert.Equal(t, float64(1000), ll.Limits.HttpRequests)
-		assert.Equal(t, float64(872), ll.Limits.Bandwidth)
+		require.Equal(t, 1, len(cfg.LabelLinks))
+		assert.Equal(t, splits, cfg.LabelLinks[0].Splits)
 
-		_, err = s.RemoveLabelLink(
-			metadata.NewIncomingContext(top, md2),
-			&pb.RemoveLabelLinkRequest{
-				Labels: label,
-				Account: &pb.Account{
-					AccountId: accountId,
-					Namespace: "/",
-				},
+		_, err = s.SetLabelLinkSplits(mgmtCtx, &pb.SetLabelLinkSplitsRequest{
+			Account: account,
+			Labels:  label,
+			Splits: []*pb.TargetSplit{
+				{Target: pb.ParseLabelSet("env=stable")},
 			},
-		)
-		require.NoError(t, err)
-
-		var llr LabelLink
-		err = dbx.Check(db.First(&llr))
-
-		assert.Error(t, err)
-
-		resp, err = s3api.GetObject(&s3.GetObjectInput{
-			Bucket: aws.String(s.bucket),
-			Key:    aws.String("label_links"),
 		})
+		assert.True(t, errors.Is(err, ErrInvalidRequest))
 
-		require.NoError(t, err)
-
-		compressedData, err = ioutil.ReadAll(resp.Body)
-		require.NoError(t, err)
+		_, err = s.SetLabelLinkSplits(mgmtCtx, &pb.SetLabelLinkSplitsRequest{
+			Account: account,
+			Labels:  pb.ParseLabelSet(":hostname=other.example.com"),
+			Splits:  splits,
+		})
+		assert.Equal(t, codes.NotFound, status.Code(err))
 
-		data, err = zstdDecompress(compressedData)
+		_, err = s.SetLabelLinkSplits(mgmtCtx, &pb.SetLabelLinkSplitsRequest{
+			Account: account,
+			Labels:  label,
+		})
 		require.NoError(t, err)
 
-		var lls2 pb.LabelLinks
-
-		err = lls2.Unmarshal(data)
+		links, err = s.allLabelLinks(top)
 		require.NoError(t, err)
 
-		require.Equal(t, 0, len(lls2.LabelLinks))
+		assert.Equal(t, 0, len(links.LabelLinks[0].Splits))
 	})
 
-	t.Run("can create and remove a service for an account", func(t *testing.T) {
+	t.Run("serves a snapshot of its live state for debugging", func(t *testing.T) {
 		db := testsql.TestPostgresDB(t, "hzn")
 		defer db.Close()
 
@@ -658,14 +6182,22 @@ func TestServer(t *testing.T) {
 		s.vaultPath = pb.NewULID().SpecString()
 		s.keyId = "k1"
 		s.registerToken = "aabbcc"
+		s.opsToken = "ddeeff"
 		s.awsSess = sess
 		s.bucket = bucket
 		s.lockTable = "hzntest"
+		s.connectedHubs = make(map[string]*connectedHub)
+		s.cfg.SelectiveBroadcast = true
+		s.mux = http.NewServeMux()
+		s.setupRoutes()
 
 		var err error
 		s.lockMgr, err = dynamolock.New(dynamodb.New(sess), s.lockTable)
 		require.NoError(t, err)
 
+		s.flowTop, err = NewFlowTop(DefaultFlowTopSize)
+		require.NoError(t, err)
+
 		pub, err := token.SetupVault(vc, s.vaultPath)
 		require.NoError(t, err)
 
@@ -676,131 +6208,145 @@ func TestServer(t *testing.T) {
 		md := make(metadata.MD)
 		md.Set("authorization", "aabbcc")
 
-		ctx := metadata.NewIncomingContext(top, md)
+		ctr, err := s.IssueHubToken(metadata.NewIncomingContext(top, md), &pb.Noop{})
+		require.NoError(t, err)
 
-		ct, err := s.Register(ctx, &pb.ControlRegister{
-			Namespace: "/",
-		})
+		md3 := make(metadata.MD)
+		md3.Set("authorization", ctr.Token)
 
-		require.NoError(t, err)
+		hubCtx := metadata.NewIncomingContext(top, md3)
 
-		md2 := make(metadata.MD)
-		md2.Set("authorization", ct.Token)
+		hubId := pb.NewULID()
+
+		var stream staticServerStream
+		stream.ctx = hubCtx
+		stream.SendC = make(chan *pb.CentralActivity, 10)
+		stream.RecvC = make(chan *pb.HubActivity, 1)
+
+		stream.RecvC <- &pb.HubActivity{
+			HubReg: &pb.HubActivity_HubRegistration{
+				Hub: hubId,
+			},
+		}
+
+		go s.StreamActivity(&stream)
+
+		require.Eventually(t, func() bool {
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+
+			return len(s.connectedHubs) == 1
+		}, 5*time.Second, 10*time.Millisecond)
 
-		accountId := pb.NewULID()
 		account := &pb.Account{
 			Namespace: "/",
-			AccountId: accountId,
+			AccountId: pb.NewULID(),
 		}
 
-		ctr, err := s.IssueHubToken(ctx, &pb.Noop{})
+		_, err = s.AddService(hubCtx, &pb.ServiceRequest{
+			Account: account,
+			Hub:     hubId,
+			Id:      pb.NewULID(),
+			Type:    "test",
+			Labels:  pb.ParseLabelSet("service=www,env=prod"),
+		})
 		require.NoError(t, err)
 
-		md3 := make(metadata.MD)
-		md3.Set("authorization", ctr.Token)
+		flowId := pb.NewULID()
 
-		labels := pb.ParseLabelSet("service=www,env=prod")
+		s.flowTop.Add(&pb.FlowStream{
+			FlowId:      flowId,
+			HubId:       hubId,
+			Account:     account,
+			NumMessages: 3,
+			NumBytes:    300,
+		})
 
-		hubId := pb.NewULID()
-		serviceId := pb.NewULID()
+		get := func(auth string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest("GET", DebugStatePath, nil)
 
-		_, err = s.AddService(
-			metadata.NewIncomingContext(top, md3),
-			&pb.ServiceRequest{
-				Account: account,
-				Hub:     hubId,
-				Id:      serviceId,
-				Type:    "test",
-				Labels:  labels,
-				Metadata: []*pb.KVPair{
-					{
-						Key:   "version",
-						Value: "0.1x",
-					},
-				},
-			},
-		)
-		require.NoError(t, err)
+			if auth != "" {
+				req.Header.Set("Authorization", auth)
+			}
 
-		// Check the account payload written to s3
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, req)
 
-		s3api := s3.New(sess)
+			return w
+		}
 
-		resp, err := s3api.GetObject(&s3.GetObjectInput{
-			Bucket: aws.String(s.bucket),
-			Key:    aws.String("account_services/" + account.HashKey()),
-		})
+		assert.Equal(t, http.StatusUnauthorized, get("").Code)
+		assert.Equal(t, http.StatusUnauthorized, get("aabbcc").Code)
 
-		require.NoError(t, err)
+		var state debugState
 
-		compressedData, err := ioutil.ReadAll(resp.Body)
-		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			w := get("Bearer ddeeff")
+			if w.Code != http.StatusOK {
+				return false
+			}
 
-		data, err := zstdDecompress(compressedData)
-		require.NoError(t, err)
+			state = debugState{}
 
-		var accs pb.AccountServices
+			if json.Unmarshal(w.Body.Bytes(), &state) != nil {
+				return false
+			}
 
-		err = accs.Unmarshal(data)
-		require.NoError(t, err)
+			return len(state.Hubs) == 1 && len(state.Hubs[0].Accounts) == 1
+		}, 5*time.Second, 10*time.Millisecond)
 
-		require.Equal(t, 1, len(accs.Services))
+		assert.Equal(t, "application/json", get("ddeeff").Header().Get("Content-Type"))
 
-		sr := accs.Services[0]
+		hub := state.Hubs[0]
 
-		assert.Equal(t, hubId, sr.Hub)
-		assert.Equal(t, serviceId, sr.Id)
-		assert.Equal(t, labels, sr.Labels)
-		assert.Equal(t, "test", sr.Type)
+		assert.Equal(t, hubId.SpecString(), hub.Hub)
+		assert.Equal(t, account.StringKey(), hub.Accounts[0])
+		assert.True(t, hub.QueueDepth >= 0)
 
-		{
-			// Verify we have the service
-			resp, err := s.ListServices(
-				metadata.NewIncomingContext(top, md3),
-				&pb.ListServicesRequest{
-					Account: account,
-				},
-			)
-			require.NoError(t, err)
-			require.NotNil(t, resp)
-			require.Len(t, resp.Services, 1)
-			require.Equal(t, resp.Services[0].Id, serviceId)
-		}
+		require.Equal(t, 1, len(state.Flows))
+		assert.Equal(t, flowId, state.Flows[0].FlowId)
+		assert.Equal(t, int64(300), state.Flows[0].NumBytes)
 
-		_, err = s.RemoveService(
-			metadata.NewIncomingContext(top, md3),
-			&pb.ServiceRequest{
-				Account: account,
-				Hub:     hubId,
-				Id:      serviceId,
-			},
-		)
-		require.NoError(t, err)
+		// The raw JSON has the documented keys.
+		var raw map[string]json.RawMessage
 
-		var so Service
-		err = dbx.Check(db.First(&so))
+		require.NoError(t, json.Unmarshal(get("ddeeff").Body.Bytes(), &raw))
+		assert.Contains(t, raw, "hubs")
+		assert.Contains(t, raw, "flows")
+	})
 
-		assert.Error(t, err)
+	t.Run("fails or carries on without metrics when they can't be set up", func(t *testing.T) {
+		db := testsql.TestPostgresDB(t, "hzn")
+		defer db.Close()
 
-		resp, err = s3api.GetObject(&s3.GetObjectInput{
-			Bucket: aws.String(s.bucket),
-			Key:    aws.String("account_services/" + account.HashKey()),
-		})
+		errSink := errors.New("no sink for you")
 
-		require.NoError(t, err)
+		orig := newPrometheusSink
+		defer func() { newPrometheusSink = orig }()
 
-		compressedData, err = ioutil.ReadAll(resp.Body)
-		require.NoError(t, err)
+		newPrometheusSink = func() (metrics.MetricSink, error) {
+			return nil, errSink
+		}
 
-		data, err = zstdDecompress(compressedData)
-		require.NoError(t, err)
+		cfg := scfg
+		cfg.DB = db
 
-		var accs2 pb.AccountServices
+		_, err := NewServer(cfg)
+		assert.True(t, errors.Is(err, errSink))
 
-		err = accs2.Unmarshal(data)
+		cfg.MetricsBestEffort = true
+
+		s, err := NewServer(cfg)
 		require.NoError(t, err)
 
-		require.Equal(t, 0, len(accs2.Services))
+		defer s.Close()
+
+		require.NotNil(t, s.m)
+		assert.Nil(t, s.msink)
+		assert.Empty(t, s.sinks)
+
+		// Metrics are still safe to emit, they just go nowhere.
+		s.m.IncrCounter([]string{"hubs", "connected"}, 1)
 	})
 
 	t.Run("picks up activity from postgresql", func(t *testing.T) {
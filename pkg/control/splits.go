@@ -0,0 +1,155 @@
+package control
+
+import (
+	context "context"
+
+	"github.com/hashicorp/horizon/pkg/dbx"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// encodeSplits returns splits the way LabelLink.Splits stores them.
+func encodeSplits(splits []*pb.TargetSplit) ([]byte, error) {
+	if len(splits) == 0 {
+		return nil, nil
+	}
+
+	return (&pb.TargetSplits{Splits: splits}).Marshal()
+}
+
+// decodeSplits reads the splits stored in LabelLink.Splits.
+func decodeSplits(data []byte) ([]*pb.TargetSplit, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var ts pb.TargetSplits
+
+	err := ts.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return ts.Splits, nil
+}
+
+// checkSplits verifies the splits of a label link: each needs a target that
+// would be accepted as the target of the label link itself, and at least one
+// has to have a weight, or no request could go anywhere.
+func (s *Server) checkSplits(labels *pb.LabelSet, splits []*pb.TargetSplit) error {
+	var total uint64
+
+	for _, split := range splits {
+		if split.Target == nil || len(split.Target.Labels) == 0 {
+			return errors.Wrapf(ErrInvalidRequest, "split has no target")
+		}
+
+		err := s.checkLabelLimits("label-link split target", split.Target)
+		if err != nil {
+			return err
+		}
+
+		err = checkLabelLink(labels, split.Target)
+		if err != nil {
+			return err
+		}
+
+		total += uint64(split.Weight)
+	}
+
+	if len(splits) > 0 && total == 0 {
+		return errors.Wrapf(ErrInvalidRequest, "splits have no weight")
+	}
+
+	return nil
+}
+
+// SetLabelLinkSplits replaces the targets a label link splits requests
+// between, so the weights of a canary can be changed as it rolls out. The new
+// splits are sent to the hubs right away.
+func (s *Server) SetLabelLinkSplits(ctx context.Context, req *pb.SetLabelLinkSplitsRequest) (*pb.Noop, error) {
+	L := s.requestLogger(ctx).Named("set-label-link-splits")
+
+	caller, err := s.checkMgmtAllowed(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkAccountAllowed(L, caller, req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkSplits(req.Labels, req.Splits)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := accountKey(req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := encodeSplits(req.Splits)
+	if err != nil {
+		return nil, err
+	}
+
+	var llr LabelLink
+
+	err = dbx.Check(s.db.
+		Where("account_id = ?", key).
+		Where("labels = ?", FlattenLabels(req.Labels)).
+		First(&llr),
+	)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, status.Errorf(codes.NotFound, "no label link for %s", req.Labels.SpecString())
+		}
+
+		return nil, err
+	}
+
+	err = dbx.Check(s.db.Model(&llr).Update("splits", data))
+	if err != nil {
+		return nil, err
+	}
+
+	L.Info("updated label-link splits",
+		"account", req.Account.SpecString(),
+		"labels", req.Labels.SpecString(),
+		"splits", len(req.Splits),
+	)
+
+	var ao Account
+
+	err = dbx.Check(s.db.First(&ao, key))
+	if err != nil {
+		return nil, err
+	}
+
+	var pblimit pb.Account_Limits
+	ao.Data.Get("limits", &pblimit)
+
+	s.broadcastActivity(&pb.CentralActivity{
+		NewLabelLinks: &pb.LabelLinks{
+			LabelLinks: []*pb.LabelLink{{
+				Account: req.Account,
+				Labels:  req.Labels,
+				Target:  ExplodeLabels(llr.Target),
+				Limits:  &pblimit,
+				Splits:  req.Splits,
+			}},
+		},
+	})
+
+	err = s.updateLabelLinks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Noop{}, nil
+}
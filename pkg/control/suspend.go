@@ -0,0 +1,81 @@
+package control
+
+import (
+	context "context"
+
+	"github.com/hashicorp/horizon/pkg/dbx"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/jinzhu/gorm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrAccountSuspended is returned when creating a token for a suspended
+// account.
+var ErrAccountSuspended = status.Error(codes.FailedPrecondition, "account is suspended")
+
+// accountSuspended reports if the account with the given key is suspended.
+// Accounts without a record aren't.
+func accountSuspended(db *gorm.DB, key []byte) (bool, error) {
+	var ao Account
+
+	err := dbx.Check(db.Select("suspended").Where("id = ?", key).First(&ao))
+	switch err {
+	case nil:
+		return ao.Suspended, nil
+	case gorm.ErrRecordNotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// SetAccountSuspended suspends or unsuspends an account. While it's
+// suspended, its configuration is kept but hubs route nothing to its services
+// and no tokens can be created for it. The change is published to the hubs
+// right away, as well as to the account's routing in S3.
+func (s *Server) SetAccountSuspended(ctx context.Context, req *pb.SetSuspendedRequest) (*pb.Noop, error) {
+	if !s.checkOpsAllowed(ctx) {
+		return nil, ErrBadAuthentication
+	}
+
+	key, err := accountKey(req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	err = upsertAccount(s.db, key, req.Account.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	err = dbx.Check(s.db.Model(&Account{}).Where("id = ?", key).Update("suspended", req.Suspended))
+	if err != nil {
+		return nil, err
+	}
+
+	s.L.Warn("account suspension changed",
+		"account", req.Account.SpecString(),
+		"suspended", req.Suspended,
+	)
+
+	routes, err := s.accountServices(ctx, s.db, req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	routes.Account = req.Account
+
+	// Resolved routes replace the hubs' view of the account, so suspending
+	// clears it and unsuspending restores every service.
+	s.broadcastActivity(&pb.CentralActivity{
+		ResolvedRoutes: []*pb.AccountServices{routes},
+	})
+
+	err = s.updateAccountRouting(ctx, s.db, req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Noop{}, nil
+}
@@ -0,0 +1,103 @@
+package control
+
+import (
+	context "context"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/hashicorp/horizon/pkg/token"
+	"github.com/pkg/errors"
+)
+
+var ErrTooEarlyToRenew = errors.New("token not yet within its renewal window")
+
+// renewalWindow returns when vt enters its renewal window, and whether it's
+// renewable at all. Only tokens that expire are renewable. The window is the
+// configured TokenRenewalWindow, or the last third of the token's lifetime if
+// that isn't set.
+func (s *Server) renewalWindow(vt *token.ValidToken) (time.Time, bool) {
+	if vt.Body.ValidUntil == nil {
+		return time.Time{}, false
+	}
+
+	validUntil := vt.Body.ValidUntil.Time()
+
+	window := s.cfg.TokenRenewalWindow
+	if window == 0 {
+		window = validUntil.Sub(vt.Body.Id.Time()) / 3
+	}
+
+	return validUntil.Add(-window), true
+}
+
+// TokenStatus describes how long the token the request was made with remains
+// valid, so clients can renew it before it expires. Any valid token can call
+// it, regardless of its role.
+func (s *Server) TokenStatus(ctx context.Context, _ *pb.Noop) (*pb.TokenStatusResponse, error) {
+	caller, err := s.checkToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.TokenStatusResponse{
+		TokenId:    caller.Body.Id,
+		NotBefore:  pb.NewTimestamp(caller.Body.Id.Time()),
+		ValidUntil: caller.Body.ValidUntil,
+	}
+
+	renewAfter, ok := s.renewalWindow(caller)
+	if ok {
+		resp.Renewable = true
+		resp.RenewAfter = pb.NewTimestamp(renewAfter)
+		resp.WithinRenewalWindow = !s.now().Before(renewAfter)
+	}
+
+	return resp, nil
+}
+
+// RenewToken returns a new token with the same role, account, and
+// capabilities as the one the request was made with, valid for as long as the
+// original was (capped at TokenMaxTTL). The token has to be renewable and
+// within its renewal window, see TokenStatus.
+func (s *Server) RenewToken(ctx context.Context, _ *pb.Noop) (*pb.CreateTokenResponse, error) {
+	L := s.requestLogger(ctx).Named("renew-token")
+
+	caller, err := s.checkToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	renewAfter, ok := s.renewalWindow(caller)
+	if !ok {
+		return nil, errors.Wrapf(ErrInvalidRequest, "token doesn't expire")
+	}
+
+	if s.now().Before(renewAfter) {
+		return nil, errors.Wrapf(ErrTooEarlyToRenew, "renewable after %s", renewAfter)
+	}
+
+	dur := caller.Body.ValidUntil.Time().Sub(caller.Body.Id.Time())
+
+	if s.cfg.TokenMaxTTL > 0 && dur > s.cfg.TokenMaxTTL {
+		dur = s.cfg.TokenMaxTTL
+	}
+
+	var tc token.TokenCreator
+	tc.Role = caller.Body.Role
+	tc.RawCapabilities = caller.Body.Capabilities
+	tc.ValidDuration = dur
+
+	if account := caller.Body.Account; account != nil {
+		tc.AccountId = account.AccountId
+		tc.AccuntNamespace = account.Namespace
+	}
+
+	token, err := tc.EncodeED25519WithVault(s.vaultClient, s.vaultPath, s.keyId)
+	if err != nil {
+		return nil, err
+	}
+
+	L.Info("renewed token", "token-id", caller.Body.Id, "role", caller.Body.Role, "valid-for", dur)
+
+	return s.tokenResponse(token)
+}
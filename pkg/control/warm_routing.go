@@ -0,0 +1,74 @@
+package control
+
+import (
+	context "context"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/dbx"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+// How many accounts WarmRouting loads from the database at once.
+const warmRoutingBatch = 100
+
+// WarmRouting computes the routing of every account and uploads it for the
+// hubs, so the first requests after a restart don't wait on it. It can be run
+// in the background after NewServer, and stops early if ctx is canceled.
+func (s *Server) WarmRouting(ctx context.Context) error {
+	L := s.L.Named("warm-routing")
+
+	start := time.Now()
+
+	var (
+		lastId   []byte
+		warmed   int
+		accounts []*Account
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		q := s.db
+		if lastId != nil {
+			q = q.Where("id > ?", lastId)
+		}
+
+		err := dbx.Check(q.Order("id ASC").Limit(warmRoutingBatch).Find(&accounts))
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return err
+		}
+
+		if len(accounts) == 0 {
+			break
+		}
+
+		for _, rec := range accounts {
+			account, err := pb.AccountFromKey(rec.ID)
+			if err != nil {
+				return err
+			}
+
+			err = s.updateAccountRouting(ctx, s.db, account)
+			if err != nil {
+				return errors.Wrapf(err, "warming routing for account %s", account)
+			}
+
+			warmed++
+		}
+
+		lastId = accounts[len(accounts)-1].ID
+		accounts = accounts[:0]
+
+		L.Info("warmed account routing", "accounts", warmed)
+	}
+
+	L.Info("finished warming account routing", "accounts", warmed, "elapsed", time.Since(start))
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package control
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// ServiceWeight gives the routes of services matching Labels a weight.
+type ServiceWeight struct {
+	Labels *pb.LabelSet
+	Weight uint32
+}
+
+// weighRoutes sets the weight of each route from ServerConfig.ServiceWeights.
+func (s *Server) weighRoutes(routes []*pb.ServiceRoute) {
+	if len(s.cfg.ServiceWeights) == 0 {
+		return
+	}
+
+	for _, route := range routes {
+		route.Weight = 0
+
+		for _, sw := range s.cfg.ServiceWeights {
+			if sw.Labels.Matches(route.Labels) {
+				route.Weight = sw.Weight
+				break
+			}
+		}
+	}
+}
+
+// routeWeight returns the weight of route, treating zero as 1.
+func routeWeight(route *pb.ServiceRoute) float64 {
+	if route.Weight == 0 {
+		return 1
+	}
+
+	return float64(route.Weight)
+}
+
+// weightedShuffle orders in randomly such that each route is first with a
+// chance in proportion to its weight, and likewise for each place after it
+// among the routes that remain. When all the weights are the same, it's a
+// plain shuffle.
+func weightedShuffle(in []*pb.ServiceRoute) []*pb.ServiceRoute {
+	if len(in) < 2 {
+		return in
+	}
+
+	// Each route gets the key u^(1/w) for a uniform random u, and sorting by
+	// the keys, highest first, is a weighted sample of all of them
+	// (Efraimidis & Spirakis).
+	keys := make(map[*pb.ServiceRoute]float64, len(in))
+
+	for _, route := range in {
+		keys[route] = math.Pow(rand.Float64(), 1/routeWeight(route))
+	}
+
+	sort.SliceStable(in, func(i, j int) bool {
+		return keys[in[i]] > keys[in[j]]
+	})
+
+	return in
+}
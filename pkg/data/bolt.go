@@ -9,6 +9,8 @@ import (
 
 	"github.com/caddyserver/certmagic"
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/horizon/pkg/clock"
+	"github.com/pkg/errors"
 	"go.etcd.io/bbolt"
 	"golang.org/x/crypto/blake2b"
 )
@@ -16,11 +18,47 @@ import (
 type Bolt struct {
 	L  hclog.Logger
 	db *bbolt.DB
+
+	// When set, writes made via CertStorage are performed with bbolt's
+	// Batch rather than Update. Batch coalesces concurrent writers into
+	// a single transaction, which greatly reduces the number of fsyncs
+	// performed under a burst of cert operations. Each write still
+	// only returns once its transaction has been committed.
+	Batched bool
+}
+
+// BoltOptions tunes how a Bolt trades durability for speed. The zero value
+// syncs every commit to disk, which is what the cert store should use. The
+// other settings are meant for data that can be rebuilt, such as caches.
+type BoltOptions struct {
+	// Skip the fsync after each commit. A crash can lose recent writes or
+	// leave the database needing repair, so call Sync to flush at points
+	// that matter.
+	NoSync bool
+
+	// Don't write the freelist to disk on commit. Commits are faster, but
+	// opening the database has to scan it to rebuild the freelist.
+	NoFreelistSync bool
+
+	// The initial size of the memory map, in bytes. Setting it to the
+	// expected size of the database avoids remapping as it grows, which
+	// blocks writers.
+	InitialMmapSize int
 }
 
+// NewBolt opens the database at path, syncing every commit to disk.
 func NewBolt(path string) (*Bolt, error) {
-	opts := bbolt.DefaultOptions
-	db, err := bbolt.Open(path, 0755, opts)
+	return NewBoltWithOptions(path, BoltOptions{})
+}
+
+// NewBoltWithOptions opens the database at path, tuned by bopts.
+func NewBoltWithOptions(path string, bopts BoltOptions) (*Bolt, error) {
+	opts := *bbolt.DefaultOptions
+	opts.NoSync = bopts.NoSync
+	opts.NoFreelistSync = bopts.NoFreelistSync
+	opts.InitialMmapSize = bopts.InitialMmapSize
+
+	db, err := bbolt.Open(path, 0755, &opts)
 	if err != nil {
 		return nil, err
 	}
@@ -33,6 +71,28 @@ func NewBolt(path string) (*Bolt, error) {
 	return b, nil
 }
 
+// update runs fn in a write transaction, using a batched transaction if
+// the Bolt is configured for it. Because bbolt may retry a batched function,
+// fn must be idempotent.
+func (b *Bolt) update(fn func(tx *bbolt.Tx) error) error {
+	if b.Batched {
+		return b.db.Batch(fn)
+	}
+
+	return b.db.Update(fn)
+}
+
+// Sync flushes the database to disk. It's only needed when NoSync is set,
+// since otherwise every commit is synced.
+func (b *Bolt) Sync() error {
+	return b.db.Sync()
+}
+
+// Close closes the database.
+func (b *Bolt) Close() error {
+	return b.db.Close()
+}
+
 func (b *Bolt) CertStorage() *CertStorage {
 	return &CertStorage{b: b}
 }
@@ -40,6 +100,23 @@ func (b *Bolt) CertStorage() *CertStorage {
 type CertStorage struct {
 	b  *Bolt
 	mu sync.Mutex
+
+	// Tells the time certs are stored and modified at. Defaults to
+	// clock.Real.
+	Clock clock.Clock
+}
+
+func (c *CertStorage) now() time.Time {
+	if c.Clock != nil {
+		return c.Clock.Now()
+	}
+
+	return clock.Real.Now()
+}
+
+// Close closes the Bolt the certs are stored in.
+func (c *CertStorage) Close() error {
+	return c.b.Close()
 }
 
 // Lock acquires the lock for key, blocking until the lock
@@ -75,23 +152,37 @@ func (c *CertStorage) Unlock(key string) error {
 	return nil
 }
 
-// Store puts value at key.
+// Store puts value at key. If key already exists, the time it was first
+// stored is kept and only the modified time is updated.
 func (c *CertStorage) Store(key string, value []byte) error {
-	return c.b.db.Update(func(tx *bbolt.Tx) error {
+	return c.b.update(func(tx *bbolt.Tx) error {
 		buk, err := tx.CreateBucketIfNotExists([]byte("certs"))
 		if err != nil {
 			return err
 		}
 
-		t := time.Now()
+		now := c.now()
 
-		data, err := t.MarshalBinary()
+		ce := certEntry{
+			Created:  now,
+			Modified: now,
+			Value:    value,
+		}
+
+		if cur := buk.Get([]byte(key)); cur != nil {
+			old, err := decodeCertEntry(cur)
+			if err != nil {
+				c.b.L.Warn("unable to decode existing cert data, replacing it", "key", key, "error", err)
+			} else {
+				ce.Created = old.Created
+			}
+		}
+
+		data, err := ce.encode()
 		if err != nil {
 			return err
 		}
 
-		data = append(data, value...)
-
 		c.b.L.Debug("cert-storage store", "key", key, "value-size", len(value), "value", hash(value))
 
 		return buk.Put([]byte(key), data)
@@ -107,24 +198,40 @@ func hash(value []byte) string {
 
 // Load retrieves the value at key.
 func (c *CertStorage) Load(key string) ([]byte, error) {
-	var data []byte
+	ce, err := c.load(key)
+	if err != nil {
+		return nil, err
+	}
+
+	c.b.L.Debug("cert-storage load", "key", key, "value-size", len(ce.Value), "value", hash(ce.Value))
+
+	return ce.Value, nil
+}
+
+// load retrieves and decodes the data stored at key.
+func (c *CertStorage) load(key string) (*certEntry, error) {
+	var ce *certEntry
+
 	err := c.b.db.View(func(tx *bbolt.Tx) error {
 		buk := tx.Bucket([]byte("certs"))
 		if buk == nil {
 			return certmagic.ErrNotExist(io.EOF)
 		}
 
-		data = buk.Get([]byte(key))
-
+		data := buk.Get([]byte(key))
 		if data == nil {
 			return certmagic.ErrNotExist(io.EOF)
 		}
 
-		if data != nil {
-			data = data[15:]
+		var err error
+		ce, err = decodeCertEntry(data)
+		if err != nil {
+			return err
 		}
 
-		c.b.L.Debug("cert-storage load", "key", key, "value-size", len(data), "value", hash(data))
+		// data is only valid during the transaction.
+		ce.Value = append([]byte(nil), ce.Value...)
+
 		return nil
 	})
 
@@ -132,12 +239,12 @@ func (c *CertStorage) Load(key string) ([]byte, error) {
 		return nil, err
 	}
 
-	return data, nil
+	return ce, nil
 }
 
 // Delete deletes key.
 func (c *CertStorage) Delete(key string) error {
-	return c.b.db.Update(func(tx *bbolt.Tx) error {
+	return c.b.update(func(tx *bbolt.Tx) error {
 		buk := tx.Bucket([]byte("certs"))
 		if buk == nil {
 			return certmagic.ErrNotExist(io.EOF)
@@ -200,32 +307,103 @@ func (c *CertStorage) List(prefix string, recursive bool) ([]string, error) {
 	return matches, err
 }
 
-// Stat returns information about key.
+// Stat returns information about key. The modified time is when key was last
+// stored.
 func (c *CertStorage) Stat(key string) (certmagic.KeyInfo, error) {
 	var ki certmagic.KeyInfo
 
-	err := c.b.db.View(func(tx *bbolt.Tx) error {
-		buk := tx.Bucket([]byte("certs"))
-		if buk == nil {
-			return nil
+	ce, err := c.load(key)
+	if err != nil {
+		return ki, err
+	}
+
+	ki.Key = key
+	ki.Modified = ce.Modified
+	ki.Size = int64(len(ce.Value))
+	ki.IsTerminal = false
+
+	return ki, nil
+}
+
+var ErrBadCertData = errors.New("bad cert data")
+
+const (
+	// The length of a time encoded with MarshalBinary, as long as it has no
+	// sub-minute zone offset, which neither local time nor UTC do.
+	encodedTimeLen = 15
+
+	// The first byte of cert data that holds both a created and a modified
+	// time. Legacy cert data only holds the time it was stored, and starts
+	// with the version byte of the encoded time, which is never this.
+	certDataWithCreated byte = 0xff
+)
+
+// certEntry is the data stored for each key.
+type certEntry struct {
+	Created  time.Time
+	Modified time.Time
+	Value    []byte
+}
+
+func (ce *certEntry) encode() ([]byte, error) {
+	created, err := ce.Created.UTC().MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	modified, err := ce.Modified.UTC().MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0, 1+len(created)+len(modified)+len(ce.Value))
+	data = append(data, certDataWithCreated)
+	data = append(data, created...)
+	data = append(data, modified...)
+	data = append(data, ce.Value...)
+
+	return data, nil
+}
+
+// decodeCertEntry decodes data in either the current format or the legacy
+// one, which only has a single time that's used as both the created and
+// modified time.
+func decodeCertEntry(data []byte) (*certEntry, error) {
+	var ce certEntry
+
+	if len(data) > 0 && data[0] == certDataWithCreated {
+		data = data[1:]
+
+		if len(data) < 2*encodedTimeLen {
+			return nil, errors.Wrapf(ErrBadCertData, "too short: %d bytes", len(data))
 		}
 
-		data := buk.Get([]byte(key))
+		err := ce.Created.UnmarshalBinary(data[:encodedTimeLen])
+		if err != nil {
+			return nil, err
+		}
 
-		err := ki.Modified.UnmarshalBinary(data[:15])
+		err = ce.Modified.UnmarshalBinary(data[encodedTimeLen : 2*encodedTimeLen])
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		ki.Size = int64(len(data) - 15)
-		ki.IsTerminal = false
+		ce.Value = data[2*encodedTimeLen:]
 
-		return nil
-	})
+		return &ce, nil
+	}
+
+	if len(data) < encodedTimeLen {
+		return nil, errors.Wrapf(ErrBadCertData, "too short: %d bytes", len(data))
+	}
 
+	err := ce.Modified.UnmarshalBinary(data[:encodedTimeLen])
 	if err != nil {
-		return ki, err
+		return nil, err
 	}
 
-	return ki, nil
+	ce.Created = ce.Modified
+	ce.Value = data[encodedTimeLen:]
+
+	return &ce, nil
 }
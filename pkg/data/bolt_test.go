@@ -0,0 +1,317 @@
+package data
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/clock"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+)
+
+func TestCertStorage(t *testing.T) {
+	setup := func(t *testing.T) (*Bolt, func()) {
+		dir, err := ioutil.TempDir("", "hzn-bolt")
+		require.NoError(t, err)
+
+		db, err := NewBolt(filepath.Join(dir, "data.db"))
+		require.NoError(t, err)
+
+		return db, func() {
+			db.db.Close()
+			os.RemoveAll(dir)
+		}
+	}
+
+	t.Run("keeps the created time when a key is overwritten", func(t *testing.T) {
+		db, cleanup := setup(t)
+		defer cleanup()
+
+		cs := db.CertStorage()
+
+		require.NoError(t, cs.Store("certs/a", []byte("first")))
+
+		first, err := cs.load("certs/a")
+		require.NoError(t, err)
+
+		assert.Equal(t, first.Created, first.Modified)
+
+		time.Sleep(10 * time.Millisecond)
+
+		require.NoError(t, cs.Store("certs/a", []byte("second value")))
+
+		second, err := cs.load("certs/a")
+		require.NoError(t, err)
+
+		assert.True(t, second.Created.Equal(first.Created))
+		assert.True(t, second.Modified.After(first.Modified))
+
+		ki, err := cs.Stat("certs/a")
+		require.NoError(t, err)
+
+		assert.True(t, ki.Modified.Equal(second.Modified))
+		assert.Equal(t, int64(len("second value")), ki.Size)
+
+		val, err := cs.Load("certs/a")
+		require.NoError(t, err)
+
+		assert.Equal(t, "second value", string(val))
+	})
+
+	t.Run("stamps certs with the time from its clock", func(t *testing.T) {
+		db, cleanup := setup(t)
+		defer cleanup()
+
+		start := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+		fake := clock.NewFake(start)
+
+		cs := db.CertStorage()
+		cs.Clock = fake
+
+		require.NoError(t, cs.Store("certs/a", []byte("first")))
+
+		fake.Advance(time.Hour)
+
+		require.NoError(t, cs.Store("certs/a", []byte("second")))
+
+		ce, err := cs.load("certs/a")
+		require.NoError(t, err)
+
+		assert.True(t, ce.Created.Equal(start))
+		assert.True(t, ce.Modified.Equal(start.Add(time.Hour)))
+	})
+
+	t.Run("reads data stored with a single time", func(t *testing.T) {
+		db, cleanup := setup(t)
+		defer cleanup()
+
+		cs := db.CertStorage()
+
+		stored := time.Now().Add(-time.Hour)
+
+		legacy, err := stored.MarshalBinary()
+		require.NoError(t, err)
+
+		legacy = append(legacy, "legacy"...)
+
+		err = db.db.Update(func(tx *bbolt.Tx) error {
+			buk, err := tx.CreateBucketIfNotExists([]byte("certs"))
+			if err != nil {
+				return err
+			}
+
+			return buk.Put([]byte("certs/a"), legacy)
+		})
+		require.NoError(t, err)
+
+		val, err := cs.Load("certs/a")
+		require.NoError(t, err)
+
+		assert.Equal(t, "legacy", string(val))
+
+		ki, err := cs.Stat("certs/a")
+		require.NoError(t, err)
+
+		assert.True(t, ki.Modified.Equal(stored))
+		assert.Equal(t, int64(len("legacy")), ki.Size)
+
+		require.NoError(t, cs.Store("certs/a", []byte("updated")))
+
+		ce, err := cs.load("certs/a")
+		require.NoError(t, err)
+
+		assert.True(t, ce.Created.Equal(stored))
+		assert.True(t, ce.Modified.After(stored))
+		assert.Equal(t, "updated", string(ce.Value))
+	})
+
+	t.Run("returns an error for missing keys", func(t *testing.T) {
+		db, cleanup := setup(t)
+		defer cleanup()
+
+		cs := db.CertStorage()
+
+		_, err := cs.Stat("certs/missing")
+		assert.Error(t, err)
+	})
+}
+
+func benchmarkStore(b *testing.B, batched bool, opts BoltOptions) {
+	dir, err := ioutil.TempDir("", "hzn-bolt")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	db, err := NewBoltWithOptions(filepath.Join(dir, "data.db"), opts)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	db.Batched = batched
+
+	cs := db.CertStorage()
+
+	value := make([]byte, 2048)
+
+	var seq int64
+
+	b.SetParallelism(16)
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			key := fmt.Sprintf("certs/%d", atomic.AddInt64(&seq, 1))
+
+			err := cs.Store(key, value)
+			if err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+
+	if opts.NoSync {
+		err = db.Sync()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCertStorageStore(b *testing.B) {
+	b.Run("update", func(b *testing.B) {
+		benchmarkStore(b, false, BoltOptions{})
+	})
+
+	b.Run("batched", func(b *testing.B) {
+		benchmarkStore(b, true, BoltOptions{})
+	})
+}
+
+func BenchmarkCertStorageStoreSync(b *testing.B) {
+	b.Run("sync", func(b *testing.B) {
+		benchmarkStore(b, false, BoltOptions{})
+	})
+
+	b.Run("no-sync", func(b *testing.B) {
+		benchmarkStore(b, false, BoltOptions{NoSync: true})
+	})
+
+	b.Run("no-sync-no-freelist", func(b *testing.B) {
+		benchmarkStore(b, false, BoltOptions{NoSync: true, NoFreelistSync: true})
+	})
+}
+
+func TestAccountPages(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hzn-bolt")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	db, err := NewBolt(filepath.Join(dir, "data.db"))
+	require.NoError(t, err)
+
+	defer db.db.Close()
+
+	account := &pb.Account{
+		Namespace: "/",
+		AccountId: pb.NewULID(),
+	}
+
+	pages, err := db.AccountPages(account)
+	require.NoError(t, err)
+	assert.Nil(t, pages)
+
+	stored := &pb.AccountPages{
+		Suspended: true,
+		SuspendedPage: &pb.StaticResponse{
+			Code: 451,
+			Body: []byte("suspended"),
+		},
+	}
+
+	require.NoError(t, db.SetAccountPages(account, stored))
+
+	pages, err = db.AccountPages(account)
+	require.NoError(t, err)
+	assert.True(t, stored.Equal(pages))
+
+	require.NoError(t, db.SetAccountPages(account, nil))
+
+	pages, err = db.AccountPages(account)
+	require.NoError(t, err)
+	assert.Nil(t, pages)
+}
+
+func TestResolutions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hzn-bolt")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	db, err := NewBolt(filepath.Join(dir, "data.db"))
+	require.NoError(t, err)
+
+	defer db.db.Close()
+
+	account := &pb.Account{
+		Namespace: "/",
+		AccountId: pb.NewULID(),
+	}
+
+	ll, err := db.LabelLinkResolution("app")
+	require.NoError(t, err)
+	assert.Nil(t, ll)
+
+	as, err := db.ServicesResolution("app")
+	require.NoError(t, err)
+	assert.Nil(t, as)
+
+	storedLink := &pb.LabelLink{
+		Account: account,
+		Labels:  pb.ParseLabelSet(":hostname=app.example.com"),
+		Target:  pb.ParseLabelSet("service=www"),
+	}
+
+	storedServices := &pb.AccountServices{
+		Account: account,
+		Services: []*pb.ServiceRoute{
+			{
+				Hub:  pb.NewULID(),
+				Id:   pb.NewULID(),
+				Type: "http",
+			},
+		},
+	}
+
+	require.NoError(t, db.SetLabelLinkResolution("app", storedLink))
+	require.NoError(t, db.SetServicesResolution("app", storedServices))
+
+	ll, err = db.LabelLinkResolution("app")
+	require.NoError(t, err)
+	assert.True(t, storedLink.Equal(ll))
+
+	// Label links and services are kept apart, even under the same key.
+	as, err = db.ServicesResolution("app")
+	require.NoError(t, err)
+	assert.True(t, storedServices.Equal(as))
+
+	require.NoError(t, db.DeleteLabelLinkResolution("app"))
+
+	ll, err = db.LabelLinkResolution("app")
+	require.NoError(t, err)
+	assert.Nil(t, ll)
+
+	as, err = db.ServicesResolution("app")
+	require.NoError(t, err)
+	assert.True(t, storedServices.Equal(as))
+}
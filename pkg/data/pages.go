@@ -0,0 +1,60 @@
+package data
+
+import (
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+)
+
+var pagesBucket = []byte("account-pages")
+
+// SetAccountPages stores the pages served for account. Passing nil removes
+// them.
+func (b *Bolt) SetAccountPages(account *pb.Account, pages *pb.AccountPages) error {
+	key := []byte(account.SpecString())
+
+	return b.update(func(tx *bbolt.Tx) error {
+		buk, err := tx.CreateBucketIfNotExists(pagesBucket)
+		if err != nil {
+			return err
+		}
+
+		if pages == nil {
+			return buk.Delete(key)
+		}
+
+		data, err := pages.Marshal()
+		if err != nil {
+			return err
+		}
+
+		return buk.Put(key, data)
+	})
+}
+
+// AccountPages returns the pages served for account, or nil if it has none.
+func (b *Bolt) AccountPages(account *pb.Account) (*pb.AccountPages, error) {
+	var pages *pb.AccountPages
+
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		buk := tx.Bucket(pagesBucket)
+		if buk == nil {
+			return nil
+		}
+
+		data := buk.Get([]byte(account.SpecString()))
+		if data == nil {
+			return nil
+		}
+
+		pages = new(pb.AccountPages)
+
+		return pages.Unmarshal(data)
+	})
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading pages for account %s", account.SpecString())
+	}
+
+	return pages, nil
+}
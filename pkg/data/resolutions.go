@@ -0,0 +1,120 @@
+package data
+
+import (
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+)
+
+var resolutionsBucket = []byte("resolutions")
+
+// Label links and services are kept in the same bucket, told apart by the
+// prefix of their keys.
+const (
+	labelLinkPrefix = "label-link:"
+	servicesPrefix  = "services:"
+)
+
+type resolution interface {
+	Marshal() ([]byte, error)
+	Unmarshal([]byte) error
+}
+
+// SetLabelLinkResolution stores the label link that key last resolved to, so
+// a web frontend can keep serving it when control can't be reached.
+func (b *Bolt) SetLabelLinkResolution(key string, ll *pb.LabelLink) error {
+	return b.setResolution(labelLinkPrefix+key, ll)
+}
+
+// LabelLinkResolution returns the label link stored for key, or nil if there
+// isn't one.
+func (b *Bolt) LabelLinkResolution(key string) (*pb.LabelLink, error) {
+	var ll pb.LabelLink
+
+	ok, err := b.resolution(labelLinkPrefix+key, &ll)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &ll, nil
+}
+
+// DeleteLabelLinkResolution removes the label link stored for key, such as
+// once key no longer resolves to one.
+func (b *Bolt) DeleteLabelLinkResolution(key string) error {
+	return b.deleteResolution(labelLinkPrefix + key)
+}
+
+// SetServicesResolution stores the services that key last resolved to, so a
+// web frontend can keep serving them when control can't be reached.
+func (b *Bolt) SetServicesResolution(key string, as *pb.AccountServices) error {
+	return b.setResolution(servicesPrefix+key, as)
+}
+
+// ServicesResolution returns the services stored for key, or nil if there
+// aren't any.
+func (b *Bolt) ServicesResolution(key string) (*pb.AccountServices, error) {
+	var as pb.AccountServices
+
+	ok, err := b.resolution(servicesPrefix+key, &as)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &as, nil
+}
+
+func (b *Bolt) setResolution(key string, res resolution) error {
+	data, err := res.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return b.update(func(tx *bbolt.Tx) error {
+		buk, err := tx.CreateBucketIfNotExists(resolutionsBucket)
+		if err != nil {
+			return err
+		}
+
+		return buk.Put([]byte(key), data)
+	})
+}
+
+func (b *Bolt) deleteResolution(key string) error {
+	return b.update(func(tx *bbolt.Tx) error {
+		buk := tx.Bucket(resolutionsBucket)
+		if buk == nil {
+			return nil
+		}
+
+		return buk.Delete([]byte(key))
+	})
+}
+
+// resolution decodes the resolution stored at key into res, returning false if
+// there isn't one.
+func (b *Bolt) resolution(key string, res resolution) (bool, error) {
+	var found bool
+
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		buk := tx.Bucket(resolutionsBucket)
+		if buk == nil {
+			return nil
+		}
+
+		data := buk.Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+
+		found = true
+
+		return res.Unmarshal(data)
+	})
+
+	if err != nil {
+		return false, errors.Wrapf(err, "loading resolution %s", key)
+	}
+
+	return found, nil
+}
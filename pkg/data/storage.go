@@ -0,0 +1,230 @@
+package data
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/caddyserver/certmagic"
+	"github.com/hashicorp/horizon/pkg/clock"
+	"github.com/pkg/errors"
+)
+
+// Storage is where certmagic keeps certs. Closing it releases whatever it's
+// stored in.
+type Storage interface {
+	certmagic.Storage
+	io.Closer
+}
+
+// The kinds of Storage that OpenStorage can return.
+const (
+	// Certs are kept in a bolt database file, so they survive a restart.
+	StorageBolt = "bolt"
+
+	// Certs are only kept in memory, which suits tests and short lived
+	// instances that can get their certs again.
+	StorageMemory = "memory"
+)
+
+var ErrUnknownStorage = errors.New("unknown storage type")
+
+// StorageConfig selects the Storage that OpenStorage returns.
+type StorageConfig struct {
+	// StorageBolt or StorageMemory. Defaults to StorageBolt.
+	Type string
+
+	// The bolt database file, required for StorageBolt.
+	Path string
+
+	// How the bolt database trades durability for speed, and whether its
+	// writes are batched. See BoltOptions and Bolt.Batched.
+	BoltOptions BoltOptions
+	Batched     bool
+
+	// Tells the time certs are stored and modified at. Defaults to
+	// clock.Real.
+	Clock clock.Clock
+}
+
+// OpenStorage returns the Storage that cfg selects.
+func OpenStorage(cfg StorageConfig) (Storage, error) {
+	switch cfg.Type {
+	case "", StorageBolt:
+		if cfg.Path == "" {
+			return nil, errors.New("bolt storage requires a path")
+		}
+
+		b, err := NewBoltWithOptions(cfg.Path, cfg.BoltOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		b.Batched = cfg.Batched
+
+		cs := b.CertStorage()
+		cs.Clock = cfg.Clock
+
+		return cs, nil
+	case StorageMemory:
+		return &MemoryCertStorage{Clock: cfg.Clock}, nil
+	default:
+		return nil, errors.Wrapf(ErrUnknownStorage, "%q", cfg.Type)
+	}
+}
+
+// MemoryCertStorage keeps certs in memory. They're lost when the process
+// exits.
+type MemoryCertStorage struct {
+	// Held between Lock and Unlock.
+	lock sync.Mutex
+
+	mu    sync.RWMutex
+	certs map[string]*certEntry
+
+	// Tells the time certs are stored and modified at. Defaults to
+	// clock.Real.
+	Clock clock.Clock
+}
+
+func (m *MemoryCertStorage) now() time.Time {
+	if m.Clock != nil {
+		return m.Clock.Now()
+	}
+
+	return clock.Real.Now()
+}
+
+// Close releases the certs.
+func (m *MemoryCertStorage) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.certs = nil
+
+	return nil
+}
+
+// Lock acquires the lock for key. Like CertStorage, there is a single lock
+// for every key, held until Unlock is called.
+func (m *MemoryCertStorage) Lock(key string) error {
+	m.lock.Lock()
+	return nil
+}
+
+// Unlock releases the lock for key.
+func (m *MemoryCertStorage) Unlock(key string) error {
+	m.lock.Unlock()
+	return nil
+}
+
+// Store puts value at key. If key already exists, the time it was first
+// stored is kept and only the modified time is updated.
+func (m *MemoryCertStorage) Store(key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.certs == nil {
+		m.certs = make(map[string]*certEntry)
+	}
+
+	now := m.now()
+
+	ce := &certEntry{
+		Created:  now,
+		Modified: now,
+		Value:    append([]byte(nil), value...),
+	}
+
+	if old, ok := m.certs[key]; ok {
+		ce.Created = old.Created
+	}
+
+	m.certs[key] = ce
+
+	return nil
+}
+
+// Load retrieves the value at key.
+func (m *MemoryCertStorage) Load(key string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ce, ok := m.certs[key]
+	if !ok {
+		return nil, certmagic.ErrNotExist(io.EOF)
+	}
+
+	return append([]byte(nil), ce.Value...), nil
+}
+
+// Delete deletes key.
+func (m *MemoryCertStorage) Delete(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.certs[key]; !ok {
+		return certmagic.ErrNotExist(io.EOF)
+	}
+
+	delete(m.certs, key)
+
+	return nil
+}
+
+// Exists returns true if the key exists.
+func (m *MemoryCertStorage) Exists(key string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, ok := m.certs[key]
+	return ok
+}
+
+// List returns the keys that start with prefix, in order. Unless recursive
+// is set, only the keys directly under prefix are listed.
+func (m *MemoryCertStorage) List(prefix string, recursive bool) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var matches []string
+
+	for key := range m.certs {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		rest := strings.TrimPrefix(key[len(prefix):], "/")
+
+		if !recursive && strings.Contains(rest, "/") {
+			continue
+		}
+
+		matches = append(matches, key)
+	}
+
+	sort.Strings(matches)
+
+	return matches, nil
+}
+
+// Stat returns information about key. The modified time is when key was last
+// stored.
+func (m *MemoryCertStorage) Stat(key string) (certmagic.KeyInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ce, ok := m.certs[key]
+	if !ok {
+		return certmagic.KeyInfo{}, certmagic.ErrNotExist(io.EOF)
+	}
+
+	return certmagic.KeyInfo{
+		Key:        key,
+		Modified:   ce.Modified,
+		Size:       int64(len(ce.Value)),
+		IsTerminal: true,
+	}, nil
+}
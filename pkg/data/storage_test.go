@@ -0,0 +1,112 @@
+package data
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/caddyserver/certmagic"
+	"github.com/hashicorp/horizon/pkg/clock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenStorage(t *testing.T) {
+	var _ certmagic.Storage = &CertStorage{}
+	var _ certmagic.Storage = &MemoryCertStorage{}
+
+	dir, err := ioutil.TempDir("", "hzn-storage")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	t.Run("opens the storage the config selects", func(t *testing.T) {
+		for _, tc := range []struct {
+			name string
+			cfg  StorageConfig
+			typ  interface{}
+		}{
+			{
+				name: "default",
+				cfg:  StorageConfig{Path: filepath.Join(dir, "default.db")},
+				typ:  &CertStorage{},
+			},
+			{
+				name: "bolt",
+				cfg:  StorageConfig{Type: StorageBolt, Path: filepath.Join(dir, "bolt.db"), Batched: true},
+				typ:  &CertStorage{},
+			},
+			{
+				name: "memory",
+				cfg:  StorageConfig{Type: StorageMemory},
+				typ:  &MemoryCertStorage{},
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				storage, err := OpenStorage(tc.cfg)
+				require.NoError(t, err)
+
+				defer storage.Close()
+
+				assert.IsType(t, tc.typ, storage)
+
+				require.NoError(t, storage.Store("certs/a", []byte("value")))
+
+				value, err := storage.Load("certs/a")
+				require.NoError(t, err)
+
+				assert.Equal(t, []byte("value"), value)
+				assert.True(t, storage.Exists("certs/a"))
+			})
+		}
+	})
+
+	t.Run("rejects bad configs", func(t *testing.T) {
+		_, err := OpenStorage(StorageConfig{Type: "s3"})
+		assert.True(t, errors.Is(err, ErrUnknownStorage))
+
+		_, err = OpenStorage(StorageConfig{Type: StorageBolt})
+		assert.Error(t, err)
+	})
+}
+
+func TestMemoryCertStorage(t *testing.T) {
+	fake := clock.NewFake(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	ms := &MemoryCertStorage{Clock: fake}
+
+	_, err := ms.Load("certs/a")
+	assert.Error(t, err)
+
+	require.NoError(t, ms.Store("certs/a", []byte("first")))
+
+	fake.Advance(time.Hour)
+
+	require.NoError(t, ms.Store("certs/a", []byte("second value")))
+	require.NoError(t, ms.Store("certs/b/c", []byte("nested")))
+
+	ki, err := ms.Stat("certs/a")
+	require.NoError(t, err)
+
+	assert.Equal(t, fake.Now(), ki.Modified)
+	assert.Equal(t, int64(len("second value")), ki.Size)
+	assert.Equal(t, time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), ms.certs["certs/a"].Created)
+
+	keys, err := ms.List("certs", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"certs/a"}, keys)
+
+	keys, err = ms.List("certs", true)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"certs/a", "certs/b/c"}, keys)
+
+	require.NoError(t, ms.Delete("certs/a"))
+
+	assert.False(t, ms.Exists("certs/a"))
+	assert.Error(t, ms.Delete("certs/a"))
+}
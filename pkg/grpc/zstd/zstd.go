@@ -0,0 +1,88 @@
+// Package zstd registers a zstd compressor with grpc. It gets a better ratio
+// than lz4 at the cost of more CPU, which suits hubs on constrained links.
+//
+// As with any grpc compressor, a client opts in per call with
+// grpc.UseCompressor(zstd.Name) and the server responds in kind.
+package zstd
+
+import (
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+)
+
+// Name is the name registered for the zstd compressor.
+const Name = "zstd"
+
+func init() {
+	encoding.RegisterCompressor(&compressor{})
+}
+
+type compressor struct {
+	poolCompressor   sync.Pool
+	poolDecompressor sync.Pool
+}
+
+type writer struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+func (c *compressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	z, inPool := c.poolCompressor.Get().(*writer)
+	if inPool {
+		z.Encoder.Reset(w)
+		return z, nil
+	}
+
+	enc, err := zstd.NewWriter(w)
+	if err != nil {
+		return nil, err
+	}
+
+	return &writer{Encoder: enc, pool: &c.poolCompressor}, nil
+}
+
+func (z *writer) Close() (err error) {
+	err = z.Encoder.Close()
+	z.pool.Put(z)
+	return
+}
+
+type reader struct {
+	*zstd.Decoder
+	pool *sync.Pool
+}
+
+func (c *compressor) Decompress(r io.Reader) (io.Reader, error) {
+	z, inPool := c.poolDecompressor.Get().(*reader)
+	if inPool {
+		err := z.Decoder.Reset(r)
+		if err != nil {
+			return nil, err
+		}
+
+		return z, nil
+	}
+
+	dec, err := zstd.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reader{Decoder: dec, pool: &c.poolDecompressor}, nil
+}
+
+func (z *reader) Read(p []byte) (n int, err error) {
+	if n, err = z.Decoder.Read(p); err == io.EOF {
+		z.pool.Put(z)
+	}
+
+	return
+}
+
+func (c *compressor) Name() string {
+	return Name
+}
@@ -9,12 +9,16 @@ import (
 	"github.com/hashicorp/horizon/pkg/connect"
 	"github.com/hashicorp/horizon/pkg/pb"
 	"github.com/hashicorp/horizon/pkg/timing"
+	"github.com/hashicorp/horizon/pkg/web"
 	"github.com/hashicorp/horizon/pkg/wire"
 	"github.com/pierrec/lz4/v3"
 	"github.com/pkg/errors"
 )
 
-var ErrNoRoutes = errors.New("no routes to any service available")
+var (
+	ErrNoRoutes  = errors.New("no routes to any service available")
+	ErrOverQuota = errors.New("over traffic quota")
+)
 
 func (h *Hub) ConnectToService(
 	ctx context.Context,
@@ -28,6 +32,14 @@ func (h *Hub) ConnectToService(
 		err  error
 	)
 
+	if h.cc.OverQuota() {
+		return nil, web.NewConnectError(web.ConnectQuotaExceeded, errors.Wrapf(ErrOverQuota, "hub"))
+	}
+
+	if h.cc.AccountOverQuota(account) {
+		return nil, web.NewConnectError(web.ConnectQuotaExceeded, errors.Wrapf(ErrOverQuota, "account %s", account))
+	}
+
 	// Oh look it's not for me!
 	if !target.Hub.Equal(h.id) {
 		wctx, err = h.connectToRemoteService(ctx, target, account, proto, token)
@@ -42,12 +54,12 @@ func (h *Hub) ConnectToService(
 		h.mu.RUnlock()
 
 		if !ok {
-			return nil, ErrNoSuchSession
+			return nil, web.NewConnectError(web.ConnectNoRoute, ErrNoSuchSession)
 		}
 
 		stream, err := ac.session.OpenStream()
 		if err != nil {
-			return nil, err
+			return nil, web.NewConnectError(web.ConnectRefused, err)
 		}
 
 		var (
@@ -79,7 +91,9 @@ func (h *Hub) ConnectToService(
 			return nil, err
 		}
 
-		wctx = wire.NewContext(account, fr, fw)
+		// Closing the context closes the stream so the agent sees the
+		// connection go away, for instance when an http client disconnects.
+		wctx = wire.WithCloser(wire.NewContext(account, fr, fw), stream.Close)
 	}
 
 	sub, cancel := context.WithCancel(ctx)
@@ -166,7 +180,7 @@ func (h *Hub) connectToRemoteService(
 
 	if len(locs) == 0 {
 		L.Error("no locations for target hub", "hub", target.Hub)
-		return nil, ErrNoSuchSession
+		return nil, web.NewConnectError(web.ConnectNoRoute, ErrNoSuchSession)
 	}
 
 	L.Trace("locations for target hub", "hub", target.Hub, "locations", locs)
@@ -192,7 +206,11 @@ func (h *Hub) connectToRemoteService(
 	// pool.
 	session, err := connect.Connect(L, addr, token)
 	if err != nil {
-		return nil, err
+		if err == connect.ErrInvalidToken {
+			return nil, web.NewConnectError(web.ConnectAuthRejected, err)
+		}
+
+		return nil, web.NewConnectError(web.ConnectRefused, err)
 	}
 
 	// We're allowing the target hub to do it's own lookup again rather than
@@ -201,7 +219,8 @@ func (h *Hub) connectToRemoteService(
 	// in multiple relays).
 	conn, err := session.ConnecToAccountService(account, target.Labels)
 	if err != nil {
-		return nil, err
+		session.Close()
+		return nil, web.NewConnectError(web.ConnectRefused, err)
 	}
 
 	return wire.WithCloser(conn.WireContext(account), session.Close), nil
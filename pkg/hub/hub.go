@@ -156,6 +156,9 @@ func (hub *Hub) sendStats(ctx context.Context) {
 func (hub *Hub) WaitToDrain() error {
 	hub.wg.Wait()
 
+	// Report the traffic the frontend hasn't yet, now that there's no more.
+	hub.fe.FlushFlows()
+
 	return nil
 }
 
@@ -253,6 +256,17 @@ func (h *Hub) handshake(ctx context.Context, fr *wire.FramingReader, fw *wire.Fr
 		return nil, errors.Wrapf(err, "invalid token received")
 	}
 
+	if !vt.AllowHub(h.location) {
+		wc.Status = "bad-token-scope"
+
+		_, err = fw.WriteMarshal(1, &wc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error marshalling confirmation")
+		}
+
+		return nil, errors.Wrapf(ErrProtocolError, "token not scoped to this hub")
+	}
+
 	if len(preamble.Services) > 0 {
 		ok, _ := vt.HasCapability(pb.SERVE)
 		if !ok {
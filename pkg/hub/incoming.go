@@ -69,7 +69,13 @@ func (h *Hub) handleAgentStream(ctx context.Context, ai *agentConn, stream *yamu
 		return
 	}
 
-	routes := calc.Services()
+	routes, err := h.cc.RoutesInScope(ctx, calc.Services(), ai.token.HubScope())
+	if err != nil {
+		var resp pb.Response
+		resp.Error = err.Error()
+		wctx.WriteMarshal(255, &resp)
+		return
+	}
 
 	for len(routes) > 0 {
 		var target *pb.ServiceRoute
@@ -3,12 +3,19 @@ package pb
 import (
 	"bytes"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/mr-tron/base58"
 	"golang.org/x/crypto/blake2b"
 )
 
-// Collapse the Account to an unambigious sequence
+// Collapse the Account to an unambigious sequence. This is the key accounts
+// are stored under and that services and label links refer to them by: the
+// namespace, a '!', and then the raw bytes of the AccountId. Because the
+// namespace comes first, it must not itself contain a '!', otherwise
+// AccountFromKey would split the key in the wrong place. Use Validate to check
+// that an Account can be keyed before storing anything under its Key.
 func (a *Account) Key() []byte {
 	var buf bytes.Buffer
 	buf.WriteString(a.Namespace)
@@ -48,6 +55,37 @@ func (a *Account) HashKey() string {
 
 var ErrInvalidAccount = errors.New("invalid account key")
 
+// Validate checks that the account has both an id and a namespace, and that
+// its Key maps back to the same namespace and id.
+func (a *Account) Validate() error {
+	if a == nil {
+		return fmt.Errorf("%w: no account", ErrInvalidAccount)
+	}
+
+	if a.AccountId == nil {
+		return fmt.Errorf("%w: no account id", ErrInvalidAccount)
+	}
+
+	if a.Namespace == "" {
+		return fmt.Errorf("%w: no namespace", ErrInvalidAccount)
+	}
+
+	if strings.ContainsRune(a.Namespace, '!') {
+		return fmt.Errorf("%w: namespace contains '!': %s", ErrInvalidAccount, a.Namespace)
+	}
+
+	back, err := AccountFromKey(a.Key())
+	if err != nil {
+		return err
+	}
+
+	if back.Namespace != a.Namespace || !bytes.Equal(back.AccountId.Bytes(), a.AccountId.Bytes()) {
+		return fmt.Errorf("%w: key does not match account %s", ErrInvalidAccount, a.StringKey())
+	}
+
+	return nil
+}
+
 func AccountFromKey(k []byte) (*Account, error) {
 	pos := bytes.IndexRune(k, '!')
 	if pos == -1 {
@@ -0,0 +1,42 @@
+package pb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccount(t *testing.T) {
+	t.Run("key maps back to the account", func(t *testing.T) {
+		acc := &Account{
+			Namespace: "/foo/bar",
+			AccountId: NewULID(),
+		}
+
+		require.NoError(t, acc.Validate())
+
+		back, err := AccountFromKey(acc.Key())
+		require.NoError(t, err)
+
+		assert.Equal(t, acc.Namespace, back.Namespace)
+		assert.Equal(t, acc.AccountId.Bytes(), back.AccountId.Bytes())
+	})
+
+	t.Run("rejects accounts that can't be keyed", func(t *testing.T) {
+		var nilAccount *Account
+
+		for name, acc := range map[string]*Account{
+			"nil account":    nilAccount,
+			"no account id":  {Namespace: "/"},
+			"no namespace":   {AccountId: NewULID()},
+			"ambiguous name": {Namespace: "/foo!bar", AccountId: NewULID()},
+		} {
+			err := acc.Validate()
+			require.Error(t, err, name)
+
+			assert.True(t, errors.Is(err, ErrInvalidAccount), name)
+		}
+	})
+}
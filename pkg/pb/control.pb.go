@@ -153,6 +153,9 @@ type LabelLink struct {
 	Labels  *LabelSet       `protobuf:"bytes,2,opt,name=labels,proto3" json:"labels,omitempty"`
 	Target  *LabelSet       `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
 	Limits  *Account_Limits `protobuf:"bytes,4,opt,name=limits,proto3" json:"limits,omitempty"`
+	// If set, requests are split between these targets according to their
+	// weights, rather than all going to target.
+	Splits []*TargetSplit `protobuf:"bytes,5,rep,name=splits,proto3" json:"splits,omitempty"`
 }
 
 func (m *LabelLink) Reset()      { *m = LabelLink{} }
@@ -215,6 +218,13 @@ func (m *LabelLink) GetLimits() *Account_Limits {
 	return nil
 }
 
+func (m *LabelLink) GetSplits() []*TargetSplit {
+	if m != nil {
+		return m.Splits
+	}
+	return nil
+}
+
 type LabelLinks struct {
 	LabelLinks []*LabelLink `protobuf:"bytes,1,rep,name=label_links,json=labelLinks,proto3" json:"label_links,omitempty"`
 }
@@ -263,6 +273,13 @@ type ServiceRoute struct {
 	Id     *ULID     `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
 	Type   string    `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
 	Labels *LabelSet `protobuf:"bytes,4,opt,name=labels,proto3" json:"labels,omitempty"`
+	// Set by control when the hub the service is on is not connected or has not
+	// checked in recently.
+	Unhealthy bool `protobuf:"varint,5,opt,name=unhealthy,proto3" json:"unhealthy,omitempty"`
+	// The share of requests the service gets relative to the others it's
+	// picked from. Zero is the same as 1, so services without a weight share
+	// equally.
+	Weight uint32 `protobuf:"varint,6,opt,name=weight,proto3" json:"weight,omitempty"`
 }
 
 func (m *ServiceRoute) Reset()      { *m = ServiceRoute{} }
@@ -325,9 +342,27 @@ func (m *ServiceRoute) GetLabels() *LabelSet {
 	return nil
 }
 
+func (m *ServiceRoute) GetUnhealthy() bool {
+	if m != nil {
+		return m.Unhealthy
+	}
+	return false
+}
+
+func (m *ServiceRoute) GetWeight() uint32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
 type AccountServices struct {
 	Account  *Account        `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
 	Services []*ServiceRoute `protobuf:"bytes,2,rep,name=services,proto3" json:"services,omitempty"`
+	// Set when the account is suspended, in which case services is empty and
+	// none of the account's services, even those connected to the hub itself,
+	// should be routed to.
+	Suspended bool `protobuf:"varint,3,opt,name=suspended,proto3" json:"suspended,omitempty"`
 }
 
 func (m *AccountServices) Reset()      { *m = AccountServices{} }
@@ -376,6 +411,13 @@ func (m *AccountServices) GetServices() []*ServiceRoute {
 	return nil
 }
 
+func (m *AccountServices) GetSuspended() bool {
+	if m != nil {
+		return m.Suspended
+	}
+	return false
+}
+
 type ActivityEntry struct {
 	RouteAdded   *AccountServices `protobuf:"bytes,1,opt,name=route_added,json=routeAdded,proto3" json:"route_added,omitempty"`
 	RouteRemoved *ULID            `protobuf:"bytes,2,opt,name=route_removed,json=routeRemoved,proto3" json:"route_removed,omitempty"`
@@ -487,13 +529,14 @@ func (m *ConfigRequest) GetLocations() []*NetworkLocation {
 }
 
 type ConfigResponse struct {
-	TlsKey      []byte `protobuf:"bytes,1,opt,name=tls_key,json=tlsKey,proto3" json:"tls_key,omitempty"`
-	TlsCert     []byte `protobuf:"bytes,2,opt,name=tls_cert,json=tlsCert,proto3" json:"tls_cert,omitempty"`
-	TokenPub    []byte `protobuf:"bytes,3,opt,name=token_pub,json=tokenPub,proto3" json:"token_pub,omitempty"`
-	S3AccessKey string `protobuf:"bytes,4,opt,name=s3_access_key,json=s3AccessKey,proto3" json:"s3_access_key,omitempty"`
-	S3SecretKey string `protobuf:"bytes,5,opt,name=s3_secret_key,json=s3SecretKey,proto3" json:"s3_secret_key,omitempty"`
-	S3Bucket    string `protobuf:"bytes,6,opt,name=s3_bucket,json=s3Bucket,proto3" json:"s3_bucket,omitempty"`
-	ImageTag    string `protobuf:"bytes,7,opt,name=image_tag,json=imageTag,proto3" json:"image_tag,omitempty"`
+	TlsKey             []byte `protobuf:"bytes,1,opt,name=tls_key,json=tlsKey,proto3" json:"tls_key,omitempty"`
+	TlsCert            []byte `protobuf:"bytes,2,opt,name=tls_cert,json=tlsCert,proto3" json:"tls_cert,omitempty"`
+	TokenPub           []byte `protobuf:"bytes,3,opt,name=token_pub,json=tokenPub,proto3" json:"token_pub,omitempty"`
+	S3AccessKey        string `protobuf:"bytes,4,opt,name=s3_access_key,json=s3AccessKey,proto3" json:"s3_access_key,omitempty"`
+	S3SecretKey        string `protobuf:"bytes,5,opt,name=s3_secret_key,json=s3SecretKey,proto3" json:"s3_secret_key,omitempty"`
+	S3Bucket           string `protobuf:"bytes,6,opt,name=s3_bucket,json=s3Bucket,proto3" json:"s3_bucket,omitempty"`
+	ImageTag           string `protobuf:"bytes,7,opt,name=image_tag,json=imageTag,proto3" json:"image_tag,omitempty"`
+	ActivityCompressor string `protobuf:"bytes,8,opt,name=activity_compressor,json=activityCompressor,proto3" json:"activity_compressor,omitempty"`
 }
 
 func (m *ConfigResponse) Reset()      { *m = ConfigResponse{} }
@@ -577,10 +620,26 @@ func (m *ConfigResponse) GetImageTag() string {
 	return ""
 }
 
+func (m *ConfigResponse) GetActivityCompressor() string {
+	if m != nil {
+		return m.ActivityCompressor
+	}
+	return ""
+}
+
 type CentralActivity struct {
-	AccountServices []*AccountServices `protobuf:"bytes,1,rep,name=account_services,json=accountServices,proto3" json:"account_services,omitempty"`
-	RequestStats    bool               `protobuf:"varint,2,opt,name=request_stats,json=requestStats,proto3" json:"request_stats,omitempty"`
-	NewLabelLinks   *LabelLinks        `protobuf:"bytes,3,opt,name=new_label_links,json=newLabelLinks,proto3" json:"new_label_links,omitempty"`
+	AccountServices   []*AccountServices `protobuf:"bytes,1,rep,name=account_services,json=accountServices,proto3" json:"account_services,omitempty"`
+	RequestStats      bool               `protobuf:"varint,2,opt,name=request_stats,json=requestStats,proto3" json:"request_stats,omitempty"`
+	NewLabelLinks     *LabelLinks        `protobuf:"bytes,3,opt,name=new_label_links,json=newLabelLinks,proto3" json:"new_label_links,omitempty"`
+	ResolvedRoutes    []*AccountServices `protobuf:"bytes,4,rep,name=resolved_routes,json=resolvedRoutes,proto3" json:"resolved_routes,omitempty"`
+	RemovedLabelLinks *LabelLinks        `protobuf:"bytes,5,opt,name=removed_label_links,json=removedLabelLinks,proto3" json:"removed_label_links,omitempty"`
+	Quotas            []*QuotaStatus     `protobuf:"bytes,6,rep,name=quotas,proto3" json:"quotas,omitempty"`
+	// Rotated S3 credentials, as older servers sent them. They're now only
+	// handed out in ConfigResponse, see s3_credentials_rotated.
+	S3Credentials *S3Credentials `protobuf:"bytes,7,opt,name=s3_credentials,json=s3Credentials,proto3" json:"s3_credentials,omitempty"`
+	// Set when the S3 credentials handed out in ConfigResponse are rotated,
+	// so hubs fetch their config again to pick up the new ones.
+	S3CredentialsRotated bool `protobuf:"varint,8,opt,name=s3_credentials_rotated,json=s3CredentialsRotated,proto3" json:"s3_credentials_rotated,omitempty"`
 }
 
 func (m *CentralActivity) Reset()      { *m = CentralActivity{} }
@@ -636,17 +695,192 @@ func (m *CentralActivity) GetNewLabelLinks() *LabelLinks {
 	return nil
 }
 
+func (m *CentralActivity) GetResolvedRoutes() []*AccountServices {
+	if m != nil {
+		return m.ResolvedRoutes
+	}
+	return nil
+}
+
+func (m *CentralActivity) GetRemovedLabelLinks() *LabelLinks {
+	if m != nil {
+		return m.RemovedLabelLinks
+	}
+	return nil
+}
+
+func (m *CentralActivity) GetQuotas() []*QuotaStatus {
+	if m != nil {
+		return m.Quotas
+	}
+	return nil
+}
+
+func (m *CentralActivity) GetS3Credentials() *S3Credentials {
+	if m != nil {
+		return m.S3Credentials
+	}
+	return nil
+}
+
+func (m *CentralActivity) GetS3CredentialsRotated() bool {
+	if m != nil {
+		return m.S3CredentialsRotated
+	}
+	return false
+}
+
+type S3Credentials struct {
+	AccessKey string `protobuf:"bytes,1,opt,name=access_key,json=accessKey,proto3" json:"access_key,omitempty"`
+	SecretKey string `protobuf:"bytes,2,opt,name=secret_key,json=secretKey,proto3" json:"secret_key,omitempty"`
+	Bucket    string `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+}
+
+func (m *S3Credentials) Reset()      { *m = S3Credentials{} }
+func (*S3Credentials) ProtoMessage() {}
+func (*S3Credentials) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{10}
+}
+func (m *S3Credentials) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *S3Credentials) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_S3Credentials.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *S3Credentials) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_S3Credentials.Merge(m, src)
+}
+func (m *S3Credentials) XXX_Size() int {
+	return m.Size()
+}
+func (m *S3Credentials) XXX_DiscardUnknown() {
+	xxx_messageInfo_S3Credentials.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_S3Credentials proto.InternalMessageInfo
+
+func (m *S3Credentials) GetAccessKey() string {
+	if m != nil {
+		return m.AccessKey
+	}
+	return ""
+}
+
+func (m *S3Credentials) GetSecretKey() string {
+	if m != nil {
+		return m.SecretKey
+	}
+	return ""
+}
+
+func (m *S3Credentials) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+type QuotaStatus struct {
+	// Set for an account's quota.
+	Account *Account `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Set for a hub's quota.
+	Hub      *ULID `protobuf:"bytes,2,opt,name=hub,proto3" json:"hub,omitempty"`
+	Exceeded bool  `protobuf:"varint,3,opt,name=exceeded,proto3" json:"exceeded,omitempty"`
+	// The usage over the quota window when the status was sent.
+	Bytes    int64 `protobuf:"varint,4,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Messages int64 `protobuf:"varint,5,opt,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (m *QuotaStatus) Reset()      { *m = QuotaStatus{} }
+func (*QuotaStatus) ProtoMessage() {}
+func (*QuotaStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{11}
+}
+func (m *QuotaStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QuotaStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QuotaStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QuotaStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuotaStatus.Merge(m, src)
+}
+func (m *QuotaStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *QuotaStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuotaStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuotaStatus proto.InternalMessageInfo
+
+func (m *QuotaStatus) GetAccount() *Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func (m *QuotaStatus) GetHub() *ULID {
+	if m != nil {
+		return m.Hub
+	}
+	return nil
+}
+
+func (m *QuotaStatus) GetExceeded() bool {
+	if m != nil {
+		return m.Exceeded
+	}
+	return false
+}
+
+func (m *QuotaStatus) GetBytes() int64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *QuotaStatus) GetMessages() int64 {
+	if m != nil {
+		return m.Messages
+	}
+	return 0
+}
+
 type HubActivity struct {
 	HubReg *HubActivity_HubRegistration `protobuf:"bytes,1,opt,name=hub_reg,json=hubReg,proto3" json:"hub_reg,omitempty"`
 	SentAt *Timestamp                   `protobuf:"bytes,2,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
 	Stats  *HubActivity_HubStats        `protobuf:"bytes,3,opt,name=stats,proto3" json:"stats,omitempty"`
 	Flow   []*FlowRecord                `protobuf:"bytes,4,rep,name=flow,proto3" json:"flow,omitempty"`
+	// Accounts the hub has started keeping the routes of, so central sends it
+	// their routing activity even if it has no services for them.
+	CachedAccounts []*Account `protobuf:"bytes,5,rep,name=cached_accounts,json=cachedAccounts,proto3" json:"cached_accounts,omitempty"`
 }
 
 func (m *HubActivity) Reset()      { *m = HubActivity{} }
 func (*HubActivity) ProtoMessage() {}
 func (*HubActivity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{10}
+	return fileDescriptor_0c5120591600887d, []int{12}
 }
 func (m *HubActivity) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -703,6 +937,13 @@ func (m *HubActivity) GetFlow() []*FlowRecord {
 	return nil
 }
 
+func (m *HubActivity) GetCachedAccounts() []*Account {
+	if m != nil {
+		return m.CachedAccounts
+	}
+	return nil
+}
+
 type HubActivity_HubRegistration struct {
 	Hub       *ULID              `protobuf:"bytes,1,opt,name=hub,proto3" json:"hub,omitempty"`
 	StableHub *ULID              `protobuf:"bytes,2,opt,name=stable_hub,json=stableHub,proto3" json:"stable_hub,omitempty"`
@@ -712,7 +953,7 @@ type HubActivity_HubRegistration struct {
 func (m *HubActivity_HubRegistration) Reset()      { *m = HubActivity_HubRegistration{} }
 func (*HubActivity_HubRegistration) ProtoMessage() {}
 func (*HubActivity_HubRegistration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{10, 0}
+	return fileDescriptor_0c5120591600887d, []int{12, 0}
 }
 func (m *HubActivity_HubRegistration) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -774,7 +1015,7 @@ type HubActivity_HubStats struct {
 func (m *HubActivity_HubStats) Reset()      { *m = HubActivity_HubStats{} }
 func (*HubActivity_HubStats) ProtoMessage() {}
 func (*HubActivity_HubStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{10, 1}
+	return fileDescriptor_0c5120591600887d, []int{12, 1}
 }
 func (m *HubActivity_HubStats) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -853,7 +1094,7 @@ type HubInfo struct {
 func (m *HubInfo) Reset()      { *m = HubInfo{} }
 func (*HubInfo) ProtoMessage() {}
 func (*HubInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{11}
+	return fileDescriptor_0c5120591600887d, []int{13}
 }
 func (m *HubInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -898,12 +1139,20 @@ func (m *HubInfo) GetLocations() []*NetworkLocation {
 
 type ListOfHubs struct {
 	Hubs []*HubInfo `protobuf:"bytes,1,rep,name=hubs,proto3" json:"hubs,omitempty"`
+	// The number of hubs left out because their connection info couldn't be
+	// decoded.
+	Skipped int32 `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	// Identifies the hubs listed. Passing it back in AllHubsRequest skips the
+	// list when it hasn't changed.
+	Version string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	// Set, with the hubs left out, when the version asked for is still current.
+	NotModified bool `protobuf:"varint,4,opt,name=not_modified,json=notModified,proto3" json:"not_modified,omitempty"`
 }
 
 func (m *ListOfHubs) Reset()      { *m = ListOfHubs{} }
 func (*ListOfHubs) ProtoMessage() {}
 func (*ListOfHubs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{12}
+	return fileDescriptor_0c5120591600887d, []int{14}
 }
 func (m *ListOfHubs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -939,6 +1188,27 @@ func (m *ListOfHubs) GetHubs() []*HubInfo {
 	return nil
 }
 
+func (m *ListOfHubs) GetSkipped() int32 {
+	if m != nil {
+		return m.Skipped
+	}
+	return 0
+}
+
+func (m *ListOfHubs) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ListOfHubs) GetNotModified() bool {
+	if m != nil {
+		return m.NotModified
+	}
+	return false
+}
+
 type HubSync struct {
 	Id       *ULID             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	StableId *ULID             `protobuf:"bytes,2,opt,name=stable_id,json=stableId,proto3" json:"stable_id,omitempty"`
@@ -948,7 +1218,7 @@ type HubSync struct {
 func (m *HubSync) Reset()      { *m = HubSync{} }
 func (*HubSync) ProtoMessage() {}
 func (*HubSync) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{13}
+	return fileDescriptor_0c5120591600887d, []int{15}
 }
 func (m *HubSync) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1005,7 +1275,7 @@ type HubSyncResponse struct {
 func (m *HubSyncResponse) Reset()      { *m = HubSyncResponse{} }
 func (*HubSyncResponse) ProtoMessage() {}
 func (*HubSyncResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{14}
+	return fileDescriptor_0c5120591600887d, []int{16}
 }
 func (m *HubSyncResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1050,7 +1320,7 @@ type HubRegisterRequest struct {
 func (m *HubRegisterRequest) Reset()      { *m = HubRegisterRequest{} }
 func (*HubRegisterRequest) ProtoMessage() {}
 func (*HubRegisterRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{15}
+	return fileDescriptor_0c5120591600887d, []int{17}
 }
 func (m *HubRegisterRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1107,7 +1377,7 @@ type HubRegisterResponse struct {
 func (m *HubRegisterResponse) Reset()      { *m = HubRegisterResponse{} }
 func (*HubRegisterResponse) ProtoMessage() {}
 func (*HubRegisterResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{16}
+	return fileDescriptor_0c5120591600887d, []int{18}
 }
 func (m *HubRegisterResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1151,7 +1421,7 @@ type HubDisconnectRequest struct {
 func (m *HubDisconnectRequest) Reset()      { *m = HubDisconnectRequest{} }
 func (*HubDisconnectRequest) ProtoMessage() {}
 func (*HubDisconnectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{17}
+	return fileDescriptor_0c5120591600887d, []int{19}
 }
 func (m *HubDisconnectRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1196,12 +1466,15 @@ func (m *HubDisconnectRequest) GetInstanceId() *ULID {
 
 type ServiceTokenRequest struct {
 	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// If set, the token can only be used on hubs with a location matching
+	// these labels.
+	HubScope *LabelSet `protobuf:"bytes,2,opt,name=hub_scope,json=hubScope,proto3" json:"hub_scope,omitempty"`
 }
 
 func (m *ServiceTokenRequest) Reset()      { *m = ServiceTokenRequest{} }
 func (*ServiceTokenRequest) ProtoMessage() {}
 func (*ServiceTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{18}
+	return fileDescriptor_0c5120591600887d, []int{20}
 }
 func (m *ServiceTokenRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1237,6 +1510,13 @@ func (m *ServiceTokenRequest) GetNamespace() string {
 	return ""
 }
 
+func (m *ServiceTokenRequest) GetHubScope() *LabelSet {
+	if m != nil {
+		return m.HubScope
+	}
+	return nil
+}
+
 type ServiceTokenResponse struct {
 	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 }
@@ -1244,7 +1524,7 @@ type ServiceTokenResponse struct {
 func (m *ServiceTokenResponse) Reset()      { *m = ServiceTokenResponse{} }
 func (*ServiceTokenResponse) ProtoMessage() {}
 func (*ServiceTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{19}
+	return fileDescriptor_0c5120591600887d, []int{21}
 }
 func (m *ServiceTokenResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1287,7 +1567,7 @@ type ListServicesRequest struct {
 func (m *ListServicesRequest) Reset()      { *m = ListServicesRequest{} }
 func (*ListServicesRequest) ProtoMessage() {}
 func (*ListServicesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{20}
+	return fileDescriptor_0c5120591600887d, []int{22}
 }
 func (m *ListServicesRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1330,7 +1610,7 @@ type ListServicesResponse struct {
 func (m *ListServicesResponse) Reset()      { *m = ListServicesResponse{} }
 func (*ListServicesResponse) ProtoMessage() {}
 func (*ListServicesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{21}
+	return fileDescriptor_0c5120591600887d, []int{23}
 }
 func (m *ListServicesResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1372,12 +1652,13 @@ type Service struct {
 	Type     string    `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
 	Labels   *LabelSet `protobuf:"bytes,4,opt,name=labels,proto3" json:"labels,omitempty"`
 	Metadata []*KVPair `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty"`
+	Account  *Account  `protobuf:"bytes,6,opt,name=account,proto3" json:"account,omitempty"`
 }
 
 func (m *Service) Reset()      { *m = Service{} }
 func (*Service) ProtoMessage() {}
 func (*Service) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{22}
+	return fileDescriptor_0c5120591600887d, []int{24}
 }
 func (m *Service) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1441,6 +1722,80 @@ func (m *Service) GetMetadata() []*KVPair {
 	return nil
 }
 
+func (m *Service) GetAccount() *Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+type StreamServicesRequest struct {
+	Namespace string    `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Type      string    `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Labels    *LabelSet `protobuf:"bytes,3,opt,name=labels,proto3" json:"labels,omitempty"`
+	BatchSize int32     `protobuf:"varint,4,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+}
+
+func (m *StreamServicesRequest) Reset()      { *m = StreamServicesRequest{} }
+func (*StreamServicesRequest) ProtoMessage() {}
+func (*StreamServicesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{25}
+}
+func (m *StreamServicesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StreamServicesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StreamServicesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StreamServicesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamServicesRequest.Merge(m, src)
+}
+func (m *StreamServicesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *StreamServicesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamServicesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamServicesRequest proto.InternalMessageInfo
+
+func (m *StreamServicesRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *StreamServicesRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *StreamServicesRequest) GetLabels() *LabelSet {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *StreamServicesRequest) GetBatchSize() int32 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
 type AddAccountRequest struct {
 	Account *Account        `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
 	Limits  *Account_Limits `protobuf:"bytes,2,opt,name=limits,proto3" json:"limits,omitempty"`
@@ -1449,7 +1804,7 @@ type AddAccountRequest struct {
 func (m *AddAccountRequest) Reset()      { *m = AddAccountRequest{} }
 func (*AddAccountRequest) ProtoMessage() {}
 func (*AddAccountRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{23}
+	return fileDescriptor_0c5120591600887d, []int{26}
 }
 func (m *AddAccountRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1492,23 +1847,22 @@ func (m *AddAccountRequest) GetLimits() *Account_Limits {
 	return nil
 }
 
-type AddLabelLinkRequest struct {
-	Labels  *LabelSet `protobuf:"bytes,1,opt,name=labels,proto3" json:"labels,omitempty"`
-	Account *Account  `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
-	Target  *LabelSet `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+type CreateAccountRequest struct {
+	Account *Account        `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Limits  *Account_Limits `protobuf:"bytes,2,opt,name=limits,proto3" json:"limits,omitempty"`
 }
 
-func (m *AddLabelLinkRequest) Reset()      { *m = AddLabelLinkRequest{} }
-func (*AddLabelLinkRequest) ProtoMessage() {}
-func (*AddLabelLinkRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{24}
+func (m *CreateAccountRequest) Reset()      { *m = CreateAccountRequest{} }
+func (*CreateAccountRequest) ProtoMessage() {}
+func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{27}
 }
-func (m *AddLabelLinkRequest) XXX_Unmarshal(b []byte) error {
+func (m *CreateAccountRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *AddLabelLinkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *CreateAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_AddLabelLinkRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_CreateAccountRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1518,7 +1872,110 @@ func (m *AddLabelLinkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *AddLabelLinkRequest) XXX_Merge(src proto.Message) {
+func (m *CreateAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAccountRequest.Merge(m, src)
+}
+func (m *CreateAccountRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAccountRequest proto.InternalMessageInfo
+
+func (m *CreateAccountRequest) GetAccount() *Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func (m *CreateAccountRequest) GetLimits() *Account_Limits {
+	if m != nil {
+		return m.Limits
+	}
+	return nil
+}
+
+type CreateAccountResponse struct {
+	Account *Account        `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Limits  *Account_Limits `protobuf:"bytes,2,opt,name=limits,proto3" json:"limits,omitempty"`
+}
+
+func (m *CreateAccountResponse) Reset()      { *m = CreateAccountResponse{} }
+func (*CreateAccountResponse) ProtoMessage() {}
+func (*CreateAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{28}
+}
+func (m *CreateAccountResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CreateAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreateAccountResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CreateAccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAccountResponse.Merge(m, src)
+}
+func (m *CreateAccountResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateAccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAccountResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAccountResponse proto.InternalMessageInfo
+
+func (m *CreateAccountResponse) GetAccount() *Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func (m *CreateAccountResponse) GetLimits() *Account_Limits {
+	if m != nil {
+		return m.Limits
+	}
+	return nil
+}
+
+type AddLabelLinkRequest struct {
+	Labels  *LabelSet `protobuf:"bytes,1,opt,name=labels,proto3" json:"labels,omitempty"`
+	Account *Account  `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	Target  *LabelSet `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+}
+
+func (m *AddLabelLinkRequest) Reset()      { *m = AddLabelLinkRequest{} }
+func (*AddLabelLinkRequest) ProtoMessage() {}
+func (*AddLabelLinkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{29}
+}
+func (m *AddLabelLinkRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AddLabelLinkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AddLabelLinkRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AddLabelLinkRequest) XXX_Merge(src proto.Message) {
 	xxx_messageInfo_AddLabelLinkRequest.Merge(m, src)
 }
 func (m *AddLabelLinkRequest) XXX_Size() int {
@@ -1557,7 +2014,7 @@ type Noop struct {
 func (m *Noop) Reset()      { *m = Noop{} }
 func (*Noop) ProtoMessage() {}
 func (*Noop) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{25}
+	return fileDescriptor_0c5120591600887d, []int{30}
 }
 func (m *Noop) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1594,7 +2051,7 @@ type RemoveLabelLinkRequest struct {
 func (m *RemoveLabelLinkRequest) Reset()      { *m = RemoveLabelLinkRequest{} }
 func (*RemoveLabelLinkRequest) ProtoMessage() {}
 func (*RemoveLabelLinkRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{26}
+	return fileDescriptor_0c5120591600887d, []int{31}
 }
 func (m *RemoveLabelLinkRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1646,7 +2103,7 @@ type CreateTokenRequest struct {
 func (m *CreateTokenRequest) Reset()      { *m = CreateTokenRequest{} }
 func (*CreateTokenRequest) ProtoMessage() {}
 func (*CreateTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{27}
+	return fileDescriptor_0c5120591600887d, []int{32}
 }
 func (m *CreateTokenRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1698,12 +2155,20 @@ func (m *CreateTokenRequest) GetValidDuration() *Timestamp {
 
 type CreateTokenResponse struct {
 	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// What the token grants, as it was minted, so callers can check and record
+	// it without decoding the token.
+	Role         TokenRole         `protobuf:"varint,2,opt,name=role,proto3,enum=pb.TokenRole" json:"role,omitempty"`
+	TokenId      *ULID             `protobuf:"bytes,3,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	Account      *Account          `protobuf:"bytes,4,opt,name=account,proto3" json:"account,omitempty"`
+	Capabilities []TokenCapability `protobuf:"bytes,5,rep,name=capabilities,proto3" json:"capabilities"`
+	// Unset if the token doesn't expire.
+	ValidUntil *Timestamp `protobuf:"bytes,6,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
 }
 
 func (m *CreateTokenResponse) Reset()      { *m = CreateTokenResponse{} }
 func (*CreateTokenResponse) ProtoMessage() {}
 func (*CreateTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{28}
+	return fileDescriptor_0c5120591600887d, []int{33}
 }
 func (m *CreateTokenResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1739,6 +2204,191 @@ func (m *CreateTokenResponse) GetToken() string {
 	return ""
 }
 
+func (m *CreateTokenResponse) GetRole() TokenRole {
+	if m != nil {
+		return m.Role
+	}
+	return AGENT
+}
+
+func (m *CreateTokenResponse) GetTokenId() *ULID {
+	if m != nil {
+		return m.TokenId
+	}
+	return nil
+}
+
+func (m *CreateTokenResponse) GetAccount() *Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func (m *CreateTokenResponse) GetCapabilities() []TokenCapability {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func (m *CreateTokenResponse) GetValidUntil() *Timestamp {
+	if m != nil {
+		return m.ValidUntil
+	}
+	return nil
+}
+
+type CreateTokensRequest struct {
+	Tokens []*CreateTokenRequest `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	// If set, any token that can't be created fails the whole batch, and an
+	// invalid request fails it before any accounts are created. Otherwise each
+	// failure is reported in its own result.
+	AllOrNothing bool `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
+}
+
+func (m *CreateTokensRequest) Reset()      { *m = CreateTokensRequest{} }
+func (*CreateTokensRequest) ProtoMessage() {}
+func (*CreateTokensRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{34}
+}
+func (m *CreateTokensRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CreateTokensRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreateTokensRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CreateTokensRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateTokensRequest.Merge(m, src)
+}
+func (m *CreateTokensRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateTokensRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateTokensRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateTokensRequest proto.InternalMessageInfo
+
+func (m *CreateTokensRequest) GetTokens() []*CreateTokenRequest {
+	if m != nil {
+		return m.Tokens
+	}
+	return nil
+}
+
+func (m *CreateTokensRequest) GetAllOrNothing() bool {
+	if m != nil {
+		return m.AllOrNothing
+	}
+	return false
+}
+
+type CreateTokenResult struct {
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Set instead of token when this token couldn't be created.
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *CreateTokenResult) Reset()      { *m = CreateTokenResult{} }
+func (*CreateTokenResult) ProtoMessage() {}
+func (*CreateTokenResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{35}
+}
+func (m *CreateTokenResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CreateTokenResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreateTokenResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CreateTokenResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateTokenResult.Merge(m, src)
+}
+func (m *CreateTokenResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateTokenResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateTokenResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateTokenResult proto.InternalMessageInfo
+
+func (m *CreateTokenResult) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *CreateTokenResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type CreateTokensResponse struct {
+	// One result per requested token, in the same order as the request.
+	Results []*CreateTokenResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (m *CreateTokensResponse) Reset()      { *m = CreateTokensResponse{} }
+func (*CreateTokensResponse) ProtoMessage() {}
+func (*CreateTokensResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{36}
+}
+func (m *CreateTokensResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CreateTokensResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreateTokensResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CreateTokensResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateTokensResponse.Merge(m, src)
+}
+func (m *CreateTokensResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateTokensResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateTokensResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateTokensResponse proto.InternalMessageInfo
+
+func (m *CreateTokensResponse) GetResults() []*CreateTokenResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
 type ControlRegister struct {
 	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
 }
@@ -1746,7 +2396,7 @@ type ControlRegister struct {
 func (m *ControlRegister) Reset()      { *m = ControlRegister{} }
 func (*ControlRegister) ProtoMessage() {}
 func (*ControlRegister) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{29}
+	return fileDescriptor_0c5120591600887d, []int{37}
 }
 func (m *ControlRegister) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1789,7 +2439,7 @@ type ControlToken struct {
 func (m *ControlToken) Reset()      { *m = ControlToken{} }
 func (*ControlToken) ProtoMessage() {}
 func (*ControlToken) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{30}
+	return fileDescriptor_0c5120591600887d, []int{38}
 }
 func (m *ControlToken) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1832,7 +2482,7 @@ type TokenInfo struct {
 func (m *TokenInfo) Reset()      { *m = TokenInfo{} }
 func (*TokenInfo) ProtoMessage() {}
 func (*TokenInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{31}
+	return fileDescriptor_0c5120591600887d, []int{39}
 }
 func (m *TokenInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1876,7 +2526,7 @@ type ListAccountsRequest struct {
 func (m *ListAccountsRequest) Reset()      { *m = ListAccountsRequest{} }
 func (*ListAccountsRequest) ProtoMessage() {}
 func (*ListAccountsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{32}
+	return fileDescriptor_0c5120591600887d, []int{40}
 }
 func (m *ListAccountsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1927,7 +2577,7 @@ type ListAccountsResponse struct {
 func (m *ListAccountsResponse) Reset()      { *m = ListAccountsResponse{} }
 func (*ListAccountsResponse) ProtoMessage() {}
 func (*ListAccountsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{33}
+	return fileDescriptor_0c5120591600887d, []int{41}
 }
 func (m *ListAccountsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1970,737 +2620,1696 @@ func (m *ListAccountsResponse) GetNextMarker() []byte {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*ServiceRequest)(nil), "pb.ServiceRequest")
-	proto.RegisterType((*ServiceResponse)(nil), "pb.ServiceResponse")
-	proto.RegisterType((*LabelLink)(nil), "pb.LabelLink")
-	proto.RegisterType((*LabelLinks)(nil), "pb.LabelLinks")
-	proto.RegisterType((*ServiceRoute)(nil), "pb.ServiceRoute")
-	proto.RegisterType((*AccountServices)(nil), "pb.AccountServices")
-	proto.RegisterType((*ActivityEntry)(nil), "pb.ActivityEntry")
-	proto.RegisterType((*ConfigRequest)(nil), "pb.ConfigRequest")
-	proto.RegisterType((*ConfigResponse)(nil), "pb.ConfigResponse")
-	proto.RegisterType((*CentralActivity)(nil), "pb.CentralActivity")
-	proto.RegisterType((*HubActivity)(nil), "pb.HubActivity")
-	proto.RegisterType((*HubActivity_HubRegistration)(nil), "pb.HubActivity.HubRegistration")
-	proto.RegisterType((*HubActivity_HubStats)(nil), "pb.HubActivity.HubStats")
-	proto.RegisterType((*HubInfo)(nil), "pb.HubInfo")
-	proto.RegisterType((*ListOfHubs)(nil), "pb.ListOfHubs")
-	proto.RegisterType((*HubSync)(nil), "pb.HubSync")
-	proto.RegisterType((*HubSyncResponse)(nil), "pb.HubSyncResponse")
-	proto.RegisterType((*HubRegisterRequest)(nil), "pb.HubRegisterRequest")
-	proto.RegisterType((*HubRegisterResponse)(nil), "pb.HubRegisterResponse")
-	proto.RegisterType((*HubDisconnectRequest)(nil), "pb.HubDisconnectRequest")
-	proto.RegisterType((*ServiceTokenRequest)(nil), "pb.ServiceTokenRequest")
-	proto.RegisterType((*ServiceTokenResponse)(nil), "pb.ServiceTokenResponse")
-	proto.RegisterType((*ListServicesRequest)(nil), "pb.ListServicesRequest")
-	proto.RegisterType((*ListServicesResponse)(nil), "pb.ListServicesResponse")
-	proto.RegisterType((*Service)(nil), "pb.Service")
-	proto.RegisterType((*AddAccountRequest)(nil), "pb.AddAccountRequest")
-	proto.RegisterType((*AddLabelLinkRequest)(nil), "pb.AddLabelLinkRequest")
-	proto.RegisterType((*Noop)(nil), "pb.Noop")
-	proto.RegisterType((*RemoveLabelLinkRequest)(nil), "pb.RemoveLabelLinkRequest")
-	proto.RegisterType((*CreateTokenRequest)(nil), "pb.CreateTokenRequest")
-	proto.RegisterType((*CreateTokenResponse)(nil), "pb.CreateTokenResponse")
-	proto.RegisterType((*ControlRegister)(nil), "pb.ControlRegister")
-	proto.RegisterType((*ControlToken)(nil), "pb.ControlToken")
-	proto.RegisterType((*TokenInfo)(nil), "pb.TokenInfo")
-	proto.RegisterType((*ListAccountsRequest)(nil), "pb.ListAccountsRequest")
-	proto.RegisterType((*ListAccountsResponse)(nil), "pb.ListAccountsResponse")
+type ManagementClient struct {
+	Id        *ULID      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Namespace string     `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	CreatedAt *Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 }
 
-func init() { proto.RegisterFile("control.proto", fileDescriptor_0c5120591600887d) }
-
-var fileDescriptor_0c5120591600887d = []byte{
-	// 1828 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0x4b, 0x73, 0x1b, 0xc7,
-	0x11, 0xc6, 0x02, 0x20, 0x08, 0x34, 0x00, 0x42, 0x1c, 0xd0, 0x12, 0x02, 0x27, 0x90, 0x32, 0x56,
-	0x2c, 0xc5, 0xb6, 0x28, 0x87, 0x54, 0x94, 0x47, 0x29, 0x0f, 0x08, 0x8a, 0x4d, 0x46, 0xb4, 0xe3,
-	0x5a, 0xca, 0xbe, 0x6e, 0x66, 0x77, 0x87, 0xe0, 0x16, 0x17, 0xbb, 0xc8, 0xce, 0x2c, 0x19, 0xe4,
-	0x90, 0x4a, 0xe5, 0x94, 0xdc, 0x72, 0xc8, 0x25, 0xb9, 0xe5, 0x96, 0xca, 0x21, 0xe5, 0x9f, 0xe1,
-	0x5b, 0x74, 0x4a, 0xf9, 0x94, 0x8a, 0xa8, 0x4b, 0x8e, 0xfe, 0x09, 0xa9, 0x79, 0xec, 0x93, 0x10,
-	0x24, 0xab, 0x4a, 0x55, 0xbe, 0x61, 0xba, 0xbf, 0xe9, 0xe9, 0x9e, 0xe9, 0xfe, 0xba, 0x17, 0xd0,
-	0x75, 0xc2, 0x80, 0x47, 0xa1, 0xbf, 0x3d, 0x8f, 0x42, 0x1e, 0xa2, 0xea, 0xdc, 0x1e, 0xf6, 0x5c,
-	0x7a, 0xc4, 0x6e, 0x4f, 0xc3, 0x69, 0xa8, 0x84, 0xc3, 0xe6, 0xc9, 0xa9, 0xfe, 0xd5, 0xf6, 0x89,
-	0x4d, 0x35, 0x76, 0xd8, 0x25, 0x8e, 0x13, 0xc6, 0x01, 0xd7, 0x4b, 0x88, 0x7d, 0xcf, 0x4d, 0x70,
-	0x3c, 0x3c, 0xa1, 0x81, 0x5e, 0xf4, 0xb8, 0x37, 0xa3, 0x8c, 0x93, 0xd9, 0x3c, 0x41, 0x1e, 0xf9,
-	0xe1, 0x59, 0x62, 0x24, 0xa0, 0xfc, 0x2c, 0x8c, 0x4e, 0xd4, 0x12, 0xff, 0xcb, 0x80, 0x8d, 0x43,
-	0x1a, 0x9d, 0x7a, 0x0e, 0x35, 0xe9, 0xaf, 0x62, 0xca, 0x38, 0xfa, 0x16, 0xac, 0xeb, 0x83, 0x06,
-	0xc6, 0x35, 0xe3, 0x66, 0x7b, 0xa7, 0xbd, 0x3d, 0xb7, 0xb7, 0xc7, 0x4a, 0x64, 0x26, 0x3a, 0x34,
-	0x84, 0xda, 0x71, 0x6c, 0x0f, 0xaa, 0x12, 0xd2, 0x14, 0x90, 0x8f, 0x0f, 0xf6, 0x1f, 0x98, 0x42,
-	0x88, 0x06, 0x50, 0xf5, 0xdc, 0x41, 0xad, 0xa4, 0xaa, 0x7a, 0x2e, 0x42, 0x50, 0xe7, 0x8b, 0x39,
-	0x1d, 0xd4, 0xaf, 0x19, 0x37, 0x5b, 0xa6, 0xfc, 0x8d, 0xae, 0x43, 0x43, 0x86, 0xc9, 0x06, 0x6b,
-	0x72, 0x47, 0x47, 0xec, 0x38, 0x10, 0x92, 0x43, 0xca, 0x4d, 0xad, 0x43, 0x6f, 0x42, 0x73, 0x46,
-	0x39, 0x71, 0x09, 0x27, 0x83, 0xc6, 0xb5, 0xda, 0xcd, 0xf6, 0x0e, 0x08, 0xdc, 0xc3, 0x4f, 0x3e,
-	0x22, 0x5e, 0x64, 0xa6, 0x3a, 0xbc, 0x09, 0xbd, 0x34, 0x20, 0x36, 0x0f, 0x03, 0x46, 0xf1, 0x3f,
-	0x0c, 0x68, 0x49, 0x7b, 0x07, 0x5e, 0x70, 0xf2, 0xa2, 0xf1, 0x65, 0x5e, 0x55, 0x57, 0x78, 0x75,
-	0x1d, 0x1a, 0x9c, 0x44, 0x53, 0xca, 0x75, 0xb4, 0x25, 0x94, 0xd2, 0xa1, 0xb7, 0xa0, 0xe1, 0x7b,
-	0x33, 0x8f, 0x33, 0x19, 0x77, 0x7b, 0x07, 0xe5, 0x4e, 0xdc, 0x3e, 0x90, 0x1a, 0x53, 0x23, 0xf0,
-	0x3d, 0x80, 0xd4, 0x57, 0x86, 0xb6, 0x41, 0xa5, 0x80, 0xe5, 0x8b, 0xe5, 0xc0, 0x90, 0x81, 0x77,
-	0xd3, 0x43, 0x04, 0xc8, 0x04, 0x3f, 0xc5, 0xe3, 0xdf, 0x42, 0x27, 0x89, 0x3e, 0x8c, 0x39, 0x4d,
-	0x5e, 0xc9, 0x78, 0xf6, 0x2b, 0x55, 0x57, 0xbc, 0x52, 0x6d, 0xe9, 0x2b, 0xd5, 0x9f, 0x7d, 0x1f,
-	0xf8, 0x08, 0x7a, 0x3a, 0x2e, 0xed, 0x06, 0x7b, 0xd1, 0xfb, 0x7e, 0x07, 0x9a, 0x4c, 0x6f, 0x19,
-	0x54, 0x65, 0x98, 0x97, 0x04, 0x2e, 0x1f, 0x8d, 0x99, 0x22, 0x30, 0x87, 0xee, 0xd8, 0xe1, 0xde,
-	0xa9, 0xc7, 0x17, 0x3f, 0x0b, 0x78, 0xb4, 0x40, 0x77, 0xa0, 0x1d, 0x09, 0x8c, 0x45, 0x5c, 0x97,
-	0xba, 0xfa, 0xa4, 0x7e, 0xee, 0xa4, 0xc4, 0x1f, 0x13, 0x24, 0x6e, 0x2c, 0x60, 0xe8, 0x16, 0x74,
-	0xd5, 0xae, 0x88, 0xce, 0xc2, 0x53, 0x7a, 0xf1, 0x36, 0x3a, 0x52, 0x6d, 0x2a, 0x2d, 0xfe, 0xb3,
-	0x01, 0xdd, 0x49, 0x18, 0x1c, 0x79, 0xd3, 0xac, 0x58, 0x5a, 0x8c, 0x13, 0xdb, 0xa7, 0x96, 0xe7,
-	0x5e, 0xb8, 0xe5, 0xa6, 0x52, 0xed, 0xbb, 0xe8, 0xdb, 0xd0, 0xf6, 0x02, 0xc6, 0x49, 0xe0, 0x48,
-	0x60, 0xf9, 0x14, 0x48, 0x94, 0xfb, 0x2e, 0xfa, 0x0e, 0xb4, 0xfc, 0xd0, 0x21, 0xdc, 0x0b, 0x03,
-	0x36, 0xa8, 0xc9, 0x8b, 0x90, 0x61, 0x7c, 0xa8, 0xea, 0xf6, 0x40, 0xeb, 0xcc, 0x0c, 0x85, 0x9f,
-	0x1a, 0xb0, 0x91, 0xb8, 0xa5, 0x52, 0x1e, 0x5d, 0x81, 0x75, 0xee, 0x33, 0xeb, 0x84, 0x2e, 0xa4,
-	0x57, 0x1d, 0xb3, 0xc1, 0x7d, 0xf6, 0x90, 0x2e, 0xd0, 0xd7, 0xa0, 0x29, 0x14, 0x0e, 0x8d, 0xb8,
-	0x74, 0xa3, 0x63, 0x0a, 0xe0, 0x84, 0x46, 0x1c, 0xbd, 0x0e, 0x2d, 0x49, 0x23, 0xd6, 0x3c, 0xb6,
-	0xe5, 0xd3, 0x77, 0xcc, 0xa6, 0x14, 0x7c, 0x14, 0xdb, 0x08, 0x43, 0x97, 0xed, 0x5a, 0xc4, 0x71,
-	0x28, 0x53, 0x66, 0x55, 0x05, 0xb7, 0xd9, 0xee, 0x58, 0xca, 0x84, 0x6d, 0x85, 0x61, 0xd4, 0x89,
-	0x28, 0x97, 0x98, 0xb5, 0x04, 0x73, 0x28, 0x65, 0x02, 0xf3, 0x3a, 0xb4, 0xd8, 0xae, 0x65, 0xc7,
-	0xce, 0x09, 0xe5, 0x83, 0x86, 0xd4, 0x37, 0xd9, 0xee, 0x7d, 0xb9, 0x16, 0x4a, 0x6f, 0x46, 0xa6,
-	0xd4, 0xe2, 0x64, 0x3a, 0x58, 0x57, 0x4a, 0x29, 0x78, 0x44, 0xa6, 0xf8, 0x9f, 0x06, 0xf4, 0x26,
-	0x34, 0xe0, 0x11, 0xf1, 0x93, 0xa7, 0x47, 0x3f, 0x86, 0x4b, 0x3a, 0x7f, 0xac, 0x34, 0x79, 0x8c,
-	0xec, 0xce, 0xca, 0x4f, 0xdf, 0x23, 0xa5, 0xdc, 0x7c, 0x03, 0xba, 0x91, 0x7a, 0x49, 0x8b, 0x71,
-	0xc2, 0x55, 0xad, 0x37, 0xcd, 0x8e, 0x16, 0x1e, 0x0a, 0x19, 0xba, 0x0b, 0xbd, 0x80, 0x9e, 0x59,
-	0xf9, 0x3a, 0x54, 0xc5, 0xbe, 0x51, 0xa8, 0x43, 0x66, 0x76, 0x03, 0x7a, 0x96, 0x2d, 0xf1, 0xef,
-	0xd7, 0xa0, 0xbd, 0x17, 0xdb, 0xa9, 0xb3, 0xdf, 0x87, 0xf5, 0xe3, 0xd8, 0xb6, 0x22, 0x3a, 0xd5,
-	0x99, 0x72, 0x55, 0xec, 0xcf, 0x21, 0xc4, 0x6f, 0x93, 0x4e, 0x3d, 0xc6, 0x23, 0xf5, 0xc6, 0x8d,
-	0x63, 0x29, 0x40, 0x6f, 0xc2, 0x3a, 0xa3, 0x01, 0xb7, 0x08, 0xd7, 0xa9, 0x23, 0x19, 0xe0, 0x51,
-	0x42, 0xf3, 0x66, 0x43, 0x68, 0xc7, 0x1c, 0x6d, 0xc3, 0x9a, 0x0a, 0x43, 0xf9, 0x37, 0x58, 0x62,
-	0x5f, 0x86, 0x64, 0x2a, 0x18, 0xc2, 0x50, 0x17, 0xad, 0x61, 0x50, 0x97, 0x57, 0x26, 0xc3, 0x79,
-	0xcf, 0x0f, 0xcf, 0x4c, 0xea, 0x84, 0x91, 0x6b, 0x4a, 0xdd, 0xf0, 0x8f, 0x06, 0xf4, 0x4a, 0x7e,
-	0xad, 0x64, 0x95, 0x1b, 0x00, 0xba, 0x22, 0x96, 0xb5, 0x07, 0x5d, 0x2d, 0x7b, 0xb1, 0xfd, 0x12,
-	0x89, 0x3e, 0xfc, 0xb4, 0x0a, 0xcd, 0x24, 0x06, 0xf4, 0x36, 0x6c, 0x92, 0xa9, 0xb8, 0x15, 0x27,
-	0x0c, 0x02, 0xea, 0x28, 0x3b, 0xc2, 0xa5, 0x9a, 0x79, 0x49, 0x2a, 0x26, 0x99, 0x5c, 0x3c, 0xb4,
-	0x7e, 0x7b, 0x66, 0x31, 0x4a, 0x03, 0xe9, 0x58, 0xcd, 0xec, 0x24, 0xc2, 0x43, 0x4a, 0x03, 0x74,
-	0x03, 0x7a, 0x29, 0xc8, 0x21, 0xce, 0x31, 0x55, 0x3d, 0xac, 0x66, 0x6e, 0x24, 0xe2, 0x89, 0x94,
-	0xa2, 0x6f, 0x42, 0x47, 0xe9, 0x2d, 0x7b, 0xc1, 0xa9, 0x62, 0xc4, 0x9a, 0xd9, 0x56, 0xb2, 0xfb,
-	0x42, 0x84, 0x26, 0x70, 0xd9, 0x27, 0x22, 0xad, 0x62, 0x59, 0x1e, 0x47, 0xb1, 0x6f, 0xc5, 0x73,
-	0x97, 0x70, 0xaa, 0x9b, 0x5c, 0xe9, 0x05, 0xb7, 0x04, 0xf8, 0x30, 0xc5, 0x7e, 0x2c, 0xa1, 0x68,
-	0x0c, 0xaf, 0x49, 0x23, 0x84, 0x73, 0x3a, 0x9b, 0x73, 0xea, 0x26, 0x36, 0x1a, 0xcb, 0x6c, 0xf4,
-	0x05, 0x76, 0x9c, 0x40, 0x95, 0x09, 0xfc, 0x09, 0xac, 0xef, 0xc5, 0xf6, 0x7e, 0x70, 0x14, 0x6a,
-	0xbe, 0x37, 0x96, 0xf0, 0x7d, 0xe1, 0x29, 0xaa, 0x2f, 0xc4, 0x39, 0xb7, 0x00, 0x0e, 0x3c, 0xc6,
-	0x7f, 0x71, 0xb4, 0x17, 0xdb, 0x0c, 0x5d, 0x85, 0xfa, 0x71, 0x6c, 0x27, 0xb5, 0xd7, 0xd6, 0x79,
-	0x27, 0x4e, 0x35, 0xa5, 0x02, 0xff, 0x46, 0xba, 0x71, 0xb8, 0x08, 0x9c, 0x15, 0x6e, 0x14, 0xc8,
-	0xb4, 0xfa, 0x4c, 0x32, 0xdd, 0xce, 0x75, 0x0a, 0x95, 0x37, 0x28, 0xdf, 0x29, 0x54, 0xe9, 0xe6,
-	0x7a, 0xc5, 0x5d, 0x99, 0xc0, 0xe2, 0xec, 0x94, 0x1e, 0xdf, 0x80, 0xae, 0x56, 0x5b, 0x59, 0x67,
-	0xaa, 0x99, 0x1d, 0x2d, 0x9c, 0x08, 0x19, 0xfe, 0x8b, 0x01, 0x28, 0xcd, 0x7c, 0x1a, 0x7d, 0xa5,
-	0x28, 0xff, 0x7d, 0xe8, 0x17, 0x5c, 0xd3, 0x71, 0xbd, 0x0b, 0x1d, 0x3d, 0x5f, 0x5a, 0x62, 0x08,
-	0xd4, 0xee, 0x95, 0xf2, 0xa4, 0xad, 0x21, 0x42, 0x82, 0x8f, 0x61, 0x6b, 0x2f, 0xb6, 0x1f, 0x78,
-	0x4c, 0x57, 0xd1, 0x2b, 0x8b, 0x12, 0xef, 0x42, 0x5f, 0x3f, 0xd1, 0x23, 0xd1, 0x54, 0x92, 0x83,
-	0xbe, 0x0e, 0xad, 0x80, 0xcc, 0x28, 0x9b, 0x13, 0x47, 0xf9, 0xdb, 0x32, 0x33, 0x01, 0x7e, 0x07,
-	0xb6, 0x8a, 0x9b, 0x74, 0xa0, 0x5b, 0xb0, 0x26, 0x5b, 0x93, 0xde, 0xa1, 0x16, 0xf8, 0x1e, 0xf4,
-	0x45, 0x52, 0xa6, 0x7c, 0xff, 0xa5, 0x26, 0x5a, 0xfc, 0x13, 0xd8, 0x2a, 0xee, 0xd6, 0x67, 0xdd,
-	0xc8, 0xe5, 0x5b, 0x2e, 0xc1, 0x93, 0x7c, 0xcb, 0x12, 0xed, 0x6f, 0x06, 0xac, 0x6b, 0xe9, 0x8a,
-	0x2c, 0x5f, 0x35, 0x38, 0xbf, 0xf4, 0xe0, 0x55, 0x18, 0x8f, 0xd7, 0x56, 0x8c, 0xc7, 0x47, 0xb0,
-	0x39, 0x76, 0xdd, 0x24, 0xf6, 0x2f, 0x37, 0xf2, 0x67, 0x63, 0x6c, 0xf5, 0xb9, 0x63, 0xec, 0x1f,
-	0x0c, 0xe8, 0x8f, 0x5d, 0x37, 0x9b, 0x52, 0xf5, 0x51, 0x59, 0x34, 0xc6, 0x8a, 0x68, 0x72, 0x0e,
-	0x55, 0x57, 0xcf, 0xe8, 0xcf, 0x9f, 0xbe, 0x71, 0x03, 0xea, 0x1f, 0x86, 0xe1, 0x1c, 0x53, 0xb8,
-	0xac, 0x06, 0xb9, 0x57, 0xea, 0x14, 0xfe, 0xd4, 0x00, 0x34, 0x89, 0x28, 0xe1, 0xc5, 0x3c, 0x7f,
-	0xc1, 0x3b, 0xfe, 0x91, 0x68, 0x2d, 0x73, 0x62, 0x7b, 0xbe, 0xc7, 0x3d, 0x5a, 0x60, 0x63, 0x69,
-	0x6e, 0x92, 0x28, 0x17, 0xf7, 0xeb, 0x9f, 0xfd, 0xe7, 0x6a, 0xc5, 0x2c, 0xc0, 0xd1, 0x1d, 0xd8,
-	0x38, 0x25, 0xbe, 0xe7, 0x5a, 0x6e, 0xac, 0x7a, 0xb5, 0xbe, 0x99, 0x12, 0x05, 0x74, 0x25, 0xe8,
-	0x81, 0xc6, 0xe0, 0xb7, 0xa1, 0x5f, 0xf0, 0x78, 0x65, 0x91, 0xdd, 0x86, 0xde, 0x44, 0x11, 0x48,
-	0x42, 0x3f, 0xcf, 0xa9, 0xe1, 0xeb, 0xd0, 0xd1, 0x1b, 0xa4, 0xf9, 0x67, 0x98, 0x7d, 0x0b, 0x5a,
-	0x52, 0x2d, 0x5b, 0xd5, 0x37, 0x00, 0xe6, 0xb1, 0xed, 0x7b, 0x4e, 0x6e, 0x82, 0x6d, 0x29, 0xc9,
-	0x43, 0xba, 0xc0, 0x13, 0x55, 0xe7, 0xfa, 0xf2, 0xd2, 0x3a, 0xdf, 0x82, 0x35, 0x99, 0x7d, 0x72,
-	0xc3, 0x9a, 0xa9, 0x16, 0xe8, 0x32, 0x34, 0x66, 0x24, 0x3a, 0xa1, 0x91, 0x9e, 0x77, 0xf5, 0x0a,
-	0xff, 0x52, 0x95, 0x7b, 0x66, 0x24, 0x2b, 0xf7, 0xa4, 0xdd, 0xe7, 0xcb, 0x3d, 0x79, 0xa9, 0x54,
-	0x89, 0xae, 0x42, 0x3b, 0xa0, 0xbf, 0xe6, 0x56, 0xc1, 0x3a, 0x08, 0xd1, 0x07, 0x52, 0xb2, 0xf3,
-	0xd7, 0x7a, 0x7a, 0x55, 0xe9, 0xc4, 0xf9, 0x3d, 0x80, 0xb1, 0xeb, 0x26, 0x2c, 0xb1, 0xa4, 0x71,
-	0x0d, 0xfb, 0x05, 0x99, 0xfe, 0x84, 0xad, 0xa0, 0x1f, 0x42, 0x57, 0x65, 0xef, 0x4b, 0xec, 0x9d,
-	0x40, 0x27, 0xcf, 0x6c, 0xe8, 0x8a, 0xcc, 0xef, 0x8b, 0x4c, 0x39, 0x1c, 0x5c, 0x54, 0xa4, 0x46,
-	0xee, 0x42, 0xfb, 0x3d, 0xca, 0x9d, 0x63, 0xf5, 0xa5, 0x81, 0x36, 0x05, 0xb4, 0xf0, 0x31, 0x34,
-	0x44, 0x79, 0x51, 0xba, 0xef, 0x1e, 0x6c, 0x1c, 0xf2, 0x88, 0x92, 0x59, 0x3a, 0x08, 0xf7, 0x4a,
-	0x73, 0xa9, 0x72, 0xbb, 0x34, 0xdb, 0xe3, 0xca, 0x4d, 0xe3, 0x5d, 0x03, 0xdd, 0x82, 0x75, 0xd1,
-	0xb9, 0xc5, 0xc0, 0x98, 0x8c, 0x15, 0x62, 0xad, 0xb6, 0x94, 0xda, 0x3a, 0xae, 0xa0, 0xef, 0x42,
-	0xb7, 0xd0, 0xce, 0x50, 0x32, 0x03, 0x5f, 0xe8, 0x70, 0x43, 0x49, 0xbd, 0x92, 0x18, 0x2a, 0xa2,
-	0x38, 0xc7, 0xbe, 0x2f, 0x47, 0x99, 0x54, 0x3c, 0xdc, 0x48, 0x2e, 0x43, 0x0d, 0x39, 0xb8, 0x82,
-	0x7e, 0x0e, 0x7d, 0xbd, 0x3b, 0xdf, 0x94, 0xd4, 0x75, 0x2e, 0xe9, 0x6d, 0xea, 0x3a, 0x97, 0xf5,
-	0x2f, 0x5c, 0xd9, 0xf9, 0x77, 0x0d, 0x36, 0x75, 0x72, 0x7c, 0x40, 0x02, 0x32, 0xa5, 0x33, 0x1a,
-	0x70, 0xb4, 0x0b, 0xcd, 0xb4, 0xaa, 0xfa, 0xfa, 0x3a, 0xf3, 0xa5, 0x36, 0xbc, 0x94, 0x13, 0x4a,
-	0x93, 0xb8, 0x82, 0x6e, 0xcb, 0x9c, 0xd2, 0x09, 0x8a, 0x5e, 0x93, 0xd9, 0x5a, 0xe6, 0xf8, 0x42,
-	0xb8, 0xbb, 0xd0, 0xc9, 0x73, 0xb3, 0x0a, 0x60, 0x09, 0x5b, 0x17, 0x36, 0xfd, 0x00, 0x7a, 0x25,
-	0xfa, 0x44, 0x43, 0xa1, 0x5e, 0xce, 0xa9, 0x85, 0xad, 0x3f, 0x85, 0x76, 0x8e, 0x5f, 0xd0, 0x65,
-	0x19, 0xc3, 0x05, 0x8a, 0x1c, 0x5e, 0xb9, 0x20, 0x4f, 0xdf, 0xf5, 0x0e, 0x74, 0xf7, 0x19, 0x8b,
-	0xc5, 0x87, 0x83, 0xb2, 0x91, 0x3d, 0xd3, 0x8a, 0x5d, 0xdb, 0xb0, 0xf9, 0x3e, 0xe5, 0x8f, 0xf4,
-	0x37, 0xac, 0x22, 0x8f, 0xdc, 0xce, 0x6e, 0xca, 0xaa, 0x82, 0x74, 0xb2, 0x3a, 0x49, 0x28, 0x21,
-	0xab, 0x93, 0x12, 0xd3, 0x64, 0x75, 0x52, 0x66, 0x0f, 0x5c, 0xb9, 0x7f, 0xe7, 0xf1, 0x93, 0x51,
-	0xe5, 0xf3, 0x27, 0xa3, 0xca, 0x17, 0x4f, 0x46, 0xc6, 0xef, 0xce, 0x47, 0xc6, 0xdf, 0xcf, 0x47,
-	0xc6, 0x67, 0xe7, 0x23, 0xe3, 0xf1, 0xf9, 0xc8, 0xf8, 0xef, 0xf9, 0xc8, 0xf8, 0xdf, 0xf9, 0xa8,
-	0xf2, 0xc5, 0xf9, 0xc8, 0xf8, 0xd3, 0xd3, 0x51, 0xe5, 0xf1, 0xd3, 0x51, 0xe5, 0xf3, 0xa7, 0xa3,
-	0x8a, 0xdd, 0x90, 0xff, 0xc7, 0xed, 0xfe, 0x3f, 0x00, 0x00, 0xff, 0xff, 0x1d, 0xf9, 0x4e, 0x4d,
-	0x20, 0x14, 0x00, 0x00,
+func (m *ManagementClient) Reset()      { *m = ManagementClient{} }
+func (*ManagementClient) ProtoMessage() {}
+func (*ManagementClient) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{42}
 }
-
-func (this *ServiceRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
-
-	that1, ok := that.(*ServiceRequest)
-	if !ok {
-		that2, ok := that.(ServiceRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+func (m *ManagementClient) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ManagementClient) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ManagementClient.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Account.Equal(that1.Account) {
-		return false
-	}
-	if !this.Hub.Equal(that1.Hub) {
-		return false
-	}
-	if !this.Id.Equal(that1.Id) {
-		return false
-	}
-	if this.Type != that1.Type {
-		return false
-	}
-	if !this.Labels.Equal(that1.Labels) {
-		return false
-	}
-	if len(this.Metadata) != len(that1.Metadata) {
-		return false
+}
+func (m *ManagementClient) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ManagementClient.Merge(m, src)
+}
+func (m *ManagementClient) XXX_Size() int {
+	return m.Size()
+}
+func (m *ManagementClient) XXX_DiscardUnknown() {
+	xxx_messageInfo_ManagementClient.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ManagementClient proto.InternalMessageInfo
+
+func (m *ManagementClient) GetId() *ULID {
+	if m != nil {
+		return m.Id
 	}
-	for i := range this.Metadata {
-		if !this.Metadata[i].Equal(that1.Metadata[i]) {
-			return false
-		}
+	return nil
+}
+
+func (m *ManagementClient) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
 	}
-	return true
+	return ""
 }
-func (this *ServiceResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *ManagementClient) GetCreatedAt() *Timestamp {
+	if m != nil {
+		return m.CreatedAt
 	}
+	return nil
+}
 
-	that1, ok := that.(*ServiceResponse)
-	if !ok {
-		that2, ok := that.(ServiceResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type ListManagementClientsRequest struct {
+	Limit  int32  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Marker []byte `protobuf:"bytes,2,opt,name=marker,proto3" json:"marker,omitempty"`
+}
+
+func (m *ListManagementClientsRequest) Reset()      { *m = ListManagementClientsRequest{} }
+func (*ListManagementClientsRequest) ProtoMessage() {}
+func (*ListManagementClientsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{43}
+}
+func (m *ListManagementClientsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListManagementClientsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListManagementClientsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+}
+func (m *ListManagementClientsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListManagementClientsRequest.Merge(m, src)
+}
+func (m *ListManagementClientsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListManagementClientsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListManagementClientsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListManagementClientsRequest proto.InternalMessageInfo
+
+func (m *ListManagementClientsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
 	}
-	return true
+	return 0
 }
-func (this *LabelLink) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *ListManagementClientsRequest) GetMarker() []byte {
+	if m != nil {
+		return m.Marker
 	}
+	return nil
+}
 
-	that1, ok := that.(*LabelLink)
-	if !ok {
-		that2, ok := that.(LabelLink)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type ListManagementClientsResponse struct {
+	Clients    []*ManagementClient `protobuf:"bytes,1,rep,name=clients,proto3" json:"clients,omitempty"`
+	NextMarker []byte              `protobuf:"bytes,2,opt,name=next_marker,json=nextMarker,proto3" json:"next_marker,omitempty"`
+}
+
+func (m *ListManagementClientsResponse) Reset()      { *m = ListManagementClientsResponse{} }
+func (*ListManagementClientsResponse) ProtoMessage() {}
+func (*ListManagementClientsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{44}
+}
+func (m *ListManagementClientsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListManagementClientsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListManagementClientsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Account.Equal(that1.Account) {
-		return false
+}
+func (m *ListManagementClientsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListManagementClientsResponse.Merge(m, src)
+}
+func (m *ListManagementClientsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListManagementClientsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListManagementClientsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListManagementClientsResponse proto.InternalMessageInfo
+
+func (m *ListManagementClientsResponse) GetClients() []*ManagementClient {
+	if m != nil {
+		return m.Clients
 	}
-	if !this.Labels.Equal(that1.Labels) {
-		return false
+	return nil
+}
+
+func (m *ListManagementClientsResponse) GetNextMarker() []byte {
+	if m != nil {
+		return m.NextMarker
 	}
-	if !this.Target.Equal(that1.Target) {
-		return false
+	return nil
+}
+
+type ListTokensRequest struct {
+	Limit  int32  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Marker []byte `protobuf:"bytes,2,opt,name=marker,proto3" json:"marker,omitempty"`
+}
+
+func (m *ListTokensRequest) Reset()      { *m = ListTokensRequest{} }
+func (*ListTokensRequest) ProtoMessage() {}
+func (*ListTokensRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{45}
+}
+func (m *ListTokensRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListTokensRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListTokensRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if !this.Limits.Equal(that1.Limits) {
-		return false
+}
+func (m *ListTokensRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTokensRequest.Merge(m, src)
+}
+func (m *ListTokensRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListTokensRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTokensRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTokensRequest proto.InternalMessageInfo
+
+func (m *ListTokensRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
 	}
-	return true
+	return 0
 }
-func (this *LabelLinks) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *ListTokensRequest) GetMarker() []byte {
+	if m != nil {
+		return m.Marker
 	}
+	return nil
+}
 
-	that1, ok := that.(*LabelLinks)
-	if !ok {
-		that2, ok := that.(LabelLinks)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type IssuedToken struct {
+	TokenId      *ULID             `protobuf:"bytes,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	Role         TokenRole         `protobuf:"varint,2,opt,name=role,proto3,enum=pb.TokenRole" json:"role,omitempty"`
+	Account      *Account          `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"`
+	Capabilities []TokenCapability `protobuf:"bytes,4,rep,name=capabilities,proto3" json:"capabilities"`
+	// Unset if the token doesn't expire.
+	ValidUntil *Timestamp `protobuf:"bytes,5,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+	IssuedAt   *Timestamp `protobuf:"bytes,6,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+}
+
+func (m *IssuedToken) Reset()      { *m = IssuedToken{} }
+func (*IssuedToken) ProtoMessage() {}
+func (*IssuedToken) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{46}
+}
+func (m *IssuedToken) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IssuedToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IssuedToken.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+}
+func (m *IssuedToken) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssuedToken.Merge(m, src)
+}
+func (m *IssuedToken) XXX_Size() int {
+	return m.Size()
+}
+func (m *IssuedToken) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssuedToken.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssuedToken proto.InternalMessageInfo
+
+func (m *IssuedToken) GetTokenId() *ULID {
+	if m != nil {
+		return m.TokenId
 	}
-	if len(this.LabelLinks) != len(that1.LabelLinks) {
-		return false
+	return nil
+}
+
+func (m *IssuedToken) GetRole() TokenRole {
+	if m != nil {
+		return m.Role
 	}
-	for i := range this.LabelLinks {
-		if !this.LabelLinks[i].Equal(that1.LabelLinks[i]) {
-			return false
-		}
+	return AGENT
+}
+
+func (m *IssuedToken) GetAccount() *Account {
+	if m != nil {
+		return m.Account
 	}
-	return true
+	return nil
 }
-func (this *ServiceRoute) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *IssuedToken) GetCapabilities() []TokenCapability {
+	if m != nil {
+		return m.Capabilities
 	}
+	return nil
+}
 
-	that1, ok := that.(*ServiceRoute)
-	if !ok {
-		that2, ok := that.(ServiceRoute)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+func (m *IssuedToken) GetValidUntil() *Timestamp {
+	if m != nil {
+		return m.ValidUntil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Hub.Equal(that1.Hub) {
-		return false
-	}
-	if !this.Id.Equal(that1.Id) {
-		return false
-	}
-	if this.Type != that1.Type {
-		return false
-	}
-	if !this.Labels.Equal(that1.Labels) {
-		return false
-	}
-	return true
+	return nil
 }
-func (this *AccountServices) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *IssuedToken) GetIssuedAt() *Timestamp {
+	if m != nil {
+		return m.IssuedAt
 	}
+	return nil
+}
 
-	that1, ok := that.(*AccountServices)
-	if !ok {
-		that2, ok := that.(AccountServices)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type ListTokensResponse struct {
+	Tokens     []*IssuedToken `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	NextMarker []byte         `protobuf:"bytes,2,opt,name=next_marker,json=nextMarker,proto3" json:"next_marker,omitempty"`
+}
+
+func (m *ListTokensResponse) Reset()      { *m = ListTokensResponse{} }
+func (*ListTokensResponse) ProtoMessage() {}
+func (*ListTokensResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{47}
+}
+func (m *ListTokensResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListTokensResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListTokensResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Account.Equal(that1.Account) {
-		return false
+}
+func (m *ListTokensResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTokensResponse.Merge(m, src)
+}
+func (m *ListTokensResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListTokensResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTokensResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTokensResponse proto.InternalMessageInfo
+
+func (m *ListTokensResponse) GetTokens() []*IssuedToken {
+	if m != nil {
+		return m.Tokens
 	}
-	if len(this.Services) != len(that1.Services) {
-		return false
+	return nil
+}
+
+func (m *ListTokensResponse) GetNextMarker() []byte {
+	if m != nil {
+		return m.NextMarker
 	}
-	for i := range this.Services {
-		if !this.Services[i].Equal(that1.Services[i]) {
-			return false
+	return nil
+}
+
+type WhoAmIResponse struct {
+	Role         TokenRole         `protobuf:"varint,1,opt,name=role,proto3,enum=pb.TokenRole" json:"role,omitempty"`
+	TokenId      *ULID             `protobuf:"bytes,2,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	Account      *Account          `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"`
+	Capabilities []TokenCapability `protobuf:"bytes,4,rep,name=capabilities,proto3" json:"capabilities"`
+	// The namespace the caller can act on, which includes every namespace below
+	// it. Empty if the caller can't act on accounts other than its own.
+	AccessNamespace string `protobuf:"bytes,5,opt,name=access_namespace,json=accessNamespace,proto3" json:"access_namespace,omitempty"`
+	// Unset if the token doesn't expire.
+	ValidUntil *Timestamp `protobuf:"bytes,6,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+}
+
+func (m *WhoAmIResponse) Reset()      { *m = WhoAmIResponse{} }
+func (*WhoAmIResponse) ProtoMessage() {}
+func (*WhoAmIResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{48}
+}
+func (m *WhoAmIResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WhoAmIResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WhoAmIResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *ActivityEntry) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *WhoAmIResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WhoAmIResponse.Merge(m, src)
+}
+func (m *WhoAmIResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *WhoAmIResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WhoAmIResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WhoAmIResponse proto.InternalMessageInfo
+
+func (m *WhoAmIResponse) GetRole() TokenRole {
+	if m != nil {
+		return m.Role
 	}
+	return AGENT
+}
 
-	that1, ok := that.(*ActivityEntry)
-	if !ok {
-		that2, ok := that.(ActivityEntry)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+func (m *WhoAmIResponse) GetTokenId() *ULID {
+	if m != nil {
+		return m.TokenId
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+	return nil
+}
+
+func (m *WhoAmIResponse) GetAccount() *Account {
+	if m != nil {
+		return m.Account
 	}
-	if !this.RouteAdded.Equal(that1.RouteAdded) {
-		return false
+	return nil
+}
+
+func (m *WhoAmIResponse) GetCapabilities() []TokenCapability {
+	if m != nil {
+		return m.Capabilities
 	}
-	if !this.RouteRemoved.Equal(that1.RouteRemoved) {
-		return false
+	return nil
+}
+
+func (m *WhoAmIResponse) GetAccessNamespace() string {
+	if m != nil {
+		return m.AccessNamespace
 	}
-	return true
+	return ""
 }
-func (this *ConfigRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *WhoAmIResponse) GetValidUntil() *Timestamp {
+	if m != nil {
+		return m.ValidUntil
 	}
+	return nil
+}
 
-	that1, ok := that.(*ConfigRequest)
-	if !ok {
-		that2, ok := that.(ConfigRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type TokenStatusResponse struct {
+	TokenId *ULID `protobuf:"bytes,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	// The token isn't valid before this time.
+	NotBefore *Timestamp `protobuf:"bytes,2,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	// Unset if the token doesn't expire.
+	ValidUntil *Timestamp `protobuf:"bytes,3,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+	// Whether the token can be passed to RenewToken. Tokens that don't expire
+	// have no need to be renewed.
+	Renewable bool `protobuf:"varint,4,opt,name=renewable,proto3" json:"renewable,omitempty"`
+	// Whether the token is close enough to expiring that RenewToken will
+	// renew it.
+	WithinRenewalWindow bool `protobuf:"varint,5,opt,name=within_renewal_window,json=withinRenewalWindow,proto3" json:"within_renewal_window,omitempty"`
+	// When the token enters the renewal window. Unset if it isn't renewable.
+	RenewAfter *Timestamp `protobuf:"bytes,6,opt,name=renew_after,json=renewAfter,proto3" json:"renew_after,omitempty"`
+}
+
+func (m *TokenStatusResponse) Reset()      { *m = TokenStatusResponse{} }
+func (*TokenStatusResponse) ProtoMessage() {}
+func (*TokenStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{49}
+}
+func (m *TokenStatusResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TokenStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TokenStatusResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+}
+func (m *TokenStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenStatusResponse.Merge(m, src)
+}
+func (m *TokenStatusResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *TokenStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenStatusResponse proto.InternalMessageInfo
+
+func (m *TokenStatusResponse) GetTokenId() *ULID {
+	if m != nil {
+		return m.TokenId
 	}
-	if !this.StableId.Equal(that1.StableId) {
-		return false
+	return nil
+}
+
+func (m *TokenStatusResponse) GetNotBefore() *Timestamp {
+	if m != nil {
+		return m.NotBefore
 	}
-	if !this.InstanceId.Equal(that1.InstanceId) {
-		return false
+	return nil
+}
+
+func (m *TokenStatusResponse) GetValidUntil() *Timestamp {
+	if m != nil {
+		return m.ValidUntil
 	}
-	if len(this.Locations) != len(that1.Locations) {
-		return false
+	return nil
+}
+
+func (m *TokenStatusResponse) GetRenewable() bool {
+	if m != nil {
+		return m.Renewable
 	}
-	for i := range this.Locations {
-		if !this.Locations[i].Equal(that1.Locations[i]) {
-			return false
-		}
+	return false
+}
+
+func (m *TokenStatusResponse) GetWithinRenewalWindow() bool {
+	if m != nil {
+		return m.WithinRenewalWindow
 	}
-	return true
+	return false
 }
-func (this *ConfigResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *TokenStatusResponse) GetRenewAfter() *Timestamp {
+	if m != nil {
+		return m.RenewAfter
 	}
+	return nil
+}
 
-	that1, ok := that.(*ConfigResponse)
-	if !ok {
-		that2, ok := that.(ConfigResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type ExportRequest struct {
+	Account *Account `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+}
+
+func (m *ExportRequest) Reset()      { *m = ExportRequest{} }
+func (*ExportRequest) ProtoMessage() {}
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{50}
+}
+func (m *ExportRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !bytes.Equal(this.TlsKey, that1.TlsKey) {
-		return false
-	}
-	if !bytes.Equal(this.TlsCert, that1.TlsCert) {
-		return false
-	}
-	if !bytes.Equal(this.TokenPub, that1.TokenPub) {
-		return false
-	}
-	if this.S3AccessKey != that1.S3AccessKey {
-		return false
-	}
-	if this.S3SecretKey != that1.S3SecretKey {
-		return false
-	}
-	if this.S3Bucket != that1.S3Bucket {
-		return false
-	}
-	if this.ImageTag != that1.ImageTag {
-		return false
-	}
-	return true
 }
-func (this *CentralActivity) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
+func (m *ExportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportRequest.Merge(m, src)
+}
+func (m *ExportRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportRequest.DiscardUnknown(m)
+}
 
-	that1, ok := that.(*CentralActivity)
-	if !ok {
-		that2, ok := that.(CentralActivity)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+var xxx_messageInfo_ExportRequest proto.InternalMessageInfo
+
+func (m *ExportRequest) GetAccount() *Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+type AccountConfig struct {
+	Account    *Account          `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Limits     *Account_Limits   `protobuf:"bytes,2,opt,name=limits,proto3" json:"limits,omitempty"`
+	LabelLinks []*LabelLink      `protobuf:"bytes,3,rep,name=label_links,json=labelLinks,proto3" json:"label_links,omitempty"`
+	Services   []*ServiceRequest `protobuf:"bytes,4,rep,name=services,proto3" json:"services,omitempty"`
+}
+
+func (m *AccountConfig) Reset()      { *m = AccountConfig{} }
+func (*AccountConfig) ProtoMessage() {}
+func (*AccountConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{51}
+}
+func (m *AccountConfig) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AccountConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AccountConfig.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+}
+func (m *AccountConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountConfig.Merge(m, src)
+}
+func (m *AccountConfig) XXX_Size() int {
+	return m.Size()
+}
+func (m *AccountConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountConfig proto.InternalMessageInfo
+
+func (m *AccountConfig) GetAccount() *Account {
+	if m != nil {
+		return m.Account
 	}
-	if len(this.AccountServices) != len(that1.AccountServices) {
-		return false
+	return nil
+}
+
+func (m *AccountConfig) GetLimits() *Account_Limits {
+	if m != nil {
+		return m.Limits
 	}
-	for i := range this.AccountServices {
-		if !this.AccountServices[i].Equal(that1.AccountServices[i]) {
-			return false
-		}
+	return nil
+}
+
+func (m *AccountConfig) GetLabelLinks() []*LabelLink {
+	if m != nil {
+		return m.LabelLinks
 	}
-	if this.RequestStats != that1.RequestStats {
-		return false
+	return nil
+}
+
+func (m *AccountConfig) GetServices() []*ServiceRequest {
+	if m != nil {
+		return m.Services
 	}
-	if !this.NewLabelLinks.Equal(that1.NewLabelLinks) {
-		return false
+	return nil
+}
+
+type ImportRequest struct {
+	Config *AccountConfig `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+}
+
+func (m *ImportRequest) Reset()      { *m = ImportRequest{} }
+func (*ImportRequest) ProtoMessage() {}
+func (*ImportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{52}
+}
+func (m *ImportRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ImportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ImportRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *HubActivity) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *ImportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportRequest.Merge(m, src)
+}
+func (m *ImportRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ImportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportRequest proto.InternalMessageInfo
+
+func (m *ImportRequest) GetConfig() *AccountConfig {
+	if m != nil {
+		return m.Config
 	}
+	return nil
+}
 
-	that1, ok := that.(*HubActivity)
-	if !ok {
-		that2, ok := that.(HubActivity)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type ConnectedHub struct {
+	// The instance id of the hub.
+	Hub string `protobuf:"bytes,1,opt,name=hub,proto3" json:"hub,omitempty"`
+	// How many activity messages the hub has missed because its queue was full.
+	Dropped       int64 `protobuf:"varint,2,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	QueueDepth    int64 `protobuf:"varint,3,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`
+	MaxQueueDepth int64 `protobuf:"varint,4,opt,name=max_queue_depth,json=maxQueueDepth,proto3" json:"max_queue_depth,omitempty"`
+}
+
+func (m *ConnectedHub) Reset()      { *m = ConnectedHub{} }
+func (*ConnectedHub) ProtoMessage() {}
+func (*ConnectedHub) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{53}
+}
+func (m *ConnectedHub) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConnectedHub) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConnectedHub.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.HubReg.Equal(that1.HubReg) {
-		return false
+}
+func (m *ConnectedHub) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectedHub.Merge(m, src)
+}
+func (m *ConnectedHub) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConnectedHub) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectedHub.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectedHub proto.InternalMessageInfo
+
+func (m *ConnectedHub) GetHub() string {
+	if m != nil {
+		return m.Hub
 	}
-	if !this.SentAt.Equal(that1.SentAt) {
-		return false
+	return ""
+}
+
+func (m *ConnectedHub) GetDropped() int64 {
+	if m != nil {
+		return m.Dropped
 	}
-	if !this.Stats.Equal(that1.Stats) {
-		return false
+	return 0
+}
+
+func (m *ConnectedHub) GetQueueDepth() int64 {
+	if m != nil {
+		return m.QueueDepth
 	}
-	if len(this.Flow) != len(that1.Flow) {
-		return false
+	return 0
+}
+
+func (m *ConnectedHub) GetMaxQueueDepth() int64 {
+	if m != nil {
+		return m.MaxQueueDepth
 	}
-	for i := range this.Flow {
-		if !this.Flow[i].Equal(that1.Flow[i]) {
-			return false
+	return 0
+}
+
+type ConnectedHubsResponse struct {
+	Hubs []*ConnectedHub `protobuf:"bytes,1,rep,name=hubs,proto3" json:"hubs,omitempty"`
+}
+
+func (m *ConnectedHubsResponse) Reset()      { *m = ConnectedHubsResponse{} }
+func (*ConnectedHubsResponse) ProtoMessage() {}
+func (*ConnectedHubsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{54}
+}
+func (m *ConnectedHubsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConnectedHubsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConnectedHubsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *HubActivity_HubRegistration) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *ConnectedHubsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectedHubsResponse.Merge(m, src)
+}
+func (m *ConnectedHubsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConnectedHubsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectedHubsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectedHubsResponse proto.InternalMessageInfo
+
+func (m *ConnectedHubsResponse) GetHubs() []*ConnectedHub {
+	if m != nil {
+		return m.Hubs
 	}
+	return nil
+}
 
-	that1, ok := that.(*HubActivity_HubRegistration)
-	if !ok {
-		that2, ok := that.(HubActivity_HubRegistration)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type HubFlowCounters struct {
+	// The instance id of the hub.
+	Hub string `protobuf:"bytes,1,opt,name=hub,proto3" json:"hub,omitempty"`
+	// The messages and bytes of the hub's flows since it connected or its
+	// counters were last reset.
+	Messages int64 `protobuf:"varint,2,opt,name=messages,proto3" json:"messages,omitempty"`
+	Bytes    int64 `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+}
+
+func (m *HubFlowCounters) Reset()      { *m = HubFlowCounters{} }
+func (*HubFlowCounters) ProtoMessage() {}
+func (*HubFlowCounters) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{55}
+}
+func (m *HubFlowCounters) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HubFlowCounters) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HubFlowCounters.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Hub.Equal(that1.Hub) {
-		return false
+}
+func (m *HubFlowCounters) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HubFlowCounters.Merge(m, src)
+}
+func (m *HubFlowCounters) XXX_Size() int {
+	return m.Size()
+}
+func (m *HubFlowCounters) XXX_DiscardUnknown() {
+	xxx_messageInfo_HubFlowCounters.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HubFlowCounters proto.InternalMessageInfo
+
+func (m *HubFlowCounters) GetHub() string {
+	if m != nil {
+		return m.Hub
 	}
-	if !this.StableHub.Equal(that1.StableHub) {
-		return false
+	return ""
+}
+
+func (m *HubFlowCounters) GetMessages() int64 {
+	if m != nil {
+		return m.Messages
 	}
-	if len(this.Locations) != len(that1.Locations) {
-		return false
+	return 0
+}
+
+func (m *HubFlowCounters) GetBytes() int64 {
+	if m != nil {
+		return m.Bytes
 	}
-	for i := range this.Locations {
-		if !this.Locations[i].Equal(that1.Locations[i]) {
-			return false
+	return 0
+}
+
+type FlowCountersResponse struct {
+	Hubs []*HubFlowCounters `protobuf:"bytes,1,rep,name=hubs,proto3" json:"hubs,omitempty"`
+}
+
+func (m *FlowCountersResponse) Reset()      { *m = FlowCountersResponse{} }
+func (*FlowCountersResponse) ProtoMessage() {}
+func (*FlowCountersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{56}
+}
+func (m *FlowCountersResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FlowCountersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FlowCountersResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *HubActivity_HubStats) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *FlowCountersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlowCountersResponse.Merge(m, src)
+}
+func (m *FlowCountersResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *FlowCountersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlowCountersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlowCountersResponse proto.InternalMessageInfo
+
+func (m *FlowCountersResponse) GetHubs() []*HubFlowCounters {
+	if m != nil {
+		return m.Hubs
 	}
+	return nil
+}
 
-	that1, ok := that.(*HubActivity_HubStats)
-	if !ok {
-		that2, ok := that.(HubActivity_HubStats)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type SetSuspendedRequest struct {
+	Account   *Account `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Suspended bool     `protobuf:"varint,2,opt,name=suspended,proto3" json:"suspended,omitempty"`
+}
+
+func (m *SetSuspendedRequest) Reset()      { *m = SetSuspendedRequest{} }
+func (*SetSuspendedRequest) ProtoMessage() {}
+func (*SetSuspendedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{57}
+}
+func (m *SetSuspendedRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetSuspendedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetSuspendedRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.AgentConnections != that1.AgentConnections {
-		return false
-	}
-	if this.AccountsSeen != that1.AccountsSeen {
-		return false
-	}
-	if this.AccountsCached != that1.AccountsCached {
-		return false
-	}
-	if this.CachedBytes != that1.CachedBytes {
-		return false
-	}
-	if !this.LastSuccessfulUpdate.Equal(that1.LastSuccessfulUpdate) {
-		return false
-	}
-	if !this.LastAttemptedUpdate.Equal(that1.LastAttemptedUpdate) {
-		return false
+}
+func (m *SetSuspendedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetSuspendedRequest.Merge(m, src)
+}
+func (m *SetSuspendedRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetSuspendedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetSuspendedRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetSuspendedRequest proto.InternalMessageInfo
+
+func (m *SetSuspendedRequest) GetAccount() *Account {
+	if m != nil {
+		return m.Account
 	}
-	return true
+	return nil
 }
-func (this *HubInfo) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *SetSuspendedRequest) GetSuspended() bool {
+	if m != nil {
+		return m.Suspended
 	}
+	return false
+}
 
-	that1, ok := that.(*HubInfo)
-	if !ok {
-		that2, ok := that.(HubInfo)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type UnregisterRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Cascade   bool   `protobuf:"varint,2,opt,name=cascade,proto3" json:"cascade,omitempty"`
+}
+
+func (m *UnregisterRequest) Reset()      { *m = UnregisterRequest{} }
+func (*UnregisterRequest) ProtoMessage() {}
+func (*UnregisterRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{58}
+}
+func (m *UnregisterRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UnregisterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UnregisterRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Id.Equal(that1.Id) {
-		return false
+}
+func (m *UnregisterRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnregisterRequest.Merge(m, src)
+}
+func (m *UnregisterRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *UnregisterRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnregisterRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnregisterRequest proto.InternalMessageInfo
+
+func (m *UnregisterRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
 	}
-	if len(this.Locations) != len(that1.Locations) {
-		return false
+	return ""
+}
+
+func (m *UnregisterRequest) GetCascade() bool {
+	if m != nil {
+		return m.Cascade
 	}
-	for i := range this.Locations {
-		if !this.Locations[i].Equal(that1.Locations[i]) {
-			return false
+	return false
+}
+
+type AllHubsRequest struct {
+	// The version of the list the caller already has, if any.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (m *AllHubsRequest) Reset()      { *m = AllHubsRequest{} }
+func (*AllHubsRequest) ProtoMessage() {}
+func (*AllHubsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{59}
+}
+func (m *AllHubsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AllHubsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AllHubsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *ListOfHubs) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *AllHubsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AllHubsRequest.Merge(m, src)
+}
+func (m *AllHubsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *AllHubsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AllHubsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AllHubsRequest proto.InternalMessageInfo
+
+func (m *AllHubsRequest) GetVersion() string {
+	if m != nil {
+		return m.Version
 	}
+	return ""
+}
 
-	that1, ok := that.(*ListOfHubs)
-	if !ok {
-		that2, ok := that.(ListOfHubs)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type ResolveDebugRequest struct {
+	// The hostname a request was sent to, with any deployment id already split
+	// out of it.
+	Hostname string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	// The path of the request, which picks among label links with a path
+	// prefix.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// The deployment a deployment specific hostname named, if any.
+	DeploymentId string `protobuf:"bytes,3,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+}
+
+func (m *ResolveDebugRequest) Reset()      { *m = ResolveDebugRequest{} }
+func (*ResolveDebugRequest) ProtoMessage() {}
+func (*ResolveDebugRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{60}
+}
+func (m *ResolveDebugRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResolveDebugRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResolveDebugRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.Hubs) != len(that1.Hubs) {
-		return false
+}
+func (m *ResolveDebugRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolveDebugRequest.Merge(m, src)
+}
+func (m *ResolveDebugRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResolveDebugRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolveDebugRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResolveDebugRequest proto.InternalMessageInfo
+
+func (m *ResolveDebugRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
 	}
-	for i := range this.Hubs {
-		if !this.Hubs[i].Equal(that1.Hubs[i]) {
-			return false
-		}
+	return ""
+}
+
+func (m *ResolveDebugRequest) GetPath() string {
+	if m != nil {
+		return m.Path
 	}
-	return true
+	return ""
 }
-func (this *HubSync) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *ResolveDebugRequest) GetDeploymentId() string {
+	if m != nil {
+		return m.DeploymentId
 	}
+	return ""
+}
 
-	that1, ok := that.(*HubSync)
-	if !ok {
-		that2, ok := that.(HubSync)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type ResolveDebugResponse struct {
+	// The account and target of the label link the hostname resolved to. The
+	// target includes the :deployment label for deployment specific hostnames.
+	Account *Account  `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Target  *LabelSet `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// The path prefix of the label link, if it had one.
+	PathPrefix string `protobuf:"bytes,3,opt,name=path_prefix,json=pathPrefix,proto3" json:"path_prefix,omitempty"`
+	// Set when the account is suspended, so nothing is routed to.
+	Suspended bool `protobuf:"varint,4,opt,name=suspended,proto3" json:"suspended,omitempty"`
+	// Set when some of the matching services have a deployment order, so only
+	// the newest deployment's are routed to. Otherwise every matching service
+	// is.
+	NewestDeployment bool `protobuf:"varint,5,opt,name=newest_deployment,json=newestDeployment,proto3" json:"newest_deployment,omitempty"`
+	// The services the target would be routed to, with their hubs, before
+	// they're shuffled according to their weights.
+	Services []*ServiceRoute `protobuf:"bytes,6,rep,name=services,proto3" json:"services,omitempty"`
+}
+
+func (m *ResolveDebugResponse) Reset()      { *m = ResolveDebugResponse{} }
+func (*ResolveDebugResponse) ProtoMessage() {}
+func (*ResolveDebugResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{61}
+}
+func (m *ResolveDebugResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResolveDebugResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResolveDebugResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Id.Equal(that1.Id) {
-		return false
+}
+func (m *ResolveDebugResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolveDebugResponse.Merge(m, src)
+}
+func (m *ResolveDebugResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResolveDebugResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolveDebugResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResolveDebugResponse proto.InternalMessageInfo
+
+func (m *ResolveDebugResponse) GetAccount() *Account {
+	if m != nil {
+		return m.Account
 	}
-	if !this.StableId.Equal(that1.StableId) {
-		return false
+	return nil
+}
+
+func (m *ResolveDebugResponse) GetTarget() *LabelSet {
+	if m != nil {
+		return m.Target
 	}
-	if len(this.Services) != len(that1.Services) {
-		return false
+	return nil
+}
+
+func (m *ResolveDebugResponse) GetPathPrefix() string {
+	if m != nil {
+		return m.PathPrefix
 	}
-	for i := range this.Services {
-		if !this.Services[i].Equal(that1.Services[i]) {
-			return false
-		}
+	return ""
+}
+
+func (m *ResolveDebugResponse) GetSuspended() bool {
+	if m != nil {
+		return m.Suspended
 	}
-	return true
+	return false
 }
-func (this *HubSyncResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *ResolveDebugResponse) GetNewestDeployment() bool {
+	if m != nil {
+		return m.NewestDeployment
 	}
+	return false
+}
 
-	that1, ok := that.(*HubSyncResponse)
-	if !ok {
-		that2, ok := that.(HubSyncResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+func (m *ResolveDebugResponse) GetServices() []*ServiceRoute {
+	if m != nil {
+		return m.Services
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+	return nil
+}
+
+// TargetSplit is one of the targets a label link splits requests between.
+type TargetSplit struct {
+	Target *LabelSet `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	// The share of requests sent to target, relative to the weights of the
+	// other targets.
+	Weight uint32 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+}
+
+func (m *TargetSplit) Reset()      { *m = TargetSplit{} }
+func (*TargetSplit) ProtoMessage() {}
+func (*TargetSplit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{62}
+}
+func (m *TargetSplit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TargetSplit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TargetSplit.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if this.ServiceCount != that1.ServiceCount {
-		return false
+}
+func (m *TargetSplit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TargetSplit.Merge(m, src)
+}
+func (m *TargetSplit) XXX_Size() int {
+	return m.Size()
+}
+func (m *TargetSplit) XXX_DiscardUnknown() {
+	xxx_messageInfo_TargetSplit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TargetSplit proto.InternalMessageInfo
+
+func (m *TargetSplit) GetTarget() *LabelSet {
+	if m != nil {
+		return m.Target
 	}
-	return true
+	return nil
 }
-func (this *HubRegisterRequest) Equal(that interface{}) bool {
+
+func (m *TargetSplit) GetWeight() uint32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+// TargetSplits is how the splits of a label link are stored.
+type TargetSplits struct {
+	Splits []*TargetSplit `protobuf:"bytes,1,rep,name=splits,proto3" json:"splits,omitempty"`
+}
+
+func (m *TargetSplits) Reset()      { *m = TargetSplits{} }
+func (*TargetSplits) ProtoMessage() {}
+func (*TargetSplits) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{63}
+}
+func (m *TargetSplits) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TargetSplits) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TargetSplits.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TargetSplits) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TargetSplits.Merge(m, src)
+}
+func (m *TargetSplits) XXX_Size() int {
+	return m.Size()
+}
+func (m *TargetSplits) XXX_DiscardUnknown() {
+	xxx_messageInfo_TargetSplits.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TargetSplits proto.InternalMessageInfo
+
+func (m *TargetSplits) GetSplits() []*TargetSplit {
+	if m != nil {
+		return m.Splits
+	}
+	return nil
+}
+
+type SetLabelLinkSplitsRequest struct {
+	Account *Account  `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Labels  *LabelSet `protobuf:"bytes,2,opt,name=labels,proto3" json:"labels,omitempty"`
+	// The targets to split requests between. Empty sends every request to the
+	// label link's target again.
+	Splits []*TargetSplit `protobuf:"bytes,3,rep,name=splits,proto3" json:"splits,omitempty"`
+}
+
+func (m *SetLabelLinkSplitsRequest) Reset()      { *m = SetLabelLinkSplitsRequest{} }
+func (*SetLabelLinkSplitsRequest) ProtoMessage() {}
+func (*SetLabelLinkSplitsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{64}
+}
+func (m *SetLabelLinkSplitsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetLabelLinkSplitsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetLabelLinkSplitsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetLabelLinkSplitsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetLabelLinkSplitsRequest.Merge(m, src)
+}
+func (m *SetLabelLinkSplitsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetLabelLinkSplitsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetLabelLinkSplitsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetLabelLinkSplitsRequest proto.InternalMessageInfo
+
+func (m *SetLabelLinkSplitsRequest) GetAccount() *Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func (m *SetLabelLinkSplitsRequest) GetLabels() *LabelSet {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *SetLabelLinkSplitsRequest) GetSplits() []*TargetSplit {
+	if m != nil {
+		return m.Splits
+	}
+	return nil
+}
+
+type InvalidateRequest struct {
+	Account *Account `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+}
+
+func (m *InvalidateRequest) Reset()      { *m = InvalidateRequest{} }
+func (*InvalidateRequest) ProtoMessage() {}
+func (*InvalidateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{65}
+}
+func (m *InvalidateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InvalidateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InvalidateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *InvalidateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvalidateRequest.Merge(m, src)
+}
+func (m *InvalidateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *InvalidateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvalidateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvalidateRequest proto.InternalMessageInfo
+
+func (m *InvalidateRequest) GetAccount() *Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ServiceRequest)(nil), "pb.ServiceRequest")
+	proto.RegisterType((*ServiceResponse)(nil), "pb.ServiceResponse")
+	proto.RegisterType((*LabelLink)(nil), "pb.LabelLink")
+	proto.RegisterType((*LabelLinks)(nil), "pb.LabelLinks")
+	proto.RegisterType((*ServiceRoute)(nil), "pb.ServiceRoute")
+	proto.RegisterType((*AccountServices)(nil), "pb.AccountServices")
+	proto.RegisterType((*ActivityEntry)(nil), "pb.ActivityEntry")
+	proto.RegisterType((*ConfigRequest)(nil), "pb.ConfigRequest")
+	proto.RegisterType((*ConfigResponse)(nil), "pb.ConfigResponse")
+	proto.RegisterType((*CentralActivity)(nil), "pb.CentralActivity")
+	proto.RegisterType((*S3Credentials)(nil), "pb.S3Credentials")
+	proto.RegisterType((*QuotaStatus)(nil), "pb.QuotaStatus")
+	proto.RegisterType((*HubActivity)(nil), "pb.HubActivity")
+	proto.RegisterType((*HubActivity_HubRegistration)(nil), "pb.HubActivity.HubRegistration")
+	proto.RegisterType((*HubActivity_HubStats)(nil), "pb.HubActivity.HubStats")
+	proto.RegisterType((*HubInfo)(nil), "pb.HubInfo")
+	proto.RegisterType((*ListOfHubs)(nil), "pb.ListOfHubs")
+	proto.RegisterType((*HubSync)(nil), "pb.HubSync")
+	proto.RegisterType((*HubSyncResponse)(nil), "pb.HubSyncResponse")
+	proto.RegisterType((*HubRegisterRequest)(nil), "pb.HubRegisterRequest")
+	proto.RegisterType((*HubRegisterResponse)(nil), "pb.HubRegisterResponse")
+	proto.RegisterType((*HubDisconnectRequest)(nil), "pb.HubDisconnectRequest")
+	proto.RegisterType((*ServiceTokenRequest)(nil), "pb.ServiceTokenRequest")
+	proto.RegisterType((*ServiceTokenResponse)(nil), "pb.ServiceTokenResponse")
+	proto.RegisterType((*ListServicesRequest)(nil), "pb.ListServicesRequest")
+	proto.RegisterType((*ListServicesResponse)(nil), "pb.ListServicesResponse")
+	proto.RegisterType((*Service)(nil), "pb.Service")
+	proto.RegisterType((*StreamServicesRequest)(nil), "pb.StreamServicesRequest")
+	proto.RegisterType((*AddAccountRequest)(nil), "pb.AddAccountRequest")
+	proto.RegisterType((*CreateAccountRequest)(nil), "pb.CreateAccountRequest")
+	proto.RegisterType((*CreateAccountResponse)(nil), "pb.CreateAccountResponse")
+	proto.RegisterType((*AddLabelLinkRequest)(nil), "pb.AddLabelLinkRequest")
+	proto.RegisterType((*Noop)(nil), "pb.Noop")
+	proto.RegisterType((*RemoveLabelLinkRequest)(nil), "pb.RemoveLabelLinkRequest")
+	proto.RegisterType((*CreateTokenRequest)(nil), "pb.CreateTokenRequest")
+	proto.RegisterType((*CreateTokenResponse)(nil), "pb.CreateTokenResponse")
+	proto.RegisterType((*CreateTokensRequest)(nil), "pb.CreateTokensRequest")
+	proto.RegisterType((*CreateTokenResult)(nil), "pb.CreateTokenResult")
+	proto.RegisterType((*CreateTokensResponse)(nil), "pb.CreateTokensResponse")
+	proto.RegisterType((*ControlRegister)(nil), "pb.ControlRegister")
+	proto.RegisterType((*ControlToken)(nil), "pb.ControlToken")
+	proto.RegisterType((*TokenInfo)(nil), "pb.TokenInfo")
+	proto.RegisterType((*ListAccountsRequest)(nil), "pb.ListAccountsRequest")
+	proto.RegisterType((*ListAccountsResponse)(nil), "pb.ListAccountsResponse")
+	proto.RegisterType((*ManagementClient)(nil), "pb.ManagementClient")
+	proto.RegisterType((*ListManagementClientsRequest)(nil), "pb.ListManagementClientsRequest")
+	proto.RegisterType((*ListManagementClientsResponse)(nil), "pb.ListManagementClientsResponse")
+	proto.RegisterType((*ListTokensRequest)(nil), "pb.ListTokensRequest")
+	proto.RegisterType((*IssuedToken)(nil), "pb.IssuedToken")
+	proto.RegisterType((*ListTokensResponse)(nil), "pb.ListTokensResponse")
+	proto.RegisterType((*WhoAmIResponse)(nil), "pb.WhoAmIResponse")
+	proto.RegisterType((*TokenStatusResponse)(nil), "pb.TokenStatusResponse")
+	proto.RegisterType((*ExportRequest)(nil), "pb.ExportRequest")
+	proto.RegisterType((*AccountConfig)(nil), "pb.AccountConfig")
+	proto.RegisterType((*ImportRequest)(nil), "pb.ImportRequest")
+	proto.RegisterType((*ConnectedHub)(nil), "pb.ConnectedHub")
+	proto.RegisterType((*ConnectedHubsResponse)(nil), "pb.ConnectedHubsResponse")
+	proto.RegisterType((*HubFlowCounters)(nil), "pb.HubFlowCounters")
+	proto.RegisterType((*FlowCountersResponse)(nil), "pb.FlowCountersResponse")
+	proto.RegisterType((*SetSuspendedRequest)(nil), "pb.SetSuspendedRequest")
+	proto.RegisterType((*UnregisterRequest)(nil), "pb.UnregisterRequest")
+	proto.RegisterType((*AllHubsRequest)(nil), "pb.AllHubsRequest")
+	proto.RegisterType((*ResolveDebugRequest)(nil), "pb.ResolveDebugRequest")
+	proto.RegisterType((*ResolveDebugResponse)(nil), "pb.ResolveDebugResponse")
+	proto.RegisterType((*TargetSplit)(nil), "pb.TargetSplit")
+	proto.RegisterType((*TargetSplits)(nil), "pb.TargetSplits")
+	proto.RegisterType((*SetLabelLinkSplitsRequest)(nil), "pb.SetLabelLinkSplitsRequest")
+	proto.RegisterType((*InvalidateRequest)(nil), "pb.InvalidateRequest")
+}
+
+func init() { proto.RegisterFile("control.proto", fileDescriptor_0c5120591600887d) }
+
+var fileDescriptor_0c5120591600887d = []byte{
+	// 3323 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcd, 0x1a, 0x4b, 0x6c, 0x1b, 0xd7,
+	0x51, 0xcb, 0x8f, 0x48, 0x0e, 0x7f, 0xd2, 0x52, 0x76, 0x68, 0x36, 0x71, 0x92, 0x6d, 0xda, 0x38,
+	0x69, 0x22, 0x27, 0x96, 0xeb, 0xa4, 0x41, 0xdc, 0x94, 0xa6, 0xe3, 0x54, 0xb5, 0xe2, 0x38, 0x2b,
+	0x3b, 0x01, 0x7a, 0xc8, 0x76, 0x49, 0x3e, 0x89, 0x5b, 0x2d, 0xb9, 0xcc, 0xee, 0xd2, 0xb2, 0x72,
+	0x2a, 0xd0, 0x1e, 0x9a, 0x4b, 0xd1, 0x02, 0x01, 0x8a, 0xe4, 0xd0, 0x73, 0x8f, 0x39, 0xf4, 0xd0,
+	0x73, 0x81, 0x02, 0xb9, 0x35, 0x40, 0x2f, 0x39, 0x15, 0x4d, 0x7a, 0xe9, 0xad, 0xbd, 0xf6, 0x50,
+	0xa0, 0xf3, 0x7e, 0xbb, 0x6f, 0x97, 0x2b, 0x4a, 0x74, 0x6b, 0xa0, 0x07, 0x4a, 0x7c, 0x33, 0xf3,
+	0xe6, 0xcd, 0x9b, 0x37, 0x6f, 0x7e, 0x8f, 0x50, 0x1f, 0x78, 0x93, 0xd0, 0xf7, 0xdc, 0xcd, 0xa9,
+	0xef, 0x85, 0x9e, 0x9e, 0x9b, 0xf6, 0x3b, 0xcd, 0x21, 0xd9, 0x0b, 0x2e, 0xee, 0x7b, 0xfb, 0x1e,
+	0x07, 0x76, 0xca, 0x07, 0xf7, 0xc4, 0xb7, 0xaa, 0x6b, 0xf7, 0x89, 0xa0, 0xed, 0xd4, 0xed, 0xc1,
+	0xc0, 0x9b, 0x4d, 0x42, 0x31, 0x84, 0x99, 0xeb, 0x0c, 0x25, 0x5d, 0xe8, 0x1d, 0x90, 0x89, 0x18,
+	0x34, 0x43, 0x67, 0x4c, 0x82, 0xd0, 0x1e, 0x4f, 0x25, 0xe5, 0x9e, 0xeb, 0x1d, 0x4a, 0x26, 0x13,
+	0x12, 0x1e, 0x7a, 0xfe, 0x01, 0x1f, 0x1a, 0x7f, 0xd2, 0xa0, 0xb1, 0x4b, 0xfc, 0x7b, 0xce, 0x80,
+	0x98, 0xe4, 0xfd, 0x19, 0x4e, 0xd3, 0xbf, 0x01, 0x25, 0xb1, 0x50, 0x5b, 0x7b, 0x42, 0xbb, 0x50,
+	0xbd, 0x54, 0xdd, 0x9c, 0xf6, 0x37, 0xbb, 0x1c, 0x64, 0x4a, 0x9c, 0xde, 0x81, 0xfc, 0x68, 0xd6,
+	0x6f, 0xe7, 0x18, 0x49, 0x99, 0x92, 0xdc, 0xdd, 0xd9, 0xbe, 0x6e, 0x52, 0xa0, 0xde, 0x86, 0x9c,
+	0x33, 0x6c, 0xe7, 0x53, 0x28, 0x84, 0xe9, 0x3a, 0x14, 0xc2, 0xa3, 0x29, 0x69, 0x17, 0x10, 0x57,
+	0x31, 0xd9, 0x77, 0xfd, 0x29, 0x58, 0x65, 0xdb, 0x0c, 0xda, 0x45, 0x36, 0xa3, 0x46, 0x67, 0xec,
+	0x50, 0xc8, 0x2e, 0x09, 0x4d, 0x81, 0xd3, 0xbf, 0x09, 0xe5, 0x31, 0x09, 0xed, 0xa1, 0x1d, 0xda,
+	0xed, 0xd5, 0x27, 0xf2, 0x48, 0x07, 0x94, 0xee, 0xe6, 0x3b, 0xb7, 0x6d, 0xc7, 0x37, 0x23, 0x9c,
+	0xb1, 0x0e, 0xcd, 0x68, 0x43, 0xc1, 0xd4, 0x9b, 0x04, 0xc4, 0xf8, 0xb3, 0x06, 0x15, 0xc6, 0x6f,
+	0xc7, 0x99, 0x1c, 0x9c, 0x76, 0x7f, 0xb1, 0x54, 0xb9, 0x05, 0x52, 0x21, 0x55, 0x68, 0xfb, 0xfb,
+	0x24, 0x14, 0xbb, 0x4d, 0x51, 0x71, 0x9c, 0xfe, 0x2c, 0xf2, 0x72, 0xc6, 0x4e, 0x18, 0xb0, 0x7d,
+	0x57, 0x2f, 0xe9, 0xca, 0x8a, 0x9b, 0x3b, 0x0c, 0x63, 0x0a, 0x0a, 0xfd, 0x69, 0x58, 0x0d, 0xa6,
+	0x2e, 0xa5, 0x2d, 0xb2, 0x5d, 0x36, 0x29, 0xed, 0x1d, 0xc6, 0x67, 0x97, 0xc2, 0x4d, 0x81, 0x36,
+	0x5e, 0x05, 0x88, 0x36, 0x15, 0xe8, 0x9b, 0xc0, 0x6d, 0xc5, 0x72, 0xe9, 0x10, 0x77, 0x46, 0xe7,
+	0xd6, 0x23, 0x69, 0x28, 0x91, 0x09, 0x6e, 0x44, 0x6f, 0xfc, 0x4e, 0x83, 0x9a, 0xd4, 0x93, 0x37,
+	0x0b, 0x89, 0x3c, 0x4f, 0xed, 0xf8, 0xf3, 0xcc, 0x2d, 0x38, 0xcf, 0x7c, 0xe6, 0x79, 0x16, 0x16,
+	0x68, 0xee, 0x51, 0xa8, 0xcc, 0x26, 0x23, 0x62, 0xbb, 0xe1, 0xe8, 0x88, 0x1d, 0x7c, 0xd9, 0x8c,
+	0x01, 0xfa, 0x59, 0x58, 0x3d, 0x24, 0xce, 0xfe, 0x28, 0xc4, 0xb3, 0xd6, 0x2e, 0xd4, 0x4d, 0x31,
+	0x32, 0x7e, 0xa6, 0x41, 0x53, 0x28, 0x4e, 0x48, 0x1f, 0x9c, 0xf6, 0x40, 0x9f, 0x83, 0x72, 0x20,
+	0xa6, 0xe0, 0x56, 0xa8, 0x7a, 0xd6, 0x28, 0x9d, 0xaa, 0x04, 0x33, 0xa2, 0xa0, 0xe2, 0x05, 0xb3,
+	0x60, 0x4a, 0x26, 0x43, 0xc2, 0x2d, 0x19, 0xc5, 0x8b, 0x00, 0x46, 0x08, 0xf5, 0xee, 0x20, 0x74,
+	0xee, 0x39, 0xe1, 0xd1, 0xeb, 0x78, 0x9d, 0x8f, 0xf4, 0xcb, 0x50, 0xf5, 0x29, 0x07, 0xcb, 0x1e,
+	0xd2, 0x09, 0x5c, 0x8e, 0x96, 0x22, 0x87, 0x94, 0xd6, 0x04, 0x46, 0xd7, 0xa5, 0x64, 0xfa, 0xf3,
+	0x50, 0xe7, 0xb3, 0x7c, 0x32, 0xf6, 0xee, 0x91, 0x79, 0x15, 0xd7, 0x18, 0xda, 0xe4, 0x58, 0xe3,
+	0x23, 0x0d, 0xea, 0x3d, 0x6f, 0xb2, 0xe7, 0xec, 0xc7, 0x77, 0xb5, 0x82, 0x17, 0xbd, 0xef, 0x12,
+	0xcb, 0x19, 0xce, 0x1d, 0x5d, 0x99, 0xa3, 0xb6, 0x87, 0xfa, 0x33, 0x50, 0x75, 0x26, 0x38, 0x9a,
+	0x0c, 0x18, 0x61, 0x7a, 0x15, 0x90, 0x48, 0x24, 0x7d, 0x11, 0x2a, 0xae, 0x37, 0xb0, 0x43, 0x07,
+	0x6f, 0x0e, 0xee, 0x3b, 0x2f, 0xb7, 0x71, 0x8b, 0xbb, 0x8d, 0x1d, 0x81, 0x33, 0x63, 0x2a, 0xe3,
+	0xa3, 0x1c, 0x34, 0xa4, 0x58, 0xfc, 0xc6, 0xe9, 0x8f, 0x40, 0x29, 0x74, 0x03, 0xeb, 0x80, 0x1c,
+	0x31, 0xa9, 0x6a, 0x78, 0x13, 0xdc, 0xe0, 0x26, 0x39, 0xd2, 0xcf, 0x41, 0x99, 0x22, 0x06, 0xc4,
+	0x0f, 0x99, 0x18, 0x35, 0x93, 0x12, 0xf6, 0x70, 0xa8, 0x7f, 0x0d, 0x2a, 0xcc, 0x8b, 0x59, 0x53,
+	0x34, 0xc3, 0x3c, 0xc3, 0x95, 0x19, 0xe0, 0x36, 0x5a, 0xa0, 0x01, 0xf5, 0x60, 0xcb, 0xc2, 0xa3,
+	0x24, 0x01, 0x67, 0xcb, 0x1d, 0x48, 0x35, 0xd8, 0xea, 0x32, 0x18, 0xe5, 0xcd, 0x69, 0x02, 0x32,
+	0xf0, 0x49, 0xc8, 0x68, 0x8a, 0x92, 0x66, 0x97, 0xc1, 0x28, 0x0d, 0x2e, 0x82, 0x34, 0xfd, 0xd9,
+	0xe0, 0x80, 0x70, 0xd3, 0xaa, 0xa0, 0x9a, 0xb6, 0xae, 0xb1, 0x31, 0x45, 0x3a, 0x63, 0x7b, 0x9f,
+	0x58, 0xa1, 0xbd, 0xdf, 0x2e, 0x71, 0x24, 0x03, 0xdc, 0xb1, 0xf7, 0xf5, 0x8b, 0xd0, 0xb2, 0xc5,
+	0x91, 0x5b, 0x03, 0x6f, 0x3c, 0xf5, 0x71, 0x55, 0xcf, 0x6f, 0x97, 0x19, 0x99, 0x2e, 0x51, 0xbd,
+	0x08, 0x63, 0xfc, 0x31, 0x0f, 0xcd, 0x1e, 0x41, 0xeb, 0xb0, 0x5d, 0x69, 0x2b, 0xfa, 0x77, 0x61,
+	0x4d, 0x98, 0xa3, 0x15, 0xd9, 0xa2, 0x16, 0x2b, 0x39, 0x6d, 0x2b, 0x4d, 0x3b, 0x65, 0xea, 0x5f,
+	0x47, 0x83, 0xe1, 0x47, 0x6f, 0xe1, 0x89, 0x85, 0xdc, 0x37, 0x95, 0xd1, 0x4c, 0x38, 0x70, 0x97,
+	0xc2, 0xf4, 0x2b, 0xd0, 0x9c, 0x90, 0x43, 0x4b, 0x75, 0x07, 0xdc, 0x39, 0x35, 0x12, 0xee, 0x20,
+	0x30, 0x31, 0x16, 0x1c, 0x2a, 0x2e, 0xe4, 0x55, 0x68, 0xa2, 0xe8, 0x9e, 0x8b, 0xa6, 0x66, 0x31,
+	0xbb, 0xa3, 0x17, 0xf8, 0x58, 0xd9, 0x1a, 0x92, 0x96, 0xdd, 0x9c, 0x00, 0xb7, 0xd6, 0x12, 0x56,
+	0x9c, 0x58, 0xb9, 0x98, 0xb9, 0xf2, 0xba, 0x20, 0x55, 0x56, 0x47, 0xbf, 0xf7, 0xfe, 0xcc, 0x0b,
+	0xed, 0x40, 0x78, 0x77, 0xe6, 0xf7, 0xde, 0xa6, 0x10, 0xba, 0xab, 0x19, 0x3a, 0x48, 0x8e, 0xd6,
+	0x5f, 0x86, 0x06, 0x1e, 0x21, 0x1e, 0xe8, 0x10, 0x95, 0xeb, 0xd8, 0xe8, 0x66, 0x4a, 0x6c, 0x8d,
+	0x75, 0x76, 0x9b, 0xb7, 0x7a, 0x31, 0xc2, 0x44, 0x7b, 0x50, 0x86, 0x78, 0x49, 0xcf, 0x26, 0x67,
+	0xe2, 0x36, 0x91, 0x37, 0xde, 0xbb, 0x32, 0x53, 0xe3, 0x46, 0x82, 0xdc, 0xe4, 0x38, 0x83, 0x40,
+	0x3d, 0xc1, 0x55, 0x7f, 0x0c, 0x40, 0x31, 0x44, 0x8d, 0x19, 0x40, 0xc5, 0x8e, 0xcc, 0x10, 0xd1,
+	0x8a, 0x0d, 0xe6, 0x38, 0x3a, 0x88, 0x2c, 0x10, 0x3d, 0x9b, 0x30, 0x3f, 0xee, 0x33, 0xc5, 0xc8,
+	0xf8, 0x8d, 0x06, 0x55, 0x65, 0xbb, 0xff, 0x8b, 0x30, 0xdc, 0x81, 0x32, 0xb9, 0x3f, 0x20, 0x24,
+	0x76, 0x61, 0xd1, 0x58, 0xdf, 0x80, 0x62, 0xff, 0x88, 0x1f, 0xb1, 0x76, 0x21, 0x6f, 0xf2, 0x01,
+	0x9d, 0x81, 0xa9, 0x43, 0x80, 0x26, 0xcf, 0x4f, 0x2e, 0x6f, 0x46, 0x63, 0xe3, 0xf7, 0x45, 0xa8,
+	0x7e, 0x7f, 0xd6, 0x8f, 0x6c, 0xf9, 0x65, 0x28, 0xe1, 0x22, 0xe8, 0xba, 0xf6, 0x85, 0x80, 0x8f,
+	0xd3, 0xd5, 0x15, 0x0a, 0xfa, 0xdd, 0x24, 0xfb, 0x4e, 0x80, 0x57, 0x80, 0xf9, 0x8c, 0xd5, 0x11,
+	0x03, 0x60, 0x28, 0x2f, 0x05, 0xa8, 0x4c, 0xcb, 0x0e, 0x85, 0xdc, 0x2c, 0x4e, 0xdd, 0x91, 0x59,
+	0x0b, 0x46, 0x38, 0xc4, 0x76, 0x43, 0x8c, 0x69, 0x45, 0x6e, 0xe5, 0xdc, 0x7c, 0xdb, 0x19, 0xfc,
+	0x99, 0xc5, 0x9b, 0x9c, 0x0c, 0x1d, 0x40, 0x81, 0x66, 0x3a, 0xc2, 0x6a, 0x99, 0xcd, 0xdd, 0xc0,
+	0xb1, 0x49, 0x06, 0x9e, 0x3f, 0x34, 0x19, 0xae, 0xf3, 0x21, 0x06, 0x90, 0x94, 0x5c, 0x0b, 0x43,
+	0xdf, 0xd3, 0x78, 0x9a, 0xdc, 0xc3, 0x66, 0xa9, 0x59, 0x78, 0x5f, 0x64, 0xf8, 0x00, 0x8e, 0xb3,
+	0xf3, 0x69, 0x0e, 0xca, 0x72, 0x0f, 0xfa, 0xb7, 0x60, 0x1d, 0xd5, 0x8c, 0x5a, 0xc1, 0x04, 0x71,
+	0x42, 0x06, 0x9c, 0x8f, 0xc6, 0xce, 0x60, 0x8d, 0x21, 0x7a, 0x31, 0x9c, 0xfa, 0x01, 0x61, 0x00,
+	0x01, 0x3a, 0x12, 0x32, 0x61, 0x82, 0xe5, 0xcd, 0x9a, 0x04, 0xee, 0x22, 0x0c, 0x45, 0x6f, 0x46,
+	0x44, 0x03, 0x7b, 0x30, 0x12, 0x56, 0x90, 0x37, 0x1b, 0x12, 0xdc, 0x63, 0x50, 0xfd, 0x49, 0xa8,
+	0x71, 0xbc, 0xa5, 0x9a, 0x44, 0x95, 0xc3, 0xae, 0x31, 0xc3, 0xe8, 0xc1, 0x59, 0xd7, 0xa6, 0x5e,
+	0x67, 0xc6, 0xec, 0x7c, 0x6f, 0xe6, 0x5a, 0xb3, 0x29, 0xe6, 0x5b, 0x44, 0x5c, 0xf0, 0xd4, 0x09,
+	0x6e, 0x50, 0xe2, 0xdd, 0x88, 0xf6, 0x2e, 0x23, 0xd5, 0xbb, 0x70, 0x86, 0x31, 0xb1, 0xc3, 0x90,
+	0x8c, 0xa7, 0x78, 0xb7, 0x24, 0x8f, 0xd5, 0x2c, 0x1e, 0x2d, 0x4a, 0xdb, 0x95, 0xa4, 0x82, 0xc5,
+	0x65, 0x68, 0x0a, 0x51, 0xe5, 0x1e, 0x44, 0x9a, 0x94, 0xb8, 0x1d, 0x0d, 0x4e, 0x23, 0x86, 0x81,
+	0xf1, 0x0e, 0x94, 0x50, 0xcf, 0xdb, 0x93, 0x3d, 0x4f, 0xa4, 0x32, 0x5a, 0x46, 0x2a, 0x93, 0x38,
+	0xc0, 0xdc, 0xa9, 0x22, 0x1f, 0x66, 0x23, 0xb0, 0x83, 0x76, 0xf4, 0xd6, 0x1e, 0xb2, 0x0f, 0xf4,
+	0xc7, 0xa1, 0x80, 0x46, 0x22, 0x3d, 0x7a, 0x55, 0x98, 0x2b, 0x5d, 0xd6, 0x64, 0x08, 0x5c, 0xbc,
+	0x14, 0x1c, 0x38, 0xd3, 0xa9, 0x88, 0xf4, 0x45, 0x53, 0x0e, 0x29, 0xe6, 0x1e, 0xf1, 0x03, 0xe4,
+	0x2a, 0xdc, 0x82, 0x1c, 0xd2, 0xc3, 0x99, 0x78, 0xa1, 0x35, 0xf6, 0x86, 0xce, 0x9e, 0x83, 0x13,
+	0x0b, 0xec, 0x22, 0x57, 0x11, 0xf6, 0xa6, 0x00, 0x19, 0x1f, 0xb0, 0xed, 0xed, 0x1e, 0x4d, 0x06,
+	0x0b, 0xb6, 0x97, 0x48, 0x15, 0x72, 0xc7, 0xa6, 0x0a, 0x9b, 0x4a, 0x96, 0xc4, 0xad, 0x58, 0x57,
+	0xb3, 0x24, 0x1e, 0x67, 0xe2, 0x3c, 0xc9, 0xb8, 0xc2, 0xae, 0x13, 0x5d, 0x3b, 0x0a, 0xfe, 0x68,
+	0x9c, 0x02, 0x6d, 0xc5, 0xfe, 0x0b, 0x8d, 0x53, 0x00, 0x7b, 0x14, 0x66, 0x7c, 0xac, 0x81, 0x1e,
+	0xdd, 0x43, 0xe2, 0xff, 0x5f, 0x25, 0x34, 0x6f, 0x40, 0x2b, 0x21, 0x9a, 0xd8, 0xd7, 0x0b, 0x78,
+	0x4d, 0x78, 0xf1, 0x66, 0xd1, 0x0a, 0x4b, 0x88, 0x97, 0xb2, 0xda, 0xaa, 0x20, 0xa1, 0x10, 0x63,
+	0x04, 0x1b, 0xc8, 0xe8, 0xba, 0x13, 0x88, 0x3b, 0xfd, 0xd0, 0x76, 0x69, 0xbc, 0x07, 0x2d, 0x71,
+	0x44, 0x77, 0x68, 0xca, 0x24, 0x17, 0xc2, 0x2c, 0x76, 0x62, 0xa3, 0x68, 0x53, 0x7b, 0x40, 0x64,
+	0xa4, 0x8a, 0x00, 0xc8, 0xbf, 0x42, 0x3d, 0x38, 0x4a, 0x87, 0x19, 0x7c, 0x56, 0x95, 0x53, 0x46,
+	0xf4, 0x2e, 0xc5, 0x1a, 0xcf, 0xc1, 0x46, 0x92, 0xbf, 0xd0, 0x09, 0x86, 0x11, 0x96, 0xa3, 0x09,
+	0xe6, 0x7c, 0x80, 0xa5, 0x49, 0x8b, 0x5e, 0x8b, 0x28, 0x57, 0x58, 0xaa, 0xb2, 0x34, 0x5e, 0x83,
+	0x8d, 0xe4, 0x6c, 0xb1, 0xd6, 0xd3, 0x8a, 0x69, 0x2a, 0x57, 0x4c, 0x9a, 0x66, 0x6c, 0x93, 0x9f,
+	0x69, 0x50, 0x12, 0xd0, 0x05, 0x17, 0x62, 0x51, 0xe4, 0x7c, 0xf0, 0xb2, 0x46, 0x2d, 0x53, 0x8b,
+	0xc7, 0x97, 0xa9, 0xaa, 0x2e, 0x56, 0x17, 0xe8, 0xe2, 0x17, 0x1a, 0x9c, 0xd9, 0x0d, 0x7d, 0x62,
+	0x8f, 0xd3, 0xca, 0x5c, 0x7c, 0xb4, 0x72, 0x03, 0xb9, 0xcc, 0x0d, 0xe4, 0x17, 0x6c, 0x00, 0xd3,
+	0x97, 0xbe, 0x1d, 0x0e, 0x46, 0x56, 0xe0, 0x7c, 0xc0, 0xeb, 0xf4, 0xa2, 0x59, 0x61, 0x90, 0x5d,
+	0x04, 0x18, 0x7b, 0xb0, 0x8e, 0xb5, 0x8b, 0x94, 0x73, 0xb9, 0x96, 0x41, 0x5c, 0x06, 0xe7, 0x4e,
+	0x2a, 0x83, 0x0d, 0x07, 0x36, 0x30, 0xe7, 0x42, 0x97, 0xff, 0xf0, 0x97, 0xfa, 0x31, 0x9c, 0x49,
+	0x2d, 0x25, 0x0c, 0xee, 0x21, 0xac, 0xf5, 0x73, 0x0d, 0x5a, 0xa8, 0xbf, 0xb8, 0x26, 0x17, 0xdb,
+	0x8a, 0xcf, 0x46, 0x5b, 0x70, 0x36, 0x8a, 0x40, 0xb9, 0xc5, 0xad, 0x8b, 0x93, 0x9b, 0x12, 0xc6,
+	0x2a, 0x14, 0x6e, 0x79, 0xde, 0x14, 0xf3, 0xdb, 0xb3, 0xbc, 0xc0, 0x7c, 0xa8, 0x42, 0x19, 0x9f,
+	0xa2, 0xc3, 0xe7, 0x6a, 0x4e, 0x78, 0xa8, 0x53, 0xea, 0xf8, 0x2a, 0x4d, 0x51, 0xa6, 0x76, 0xdf,
+	0x71, 0x9d, 0xd0, 0x21, 0x89, 0xf8, 0xcc, 0xd8, 0xf5, 0x24, 0xf2, 0xe8, 0x5a, 0xe1, 0xb3, 0xbf,
+	0x3c, 0xbe, 0x62, 0x26, 0xc8, 0x31, 0x6d, 0x68, 0xdc, 0xb3, 0x5d, 0x67, 0x68, 0x0d, 0x67, 0x3c,
+	0xe7, 0x13, 0x9a, 0x49, 0x39, 0xef, 0x3a, 0x23, 0xba, 0x2e, 0x68, 0x8c, 0x0f, 0x73, 0xd0, 0x4a,
+	0x88, 0xbc, 0xc8, 0xe9, 0x61, 0xa0, 0x2e, 0xa0, 0xdf, 0xe7, 0x57, 0xae, 0x21, 0x38, 0xb3, 0x69,
+	0x08, 0x34, 0x19, 0x0a, 0x23, 0x23, 0xaf, 0x68, 0xad, 0x8c, 0xee, 0x58, 0x89, 0x61, 0xb6, 0x87,
+	0xaa, 0x46, 0x0a, 0x4b, 0x68, 0xa4, 0xb8, 0x9c, 0x46, 0x36, 0xa1, 0xca, 0x35, 0x82, 0xbc, 0x1c,
+	0x37, 0x3b, 0x03, 0x03, 0x46, 0x71, 0x97, 0x12, 0x18, 0x07, 0x09, 0x55, 0x44, 0x5e, 0x68, 0x13,
+	0x4d, 0x8d, 0x01, 0x84, 0x47, 0x3e, 0x4b, 0x39, 0xcc, 0x1f, 0xb3, 0x29, 0xa8, 0xd0, 0xa4, 0x1a,
+	0xb6, 0xeb, 0x5a, 0x9e, 0x6f, 0x61, 0x02, 0x33, 0x72, 0x26, 0xfb, 0xb2, 0x82, 0x45, 0xe8, 0x5b,
+	0xfe, 0x2d, 0x0e, 0xc3, 0x08, 0xb0, 0x9e, 0xd4, 0xfb, 0xcc, 0x0d, 0x8f, 0xd1, 0x3a, 0x42, 0x89,
+	0xef, 0x63, 0x21, 0xce, 0x3d, 0x1d, 0x1f, 0x60, 0x04, 0xdf, 0x48, 0x4a, 0x2b, 0x4e, 0xee, 0x22,
+	0x94, 0x7c, 0xc6, 0x4d, 0xca, 0x7b, 0x66, 0x4e, 0x5e, 0x8a, 0x35, 0x25, 0x95, 0x71, 0x11, 0x6b,
+	0x78, 0x1e, 0xd0, 0x65, 0x3a, 0xb0, 0xd8, 0xf1, 0x1a, 0x4f, 0x41, 0x4d, 0x4c, 0xb8, 0x23, 0xe5,
+	0xcb, 0x08, 0x90, 0xcf, 0x42, 0x85, 0xa1, 0x59, 0x4a, 0x8a, 0x1e, 0x77, 0x3a, 0xeb, 0xbb, 0xce,
+	0x40, 0xe9, 0x97, 0x54, 0x38, 0x04, 0x0b, 0x46, 0xa3, 0xc7, 0x83, 0xa9, 0x4c, 0x66, 0xa5, 0xe6,
+	0x91, 0x31, 0xf3, 0x29, 0x6c, 0x42, 0xd1, 0xe4, 0x03, 0x5a, 0x5d, 0x8e, 0x6d, 0xff, 0x80, 0xf8,
+	0xa2, 0xbb, 0x22, 0x46, 0xc6, 0x8f, 0x78, 0x4c, 0x8d, 0x99, 0xc4, 0x31, 0x35, 0x4a, 0xa4, 0xb5,
+	0xf9, 0x44, 0x3a, 0x42, 0x62, 0x6e, 0x5b, 0x9d, 0x90, 0xfb, 0x98, 0x87, 0xaa, 0xdc, 0x81, 0x82,
+	0xde, 0xe4, 0x2b, 0xdc, 0x87, 0xb5, 0x37, 0xed, 0x09, 0x56, 0x2a, 0x63, 0x5a, 0xab, 0xb8, 0x0e,
+	0xfe, 0x5d, 0x10, 0x7c, 0x13, 0x4a, 0xcc, 0xa5, 0xa3, 0xd7, 0x73, 0x00, 0x03, 0x76, 0x26, 0x43,
+	0x5a, 0x23, 0x66, 0x5e, 0xd5, 0x8a, 0x20, 0xe8, 0x86, 0xc6, 0x0e, 0x3c, 0x4a, 0xf7, 0x96, 0x5e,
+	0xfd, 0x01, 0x35, 0x35, 0x85, 0xc7, 0x8e, 0xe1, 0x26, 0x54, 0xb6, 0x09, 0xa5, 0x01, 0x07, 0x09,
+	0x8d, 0x6d, 0x50, 0xc9, 0xd2, 0xf4, 0xa6, 0x24, 0x3a, 0x59, 0x73, 0x5d, 0x58, 0xa7, 0x2b, 0x26,
+	0x2f, 0xd6, 0x72, 0x42, 0xff, 0x3a, 0x07, 0xd5, 0xed, 0x20, 0x98, 0x91, 0x21, 0xb7, 0x3a, 0xd5,
+	0xd1, 0x68, 0xc7, 0x39, 0x9a, 0x53, 0x38, 0x2c, 0xc5, 0x17, 0xe5, 0x97, 0xf0, 0x45, 0x85, 0xff,
+	0xca, 0x17, 0x15, 0x4f, 0xf0, 0x45, 0x18, 0x70, 0x2b, 0x0e, 0xdb, 0x2c, 0xb5, 0x8e, 0x4c, 0xcf,
+	0x55, 0xe6, 0x78, 0x34, 0x8e, 0xf7, 0x40, 0x57, 0x95, 0x1b, 0x99, 0x7d, 0xd2, 0x6d, 0xb1, 0x66,
+	0x93, 0xa2, 0xc0, 0xc8, 0x5f, 0x9d, 0x78, 0x78, 0x1f, 0xe7, 0xa0, 0xf1, 0xee, 0xc8, 0xeb, 0x8e,
+	0xb7, 0x23, 0xe6, 0x52, 0xaf, 0xda, 0xe9, 0x02, 0x41, 0xee, 0x14, 0x81, 0xe0, 0x21, 0x2a, 0xff,
+	0x19, 0xd6, 0x92, 0xa4, 0xdd, 0xac, 0xf8, 0x42, 0xf2, 0xc6, 0x69, 0x93, 0xc3, 0x6f, 0x45, 0xd7,
+	0x72, 0xd9, 0x98, 0xf1, 0x09, 0xc6, 0x4f, 0x26, 0x82, 0xe8, 0xe0, 0xc5, 0x05, 0xe2, 0x29, 0xac,
+	0x13, 0x7d, 0x00, 0xad, 0x7b, 0xfb, 0x64, 0xcf, 0xf3, 0x49, 0x76, 0x9f, 0xa8, 0x82, 0x04, 0xd7,
+	0x18, 0x3e, 0x2d, 0x5a, 0xfe, 0x24, 0x13, 0x42, 0xff, 0xe3, 0x93, 0x09, 0x39, 0xa4, 0x95, 0x96,
+	0x28, 0xa9, 0x63, 0x80, 0x7e, 0x09, 0xce, 0x1c, 0x3a, 0x34, 0x14, 0x59, 0x1c, 0xe6, 0x5a, 0x87,
+	0xce, 0x64, 0xe8, 0x1d, 0x8a, 0x77, 0x8a, 0x16, 0x47, 0x9a, 0x1c, 0xf7, 0x2e, 0x43, 0x51, 0x09,
+	0x18, 0xb1, 0x65, 0xef, 0x61, 0x94, 0x38, 0x46, 0x39, 0x8c, 0xa2, 0x4b, 0x09, 0xb0, 0x70, 0xae,
+	0xbf, 0x7e, 0x7f, 0xea, 0xf9, 0x4b, 0x66, 0xb6, 0xc6, 0x1f, 0x34, 0xfa, 0xf6, 0xc0, 0xbe, 0xf3,
+	0xa6, 0xfb, 0x43, 0x48, 0x53, 0xd3, 0xaf, 0x49, 0xf9, 0x13, 0x5e, 0x93, 0x12, 0x5d, 0x83, 0xc2,
+	0x29, 0xba, 0x06, 0xaf, 0x40, 0x7d, 0x7b, 0xac, 0x6e, 0xfe, 0x19, 0x58, 0x1d, 0xb0, 0xdd, 0x88,
+	0x2d, 0xac, 0x2b, 0xc2, 0x89, 0xb7, 0x05, 0x41, 0x60, 0xfc, 0x54, 0x63, 0x21, 0x96, 0xd6, 0xd3,
+	0x64, 0x48, 0x3b, 0x6f, 0x6b, 0x71, 0xfb, 0xae, 0x22, 0xdf, 0xab, 0x4a, 0x43, 0xdf, 0x8b, 0xfa,
+	0x2c, 0x79, 0x53, 0x0e, 0xe9, 0x7d, 0xc6, 0x05, 0x67, 0xc4, 0x1a, 0x92, 0x69, 0x38, 0x12, 0xfd,
+	0x30, 0x60, 0xa0, 0xeb, 0x14, 0x82, 0xf5, 0x5b, 0x73, 0x6c, 0xdf, 0xb7, 0x54, 0x22, 0xde, 0x0e,
+	0xab, 0x23, 0xf8, 0xed, 0x88, 0xce, 0xb8, 0x8a, 0x45, 0x83, 0x22, 0x44, 0x6c, 0xdc, 0x4f, 0x25,
+	0x9a, 0x40, 0xec, 0x89, 0x49, 0x25, 0xe4, 0x9d, 0x20, 0xe3, 0x2e, 0x6b, 0x9b, 0xd0, 0xee, 0x24,
+	0x6b, 0x87, 0x10, 0x3f, 0xc8, 0xd8, 0x86, 0xda, 0x8d, 0xcd, 0x25, 0xbb, 0xb1, 0x71, 0xff, 0x36,
+	0xaf, 0xf4, 0x6f, 0x69, 0xe9, 0xac, 0xf2, 0x54, 0xfc, 0x9d, 0x2a, 0x54, 0x4b, 0x74, 0xa6, 0x12,
+	0xa4, 0x5c, 0xae, 0x1f, 0xd2, 0x3e, 0x42, 0xb8, 0x2b, 0x1f, 0xba, 0x96, 0xcc, 0xd2, 0x13, 0x8f,
+	0x66, 0xb9, 0xf4, 0xa3, 0xd9, 0x4d, 0x58, 0xbf, 0x3b, 0xf1, 0x53, 0x0d, 0x9f, 0xc5, 0x65, 0x2c,
+	0x1e, 0xe4, 0xc0, 0x0e, 0x06, 0xf6, 0x90, 0x08, 0x76, 0x72, 0x88, 0x19, 0x54, 0xa3, 0xeb, 0xba,
+	0x5c, 0xf3, 0x9c, 0x93, 0xd2, 0x42, 0xd3, 0x12, 0x2d, 0x34, 0x2c, 0xf0, 0x5a, 0x26, 0x7f, 0xac,
+	0xb8, 0x4e, 0xfa, 0xb3, 0xe8, 0xf1, 0x0c, 0xd5, 0x3b, 0xf2, 0x82, 0x90, 0xae, 0x26, 0x66, 0x44,
+	0x63, 0x5a, 0x3f, 0x4f, 0x6d, 0x3c, 0x7b, 0x51, 0x3f, 0xd3, 0xef, 0xb4, 0xaf, 0x85, 0x06, 0xe1,
+	0x7a, 0x47, 0x34, 0xca, 0xcb, 0x14, 0xbe, 0x62, 0xd6, 0x62, 0xe0, 0xf6, 0xd0, 0xf8, 0xb7, 0x06,
+	0x1b, 0xc9, 0xc5, 0x96, 0x2b, 0x26, 0xe3, 0xda, 0x2d, 0xb7, 0xe0, 0x41, 0x19, 0xcd, 0x98, 0x8a,
+	0x64, 0x4d, 0x7d, 0xb2, 0xe7, 0xdc, 0x17, 0x82, 0x00, 0x05, 0xdd, 0x66, 0x90, 0xe4, 0x49, 0x14,
+	0x52, 0x27, 0x41, 0x7b, 0xcd, 0xe8, 0x87, 0xe8, 0x2b, 0x52, 0x2c, 0xbb, 0xf0, 0x6d, 0x6b, 0x1c,
+	0x71, 0x3d, 0x82, 0x27, 0xde, 0x4d, 0x57, 0x4f, 0x7a, 0x37, 0xc5, 0x43, 0xae, 0x2a, 0x8f, 0xd5,
+	0xca, 0x76, 0xb4, 0x05, 0xdb, 0x89, 0x5f, 0x7b, 0x73, 0x89, 0xd7, 0xde, 0x97, 0xa0, 0xa6, 0x30,
+	0x53, 0xdf, 0xc6, 0xb5, 0xc5, 0x6f, 0xe3, 0xbf, 0xd2, 0xe0, 0x1c, 0x2e, 0x10, 0x39, 0x2b, 0x3e,
+	0x7f, 0x49, 0x6b, 0x3e, 0xdd, 0x2f, 0x00, 0x62, 0x99, 0xf2, 0x8b, 0x65, 0x7a, 0x05, 0xd6, 0xb7,
+	0x27, 0x2c, 0x04, 0xd9, 0xe1, 0x92, 0x3f, 0xb6, 0xb8, 0xf4, 0x8f, 0x42, 0x54, 0x87, 0x44, 0x6f,
+	0x81, 0x2f, 0x01, 0x74, 0x87, 0x43, 0xd9, 0xe7, 0xca, 0xf0, 0xb7, 0x9d, 0x56, 0x02, 0x26, 0x7e,
+	0x0c, 0xb1, 0xa2, 0xa3, 0xf3, 0xe5, 0x05, 0xff, 0x03, 0xcc, 0xed, 0x41, 0x4d, 0xed, 0xcd, 0xe9,
+	0x8f, 0x30, 0x9d, 0xcc, 0xf7, 0xfa, 0x3a, 0xed, 0x79, 0x44, 0xc4, 0x64, 0x1b, 0x1a, 0xc9, 0x9e,
+	0x96, 0x7e, 0x8e, 0xad, 0x96, 0xd5, 0xe7, 0x5a, 0xc4, 0xe8, 0x05, 0x4d, 0xbf, 0x02, 0xd5, 0x1b,
+	0x24, 0x1c, 0x8c, 0x44, 0x28, 0x5c, 0x17, 0xee, 0x36, 0x7e, 0x22, 0xef, 0xe8, 0x2a, 0x28, 0x12,
+	0xe1, 0x55, 0x29, 0x42, 0xf4, 0x9c, 0xd5, 0x4c, 0xbd, 0x2e, 0x71, 0x0d, 0xa4, 0x1e, 0x70, 0x8d,
+	0x95, 0x0b, 0x1a, 0xae, 0xfa, 0x3c, 0x94, 0x68, 0xc7, 0x9b, 0x06, 0x1f, 0xd9, 0xe5, 0xa7, 0xe3,
+	0x4e, 0x4b, 0x19, 0x28, 0x8b, 0x7d, 0x1b, 0xea, 0x89, 0x36, 0xb0, 0x2e, 0x5f, 0xb2, 0xe6, 0x3a,
+	0xc3, 0x1d, 0x96, 0x08, 0xb1, 0xb6, 0xcc, 0x0a, 0x2d, 0x56, 0x85, 0x8b, 0xe3, 0x27, 0x94, 0xf4,
+	0x77, 0x9d, 0x86, 0x54, 0x0c, 0x7f, 0x7d, 0xc0, 0x09, 0x3f, 0xa0, 0x7e, 0x8e, 0x3f, 0x04, 0x2b,
+	0xbd, 0x5a, 0x7e, 0x46, 0x19, 0xdd, 0x61, 0xae, 0xda, 0xac, 0xb6, 0xae, 0xb1, 0x72, 0xe9, 0x5f,
+	0x55, 0xac, 0xc1, 0xb9, 0xc5, 0xc5, 0xa5, 0x8d, 0xbe, 0x05, 0xe5, 0xa8, 0x0e, 0x6e, 0x09, 0xc5,
+	0xaa, 0xc5, 0x71, 0x67, 0x4d, 0x01, 0x32, 0x96, 0x6c, 0x1f, 0x10, 0xb7, 0x0c, 0x75, 0x56, 0x71,
+	0xcf, 0xb5, 0x10, 0x13, 0x1b, 0xbf, 0x01, 0xf5, 0x44, 0x43, 0x8e, 0xeb, 0x2b, 0xab, 0x1d, 0xd8,
+	0x39, 0x97, 0x81, 0x89, 0xf4, 0xbe, 0x05, 0x35, 0xb5, 0xd7, 0xc6, 0x15, 0x91, 0xd1, 0x7d, 0x4b,
+	0x2c, 0xfe, 0x1d, 0x68, 0xa6, 0xda, 0x61, 0x7a, 0x87, 0xa2, 0xb3, 0x7b, 0x64, 0x89, 0xa9, 0xdf,
+	0x83, 0xaa, 0xd2, 0x4a, 0xd0, 0x8f, 0xe9, 0x85, 0x74, 0x1e, 0x99, 0xef, 0x39, 0x28, 0xd7, 0x4b,
+	0xed, 0x5b, 0xe8, 0x69, 0xd2, 0xe4, 0xad, 0xc8, 0x6a, 0x71, 0x20, 0x93, 0xcb, 0x98, 0x5c, 0xd1,
+	0x52, 0x06, 0xad, 0x82, 0x0b, 0x12, 0xc9, 0xb8, 0x68, 0xe9, 0x4d, 0x58, 0x7f, 0x83, 0xf0, 0x3a,
+	0xe9, 0xb6, 0xec, 0x3d, 0x28, 0x33, 0xe3, 0x32, 0x86, 0xf6, 0x2c, 0x62, 0x4f, 0x20, 0x3b, 0x0a,
+	0xb1, 0x27, 0x48, 0x35, 0x2a, 0xe2, 0x0b, 0x9c, 0x6e, 0x3e, 0x20, 0x93, 0xf7, 0xe0, 0x4c, 0x66,
+	0xb1, 0xad, 0x3f, 0x21, 0x27, 0x1d, 0x57, 0xd5, 0x77, 0x9e, 0x5c, 0x40, 0x11, 0xf1, 0x7f, 0x0d,
+	0x3a, 0x71, 0xca, 0x31, 0xd7, 0x9e, 0x60, 0xa6, 0x38, 0x97, 0x92, 0x24, 0x8e, 0xf4, 0x02, 0xac,
+	0xf2, 0xea, 0x4e, 0x51, 0x05, 0xbb, 0x8c, 0xc9, 0x9a, 0x0f, 0x29, 0x2f, 0x61, 0xe0, 0x8b, 0x6b,
+	0x9d, 0xb4, 0xce, 0x33, 0xca, 0x20, 0x9c, 0xf3, 0x22, 0x00, 0x2b, 0x22, 0x96, 0x38, 0xa6, 0xab,
+	0xd0, 0xe2, 0x65, 0x43, 0xb2, 0x06, 0x60, 0x8e, 0x2f, 0x51, 0x4f, 0x74, 0xe6, 0x53, 0x68, 0x66,
+	0x1b, 0x2d, 0x9e, 0x78, 0x67, 0x4c, 0x4f, 0x64, 0xe4, 0x09, 0x2d, 0x5c, 0x61, 0xbf, 0x3b, 0x8a,
+	0x93, 0x5d, 0x45, 0xd4, 0x73, 0xe9, 0x04, 0x37, 0x69, 0x89, 0xb5, 0x44, 0x8a, 0x1b, 0x4f, 0x6b,
+	0xcb, 0xc7, 0xf9, 0x74, 0xaa, 0xca, 0x6e, 0xe0, 0x3a, 0x8e, 0x48, 0xf8, 0x00, 0x53, 0x5f, 0x61,
+	0xe9, 0xab, 0xfc, 0xa9, 0x4a, 0x94, 0xef, 0x08, 0x0f, 0x38, 0x97, 0xd7, 0x26, 0x36, 0xd9, 0x83,
+	0x35, 0x6a, 0x4e, 0x4a, 0x17, 0x20, 0xe0, 0x16, 0x32, 0xd7, 0x9c, 0xe9, 0x9c, 0x4d, 0x83, 0xd5,
+	0x0b, 0xac, 0x66, 0x7f, 0x7c, 0xe5, 0x8c, 0xe4, 0x93, 0xef, 0x22, 0x2b, 0x51, 0x64, 0x56, 0xab,
+	0xcf, 0x27, 0x2f, 0xfa, 0x63, 0x62, 0x13, 0xd9, 0x49, 0x4d, 0x62, 0x2b, 0x57, 0xa1, 0x1d, 0xa7,
+	0x1a, 0xd2, 0x2f, 0x62, 0xa2, 0xe6, 0x4c, 0xf6, 0xf9, 0x96, 0xe6, 0x12, 0x11, 0x75, 0xfa, 0xb5,
+	0xcb, 0x9f, 0x7f, 0x79, 0x7e, 0xe5, 0x0b, 0xfc, 0xfc, 0xf3, 0xcb, 0xf3, 0xda, 0x4f, 0xbe, 0x3a,
+	0xaf, 0xfd, 0x16, 0x3f, 0x9f, 0xe1, 0xe7, 0x73, 0xfc, 0xfc, 0x15, 0x3f, 0x7f, 0xff, 0x0a, 0x71,
+	0xf8, 0xff, 0x97, 0x7f, 0x3b, 0xbf, 0xf2, 0x39, 0x7e, 0xbe, 0xc0, 0x4f, 0x7f, 0x95, 0xfd, 0xa2,
+	0x74, 0xeb, 0x3f, 0x30, 0x05, 0x44, 0x37, 0xe2, 0x2a, 0x00, 0x00,
+}
+
+func (this *ServiceRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*HubRegisterRequest)
+	that1, ok := that.(*ServiceRequest)
 	if !ok {
-		that2, ok := that.(HubRegisterRequest)
+		that2, ok := that.(ServiceRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2712,30 +4321,39 @@ func (this *HubRegisterRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.StableId.Equal(that1.StableId) {
+	if !this.Account.Equal(that1.Account) {
 		return false
 	}
-	if !this.InstanceId.Equal(that1.InstanceId) {
+	if !this.Hub.Equal(that1.Hub) {
 		return false
 	}
-	if len(this.Locations) != len(that1.Locations) {
+	if !this.Id.Equal(that1.Id) {
 		return false
 	}
-	for i := range this.Locations {
-		if !this.Locations[i].Equal(that1.Locations[i]) {
+	if this.Type != that1.Type {
+		return false
+	}
+	if !this.Labels.Equal(that1.Labels) {
+		return false
+	}
+	if len(this.Metadata) != len(that1.Metadata) {
+		return false
+	}
+	for i := range this.Metadata {
+		if !this.Metadata[i].Equal(that1.Metadata[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *HubRegisterResponse) Equal(that interface{}) bool {
+func (this *ServiceResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*HubRegisterResponse)
+	that1, ok := that.(*ServiceResponse)
 	if !ok {
-		that2, ok := that.(HubRegisterResponse)
+		that2, ok := that.(ServiceResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2747,19 +4365,16 @@ func (this *HubRegisterResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.ControlTime.Equal(that1.ControlTime) {
-		return false
-	}
 	return true
 }
-func (this *HubDisconnectRequest) Equal(that interface{}) bool {
+func (this *LabelLink) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*HubDisconnectRequest)
+	that1, ok := that.(*LabelLink)
 	if !ok {
-		that2, ok := that.(HubDisconnectRequest)
+		that2, ok := that.(LabelLink)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2771,22 +4386,36 @@ func (this *HubDisconnectRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.StableId.Equal(that1.StableId) {
+	if !this.Account.Equal(that1.Account) {
 		return false
 	}
-	if !this.InstanceId.Equal(that1.InstanceId) {
+	if !this.Labels.Equal(that1.Labels) {
+		return false
+	}
+	if !this.Target.Equal(that1.Target) {
+		return false
+	}
+	if !this.Limits.Equal(that1.Limits) {
+		return false
+	}
+	if len(this.Splits) != len(that1.Splits) {
 		return false
 	}
+	for i := range this.Splits {
+		if !this.Splits[i].Equal(that1.Splits[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *ServiceTokenRequest) Equal(that interface{}) bool {
+func (this *LabelLinks) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ServiceTokenRequest)
+	that1, ok := that.(*LabelLinks)
 	if !ok {
-		that2, ok := that.(ServiceTokenRequest)
+		that2, ok := that.(LabelLinks)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2798,19 +4427,24 @@ func (this *ServiceTokenRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Namespace != that1.Namespace {
+	if len(this.LabelLinks) != len(that1.LabelLinks) {
 		return false
 	}
+	for i := range this.LabelLinks {
+		if !this.LabelLinks[i].Equal(that1.LabelLinks[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *ServiceTokenResponse) Equal(that interface{}) bool {
+func (this *ServiceRoute) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ServiceTokenResponse)
+	that1, ok := that.(*ServiceRoute)
 	if !ok {
-		that2, ok := that.(ServiceTokenResponse)
+		that2, ok := that.(ServiceRoute)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2822,19 +4456,34 @@ func (this *ServiceTokenResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Token != that1.Token {
+	if !this.Hub.Equal(that1.Hub) {
 		return false
 	}
-	return true
-}
-func (this *ListServicesRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+	if !this.Id.Equal(that1.Id) {
+		return false
+	}
+	if this.Type != that1.Type {
+		return false
+	}
+	if !this.Labels.Equal(that1.Labels) {
+		return false
+	}
+	if this.Unhealthy != that1.Unhealthy {
+		return false
+	}
+	if this.Weight != that1.Weight {
+		return false
+	}
+	return true
+}
+func (this *AccountServices) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
 
-	that1, ok := that.(*ListServicesRequest)
+	that1, ok := that.(*AccountServices)
 	if !ok {
-		that2, ok := that.(ListServicesRequest)
+		that2, ok := that.(AccountServices)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2849,16 +4498,27 @@ func (this *ListServicesRequest) Equal(that interface{}) bool {
 	if !this.Account.Equal(that1.Account) {
 		return false
 	}
+	if len(this.Services) != len(that1.Services) {
+		return false
+	}
+	for i := range this.Services {
+		if !this.Services[i].Equal(that1.Services[i]) {
+			return false
+		}
+	}
+	if this.Suspended != that1.Suspended {
+		return false
+	}
 	return true
 }
-func (this *ListServicesResponse) Equal(that interface{}) bool {
+func (this *ActivityEntry) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ListServicesResponse)
+	that1, ok := that.(*ActivityEntry)
 	if !ok {
-		that2, ok := that.(ListServicesResponse)
+		that2, ok := that.(ActivityEntry)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2870,24 +4530,22 @@ func (this *ListServicesResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Services) != len(that1.Services) {
+	if !this.RouteAdded.Equal(that1.RouteAdded) {
 		return false
 	}
-	for i := range this.Services {
-		if !this.Services[i].Equal(that1.Services[i]) {
-			return false
-		}
+	if !this.RouteRemoved.Equal(that1.RouteRemoved) {
+		return false
 	}
 	return true
 }
-func (this *Service) Equal(that interface{}) bool {
+func (this *ConfigRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Service)
+	that1, ok := that.(*ConfigRequest)
 	if !ok {
-		that2, ok := that.(Service)
+		that2, ok := that.(ConfigRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2899,36 +4557,30 @@ func (this *Service) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Id.Equal(that1.Id) {
-		return false
-	}
-	if !this.Hub.Equal(that1.Hub) {
-		return false
-	}
-	if this.Type != that1.Type {
+	if !this.StableId.Equal(that1.StableId) {
 		return false
 	}
-	if !this.Labels.Equal(that1.Labels) {
+	if !this.InstanceId.Equal(that1.InstanceId) {
 		return false
 	}
-	if len(this.Metadata) != len(that1.Metadata) {
+	if len(this.Locations) != len(that1.Locations) {
 		return false
 	}
-	for i := range this.Metadata {
-		if !this.Metadata[i].Equal(that1.Metadata[i]) {
+	for i := range this.Locations {
+		if !this.Locations[i].Equal(that1.Locations[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *AddAccountRequest) Equal(that interface{}) bool {
+func (this *ConfigResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*AddAccountRequest)
+	that1, ok := that.(*ConfigResponse)
 	if !ok {
-		that2, ok := that.(AddAccountRequest)
+		that2, ok := that.(ConfigResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2940,22 +4592,40 @@ func (this *AddAccountRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Account.Equal(that1.Account) {
+	if !bytes.Equal(this.TlsKey, that1.TlsKey) {
 		return false
 	}
-	if !this.Limits.Equal(that1.Limits) {
+	if !bytes.Equal(this.TlsCert, that1.TlsCert) {
+		return false
+	}
+	if !bytes.Equal(this.TokenPub, that1.TokenPub) {
+		return false
+	}
+	if this.S3AccessKey != that1.S3AccessKey {
+		return false
+	}
+	if this.S3SecretKey != that1.S3SecretKey {
+		return false
+	}
+	if this.S3Bucket != that1.S3Bucket {
+		return false
+	}
+	if this.ImageTag != that1.ImageTag {
+		return false
+	}
+	if this.ActivityCompressor != that1.ActivityCompressor {
 		return false
 	}
 	return true
 }
-func (this *AddLabelLinkRequest) Equal(that interface{}) bool {
+func (this *CentralActivity) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*AddLabelLinkRequest)
+	that1, ok := that.(*CentralActivity)
 	if !ok {
-		that2, ok := that.(AddLabelLinkRequest)
+		that2, ok := that.(CentralActivity)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2967,25 +4637,55 @@ func (this *AddLabelLinkRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Labels.Equal(that1.Labels) {
+	if len(this.AccountServices) != len(that1.AccountServices) {
 		return false
 	}
-	if !this.Account.Equal(that1.Account) {
+	for i := range this.AccountServices {
+		if !this.AccountServices[i].Equal(that1.AccountServices[i]) {
+			return false
+		}
+	}
+	if this.RequestStats != that1.RequestStats {
 		return false
 	}
-	if !this.Target.Equal(that1.Target) {
+	if !this.NewLabelLinks.Equal(that1.NewLabelLinks) {
+		return false
+	}
+	if len(this.ResolvedRoutes) != len(that1.ResolvedRoutes) {
+		return false
+	}
+	for i := range this.ResolvedRoutes {
+		if !this.ResolvedRoutes[i].Equal(that1.ResolvedRoutes[i]) {
+			return false
+		}
+	}
+	if !this.RemovedLabelLinks.Equal(that1.RemovedLabelLinks) {
+		return false
+	}
+	if len(this.Quotas) != len(that1.Quotas) {
+		return false
+	}
+	for i := range this.Quotas {
+		if !this.Quotas[i].Equal(that1.Quotas[i]) {
+			return false
+		}
+	}
+	if !this.S3Credentials.Equal(that1.S3Credentials) {
+		return false
+	}
+	if this.S3CredentialsRotated != that1.S3CredentialsRotated {
 		return false
 	}
 	return true
 }
-func (this *Noop) Equal(that interface{}) bool {
+func (this *S3Credentials) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Noop)
+	that1, ok := that.(*S3Credentials)
 	if !ok {
-		that2, ok := that.(Noop)
+		that2, ok := that.(S3Credentials)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2997,16 +4697,25 @@ func (this *Noop) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
+	if this.AccessKey != that1.AccessKey {
+		return false
+	}
+	if this.SecretKey != that1.SecretKey {
+		return false
+	}
+	if this.Bucket != that1.Bucket {
+		return false
+	}
 	return true
 }
-func (this *RemoveLabelLinkRequest) Equal(that interface{}) bool {
+func (this *QuotaStatus) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*RemoveLabelLinkRequest)
+	that1, ok := that.(*QuotaStatus)
 	if !ok {
-		that2, ok := that.(RemoveLabelLinkRequest)
+		that2, ok := that.(QuotaStatus)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3018,22 +4727,31 @@ func (this *RemoveLabelLinkRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Labels.Equal(that1.Labels) {
+	if !this.Account.Equal(that1.Account) {
 		return false
 	}
-	if !this.Account.Equal(that1.Account) {
+	if !this.Hub.Equal(that1.Hub) {
+		return false
+	}
+	if this.Exceeded != that1.Exceeded {
+		return false
+	}
+	if this.Bytes != that1.Bytes {
+		return false
+	}
+	if this.Messages != that1.Messages {
 		return false
 	}
 	return true
 }
-func (this *CreateTokenRequest) Equal(that interface{}) bool {
+func (this *HubActivity) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*CreateTokenRequest)
+	that1, ok := that.(*HubActivity)
 	if !ok {
-		that2, ok := that.(CreateTokenRequest)
+		that2, ok := that.(HubActivity)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3045,30 +4763,41 @@ func (this *CreateTokenRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Account.Equal(that1.Account) {
+	if !this.HubReg.Equal(that1.HubReg) {
 		return false
 	}
-	if len(this.Capabilities) != len(that1.Capabilities) {
+	if !this.SentAt.Equal(that1.SentAt) {
 		return false
 	}
-	for i := range this.Capabilities {
-		if !this.Capabilities[i].Equal(&that1.Capabilities[i]) {
+	if !this.Stats.Equal(that1.Stats) {
+		return false
+	}
+	if len(this.Flow) != len(that1.Flow) {
+		return false
+	}
+	for i := range this.Flow {
+		if !this.Flow[i].Equal(that1.Flow[i]) {
 			return false
 		}
 	}
-	if !this.ValidDuration.Equal(that1.ValidDuration) {
+	if len(this.CachedAccounts) != len(that1.CachedAccounts) {
 		return false
 	}
+	for i := range this.CachedAccounts {
+		if !this.CachedAccounts[i].Equal(that1.CachedAccounts[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *CreateTokenResponse) Equal(that interface{}) bool {
+func (this *HubActivity_HubRegistration) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*CreateTokenResponse)
+	that1, ok := that.(*HubActivity_HubRegistration)
 	if !ok {
-		that2, ok := that.(CreateTokenResponse)
+		that2, ok := that.(HubActivity_HubRegistration)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3080,19 +4809,30 @@ func (this *CreateTokenResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Token != that1.Token {
+	if !this.Hub.Equal(that1.Hub) {
+		return false
+	}
+	if !this.StableHub.Equal(that1.StableHub) {
+		return false
+	}
+	if len(this.Locations) != len(that1.Locations) {
 		return false
 	}
+	for i := range this.Locations {
+		if !this.Locations[i].Equal(that1.Locations[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *ControlRegister) Equal(that interface{}) bool {
+func (this *HubActivity_HubStats) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ControlRegister)
+	that1, ok := that.(*HubActivity_HubStats)
 	if !ok {
-		that2, ok := that.(ControlRegister)
+		that2, ok := that.(HubActivity_HubStats)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3104,19 +4844,34 @@ func (this *ControlRegister) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Namespace != that1.Namespace {
+	if this.AgentConnections != that1.AgentConnections {
+		return false
+	}
+	if this.AccountsSeen != that1.AccountsSeen {
+		return false
+	}
+	if this.AccountsCached != that1.AccountsCached {
+		return false
+	}
+	if this.CachedBytes != that1.CachedBytes {
+		return false
+	}
+	if !this.LastSuccessfulUpdate.Equal(that1.LastSuccessfulUpdate) {
+		return false
+	}
+	if !this.LastAttemptedUpdate.Equal(that1.LastAttemptedUpdate) {
 		return false
 	}
 	return true
 }
-func (this *ControlToken) Equal(that interface{}) bool {
+func (this *HubInfo) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ControlToken)
+	that1, ok := that.(*HubInfo)
 	if !ok {
-		that2, ok := that.(ControlToken)
+		that2, ok := that.(HubInfo)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3128,19 +4883,27 @@ func (this *ControlToken) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Token != that1.Token {
+	if !this.Id.Equal(that1.Id) {
+		return false
+	}
+	if len(this.Locations) != len(that1.Locations) {
 		return false
 	}
+	for i := range this.Locations {
+		if !this.Locations[i].Equal(that1.Locations[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *TokenInfo) Equal(that interface{}) bool {
+func (this *ListOfHubs) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*TokenInfo)
+	that1, ok := that.(*ListOfHubs)
 	if !ok {
-		that2, ok := that.(TokenInfo)
+		that2, ok := that.(ListOfHubs)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3152,19 +4915,33 @@ func (this *TokenInfo) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.PublicKey, that1.PublicKey) {
+	if len(this.Hubs) != len(that1.Hubs) {
+		return false
+	}
+	for i := range this.Hubs {
+		if !this.Hubs[i].Equal(that1.Hubs[i]) {
+			return false
+		}
+	}
+	if this.Skipped != that1.Skipped {
+		return false
+	}
+	if this.Version != that1.Version {
+		return false
+	}
+	if this.NotModified != that1.NotModified {
 		return false
 	}
 	return true
 }
-func (this *ListAccountsRequest) Equal(that interface{}) bool {
+func (this *HubSync) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ListAccountsRequest)
+	that1, ok := that.(*HubSync)
 	if !ok {
-		that2, ok := that.(ListAccountsRequest)
+		that2, ok := that.(HubSync)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3176,22 +4953,30 @@ func (this *ListAccountsRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Limit != that1.Limit {
+	if !this.Id.Equal(that1.Id) {
 		return false
 	}
-	if !bytes.Equal(this.Marker, that1.Marker) {
+	if !this.StableId.Equal(that1.StableId) {
+		return false
+	}
+	if len(this.Services) != len(that1.Services) {
 		return false
 	}
+	for i := range this.Services {
+		if !this.Services[i].Equal(that1.Services[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *ListAccountsResponse) Equal(that interface{}) bool {
+func (this *HubSyncResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ListAccountsResponse)
+	that1, ok := that.(*HubSyncResponse)
 	if !ok {
-		that2, ok := that.(ListAccountsResponse)
+		that2, ok := that.(HubSyncResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3203,4227 +4988,14564 @@ func (this *ListAccountsResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Accounts) != len(that1.Accounts) {
+	if this.ServiceCount != that1.ServiceCount {
 		return false
 	}
-	for i := range this.Accounts {
-		if !this.Accounts[i].Equal(that1.Accounts[i]) {
+	return true
+}
+func (this *HubRegisterRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*HubRegisterRequest)
+	if !ok {
+		that2, ok := that.(HubRegisterRequest)
+		if ok {
+			that1 = &that2
+		} else {
 			return false
 		}
 	}
-	if !bytes.Equal(this.NextMarker, that1.NextMarker) {
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
 		return false
 	}
-	return true
-}
-func (this *ServiceRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if !this.StableId.Equal(that1.StableId) {
+		return false
 	}
-	s := make([]string, 0, 10)
-	s = append(s, "&pb.ServiceRequest{")
-	if this.Account != nil {
-		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
+	if !this.InstanceId.Equal(that1.InstanceId) {
+		return false
 	}
-	if this.Hub != nil {
-		s = append(s, "Hub: "+fmt.Sprintf("%#v", this.Hub)+",\n")
+	if len(this.Locations) != len(that1.Locations) {
+		return false
 	}
-	if this.Id != nil {
-		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
+	for i := range this.Locations {
+		if !this.Locations[i].Equal(that1.Locations[i]) {
+			return false
+		}
 	}
-	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
-	if this.Labels != nil {
-		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	return true
+}
+func (this *HubRegisterResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if this.Metadata != nil {
-		s = append(s, "Metadata: "+fmt.Sprintf("%#v", this.Metadata)+",\n")
+
+	that1, ok := that.(*HubRegisterResponse)
+	if !ok {
+		that2, ok := that.(HubRegisterResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ServiceResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&pb.ServiceResponse{")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	if !this.ControlTime.Equal(that1.ControlTime) {
+		return false
+	}
+	return true
 }
-func (this *LabelLink) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *HubDisconnectRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&pb.LabelLink{")
-	if this.Account != nil {
-		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
+
+	that1, ok := that.(*HubDisconnectRequest)
+	if !ok {
+		that2, ok := that.(HubDisconnectRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.Labels != nil {
-		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if this.Target != nil {
-		s = append(s, "Target: "+fmt.Sprintf("%#v", this.Target)+",\n")
+	if !this.StableId.Equal(that1.StableId) {
+		return false
 	}
-	if this.Limits != nil {
-		s = append(s, "Limits: "+fmt.Sprintf("%#v", this.Limits)+",\n")
+	if !this.InstanceId.Equal(that1.InstanceId) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *LabelLinks) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.LabelLinks{")
-	if this.LabelLinks != nil {
-		s = append(s, "LabelLinks: "+fmt.Sprintf("%#v", this.LabelLinks)+",\n")
+func (this *ServiceTokenRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ServiceRoute) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*ServiceTokenRequest)
+	if !ok {
+		that2, ok := that.(ServiceTokenRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&pb.ServiceRoute{")
-	if this.Hub != nil {
-		s = append(s, "Hub: "+fmt.Sprintf("%#v", this.Hub)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if this.Id != nil {
-		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
+	if this.Namespace != that1.Namespace {
+		return false
 	}
-	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
-	if this.Labels != nil {
-		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	if !this.HubScope.Equal(that1.HubScope) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *AccountServices) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 6)
-	s = append(s, "&pb.AccountServices{")
-	if this.Account != nil {
-		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
-	}
-	if this.Services != nil {
-		s = append(s, "Services: "+fmt.Sprintf("%#v", this.Services)+",\n")
+func (this *ServiceTokenResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ActivityEntry) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*ServiceTokenResponse)
+	if !ok {
+		that2, ok := that.(ServiceTokenResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&pb.ActivityEntry{")
-	if this.RouteAdded != nil {
-		s = append(s, "RouteAdded: "+fmt.Sprintf("%#v", this.RouteAdded)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if this.RouteRemoved != nil {
-		s = append(s, "RouteRemoved: "+fmt.Sprintf("%#v", this.RouteRemoved)+",\n")
+	if this.Token != that1.Token {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *ConfigRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *ListServicesRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&pb.ConfigRequest{")
-	if this.StableId != nil {
-		s = append(s, "StableId: "+fmt.Sprintf("%#v", this.StableId)+",\n")
+
+	that1, ok := that.(*ListServicesRequest)
+	if !ok {
+		that2, ok := that.(ListServicesRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.InstanceId != nil {
-		s = append(s, "InstanceId: "+fmt.Sprintf("%#v", this.InstanceId)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if this.Locations != nil {
-		s = append(s, "Locations: "+fmt.Sprintf("%#v", this.Locations)+",\n")
+	if !this.Account.Equal(that1.Account) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *ConfigResponse) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *ListServicesResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 11)
-	s = append(s, "&pb.ConfigResponse{")
-	s = append(s, "TlsKey: "+fmt.Sprintf("%#v", this.TlsKey)+",\n")
-	s = append(s, "TlsCert: "+fmt.Sprintf("%#v", this.TlsCert)+",\n")
-	s = append(s, "TokenPub: "+fmt.Sprintf("%#v", this.TokenPub)+",\n")
-	s = append(s, "S3AccessKey: "+fmt.Sprintf("%#v", this.S3AccessKey)+",\n")
-	s = append(s, "S3SecretKey: "+fmt.Sprintf("%#v", this.S3SecretKey)+",\n")
-	s = append(s, "S3Bucket: "+fmt.Sprintf("%#v", this.S3Bucket)+",\n")
-	s = append(s, "ImageTag: "+fmt.Sprintf("%#v", this.ImageTag)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *CentralActivity) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*ListServicesResponse)
+	if !ok {
+		that2, ok := that.(ListServicesResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&pb.CentralActivity{")
-	if this.AccountServices != nil {
-		s = append(s, "AccountServices: "+fmt.Sprintf("%#v", this.AccountServices)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "RequestStats: "+fmt.Sprintf("%#v", this.RequestStats)+",\n")
-	if this.NewLabelLinks != nil {
-		s = append(s, "NewLabelLinks: "+fmt.Sprintf("%#v", this.NewLabelLinks)+",\n")
+	if len(this.Services) != len(that1.Services) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	for i := range this.Services {
+		if !this.Services[i].Equal(that1.Services[i]) {
+			return false
+		}
+	}
+	return true
 }
-func (this *HubActivity) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *Service) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&pb.HubActivity{")
-	if this.HubReg != nil {
-		s = append(s, "HubReg: "+fmt.Sprintf("%#v", this.HubReg)+",\n")
+
+	that1, ok := that.(*Service)
+	if !ok {
+		that2, ok := that.(Service)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.SentAt != nil {
-		s = append(s, "SentAt: "+fmt.Sprintf("%#v", this.SentAt)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if this.Stats != nil {
-		s = append(s, "Stats: "+fmt.Sprintf("%#v", this.Stats)+",\n")
+	if !this.Id.Equal(that1.Id) {
+		return false
 	}
-	if this.Flow != nil {
-		s = append(s, "Flow: "+fmt.Sprintf("%#v", this.Flow)+",\n")
+	if !this.Hub.Equal(that1.Hub) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *HubActivity_HubRegistration) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.Type != that1.Type {
+		return false
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&pb.HubActivity_HubRegistration{")
-	if this.Hub != nil {
-		s = append(s, "Hub: "+fmt.Sprintf("%#v", this.Hub)+",\n")
+	if !this.Labels.Equal(that1.Labels) {
+		return false
 	}
-	if this.StableHub != nil {
-		s = append(s, "StableHub: "+fmt.Sprintf("%#v", this.StableHub)+",\n")
+	if len(this.Metadata) != len(that1.Metadata) {
+		return false
 	}
-	if this.Locations != nil {
-		s = append(s, "Locations: "+fmt.Sprintf("%#v", this.Locations)+",\n")
+	for i := range this.Metadata {
+		if !this.Metadata[i].Equal(that1.Metadata[i]) {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *HubActivity_HubStats) GoString() string {
-	if this == nil {
-		return "nil"
+	if !this.Account.Equal(that1.Account) {
+		return false
 	}
-	s := make([]string, 0, 10)
-	s = append(s, "&pb.HubActivity_HubStats{")
-	s = append(s, "AgentConnections: "+fmt.Sprintf("%#v", this.AgentConnections)+",\n")
-	s = append(s, "AccountsSeen: "+fmt.Sprintf("%#v", this.AccountsSeen)+",\n")
-	s = append(s, "AccountsCached: "+fmt.Sprintf("%#v", this.AccountsCached)+",\n")
-	s = append(s, "CachedBytes: "+fmt.Sprintf("%#v", this.CachedBytes)+",\n")
-	if this.LastSuccessfulUpdate != nil {
-		s = append(s, "LastSuccessfulUpdate: "+fmt.Sprintf("%#v", this.LastSuccessfulUpdate)+",\n")
+	return true
+}
+func (this *StreamServicesRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if this.LastAttemptedUpdate != nil {
-		s = append(s, "LastAttemptedUpdate: "+fmt.Sprintf("%#v", this.LastAttemptedUpdate)+",\n")
+
+	that1, ok := that.(*StreamServicesRequest)
+	if !ok {
+		that2, ok := that.(StreamServicesRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *HubInfo) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&pb.HubInfo{")
-	if this.Id != nil {
-		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
+	if this.Namespace != that1.Namespace {
+		return false
 	}
-	if this.Locations != nil {
-		s = append(s, "Locations: "+fmt.Sprintf("%#v", this.Locations)+",\n")
+	if this.Type != that1.Type {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ListOfHubs) GoString() string {
-	if this == nil {
-		return "nil"
+	if !this.Labels.Equal(that1.Labels) {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.ListOfHubs{")
-	if this.Hubs != nil {
-		s = append(s, "Hubs: "+fmt.Sprintf("%#v", this.Hubs)+",\n")
+	if this.BatchSize != that1.BatchSize {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *HubSync) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *AddAccountRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&pb.HubSync{")
-	if this.Id != nil {
-		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
+
+	that1, ok := that.(*AddAccountRequest)
+	if !ok {
+		that2, ok := that.(AddAccountRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.StableId != nil {
-		s = append(s, "StableId: "+fmt.Sprintf("%#v", this.StableId)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if this.Services != nil {
-		s = append(s, "Services: "+fmt.Sprintf("%#v", this.Services)+",\n")
+	if !this.Account.Equal(that1.Account) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *HubSyncResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if !this.Limits.Equal(that1.Limits) {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.HubSyncResponse{")
-	s = append(s, "ServiceCount: "+fmt.Sprintf("%#v", this.ServiceCount)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *HubRegisterRequest) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 7)
-	s = append(s, "&pb.HubRegisterRequest{")
-	if this.StableId != nil {
-		s = append(s, "StableId: "+fmt.Sprintf("%#v", this.StableId)+",\n")
+func (this *CreateAccountRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if this.InstanceId != nil {
-		s = append(s, "InstanceId: "+fmt.Sprintf("%#v", this.InstanceId)+",\n")
+
+	that1, ok := that.(*CreateAccountRequest)
+	if !ok {
+		that2, ok := that.(CreateAccountRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.Locations != nil {
-		s = append(s, "Locations: "+fmt.Sprintf("%#v", this.Locations)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *HubRegisterResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if !this.Account.Equal(that1.Account) {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.HubRegisterResponse{")
-	if this.ControlTime != nil {
-		s = append(s, "ControlTime: "+fmt.Sprintf("%#v", this.ControlTime)+",\n")
+	if !this.Limits.Equal(that1.Limits) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *HubDisconnectRequest) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 6)
-	s = append(s, "&pb.HubDisconnectRequest{")
-	if this.StableId != nil {
-		s = append(s, "StableId: "+fmt.Sprintf("%#v", this.StableId)+",\n")
-	}
-	if this.InstanceId != nil {
-		s = append(s, "InstanceId: "+fmt.Sprintf("%#v", this.InstanceId)+",\n")
+func (this *CreateAccountResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ServiceTokenRequest) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*CreateAccountResponse)
+	if !ok {
+		that2, ok := that.(CreateAccountResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.ServiceTokenRequest{")
-	s = append(s, "Namespace: "+fmt.Sprintf("%#v", this.Namespace)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ServiceTokenResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.ServiceTokenResponse{")
-	s = append(s, "Token: "+fmt.Sprintf("%#v", this.Token)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ListServicesRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if !this.Account.Equal(that1.Account) {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.ListServicesRequest{")
-	if this.Account != nil {
-		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
+	if !this.Limits.Equal(that1.Limits) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *ListServicesResponse) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.ListServicesResponse{")
-	if this.Services != nil {
-		s = append(s, "Services: "+fmt.Sprintf("%#v", this.Services)+",\n")
+func (this *AddLabelLinkRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *Service) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*AddLabelLinkRequest)
+	if !ok {
+		that2, ok := that.(AddLabelLinkRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 9)
-	s = append(s, "&pb.Service{")
-	if this.Id != nil {
-		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if this.Hub != nil {
-		s = append(s, "Hub: "+fmt.Sprintf("%#v", this.Hub)+",\n")
+	if !this.Labels.Equal(that1.Labels) {
+		return false
 	}
-	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
-	if this.Labels != nil {
-		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	if !this.Account.Equal(that1.Account) {
+		return false
 	}
-	if this.Metadata != nil {
-		s = append(s, "Metadata: "+fmt.Sprintf("%#v", this.Metadata)+",\n")
+	if !this.Target.Equal(that1.Target) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *AddAccountRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *Noop) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&pb.AddAccountRequest{")
-	if this.Account != nil {
-		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
+
+	that1, ok := that.(*Noop)
+	if !ok {
+		that2, ok := that.(Noop)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.Limits != nil {
-		s = append(s, "Limits: "+fmt.Sprintf("%#v", this.Limits)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *AddLabelLinkRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *RemoveLabelLinkRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&pb.AddLabelLinkRequest{")
-	if this.Labels != nil {
-		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+
+	that1, ok := that.(*RemoveLabelLinkRequest)
+	if !ok {
+		that2, ok := that.(RemoveLabelLinkRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.Account != nil {
-		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if this.Target != nil {
-		s = append(s, "Target: "+fmt.Sprintf("%#v", this.Target)+",\n")
+	if !this.Labels.Equal(that1.Labels) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *Noop) GoString() string {
-	if this == nil {
-		return "nil"
+	if !this.Account.Equal(that1.Account) {
+		return false
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&pb.Noop{")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *RemoveLabelLinkRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *CreateTokenRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&pb.RemoveLabelLinkRequest{")
-	if this.Labels != nil {
-		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+
+	that1, ok := that.(*CreateTokenRequest)
+	if !ok {
+		that2, ok := that.(CreateTokenRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.Account != nil {
-		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *CreateTokenRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if !this.Account.Equal(that1.Account) {
+		return false
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&pb.CreateTokenRequest{")
-	if this.Account != nil {
-		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
+	if len(this.Capabilities) != len(that1.Capabilities) {
+		return false
 	}
-	if this.Capabilities != nil {
-		vs := make([]TokenCapability, len(this.Capabilities))
-		for i := range vs {
-			vs[i] = this.Capabilities[i]
+	for i := range this.Capabilities {
+		if !this.Capabilities[i].Equal(&that1.Capabilities[i]) {
+			return false
 		}
-		s = append(s, "Capabilities: "+fmt.Sprintf("%#v", vs)+",\n")
-	}
-	if this.ValidDuration != nil {
-		s = append(s, "ValidDuration: "+fmt.Sprintf("%#v", this.ValidDuration)+",\n")
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *CreateTokenResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if !this.ValidDuration.Equal(that1.ValidDuration) {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.CreateTokenResponse{")
-	s = append(s, "Token: "+fmt.Sprintf("%#v", this.Token)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *ControlRegister) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *CreateTokenResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.ControlRegister{")
-	s = append(s, "Namespace: "+fmt.Sprintf("%#v", this.Namespace)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ControlToken) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*CreateTokenResponse)
+	if !ok {
+		that2, ok := that.(CreateTokenResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.ControlToken{")
-	s = append(s, "Token: "+fmt.Sprintf("%#v", this.Token)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *TokenInfo) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.TokenInfo{")
-	s = append(s, "PublicKey: "+fmt.Sprintf("%#v", this.PublicKey)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ListAccountsRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.Token != that1.Token {
+		return false
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&pb.ListAccountsRequest{")
-	s = append(s, "Limit: "+fmt.Sprintf("%#v", this.Limit)+",\n")
-	s = append(s, "Marker: "+fmt.Sprintf("%#v", this.Marker)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ListAccountsResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.Role != that1.Role {
+		return false
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&pb.ListAccountsResponse{")
-	if this.Accounts != nil {
-		s = append(s, "Accounts: "+fmt.Sprintf("%#v", this.Accounts)+",\n")
+	if !this.TokenId.Equal(that1.TokenId) {
+		return false
 	}
-	s = append(s, "NextMarker: "+fmt.Sprintf("%#v", this.NextMarker)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func valueToGoStringControl(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+	if !this.Account.Equal(that1.Account) {
+		return false
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
-}
-
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// ControlServicesClient is the client API for ControlServices service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ControlServicesClient interface {
-	AddService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
-	RemoveService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
-	ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error)
-	FetchConfig(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
-	StreamActivity(ctx context.Context, opts ...grpc.CallOption) (ControlServices_StreamActivityClient, error)
-	SyncHub(ctx context.Context, in *HubSync, opts ...grpc.CallOption) (*HubSyncResponse, error)
-	HubDisconnect(ctx context.Context, in *HubDisconnectRequest, opts ...grpc.CallOption) (*Noop, error)
-	AllHubs(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*ListOfHubs, error)
-	RequestServiceToken(ctx context.Context, in *ServiceTokenRequest, opts ...grpc.CallOption) (*ServiceTokenResponse, error)
-}
-
-type controlServicesClient struct {
-	cc *grpc.ClientConn
-}
-
-func NewControlServicesClient(cc *grpc.ClientConn) ControlServicesClient {
-	return &controlServicesClient{cc}
-}
-
-func (c *controlServicesClient) AddService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
-	out := new(ServiceResponse)
-	err := c.cc.Invoke(ctx, "/pb.ControlServices/AddService", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if len(this.Capabilities) != len(that1.Capabilities) {
+		return false
 	}
-	return out, nil
-}
-
-func (c *controlServicesClient) RemoveService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
-	out := new(ServiceResponse)
-	err := c.cc.Invoke(ctx, "/pb.ControlServices/RemoveService", in, out, opts...)
-	if err != nil {
-		return nil, err
+	for i := range this.Capabilities {
+		if !this.Capabilities[i].Equal(&that1.Capabilities[i]) {
+			return false
+		}
 	}
-	return out, nil
-}
-
-func (c *controlServicesClient) ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error) {
-	out := new(ListServicesResponse)
-	err := c.cc.Invoke(ctx, "/pb.ControlServices/ListServices", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if !this.ValidUntil.Equal(that1.ValidUntil) {
+		return false
 	}
-	return out, nil
+	return true
 }
-
-func (c *controlServicesClient) FetchConfig(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
-	out := new(ConfigResponse)
-	err := c.cc.Invoke(ctx, "/pb.ControlServices/FetchConfig", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (this *CreateTokensRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return out, nil
-}
 
-func (c *controlServicesClient) StreamActivity(ctx context.Context, opts ...grpc.CallOption) (ControlServices_StreamActivityClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ControlServices_serviceDesc.Streams[0], "/pb.ControlServices/StreamActivity", opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*CreateTokensRequest)
+	if !ok {
+		that2, ok := that.(CreateTokensRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	x := &controlServicesStreamActivityClient{stream}
-	return x, nil
-}
-
-type ControlServices_StreamActivityClient interface {
-	Send(*HubActivity) error
-	Recv() (*CentralActivity, error)
-	grpc.ClientStream
-}
-
-type controlServicesStreamActivityClient struct {
-	grpc.ClientStream
-}
-
-func (x *controlServicesStreamActivityClient) Send(m *HubActivity) error {
-	return x.ClientStream.SendMsg(m)
-}
-
-func (x *controlServicesStreamActivityClient) Recv() (*CentralActivity, error) {
-	m := new(CentralActivity)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return m, nil
-}
-
-func (c *controlServicesClient) SyncHub(ctx context.Context, in *HubSync, opts ...grpc.CallOption) (*HubSyncResponse, error) {
-	out := new(HubSyncResponse)
-	err := c.cc.Invoke(ctx, "/pb.ControlServices/SyncHub", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if len(this.Tokens) != len(that1.Tokens) {
+		return false
 	}
-	return out, nil
-}
-
-func (c *controlServicesClient) HubDisconnect(ctx context.Context, in *HubDisconnectRequest, opts ...grpc.CallOption) (*Noop, error) {
-	out := new(Noop)
-	err := c.cc.Invoke(ctx, "/pb.ControlServices/HubDisconnect", in, out, opts...)
-	if err != nil {
-		return nil, err
+	for i := range this.Tokens {
+		if !this.Tokens[i].Equal(that1.Tokens[i]) {
+			return false
+		}
 	}
-	return out, nil
+	if this.AllOrNothing != that1.AllOrNothing {
+		return false
+	}
+	return true
 }
+func (this *CreateTokenResult) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (c *controlServicesClient) AllHubs(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*ListOfHubs, error) {
-	out := new(ListOfHubs)
-	err := c.cc.Invoke(ctx, "/pb.ControlServices/AllHubs", in, out, opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*CreateTokenResult)
+	if !ok {
+		that2, ok := that.(CreateTokenResult)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return out, nil
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Token != that1.Token {
+		return false
+	}
+	if this.Error != that1.Error {
+		return false
+	}
+	return true
 }
+func (this *CreateTokensResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (c *controlServicesClient) RequestServiceToken(ctx context.Context, in *ServiceTokenRequest, opts ...grpc.CallOption) (*ServiceTokenResponse, error) {
-	out := new(ServiceTokenResponse)
-	err := c.cc.Invoke(ctx, "/pb.ControlServices/RequestServiceToken", in, out, opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*CreateTokensResponse)
+	if !ok {
+		that2, ok := that.(CreateTokensResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return out, nil
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Results) != len(that1.Results) {
+		return false
+	}
+	for i := range this.Results {
+		if !this.Results[i].Equal(that1.Results[i]) {
+			return false
+		}
+	}
+	return true
 }
+func (this *ControlRegister) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-// ControlServicesServer is the server API for ControlServices service.
-type ControlServicesServer interface {
-	AddService(context.Context, *ServiceRequest) (*ServiceResponse, error)
-	RemoveService(context.Context, *ServiceRequest) (*ServiceResponse, error)
-	ListServices(context.Context, *ListServicesRequest) (*ListServicesResponse, error)
-	FetchConfig(context.Context, *ConfigRequest) (*ConfigResponse, error)
-	StreamActivity(ControlServices_StreamActivityServer) error
-	SyncHub(context.Context, *HubSync) (*HubSyncResponse, error)
-	HubDisconnect(context.Context, *HubDisconnectRequest) (*Noop, error)
-	AllHubs(context.Context, *Noop) (*ListOfHubs, error)
-	RequestServiceToken(context.Context, *ServiceTokenRequest) (*ServiceTokenResponse, error)
-}
-
-// UnimplementedControlServicesServer can be embedded to have forward compatible implementations.
-type UnimplementedControlServicesServer struct {
-}
-
-func (*UnimplementedControlServicesServer) AddService(ctx context.Context, req *ServiceRequest) (*ServiceResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddService not implemented")
-}
-func (*UnimplementedControlServicesServer) RemoveService(ctx context.Context, req *ServiceRequest) (*ServiceResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveService not implemented")
-}
-func (*UnimplementedControlServicesServer) ListServices(ctx context.Context, req *ListServicesRequest) (*ListServicesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListServices not implemented")
-}
-func (*UnimplementedControlServicesServer) FetchConfig(ctx context.Context, req *ConfigRequest) (*ConfigResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method FetchConfig not implemented")
-}
-func (*UnimplementedControlServicesServer) StreamActivity(srv ControlServices_StreamActivityServer) error {
-	return status.Errorf(codes.Unimplemented, "method StreamActivity not implemented")
-}
-func (*UnimplementedControlServicesServer) SyncHub(ctx context.Context, req *HubSync) (*HubSyncResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SyncHub not implemented")
-}
-func (*UnimplementedControlServicesServer) HubDisconnect(ctx context.Context, req *HubDisconnectRequest) (*Noop, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method HubDisconnect not implemented")
-}
-func (*UnimplementedControlServicesServer) AllHubs(ctx context.Context, req *Noop) (*ListOfHubs, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AllHubs not implemented")
-}
-func (*UnimplementedControlServicesServer) RequestServiceToken(ctx context.Context, req *ServiceTokenRequest) (*ServiceTokenResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RequestServiceToken not implemented")
-}
-
-func RegisterControlServicesServer(s *grpc.Server, srv ControlServicesServer) {
-	s.RegisterService(&_ControlServices_serviceDesc, srv)
-}
-
-func _ControlServices_AddService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ServiceRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ControlServicesServer).AddService(ctx, in)
+	that1, ok := that.(*ControlRegister)
+	if !ok {
+		that2, ok := that.(ControlRegister)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlServices/AddService",
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServicesServer).AddService(ctx, req.(*ServiceRequest))
+	if this.Namespace != that1.Namespace {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
-
-func _ControlServices_RemoveService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ServiceRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *ControlToken) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if interceptor == nil {
-		return srv.(ControlServicesServer).RemoveService(ctx, in)
+
+	that1, ok := that.(*ControlToken)
+	if !ok {
+		that2, ok := that.(ControlToken)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlServices/RemoveService",
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServicesServer).RemoveService(ctx, req.(*ServiceRequest))
+	if this.Token != that1.Token {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
-
-func _ControlServices_ListServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListServicesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *TokenInfo) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if interceptor == nil {
-		return srv.(ControlServicesServer).ListServices(ctx, in)
+
+	that1, ok := that.(*TokenInfo)
+	if !ok {
+		that2, ok := that.(TokenInfo)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlServices/ListServices",
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServicesServer).ListServices(ctx, req.(*ListServicesRequest))
+	if !bytes.Equal(this.PublicKey, that1.PublicKey) {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
+func (this *ListAccountsRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _ControlServices_FetchConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ConfigRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*ListAccountsRequest)
+	if !ok {
+		that2, ok := that.(ListAccountsRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(ControlServicesServer).FetchConfig(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlServices/FetchConfig",
+	if this.Limit != that1.Limit {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServicesServer).FetchConfig(ctx, req.(*ConfigRequest))
+	if !bytes.Equal(this.Marker, that1.Marker) {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _ControlServices_StreamActivity_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(ControlServicesServer).StreamActivity(&controlServicesStreamActivityServer{stream})
-}
-
-type ControlServices_StreamActivityServer interface {
-	Send(*CentralActivity) error
-	Recv() (*HubActivity, error)
-	grpc.ServerStream
-}
-
-type controlServicesStreamActivityServer struct {
-	grpc.ServerStream
-}
-
-func (x *controlServicesStreamActivityServer) Send(m *CentralActivity) error {
-	return x.ServerStream.SendMsg(m)
+	return true
 }
-
-func (x *controlServicesStreamActivityServer) Recv() (*HubActivity, error) {
-	m := new(HubActivity)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
+func (this *ListAccountsResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return m, nil
-}
 
-func _ControlServices_SyncHub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HubSync)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*ListAccountsResponse)
+	if !ok {
+		that2, ok := that.(ListAccountsResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(ControlServicesServer).SyncHub(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlServices/SyncHub",
+	if len(this.Accounts) != len(that1.Accounts) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServicesServer).SyncHub(ctx, req.(*HubSync))
+	for i := range this.Accounts {
+		if !this.Accounts[i].Equal(that1.Accounts[i]) {
+			return false
+		}
 	}
-	return interceptor(ctx, in, info, handler)
+	if !bytes.Equal(this.NextMarker, that1.NextMarker) {
+		return false
+	}
+	return true
 }
+func (this *ManagementClient) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _ControlServices_HubDisconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HubDisconnectRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*ManagementClient)
+	if !ok {
+		that2, ok := that.(ManagementClient)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(ControlServicesServer).HubDisconnect(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlServices/HubDisconnect",
+	if !this.Id.Equal(that1.Id) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServicesServer).HubDisconnect(ctx, req.(*HubDisconnectRequest))
+	if this.Namespace != that1.Namespace {
+		return false
+	}
+	if !this.CreatedAt.Equal(that1.CreatedAt) {
+		return false
+	}
+	return true
+}
+func (this *ListManagementClientsRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return interceptor(ctx, in, info, handler)
-}
 
-func _ControlServices_AllHubs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Noop)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*ListManagementClientsRequest)
+	if !ok {
+		that2, ok := that.(ListManagementClientsRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(ControlServicesServer).AllHubs(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlServices/AllHubs",
+	if this.Limit != that1.Limit {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServicesServer).AllHubs(ctx, req.(*Noop))
+	if !bytes.Equal(this.Marker, that1.Marker) {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
+func (this *ListManagementClientsResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _ControlServices_RequestServiceToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ServiceTokenRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*ListManagementClientsResponse)
+	if !ok {
+		that2, ok := that.(ListManagementClientsResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(ControlServicesServer).RequestServiceToken(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlServices/RequestServiceToken",
+	if len(this.Clients) != len(that1.Clients) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServicesServer).RequestServiceToken(ctx, req.(*ServiceTokenRequest))
+	for i := range this.Clients {
+		if !this.Clients[i].Equal(that1.Clients[i]) {
+			return false
+		}
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-var _ControlServices_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.ControlServices",
-	HandlerType: (*ControlServicesServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "AddService",
-			Handler:    _ControlServices_AddService_Handler,
-		},
-		{
-			MethodName: "RemoveService",
-			Handler:    _ControlServices_RemoveService_Handler,
-		},
-		{
-			MethodName: "ListServices",
-			Handler:    _ControlServices_ListServices_Handler,
-		},
-		{
-			MethodName: "FetchConfig",
-			Handler:    _ControlServices_FetchConfig_Handler,
-		},
-		{
-			MethodName: "SyncHub",
-			Handler:    _ControlServices_SyncHub_Handler,
-		},
-		{
-			MethodName: "HubDisconnect",
-			Handler:    _ControlServices_HubDisconnect_Handler,
-		},
-		{
-			MethodName: "AllHubs",
-			Handler:    _ControlServices_AllHubs_Handler,
-		},
-		{
-			MethodName: "RequestServiceToken",
-			Handler:    _ControlServices_RequestServiceToken_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "StreamActivity",
-			Handler:       _ControlServices_StreamActivity_Handler,
-			ServerStreams: true,
-			ClientStreams: true,
-		},
-	},
-	Metadata: "control.proto",
-}
-
-// ControlManagementClient is the client API for ControlManagement service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ControlManagementClient interface {
-	Register(ctx context.Context, in *ControlRegister, opts ...grpc.CallOption) (*ControlToken, error)
-	AddAccount(ctx context.Context, in *AddAccountRequest, opts ...grpc.CallOption) (*Noop, error)
-	AddLabelLink(ctx context.Context, in *AddLabelLinkRequest, opts ...grpc.CallOption) (*Noop, error)
-	RemoveLabelLink(ctx context.Context, in *RemoveLabelLinkRequest, opts ...grpc.CallOption) (*Noop, error)
-	CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error)
-	IssueHubToken(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*CreateTokenResponse, error)
-	GetTokenPublicKey(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*TokenInfo, error)
-	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
-}
-
-type controlManagementClient struct {
-	cc *grpc.ClientConn
-}
-
-func NewControlManagementClient(cc *grpc.ClientConn) ControlManagementClient {
-	return &controlManagementClient{cc}
-}
-
-func (c *controlManagementClient) Register(ctx context.Context, in *ControlRegister, opts ...grpc.CallOption) (*ControlToken, error) {
-	out := new(ControlToken)
-	err := c.cc.Invoke(ctx, "/pb.ControlManagement/Register", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if !bytes.Equal(this.NextMarker, that1.NextMarker) {
+		return false
 	}
-	return out, nil
+	return true
 }
-
-func (c *controlManagementClient) AddAccount(ctx context.Context, in *AddAccountRequest, opts ...grpc.CallOption) (*Noop, error) {
-	out := new(Noop)
-	err := c.cc.Invoke(ctx, "/pb.ControlManagement/AddAccount", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (this *ListTokensRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return out, nil
-}
 
-func (c *controlManagementClient) AddLabelLink(ctx context.Context, in *AddLabelLinkRequest, opts ...grpc.CallOption) (*Noop, error) {
-	out := new(Noop)
-	err := c.cc.Invoke(ctx, "/pb.ControlManagement/AddLabelLink", in, out, opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*ListTokensRequest)
+	if !ok {
+		that2, ok := that.(ListTokensRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return out, nil
-}
-
-func (c *controlManagementClient) RemoveLabelLink(ctx context.Context, in *RemoveLabelLinkRequest, opts ...grpc.CallOption) (*Noop, error) {
-	out := new(Noop)
-	err := c.cc.Invoke(ctx, "/pb.ControlManagement/RemoveLabelLink", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return out, nil
-}
-
-func (c *controlManagementClient) CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error) {
-	out := new(CreateTokenResponse)
-	err := c.cc.Invoke(ctx, "/pb.ControlManagement/CreateToken", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if this.Limit != that1.Limit {
+		return false
 	}
-	return out, nil
-}
-
-func (c *controlManagementClient) IssueHubToken(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*CreateTokenResponse, error) {
-	out := new(CreateTokenResponse)
-	err := c.cc.Invoke(ctx, "/pb.ControlManagement/IssueHubToken", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if !bytes.Equal(this.Marker, that1.Marker) {
+		return false
 	}
-	return out, nil
+	return true
 }
-
-func (c *controlManagementClient) GetTokenPublicKey(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*TokenInfo, error) {
-	out := new(TokenInfo)
-	err := c.cc.Invoke(ctx, "/pb.ControlManagement/GetTokenPublicKey", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (this *IssuedToken) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return out, nil
-}
 
-func (c *controlManagementClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
-	out := new(ListAccountsResponse)
-	err := c.cc.Invoke(ctx, "/pb.ControlManagement/ListAccounts", in, out, opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*IssuedToken)
+	if !ok {
+		that2, ok := that.(IssuedToken)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return out, nil
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.TokenId.Equal(that1.TokenId) {
+		return false
+	}
+	if this.Role != that1.Role {
+		return false
+	}
+	if !this.Account.Equal(that1.Account) {
+		return false
+	}
+	if len(this.Capabilities) != len(that1.Capabilities) {
+		return false
+	}
+	for i := range this.Capabilities {
+		if !this.Capabilities[i].Equal(&that1.Capabilities[i]) {
+			return false
+		}
+	}
+	if !this.ValidUntil.Equal(that1.ValidUntil) {
+		return false
+	}
+	if !this.IssuedAt.Equal(that1.IssuedAt) {
+		return false
+	}
+	return true
 }
+func (this *ListTokensResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-// ControlManagementServer is the server API for ControlManagement service.
-type ControlManagementServer interface {
-	Register(context.Context, *ControlRegister) (*ControlToken, error)
-	AddAccount(context.Context, *AddAccountRequest) (*Noop, error)
-	AddLabelLink(context.Context, *AddLabelLinkRequest) (*Noop, error)
-	RemoveLabelLink(context.Context, *RemoveLabelLinkRequest) (*Noop, error)
-	CreateToken(context.Context, *CreateTokenRequest) (*CreateTokenResponse, error)
-	IssueHubToken(context.Context, *Noop) (*CreateTokenResponse, error)
-	GetTokenPublicKey(context.Context, *Noop) (*TokenInfo, error)
-	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
-}
-
-// UnimplementedControlManagementServer can be embedded to have forward compatible implementations.
-type UnimplementedControlManagementServer struct {
-}
-
-func (*UnimplementedControlManagementServer) Register(ctx context.Context, req *ControlRegister) (*ControlToken, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
-}
-func (*UnimplementedControlManagementServer) AddAccount(ctx context.Context, req *AddAccountRequest) (*Noop, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddAccount not implemented")
-}
-func (*UnimplementedControlManagementServer) AddLabelLink(ctx context.Context, req *AddLabelLinkRequest) (*Noop, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddLabelLink not implemented")
-}
-func (*UnimplementedControlManagementServer) RemoveLabelLink(ctx context.Context, req *RemoveLabelLinkRequest) (*Noop, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveLabelLink not implemented")
-}
-func (*UnimplementedControlManagementServer) CreateToken(ctx context.Context, req *CreateTokenRequest) (*CreateTokenResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateToken not implemented")
-}
-func (*UnimplementedControlManagementServer) IssueHubToken(ctx context.Context, req *Noop) (*CreateTokenResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IssueHubToken not implemented")
-}
-func (*UnimplementedControlManagementServer) GetTokenPublicKey(ctx context.Context, req *Noop) (*TokenInfo, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTokenPublicKey not implemented")
-}
-func (*UnimplementedControlManagementServer) ListAccounts(ctx context.Context, req *ListAccountsRequest) (*ListAccountsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListAccounts not implemented")
-}
-
-func RegisterControlManagementServer(s *grpc.Server, srv ControlManagementServer) {
-	s.RegisterService(&_ControlManagement_serviceDesc, srv)
-}
-
-func _ControlManagement_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ControlRegister)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*ListTokensResponse)
+	if !ok {
+		that2, ok := that.(ListTokensResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(ControlManagementServer).Register(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlManagement/Register",
+	if len(this.Tokens) != len(that1.Tokens) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlManagementServer).Register(ctx, req.(*ControlRegister))
+	for i := range this.Tokens {
+		if !this.Tokens[i].Equal(that1.Tokens[i]) {
+			return false
+		}
 	}
-	return interceptor(ctx, in, info, handler)
+	if !bytes.Equal(this.NextMarker, that1.NextMarker) {
+		return false
+	}
+	return true
 }
+func (this *WhoAmIResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _ControlManagement_AddAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddAccountRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*WhoAmIResponse)
+	if !ok {
+		that2, ok := that.(WhoAmIResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(ControlManagementServer).AddAccount(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlManagement/AddAccount",
+	if this.Role != that1.Role {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlManagementServer).AddAccount(ctx, req.(*AddAccountRequest))
+	if !this.TokenId.Equal(that1.TokenId) {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _ControlManagement_AddLabelLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddLabelLinkRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	if !this.Account.Equal(that1.Account) {
+		return false
 	}
-	if interceptor == nil {
-		return srv.(ControlManagementServer).AddLabelLink(ctx, in)
+	if len(this.Capabilities) != len(that1.Capabilities) {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlManagement/AddLabelLink",
+	for i := range this.Capabilities {
+		if !this.Capabilities[i].Equal(&that1.Capabilities[i]) {
+			return false
+		}
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlManagementServer).AddLabelLink(ctx, req.(*AddLabelLinkRequest))
+	if this.AccessNamespace != that1.AccessNamespace {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	if !this.ValidUntil.Equal(that1.ValidUntil) {
+		return false
+	}
+	return true
 }
+func (this *TokenStatusResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _ControlManagement_RemoveLabelLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveLabelLinkRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*TokenStatusResponse)
+	if !ok {
+		that2, ok := that.(TokenStatusResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(ControlManagementServer).RemoveLabelLink(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlManagement/RemoveLabelLink",
+	if !this.TokenId.Equal(that1.TokenId) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlManagementServer).RemoveLabelLink(ctx, req.(*RemoveLabelLinkRequest))
+	if !this.NotBefore.Equal(that1.NotBefore) {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _ControlManagement_CreateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateTokenRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	if !this.ValidUntil.Equal(that1.ValidUntil) {
+		return false
 	}
-	if interceptor == nil {
-		return srv.(ControlManagementServer).CreateToken(ctx, in)
+	if this.Renewable != that1.Renewable {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlManagement/CreateToken",
+	if this.WithinRenewalWindow != that1.WithinRenewalWindow {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlManagementServer).CreateToken(ctx, req.(*CreateTokenRequest))
+	if !this.RenewAfter.Equal(that1.RenewAfter) {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
-
-func _ControlManagement_IssueHubToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Noop)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *ExportRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if interceptor == nil {
-		return srv.(ControlManagementServer).IssueHubToken(ctx, in)
+
+	that1, ok := that.(*ExportRequest)
+	if !ok {
+		that2, ok := that.(ExportRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlManagement/IssueHubToken",
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlManagementServer).IssueHubToken(ctx, req.(*Noop))
+	if !this.Account.Equal(that1.Account) {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
+func (this *AccountConfig) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _ControlManagement_GetTokenPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Noop)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*AccountConfig)
+	if !ok {
+		that2, ok := that.(AccountConfig)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(ControlManagementServer).GetTokenPublicKey(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlManagement/GetTokenPublicKey",
+	if !this.Account.Equal(that1.Account) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlManagementServer).GetTokenPublicKey(ctx, req.(*Noop))
+	if !this.Limits.Equal(that1.Limits) {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _ControlManagement_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListAccountsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	if len(this.LabelLinks) != len(that1.LabelLinks) {
+		return false
 	}
-	if interceptor == nil {
-		return srv.(ControlManagementServer).ListAccounts(ctx, in)
+	for i := range this.LabelLinks {
+		if !this.LabelLinks[i].Equal(that1.LabelLinks[i]) {
+			return false
+		}
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ControlManagement/ListAccounts",
+	if len(this.Services) != len(that1.Services) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlManagementServer).ListAccounts(ctx, req.(*ListAccountsRequest))
+	for i := range this.Services {
+		if !this.Services[i].Equal(that1.Services[i]) {
+			return false
+		}
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
+func (this *ImportRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-var _ControlManagement_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.ControlManagement",
-	HandlerType: (*ControlManagementServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Register",
-			Handler:    _ControlManagement_Register_Handler,
-		},
-		{
-			MethodName: "AddAccount",
-			Handler:    _ControlManagement_AddAccount_Handler,
-		},
-		{
-			MethodName: "AddLabelLink",
-			Handler:    _ControlManagement_AddLabelLink_Handler,
-		},
-		{
-			MethodName: "RemoveLabelLink",
-			Handler:    _ControlManagement_RemoveLabelLink_Handler,
-		},
-		{
-			MethodName: "CreateToken",
-			Handler:    _ControlManagement_CreateToken_Handler,
-		},
-		{
-			MethodName: "IssueHubToken",
-			Handler:    _ControlManagement_IssueHubToken_Handler,
-		},
-		{
-			MethodName: "GetTokenPublicKey",
-			Handler:    _ControlManagement_GetTokenPublicKey_Handler,
-		},
-		{
-			MethodName: "ListAccounts",
-			Handler:    _ControlManagement_ListAccounts_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "control.proto",
-}
-
-func (m *ServiceRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*ImportRequest)
+	if !ok {
+		that2, ok := that.(ImportRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ServiceRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Config.Equal(that1.Config) {
+		return false
+	}
+	return true
 }
-
-func (m *ServiceRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Metadata) > 0 {
-		for iNdEx := len(m.Metadata) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Metadata[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x32
-		}
+func (this *ConnectedHub) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if m.Labels != nil {
-		{
-			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
+
+	that1, ok := that.(*ConnectedHub)
+	if !ok {
+		that2, ok := that.(ConnectedHub)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i--
-		dAtA[i] = 0x2a
 	}
-	if len(m.Type) > 0 {
-		i -= len(m.Type)
-		copy(dAtA[i:], m.Type)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.Type)))
-		i--
-		dAtA[i] = 0x22
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Id != nil {
-		{
-			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
+	if this.Hub != that1.Hub {
+		return false
 	}
-	if m.Hub != nil {
-		{
-			size, err := m.Hub.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	if this.Dropped != that1.Dropped {
+		return false
 	}
-	if m.Account != nil {
-		{
-			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	if this.QueueDepth != that1.QueueDepth {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ServiceResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.MaxQueueDepth != that1.MaxQueueDepth {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ServiceResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *ServiceResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *LabelLink) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *ConnectedHubsResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *LabelLink) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *LabelLink) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Limits != nil {
-		{
-			size, err := m.Limits.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
+	that1, ok := that.(*ConnectedHubsResponse)
+	if !ok {
+		that2, ok := that.(ConnectedHubsResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i--
-		dAtA[i] = 0x22
 	}
-	if m.Target != nil {
-		{
-			size, err := m.Target.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Labels != nil {
-		{
-			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	if len(this.Hubs) != len(that1.Hubs) {
+		return false
 	}
-	if m.Account != nil {
-		{
-			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
+	for i := range this.Hubs {
+		if !this.Hubs[i].Equal(that1.Hubs[i]) {
+			return false
 		}
-		i--
-		dAtA[i] = 0xa
 	}
-	return len(dAtA) - i, nil
+	return true
 }
+func (this *HubFlowCounters) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *LabelLinks) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *LabelLinks) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *LabelLinks) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.LabelLinks) > 0 {
-		for iNdEx := len(m.LabelLinks) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.LabelLinks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+	that1, ok := that.(*HubFlowCounters)
+	if !ok {
+		that2, ok := that.(HubFlowCounters)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ServiceRoute) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ServiceRoute) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if this.Hub != that1.Hub {
+		return false
+	}
+	if this.Messages != that1.Messages {
+		return false
+	}
+	if this.Bytes != that1.Bytes {
+		return false
+	}
+	return true
 }
+func (this *FlowCountersResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ServiceRoute) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Labels != nil {
-		{
-			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
+	that1, ok := that.(*FlowCountersResponse)
+	if !ok {
+		that2, ok := that.(FlowCountersResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i--
-		dAtA[i] = 0x22
 	}
-	if len(m.Type) > 0 {
-		i -= len(m.Type)
-		copy(dAtA[i:], m.Type)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.Type)))
-		i--
-		dAtA[i] = 0x1a
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Id != nil {
-		{
-			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	if len(this.Hubs) != len(that1.Hubs) {
+		return false
 	}
-	if m.Hub != nil {
-		{
-			size, err := m.Hub.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
+	for i := range this.Hubs {
+		if !this.Hubs[i].Equal(that1.Hubs[i]) {
+			return false
 		}
-		i--
-		dAtA[i] = 0xa
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *AccountServices) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *SetSuspendedRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *AccountServices) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *AccountServices) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Services) > 0 {
-		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Services[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
+	that1, ok := that.(*SetSuspendedRequest)
+	if !ok {
+		that2, ok := that.(SetSuspendedRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if m.Account != nil {
-		{
-			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ActivityEntry) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if !this.Account.Equal(that1.Account) {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ActivityEntry) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if this.Suspended != that1.Suspended {
+		return false
+	}
+	return true
 }
+func (this *UnregisterRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ActivityEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.RouteRemoved != nil {
-		{
-			size, err := m.RouteRemoved.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
+	that1, ok := that.(*UnregisterRequest)
+	if !ok {
+		that2, ok := that.(UnregisterRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i--
-		dAtA[i] = 0x12
 	}
-	if m.RouteAdded != nil {
-		{
-			size, err := m.RouteAdded.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ConfigRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.Namespace != that1.Namespace {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ConfigRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if this.Cascade != that1.Cascade {
+		return false
+	}
+	return true
 }
+func (this *AllHubsRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ConfigRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Locations) > 0 {
-		for iNdEx := len(m.Locations) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Locations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
+	that1, ok := that.(*AllHubsRequest)
+	if !ok {
+		that2, ok := that.(AllHubsRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if m.InstanceId != nil {
-		{
-			size, err := m.InstanceId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.StableId != nil {
-		{
-			size, err := m.StableId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	if this.Version != that1.Version {
+		return false
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *ConfigResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *ResolveDebugRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *ConfigResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	that1, ok := that.(*ResolveDebugRequest)
+	if !ok {
+		that2, ok := that.(ResolveDebugRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Hostname != that1.Hostname {
+		return false
+	}
+	if this.Path != that1.Path {
+		return false
+	}
+	if this.DeploymentId != that1.DeploymentId {
+		return false
+	}
+	return true
 }
+func (this *ResolveDebugResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ConfigResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.ImageTag) > 0 {
-		i -= len(m.ImageTag)
-		copy(dAtA[i:], m.ImageTag)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.ImageTag)))
-		i--
-		dAtA[i] = 0x3a
+	that1, ok := that.(*ResolveDebugResponse)
+	if !ok {
+		that2, ok := that.(ResolveDebugResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.S3Bucket) > 0 {
-		i -= len(m.S3Bucket)
-		copy(dAtA[i:], m.S3Bucket)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.S3Bucket)))
-		i--
-		dAtA[i] = 0x32
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.S3SecretKey) > 0 {
-		i -= len(m.S3SecretKey)
-		copy(dAtA[i:], m.S3SecretKey)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.S3SecretKey)))
-		i--
-		dAtA[i] = 0x2a
+	if !this.Account.Equal(that1.Account) {
+		return false
 	}
-	if len(m.S3AccessKey) > 0 {
-		i -= len(m.S3AccessKey)
-		copy(dAtA[i:], m.S3AccessKey)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.S3AccessKey)))
-		i--
-		dAtA[i] = 0x22
+	if !this.Target.Equal(that1.Target) {
+		return false
 	}
-	if len(m.TokenPub) > 0 {
-		i -= len(m.TokenPub)
-		copy(dAtA[i:], m.TokenPub)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.TokenPub)))
-		i--
-		dAtA[i] = 0x1a
+	if this.PathPrefix != that1.PathPrefix {
+		return false
 	}
-	if len(m.TlsCert) > 0 {
-		i -= len(m.TlsCert)
-		copy(dAtA[i:], m.TlsCert)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.TlsCert)))
-		i--
-		dAtA[i] = 0x12
+	if this.Suspended != that1.Suspended {
+		return false
 	}
-	if len(m.TlsKey) > 0 {
-		i -= len(m.TlsKey)
-		copy(dAtA[i:], m.TlsKey)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.TlsKey)))
-		i--
-		dAtA[i] = 0xa
+	if this.NewestDeployment != that1.NewestDeployment {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *CentralActivity) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if len(this.Services) != len(that1.Services) {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *CentralActivity) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	for i := range this.Services {
+		if !this.Services[i].Equal(that1.Services[i]) {
+			return false
+		}
+	}
+	return true
 }
+func (this *TargetSplit) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *CentralActivity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.NewLabelLinks != nil {
-		{
-			size, err := m.NewLabelLinks.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
+	that1, ok := that.(*TargetSplit)
+	if !ok {
+		that2, ok := that.(TargetSplit)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i--
-		dAtA[i] = 0x1a
 	}
-	if m.RequestStats {
-		i--
-		if m.RequestStats {
-			dAtA[i] = 1
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Target.Equal(that1.Target) {
+		return false
+	}
+	if this.Weight != that1.Weight {
+		return false
+	}
+	return true
+}
+func (this *TargetSplits) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*TargetSplits)
+	if !ok {
+		that2, ok := that.(TargetSplits)
+		if ok {
+			that1 = &that2
 		} else {
-			dAtA[i] = 0
+			return false
 		}
-		i--
-		dAtA[i] = 0x10
 	}
-	if len(m.AccountServices) > 0 {
-		for iNdEx := len(m.AccountServices) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.AccountServices[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Splits) != len(that1.Splits) {
+		return false
+	}
+	for i := range this.Splits {
+		if !this.Splits[i].Equal(that1.Splits[i]) {
+			return false
 		}
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *HubActivity) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *SetLabelLinkSplitsRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *HubActivity) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *HubActivity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Flow) > 0 {
-		for iNdEx := len(m.Flow) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Flow[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x22
+	that1, ok := that.(*SetLabelLinkSplitsRequest)
+	if !ok {
+		that2, ok := that.(SetLabelLinkSplitsRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if m.Stats != nil {
-		{
-			size, err := m.Stats.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.SentAt != nil {
-		{
-			size, err := m.SentAt.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	if !this.Account.Equal(that1.Account) {
+		return false
 	}
-	if m.HubReg != nil {
-		{
-			size, err := m.HubReg.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
+	if !this.Labels.Equal(that1.Labels) {
+		return false
+	}
+	if len(this.Splits) != len(that1.Splits) {
+		return false
+	}
+	for i := range this.Splits {
+		if !this.Splits[i].Equal(that1.Splits[i]) {
+			return false
 		}
-		i--
-		dAtA[i] = 0xa
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *HubActivity_HubRegistration) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *InvalidateRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *HubActivity_HubRegistration) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *HubActivity_HubRegistration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Locations) > 0 {
-		for iNdEx := len(m.Locations) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Locations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
+	that1, ok := that.(*InvalidateRequest)
+	if !ok {
+		that2, ok := that.(InvalidateRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if m.StableHub != nil {
-		{
-			size, err := m.StableHub.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Hub != nil {
-		{
-			size, err := m.Hub.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	if !this.Account.Equal(that1.Account) {
+		return false
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *HubActivity_HubStats) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *ServiceRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 10)
+	s = append(s, "&pb.ServiceRequest{")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
+	}
+	if this.Hub != nil {
+		s = append(s, "Hub: "+fmt.Sprintf("%#v", this.Hub)+",\n")
+	}
+	if this.Id != nil {
+		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
+	}
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	if this.Labels != nil {
+		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	}
+	if this.Metadata != nil {
+		s = append(s, "Metadata: "+fmt.Sprintf("%#v", this.Metadata)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubActivity_HubStats) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *ServiceResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&pb.ServiceResponse{")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubActivity_HubStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.LastAttemptedUpdate != nil {
-		{
-			size, err := m.LastAttemptedUpdate.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x32
+func (this *LabelLink) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.LastSuccessfulUpdate != nil {
-		{
-			size, err := m.LastSuccessfulUpdate.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x2a
+	s := make([]string, 0, 9)
+	s = append(s, "&pb.LabelLink{")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	if m.CachedBytes != 0 {
-		i = encodeVarintControl(dAtA, i, uint64(m.CachedBytes))
-		i--
-		dAtA[i] = 0x20
+	if this.Labels != nil {
+		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
 	}
-	if m.AccountsCached != 0 {
-		i = encodeVarintControl(dAtA, i, uint64(m.AccountsCached))
-		i--
-		dAtA[i] = 0x18
+	if this.Target != nil {
+		s = append(s, "Target: "+fmt.Sprintf("%#v", this.Target)+",\n")
 	}
-	if m.AccountsSeen != 0 {
-		i = encodeVarintControl(dAtA, i, uint64(m.AccountsSeen))
-		i--
-		dAtA[i] = 0x10
+	if this.Limits != nil {
+		s = append(s, "Limits: "+fmt.Sprintf("%#v", this.Limits)+",\n")
 	}
-	if m.AgentConnections != 0 {
-		i = encodeVarintControl(dAtA, i, uint64(m.AgentConnections))
-		i--
-		dAtA[i] = 0x8
+	if this.Splits != nil {
+		s = append(s, "Splits: "+fmt.Sprintf("%#v", this.Splits)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *LabelLinks) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
-}
-
-func (m *HubInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.LabelLinks{")
+	if this.LabelLinks != nil {
+		s = append(s, "LabelLinks: "+fmt.Sprintf("%#v", this.LabelLinks)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Locations) > 0 {
-		for iNdEx := len(m.Locations) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Locations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
-		}
+func (this *ServiceRoute) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.Id != nil {
-		{
-			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	s := make([]string, 0, 10)
+	s = append(s, "&pb.ServiceRoute{")
+	if this.Hub != nil {
+		s = append(s, "Hub: "+fmt.Sprintf("%#v", this.Hub)+",\n")
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ListOfHubs) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.Id != nil {
+		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ListOfHubs) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *ListOfHubs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Hubs) > 0 {
-		for iNdEx := len(m.Hubs) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Hubs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	if this.Labels != nil {
+		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "Unhealthy: "+fmt.Sprintf("%#v", this.Unhealthy)+",\n")
+	s = append(s, "Weight: "+fmt.Sprintf("%#v", this.Weight)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubSync) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *AccountServices) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
-}
-
-func (m *HubSync) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.AccountServices{")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
+	}
+	if this.Services != nil {
+		s = append(s, "Services: "+fmt.Sprintf("%#v", this.Services)+",\n")
+	}
+	s = append(s, "Suspended: "+fmt.Sprintf("%#v", this.Suspended)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubSync) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Services) > 0 {
-		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Services[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
-		}
+func (this *ActivityEntry) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.StableId != nil {
-		{
-			size, err := m.StableId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.ActivityEntry{")
+	if this.RouteAdded != nil {
+		s = append(s, "RouteAdded: "+fmt.Sprintf("%#v", this.RouteAdded)+",\n")
 	}
-	if m.Id != nil {
-		{
-			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	if this.RouteRemoved != nil {
+		s = append(s, "RouteRemoved: "+fmt.Sprintf("%#v", this.RouteRemoved)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubSyncResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *ConfigRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.ConfigRequest{")
+	if this.StableId != nil {
+		s = append(s, "StableId: "+fmt.Sprintf("%#v", this.StableId)+",\n")
+	}
+	if this.InstanceId != nil {
+		s = append(s, "InstanceId: "+fmt.Sprintf("%#v", this.InstanceId)+",\n")
+	}
+	if this.Locations != nil {
+		s = append(s, "Locations: "+fmt.Sprintf("%#v", this.Locations)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubSyncResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *ConfigResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 12)
+	s = append(s, "&pb.ConfigResponse{")
+	s = append(s, "TlsKey: "+fmt.Sprintf("%#v", this.TlsKey)+",\n")
+	s = append(s, "TlsCert: "+fmt.Sprintf("%#v", this.TlsCert)+",\n")
+	s = append(s, "TokenPub: "+fmt.Sprintf("%#v", this.TokenPub)+",\n")
+	s = append(s, "S3AccessKey: "+fmt.Sprintf("%#v", this.S3AccessKey)+",\n")
+	s = append(s, "S3SecretKey: "+fmt.Sprintf("%#v", this.S3SecretKey)+",\n")
+	s = append(s, "S3Bucket: "+fmt.Sprintf("%#v", this.S3Bucket)+",\n")
+	s = append(s, "ImageTag: "+fmt.Sprintf("%#v", this.ImageTag)+",\n")
+	s = append(s, "ActivityCompressor: "+fmt.Sprintf("%#v", this.ActivityCompressor)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubSyncResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.ServiceCount != 0 {
-		i = encodeVarintControl(dAtA, i, uint64(m.ServiceCount))
-		i--
-		dAtA[i] = 0x8
+func (this *CentralActivity) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
+	s := make([]string, 0, 12)
+	s = append(s, "&pb.CentralActivity{")
+	if this.AccountServices != nil {
+		s = append(s, "AccountServices: "+fmt.Sprintf("%#v", this.AccountServices)+",\n")
+	}
+	s = append(s, "RequestStats: "+fmt.Sprintf("%#v", this.RequestStats)+",\n")
+	if this.NewLabelLinks != nil {
+		s = append(s, "NewLabelLinks: "+fmt.Sprintf("%#v", this.NewLabelLinks)+",\n")
+	}
+	if this.ResolvedRoutes != nil {
+		s = append(s, "ResolvedRoutes: "+fmt.Sprintf("%#v", this.ResolvedRoutes)+",\n")
+	}
+	if this.RemovedLabelLinks != nil {
+		s = append(s, "RemovedLabelLinks: "+fmt.Sprintf("%#v", this.RemovedLabelLinks)+",\n")
+	}
+	if this.Quotas != nil {
+		s = append(s, "Quotas: "+fmt.Sprintf("%#v", this.Quotas)+",\n")
+	}
+	if this.S3Credentials != nil {
+		s = append(s, "S3Credentials: "+fmt.Sprintf("%#v", this.S3Credentials)+",\n")
+	}
+	s = append(s, "S3CredentialsRotated: "+fmt.Sprintf("%#v", this.S3CredentialsRotated)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubRegisterRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *S3Credentials) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.S3Credentials{")
+	s = append(s, "AccessKey: "+fmt.Sprintf("%#v", this.AccessKey)+",\n")
+	s = append(s, "SecretKey: "+fmt.Sprintf("%#v", this.SecretKey)+",\n")
+	s = append(s, "Bucket: "+fmt.Sprintf("%#v", this.Bucket)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubRegisterRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *HubRegisterRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Locations) > 0 {
-		for iNdEx := len(m.Locations) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Locations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
-		}
+func (this *QuotaStatus) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.InstanceId != nil {
-		{
-			size, err := m.InstanceId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	s := make([]string, 0, 9)
+	s = append(s, "&pb.QuotaStatus{")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	if m.StableId != nil {
-		{
-			size, err := m.StableId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	if this.Hub != nil {
+		s = append(s, "Hub: "+fmt.Sprintf("%#v", this.Hub)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "Exceeded: "+fmt.Sprintf("%#v", this.Exceeded)+",\n")
+	s = append(s, "Bytes: "+fmt.Sprintf("%#v", this.Bytes)+",\n")
+	s = append(s, "Messages: "+fmt.Sprintf("%#v", this.Messages)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubRegisterResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *HubActivity) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
-}
-
-func (m *HubRegisterResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *HubRegisterResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.ControlTime != nil {
-		{
-			size, err := m.ControlTime.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	s := make([]string, 0, 9)
+	s = append(s, "&pb.HubActivity{")
+	if this.HubReg != nil {
+		s = append(s, "HubReg: "+fmt.Sprintf("%#v", this.HubReg)+",\n")
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *HubDisconnectRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.SentAt != nil {
+		s = append(s, "SentAt: "+fmt.Sprintf("%#v", this.SentAt)+",\n")
 	}
-	return dAtA[:n], nil
-}
-
-func (m *HubDisconnectRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *HubDisconnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.InstanceId != nil {
-		{
-			size, err := m.InstanceId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	if this.Stats != nil {
+		s = append(s, "Stats: "+fmt.Sprintf("%#v", this.Stats)+",\n")
 	}
-	if m.StableId != nil {
-		{
-			size, err := m.StableId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	if this.Flow != nil {
+		s = append(s, "Flow: "+fmt.Sprintf("%#v", this.Flow)+",\n")
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ServiceTokenRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.CachedAccounts != nil {
+		s = append(s, "CachedAccounts: "+fmt.Sprintf("%#v", this.CachedAccounts)+",\n")
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ServiceTokenRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ServiceTokenRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Namespace) > 0 {
-		i -= len(m.Namespace)
-		copy(dAtA[i:], m.Namespace)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.Namespace)))
-		i--
-		dAtA[i] = 0xa
+func (this *HubActivity_HubRegistration) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ServiceTokenResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.HubActivity_HubRegistration{")
+	if this.Hub != nil {
+		s = append(s, "Hub: "+fmt.Sprintf("%#v", this.Hub)+",\n")
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ServiceTokenResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *ServiceTokenResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Token) > 0 {
-		i -= len(m.Token)
-		copy(dAtA[i:], m.Token)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.Token)))
-		i--
-		dAtA[i] = 0xa
+	if this.StableHub != nil {
+		s = append(s, "StableHub: "+fmt.Sprintf("%#v", this.StableHub)+",\n")
 	}
-	return len(dAtA) - i, nil
+	if this.Locations != nil {
+		s = append(s, "Locations: "+fmt.Sprintf("%#v", this.Locations)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ListServicesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *HubActivity_HubStats) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 10)
+	s = append(s, "&pb.HubActivity_HubStats{")
+	s = append(s, "AgentConnections: "+fmt.Sprintf("%#v", this.AgentConnections)+",\n")
+	s = append(s, "AccountsSeen: "+fmt.Sprintf("%#v", this.AccountsSeen)+",\n")
+	s = append(s, "AccountsCached: "+fmt.Sprintf("%#v", this.AccountsCached)+",\n")
+	s = append(s, "CachedBytes: "+fmt.Sprintf("%#v", this.CachedBytes)+",\n")
+	if this.LastSuccessfulUpdate != nil {
+		s = append(s, "LastSuccessfulUpdate: "+fmt.Sprintf("%#v", this.LastSuccessfulUpdate)+",\n")
+	}
+	if this.LastAttemptedUpdate != nil {
+		s = append(s, "LastAttemptedUpdate: "+fmt.Sprintf("%#v", this.LastAttemptedUpdate)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ListServicesRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *HubInfo) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.HubInfo{")
+	if this.Id != nil {
+		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
+	}
+	if this.Locations != nil {
+		s = append(s, "Locations: "+fmt.Sprintf("%#v", this.Locations)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ListServicesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Account != nil {
-		{
-			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+func (this *ListOfHubs) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ListServicesResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	s := make([]string, 0, 8)
+	s = append(s, "&pb.ListOfHubs{")
+	if this.Hubs != nil {
+		s = append(s, "Hubs: "+fmt.Sprintf("%#v", this.Hubs)+",\n")
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ListServicesResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	s = append(s, "Skipped: "+fmt.Sprintf("%#v", this.Skipped)+",\n")
+	s = append(s, "Version: "+fmt.Sprintf("%#v", this.Version)+",\n")
+	s = append(s, "NotModified: "+fmt.Sprintf("%#v", this.NotModified)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ListServicesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Services) > 0 {
-		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Services[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+func (this *HubSync) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *Service) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.HubSync{")
+	if this.Id != nil {
+		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
 	}
-	return dAtA[:n], nil
-}
-
-func (m *Service) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if this.StableId != nil {
+		s = append(s, "StableId: "+fmt.Sprintf("%#v", this.StableId)+",\n")
+	}
+	if this.Services != nil {
+		s = append(s, "Services: "+fmt.Sprintf("%#v", this.Services)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *Service) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Metadata) > 0 {
-		for iNdEx := len(m.Metadata) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Metadata[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x2a
-		}
+func (this *HubSyncResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.Labels != nil {
-		{
-			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.HubSyncResponse{")
+	s = append(s, "ServiceCount: "+fmt.Sprintf("%#v", this.ServiceCount)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *HubRegisterRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if len(m.Type) > 0 {
-		i -= len(m.Type)
-		copy(dAtA[i:], m.Type)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.Type)))
-		i--
-		dAtA[i] = 0x1a
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.HubRegisterRequest{")
+	if this.StableId != nil {
+		s = append(s, "StableId: "+fmt.Sprintf("%#v", this.StableId)+",\n")
 	}
-	if m.Hub != nil {
-		{
-			size, err := m.Hub.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	if this.InstanceId != nil {
+		s = append(s, "InstanceId: "+fmt.Sprintf("%#v", this.InstanceId)+",\n")
 	}
-	if m.Id != nil {
-		{
-			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	if this.Locations != nil {
+		s = append(s, "Locations: "+fmt.Sprintf("%#v", this.Locations)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *AddAccountRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *HubRegisterResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
-}
-
-func (m *AddAccountRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.HubRegisterResponse{")
+	if this.ControlTime != nil {
+		s = append(s, "ControlTime: "+fmt.Sprintf("%#v", this.ControlTime)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *AddAccountRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Limits != nil {
-		{
-			size, err := m.Limits.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+func (this *HubDisconnectRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.Account != nil {
-		{
-			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.HubDisconnectRequest{")
+	if this.StableId != nil {
+		s = append(s, "StableId: "+fmt.Sprintf("%#v", this.StableId)+",\n")
 	}
-	return len(dAtA) - i, nil
+	if this.InstanceId != nil {
+		s = append(s, "InstanceId: "+fmt.Sprintf("%#v", this.InstanceId)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *AddLabelLinkRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *ServiceTokenRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.ServiceTokenRequest{")
+	s = append(s, "Namespace: "+fmt.Sprintf("%#v", this.Namespace)+",\n")
+	if this.HubScope != nil {
+		s = append(s, "HubScope: "+fmt.Sprintf("%#v", this.HubScope)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *AddLabelLinkRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *AddLabelLinkRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Target != nil {
-		{
-			size, err := m.Target.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
+func (this *ServiceTokenResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.Account != nil {
-		{
-			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.ServiceTokenResponse{")
+	s = append(s, "Token: "+fmt.Sprintf("%#v", this.Token)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ListServicesRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.Labels != nil {
-		{
-			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.ListServicesRequest{")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *Noop) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *ListServicesResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
-}
-
-func (m *Noop) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *Noop) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
-}
-
-func (m *RemoveLabelLinkRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.ListServicesResponse{")
+	if this.Services != nil {
+		s = append(s, "Services: "+fmt.Sprintf("%#v", this.Services)+",\n")
 	}
-	return dAtA[:n], nil
-}
-
-func (m *RemoveLabelLinkRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *RemoveLabelLinkRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Account != nil {
-		{
-			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+func (this *Service) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.Labels != nil {
-		{
-			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	s := make([]string, 0, 10)
+	s = append(s, "&pb.Service{")
+	if this.Id != nil {
+		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *CreateTokenRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.Hub != nil {
+		s = append(s, "Hub: "+fmt.Sprintf("%#v", this.Hub)+",\n")
 	}
-	return dAtA[:n], nil
-}
-
-func (m *CreateTokenRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *CreateTokenRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.ValidDuration != nil {
-		{
-			size, err := m.ValidDuration.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	if this.Labels != nil {
+		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
 	}
-	if len(m.Capabilities) > 0 {
-		for iNdEx := len(m.Capabilities) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Capabilities[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
-		}
+	if this.Metadata != nil {
+		s = append(s, "Metadata: "+fmt.Sprintf("%#v", this.Metadata)+",\n")
 	}
-	if m.Account != nil {
-		{
-			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintControl(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *CreateTokenResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *StreamServicesRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
-}
-
-func (m *CreateTokenResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *CreateTokenResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Token) > 0 {
-		i -= len(m.Token)
-		copy(dAtA[i:], m.Token)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.Token)))
-		i--
-		dAtA[i] = 0xa
+	s := make([]string, 0, 8)
+	s = append(s, "&pb.StreamServicesRequest{")
+	s = append(s, "Namespace: "+fmt.Sprintf("%#v", this.Namespace)+",\n")
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	if this.Labels != nil {
+		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "BatchSize: "+fmt.Sprintf("%#v", this.BatchSize)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ControlRegister) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *AddAccountRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ControlRegister) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *ControlRegister) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Namespace) > 0 {
-		i -= len(m.Namespace)
-		copy(dAtA[i:], m.Namespace)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.Namespace)))
-		i--
-		dAtA[i] = 0xa
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.AddAccountRequest{")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ControlToken) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.Limits != nil {
+		s = append(s, "Limits: "+fmt.Sprintf("%#v", this.Limits)+",\n")
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ControlToken) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ControlToken) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Token) > 0 {
-		i -= len(m.Token)
-		copy(dAtA[i:], m.Token)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.Token)))
-		i--
-		dAtA[i] = 0xa
+func (this *CreateAccountRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *TokenInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.CreateAccountRequest{")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	return dAtA[:n], nil
-}
-
-func (m *TokenInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *TokenInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.PublicKey) > 0 {
-		i -= len(m.PublicKey)
-		copy(dAtA[i:], m.PublicKey)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.PublicKey)))
-		i--
-		dAtA[i] = 0xa
+	if this.Limits != nil {
+		s = append(s, "Limits: "+fmt.Sprintf("%#v", this.Limits)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ListAccountsRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *CreateAccountResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ListAccountsRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *ListAccountsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Marker) > 0 {
-		i -= len(m.Marker)
-		copy(dAtA[i:], m.Marker)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.Marker)))
-		i--
-		dAtA[i] = 0x12
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.CreateAccountResponse{")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	if m.Limit != 0 {
-		i = encodeVarintControl(dAtA, i, uint64(m.Limit))
-		i--
-		dAtA[i] = 0x8
+	if this.Limits != nil {
+		s = append(s, "Limits: "+fmt.Sprintf("%#v", this.Limits)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ListAccountsResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *AddLabelLinkRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ListAccountsResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *ListAccountsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.NextMarker) > 0 {
-		i -= len(m.NextMarker)
-		copy(dAtA[i:], m.NextMarker)
-		i = encodeVarintControl(dAtA, i, uint64(len(m.NextMarker)))
-		i--
-		dAtA[i] = 0x12
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.AddLabelLinkRequest{")
+	if this.Labels != nil {
+		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
 	}
-	if len(m.Accounts) > 0 {
-		for iNdEx := len(m.Accounts) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Accounts[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintControl(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	return len(dAtA) - i, nil
-}
-
-func encodeVarintControl(dAtA []byte, offset int, v uint64) int {
-	offset -= sovControl(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+	if this.Target != nil {
+		s = append(s, "Target: "+fmt.Sprintf("%#v", this.Target)+",\n")
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-func (m *ServiceRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *Noop) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	if m.Account != nil {
-		l = m.Account.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 4)
+	s = append(s, "&pb.Noop{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *RemoveLabelLinkRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.Hub != nil {
-		l = m.Hub.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.RemoveLabelLinkRequest{")
+	if this.Labels != nil {
+		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
 	}
-	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	l = len(m.Type)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *CreateTokenRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.Labels != nil {
-		l = m.Labels.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.CreateTokenRequest{")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	if len(m.Metadata) > 0 {
-		for _, e := range m.Metadata {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
+	if this.Capabilities != nil {
+		vs := make([]TokenCapability, len(this.Capabilities))
+		for i := range vs {
+			vs[i] = this.Capabilities[i]
 		}
+		s = append(s, "Capabilities: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	return n
+	if this.ValidDuration != nil {
+		s = append(s, "ValidDuration: "+fmt.Sprintf("%#v", this.ValidDuration)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ServiceResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	return n
-}
-
-func (m *LabelLink) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *CreateTokenResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	if m.Account != nil {
-		l = m.Account.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 10)
+	s = append(s, "&pb.CreateTokenResponse{")
+	s = append(s, "Token: "+fmt.Sprintf("%#v", this.Token)+",\n")
+	s = append(s, "Role: "+fmt.Sprintf("%#v", this.Role)+",\n")
+	if this.TokenId != nil {
+		s = append(s, "TokenId: "+fmt.Sprintf("%#v", this.TokenId)+",\n")
 	}
-	if m.Labels != nil {
-		l = m.Labels.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	if m.Target != nil {
-		l = m.Target.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if this.Capabilities != nil {
+		vs := make([]TokenCapability, len(this.Capabilities))
+		for i := range vs {
+			vs[i] = this.Capabilities[i]
+		}
+		s = append(s, "Capabilities: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	if m.Limits != nil {
-		l = m.Limits.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if this.ValidUntil != nil {
+		s = append(s, "ValidUntil: "+fmt.Sprintf("%#v", this.ValidUntil)+",\n")
 	}
-	return n
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelLinks) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *CreateTokensRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	if len(m.LabelLinks) > 0 {
-		for _, e := range m.LabelLinks {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.CreateTokensRequest{")
+	if this.Tokens != nil {
+		s = append(s, "Tokens: "+fmt.Sprintf("%#v", this.Tokens)+",\n")
 	}
-	return n
+	s = append(s, "AllOrNothing: "+fmt.Sprintf("%#v", this.AllOrNothing)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ServiceRoute) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *CreateTokenResult) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	if m.Hub != nil {
-		l = m.Hub.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.CreateTokenResult{")
+	s = append(s, "Token: "+fmt.Sprintf("%#v", this.Token)+",\n")
+	s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *CreateTokensResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.CreateTokensResponse{")
+	if this.Results != nil {
+		s = append(s, "Results: "+fmt.Sprintf("%#v", this.Results)+",\n")
 	}
-	l = len(m.Type)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ControlRegister) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.Labels != nil {
-		l = m.Labels.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.ControlRegister{")
+	s = append(s, "Namespace: "+fmt.Sprintf("%#v", this.Namespace)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ControlToken) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return n
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.ControlToken{")
+	s = append(s, "Token: "+fmt.Sprintf("%#v", this.Token)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *AccountServices) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *TokenInfo) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	if m.Account != nil {
-		l = m.Account.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.TokenInfo{")
+	s = append(s, "PublicKey: "+fmt.Sprintf("%#v", this.PublicKey)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ListAccountsRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if len(m.Services) > 0 {
-		for _, e := range m.Services {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.ListAccountsRequest{")
+	s = append(s, "Limit: "+fmt.Sprintf("%#v", this.Limit)+",\n")
+	s = append(s, "Marker: "+fmt.Sprintf("%#v", this.Marker)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ListAccountsResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return n
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.ListAccountsResponse{")
+	if this.Accounts != nil {
+		s = append(s, "Accounts: "+fmt.Sprintf("%#v", this.Accounts)+",\n")
+	}
+	s = append(s, "NextMarker: "+fmt.Sprintf("%#v", this.NextMarker)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ActivityEntry) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *ManagementClient) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	if m.RouteAdded != nil {
-		l = m.RouteAdded.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.ManagementClient{")
+	if this.Id != nil {
+		s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
 	}
-	if m.RouteRemoved != nil {
-		l = m.RouteRemoved.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s = append(s, "Namespace: "+fmt.Sprintf("%#v", this.Namespace)+",\n")
+	if this.CreatedAt != nil {
+		s = append(s, "CreatedAt: "+fmt.Sprintf("%#v", this.CreatedAt)+",\n")
 	}
-	return n
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ConfigRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *ListManagementClientsRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	if m.StableId != nil {
-		l = m.StableId.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.ListManagementClientsRequest{")
+	s = append(s, "Limit: "+fmt.Sprintf("%#v", this.Limit)+",\n")
+	s = append(s, "Marker: "+fmt.Sprintf("%#v", this.Marker)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ListManagementClientsResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.InstanceId != nil {
-		l = m.InstanceId.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.ListManagementClientsResponse{")
+	if this.Clients != nil {
+		s = append(s, "Clients: "+fmt.Sprintf("%#v", this.Clients)+",\n")
 	}
-	if len(m.Locations) > 0 {
-		for _, e := range m.Locations {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
+	s = append(s, "NextMarker: "+fmt.Sprintf("%#v", this.NextMarker)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ListTokensRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return n
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.ListTokensRequest{")
+	s = append(s, "Limit: "+fmt.Sprintf("%#v", this.Limit)+",\n")
+	s = append(s, "Marker: "+fmt.Sprintf("%#v", this.Marker)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ConfigResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *IssuedToken) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	l = len(m.TlsKey)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 10)
+	s = append(s, "&pb.IssuedToken{")
+	if this.TokenId != nil {
+		s = append(s, "TokenId: "+fmt.Sprintf("%#v", this.TokenId)+",\n")
 	}
-	l = len(m.TlsCert)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	s = append(s, "Role: "+fmt.Sprintf("%#v", this.Role)+",\n")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	l = len(m.TokenPub)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	if this.Capabilities != nil {
+		vs := make([]TokenCapability, len(this.Capabilities))
+		for i := range vs {
+			vs[i] = this.Capabilities[i]
+		}
+		s = append(s, "Capabilities: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	l = len(m.S3AccessKey)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	if this.ValidUntil != nil {
+		s = append(s, "ValidUntil: "+fmt.Sprintf("%#v", this.ValidUntil)+",\n")
 	}
-	l = len(m.S3SecretKey)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	if this.IssuedAt != nil {
+		s = append(s, "IssuedAt: "+fmt.Sprintf("%#v", this.IssuedAt)+",\n")
 	}
-	l = len(m.S3Bucket)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ListTokensResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	l = len(m.ImageTag)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.ListTokensResponse{")
+	if this.Tokens != nil {
+		s = append(s, "Tokens: "+fmt.Sprintf("%#v", this.Tokens)+",\n")
 	}
-	return n
+	s = append(s, "NextMarker: "+fmt.Sprintf("%#v", this.NextMarker)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *CentralActivity) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *WhoAmIResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	if len(m.AccountServices) > 0 {
-		for _, e := range m.AccountServices {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
+	s := make([]string, 0, 10)
+	s = append(s, "&pb.WhoAmIResponse{")
+	s = append(s, "Role: "+fmt.Sprintf("%#v", this.Role)+",\n")
+	if this.TokenId != nil {
+		s = append(s, "TokenId: "+fmt.Sprintf("%#v", this.TokenId)+",\n")
 	}
-	if m.RequestStats {
-		n += 2
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	if m.NewLabelLinks != nil {
-		l = m.NewLabelLinks.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if this.Capabilities != nil {
+		vs := make([]TokenCapability, len(this.Capabilities))
+		for i := range vs {
+			vs[i] = this.Capabilities[i]
+		}
+		s = append(s, "Capabilities: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	return n
+	s = append(s, "AccessNamespace: "+fmt.Sprintf("%#v", this.AccessNamespace)+",\n")
+	if this.ValidUntil != nil {
+		s = append(s, "ValidUntil: "+fmt.Sprintf("%#v", this.ValidUntil)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubActivity) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *TokenStatusResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	if m.HubReg != nil {
-		l = m.HubReg.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 10)
+	s = append(s, "&pb.TokenStatusResponse{")
+	if this.TokenId != nil {
+		s = append(s, "TokenId: "+fmt.Sprintf("%#v", this.TokenId)+",\n")
 	}
-	if m.SentAt != nil {
-		l = m.SentAt.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if this.NotBefore != nil {
+		s = append(s, "NotBefore: "+fmt.Sprintf("%#v", this.NotBefore)+",\n")
 	}
-	if m.Stats != nil {
-		l = m.Stats.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if this.ValidUntil != nil {
+		s = append(s, "ValidUntil: "+fmt.Sprintf("%#v", this.ValidUntil)+",\n")
 	}
-	if len(m.Flow) > 0 {
-		for _, e := range m.Flow {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
+	s = append(s, "Renewable: "+fmt.Sprintf("%#v", this.Renewable)+",\n")
+	s = append(s, "WithinRenewalWindow: "+fmt.Sprintf("%#v", this.WithinRenewalWindow)+",\n")
+	if this.RenewAfter != nil {
+		s = append(s, "RenewAfter: "+fmt.Sprintf("%#v", this.RenewAfter)+",\n")
 	}
-	return n
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubActivity_HubRegistration) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Hub != nil {
-		l = m.Hub.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.StableHub != nil {
-		l = m.StableHub.Size()
-		n += 1 + l + sovControl(uint64(l))
+func (this *ExportRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if len(m.Locations) > 0 {
-		for _, e := range m.Locations {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.ExportRequest{")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	return n
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubActivity_HubStats) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *AccountConfig) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	if m.AgentConnections != 0 {
-		n += 1 + sovControl(uint64(m.AgentConnections))
+	s := make([]string, 0, 8)
+	s = append(s, "&pb.AccountConfig{")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	if m.AccountsSeen != 0 {
-		n += 1 + sovControl(uint64(m.AccountsSeen))
+	if this.Limits != nil {
+		s = append(s, "Limits: "+fmt.Sprintf("%#v", this.Limits)+",\n")
 	}
-	if m.AccountsCached != 0 {
-		n += 1 + sovControl(uint64(m.AccountsCached))
+	if this.LabelLinks != nil {
+		s = append(s, "LabelLinks: "+fmt.Sprintf("%#v", this.LabelLinks)+",\n")
 	}
-	if m.CachedBytes != 0 {
-		n += 1 + sovControl(uint64(m.CachedBytes))
+	if this.Services != nil {
+		s = append(s, "Services: "+fmt.Sprintf("%#v", this.Services)+",\n")
 	}
-	if m.LastSuccessfulUpdate != nil {
-		l = m.LastSuccessfulUpdate.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ImportRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.LastAttemptedUpdate != nil {
-		l = m.LastAttemptedUpdate.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.ImportRequest{")
+	if this.Config != nil {
+		s = append(s, "Config: "+fmt.Sprintf("%#v", this.Config)+",\n")
 	}
-	return n
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubInfo) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *ConnectedHub) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 8)
+	s = append(s, "&pb.ConnectedHub{")
+	s = append(s, "Hub: "+fmt.Sprintf("%#v", this.Hub)+",\n")
+	s = append(s, "Dropped: "+fmt.Sprintf("%#v", this.Dropped)+",\n")
+	s = append(s, "QueueDepth: "+fmt.Sprintf("%#v", this.QueueDepth)+",\n")
+	s = append(s, "MaxQueueDepth: "+fmt.Sprintf("%#v", this.MaxQueueDepth)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ConnectedHubsResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if len(m.Locations) > 0 {
-		for _, e := range m.Locations {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.ConnectedHubsResponse{")
+	if this.Hubs != nil {
+		s = append(s, "Hubs: "+fmt.Sprintf("%#v", this.Hubs)+",\n")
 	}
-	return n
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ListOfHubs) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Hubs) > 0 {
-		for _, e := range m.Hubs {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
+func (this *HubFlowCounters) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return n
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.HubFlowCounters{")
+	s = append(s, "Hub: "+fmt.Sprintf("%#v", this.Hub)+",\n")
+	s = append(s, "Messages: "+fmt.Sprintf("%#v", this.Messages)+",\n")
+	s = append(s, "Bytes: "+fmt.Sprintf("%#v", this.Bytes)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubSync) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *FlowCountersResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.FlowCountersResponse{")
+	if this.Hubs != nil {
+		s = append(s, "Hubs: "+fmt.Sprintf("%#v", this.Hubs)+",\n")
 	}
-	if m.StableId != nil {
-		l = m.StableId.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SetSuspendedRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if len(m.Services) > 0 {
-		for _, e := range m.Services {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.SetSuspendedRequest{")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	return n
+	s = append(s, "Suspended: "+fmt.Sprintf("%#v", this.Suspended)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubSyncResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *UnregisterRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	if m.ServiceCount != 0 {
-		n += 1 + sovControl(uint64(m.ServiceCount))
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.UnregisterRequest{")
+	s = append(s, "Namespace: "+fmt.Sprintf("%#v", this.Namespace)+",\n")
+	s = append(s, "Cascade: "+fmt.Sprintf("%#v", this.Cascade)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AllHubsRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return n
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.AllHubsRequest{")
+	s = append(s, "Version: "+fmt.Sprintf("%#v", this.Version)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubRegisterRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *ResolveDebugRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	if m.StableId != nil {
-		l = m.StableId.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.ResolveDebugRequest{")
+	s = append(s, "Hostname: "+fmt.Sprintf("%#v", this.Hostname)+",\n")
+	s = append(s, "Path: "+fmt.Sprintf("%#v", this.Path)+",\n")
+	s = append(s, "DeploymentId: "+fmt.Sprintf("%#v", this.DeploymentId)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ResolveDebugResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.InstanceId != nil {
-		l = m.InstanceId.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 10)
+	s = append(s, "&pb.ResolveDebugResponse{")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	if len(m.Locations) > 0 {
-		for _, e := range m.Locations {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
+	if this.Target != nil {
+		s = append(s, "Target: "+fmt.Sprintf("%#v", this.Target)+",\n")
 	}
-	return n
+	s = append(s, "PathPrefix: "+fmt.Sprintf("%#v", this.PathPrefix)+",\n")
+	s = append(s, "Suspended: "+fmt.Sprintf("%#v", this.Suspended)+",\n")
+	s = append(s, "NewestDeployment: "+fmt.Sprintf("%#v", this.NewestDeployment)+",\n")
+	if this.Services != nil {
+		s = append(s, "Services: "+fmt.Sprintf("%#v", this.Services)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubRegisterResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *TargetSplit) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	if m.ControlTime != nil {
-		l = m.ControlTime.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.TargetSplit{")
+	if this.Target != nil {
+		s = append(s, "Target: "+fmt.Sprintf("%#v", this.Target)+",\n")
 	}
-	return n
+	s = append(s, "Weight: "+fmt.Sprintf("%#v", this.Weight)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HubDisconnectRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.StableId != nil {
-		l = m.StableId.Size()
-		n += 1 + l + sovControl(uint64(l))
+func (this *TargetSplits) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.InstanceId != nil {
-		l = m.InstanceId.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.TargetSplits{")
+	if this.Splits != nil {
+		s = append(s, "Splits: "+fmt.Sprintf("%#v", this.Splits)+",\n")
 	}
-	return n
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ServiceTokenRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *SetLabelLinkSplitsRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	l = len(m.Namespace)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.SetLabelLinkSplitsRequest{")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	return n
-}
-
-func (m *ServiceTokenResponse) Size() (n int) {
-	if m == nil {
-		return 0
+	if this.Labels != nil {
+		s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
 	}
-	var l int
-	_ = l
-	l = len(m.Token)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	if this.Splits != nil {
+		s = append(s, "Splits: "+fmt.Sprintf("%#v", this.Splits)+",\n")
 	}
-	return n
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ListServicesRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *InvalidateRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	if m.Account != nil {
-		l = m.Account.Size()
-		n += 1 + l + sovControl(uint64(l))
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.InvalidateRequest{")
+	if this.Account != nil {
+		s = append(s, "Account: "+fmt.Sprintf("%#v", this.Account)+",\n")
 	}
-	return n
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ListServicesResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Services) > 0 {
-		for _, e := range m.Services {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
+func valueToGoStringControl(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
 	}
-	return n
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
 }
 
-func (m *Service) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.Hub != nil {
-		l = m.Hub.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	l = len(m.Type)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.Labels != nil {
-		l = m.Labels.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if len(m.Metadata) > 0 {
-		for _, e := range m.Metadata {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
-	}
-	return n
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ControlServicesClient is the client API for ControlServices service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ControlServicesClient interface {
+	AddService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
+	RemoveService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
+	ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error)
+	StreamServices(ctx context.Context, in *StreamServicesRequest, opts ...grpc.CallOption) (ControlServices_StreamServicesClient, error)
+	FetchConfig(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	StreamActivity(ctx context.Context, opts ...grpc.CallOption) (ControlServices_StreamActivityClient, error)
+	SyncHub(ctx context.Context, in *HubSync, opts ...grpc.CallOption) (*HubSyncResponse, error)
+	HubDisconnect(ctx context.Context, in *HubDisconnectRequest, opts ...grpc.CallOption) (*Noop, error)
+	AllHubs(ctx context.Context, in *AllHubsRequest, opts ...grpc.CallOption) (*ListOfHubs, error)
+	RequestServiceToken(ctx context.Context, in *ServiceTokenRequest, opts ...grpc.CallOption) (*ServiceTokenResponse, error)
 }
 
-func (m *AddAccountRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Account != nil {
-		l = m.Account.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.Limits != nil {
-		l = m.Limits.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	return n
+type controlServicesClient struct {
+	cc *grpc.ClientConn
 }
 
-func (m *AddLabelLinkRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Labels != nil {
-		l = m.Labels.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if m.Account != nil {
-		l = m.Account.Size()
-		n += 1 + l + sovControl(uint64(l))
+func NewControlServicesClient(cc *grpc.ClientConn) ControlServicesClient {
+	return &controlServicesClient{cc}
+}
+
+func (c *controlServicesClient) AddService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
+	out := new(ServiceResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlServices/AddService", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.Target != nil {
-		l = m.Target.Size()
-		n += 1 + l + sovControl(uint64(l))
+	return out, nil
+}
+
+func (c *controlServicesClient) RemoveService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
+	out := new(ServiceResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlServices/RemoveService", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func (m *Noop) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *controlServicesClient) ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error) {
+	out := new(ListServicesResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlServices/ListServices", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	return n
+	return out, nil
 }
 
-func (m *RemoveLabelLinkRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *controlServicesClient) StreamServices(ctx context.Context, in *StreamServicesRequest, opts ...grpc.CallOption) (ControlServices_StreamServicesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ControlServices_serviceDesc.Streams[0], "/pb.ControlServices/StreamServices", opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Labels != nil {
-		l = m.Labels.Size()
-		n += 1 + l + sovControl(uint64(l))
+	x := &controlServicesStreamServicesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	if m.Account != nil {
-		l = m.Account.Size()
-		n += 1 + l + sovControl(uint64(l))
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	return n
+	return x, nil
 }
 
-func (m *CreateTokenRequest) Size() (n int) {
-	if m == nil {
-		return 0
+type ControlServices_StreamServicesClient interface {
+	Recv() (*ListServicesResponse, error)
+	grpc.ClientStream
+}
+
+type controlServicesStreamServicesClient struct {
+	grpc.ClientStream
+}
+
+func (x *controlServicesStreamServicesClient) Recv() (*ListServicesResponse, error) {
+	m := new(ListServicesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Account != nil {
-		l = m.Account.Size()
-		n += 1 + l + sovControl(uint64(l))
-	}
-	if len(m.Capabilities) > 0 {
-		for _, e := range m.Capabilities {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
-	}
-	if m.ValidDuration != nil {
-		l = m.ValidDuration.Size()
-		n += 1 + l + sovControl(uint64(l))
+	return m, nil
+}
+
+func (c *controlServicesClient) FetchConfig(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlServices/FetchConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func (m *CreateTokenResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *controlServicesClient) StreamActivity(ctx context.Context, opts ...grpc.CallOption) (ControlServices_StreamActivityClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ControlServices_serviceDesc.Streams[1], "/pb.ControlServices/StreamActivity", opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.Token)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	x := &controlServicesStreamActivityClient{stream}
+	return x, nil
+}
+
+type ControlServices_StreamActivityClient interface {
+	Send(*HubActivity) error
+	Recv() (*CentralActivity, error)
+	grpc.ClientStream
+}
+
+type controlServicesStreamActivityClient struct {
+	grpc.ClientStream
+}
+
+func (x *controlServicesStreamActivityClient) Send(m *HubActivity) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *controlServicesStreamActivityClient) Recv() (*CentralActivity, error) {
+	m := new(CentralActivity)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return n
+	return m, nil
 }
 
-func (m *ControlRegister) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *controlServicesClient) SyncHub(ctx context.Context, in *HubSync, opts ...grpc.CallOption) (*HubSyncResponse, error) {
+	out := new(HubSyncResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlServices/SyncHub", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.Namespace)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	return out, nil
+}
+
+func (c *controlServicesClient) HubDisconnect(ctx context.Context, in *HubDisconnectRequest, opts ...grpc.CallOption) (*Noop, error) {
+	out := new(Noop)
+	err := c.cc.Invoke(ctx, "/pb.ControlServices/HubDisconnect", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func (m *ControlToken) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *controlServicesClient) AllHubs(ctx context.Context, in *AllHubsRequest, opts ...grpc.CallOption) (*ListOfHubs, error) {
+	out := new(ListOfHubs)
+	err := c.cc.Invoke(ctx, "/pb.ControlServices/AllHubs", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.Token)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	return out, nil
+}
+
+func (c *controlServicesClient) RequestServiceToken(ctx context.Context, in *ServiceTokenRequest, opts ...grpc.CallOption) (*ServiceTokenResponse, error) {
+	out := new(ServiceTokenResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlServices/RequestServiceToken", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func (m *TokenInfo) Size() (n int) {
-	if m == nil {
-		return 0
+// ControlServicesServer is the server API for ControlServices service.
+type ControlServicesServer interface {
+	AddService(context.Context, *ServiceRequest) (*ServiceResponse, error)
+	RemoveService(context.Context, *ServiceRequest) (*ServiceResponse, error)
+	ListServices(context.Context, *ListServicesRequest) (*ListServicesResponse, error)
+	StreamServices(*StreamServicesRequest, ControlServices_StreamServicesServer) error
+	FetchConfig(context.Context, *ConfigRequest) (*ConfigResponse, error)
+	StreamActivity(ControlServices_StreamActivityServer) error
+	SyncHub(context.Context, *HubSync) (*HubSyncResponse, error)
+	HubDisconnect(context.Context, *HubDisconnectRequest) (*Noop, error)
+	AllHubs(context.Context, *AllHubsRequest) (*ListOfHubs, error)
+	RequestServiceToken(context.Context, *ServiceTokenRequest) (*ServiceTokenResponse, error)
+}
+
+// UnimplementedControlServicesServer can be embedded to have forward compatible implementations.
+type UnimplementedControlServicesServer struct {
+}
+
+func (*UnimplementedControlServicesServer) AddService(ctx context.Context, req *ServiceRequest) (*ServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddService not implemented")
+}
+func (*UnimplementedControlServicesServer) RemoveService(ctx context.Context, req *ServiceRequest) (*ServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveService not implemented")
+}
+func (*UnimplementedControlServicesServer) ListServices(ctx context.Context, req *ListServicesRequest) (*ListServicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServices not implemented")
+}
+func (*UnimplementedControlServicesServer) StreamServices(req *StreamServicesRequest, srv ControlServices_StreamServicesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamServices not implemented")
+}
+func (*UnimplementedControlServicesServer) FetchConfig(ctx context.Context, req *ConfigRequest) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchConfig not implemented")
+}
+func (*UnimplementedControlServicesServer) StreamActivity(srv ControlServices_StreamActivityServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamActivity not implemented")
+}
+func (*UnimplementedControlServicesServer) SyncHub(ctx context.Context, req *HubSync) (*HubSyncResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncHub not implemented")
+}
+func (*UnimplementedControlServicesServer) HubDisconnect(ctx context.Context, req *HubDisconnectRequest) (*Noop, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HubDisconnect not implemented")
+}
+func (*UnimplementedControlServicesServer) AllHubs(ctx context.Context, req *AllHubsRequest) (*ListOfHubs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AllHubs not implemented")
+}
+func (*UnimplementedControlServicesServer) RequestServiceToken(ctx context.Context, req *ServiceTokenRequest) (*ServiceTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestServiceToken not implemented")
+}
+
+func RegisterControlServicesServer(s *grpc.Server, srv ControlServicesServer) {
+	s.RegisterService(&_ControlServices_serviceDesc, srv)
+}
+
+func _ControlServices_AddService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.PublicKey)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	if interceptor == nil {
+		return srv.(ControlServicesServer).AddService(ctx, in)
 	}
-	return n
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlServices/AddService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServicesServer).AddService(ctx, req.(*ServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ListAccountsRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func _ControlServices_RemoveService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Limit != 0 {
-		n += 1 + sovControl(uint64(m.Limit))
+	if interceptor == nil {
+		return srv.(ControlServicesServer).RemoveService(ctx, in)
 	}
-	l = len(m.Marker)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlServices/RemoveService",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServicesServer).RemoveService(ctx, req.(*ServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ListAccountsResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func _ControlServices_ListServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if len(m.Accounts) > 0 {
-		for _, e := range m.Accounts {
-			l = e.Size()
-			n += 1 + l + sovControl(uint64(l))
-		}
+	if interceptor == nil {
+		return srv.(ControlServicesServer).ListServices(ctx, in)
 	}
-	l = len(m.NextMarker)
-	if l > 0 {
-		n += 1 + l + sovControl(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlServices/ListServices",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServicesServer).ListServices(ctx, req.(*ListServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func sovControl(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func _ControlServices_StreamServices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamServicesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControlServicesServer).StreamServices(m, &controlServicesStreamServicesServer{stream})
 }
-func sozControl(x uint64) (n int) {
-	return sovControl(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+type ControlServices_StreamServicesServer interface {
+	Send(*ListServicesResponse) error
+	grpc.ServerStream
 }
-func (this *ServiceRequest) String() string {
-	if this == nil {
-		return "nil"
+
+type controlServicesStreamServicesServer struct {
+	grpc.ServerStream
+}
+
+func (x *controlServicesStreamServicesServer) Send(m *ListServicesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ControlServices_FetchConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	repeatedStringForMetadata := "[]*KVPair{"
-	for _, f := range this.Metadata {
-		repeatedStringForMetadata += strings.Replace(fmt.Sprintf("%v", f), "KVPair", "KVPair", 1) + ","
+	if interceptor == nil {
+		return srv.(ControlServicesServer).FetchConfig(ctx, in)
 	}
-	repeatedStringForMetadata += "}"
-	s := strings.Join([]string{`&ServiceRequest{`,
-		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
-		`Hub:` + strings.Replace(fmt.Sprintf("%v", this.Hub), "ULID", "ULID", 1) + `,`,
-		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
-		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
-		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
-		`Metadata:` + repeatedStringForMetadata + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ServiceResponse) String() string {
-	if this == nil {
-		return "nil"
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlServices/FetchConfig",
 	}
-	s := strings.Join([]string{`&ServiceResponse{`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *LabelLink) String() string {
-	if this == nil {
-		return "nil"
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServicesServer).FetchConfig(ctx, req.(*ConfigRequest))
 	}
-	s := strings.Join([]string{`&LabelLink{`,
-		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
-		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
-		`Target:` + strings.Replace(fmt.Sprintf("%v", this.Target), "LabelSet", "LabelSet", 1) + `,`,
-		`Limits:` + strings.Replace(fmt.Sprintf("%v", this.Limits), "Account_Limits", "Account_Limits", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return interceptor(ctx, in, info, handler)
 }
-func (this *LabelLinks) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForLabelLinks := "[]*LabelLink{"
-	for _, f := range this.LabelLinks {
-		repeatedStringForLabelLinks += strings.Replace(f.String(), "LabelLink", "LabelLink", 1) + ","
-	}
-	repeatedStringForLabelLinks += "}"
-	s := strings.Join([]string{`&LabelLinks{`,
-		`LabelLinks:` + repeatedStringForLabelLinks + `,`,
-		`}`,
-	}, "")
-	return s
+
+func _ControlServices_StreamActivity_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ControlServicesServer).StreamActivity(&controlServicesStreamActivityServer{stream})
 }
-func (this *ServiceRoute) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&ServiceRoute{`,
-		`Hub:` + strings.Replace(fmt.Sprintf("%v", this.Hub), "ULID", "ULID", 1) + `,`,
-		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
-		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
-		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+
+type ControlServices_StreamActivityServer interface {
+	Send(*CentralActivity) error
+	Recv() (*HubActivity, error)
+	grpc.ServerStream
 }
-func (this *AccountServices) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForServices := "[]*ServiceRoute{"
-	for _, f := range this.Services {
-		repeatedStringForServices += strings.Replace(f.String(), "ServiceRoute", "ServiceRoute", 1) + ","
-	}
-	repeatedStringForServices += "}"
-	s := strings.Join([]string{`&AccountServices{`,
-		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
-		`Services:` + repeatedStringForServices + `,`,
-		`}`,
-	}, "")
-	return s
+
+type controlServicesStreamActivityServer struct {
+	grpc.ServerStream
 }
-func (this *ActivityEntry) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&ActivityEntry{`,
-		`RouteAdded:` + strings.Replace(this.RouteAdded.String(), "AccountServices", "AccountServices", 1) + `,`,
-		`RouteRemoved:` + strings.Replace(fmt.Sprintf("%v", this.RouteRemoved), "ULID", "ULID", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (x *controlServicesStreamActivityServer) Send(m *CentralActivity) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (this *ConfigRequest) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForLocations := "[]*NetworkLocation{"
-	for _, f := range this.Locations {
-		repeatedStringForLocations += strings.Replace(fmt.Sprintf("%v", f), "NetworkLocation", "NetworkLocation", 1) + ","
+
+func (x *controlServicesStreamActivityServer) Recv() (*HubActivity, error) {
+	m := new(HubActivity)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	repeatedStringForLocations += "}"
-	s := strings.Join([]string{`&ConfigRequest{`,
-		`StableId:` + strings.Replace(fmt.Sprintf("%v", this.StableId), "ULID", "ULID", 1) + `,`,
-		`InstanceId:` + strings.Replace(fmt.Sprintf("%v", this.InstanceId), "ULID", "ULID", 1) + `,`,
-		`Locations:` + repeatedStringForLocations + `,`,
-		`}`,
-	}, "")
-	return s
+	return m, nil
 }
-func (this *ConfigResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func _ControlServices_SyncHub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HubSync)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ConfigResponse{`,
-		`TlsKey:` + fmt.Sprintf("%v", this.TlsKey) + `,`,
-		`TlsCert:` + fmt.Sprintf("%v", this.TlsCert) + `,`,
-		`TokenPub:` + fmt.Sprintf("%v", this.TokenPub) + `,`,
-		`S3AccessKey:` + fmt.Sprintf("%v", this.S3AccessKey) + `,`,
-		`S3SecretKey:` + fmt.Sprintf("%v", this.S3SecretKey) + `,`,
-		`S3Bucket:` + fmt.Sprintf("%v", this.S3Bucket) + `,`,
-		`ImageTag:` + fmt.Sprintf("%v", this.ImageTag) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *CentralActivity) String() string {
-	if this == nil {
-		return "nil"
+	if interceptor == nil {
+		return srv.(ControlServicesServer).SyncHub(ctx, in)
 	}
-	repeatedStringForAccountServices := "[]*AccountServices{"
-	for _, f := range this.AccountServices {
-		repeatedStringForAccountServices += strings.Replace(f.String(), "AccountServices", "AccountServices", 1) + ","
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlServices/SyncHub",
 	}
-	repeatedStringForAccountServices += "}"
-	s := strings.Join([]string{`&CentralActivity{`,
-		`AccountServices:` + repeatedStringForAccountServices + `,`,
-		`RequestStats:` + fmt.Sprintf("%v", this.RequestStats) + `,`,
-		`NewLabelLinks:` + strings.Replace(this.NewLabelLinks.String(), "LabelLinks", "LabelLinks", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServicesServer).SyncHub(ctx, req.(*HubSync))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (this *HubActivity) String() string {
-	if this == nil {
-		return "nil"
+
+func _ControlServices_HubDisconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HubDisconnectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	repeatedStringForFlow := "[]*FlowRecord{"
-	for _, f := range this.Flow {
-		repeatedStringForFlow += strings.Replace(fmt.Sprintf("%v", f), "FlowRecord", "FlowRecord", 1) + ","
+	if interceptor == nil {
+		return srv.(ControlServicesServer).HubDisconnect(ctx, in)
 	}
-	repeatedStringForFlow += "}"
-	s := strings.Join([]string{`&HubActivity{`,
-		`HubReg:` + strings.Replace(fmt.Sprintf("%v", this.HubReg), "HubActivity_HubRegistration", "HubActivity_HubRegistration", 1) + `,`,
-		`SentAt:` + strings.Replace(fmt.Sprintf("%v", this.SentAt), "Timestamp", "Timestamp", 1) + `,`,
-		`Stats:` + strings.Replace(fmt.Sprintf("%v", this.Stats), "HubActivity_HubStats", "HubActivity_HubStats", 1) + `,`,
-		`Flow:` + repeatedStringForFlow + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *HubActivity_HubRegistration) String() string {
-	if this == nil {
-		return "nil"
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlServices/HubDisconnect",
 	}
-	repeatedStringForLocations := "[]*NetworkLocation{"
-	for _, f := range this.Locations {
-		repeatedStringForLocations += strings.Replace(fmt.Sprintf("%v", f), "NetworkLocation", "NetworkLocation", 1) + ","
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServicesServer).HubDisconnect(ctx, req.(*HubDisconnectRequest))
 	}
-	repeatedStringForLocations += "}"
-	s := strings.Join([]string{`&HubActivity_HubRegistration{`,
-		`Hub:` + strings.Replace(fmt.Sprintf("%v", this.Hub), "ULID", "ULID", 1) + `,`,
-		`StableHub:` + strings.Replace(fmt.Sprintf("%v", this.StableHub), "ULID", "ULID", 1) + `,`,
-		`Locations:` + repeatedStringForLocations + `,`,
-		`}`,
-	}, "")
-	return s
+	return interceptor(ctx, in, info, handler)
 }
-func (this *HubActivity_HubStats) String() string {
-	if this == nil {
-		return "nil"
+
+func _ControlServices_AllHubs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AllHubsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&HubActivity_HubStats{`,
-		`AgentConnections:` + fmt.Sprintf("%v", this.AgentConnections) + `,`,
-		`AccountsSeen:` + fmt.Sprintf("%v", this.AccountsSeen) + `,`,
-		`AccountsCached:` + fmt.Sprintf("%v", this.AccountsCached) + `,`,
-		`CachedBytes:` + fmt.Sprintf("%v", this.CachedBytes) + `,`,
-		`LastSuccessfulUpdate:` + strings.Replace(fmt.Sprintf("%v", this.LastSuccessfulUpdate), "Timestamp", "Timestamp", 1) + `,`,
-		`LastAttemptedUpdate:` + strings.Replace(fmt.Sprintf("%v", this.LastAttemptedUpdate), "Timestamp", "Timestamp", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *HubInfo) String() string {
-	if this == nil {
-		return "nil"
+	if interceptor == nil {
+		return srv.(ControlServicesServer).AllHubs(ctx, in)
 	}
-	repeatedStringForLocations := "[]*NetworkLocation{"
-	for _, f := range this.Locations {
-		repeatedStringForLocations += strings.Replace(fmt.Sprintf("%v", f), "NetworkLocation", "NetworkLocation", 1) + ","
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlServices/AllHubs",
 	}
-	repeatedStringForLocations += "}"
-	s := strings.Join([]string{`&HubInfo{`,
-		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
-		`Locations:` + repeatedStringForLocations + `,`,
-		`}`,
-	}, "")
-	return s
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServicesServer).AllHubs(ctx, req.(*AllHubsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (this *ListOfHubs) String() string {
-	if this == nil {
-		return "nil"
+
+func _ControlServices_RequestServiceToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	repeatedStringForHubs := "[]*HubInfo{"
-	for _, f := range this.Hubs {
-		repeatedStringForHubs += strings.Replace(f.String(), "HubInfo", "HubInfo", 1) + ","
+	if interceptor == nil {
+		return srv.(ControlServicesServer).RequestServiceToken(ctx, in)
 	}
-	repeatedStringForHubs += "}"
-	s := strings.Join([]string{`&ListOfHubs{`,
-		`Hubs:` + repeatedStringForHubs + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *HubSync) String() string {
-	if this == nil {
-		return "nil"
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlServices/RequestServiceToken",
 	}
-	repeatedStringForServices := "[]*ServiceRequest{"
-	for _, f := range this.Services {
-		repeatedStringForServices += strings.Replace(f.String(), "ServiceRequest", "ServiceRequest", 1) + ","
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServicesServer).RequestServiceToken(ctx, req.(*ServiceTokenRequest))
 	}
-	repeatedStringForServices += "}"
-	s := strings.Join([]string{`&HubSync{`,
-		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
-		`StableId:` + strings.Replace(fmt.Sprintf("%v", this.StableId), "ULID", "ULID", 1) + `,`,
-		`Services:` + repeatedStringForServices + `,`,
-		`}`,
-	}, "")
-	return s
+	return interceptor(ctx, in, info, handler)
 }
-func (this *HubSyncResponse) String() string {
-	if this == nil {
-		return "nil"
+
+var _ControlServices_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.ControlServices",
+	HandlerType: (*ControlServicesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddService",
+			Handler:    _ControlServices_AddService_Handler,
+		},
+		{
+			MethodName: "RemoveService",
+			Handler:    _ControlServices_RemoveService_Handler,
+		},
+		{
+			MethodName: "ListServices",
+			Handler:    _ControlServices_ListServices_Handler,
+		},
+		{
+			MethodName: "FetchConfig",
+			Handler:    _ControlServices_FetchConfig_Handler,
+		},
+		{
+			MethodName: "SyncHub",
+			Handler:    _ControlServices_SyncHub_Handler,
+		},
+		{
+			MethodName: "HubDisconnect",
+			Handler:    _ControlServices_HubDisconnect_Handler,
+		},
+		{
+			MethodName: "AllHubs",
+			Handler:    _ControlServices_AllHubs_Handler,
+		},
+		{
+			MethodName: "RequestServiceToken",
+			Handler:    _ControlServices_RequestServiceToken_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamServices",
+			Handler:       _ControlServices_StreamServices_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamActivity",
+			Handler:       _ControlServices_StreamActivity_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "control.proto",
+}
+
+// ControlManagementClient is the client API for ControlManagement service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ControlManagementClient interface {
+	Register(ctx context.Context, in *ControlRegister, opts ...grpc.CallOption) (*ControlToken, error)
+	AddAccount(ctx context.Context, in *AddAccountRequest, opts ...grpc.CallOption) (*Noop, error)
+	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*CreateAccountResponse, error)
+	AddLabelLink(ctx context.Context, in *AddLabelLinkRequest, opts ...grpc.CallOption) (*Noop, error)
+	RemoveLabelLink(ctx context.Context, in *RemoveLabelLinkRequest, opts ...grpc.CallOption) (*Noop, error)
+	CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error)
+	CreateTokens(ctx context.Context, in *CreateTokensRequest, opts ...grpc.CallOption) (*CreateTokensResponse, error)
+	IssueHubToken(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*CreateTokenResponse, error)
+	GetTokenPublicKey(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*TokenInfo, error)
+	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	ListManagementClients(ctx context.Context, in *ListManagementClientsRequest, opts ...grpc.CallOption) (*ListManagementClientsResponse, error)
+	UnregisterManagementClient(ctx context.Context, in *UnregisterRequest, opts ...grpc.CallOption) (*Noop, error)
+	WhoAmI(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*WhoAmIResponse, error)
+	TokenStatus(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*TokenStatusResponse, error)
+	RenewToken(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*CreateTokenResponse, error)
+	ExportAccountConfig(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*AccountConfig, error)
+	ImportAccountConfig(ctx context.Context, in *ImportRequest, opts ...grpc.CallOption) (*Noop, error)
+	ConnectedHubs(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*ConnectedHubsResponse, error)
+	FlowCounters(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*FlowCountersResponse, error)
+	ResetFlowCounters(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*FlowCountersResponse, error)
+	SetAccountSuspended(ctx context.Context, in *SetSuspendedRequest, opts ...grpc.CallOption) (*Noop, error)
+	ListIssuedTokens(ctx context.Context, in *ListTokensRequest, opts ...grpc.CallOption) (*ListTokensResponse, error)
+	ResolveDebug(ctx context.Context, in *ResolveDebugRequest, opts ...grpc.CallOption) (*ResolveDebugResponse, error)
+	SetLabelLinkSplits(ctx context.Context, in *SetLabelLinkSplitsRequest, opts ...grpc.CallOption) (*Noop, error)
+	InvalidateAccountRouting(ctx context.Context, in *InvalidateRequest, opts ...grpc.CallOption) (*Noop, error)
+}
+
+type controlManagementClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewControlManagementClient(cc *grpc.ClientConn) ControlManagementClient {
+	return &controlManagementClient{cc}
+}
+
+func (c *controlManagementClient) Register(ctx context.Context, in *ControlRegister, opts ...grpc.CallOption) (*ControlToken, error) {
+	out := new(ControlToken)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/Register", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&HubSyncResponse{`,
-		`ServiceCount:` + fmt.Sprintf("%v", this.ServiceCount) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *HubRegisterRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *controlManagementClient) AddAccount(ctx context.Context, in *AddAccountRequest, opts ...grpc.CallOption) (*Noop, error) {
+	out := new(Noop)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/AddAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForLocations := "[]*NetworkLocation{"
-	for _, f := range this.Locations {
-		repeatedStringForLocations += strings.Replace(fmt.Sprintf("%v", f), "NetworkLocation", "NetworkLocation", 1) + ","
+	return out, nil
+}
+
+func (c *controlManagementClient) CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*CreateAccountResponse, error) {
+	out := new(CreateAccountResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/CreateAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForLocations += "}"
-	s := strings.Join([]string{`&HubRegisterRequest{`,
-		`StableId:` + strings.Replace(fmt.Sprintf("%v", this.StableId), "ULID", "ULID", 1) + `,`,
-		`InstanceId:` + strings.Replace(fmt.Sprintf("%v", this.InstanceId), "ULID", "ULID", 1) + `,`,
-		`Locations:` + repeatedStringForLocations + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *HubRegisterResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *controlManagementClient) AddLabelLink(ctx context.Context, in *AddLabelLinkRequest, opts ...grpc.CallOption) (*Noop, error) {
+	out := new(Noop)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/AddLabelLink", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&HubRegisterResponse{`,
-		`ControlTime:` + strings.Replace(fmt.Sprintf("%v", this.ControlTime), "Timestamp", "Timestamp", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *HubDisconnectRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *controlManagementClient) RemoveLabelLink(ctx context.Context, in *RemoveLabelLinkRequest, opts ...grpc.CallOption) (*Noop, error) {
+	out := new(Noop)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/RemoveLabelLink", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&HubDisconnectRequest{`,
-		`StableId:` + strings.Replace(fmt.Sprintf("%v", this.StableId), "ULID", "ULID", 1) + `,`,
-		`InstanceId:` + strings.Replace(fmt.Sprintf("%v", this.InstanceId), "ULID", "ULID", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *ServiceTokenRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *controlManagementClient) CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error) {
+	out := new(CreateTokenResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/CreateToken", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ServiceTokenRequest{`,
-		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *ServiceTokenResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *controlManagementClient) CreateTokens(ctx context.Context, in *CreateTokensRequest, opts ...grpc.CallOption) (*CreateTokensResponse, error) {
+	out := new(CreateTokensResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/CreateTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ServiceTokenResponse{`,
-		`Token:` + fmt.Sprintf("%v", this.Token) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *ListServicesRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *controlManagementClient) IssueHubToken(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*CreateTokenResponse, error) {
+	out := new(CreateTokenResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/IssueHubToken", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ListServicesRequest{`,
-		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *ListServicesResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *controlManagementClient) GetTokenPublicKey(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*TokenInfo, error) {
+	out := new(TokenInfo)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/GetTokenPublicKey", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForServices := "[]*Service{"
-	for _, f := range this.Services {
-		repeatedStringForServices += strings.Replace(f.String(), "Service", "Service", 1) + ","
+	return out, nil
+}
+
+func (c *controlManagementClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
+	out := new(ListAccountsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/ListAccounts", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForServices += "}"
-	s := strings.Join([]string{`&ListServicesResponse{`,
-		`Services:` + repeatedStringForServices + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *Service) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *controlManagementClient) ListManagementClients(ctx context.Context, in *ListManagementClientsRequest, opts ...grpc.CallOption) (*ListManagementClientsResponse, error) {
+	out := new(ListManagementClientsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/ListManagementClients", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForMetadata := "[]*KVPair{"
-	for _, f := range this.Metadata {
-		repeatedStringForMetadata += strings.Replace(fmt.Sprintf("%v", f), "KVPair", "KVPair", 1) + ","
+	return out, nil
+}
+
+func (c *controlManagementClient) UnregisterManagementClient(ctx context.Context, in *UnregisterRequest, opts ...grpc.CallOption) (*Noop, error) {
+	out := new(Noop)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/UnregisterManagementClient", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForMetadata += "}"
-	s := strings.Join([]string{`&Service{`,
-		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
-		`Hub:` + strings.Replace(fmt.Sprintf("%v", this.Hub), "ULID", "ULID", 1) + `,`,
-		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
-		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
-		`Metadata:` + repeatedStringForMetadata + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *AddAccountRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *controlManagementClient) WhoAmI(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*WhoAmIResponse, error) {
+	out := new(WhoAmIResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/WhoAmI", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&AddAccountRequest{`,
-		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
-		`Limits:` + strings.Replace(fmt.Sprintf("%v", this.Limits), "Account_Limits", "Account_Limits", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *AddLabelLinkRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *controlManagementClient) TokenStatus(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*TokenStatusResponse, error) {
+	out := new(TokenStatusResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/TokenStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&AddLabelLinkRequest{`,
-		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
-		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
-		`Target:` + strings.Replace(fmt.Sprintf("%v", this.Target), "LabelSet", "LabelSet", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *Noop) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *controlManagementClient) RenewToken(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*CreateTokenResponse, error) {
+	out := new(CreateTokenResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/RenewToken", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&Noop{`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *RemoveLabelLinkRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *controlManagementClient) ExportAccountConfig(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*AccountConfig, error) {
+	out := new(AccountConfig)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/ExportAccountConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&RemoveLabelLinkRequest{`,
-		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
-		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *CreateTokenRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *controlManagementClient) ImportAccountConfig(ctx context.Context, in *ImportRequest, opts ...grpc.CallOption) (*Noop, error) {
+	out := new(Noop)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/ImportAccountConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForCapabilities := "[]TokenCapability{"
-	for _, f := range this.Capabilities {
-		repeatedStringForCapabilities += fmt.Sprintf("%v", f) + ","
+	return out, nil
+}
+
+func (c *controlManagementClient) ConnectedHubs(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*ConnectedHubsResponse, error) {
+	out := new(ConnectedHubsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/ConnectedHubs", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForCapabilities += "}"
-	s := strings.Join([]string{`&CreateTokenRequest{`,
-		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
-		`Capabilities:` + repeatedStringForCapabilities + `,`,
-		`ValidDuration:` + strings.Replace(fmt.Sprintf("%v", this.ValidDuration), "Timestamp", "Timestamp", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *CreateTokenResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *controlManagementClient) FlowCounters(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*FlowCountersResponse, error) {
+	out := new(FlowCountersResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/FlowCounters", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&CreateTokenResponse{`,
-		`Token:` + fmt.Sprintf("%v", this.Token) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *ControlRegister) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *controlManagementClient) ResetFlowCounters(ctx context.Context, in *Noop, opts ...grpc.CallOption) (*FlowCountersResponse, error) {
+	out := new(FlowCountersResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/ResetFlowCounters", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ControlRegister{`,
-		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *ControlToken) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *controlManagementClient) SetAccountSuspended(ctx context.Context, in *SetSuspendedRequest, opts ...grpc.CallOption) (*Noop, error) {
+	out := new(Noop)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/SetAccountSuspended", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ControlToken{`,
-		`Token:` + fmt.Sprintf("%v", this.Token) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *TokenInfo) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *controlManagementClient) ListIssuedTokens(ctx context.Context, in *ListTokensRequest, opts ...grpc.CallOption) (*ListTokensResponse, error) {
+	out := new(ListTokensResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/ListIssuedTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&TokenInfo{`,
-		`PublicKey:` + fmt.Sprintf("%v", this.PublicKey) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *ListAccountsRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *controlManagementClient) ResolveDebug(ctx context.Context, in *ResolveDebugRequest, opts ...grpc.CallOption) (*ResolveDebugResponse, error) {
+	out := new(ResolveDebugResponse)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/ResolveDebug", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ListAccountsRequest{`,
-		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
-		`Marker:` + fmt.Sprintf("%v", this.Marker) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *ListAccountsResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *controlManagementClient) SetLabelLinkSplits(ctx context.Context, in *SetLabelLinkSplitsRequest, opts ...grpc.CallOption) (*Noop, error) {
+	out := new(Noop)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/SetLabelLinkSplits", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForAccounts := "[]*Account{"
-	for _, f := range this.Accounts {
-		repeatedStringForAccounts += strings.Replace(fmt.Sprintf("%v", f), "Account", "Account", 1) + ","
+	return out, nil
+}
+
+func (c *controlManagementClient) InvalidateAccountRouting(ctx context.Context, in *InvalidateRequest, opts ...grpc.CallOption) (*Noop, error) {
+	out := new(Noop)
+	err := c.cc.Invoke(ctx, "/pb.ControlManagement/InvalidateAccountRouting", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ControlManagementServer is the server API for ControlManagement service.
+type ControlManagementServer interface {
+	Register(context.Context, *ControlRegister) (*ControlToken, error)
+	AddAccount(context.Context, *AddAccountRequest) (*Noop, error)
+	CreateAccount(context.Context, *CreateAccountRequest) (*CreateAccountResponse, error)
+	AddLabelLink(context.Context, *AddLabelLinkRequest) (*Noop, error)
+	RemoveLabelLink(context.Context, *RemoveLabelLinkRequest) (*Noop, error)
+	CreateToken(context.Context, *CreateTokenRequest) (*CreateTokenResponse, error)
+	CreateTokens(context.Context, *CreateTokensRequest) (*CreateTokensResponse, error)
+	IssueHubToken(context.Context, *Noop) (*CreateTokenResponse, error)
+	GetTokenPublicKey(context.Context, *Noop) (*TokenInfo, error)
+	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
+	ListManagementClients(context.Context, *ListManagementClientsRequest) (*ListManagementClientsResponse, error)
+	UnregisterManagementClient(context.Context, *UnregisterRequest) (*Noop, error)
+	WhoAmI(context.Context, *Noop) (*WhoAmIResponse, error)
+	TokenStatus(context.Context, *Noop) (*TokenStatusResponse, error)
+	RenewToken(context.Context, *Noop) (*CreateTokenResponse, error)
+	ExportAccountConfig(context.Context, *ExportRequest) (*AccountConfig, error)
+	ImportAccountConfig(context.Context, *ImportRequest) (*Noop, error)
+	ConnectedHubs(context.Context, *Noop) (*ConnectedHubsResponse, error)
+	FlowCounters(context.Context, *Noop) (*FlowCountersResponse, error)
+	ResetFlowCounters(context.Context, *Noop) (*FlowCountersResponse, error)
+	SetAccountSuspended(context.Context, *SetSuspendedRequest) (*Noop, error)
+	ListIssuedTokens(context.Context, *ListTokensRequest) (*ListTokensResponse, error)
+	ResolveDebug(context.Context, *ResolveDebugRequest) (*ResolveDebugResponse, error)
+	SetLabelLinkSplits(context.Context, *SetLabelLinkSplitsRequest) (*Noop, error)
+	InvalidateAccountRouting(context.Context, *InvalidateRequest) (*Noop, error)
+}
+
+// UnimplementedControlManagementServer can be embedded to have forward compatible implementations.
+type UnimplementedControlManagementServer struct {
+}
+
+func (*UnimplementedControlManagementServer) Register(ctx context.Context, req *ControlRegister) (*ControlToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (*UnimplementedControlManagementServer) AddAccount(ctx context.Context, req *AddAccountRequest) (*Noop, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddAccount not implemented")
+}
+func (*UnimplementedControlManagementServer) CreateAccount(ctx context.Context, req *CreateAccountRequest) (*CreateAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccount not implemented")
+}
+func (*UnimplementedControlManagementServer) AddLabelLink(ctx context.Context, req *AddLabelLinkRequest) (*Noop, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddLabelLink not implemented")
+}
+func (*UnimplementedControlManagementServer) RemoveLabelLink(ctx context.Context, req *RemoveLabelLinkRequest) (*Noop, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveLabelLink not implemented")
+}
+func (*UnimplementedControlManagementServer) CreateToken(ctx context.Context, req *CreateTokenRequest) (*CreateTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateToken not implemented")
+}
+func (*UnimplementedControlManagementServer) CreateTokens(ctx context.Context, req *CreateTokensRequest) (*CreateTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTokens not implemented")
+}
+func (*UnimplementedControlManagementServer) IssueHubToken(ctx context.Context, req *Noop) (*CreateTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueHubToken not implemented")
+}
+func (*UnimplementedControlManagementServer) GetTokenPublicKey(ctx context.Context, req *Noop) (*TokenInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTokenPublicKey not implemented")
+}
+func (*UnimplementedControlManagementServer) ListAccounts(ctx context.Context, req *ListAccountsRequest) (*ListAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccounts not implemented")
+}
+func (*UnimplementedControlManagementServer) ListManagementClients(ctx context.Context, req *ListManagementClientsRequest) (*ListManagementClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListManagementClients not implemented")
+}
+func (*UnimplementedControlManagementServer) UnregisterManagementClient(ctx context.Context, req *UnregisterRequest) (*Noop, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnregisterManagementClient not implemented")
+}
+func (*UnimplementedControlManagementServer) WhoAmI(ctx context.Context, req *Noop) (*WhoAmIResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WhoAmI not implemented")
+}
+func (*UnimplementedControlManagementServer) TokenStatus(ctx context.Context, req *Noop) (*TokenStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TokenStatus not implemented")
+}
+func (*UnimplementedControlManagementServer) RenewToken(ctx context.Context, req *Noop) (*CreateTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewToken not implemented")
+}
+func (*UnimplementedControlManagementServer) ExportAccountConfig(ctx context.Context, req *ExportRequest) (*AccountConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportAccountConfig not implemented")
+}
+func (*UnimplementedControlManagementServer) ImportAccountConfig(ctx context.Context, req *ImportRequest) (*Noop, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportAccountConfig not implemented")
+}
+func (*UnimplementedControlManagementServer) ConnectedHubs(ctx context.Context, req *Noop) (*ConnectedHubsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConnectedHubs not implemented")
+}
+func (*UnimplementedControlManagementServer) FlowCounters(ctx context.Context, req *Noop) (*FlowCountersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FlowCounters not implemented")
+}
+func (*UnimplementedControlManagementServer) ResetFlowCounters(ctx context.Context, req *Noop) (*FlowCountersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetFlowCounters not implemented")
+}
+func (*UnimplementedControlManagementServer) SetAccountSuspended(ctx context.Context, req *SetSuspendedRequest) (*Noop, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAccountSuspended not implemented")
+}
+func (*UnimplementedControlManagementServer) ListIssuedTokens(ctx context.Context, req *ListTokensRequest) (*ListTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIssuedTokens not implemented")
+}
+func (*UnimplementedControlManagementServer) ResolveDebug(ctx context.Context, req *ResolveDebugRequest) (*ResolveDebugResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveDebug not implemented")
+}
+func (*UnimplementedControlManagementServer) SetLabelLinkSplits(ctx context.Context, req *SetLabelLinkSplitsRequest) (*Noop, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLabelLinkSplits not implemented")
+}
+func (*UnimplementedControlManagementServer) InvalidateAccountRouting(ctx context.Context, req *InvalidateRequest) (*Noop, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InvalidateAccountRouting not implemented")
+}
+
+func RegisterControlManagementServer(s *grpc.Server, srv ControlManagementServer) {
+	s.RegisterService(&_ControlManagement_serviceDesc, srv)
+}
+
+func _ControlManagement_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ControlRegister)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/Register",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).Register(ctx, req.(*ControlRegister))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_AddAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).AddAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/AddAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).AddAccount(ctx, req.(*AddAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_CreateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).CreateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/CreateAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).CreateAccount(ctx, req.(*CreateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_AddLabelLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddLabelLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).AddLabelLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/AddLabelLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).AddLabelLink(ctx, req.(*AddLabelLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_RemoveLabelLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveLabelLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).RemoveLabelLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/RemoveLabelLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).RemoveLabelLink(ctx, req.(*RemoveLabelLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_CreateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).CreateToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/CreateToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).CreateToken(ctx, req.(*CreateTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_CreateTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).CreateTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/CreateTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).CreateTokens(ctx, req.(*CreateTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_IssueHubToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Noop)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).IssueHubToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/IssueHubToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).IssueHubToken(ctx, req.(*Noop))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_GetTokenPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Noop)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).GetTokenPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/GetTokenPublicKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).GetTokenPublicKey(ctx, req.(*Noop))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).ListAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/ListAccounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).ListAccounts(ctx, req.(*ListAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_ListManagementClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListManagementClientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).ListManagementClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/ListManagementClients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).ListManagementClients(ctx, req.(*ListManagementClientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_UnregisterManagementClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnregisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).UnregisterManagementClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/UnregisterManagementClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).UnregisterManagementClient(ctx, req.(*UnregisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_WhoAmI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Noop)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).WhoAmI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/WhoAmI",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).WhoAmI(ctx, req.(*Noop))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_TokenStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Noop)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).TokenStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/TokenStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).TokenStatus(ctx, req.(*Noop))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_RenewToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Noop)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).RenewToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/RenewToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).RenewToken(ctx, req.(*Noop))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_ExportAccountConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).ExportAccountConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/ExportAccountConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).ExportAccountConfig(ctx, req.(*ExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_ImportAccountConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).ImportAccountConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/ImportAccountConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).ImportAccountConfig(ctx, req.(*ImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_ConnectedHubs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Noop)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).ConnectedHubs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/ConnectedHubs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).ConnectedHubs(ctx, req.(*Noop))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_FlowCounters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Noop)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).FlowCounters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/FlowCounters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).FlowCounters(ctx, req.(*Noop))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_ResetFlowCounters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Noop)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).ResetFlowCounters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/ResetFlowCounters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).ResetFlowCounters(ctx, req.(*Noop))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_SetAccountSuspended_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSuspendedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).SetAccountSuspended(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/SetAccountSuspended",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).SetAccountSuspended(ctx, req.(*SetSuspendedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_ListIssuedTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).ListIssuedTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/ListIssuedTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).ListIssuedTokens(ctx, req.(*ListTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_ResolveDebug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveDebugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).ResolveDebug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/ResolveDebug",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).ResolveDebug(ctx, req.(*ResolveDebugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_SetLabelLinkSplits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLabelLinkSplitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).SetLabelLinkSplits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/SetLabelLinkSplits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).SetLabelLinkSplits(ctx, req.(*SetLabelLinkSplitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlManagement_InvalidateAccountRouting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvalidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlManagementServer).InvalidateAccountRouting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ControlManagement/InvalidateAccountRouting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlManagementServer).InvalidateAccountRouting(ctx, req.(*InvalidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ControlManagement_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.ControlManagement",
+	HandlerType: (*ControlManagementServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _ControlManagement_Register_Handler,
+		},
+		{
+			MethodName: "AddAccount",
+			Handler:    _ControlManagement_AddAccount_Handler,
+		},
+		{
+			MethodName: "CreateAccount",
+			Handler:    _ControlManagement_CreateAccount_Handler,
+		},
+		{
+			MethodName: "AddLabelLink",
+			Handler:    _ControlManagement_AddLabelLink_Handler,
+		},
+		{
+			MethodName: "RemoveLabelLink",
+			Handler:    _ControlManagement_RemoveLabelLink_Handler,
+		},
+		{
+			MethodName: "CreateToken",
+			Handler:    _ControlManagement_CreateToken_Handler,
+		},
+		{
+			MethodName: "CreateTokens",
+			Handler:    _ControlManagement_CreateTokens_Handler,
+		},
+		{
+			MethodName: "IssueHubToken",
+			Handler:    _ControlManagement_IssueHubToken_Handler,
+		},
+		{
+			MethodName: "GetTokenPublicKey",
+			Handler:    _ControlManagement_GetTokenPublicKey_Handler,
+		},
+		{
+			MethodName: "ListAccounts",
+			Handler:    _ControlManagement_ListAccounts_Handler,
+		},
+		{
+			MethodName: "ListManagementClients",
+			Handler:    _ControlManagement_ListManagementClients_Handler,
+		},
+		{
+			MethodName: "UnregisterManagementClient",
+			Handler:    _ControlManagement_UnregisterManagementClient_Handler,
+		},
+		{
+			MethodName: "WhoAmI",
+			Handler:    _ControlManagement_WhoAmI_Handler,
+		},
+		{
+			MethodName: "TokenStatus",
+			Handler:    _ControlManagement_TokenStatus_Handler,
+		},
+		{
+			MethodName: "RenewToken",
+			Handler:    _ControlManagement_RenewToken_Handler,
+		},
+		{
+			MethodName: "ExportAccountConfig",
+			Handler:    _ControlManagement_ExportAccountConfig_Handler,
+		},
+		{
+			MethodName: "ImportAccountConfig",
+			Handler:    _ControlManagement_ImportAccountConfig_Handler,
+		},
+		{
+			MethodName: "ConnectedHubs",
+			Handler:    _ControlManagement_ConnectedHubs_Handler,
+		},
+		{
+			MethodName: "FlowCounters",
+			Handler:    _ControlManagement_FlowCounters_Handler,
+		},
+		{
+			MethodName: "ResetFlowCounters",
+			Handler:    _ControlManagement_ResetFlowCounters_Handler,
+		},
+		{
+			MethodName: "SetAccountSuspended",
+			Handler:    _ControlManagement_SetAccountSuspended_Handler,
+		},
+		{
+			MethodName: "ListIssuedTokens",
+			Handler:    _ControlManagement_ListIssuedTokens_Handler,
+		},
+		{
+			MethodName: "ResolveDebug",
+			Handler:    _ControlManagement_ResolveDebug_Handler,
+		},
+		{
+			MethodName: "SetLabelLinkSplits",
+			Handler:    _ControlManagement_SetLabelLinkSplits_Handler,
+		},
+		{
+			MethodName: "InvalidateAccountRouting",
+			Handler:    _ControlManagement_InvalidateAccountRouting_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "control.proto",
+}
+
+func (m *ServiceRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ServiceRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ServiceRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Metadata) > 0 {
+		for iNdEx := len(m.Metadata) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Metadata[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.Labels != nil {
+		{
+			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Id != nil {
+		{
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Hub != nil {
+		{
+			size, err := m.Hub.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ServiceResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ServiceResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ServiceResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelLink) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelLink) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelLink) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Splits) > 0 {
+		for iNdEx := len(m.Splits) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Splits[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Limits != nil {
+		{
+			size, err := m.Limits.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Target != nil {
+		{
+			size, err := m.Target.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Labels != nil {
+		{
+			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelLinks) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelLinks) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelLinks) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.LabelLinks) > 0 {
+		for iNdEx := len(m.LabelLinks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.LabelLinks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ServiceRoute) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ServiceRoute) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ServiceRoute) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Weight != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.Weight))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Unhealthy {
+		i--
+		if m.Unhealthy {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Labels != nil {
+		{
+			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Id != nil {
+		{
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Hub != nil {
+		{
+			size, err := m.Hub.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AccountServices) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AccountServices) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AccountServices) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Suspended {
+		i--
+		if m.Suspended {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Services) > 0 {
+		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Services[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ActivityEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActivityEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ActivityEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.RouteRemoved != nil {
+		{
+			size, err := m.RouteRemoved.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.RouteAdded != nil {
+		{
+			size, err := m.RouteAdded.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConfigRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfigRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConfigRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Locations) > 0 {
+		for iNdEx := len(m.Locations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Locations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.InstanceId != nil {
+		{
+			size, err := m.InstanceId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.StableId != nil {
+		{
+			size, err := m.StableId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConfigResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfigResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConfigResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ActivityCompressor) > 0 {
+		i -= len(m.ActivityCompressor)
+		copy(dAtA[i:], m.ActivityCompressor)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.ActivityCompressor)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.ImageTag) > 0 {
+		i -= len(m.ImageTag)
+		copy(dAtA[i:], m.ImageTag)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.ImageTag)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.S3Bucket) > 0 {
+		i -= len(m.S3Bucket)
+		copy(dAtA[i:], m.S3Bucket)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.S3Bucket)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.S3SecretKey) > 0 {
+		i -= len(m.S3SecretKey)
+		copy(dAtA[i:], m.S3SecretKey)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.S3SecretKey)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.S3AccessKey) > 0 {
+		i -= len(m.S3AccessKey)
+		copy(dAtA[i:], m.S3AccessKey)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.S3AccessKey)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.TokenPub) > 0 {
+		i -= len(m.TokenPub)
+		copy(dAtA[i:], m.TokenPub)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.TokenPub)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.TlsCert) > 0 {
+		i -= len(m.TlsCert)
+		copy(dAtA[i:], m.TlsCert)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.TlsCert)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.TlsKey) > 0 {
+		i -= len(m.TlsKey)
+		copy(dAtA[i:], m.TlsKey)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.TlsKey)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CentralActivity) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CentralActivity) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CentralActivity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.S3CredentialsRotated {
+		i--
+		if m.S3CredentialsRotated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.S3Credentials != nil {
+		{
+			size, err := m.S3Credentials.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Quotas) > 0 {
+		for iNdEx := len(m.Quotas) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Quotas[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.RemovedLabelLinks != nil {
+		{
+			size, err := m.RemovedLabelLinks.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.ResolvedRoutes) > 0 {
+		for iNdEx := len(m.ResolvedRoutes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ResolvedRoutes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.NewLabelLinks != nil {
+		{
+			size, err := m.NewLabelLinks.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.RequestStats {
+		i--
+		if m.RequestStats {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.AccountServices) > 0 {
+		for iNdEx := len(m.AccountServices) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.AccountServices[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *S3Credentials) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *S3Credentials) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *S3Credentials) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Bucket) > 0 {
+		i -= len(m.Bucket)
+		copy(dAtA[i:], m.Bucket)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Bucket)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.SecretKey) > 0 {
+		i -= len(m.SecretKey)
+		copy(dAtA[i:], m.SecretKey)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.SecretKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.AccessKey) > 0 {
+		i -= len(m.AccessKey)
+		copy(dAtA[i:], m.AccessKey)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.AccessKey)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QuotaStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QuotaStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QuotaStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Messages != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.Messages))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Bytes != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.Bytes))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Exceeded {
+		i--
+		if m.Exceeded {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Hub != nil {
+		{
+			size, err := m.Hub.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HubActivity) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HubActivity) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HubActivity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.CachedAccounts) > 0 {
+		for iNdEx := len(m.CachedAccounts) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.CachedAccounts[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Flow) > 0 {
+		for iNdEx := len(m.Flow) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Flow[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Stats != nil {
+		{
+			size, err := m.Stats.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.SentAt != nil {
+		{
+			size, err := m.SentAt.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.HubReg != nil {
+		{
+			size, err := m.HubReg.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HubActivity_HubRegistration) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HubActivity_HubRegistration) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HubActivity_HubRegistration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Locations) > 0 {
+		for iNdEx := len(m.Locations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Locations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.StableHub != nil {
+		{
+			size, err := m.StableHub.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Hub != nil {
+		{
+			size, err := m.Hub.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HubActivity_HubStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HubActivity_HubStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HubActivity_HubStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LastAttemptedUpdate != nil {
+		{
+			size, err := m.LastAttemptedUpdate.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.LastSuccessfulUpdate != nil {
+		{
+			size, err := m.LastSuccessfulUpdate.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.CachedBytes != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.CachedBytes))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.AccountsCached != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.AccountsCached))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.AccountsSeen != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.AccountsSeen))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.AgentConnections != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.AgentConnections))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HubInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HubInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HubInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Locations) > 0 {
+		for iNdEx := len(m.Locations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Locations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Id != nil {
+		{
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListOfHubs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListOfHubs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListOfHubs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.NotModified {
+		i--
+		if m.NotModified {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Skipped != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.Skipped))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Hubs) > 0 {
+		for iNdEx := len(m.Hubs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Hubs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HubSync) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HubSync) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HubSync) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Services) > 0 {
+		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Services[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.StableId != nil {
+		{
+			size, err := m.StableId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id != nil {
+		{
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HubSyncResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HubSyncResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HubSyncResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ServiceCount != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.ServiceCount))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HubRegisterRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HubRegisterRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HubRegisterRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Locations) > 0 {
+		for iNdEx := len(m.Locations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Locations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.InstanceId != nil {
+		{
+			size, err := m.InstanceId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.StableId != nil {
+		{
+			size, err := m.StableId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HubRegisterResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HubRegisterResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HubRegisterResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ControlTime != nil {
+		{
+			size, err := m.ControlTime.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HubDisconnectRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HubDisconnectRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HubDisconnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.InstanceId != nil {
+		{
+			size, err := m.InstanceId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.StableId != nil {
+		{
+			size, err := m.StableId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ServiceTokenRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ServiceTokenRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ServiceTokenRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.HubScope != nil {
+		{
+			size, err := m.HubScope.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ServiceTokenResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ServiceTokenResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ServiceTokenResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Token)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListServicesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListServicesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListServicesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListServicesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListServicesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListServicesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Services) > 0 {
+		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Services[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Service) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Service) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Service) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Metadata) > 0 {
+		for iNdEx := len(m.Metadata) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Metadata[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Labels != nil {
+		{
+			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Hub != nil {
+		{
+			size, err := m.Hub.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id != nil {
+		{
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StreamServicesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamServicesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamServicesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.BatchSize != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.BatchSize))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Labels != nil {
+		{
+			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AddAccountRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddAccountRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddAccountRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Limits != nil {
+		{
+			size, err := m.Limits.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CreateAccountRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateAccountRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreateAccountRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Limits != nil {
+		{
+			size, err := m.Limits.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CreateAccountResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateAccountResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreateAccountResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Limits != nil {
+		{
+			size, err := m.Limits.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AddLabelLinkRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddLabelLinkRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddLabelLinkRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Target != nil {
+		{
+			size, err := m.Target.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Labels != nil {
+		{
+			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Noop) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Noop) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Noop) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *RemoveLabelLinkRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RemoveLabelLinkRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RemoveLabelLinkRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Labels != nil {
+		{
+			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CreateTokenRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateTokenRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreateTokenRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ValidDuration != nil {
+		{
+			size, err := m.ValidDuration.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Capabilities) > 0 {
+		for iNdEx := len(m.Capabilities) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Capabilities[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CreateTokenResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateTokenResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreateTokenResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ValidUntil != nil {
+		{
+			size, err := m.ValidUntil.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Capabilities) > 0 {
+		for iNdEx := len(m.Capabilities) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Capabilities[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.TokenId != nil {
+		{
+			size, err := m.TokenId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Role != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.Role))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Token)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CreateTokensRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateTokensRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreateTokensRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.AllOrNothing {
+		i--
+		if m.AllOrNothing {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Tokens) > 0 {
+		for iNdEx := len(m.Tokens) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Tokens[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CreateTokenResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateTokenResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreateTokenResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Token)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CreateTokensResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateTokensResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreateTokensResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Results) > 0 {
+		for iNdEx := len(m.Results) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Results[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ControlRegister) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ControlRegister) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ControlRegister) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ControlToken) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ControlToken) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ControlToken) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Token)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TokenInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TokenInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TokenInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.PublicKey) > 0 {
+		i -= len(m.PublicKey)
+		copy(dAtA[i:], m.PublicKey)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.PublicKey)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListAccountsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListAccountsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListAccountsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Marker) > 0 {
+		i -= len(m.Marker)
+		copy(dAtA[i:], m.Marker)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Marker)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Limit != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListAccountsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListAccountsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListAccountsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.NextMarker) > 0 {
+		i -= len(m.NextMarker)
+		copy(dAtA[i:], m.NextMarker)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.NextMarker)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Accounts) > 0 {
+		for iNdEx := len(m.Accounts) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Accounts[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ManagementClient) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ManagementClient) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ManagementClient) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.CreatedAt != nil {
+		{
+			size, err := m.CreatedAt.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id != nil {
+		{
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListManagementClientsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListManagementClientsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListManagementClientsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Marker) > 0 {
+		i -= len(m.Marker)
+		copy(dAtA[i:], m.Marker)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Marker)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Limit != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListManagementClientsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListManagementClientsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListManagementClientsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.NextMarker) > 0 {
+		i -= len(m.NextMarker)
+		copy(dAtA[i:], m.NextMarker)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.NextMarker)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Clients) > 0 {
+		for iNdEx := len(m.Clients) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Clients[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListTokensRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListTokensRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListTokensRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Marker) > 0 {
+		i -= len(m.Marker)
+		copy(dAtA[i:], m.Marker)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Marker)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Limit != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *IssuedToken) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IssuedToken) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *IssuedToken) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.IssuedAt != nil {
+		{
+			size, err := m.IssuedAt.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.ValidUntil != nil {
+		{
+			size, err := m.ValidUntil.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Capabilities) > 0 {
+		for iNdEx := len(m.Capabilities) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Capabilities[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Role != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.Role))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.TokenId != nil {
+		{
+			size, err := m.TokenId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListTokensResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListTokensResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListTokensResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.NextMarker) > 0 {
+		i -= len(m.NextMarker)
+		copy(dAtA[i:], m.NextMarker)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.NextMarker)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Tokens) > 0 {
+		for iNdEx := len(m.Tokens) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Tokens[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WhoAmIResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WhoAmIResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WhoAmIResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ValidUntil != nil {
+		{
+			size, err := m.ValidUntil.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.AccessNamespace) > 0 {
+		i -= len(m.AccessNamespace)
+		copy(dAtA[i:], m.AccessNamespace)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.AccessNamespace)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Capabilities) > 0 {
+		for iNdEx := len(m.Capabilities) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Capabilities[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.TokenId != nil {
+		{
+			size, err := m.TokenId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Role != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.Role))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TokenStatusResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TokenStatusResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TokenStatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.RenewAfter != nil {
+		{
+			size, err := m.RenewAfter.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.WithinRenewalWindow {
+		i--
+		if m.WithinRenewalWindow {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Renewable {
+		i--
+		if m.Renewable {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.ValidUntil != nil {
+		{
+			size, err := m.ValidUntil.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.NotBefore != nil {
+		{
+			size, err := m.NotBefore.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.TokenId != nil {
+		{
+			size, err := m.TokenId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AccountConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AccountConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AccountConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Services) > 0 {
+		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Services[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.LabelLinks) > 0 {
+		for iNdEx := len(m.LabelLinks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.LabelLinks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Limits != nil {
+		{
+			size, err := m.Limits.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImportRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Config != nil {
+		{
+			size, err := m.Config.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnectedHub) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectedHub) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnectedHub) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxQueueDepth != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.MaxQueueDepth))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.QueueDepth != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.QueueDepth))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Dropped != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.Dropped))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Hub) > 0 {
+		i -= len(m.Hub)
+		copy(dAtA[i:], m.Hub)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Hub)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnectedHubsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectedHubsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnectedHubsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hubs) > 0 {
+		for iNdEx := len(m.Hubs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Hubs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HubFlowCounters) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HubFlowCounters) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HubFlowCounters) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Bytes != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.Bytes))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Messages != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.Messages))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Hub) > 0 {
+		i -= len(m.Hub)
+		copy(dAtA[i:], m.Hub)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Hub)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FlowCountersResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FlowCountersResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FlowCountersResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hubs) > 0 {
+		for iNdEx := len(m.Hubs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Hubs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetSuspendedRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetSuspendedRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetSuspendedRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Suspended {
+		i--
+		if m.Suspended {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UnregisterRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UnregisterRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UnregisterRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Cascade {
+		i--
+		if m.Cascade {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AllHubsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AllHubsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AllHubsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResolveDebugRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResolveDebugRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResolveDebugRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.DeploymentId) > 0 {
+		i -= len(m.DeploymentId)
+		copy(dAtA[i:], m.DeploymentId)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.DeploymentId)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Hostname) > 0 {
+		i -= len(m.Hostname)
+		copy(dAtA[i:], m.Hostname)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.Hostname)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResolveDebugResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResolveDebugResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResolveDebugResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Services) > 0 {
+		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Services[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.NewestDeployment {
+		i--
+		if m.NewestDeployment {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Suspended {
+		i--
+		if m.Suspended {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.PathPrefix) > 0 {
+		i -= len(m.PathPrefix)
+		copy(dAtA[i:], m.PathPrefix)
+		i = encodeVarintControl(dAtA, i, uint64(len(m.PathPrefix)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Target != nil {
+		{
+			size, err := m.Target.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TargetSplit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TargetSplit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TargetSplit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Weight != 0 {
+		i = encodeVarintControl(dAtA, i, uint64(m.Weight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Target != nil {
+		{
+			size, err := m.Target.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TargetSplits) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TargetSplits) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TargetSplits) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Splits) > 0 {
+		for iNdEx := len(m.Splits) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Splits[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetLabelLinkSplitsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetLabelLinkSplitsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetLabelLinkSplitsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Splits) > 0 {
+		for iNdEx := len(m.Splits) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Splits[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintControl(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Labels != nil {
+		{
+			size, err := m.Labels.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InvalidateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InvalidateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InvalidateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Account != nil {
+		{
+			size, err := m.Account.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintControl(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintControl(dAtA []byte, offset int, v uint64) int {
+	offset -= sovControl(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ServiceRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Hub != nil {
+		l = m.Hub.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Labels != nil {
+		l = m.Labels.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Metadata) > 0 {
+		for _, e := range m.Metadata {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ServiceResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *LabelLink) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Labels != nil {
+		l = m.Labels.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Target != nil {
+		l = m.Target.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Limits != nil {
+		l = m.Limits.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Splits) > 0 {
+		for _, e := range m.Splits {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LabelLinks) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.LabelLinks) > 0 {
+		for _, e := range m.LabelLinks {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ServiceRoute) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Hub != nil {
+		l = m.Hub.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Labels != nil {
+		l = m.Labels.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Unhealthy {
+		n += 2
+	}
+	if m.Weight != 0 {
+		n += 1 + sovControl(uint64(m.Weight))
+	}
+	return n
+}
+
+func (m *AccountServices) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Services) > 0 {
+		for _, e := range m.Services {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	if m.Suspended {
+		n += 2
+	}
+	return n
+}
+
+func (m *ActivityEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RouteAdded != nil {
+		l = m.RouteAdded.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.RouteRemoved != nil {
+		l = m.RouteRemoved.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ConfigRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StableId != nil {
+		l = m.StableId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.InstanceId != nil {
+		l = m.InstanceId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Locations) > 0 {
+		for _, e := range m.Locations {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ConfigResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TlsKey)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.TlsCert)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.TokenPub)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.S3AccessKey)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.S3SecretKey)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.S3Bucket)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.ImageTag)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.ActivityCompressor)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *CentralActivity) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.AccountServices) > 0 {
+		for _, e := range m.AccountServices {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	if m.RequestStats {
+		n += 2
+	}
+	if m.NewLabelLinks != nil {
+		l = m.NewLabelLinks.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.ResolvedRoutes) > 0 {
+		for _, e := range m.ResolvedRoutes {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	if m.RemovedLabelLinks != nil {
+		l = m.RemovedLabelLinks.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Quotas) > 0 {
+		for _, e := range m.Quotas {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	if m.S3Credentials != nil {
+		l = m.S3Credentials.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.S3CredentialsRotated {
+		n += 2
+	}
+	return n
+}
+
+func (m *S3Credentials) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.AccessKey)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.SecretKey)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.Bucket)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *QuotaStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Hub != nil {
+		l = m.Hub.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Exceeded {
+		n += 2
+	}
+	if m.Bytes != 0 {
+		n += 1 + sovControl(uint64(m.Bytes))
+	}
+	if m.Messages != 0 {
+		n += 1 + sovControl(uint64(m.Messages))
+	}
+	return n
+}
+
+func (m *HubActivity) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.HubReg != nil {
+		l = m.HubReg.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.SentAt != nil {
+		l = m.SentAt.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Stats != nil {
+		l = m.Stats.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Flow) > 0 {
+		for _, e := range m.Flow {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	if len(m.CachedAccounts) > 0 {
+		for _, e := range m.CachedAccounts {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *HubActivity_HubRegistration) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Hub != nil {
+		l = m.Hub.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.StableHub != nil {
+		l = m.StableHub.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Locations) > 0 {
+		for _, e := range m.Locations {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *HubActivity_HubStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AgentConnections != 0 {
+		n += 1 + sovControl(uint64(m.AgentConnections))
+	}
+	if m.AccountsSeen != 0 {
+		n += 1 + sovControl(uint64(m.AccountsSeen))
+	}
+	if m.AccountsCached != 0 {
+		n += 1 + sovControl(uint64(m.AccountsCached))
+	}
+	if m.CachedBytes != 0 {
+		n += 1 + sovControl(uint64(m.CachedBytes))
+	}
+	if m.LastSuccessfulUpdate != nil {
+		l = m.LastSuccessfulUpdate.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.LastAttemptedUpdate != nil {
+		l = m.LastAttemptedUpdate.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *HubInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Locations) > 0 {
+		for _, e := range m.Locations {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ListOfHubs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Hubs) > 0 {
+		for _, e := range m.Hubs {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	if m.Skipped != 0 {
+		n += 1 + sovControl(uint64(m.Skipped))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.NotModified {
+		n += 2
+	}
+	return n
+}
+
+func (m *HubSync) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.StableId != nil {
+		l = m.StableId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Services) > 0 {
+		for _, e := range m.Services {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *HubSyncResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ServiceCount != 0 {
+		n += 1 + sovControl(uint64(m.ServiceCount))
+	}
+	return n
+}
+
+func (m *HubRegisterRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StableId != nil {
+		l = m.StableId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.InstanceId != nil {
+		l = m.InstanceId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Locations) > 0 {
+		for _, e := range m.Locations {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *HubRegisterResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ControlTime != nil {
+		l = m.ControlTime.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *HubDisconnectRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StableId != nil {
+		l = m.StableId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.InstanceId != nil {
+		l = m.InstanceId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ServiceTokenRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.HubScope != nil {
+		l = m.HubScope.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ServiceTokenResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ListServicesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ListServicesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Services) > 0 {
+		for _, e := range m.Services {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Service) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Hub != nil {
+		l = m.Hub.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Labels != nil {
+		l = m.Labels.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Metadata) > 0 {
+		for _, e := range m.Metadata {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *StreamServicesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Labels != nil {
+		l = m.Labels.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.BatchSize != 0 {
+		n += 1 + sovControl(uint64(m.BatchSize))
+	}
+	return n
+}
+
+func (m *AddAccountRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Limits != nil {
+		l = m.Limits.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *CreateAccountRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Limits != nil {
+		l = m.Limits.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *CreateAccountResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Limits != nil {
+		l = m.Limits.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *AddLabelLinkRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Labels != nil {
+		l = m.Labels.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Target != nil {
+		l = m.Target.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *Noop) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *RemoveLabelLinkRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Labels != nil {
+		l = m.Labels.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *CreateTokenRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Capabilities) > 0 {
+		for _, e := range m.Capabilities {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	if m.ValidDuration != nil {
+		l = m.ValidDuration.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *CreateTokenResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Role != 0 {
+		n += 1 + sovControl(uint64(m.Role))
+	}
+	if m.TokenId != nil {
+		l = m.TokenId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Capabilities) > 0 {
+		for _, e := range m.Capabilities {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	if m.ValidUntil != nil {
+		l = m.ValidUntil.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *CreateTokensRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Tokens) > 0 {
+		for _, e := range m.Tokens {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	if m.AllOrNothing {
+		n += 2
+	}
+	return n
+}
+
+func (m *CreateTokenResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *CreateTokensResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Results) > 0 {
+		for _, e := range m.Results {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ControlRegister) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ControlToken) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *TokenInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PublicKey)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ListAccountsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Limit != 0 {
+		n += 1 + sovControl(uint64(m.Limit))
+	}
+	l = len(m.Marker)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ListAccountsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Accounts) > 0 {
+		for _, e := range m.Accounts {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	l = len(m.NextMarker)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ManagementClient) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.CreatedAt != nil {
+		l = m.CreatedAt.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ListManagementClientsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Limit != 0 {
+		n += 1 + sovControl(uint64(m.Limit))
+	}
+	l = len(m.Marker)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ListManagementClientsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Clients) > 0 {
+		for _, e := range m.Clients {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	l = len(m.NextMarker)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ListTokensRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Limit != 0 {
+		n += 1 + sovControl(uint64(m.Limit))
+	}
+	l = len(m.Marker)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *IssuedToken) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TokenId != nil {
+		l = m.TokenId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Role != 0 {
+		n += 1 + sovControl(uint64(m.Role))
+	}
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Capabilities) > 0 {
+		for _, e := range m.Capabilities {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	if m.ValidUntil != nil {
+		l = m.ValidUntil.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.IssuedAt != nil {
+		l = m.IssuedAt.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ListTokensResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Tokens) > 0 {
+		for _, e := range m.Tokens {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	l = len(m.NextMarker)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *WhoAmIResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Role != 0 {
+		n += 1 + sovControl(uint64(m.Role))
+	}
+	if m.TokenId != nil {
+		l = m.TokenId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Capabilities) > 0 {
+		for _, e := range m.Capabilities {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	l = len(m.AccessNamespace)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.ValidUntil != nil {
+		l = m.ValidUntil.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *TokenStatusResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TokenId != nil {
+		l = m.TokenId.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.NotBefore != nil {
+		l = m.NotBefore.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.ValidUntil != nil {
+		l = m.ValidUntil.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Renewable {
+		n += 2
+	}
+	if m.WithinRenewalWindow {
+		n += 2
+	}
+	if m.RenewAfter != nil {
+		l = m.RenewAfter.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ExportRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *AccountConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Limits != nil {
+		l = m.Limits.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.LabelLinks) > 0 {
+		for _, e := range m.LabelLinks {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	if len(m.Services) > 0 {
+		for _, e := range m.Services {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ImportRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Config != nil {
+		l = m.Config.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ConnectedHub) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hub)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Dropped != 0 {
+		n += 1 + sovControl(uint64(m.Dropped))
+	}
+	if m.QueueDepth != 0 {
+		n += 1 + sovControl(uint64(m.QueueDepth))
+	}
+	if m.MaxQueueDepth != 0 {
+		n += 1 + sovControl(uint64(m.MaxQueueDepth))
+	}
+	return n
+}
+
+func (m *ConnectedHubsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Hubs) > 0 {
+		for _, e := range m.Hubs {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *HubFlowCounters) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hub)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Messages != 0 {
+		n += 1 + sovControl(uint64(m.Messages))
+	}
+	if m.Bytes != 0 {
+		n += 1 + sovControl(uint64(m.Bytes))
+	}
+	return n
+}
+
+func (m *FlowCountersResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Hubs) > 0 {
+		for _, e := range m.Hubs {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SetSuspendedRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Suspended {
+		n += 2
+	}
+	return n
+}
+
+func (m *UnregisterRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Cascade {
+		n += 2
+	}
+	return n
+}
+
+func (m *AllHubsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ResolveDebugRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.DeploymentId)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func (m *ResolveDebugResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Target != nil {
+		l = m.Target.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	l = len(m.PathPrefix)
+	if l > 0 {
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Suspended {
+		n += 2
+	}
+	if m.NewestDeployment {
+		n += 2
+	}
+	if len(m.Services) > 0 {
+		for _, e := range m.Services {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TargetSplit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Target != nil {
+		l = m.Target.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Weight != 0 {
+		n += 1 + sovControl(uint64(m.Weight))
+	}
+	return n
+}
+
+func (m *TargetSplits) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Splits) > 0 {
+		for _, e := range m.Splits {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SetLabelLinkSplitsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if m.Labels != nil {
+		l = m.Labels.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	if len(m.Splits) > 0 {
+		for _, e := range m.Splits {
+			l = e.Size()
+			n += 1 + l + sovControl(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *InvalidateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Account != nil {
+		l = m.Account.Size()
+		n += 1 + l + sovControl(uint64(l))
+	}
+	return n
+}
+
+func sovControl(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozControl(x uint64) (n int) {
+	return sovControl(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *ServiceRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMetadata := "[]*KVPair{"
+	for _, f := range this.Metadata {
+		repeatedStringForMetadata += strings.Replace(fmt.Sprintf("%v", f), "KVPair", "KVPair", 1) + ","
+	}
+	repeatedStringForMetadata += "}"
+	s := strings.Join([]string{`&ServiceRequest{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Hub:` + strings.Replace(fmt.Sprintf("%v", this.Hub), "ULID", "ULID", 1) + `,`,
+		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
+		`Metadata:` + repeatedStringForMetadata + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ServiceResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ServiceResponse{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelLink) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForSplits := "[]*TargetSplit{"
+	for _, f := range this.Splits {
+		repeatedStringForSplits += strings.Replace(f.String(), "TargetSplit", "TargetSplit", 1) + ","
+	}
+	repeatedStringForSplits += "}"
+	s := strings.Join([]string{`&LabelLink{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
+		`Target:` + strings.Replace(fmt.Sprintf("%v", this.Target), "LabelSet", "LabelSet", 1) + `,`,
+		`Limits:` + strings.Replace(fmt.Sprintf("%v", this.Limits), "Account_Limits", "Account_Limits", 1) + `,`,
+		`Splits:` + repeatedStringForSplits + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelLinks) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForLabelLinks := "[]*LabelLink{"
+	for _, f := range this.LabelLinks {
+		repeatedStringForLabelLinks += strings.Replace(f.String(), "LabelLink", "LabelLink", 1) + ","
+	}
+	repeatedStringForLabelLinks += "}"
+	s := strings.Join([]string{`&LabelLinks{`,
+		`LabelLinks:` + repeatedStringForLabelLinks + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ServiceRoute) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ServiceRoute{`,
+		`Hub:` + strings.Replace(fmt.Sprintf("%v", this.Hub), "ULID", "ULID", 1) + `,`,
+		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
+		`Unhealthy:` + fmt.Sprintf("%v", this.Unhealthy) + `,`,
+		`Weight:` + fmt.Sprintf("%v", this.Weight) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AccountServices) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForServices := "[]*ServiceRoute{"
+	for _, f := range this.Services {
+		repeatedStringForServices += strings.Replace(f.String(), "ServiceRoute", "ServiceRoute", 1) + ","
+	}
+	repeatedStringForServices += "}"
+	s := strings.Join([]string{`&AccountServices{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Services:` + repeatedStringForServices + `,`,
+		`Suspended:` + fmt.Sprintf("%v", this.Suspended) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ActivityEntry) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ActivityEntry{`,
+		`RouteAdded:` + strings.Replace(this.RouteAdded.String(), "AccountServices", "AccountServices", 1) + `,`,
+		`RouteRemoved:` + strings.Replace(fmt.Sprintf("%v", this.RouteRemoved), "ULID", "ULID", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ConfigRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForLocations := "[]*NetworkLocation{"
+	for _, f := range this.Locations {
+		repeatedStringForLocations += strings.Replace(fmt.Sprintf("%v", f), "NetworkLocation", "NetworkLocation", 1) + ","
+	}
+	repeatedStringForLocations += "}"
+	s := strings.Join([]string{`&ConfigRequest{`,
+		`StableId:` + strings.Replace(fmt.Sprintf("%v", this.StableId), "ULID", "ULID", 1) + `,`,
+		`InstanceId:` + strings.Replace(fmt.Sprintf("%v", this.InstanceId), "ULID", "ULID", 1) + `,`,
+		`Locations:` + repeatedStringForLocations + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ConfigResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ConfigResponse{`,
+		`TlsKey:` + fmt.Sprintf("%v", this.TlsKey) + `,`,
+		`TlsCert:` + fmt.Sprintf("%v", this.TlsCert) + `,`,
+		`TokenPub:` + fmt.Sprintf("%v", this.TokenPub) + `,`,
+		`S3AccessKey:` + fmt.Sprintf("%v", this.S3AccessKey) + `,`,
+		`S3SecretKey:` + fmt.Sprintf("%v", this.S3SecretKey) + `,`,
+		`S3Bucket:` + fmt.Sprintf("%v", this.S3Bucket) + `,`,
+		`ImageTag:` + fmt.Sprintf("%v", this.ImageTag) + `,`,
+		`ActivityCompressor:` + fmt.Sprintf("%v", this.ActivityCompressor) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CentralActivity) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForAccountServices := "[]*AccountServices{"
+	for _, f := range this.AccountServices {
+		repeatedStringForAccountServices += strings.Replace(f.String(), "AccountServices", "AccountServices", 1) + ","
+	}
+	repeatedStringForAccountServices += "}"
+	repeatedStringForResolvedRoutes := "[]*AccountServices{"
+	for _, f := range this.ResolvedRoutes {
+		repeatedStringForResolvedRoutes += strings.Replace(f.String(), "AccountServices", "AccountServices", 1) + ","
+	}
+	repeatedStringForResolvedRoutes += "}"
+	repeatedStringForQuotas := "[]*QuotaStatus{"
+	for _, f := range this.Quotas {
+		repeatedStringForQuotas += strings.Replace(f.String(), "QuotaStatus", "QuotaStatus", 1) + ","
+	}
+	repeatedStringForQuotas += "}"
+	s := strings.Join([]string{`&CentralActivity{`,
+		`AccountServices:` + repeatedStringForAccountServices + `,`,
+		`RequestStats:` + fmt.Sprintf("%v", this.RequestStats) + `,`,
+		`NewLabelLinks:` + strings.Replace(fmt.Sprintf("%v", this.NewLabelLinks), "LabelLinks", "LabelLinks", 1) + `,`,
+		`ResolvedRoutes:` + repeatedStringForResolvedRoutes + `,`,
+		`RemovedLabelLinks:` + strings.Replace(fmt.Sprintf("%v", this.RemovedLabelLinks), "LabelLinks", "LabelLinks", 1) + `,`,
+		`Quotas:` + repeatedStringForQuotas + `,`,
+		`S3Credentials:` + strings.Replace(fmt.Sprintf("%v", this.S3Credentials), "S3Credentials", "S3Credentials", 1) + `,`,
+		`S3CredentialsRotated:` + fmt.Sprintf("%v", this.S3CredentialsRotated) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *S3Credentials) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&S3Credentials{`,
+		`AccessKey:` + fmt.Sprintf("%v", this.AccessKey) + `,`,
+		`SecretKey:` + fmt.Sprintf("%v", this.SecretKey) + `,`,
+		`Bucket:` + fmt.Sprintf("%v", this.Bucket) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *QuotaStatus) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&QuotaStatus{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Hub:` + strings.Replace(fmt.Sprintf("%v", this.Hub), "ULID", "ULID", 1) + `,`,
+		`Exceeded:` + fmt.Sprintf("%v", this.Exceeded) + `,`,
+		`Bytes:` + fmt.Sprintf("%v", this.Bytes) + `,`,
+		`Messages:` + fmt.Sprintf("%v", this.Messages) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubActivity) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForFlow := "[]*FlowRecord{"
+	for _, f := range this.Flow {
+		repeatedStringForFlow += strings.Replace(fmt.Sprintf("%v", f), "FlowRecord", "FlowRecord", 1) + ","
+	}
+	repeatedStringForFlow += "}"
+	repeatedStringForCachedAccounts := "[]*Account{"
+	for _, f := range this.CachedAccounts {
+		repeatedStringForCachedAccounts += strings.Replace(fmt.Sprintf("%v", f), "Account", "Account", 1) + ","
+	}
+	repeatedStringForCachedAccounts += "}"
+	s := strings.Join([]string{`&HubActivity{`,
+		`HubReg:` + strings.Replace(fmt.Sprintf("%v", this.HubReg), "HubActivity_HubRegistration", "HubActivity_HubRegistration", 1) + `,`,
+		`SentAt:` + strings.Replace(fmt.Sprintf("%v", this.SentAt), "Timestamp", "Timestamp", 1) + `,`,
+		`Stats:` + strings.Replace(fmt.Sprintf("%v", this.Stats), "HubActivity_HubStats", "HubActivity_HubStats", 1) + `,`,
+		`Flow:` + repeatedStringForFlow + `,`,
+		`CachedAccounts:` + repeatedStringForCachedAccounts + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubActivity_HubRegistration) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForLocations := "[]*NetworkLocation{"
+	for _, f := range this.Locations {
+		repeatedStringForLocations += strings.Replace(fmt.Sprintf("%v", f), "NetworkLocation", "NetworkLocation", 1) + ","
+	}
+	repeatedStringForLocations += "}"
+	s := strings.Join([]string{`&HubActivity_HubRegistration{`,
+		`Hub:` + strings.Replace(fmt.Sprintf("%v", this.Hub), "ULID", "ULID", 1) + `,`,
+		`StableHub:` + strings.Replace(fmt.Sprintf("%v", this.StableHub), "ULID", "ULID", 1) + `,`,
+		`Locations:` + repeatedStringForLocations + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubActivity_HubStats) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&HubActivity_HubStats{`,
+		`AgentConnections:` + fmt.Sprintf("%v", this.AgentConnections) + `,`,
+		`AccountsSeen:` + fmt.Sprintf("%v", this.AccountsSeen) + `,`,
+		`AccountsCached:` + fmt.Sprintf("%v", this.AccountsCached) + `,`,
+		`CachedBytes:` + fmt.Sprintf("%v", this.CachedBytes) + `,`,
+		`LastSuccessfulUpdate:` + strings.Replace(fmt.Sprintf("%v", this.LastSuccessfulUpdate), "Timestamp", "Timestamp", 1) + `,`,
+		`LastAttemptedUpdate:` + strings.Replace(fmt.Sprintf("%v", this.LastAttemptedUpdate), "Timestamp", "Timestamp", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubInfo) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForLocations := "[]*NetworkLocation{"
+	for _, f := range this.Locations {
+		repeatedStringForLocations += strings.Replace(fmt.Sprintf("%v", f), "NetworkLocation", "NetworkLocation", 1) + ","
+	}
+	repeatedStringForLocations += "}"
+	s := strings.Join([]string{`&HubInfo{`,
+		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
+		`Locations:` + repeatedStringForLocations + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListOfHubs) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForHubs := "[]*HubInfo{"
+	for _, f := range this.Hubs {
+		repeatedStringForHubs += strings.Replace(f.String(), "HubInfo", "HubInfo", 1) + ","
+	}
+	repeatedStringForHubs += "}"
+	s := strings.Join([]string{`&ListOfHubs{`,
+		`Hubs:` + repeatedStringForHubs + `,`,
+		`Skipped:` + fmt.Sprintf("%v", this.Skipped) + `,`,
+		`Version:` + fmt.Sprintf("%v", this.Version) + `,`,
+		`NotModified:` + fmt.Sprintf("%v", this.NotModified) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubSync) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForServices := "[]*ServiceRequest{"
+	for _, f := range this.Services {
+		repeatedStringForServices += strings.Replace(f.String(), "ServiceRequest", "ServiceRequest", 1) + ","
+	}
+	repeatedStringForServices += "}"
+	s := strings.Join([]string{`&HubSync{`,
+		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
+		`StableId:` + strings.Replace(fmt.Sprintf("%v", this.StableId), "ULID", "ULID", 1) + `,`,
+		`Services:` + repeatedStringForServices + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubSyncResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&HubSyncResponse{`,
+		`ServiceCount:` + fmt.Sprintf("%v", this.ServiceCount) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubRegisterRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForLocations := "[]*NetworkLocation{"
+	for _, f := range this.Locations {
+		repeatedStringForLocations += strings.Replace(fmt.Sprintf("%v", f), "NetworkLocation", "NetworkLocation", 1) + ","
+	}
+	repeatedStringForLocations += "}"
+	s := strings.Join([]string{`&HubRegisterRequest{`,
+		`StableId:` + strings.Replace(fmt.Sprintf("%v", this.StableId), "ULID", "ULID", 1) + `,`,
+		`InstanceId:` + strings.Replace(fmt.Sprintf("%v", this.InstanceId), "ULID", "ULID", 1) + `,`,
+		`Locations:` + repeatedStringForLocations + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubRegisterResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&HubRegisterResponse{`,
+		`ControlTime:` + strings.Replace(fmt.Sprintf("%v", this.ControlTime), "Timestamp", "Timestamp", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubDisconnectRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&HubDisconnectRequest{`,
+		`StableId:` + strings.Replace(fmt.Sprintf("%v", this.StableId), "ULID", "ULID", 1) + `,`,
+		`InstanceId:` + strings.Replace(fmt.Sprintf("%v", this.InstanceId), "ULID", "ULID", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ServiceTokenRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ServiceTokenRequest{`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`HubScope:` + strings.Replace(fmt.Sprintf("%v", this.HubScope), "LabelSet", "LabelSet", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ServiceTokenResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ServiceTokenResponse{`,
+		`Token:` + fmt.Sprintf("%v", this.Token) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListServicesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ListServicesRequest{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListServicesResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForServices := "[]*Service{"
+	for _, f := range this.Services {
+		repeatedStringForServices += strings.Replace(f.String(), "Service", "Service", 1) + ","
+	}
+	repeatedStringForServices += "}"
+	s := strings.Join([]string{`&ListServicesResponse{`,
+		`Services:` + repeatedStringForServices + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Service) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMetadata := "[]*KVPair{"
+	for _, f := range this.Metadata {
+		repeatedStringForMetadata += strings.Replace(fmt.Sprintf("%v", f), "KVPair", "KVPair", 1) + ","
+	}
+	repeatedStringForMetadata += "}"
+	s := strings.Join([]string{`&Service{`,
+		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
+		`Hub:` + strings.Replace(fmt.Sprintf("%v", this.Hub), "ULID", "ULID", 1) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
+		`Metadata:` + repeatedStringForMetadata + `,`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *StreamServicesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&StreamServicesRequest{`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
+		`BatchSize:` + fmt.Sprintf("%v", this.BatchSize) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AddAccountRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AddAccountRequest{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Limits:` + strings.Replace(fmt.Sprintf("%v", this.Limits), "Account_Limits", "Account_Limits", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CreateAccountRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CreateAccountRequest{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Limits:` + strings.Replace(fmt.Sprintf("%v", this.Limits), "Account_Limits", "Account_Limits", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CreateAccountResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CreateAccountResponse{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Limits:` + strings.Replace(fmt.Sprintf("%v", this.Limits), "Account_Limits", "Account_Limits", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AddLabelLinkRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AddLabelLinkRequest{`,
+		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Target:` + strings.Replace(fmt.Sprintf("%v", this.Target), "LabelSet", "LabelSet", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Noop) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Noop{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RemoveLabelLinkRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RemoveLabelLinkRequest{`,
+		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CreateTokenRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForCapabilities := "[]TokenCapability{"
+	for _, f := range this.Capabilities {
+		repeatedStringForCapabilities += fmt.Sprintf("%v", f) + ","
+	}
+	repeatedStringForCapabilities += "}"
+	s := strings.Join([]string{`&CreateTokenRequest{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Capabilities:` + repeatedStringForCapabilities + `,`,
+		`ValidDuration:` + strings.Replace(fmt.Sprintf("%v", this.ValidDuration), "Timestamp", "Timestamp", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CreateTokenResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForCapabilities := "[]TokenCapability{"
+	for _, f := range this.Capabilities {
+		repeatedStringForCapabilities += fmt.Sprintf("%v", f) + ","
+	}
+	repeatedStringForCapabilities += "}"
+	s := strings.Join([]string{`&CreateTokenResponse{`,
+		`Token:` + fmt.Sprintf("%v", this.Token) + `,`,
+		`Role:` + fmt.Sprintf("%v", this.Role) + `,`,
+		`TokenId:` + strings.Replace(fmt.Sprintf("%v", this.TokenId), "ULID", "ULID", 1) + `,`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Capabilities:` + repeatedStringForCapabilities + `,`,
+		`ValidUntil:` + strings.Replace(fmt.Sprintf("%v", this.ValidUntil), "Timestamp", "Timestamp", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CreateTokensRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForTokens := "[]*CreateTokenRequest{"
+	for _, f := range this.Tokens {
+		repeatedStringForTokens += strings.Replace(f.String(), "CreateTokenRequest", "CreateTokenRequest", 1) + ","
+	}
+	repeatedStringForTokens += "}"
+	s := strings.Join([]string{`&CreateTokensRequest{`,
+		`Tokens:` + repeatedStringForTokens + `,`,
+		`AllOrNothing:` + fmt.Sprintf("%v", this.AllOrNothing) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CreateTokenResult) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CreateTokenResult{`,
+		`Token:` + fmt.Sprintf("%v", this.Token) + `,`,
+		`Error:` + fmt.Sprintf("%v", this.Error) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CreateTokensResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForResults := "[]*CreateTokenResult{"
+	for _, f := range this.Results {
+		repeatedStringForResults += strings.Replace(f.String(), "CreateTokenResult", "CreateTokenResult", 1) + ","
+	}
+	repeatedStringForResults += "}"
+	s := strings.Join([]string{`&CreateTokensResponse{`,
+		`Results:` + repeatedStringForResults + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ControlRegister) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ControlRegister{`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ControlToken) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ControlToken{`,
+		`Token:` + fmt.Sprintf("%v", this.Token) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TokenInfo) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TokenInfo{`,
+		`PublicKey:` + fmt.Sprintf("%v", this.PublicKey) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListAccountsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ListAccountsRequest{`,
+		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
+		`Marker:` + fmt.Sprintf("%v", this.Marker) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListAccountsResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForAccounts := "[]*Account{"
+	for _, f := range this.Accounts {
+		repeatedStringForAccounts += strings.Replace(fmt.Sprintf("%v", f), "Account", "Account", 1) + ","
+	}
+	repeatedStringForAccounts += "}"
+	s := strings.Join([]string{`&ListAccountsResponse{`,
+		`Accounts:` + repeatedStringForAccounts + `,`,
+		`NextMarker:` + fmt.Sprintf("%v", this.NextMarker) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ManagementClient) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ManagementClient{`,
+		`Id:` + strings.Replace(fmt.Sprintf("%v", this.Id), "ULID", "ULID", 1) + `,`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`CreatedAt:` + strings.Replace(fmt.Sprintf("%v", this.CreatedAt), "Timestamp", "Timestamp", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListManagementClientsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ListManagementClientsRequest{`,
+		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
+		`Marker:` + fmt.Sprintf("%v", this.Marker) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListManagementClientsResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForClients := "[]*ManagementClient{"
+	for _, f := range this.Clients {
+		repeatedStringForClients += strings.Replace(f.String(), "ManagementClient", "ManagementClient", 1) + ","
+	}
+	repeatedStringForClients += "}"
+	s := strings.Join([]string{`&ListManagementClientsResponse{`,
+		`Clients:` + repeatedStringForClients + `,`,
+		`NextMarker:` + fmt.Sprintf("%v", this.NextMarker) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListTokensRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ListTokensRequest{`,
+		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
+		`Marker:` + fmt.Sprintf("%v", this.Marker) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *IssuedToken) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForCapabilities := "[]TokenCapability{"
+	for _, f := range this.Capabilities {
+		repeatedStringForCapabilities += fmt.Sprintf("%v", f) + ","
+	}
+	repeatedStringForCapabilities += "}"
+	s := strings.Join([]string{`&IssuedToken{`,
+		`TokenId:` + strings.Replace(fmt.Sprintf("%v", this.TokenId), "ULID", "ULID", 1) + `,`,
+		`Role:` + fmt.Sprintf("%v", this.Role) + `,`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Capabilities:` + repeatedStringForCapabilities + `,`,
+		`ValidUntil:` + strings.Replace(fmt.Sprintf("%v", this.ValidUntil), "Timestamp", "Timestamp", 1) + `,`,
+		`IssuedAt:` + strings.Replace(fmt.Sprintf("%v", this.IssuedAt), "Timestamp", "Timestamp", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListTokensResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForTokens := "[]*IssuedToken{"
+	for _, f := range this.Tokens {
+		repeatedStringForTokens += strings.Replace(f.String(), "IssuedToken", "IssuedToken", 1) + ","
+	}
+	repeatedStringForTokens += "}"
+	s := strings.Join([]string{`&ListTokensResponse{`,
+		`Tokens:` + repeatedStringForTokens + `,`,
+		`NextMarker:` + fmt.Sprintf("%v", this.NextMarker) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *WhoAmIResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForCapabilities := "[]TokenCapability{"
+	for _, f := range this.Capabilities {
+		repeatedStringForCapabilities += fmt.Sprintf("%v", f) + ","
+	}
+	repeatedStringForCapabilities += "}"
+	s := strings.Join([]string{`&WhoAmIResponse{`,
+		`Role:` + fmt.Sprintf("%v", this.Role) + `,`,
+		`TokenId:` + strings.Replace(fmt.Sprintf("%v", this.TokenId), "ULID", "ULID", 1) + `,`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Capabilities:` + repeatedStringForCapabilities + `,`,
+		`AccessNamespace:` + fmt.Sprintf("%v", this.AccessNamespace) + `,`,
+		`ValidUntil:` + strings.Replace(fmt.Sprintf("%v", this.ValidUntil), "Timestamp", "Timestamp", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TokenStatusResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TokenStatusResponse{`,
+		`TokenId:` + strings.Replace(fmt.Sprintf("%v", this.TokenId), "ULID", "ULID", 1) + `,`,
+		`NotBefore:` + strings.Replace(fmt.Sprintf("%v", this.NotBefore), "Timestamp", "Timestamp", 1) + `,`,
+		`ValidUntil:` + strings.Replace(fmt.Sprintf("%v", this.ValidUntil), "Timestamp", "Timestamp", 1) + `,`,
+		`Renewable:` + fmt.Sprintf("%v", this.Renewable) + `,`,
+		`WithinRenewalWindow:` + fmt.Sprintf("%v", this.WithinRenewalWindow) + `,`,
+		`RenewAfter:` + strings.Replace(fmt.Sprintf("%v", this.RenewAfter), "Timestamp", "Timestamp", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ExportRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ExportRequest{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AccountConfig) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForLabelLinks := "[]*LabelLink{"
+	for _, f := range this.LabelLinks {
+		repeatedStringForLabelLinks += strings.Replace(f.String(), "LabelLink", "LabelLink", 1) + ","
+	}
+	repeatedStringForLabelLinks += "}"
+	repeatedStringForServices := "[]*ServiceRequest{"
+	for _, f := range this.Services {
+		repeatedStringForServices += strings.Replace(f.String(), "ServiceRequest", "ServiceRequest", 1) + ","
+	}
+	repeatedStringForServices += "}"
+	s := strings.Join([]string{`&AccountConfig{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Limits:` + strings.Replace(fmt.Sprintf("%v", this.Limits), "Account_Limits", "Account_Limits", 1) + `,`,
+		`LabelLinks:` + repeatedStringForLabelLinks + `,`,
+		`Services:` + repeatedStringForServices + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImportRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ImportRequest{`,
+		`Config:` + strings.Replace(this.Config.String(), "AccountConfig", "AccountConfig", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ConnectedHub) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ConnectedHub{`,
+		`Hub:` + fmt.Sprintf("%v", this.Hub) + `,`,
+		`Dropped:` + fmt.Sprintf("%v", this.Dropped) + `,`,
+		`QueueDepth:` + fmt.Sprintf("%v", this.QueueDepth) + `,`,
+		`MaxQueueDepth:` + fmt.Sprintf("%v", this.MaxQueueDepth) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ConnectedHubsResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForHubs := "[]*ConnectedHub{"
+	for _, f := range this.Hubs {
+		repeatedStringForHubs += strings.Replace(f.String(), "ConnectedHub", "ConnectedHub", 1) + ","
+	}
+	repeatedStringForHubs += "}"
+	s := strings.Join([]string{`&ConnectedHubsResponse{`,
+		`Hubs:` + repeatedStringForHubs + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HubFlowCounters) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&HubFlowCounters{`,
+		`Hub:` + fmt.Sprintf("%v", this.Hub) + `,`,
+		`Messages:` + fmt.Sprintf("%v", this.Messages) + `,`,
+		`Bytes:` + fmt.Sprintf("%v", this.Bytes) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *FlowCountersResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForHubs := "[]*HubFlowCounters{"
+	for _, f := range this.Hubs {
+		repeatedStringForHubs += strings.Replace(f.String(), "HubFlowCounters", "HubFlowCounters", 1) + ","
+	}
+	repeatedStringForHubs += "}"
+	s := strings.Join([]string{`&FlowCountersResponse{`,
+		`Hubs:` + repeatedStringForHubs + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SetSuspendedRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SetSuspendedRequest{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Suspended:` + fmt.Sprintf("%v", this.Suspended) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UnregisterRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UnregisterRequest{`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`Cascade:` + fmt.Sprintf("%v", this.Cascade) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AllHubsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AllHubsRequest{`,
+		`Version:` + fmt.Sprintf("%v", this.Version) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ResolveDebugRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ResolveDebugRequest{`,
+		`Hostname:` + fmt.Sprintf("%v", this.Hostname) + `,`,
+		`Path:` + fmt.Sprintf("%v", this.Path) + `,`,
+		`DeploymentId:` + fmt.Sprintf("%v", this.DeploymentId) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ResolveDebugResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForServices := "[]*ServiceRoute{"
+	for _, f := range this.Services {
+		repeatedStringForServices += strings.Replace(f.String(), "ServiceRoute", "ServiceRoute", 1) + ","
+	}
+	repeatedStringForServices += "}"
+	s := strings.Join([]string{`&ResolveDebugResponse{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Target:` + strings.Replace(fmt.Sprintf("%v", this.Target), "LabelSet", "LabelSet", 1) + `,`,
+		`PathPrefix:` + fmt.Sprintf("%v", this.PathPrefix) + `,`,
+		`Suspended:` + fmt.Sprintf("%v", this.Suspended) + `,`,
+		`NewestDeployment:` + fmt.Sprintf("%v", this.NewestDeployment) + `,`,
+		`Services:` + repeatedStringForServices + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TargetSplit) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TargetSplit{`,
+		`Target:` + strings.Replace(fmt.Sprintf("%v", this.Target), "LabelSet", "LabelSet", 1) + `,`,
+		`Weight:` + fmt.Sprintf("%v", this.Weight) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TargetSplits) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForSplits := "[]*TargetSplit{"
+	for _, f := range this.Splits {
+		repeatedStringForSplits += strings.Replace(f.String(), "TargetSplit", "TargetSplit", 1) + ","
+	}
+	repeatedStringForSplits += "}"
+	s := strings.Join([]string{`&TargetSplits{`,
+		`Splits:` + repeatedStringForSplits + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SetLabelLinkSplitsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForSplits := "[]*TargetSplit{"
+	for _, f := range this.Splits {
+		repeatedStringForSplits += strings.Replace(f.String(), "TargetSplit", "TargetSplit", 1) + ","
+	}
+	repeatedStringForSplits += "}"
+	s := strings.Join([]string{`&SetLabelLinkSplitsRequest{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`Labels:` + strings.Replace(fmt.Sprintf("%v", this.Labels), "LabelSet", "LabelSet", 1) + `,`,
+		`Splits:` + repeatedStringForSplits + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *InvalidateRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&InvalidateRequest{`,
+		`Account:` + strings.Replace(fmt.Sprintf("%v", this.Account), "Account", "Account", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringControl(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ServiceRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ServiceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Account == nil {
+				m.Account = &Account{}
+			}
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hub", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Hub == nil {
+				m.Hub = &ULID{}
+			}
+			if err := m.Hub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &ULID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Labels == nil {
+				m.Labels = &LabelSet{}
+			}
+			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = append(m.Metadata, &KVPair{})
+			if err := m.Metadata[len(m.Metadata)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ServiceResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ServiceResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ServiceResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelLink) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelLink: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelLink: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Account == nil {
+				m.Account = &Account{}
+			}
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Labels == nil {
+				m.Labels = &LabelSet{}
+			}
+			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Target == nil {
+				m.Target = &LabelSet{}
+			}
+			if err := m.Target.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Limits == nil {
+				m.Limits = &Account_Limits{}
+			}
+			if err := m.Limits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Splits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Splits = append(m.Splits, &TargetSplit{})
+			if err := m.Splits[len(m.Splits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelLinks) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelLinks: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelLinks: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelLinks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LabelLinks = append(m.LabelLinks, &LabelLink{})
+			if err := m.LabelLinks[len(m.LabelLinks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ServiceRoute) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ServiceRoute: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ServiceRoute: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hub", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Hub == nil {
+				m.Hub = &ULID{}
+			}
+			if err := m.Hub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &ULID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Labels == nil {
+				m.Labels = &LabelSet{}
+			}
+			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Unhealthy", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Unhealthy = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Weight", wireType)
+			}
+			m.Weight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Weight |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AccountServices) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AccountServices: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AccountServices: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Account == nil {
+				m.Account = &Account{}
+			}
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Services = append(m.Services, &ServiceRoute{})
+			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Suspended", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Suspended = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActivityEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActivityEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActivityEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RouteAdded", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RouteAdded == nil {
+				m.RouteAdded = &AccountServices{}
+			}
+			if err := m.RouteAdded.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RouteRemoved", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RouteRemoved == nil {
+				m.RouteRemoved = &ULID{}
+			}
+			if err := m.RouteRemoved.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StableId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StableId == nil {
+				m.StableId = &ULID{}
+			}
+			if err := m.StableId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstanceId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InstanceId == nil {
+				m.InstanceId = &ULID{}
+			}
+			if err := m.InstanceId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Locations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Locations = append(m.Locations, &NetworkLocation{})
+			if err := m.Locations[len(m.Locations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TlsKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TlsKey = append(m.TlsKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.TlsKey == nil {
+				m.TlsKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TlsCert", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TlsCert = append(m.TlsCert[:0], dAtA[iNdEx:postIndex]...)
+			if m.TlsCert == nil {
+				m.TlsCert = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenPub", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenPub = append(m.TokenPub[:0], dAtA[iNdEx:postIndex]...)
+			if m.TokenPub == nil {
+				m.TokenPub = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field S3AccessKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.S3AccessKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field S3SecretKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.S3SecretKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field S3Bucket", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.S3Bucket = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ImageTag", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ImageTag = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivityCompressor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActivityCompressor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CentralActivity) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CentralActivity: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CentralActivity: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccountServices", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AccountServices = append(m.AccountServices, &AccountServices{})
+			if err := m.AccountServices[len(m.AccountServices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestStats", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RequestStats = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewLabelLinks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.NewLabelLinks == nil {
+				m.NewLabelLinks = &LabelLinks{}
+			}
+			if err := m.NewLabelLinks.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResolvedRoutes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResolvedRoutes = append(m.ResolvedRoutes, &AccountServices{})
+			if err := m.ResolvedRoutes[len(m.ResolvedRoutes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemovedLabelLinks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RemovedLabelLinks == nil {
+				m.RemovedLabelLinks = &LabelLinks{}
+			}
+			if err := m.RemovedLabelLinks.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Quotas", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Quotas = append(m.Quotas, &QuotaStatus{})
+			if err := m.Quotas[len(m.Quotas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field S3Credentials", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.S3Credentials == nil {
+				m.S3Credentials = &S3Credentials{}
+			}
+			if err := m.S3Credentials.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field S3CredentialsRotated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.S3CredentialsRotated = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *S3Credentials) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: S3Credentials: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: S3Credentials: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AccessKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SecretKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SecretKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bucket", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bucket = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QuotaStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QuotaStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QuotaStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Account == nil {
+				m.Account = &Account{}
+			}
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hub", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Hub == nil {
+				m.Hub = &ULID{}
+			}
+			if err := m.Hub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Exceeded", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Exceeded = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bytes", wireType)
+			}
+			m.Bytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Bytes |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
+			}
+			m.Messages = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Messages |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HubActivity) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HubActivity: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HubActivity: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HubReg", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HubReg == nil {
+				m.HubReg = &HubActivity_HubRegistration{}
+			}
+			if err := m.HubReg.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SentAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SentAt == nil {
+				m.SentAt = &Timestamp{}
+			}
+			if err := m.SentAt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Stats == nil {
+				m.Stats = &HubActivity_HubStats{}
+			}
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flow", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Flow = append(m.Flow, &FlowRecord{})
+			if err := m.Flow[len(m.Flow)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CachedAccounts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CachedAccounts = append(m.CachedAccounts, &Account{})
+			if err := m.CachedAccounts[len(m.CachedAccounts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HubActivity_HubRegistration) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HubRegistration: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HubRegistration: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hub", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Hub == nil {
+				m.Hub = &ULID{}
+			}
+			if err := m.Hub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StableHub", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StableHub == nil {
+				m.StableHub = &ULID{}
+			}
+			if err := m.StableHub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Locations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Locations = append(m.Locations, &NetworkLocation{})
+			if err := m.Locations[len(m.Locations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HubActivity_HubStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HubStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HubStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AgentConnections", wireType)
+			}
+			m.AgentConnections = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AgentConnections |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccountsSeen", wireType)
+			}
+			m.AccountsSeen = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AccountsSeen |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccountsCached", wireType)
+			}
+			m.AccountsCached = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AccountsCached |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CachedBytes", wireType)
+			}
+			m.CachedBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CachedBytes |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastSuccessfulUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LastSuccessfulUpdate == nil {
+				m.LastSuccessfulUpdate = &Timestamp{}
+			}
+			if err := m.LastSuccessfulUpdate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastAttemptedUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LastAttemptedUpdate == nil {
+				m.LastAttemptedUpdate = &Timestamp{}
+			}
+			if err := m.LastAttemptedUpdate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HubInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HubInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HubInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &ULID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Locations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Locations = append(m.Locations, &NetworkLocation{})
+			if err := m.Locations[len(m.Locations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListOfHubs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListOfHubs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListOfHubs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hubs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hubs = append(m.Hubs, &HubInfo{})
+			if err := m.Hubs[len(m.Hubs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Skipped", wireType)
+			}
+			m.Skipped = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Skipped |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotModified", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.NotModified = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HubSync) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HubSync: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HubSync: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &ULID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StableId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StableId == nil {
+				m.StableId = &ULID{}
+			}
+			if err := m.StableId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Services = append(m.Services, &ServiceRequest{})
+			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HubSyncResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HubSyncResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HubSyncResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceCount", wireType)
+			}
+			m.ServiceCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ServiceCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HubRegisterRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HubRegisterRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HubRegisterRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StableId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StableId == nil {
+				m.StableId = &ULID{}
+			}
+			if err := m.StableId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstanceId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InstanceId == nil {
+				m.InstanceId = &ULID{}
+			}
+			if err := m.InstanceId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Locations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Locations = append(m.Locations, &NetworkLocation{})
+			if err := m.Locations[len(m.Locations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HubRegisterResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HubRegisterResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HubRegisterResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ControlTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ControlTime == nil {
+				m.ControlTime = &Timestamp{}
+			}
+			if err := m.ControlTime.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HubDisconnectRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HubDisconnectRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HubDisconnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StableId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StableId == nil {
+				m.StableId = &ULID{}
+			}
+			if err := m.StableId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstanceId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InstanceId == nil {
+				m.InstanceId = &ULID{}
+			}
+			if err := m.InstanceId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ServiceTokenRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ServiceTokenRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ServiceTokenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HubScope", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HubScope == nil {
+				m.HubScope = &LabelSet{}
+			}
+			if err := m.HubScope.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ServiceTokenResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ServiceTokenResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ServiceTokenResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Token = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListServicesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListServicesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListServicesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Account == nil {
+				m.Account = &Account{}
+			}
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListServicesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListServicesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListServicesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Services = append(m.Services, &Service{})
+			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Service) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Service: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Service: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &ULID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hub", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Hub == nil {
+				m.Hub = &ULID{}
+			}
+			if err := m.Hub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Labels == nil {
+				m.Labels = &LabelSet{}
+			}
+			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = append(m.Metadata, &KVPair{})
+			if err := m.Metadata[len(m.Metadata)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Account == nil {
+				m.Account = &Account{}
+			}
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StreamServicesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StreamServicesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StreamServicesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Labels == nil {
+				m.Labels = &LabelSet{}
+			}
+			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchSize", wireType)
+			}
+			m.BatchSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BatchSize |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddAccountRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddAccountRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddAccountRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Account == nil {
+				m.Account = &Account{}
+			}
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Limits == nil {
+				m.Limits = &Account_Limits{}
+			}
+			if err := m.Limits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	repeatedStringForAccounts += "}"
-	s := strings.Join([]string{`&ListAccountsResponse{`,
-		`Accounts:` + repeatedStringForAccounts + `,`,
-		`NextMarker:` + fmt.Sprintf("%v", this.NextMarker) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func valueToStringControl(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	return nil
 }
-func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
+func (m *CreateAccountRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7446,10 +19568,10 @@ func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ServiceRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateAccountRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ServiceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateAccountRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7490,7 +19612,7 @@ func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hub", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Limits", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7517,16 +19639,69 @@ func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Hub == nil {
-				m.Hub = &ULID{}
+			if m.Limits == nil {
+				m.Limits = &Account_Limits{}
 			}
-			if err := m.Hub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Limits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateAccountResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateAccountResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateAccountResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7553,18 +19728,107 @@ func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &ULID{}
+			if m.Account == nil {
+				m.Account = &Account{}
 			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Limits == nil {
+				m.Limits = &Account_Limits{}
+			}
+			if err := m.Limits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddLabelLinkRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddLabelLinkRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddLabelLinkRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -7574,27 +19838,31 @@ func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Type = string(dAtA[iNdEx:postIndex])
+			if m.Labels == nil {
+				m.Labels = &LabelSet{}
+			}
+			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 5:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7621,16 +19889,16 @@ func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Labels == nil {
-				m.Labels = &LabelSet{}
+			if m.Account == nil {
+				m.Account = &Account{}
 			}
-			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7657,8 +19925,10 @@ func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Metadata = append(m.Metadata, &KVPair{})
-			if err := m.Metadata[len(m.Metadata)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Target == nil {
+				m.Target = &LabelSet{}
+			}
+			if err := m.Target.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -7686,7 +19956,7 @@ func (m *ServiceRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ServiceResponse) Unmarshal(dAtA []byte) error {
+func (m *Noop) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7709,10 +19979,10 @@ func (m *ServiceResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ServiceResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: Noop: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ServiceResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Noop: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -7739,7 +20009,7 @@ func (m *ServiceResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LabelLink) Unmarshal(dAtA []byte) error {
+func (m *RemoveLabelLinkRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7762,15 +20032,15 @@ func (m *LabelLink) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LabelLink: wiretype end group for non-group")
+			return fmt.Errorf("proto: RemoveLabelLinkRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelLink: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RemoveLabelLinkRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7797,16 +20067,16 @@ func (m *LabelLink) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Account == nil {
-				m.Account = &Account{}
+			if m.Labels == nil {
+				m.Labels = &LabelSet{}
 			}
-			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7833,16 +20103,69 @@ func (m *LabelLink) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Labels == nil {
-				m.Labels = &LabelSet{}
+			if m.Account == nil {
+				m.Account = &Account{}
 			}
-			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateTokenRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateTokenRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateTokenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7869,16 +20192,16 @@ func (m *LabelLink) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Target == nil {
-				m.Target = &LabelSet{}
+			if m.Account == nil {
+				m.Account = &Account{}
 			}
-			if err := m.Target.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Limits", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7905,69 +20228,14 @@ func (m *LabelLink) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Limits == nil {
-				m.Limits = &Account_Limits{}
-			}
-			if err := m.Limits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Capabilities = append(m.Capabilities, TokenCapability{})
+			if err := m.Capabilities[len(m.Capabilities)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipControl(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthControl
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthControl
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *LabelLinks) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowControl
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: LabelLinks: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelLinks: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LabelLinks", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidDuration", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7994,8 +20262,10 @@ func (m *LabelLinks) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LabelLinks = append(m.LabelLinks, &LabelLink{})
-			if err := m.LabelLinks[len(m.LabelLinks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.ValidDuration == nil {
+				m.ValidDuration = &Timestamp{}
+			}
+			if err := m.ValidDuration.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -8023,7 +20293,7 @@ func (m *LabelLinks) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ServiceRoute) Unmarshal(dAtA []byte) error {
+func (m *CreateTokenResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8046,15 +20316,66 @@ func (m *ServiceRoute) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ServiceRoute: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateTokenResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ServiceRoute: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateTokenResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hub", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Token = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+			}
+			m.Role = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Role |= TokenRole(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenId", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8081,16 +20402,16 @@ func (m *ServiceRoute) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Hub == nil {
-				m.Hub = &ULID{}
+			if m.TokenId == nil {
+				m.TokenId = &ULID{}
 			}
-			if err := m.Hub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.TokenId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8117,18 +20438,18 @@ func (m *ServiceRoute) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &ULID{}
+			if m.Account == nil {
+				m.Account = &Account{}
 			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -8138,27 +20459,29 @@ func (m *ServiceRoute) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Type = string(dAtA[iNdEx:postIndex])
+			m.Capabilities = append(m.Capabilities, TokenCapability{})
+			if err := m.Capabilities[len(m.Capabilities)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 4:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidUntil", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8185,10 +20508,10 @@ func (m *ServiceRoute) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Labels == nil {
-				m.Labels = &LabelSet{}
+			if m.ValidUntil == nil {
+				m.ValidUntil = &Timestamp{}
 			}
-			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ValidUntil.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -8216,7 +20539,7 @@ func (m *ServiceRoute) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AccountServices) Unmarshal(dAtA []byte) error {
+func (m *CreateTokensRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8239,15 +20562,15 @@ func (m *AccountServices) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AccountServices: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateTokensRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AccountServices: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateTokensRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tokens", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8274,18 +20597,16 @@ func (m *AccountServices) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Account == nil {
-				m.Account = &Account{}
-			}
-			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Tokens = append(m.Tokens, &CreateTokenRequest{})
+			if err := m.Tokens[len(m.Tokens)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllOrNothing", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -8295,26 +20616,12 @@ func (m *AccountServices) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Services = append(m.Services, &ServiceRoute{})
-			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.AllOrNothing = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipControl(dAtA[iNdEx:])
@@ -8339,7 +20646,7 @@ func (m *AccountServices) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ActivityEntry) Unmarshal(dAtA []byte) error {
+func (m *CreateTokenResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8362,17 +20669,17 @@ func (m *ActivityEntry) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ActivityEntry: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateTokenResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ActivityEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateTokenResult: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RouteAdded", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -8382,33 +20689,29 @@ func (m *ActivityEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.RouteAdded == nil {
-				m.RouteAdded = &AccountServices{}
-			}
-			if err := m.RouteAdded.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RouteRemoved", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -8418,27 +20721,23 @@ func (m *ActivityEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.RouteRemoved == nil {
-				m.RouteRemoved = &ULID{}
-			}
-			if err := m.RouteRemoved.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -8464,7 +20763,7 @@ func (m *ActivityEntry) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConfigRequest) Unmarshal(dAtA []byte) error {
+func (m *CreateTokensResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8487,15 +20786,15 @@ func (m *ConfigRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConfigRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateTokensResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateTokensResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StableId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Results", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8522,18 +20821,69 @@ func (m *ConfigRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.StableId == nil {
-				m.StableId = &ULID{}
-			}
-			if err := m.StableId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Results = append(m.Results, &CreateTokenResult{})
+			if err := m.Results[len(m.Results)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ControlRegister) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ControlRegister: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ControlRegister: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InstanceId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -8543,33 +20893,82 @@ func (m *ConfigRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InstanceId == nil {
-				m.InstanceId = &ULID{}
-			}
-			if err := m.InstanceId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 3:
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ControlToken) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ControlToken: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ControlToken: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Locations", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -8579,25 +20978,23 @@ func (m *ConfigRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Locations = append(m.Locations, &NetworkLocation{})
-			if err := m.Locations[len(m.Locations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -8623,7 +21020,7 @@ func (m *ConfigRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConfigResponse) Unmarshal(dAtA []byte) error {
+func (m *TokenInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8646,15 +21043,15 @@ func (m *ConfigResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConfigResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: TokenInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConfigResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TokenInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TlsKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -8681,50 +21078,69 @@ func (m *ConfigResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TlsKey = append(m.TlsKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.TlsKey == nil {
-				m.TlsKey = []byte{}
+			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PublicKey == nil {
+				m.PublicKey = []byte{}
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TlsCert", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if byteLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthControl
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TlsCert = append(m.TlsCert[:0], dAtA[iNdEx:postIndex]...)
-			if m.TlsCert == nil {
-				m.TlsCert = []byte{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListAccountsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
 			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TokenPub", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			var byteLen int
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListAccountsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListAccountsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -8734,31 +21150,16 @@ func (m *ConfigResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Limit |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.TokenPub = append(m.TokenPub[:0], dAtA[iNdEx:postIndex]...)
-			if m.TokenPub == nil {
-				m.TokenPub = []byte{}
-			}
-			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field S3AccessKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Marker", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -8768,61 +21169,84 @@ func (m *ConfigResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.S3AccessKey = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field S3SecretKey", wireType)
+			m.Marker = append(m.Marker[:0], dAtA[iNdEx:postIndex]...)
+			if m.Marker == nil {
+				m.Marker = []byte{}
 			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthControl
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.S3SecretKey = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 6:
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListAccountsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListAccountsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListAccountsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field S3Bucket", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Accounts", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -8832,29 +21256,31 @@ func (m *ConfigResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.S3Bucket = string(dAtA[iNdEx:postIndex])
+			m.Accounts = append(m.Accounts, &Account{})
+			if err := m.Accounts[len(m.Accounts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 7:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ImageTag", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NextMarker", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -8864,23 +21290,25 @@ func (m *ConfigResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ImageTag = string(dAtA[iNdEx:postIndex])
+			m.NextMarker = append(m.NextMarker[:0], dAtA[iNdEx:postIndex]...)
+			if m.NextMarker == nil {
+				m.NextMarker = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -8906,7 +21334,7 @@ func (m *ConfigResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CentralActivity) Unmarshal(dAtA []byte) error {
+func (m *ManagementClient) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8929,15 +21357,15 @@ func (m *CentralActivity) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CentralActivity: wiretype end group for non-group")
+			return fmt.Errorf("proto: ManagementClient: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CentralActivity: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ManagementClient: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AccountServices", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8964,16 +21392,18 @@ func (m *CentralActivity) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AccountServices = append(m.AccountServices, &AccountServices{})
-			if err := m.AccountServices[len(m.AccountServices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Id == nil {
+				m.Id = &ULID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RequestStats", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -8983,15 +21413,27 @@ func (m *CentralActivity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.RequestStats = bool(v != 0)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewLabelLinks", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9018,10 +21460,10 @@ func (m *CentralActivity) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.NewLabelLinks == nil {
-				m.NewLabelLinks = &LabelLinks{}
+			if m.CreatedAt == nil {
+				m.CreatedAt = &Timestamp{}
 			}
-			if err := m.NewLabelLinks.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.CreatedAt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -9049,7 +21491,7 @@ func (m *CentralActivity) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HubActivity) Unmarshal(dAtA []byte) error {
+func (m *ListManagementClientsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9072,17 +21514,17 @@ func (m *HubActivity) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HubActivity: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListManagementClientsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HubActivity: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListManagementClientsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HubReg", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
 			}
-			var msglen int
+			m.Limit = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -9092,33 +21534,16 @@ func (m *HubActivity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Limit |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.HubReg == nil {
-				m.HubReg = &HubActivity_HubRegistration{}
-			}
-			if err := m.HubReg.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SentAt", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Marker", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -9128,31 +21553,82 @@ func (m *HubActivity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.SentAt == nil {
-				m.SentAt = &Timestamp{}
+			m.Marker = append(m.Marker[:0], dAtA[iNdEx:postIndex]...)
+			if m.Marker == nil {
+				m.Marker = []byte{}
 			}
-			if err := m.SentAt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 3:
+			if skippy < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthControl
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListManagementClientsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListManagementClientsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListManagementClientsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Clients", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9179,18 +21655,16 @@ func (m *HubActivity) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Stats == nil {
-				m.Stats = &HubActivity_HubStats{}
-			}
-			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Clients = append(m.Clients, &ManagementClient{})
+			if err := m.Clients[len(m.Clients)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flow", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NextMarker", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -9200,24 +21674,24 @@ func (m *HubActivity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Flow = append(m.Flow, &FlowRecord{})
-			if err := m.Flow[len(m.Flow)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.NextMarker = append(m.NextMarker[:0], dAtA[iNdEx:postIndex]...)
+			if m.NextMarker == nil {
+				m.NextMarker = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -9244,7 +21718,7 @@ func (m *HubActivity) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HubActivity_HubRegistration) Unmarshal(dAtA []byte) error {
+func (m *ListTokensRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9267,17 +21741,17 @@ func (m *HubActivity_HubRegistration) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HubRegistration: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListTokensRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HubRegistration: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListTokensRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hub", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
 			}
-			var msglen int
+			m.Limit = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -9287,69 +21761,16 @@ func (m *HubActivity_HubRegistration) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Limit |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Hub == nil {
-				m.Hub = &ULID{}
-			}
-			if err := m.Hub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StableHub", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.StableHub == nil {
-				m.StableHub = &ULID{}
-			}
-			if err := m.StableHub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Locations", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Marker", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -9359,24 +21780,24 @@ func (m *HubActivity_HubRegistration) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Locations = append(m.Locations, &NetworkLocation{})
-			if err := m.Locations[len(m.Locations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Marker = append(m.Marker[:0], dAtA[iNdEx:postIndex]...)
+			if m.Marker == nil {
+				m.Marker = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -9403,7 +21824,7 @@ func (m *HubActivity_HubRegistration) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HubActivity_HubStats) Unmarshal(dAtA []byte) error {
+func (m *IssuedToken) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9426,17 +21847,17 @@ func (m *HubActivity_HubStats) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HubStats: wiretype end group for non-group")
+			return fmt.Errorf("proto: IssuedToken: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HubStats: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IssuedToken: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AgentConnections", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenId", wireType)
 			}
-			m.AgentConnections = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -9446,16 +21867,33 @@ func (m *HubActivity_HubStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.AgentConnections |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TokenId == nil {
+				m.TokenId = &ULID{}
+			}
+			if err := m.TokenId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AccountsSeen", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
 			}
-			m.AccountsSeen = 0
+			m.Role = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -9465,16 +21903,16 @@ func (m *HubActivity_HubStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.AccountsSeen |= int64(b&0x7F) << shift
+				m.Role |= TokenRole(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AccountsCached", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
 			}
-			m.AccountsCached = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -9484,16 +21922,33 @@ func (m *HubActivity_HubStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.AccountsCached |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Account == nil {
+				m.Account = &Account{}
+			}
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CachedBytes", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
 			}
-			m.CachedBytes = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -9503,14 +21958,29 @@ func (m *HubActivity_HubStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CachedBytes |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Capabilities = append(m.Capabilities, TokenCapability{})
+			if err := m.Capabilities[len(m.Capabilities)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastSuccessfulUpdate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidUntil", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9537,16 +22007,16 @@ func (m *HubActivity_HubStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.LastSuccessfulUpdate == nil {
-				m.LastSuccessfulUpdate = &Timestamp{}
+			if m.ValidUntil == nil {
+				m.ValidUntil = &Timestamp{}
 			}
-			if err := m.LastSuccessfulUpdate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ValidUntil.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastAttemptedUpdate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IssuedAt", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9573,10 +22043,10 @@ func (m *HubActivity_HubStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.LastAttemptedUpdate == nil {
-				m.LastAttemptedUpdate = &Timestamp{}
+			if m.IssuedAt == nil {
+				m.IssuedAt = &Timestamp{}
 			}
-			if err := m.LastAttemptedUpdate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.IssuedAt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -9604,7 +22074,7 @@ func (m *HubActivity_HubStats) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HubInfo) Unmarshal(dAtA []byte) error {
+func (m *ListTokensResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9627,15 +22097,15 @@ func (m *HubInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HubInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListTokensResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HubInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListTokensResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tokens", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9662,18 +22132,16 @@ func (m *HubInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &ULID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Tokens = append(m.Tokens, &IssuedToken{})
+			if err := m.Tokens[len(m.Tokens)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Locations", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NextMarker", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -9683,24 +22151,24 @@ func (m *HubInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Locations = append(m.Locations, &NetworkLocation{})
-			if err := m.Locations[len(m.Locations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.NextMarker = append(m.NextMarker[:0], dAtA[iNdEx:postIndex]...)
+			if m.NextMarker == nil {
+				m.NextMarker = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -9727,7 +22195,7 @@ func (m *HubInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListOfHubs) Unmarshal(dAtA []byte) error {
+func (m *WhoAmIResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9750,15 +22218,34 @@ func (m *ListOfHubs) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListOfHubs: wiretype end group for non-group")
+			return fmt.Errorf("proto: WhoAmIResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListOfHubs: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: WhoAmIResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+			}
+			m.Role = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Role |= TokenRole(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hubs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenId", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9785,67 +22272,52 @@ func (m *ListOfHubs) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Hubs = append(m.Hubs, &HubInfo{})
-			if err := m.Hubs[len(m.Hubs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.TokenId == nil {
+				m.TokenId = &ULID{}
+			}
+			if err := m.TokenId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipControl(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
 			}
-			if skippy < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *HubSync) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowControl
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.Account == nil {
+				m.Account = &Account{}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: HubSync: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HubSync: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9872,18 +22344,16 @@ func (m *HubSync) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &ULID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Capabilities = append(m.Capabilities, TokenCapability{})
+			if err := m.Capabilities[len(m.Capabilities)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StableId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessNamespace", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -9893,31 +22363,27 @@ func (m *HubSync) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.StableId == nil {
-				m.StableId = &ULID{}
-			}
-			if err := m.StableId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.AccessNamespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidUntil", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9944,8 +22410,10 @@ func (m *HubSync) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Services = append(m.Services, &ServiceRequest{})
-			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.ValidUntil == nil {
+				m.ValidUntil = &Timestamp{}
+			}
+			if err := m.ValidUntil.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -9973,7 +22441,7 @@ func (m *HubSync) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HubSyncResponse) Unmarshal(dAtA []byte) error {
+func (m *TokenStatusResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9996,17 +22464,17 @@ func (m *HubSyncResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HubSyncResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: TokenStatusResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HubSyncResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TokenStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ServiceCount", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenId", wireType)
 			}
-			m.ServiceCount = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -10016,67 +22484,31 @@ func (m *HubSyncResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ServiceCount |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipControl(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *HubRegisterRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowControl
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: HubRegisterRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HubRegisterRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			if m.TokenId == nil {
+				m.TokenId = &ULID{}
+			}
+			if err := m.TokenId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StableId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NotBefore", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10103,16 +22535,16 @@ func (m *HubRegisterRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.StableId == nil {
-				m.StableId = &ULID{}
+			if m.NotBefore == nil {
+				m.NotBefore = &Timestamp{}
 			}
-			if err := m.StableId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.NotBefore.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InstanceId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidUntil", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10139,16 +22571,56 @@ func (m *HubRegisterRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InstanceId == nil {
-				m.InstanceId = &ULID{}
+			if m.ValidUntil == nil {
+				m.ValidUntil = &Timestamp{}
 			}
-			if err := m.InstanceId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ValidUntil.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Renewable", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Renewable = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WithinRenewalWindow", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.WithinRenewalWindow = bool(v != 0)
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Locations", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RenewAfter", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10175,8 +22647,10 @@ func (m *HubRegisterRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Locations = append(m.Locations, &NetworkLocation{})
-			if err := m.Locations[len(m.Locations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.RenewAfter == nil {
+				m.RenewAfter = &Timestamp{}
+			}
+			if err := m.RenewAfter.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -10204,7 +22678,7 @@ func (m *HubRegisterRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HubRegisterResponse) Unmarshal(dAtA []byte) error {
+func (m *ExportRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10227,15 +22701,15 @@ func (m *HubRegisterResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HubRegisterResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExportRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HubRegisterResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ControlTime", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10262,10 +22736,10 @@ func (m *HubRegisterResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ControlTime == nil {
-				m.ControlTime = &Timestamp{}
+			if m.Account == nil {
+				m.Account = &Account{}
 			}
-			if err := m.ControlTime.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -10293,7 +22767,7 @@ func (m *HubRegisterResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HubDisconnectRequest) Unmarshal(dAtA []byte) error {
+func (m *AccountConfig) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10316,15 +22790,15 @@ func (m *HubDisconnectRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HubDisconnectRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: AccountConfig: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HubDisconnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AccountConfig: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StableId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10351,16 +22825,16 @@ func (m *HubDisconnectRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.StableId == nil {
-				m.StableId = &ULID{}
+			if m.Account == nil {
+				m.Account = &Account{}
 			}
-			if err := m.StableId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InstanceId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Limits", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10387,71 +22861,52 @@ func (m *HubDisconnectRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InstanceId == nil {
-				m.InstanceId = &ULID{}
+			if m.Limits == nil {
+				m.Limits = &Account_Limits{}
 			}
-			if err := m.InstanceId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Limits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipControl(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelLinks", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthControl
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ServiceTokenRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowControl
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.LabelLinks = append(m.LabelLinks, &LabelLink{})
+			if err := m.LabelLinks[len(m.LabelLinks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ServiceTokenRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ServiceTokenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -10461,23 +22916,25 @@ func (m *ServiceTokenRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Namespace = string(dAtA[iNdEx:postIndex])
+			m.Services = append(m.Services, &ServiceRequest{})
+			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -10503,7 +22960,7 @@ func (m *ServiceTokenRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ServiceTokenResponse) Unmarshal(dAtA []byte) error {
+func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10526,17 +22983,17 @@ func (m *ServiceTokenResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ServiceTokenResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ImportRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ServiceTokenResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ImportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Config", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -10546,23 +23003,27 @@ func (m *ServiceTokenResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Token = string(dAtA[iNdEx:postIndex])
+			if m.Config == nil {
+				m.Config = &AccountConfig{}
+			}
+			if err := m.Config.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -10588,7 +23049,7 @@ func (m *ServiceTokenResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListServicesRequest) Unmarshal(dAtA []byte) error {
+func (m *ConnectedHub) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10611,17 +23072,68 @@ func (m *ListServicesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListServicesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConnectedHub: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListServicesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConnectedHub: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hub", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hub = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dropped", wireType)
+			}
+			m.Dropped = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Dropped |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueueDepth", wireType)
 			}
-			var msglen int
+			m.QueueDepth = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -10631,28 +23143,30 @@ func (m *ListServicesRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.QueueDepth |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Account == nil {
-				m.Account = &Account{}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxQueueDepth", wireType)
 			}
-			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.MaxQueueDepth = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxQueueDepth |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipControl(dAtA[iNdEx:])
@@ -10677,7 +23191,7 @@ func (m *ListServicesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListServicesResponse) Unmarshal(dAtA []byte) error {
+func (m *ConnectedHubsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10700,15 +23214,15 @@ func (m *ListServicesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListServicesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConnectedHubsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListServicesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConnectedHubsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hubs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10735,8 +23249,8 @@ func (m *ListServicesResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Services = append(m.Services, &Service{})
-			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Hubs = append(m.Hubs, &ConnectedHub{})
+			if err := m.Hubs[len(m.Hubs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -10764,7 +23278,7 @@ func (m *ListServicesResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Service) Unmarshal(dAtA []byte) error {
+func (m *HubFlowCounters) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10787,17 +23301,17 @@ func (m *Service) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Service: wiretype end group for non-group")
+			return fmt.Errorf("proto: HubFlowCounters: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Service: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HubFlowCounters: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hub", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -10807,33 +23321,29 @@ func (m *Service) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &ULID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Hub = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hub", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
 			}
-			var msglen int
+			m.Messages = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -10843,33 +23353,16 @@ func (m *Service) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Messages |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Hub == nil {
-				m.Hub = &ULID{}
-			}
-			if err := m.Hub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bytes", wireType)
 			}
-			var stringLen uint64
+			m.Bytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -10879,63 +23372,67 @@ func (m *Service) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Bytes |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipControl(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthControl
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Type = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FlowCountersResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Labels == nil {
-				m.Labels = &LabelSet{}
-			}
-			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 5:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FlowCountersResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FlowCountersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hubs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10962,8 +23459,8 @@ func (m *Service) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Metadata = append(m.Metadata, &KVPair{})
-			if err := m.Metadata[len(m.Metadata)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Hubs = append(m.Hubs, &HubFlowCounters{})
+			if err := m.Hubs[len(m.Hubs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -10991,7 +23488,7 @@ func (m *Service) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddAccountRequest) Unmarshal(dAtA []byte) error {
+func (m *SetSuspendedRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11014,10 +23511,10 @@ func (m *AddAccountRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddAccountRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetSuspendedRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddAccountRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetSuspendedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -11057,10 +23554,10 @@ func (m *AddAccountRequest) Unmarshal(dAtA []byte) error {
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Limits", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Suspended", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -11070,28 +23567,12 @@ func (m *AddAccountRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Limits == nil {
-				m.Limits = &Account_Limits{}
-			}
-			if err := m.Limits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.Suspended = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipControl(dAtA[iNdEx:])
@@ -11113,79 +23594,43 @@ func (m *AddAccountRequest) Unmarshal(dAtA []byte) error {
 
 	if iNdEx > l {
 		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *AddLabelLinkRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowControl
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: AddLabelLinkRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddLabelLinkRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
+	}
+	return nil
+}
+func (m *UnregisterRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowControl
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Labels == nil {
-				m.Labels = &LabelSet{}
-			}
-			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 2:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UnregisterRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UnregisterRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -11195,33 +23640,29 @@ func (m *AddLabelLinkRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Account == nil {
-				m.Account = &Account{}
-			}
-			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cascade", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -11231,28 +23672,12 @@ func (m *AddLabelLinkRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Target == nil {
-				m.Target = &LabelSet{}
-			}
-			if err := m.Target.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.Cascade = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipControl(dAtA[iNdEx:])
@@ -11277,7 +23702,7 @@ func (m *AddLabelLinkRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Noop) Unmarshal(dAtA []byte) error {
+func (m *AllHubsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11300,12 +23725,44 @@ func (m *Noop) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Noop: wiretype end group for non-group")
+			return fmt.Errorf("proto: AllHubsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Noop: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AllHubsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipControl(dAtA[iNdEx:])
@@ -11330,7 +23787,7 @@ func (m *Noop) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RemoveLabelLinkRequest) Unmarshal(dAtA []byte) error {
+func (m *ResolveDebugRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11353,17 +23810,17 @@ func (m *RemoveLabelLinkRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveLabelLinkRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResolveDebugRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveLabelLinkRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResolveDebugRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -11373,33 +23830,29 @@ func (m *RemoveLabelLinkRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Labels == nil {
-				m.Labels = &LabelSet{}
-			}
-			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -11409,27 +23862,55 @@ func (m *RemoveLabelLinkRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Account == nil {
-				m.Account = &Account{}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeploymentId", wireType)
 			}
-			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.DeploymentId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11455,7 +23936,7 @@ func (m *RemoveLabelLinkRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateTokenRequest) Unmarshal(dAtA []byte) error {
+func (m *ResolveDebugResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11478,10 +23959,10 @@ func (m *CreateTokenRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateTokenRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResolveDebugResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateTokenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResolveDebugResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -11522,7 +24003,7 @@ func (m *CreateTokenRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11549,16 +24030,18 @@ func (m *CreateTokenRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Capabilities = append(m.Capabilities, TokenCapability{})
-			if err := m.Capabilities[len(m.Capabilities)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Target == nil {
+				m.Target = &LabelSet{}
+			}
+			if err := m.Target.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ValidDuration", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PathPrefix", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -11568,86 +24051,69 @@ func (m *CreateTokenRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ValidDuration == nil {
-				m.ValidDuration = &Timestamp{}
-			}
-			if err := m.ValidDuration.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.PathPrefix = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipControl(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthControl
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthControl
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CreateTokenResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowControl
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Suspended", wireType)
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Suspended = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewestDeployment", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CreateTokenResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateTokenResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.NewestDeployment = bool(v != 0)
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -11657,23 +24123,25 @@ func (m *CreateTokenResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Token = string(dAtA[iNdEx:postIndex])
+			m.Services = append(m.Services, &ServiceRoute{})
+			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11699,7 +24167,7 @@ func (m *CreateTokenResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ControlRegister) Unmarshal(dAtA []byte) error {
+func (m *TargetSplit) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11722,17 +24190,17 @@ func (m *ControlRegister) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ControlRegister: wiretype end group for non-group")
+			return fmt.Errorf("proto: TargetSplit: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ControlRegister: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TargetSplit: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -11742,24 +24210,47 @@ func (m *ControlRegister) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Namespace = string(dAtA[iNdEx:postIndex])
+			if m.Target == nil {
+				m.Target = &LabelSet{}
+			}
+			if err := m.Target.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Weight", wireType)
+			}
+			m.Weight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowControl
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Weight |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipControl(dAtA[iNdEx:])
@@ -11784,7 +24275,7 @@ func (m *ControlRegister) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ControlToken) Unmarshal(dAtA []byte) error {
+func (m *TargetSplits) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11807,17 +24298,17 @@ func (m *ControlToken) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ControlToken: wiretype end group for non-group")
+			return fmt.Errorf("proto: TargetSplits: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ControlToken: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TargetSplits: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Splits", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -11827,23 +24318,25 @@ func (m *ControlToken) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Token = string(dAtA[iNdEx:postIndex])
+			m.Splits = append(m.Splits, &TargetSplit{})
+			if err := m.Splits[len(m.Splits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11869,7 +24362,7 @@ func (m *ControlToken) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TokenInfo) Unmarshal(dAtA []byte) error {
+func (m *SetLabelLinkSplitsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11892,17 +24385,17 @@ func (m *TokenInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TokenInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetLabelLinkSplitsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TokenInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetLabelLinkSplitsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -11912,84 +24405,33 @@ func (m *TokenInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.PublicKey == nil {
-				m.PublicKey = []byte{}
+			if m.Account == nil {
+				m.Account = &Account{}
 			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipControl(dAtA[iNdEx:])
-			if err != nil {
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthControl
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthControl
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ListAccountsRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowControl
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ListAccountsRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListAccountsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
 			}
-			m.Limit = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -11999,16 +24441,33 @@ func (m *ListAccountsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Limit |= int32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+			if msglen < 0 {
+				return ErrInvalidLengthControl
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthControl
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Labels == nil {
+				m.Labels = &LabelSet{}
+			}
+			if err := m.Labels.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Marker", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Splits", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowControl
@@ -12018,24 +24477,24 @@ func (m *ListAccountsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthControl
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthControl
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Marker = append(m.Marker[:0], dAtA[iNdEx:postIndex]...)
-			if m.Marker == nil {
-				m.Marker = []byte{}
+			m.Splits = append(m.Splits, &TargetSplit{})
+			if err := m.Splits[len(m.Splits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -12062,7 +24521,7 @@ func (m *ListAccountsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListAccountsResponse) Unmarshal(dAtA []byte) error {
+func (m *InvalidateRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12085,15 +24544,15 @@ func (m *ListAccountsResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListAccountsResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: InvalidateRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListAccountsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InvalidateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Accounts", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12120,43 +24579,11 @@ func (m *ListAccountsResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Accounts = append(m.Accounts, &Account{})
-			if err := m.Accounts[len(m.Accounts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NextMarker", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowControl
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthControl
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthControl
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			if m.Account == nil {
+				m.Account = &Account{}
 			}
-			m.NextMarker = append(m.NextMarker[:0], dAtA[iNdEx:postIndex]...)
-			if m.NextMarker == nil {
-				m.NextMarker = []byte{}
+			if err := m.Account.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
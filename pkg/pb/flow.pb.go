@@ -155,6 +155,7 @@ type FlowRecord struct {
 	Agent    *FlowRecord_AgentConnection `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
 	Stream   *FlowStream                 `protobuf:"bytes,2,opt,name=stream,proto3" json:"stream,omitempty"`
 	HubStats *FlowRecord_HubStats        `protobuf:"bytes,3,opt,name=hub_stats,json=hubStats,proto3" json:"hub_stats,omitempty"`
+	Sequence int64                       `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
 }
 
 func (m *FlowRecord) Reset()      { *m = FlowRecord{} }
@@ -210,6 +211,13 @@ func (m *FlowRecord) GetHubStats() *FlowRecord_HubStats {
 	return nil
 }
 
+func (m *FlowRecord) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
 type FlowRecord_AgentConnection struct {
 	HubId         *ULID      `protobuf:"bytes,1,opt,name=hub_id,json=hubId,proto3" json:"hub_id,omitempty"`
 	AgentId       *ULID      `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
@@ -458,47 +466,46 @@ func init() {
 func init() { proto.RegisterFile("flow.proto", fileDescriptor_bb3fc33c49933823) }
 
 var fileDescriptor_bb3fc33c49933823 = []byte{
-	// 637 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x94, 0xcb, 0x6e, 0xd3, 0x4e,
-	0x14, 0xc6, 0xed, 0xb8, 0x49, 0x9c, 0x93, 0x4b, 0xa5, 0xf9, 0x2f, 0xfe, 0x56, 0x90, 0xa6, 0x6d,
-	0xa0, 0xd0, 0x05, 0x8a, 0x44, 0xe9, 0xae, 0xab, 0xb4, 0x08, 0x11, 0xa9, 0xb0, 0x98, 0x94, 0x75,
-	0x34, 0x8e, 0x87, 0x26, 0x92, 0xed, 0x31, 0x9e, 0x71, 0x5b, 0x76, 0x3c, 0x02, 0x6f, 0x00, 0x4b,
-	0x1e, 0x81, 0x47, 0x60, 0xd9, 0x65, 0x97, 0xd4, 0xdd, 0xb0, 0xec, 0x23, 0xa0, 0xb9, 0x98, 0x46,
-	0x51, 0xb9, 0x6c, 0xd8, 0xe5, 0x9c, 0xef, 0x77, 0x3c, 0x33, 0xdf, 0x77, 0x14, 0x80, 0x37, 0x31,
-	0x3f, 0x1b, 0x66, 0x39, 0x97, 0x1c, 0xd5, 0xb2, 0xb0, 0x0f, 0x45, 0xbc, 0x88, 0x4c, 0xdd, 0x5f,
-	0x97, 0x8b, 0x84, 0x09, 0x49, 0x93, 0xcc, 0x36, 0xda, 0x31, 0x0d, 0x59, 0x6c, 0x8b, 0x2e, 0x9d,
-	0xcd, 0x78, 0x91, 0x4a, 0x53, 0x0e, 0x3e, 0x7a, 0x00, 0xcf, 0x63, 0x7e, 0x36, 0x91, 0x39, 0xa3,
-	0x09, 0xda, 0x82, 0xa6, 0xfa, 0xf2, 0x74, 0x11, 0x05, 0xee, 0xa6, 0xbb, 0xd3, 0xde, 0xf5, 0x87,
-	0x59, 0x38, 0x7c, 0x7d, 0x34, 0x7e, 0x46, 0x1a, 0x4a, 0x18, 0x47, 0x68, 0x03, 0x1a, 0xf3, 0x22,
-	0x54, 0x44, 0x6d, 0x85, 0xa8, 0xcf, 0x8b, 0x70, 0x1c, 0xa1, 0xfb, 0xe0, 0xd3, 0x13, 0x96, 0x4a,
-	0x85, 0x78, 0x2b, 0x48, 0x53, 0x2b, 0xe3, 0x08, 0x3d, 0x02, 0x10, 0x2c, 0x3f, 0x5d, 0xcc, 0x98,
-	0xc2, 0xd6, 0x56, 0xb0, 0x96, 0xd5, 0xc6, 0x11, 0xda, 0x86, 0xa6, 0xbd, 0x71, 0x50, 0xd7, 0x54,
-	0x5b, 0x51, 0x23, 0xd3, 0x22, 0x95, 0x86, 0x1e, 0x40, 0x43, 0xbf, 0x52, 0x04, 0x0d, 0x4d, 0x75,
-	0x14, 0x75, 0xa4, 0x3a, 0x13, 0x26, 0x89, 0xd5, 0xd0, 0x63, 0x00, 0x21, 0x69, 0x2e, 0x59, 0x34,
-	0xa5, 0x32, 0x00, 0x4d, 0x76, 0x15, 0x79, 0x5c, 0x59, 0x46, 0x5a, 0x16, 0x18, 0x49, 0xb4, 0x03,
-	0x3e, 0x4b, 0x23, 0xc3, 0xb6, 0xef, 0x62, 0x9b, 0x5a, 0x1e, 0x49, 0xb4, 0x05, 0x9d, 0xb4, 0x48,
-	0xa6, 0x09, 0x13, 0x82, 0x9e, 0x30, 0x11, 0x74, 0x36, 0xdd, 0x1d, 0x8f, 0xb4, 0xd3, 0x22, 0x79,
-	0x69, 0x5b, 0xe8, 0x1e, 0xb4, 0x14, 0x12, 0xbe, 0x93, 0x4c, 0x04, 0x5d, 0xad, 0xfb, 0x69, 0x91,
-	0x1c, 0xa8, 0x1a, 0xf5, 0xc1, 0x8f, 0x8a, 0x9c, 0xca, 0x05, 0x4f, 0x83, 0x9e, 0xd1, 0xaa, 0x7a,
-	0xf0, 0x65, 0xcd, 0x24, 0x44, 0xd8, 0x8c, 0xe7, 0x11, 0xda, 0x83, 0xba, 0xf6, 0xd0, 0xe6, 0x83,
-	0xd5, 0x8d, 0x6e, 0xe5, 0xe1, 0x48, 0x69, 0x87, 0x3c, 0x4d, 0xd9, 0x4c, 0x4d, 0x13, 0x03, 0xa3,
-	0x87, 0xd0, 0x10, 0x3a, 0x61, 0x1b, 0x5a, 0xaf, 0x1a, 0x33, 0xb9, 0x13, 0xab, 0xa2, 0x3d, 0x68,
-	0xa9, 0x70, 0x85, 0xa4, 0x52, 0xd8, 0xf0, 0xfe, 0x5f, 0x39, 0xe1, 0x45, 0x11, 0x4e, 0x94, 0x4c,
-	0xfc, 0xb9, 0xfd, 0xd5, 0xff, 0x54, 0x83, 0xf5, 0x95, 0x83, 0x97, 0xd6, 0xc4, 0xfd, 0xf3, 0x9a,
-	0xd4, 0x7e, 0xb5, 0x26, 0x4b, 0xe9, 0x7b, 0xbf, 0x49, 0xff, 0x1f, 0xe7, 0x6a, 0xb7, 0xd1, 0xe4,
-	0x5a, 0xd7, 0xb9, 0x4e, 0x6c, 0x0b, 0x6d, 0x43, 0x8f, 0xce, 0xe4, 0xe2, 0x94, 0x4d, 0x8d, 0x85,
-	0x55, 0xb8, 0x5d, 0xd3, 0x35, 0xfe, 0x8a, 0xbe, 0x00, 0xbf, 0x32, 0xee, 0x6f, 0xac, 0xb1, 0xd3,
-	0x53, 0xed, 0x83, 0xd0, 0xfe, 0x78, 0xa4, 0x63, 0x9a, 0xda, 0x69, 0xa1, 0xee, 0x26, 0xb9, 0xa4,
-	0x71, 0xc5, 0x78, 0x66, 0xe7, 0x74, 0xcf, 0x20, 0x83, 0x7d, 0x58, 0x57, 0xc1, 0x1d, 0xf3, 0x6c,
-	0x92, 0xd2, 0x4c, 0xcc, 0xb9, 0x7a, 0x7b, 0x33, 0xd7, 0x39, 0x8a, 0xc0, 0xdd, 0xf4, 0xee, 0xd8,
-	0x84, 0x4a, 0x1e, 0x3c, 0x81, 0x9e, 0x1d, 0x26, 0xec, 0x6d, 0xc1, 0x84, 0x44, 0x1b, 0xd0, 0x4e,
-	0xe8, 0xf9, 0xf4, 0x76, 0x5e, 0x99, 0x01, 0x09, 0x3d, 0x37, 0x9b, 0x21, 0x76, 0x5f, 0xfd, 0x3c,
-	0x8f, 0xb0, 0x8c, 0xe7, 0x92, 0xe5, 0x68, 0x1f, 0x7a, 0x87, 0x45, 0x9e, 0xb3, 0x54, 0x5a, 0x05,
-	0xa1, 0xea, 0xc0, 0xdb, 0x2f, 0xf7, 0xff, 0x5b, 0xea, 0x55, 0x57, 0x1d, 0x38, 0x07, 0x7b, 0x17,
-	0x57, 0xd8, 0xb9, 0xbc, 0xc2, 0xce, 0xcd, 0x15, 0x76, 0xdf, 0x97, 0xd8, 0xfd, 0x5c, 0x62, 0xf7,
-	0x6b, 0x89, 0xdd, 0x8b, 0x12, 0xbb, 0xdf, 0x4a, 0xec, 0x7e, 0x2f, 0xb1, 0x73, 0x53, 0x62, 0xf7,
-	0xc3, 0x35, 0x76, 0x2e, 0xae, 0xb1, 0x73, 0x79, 0x8d, 0x9d, 0xb0, 0xa1, 0xff, 0xd9, 0x9e, 0xfe,
-	0x08, 0x00, 0x00, 0xff, 0xff, 0x09, 0xf8, 0x4d, 0xc4, 0x24, 0x05, 0x00, 0x00,
+	// 623 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb5, 0x54, 0xcb, 0x6e, 0xd3, 0x40,
+	0x14, 0x4d, 0xe2, 0xc6, 0x71, 0xae, 0xf3, 0x90, 0x86, 0x05, 0x96, 0x91, 0x02, 0x0d, 0x8f, 0x76,
+	0x81, 0x22, 0x51, 0xba, 0xeb, 0x2a, 0x2d, 0x42, 0x54, 0x2a, 0x2c, 0x9c, 0xb2, 0xb6, 0xc6, 0xf6,
+	0x40, 0x22, 0x25, 0xb6, 0xf1, 0x8c, 0x0b, 0xec, 0xf8, 0x04, 0xfe, 0x00, 0x96, 0x7c, 0x0a, 0xcb,
+	0x2c, 0x58, 0x74, 0x49, 0xcb, 0x86, 0x25, 0x9f, 0xc0, 0x9d, 0x87, 0x49, 0x14, 0x95, 0xc7, 0x86,
+	0xc5, 0x55, 0x72, 0xcf, 0x39, 0x33, 0xf7, 0xce, 0x3d, 0x57, 0x06, 0x78, 0x31, 0xcf, 0x5e, 0x8f,
+	0xf2, 0x22, 0x13, 0x19, 0x69, 0xe4, 0x91, 0x0f, 0xe5, 0x7c, 0x96, 0xe8, 0xdc, 0xef, 0x8b, 0xd9,
+	0x82, 0x71, 0x41, 0x17, 0xb9, 0x01, 0xdc, 0x39, 0x8d, 0xd8, 0xdc, 0x24, 0x5d, 0x1a, 0xc7, 0x59,
+	0x99, 0x0a, 0x9d, 0x0e, 0x3f, 0x58, 0x00, 0x8f, 0xf1, 0xae, 0x89, 0x28, 0x18, 0x5d, 0x90, 0x6d,
+	0x68, 0xc9, 0x9b, 0xc3, 0x59, 0xe2, 0xd5, 0x6f, 0xd5, 0x77, 0xdd, 0x3d, 0x67, 0x94, 0x47, 0xa3,
+	0xe7, 0x27, 0xc7, 0x8f, 0x02, 0x5b, 0x12, 0xc7, 0x09, 0xb9, 0x09, 0xf6, 0xb4, 0x8c, 0xa4, 0xa2,
+	0xb1, 0xa1, 0x68, 0x22, 0x8e, 0x82, 0xdb, 0xe0, 0xd0, 0x97, 0x2c, 0x15, 0x52, 0x62, 0x6d, 0x48,
+	0x5a, 0x8a, 0x41, 0xd1, 0x0e, 0x00, 0x67, 0xc5, 0xd9, 0x2c, 0x66, 0x52, 0xb6, 0xb5, 0x21, 0x6b,
+	0x1b, 0x0e, 0x85, 0x77, 0xa1, 0x65, 0x3a, 0xf6, 0x9a, 0x4a, 0xe5, 0x4a, 0xd5, 0x58, 0x43, 0x41,
+	0xc5, 0x91, 0x3b, 0x60, 0xab, 0x57, 0x72, 0xcf, 0x56, 0xaa, 0x8e, 0x54, 0x9d, 0x48, 0x64, 0xc2,
+	0x44, 0x60, 0x38, 0x72, 0x1f, 0xab, 0x0a, 0x5a, 0x08, 0x96, 0x84, 0x54, 0x78, 0xa0, 0x94, 0x5d,
+	0xa9, 0x3c, 0xad, 0x46, 0x86, 0xa5, 0xb5, 0x60, 0x2c, 0xc8, 0x2e, 0x38, 0x2c, 0x4d, 0xb4, 0xd6,
+	0xbd, 0x4a, 0xdb, 0x52, 0x34, 0x2a, 0xb7, 0xa1, 0x93, 0x96, 0x8b, 0x10, 0x71, 0x8e, 0x0f, 0xe4,
+	0x5e, 0x07, 0xd5, 0x56, 0xe0, 0x22, 0xf6, 0xd4, 0x40, 0xe4, 0x06, 0xb4, 0xa5, 0x24, 0x7a, 0x2b,
+	0x90, 0xef, 0x2a, 0xde, 0x41, 0xe0, 0x50, 0xe6, 0xc4, 0x07, 0x27, 0x29, 0x0b, 0x2a, 0x66, 0x59,
+	0xea, 0xf5, 0x34, 0x57, 0xe5, 0xc3, 0x2f, 0x5b, 0xda, 0xa1, 0x80, 0xc5, 0x59, 0x91, 0x90, 0x7d,
+	0x68, 0xaa, 0x19, 0x1a, 0x7f, 0x06, 0xb2, 0xa3, 0x15, 0x3d, 0x1a, 0x4b, 0xee, 0x28, 0x4b, 0x53,
+	0x16, 0xcb, 0xd3, 0x81, 0x16, 0x93, 0x7b, 0x60, 0x73, 0xe5, 0xb0, 0x31, 0xad, 0x57, 0x1d, 0xd3,
+	0xbe, 0x07, 0x86, 0xc5, 0xdb, 0xdb, 0xd2, 0x5c, 0x7c, 0x9e, 0xe0, 0xc6, 0xbc, 0xeb, 0x1b, 0x15,
+	0x9e, 0x94, 0xd1, 0x44, 0xd2, 0x81, 0x33, 0x35, 0xff, 0x64, 0xfb, 0x9c, 0xbd, 0x2a, 0x59, 0x1a,
+	0x33, 0x65, 0x25, 0xb6, 0x5f, 0xe5, 0xfe, 0xc7, 0x06, 0xf4, 0x37, 0x9a, 0x5a, 0x5b, 0xa1, 0xfa,
+	0xdf, 0x57, 0xa8, 0xf1, 0xbb, 0x15, 0x5a, 0xdb, 0x0c, 0xeb, 0x0f, 0x9b, 0xf1, 0x9f, 0x3d, 0x37,
+	0x9b, 0xaa, 0x3d, 0x6f, 0x2a, 0xcf, 0x27, 0x06, 0xc2, 0x0e, 0x7b, 0x14, 0x5f, 0x7c, 0xc6, 0x42,
+	0x3d, 0xde, 0xca, 0xf8, 0xae, 0x46, 0xf5, 0xec, 0xb9, 0xcf, 0xc1, 0xa9, 0x86, 0xfa, 0x2f, 0xa3,
+	0x31, 0xa7, 0x43, 0x35, 0x07, 0xae, 0xe6, 0x63, 0x05, 0x1d, 0x0d, 0xaa, 0x49, 0x73, 0xd9, 0x9b,
+	0xc8, 0x04, 0x9d, 0x57, 0x1a, 0x4b, 0xef, 0xa3, 0xc2, 0xb4, 0x64, 0x78, 0x00, 0x7d, 0x69, 0xea,
+	0x69, 0x96, 0x4f, 0x52, 0x9a, 0xf3, 0x69, 0x26, 0xdf, 0xde, 0x2a, 0x94, 0xc7, 0x1c, 0x8b, 0x5b,
+	0x57, 0x6c, 0x49, 0x45, 0x0f, 0x1f, 0x40, 0xcf, 0x1c, 0x0e, 0xa4, 0xcf, 0x5c, 0x60, 0xdf, 0xee,
+	0x82, 0xbe, 0x09, 0x57, 0xe7, 0xe5, 0x30, 0x00, 0x21, 0xbd, 0x35, 0x7c, 0xef, 0xd9, 0xaf, 0x7a,
+	0x01, 0xcb, 0x33, 0x9c, 0x76, 0x41, 0x0e, 0xa0, 0x77, 0x54, 0x16, 0x05, 0xb6, 0x63, 0x18, 0x42,
+	0xaa, 0x82, 0xab, 0x9b, 0xfd, 0x6b, 0x6b, 0x58, 0xd5, 0xea, 0xb0, 0x76, 0xb8, 0xbf, 0xbc, 0x18,
+	0xd4, 0xce, 0x31, 0x7e, 0x5c, 0x0c, 0xea, 0xef, 0x2e, 0x07, 0xf5, 0x4f, 0x18, 0x9f, 0x31, 0x96,
+	0x18, 0x5f, 0x31, 0xbe, 0x5f, 0x22, 0x87, 0xbf, 0xef, 0xbf, 0x0d, 0x6a, 0x4b, 0x8c, 0x73, 0x8c,
+	0xc8, 0x56, 0x5f, 0xbd, 0x87, 0x3f, 0x01, 0x95, 0x23, 0x7a, 0x35, 0x40, 0x05, 0x00, 0x00,
 }
 
 func (this *FlowStream) Equal(that interface{}) bool {
@@ -583,6 +590,9 @@ func (this *FlowRecord) Equal(that interface{}) bool {
 	if !this.HubStats.Equal(that1.HubStats) {
 		return false
 	}
+	if this.Sequence != that1.Sequence {
+		return false
+	}
 	return true
 }
 func (this *FlowRecord_AgentConnection) Equal(that interface{}) bool {
@@ -750,7 +760,7 @@ func (this *FlowRecord) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 7)
+	s := make([]string, 0, 8)
 	s = append(s, "&pb.FlowRecord{")
 	if this.Agent != nil {
 		s = append(s, "Agent: "+fmt.Sprintf("%#v", this.Agent)+",\n")
@@ -761,6 +771,7 @@ func (this *FlowRecord) GoString() string {
 	if this.HubStats != nil {
 		s = append(s, "HubStats: "+fmt.Sprintf("%#v", this.HubStats)+",\n")
 	}
+	s = append(s, "Sequence: "+fmt.Sprintf("%#v", this.Sequence)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -1069,6 +1080,11 @@ func (m *FlowRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Sequence != 0 {
+		i = encodeVarintFlow(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.HubStats != nil {
 		{
 			size, err := m.HubStats.MarshalToSizedBuffer(dAtA[:i])
@@ -1390,6 +1406,9 @@ func (m *FlowRecord) Size() (n int) {
 		l = m.HubStats.Size()
 		n += 1 + l + sovFlow(uint64(l))
 	}
+	if m.Sequence != 0 {
+		n += 1 + sovFlow(uint64(m.Sequence))
+	}
 	return n
 }
 
@@ -1508,6 +1527,7 @@ func (this *FlowRecord) String() string {
 		`Agent:` + strings.Replace(fmt.Sprintf("%v", this.Agent), "FlowRecord_AgentConnection", "FlowRecord_AgentConnection", 1) + `,`,
 		`Stream:` + strings.Replace(this.Stream.String(), "FlowStream", "FlowStream", 1) + `,`,
 		`HubStats:` + strings.Replace(fmt.Sprintf("%v", this.HubStats), "FlowRecord_HubStats", "FlowRecord_HubStats", 1) + `,`,
+		`Sequence:` + fmt.Sprintf("%v", this.Sequence) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -2108,6 +2128,25 @@ func (m *FlowRecord) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFlow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFlow(dAtA[iNdEx:])
@@ -0,0 +1,255 @@
+package pb
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+var ErrBadLabelExpr = errors.New("bad label expression")
+
+// LabelExpr is a boolean expression over the labels of a LabelSet, such as
+// "env=prod and (region=us or region=eu)". The grammar is:
+//
+//	expr   = term { "or" term }
+//	term   = factor { "and" factor }
+//	factor = "not" factor | "(" expr ")" | label
+//	label  = name [ "=" value ]
+//
+// "&&", "||" and "!" can be used in place of and, or, and not, and the
+// keywords are matched regardless of case. A label with a value matches a set
+// that has that label, and one without a value matches a set that has the
+// label with any value. Names and values are compared ignoring case, like
+// LabelSet.Matches. Names and values can't contain spaces, commas,
+// parentheses, or any of "=!&|".
+type LabelExpr struct {
+	src  string
+	root labelExprNode
+}
+
+type labelExprNode interface {
+	matches(ls *LabelSet) bool
+}
+
+type labelExprAnd struct{ a, b labelExprNode }
+type labelExprOr struct{ a, b labelExprNode }
+type labelExprNot struct{ a labelExprNode }
+
+type labelExprLabel struct {
+	name, value string
+	anyValue    bool
+}
+
+func (n *labelExprAnd) matches(ls *LabelSet) bool { return n.a.matches(ls) && n.b.matches(ls) }
+func (n *labelExprOr) matches(ls *LabelSet) bool  { return n.a.matches(ls) || n.b.matches(ls) }
+func (n *labelExprNot) matches(ls *LabelSet) bool { return !n.a.matches(ls) }
+
+func (n *labelExprLabel) matches(ls *LabelSet) bool {
+	if ls == nil {
+		return false
+	}
+
+	for _, lbl := range ls.Labels {
+		if !strings.EqualFold(lbl.Name, n.name) {
+			continue
+		}
+
+		if n.anyValue || strings.EqualFold(lbl.Value, n.value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseLabelExpr compiles s into a LabelExpr, returning ErrBadLabelExpr if it
+// doesn't follow the grammar.
+func ParseLabelExpr(s string) (*LabelExpr, error) {
+	p := &labelExprParser{toks: lexLabelExpr(s)}
+
+	root, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok != "" {
+		return nil, errors.Wrapf(ErrBadLabelExpr, "unexpected %q in %q", tok, s)
+	}
+
+	return &LabelExpr{src: s, root: root}, nil
+}
+
+// Matches reports if ls satisfies the expression.
+func (e *LabelExpr) Matches(ls *LabelSet) bool {
+	return e.root.matches(ls)
+}
+
+func (e *LabelExpr) String() string {
+	return e.src
+}
+
+const labelExprSpecial = "()=!&|,"
+
+// lexLabelExpr splits s into tokens: parentheses, the operators, "=", and the
+// words in between.
+func lexLabelExpr(s string) []string {
+	var toks []string
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case strings.HasPrefix(s[i:], "&&"), strings.HasPrefix(s[i:], "||"):
+			toks = append(toks, s[i:i+2])
+			i += 2
+		case strings.IndexByte(labelExprSpecial, c) != -1:
+			toks = append(toks, s[i:i+1])
+			i++
+		default:
+			j := i
+			for j < len(s) && !unicode.IsSpace(rune(s[j])) && strings.IndexByte(labelExprSpecial, s[j]) == -1 {
+				j++
+			}
+
+			toks = append(toks, s[i:j])
+			i = j
+		}
+	}
+
+	return toks
+}
+
+type labelExprParser struct {
+	toks []string
+	pos  int
+}
+
+func (p *labelExprParser) peek() string {
+	if p.pos >= len(p.toks) {
+		return ""
+	}
+
+	return p.toks[p.pos]
+}
+
+func (p *labelExprParser) next() string {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+// accept consumes the next token if it's one of toks, ignoring case.
+func (p *labelExprParser) accept(toks ...string) bool {
+	cur := p.peek()
+
+	for _, tok := range toks {
+		if strings.EqualFold(cur, tok) {
+			p.pos++
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *labelExprParser) expr() (labelExprNode, error) {
+	node, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept("or", "||") {
+		rhs, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+
+		node = &labelExprOr{node, rhs}
+	}
+
+	return node, nil
+}
+
+func (p *labelExprParser) term() (labelExprNode, error) {
+	node, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept("and", "&&") {
+		rhs, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+
+		node = &labelExprAnd{node, rhs}
+	}
+
+	return node, nil
+}
+
+func (p *labelExprParser) factor() (labelExprNode, error) {
+	if p.accept("not", "!") {
+		node, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+
+		return &labelExprNot{node}, nil
+	}
+
+	if p.accept("(") {
+		node, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.accept(")") {
+			return nil, errors.Wrapf(ErrBadLabelExpr, "missing ) before %q", p.peek())
+		}
+
+		return node, nil
+	}
+
+	return p.label()
+}
+
+func (p *labelExprParser) label() (labelExprNode, error) {
+	name := p.next()
+	if !isLabelExprWord(name) {
+		if name == "" {
+			return nil, errors.Wrap(ErrBadLabelExpr, "expected a label at the end")
+		}
+
+		return nil, errors.Wrapf(ErrBadLabelExpr, "expected a label, got %q", name)
+	}
+
+	if !p.accept("=") {
+		return &labelExprLabel{name: name, anyValue: true}, nil
+	}
+
+	value := p.next()
+	if !isLabelExprWord(value) {
+		return nil, errors.Wrapf(ErrBadLabelExpr, "expected a value for %s, got %q", name, value)
+	}
+
+	return &labelExprLabel{name: name, value: value}, nil
+}
+
+// isLabelExprWord reports if tok can be a label name or value, which rules out
+// the operators and keywords.
+func isLabelExprWord(tok string) bool {
+	if tok == "" || tok == "&&" || tok == "||" || strings.IndexByte(labelExprSpecial, tok[0]) != -1 {
+		return false
+	}
+
+	switch strings.ToLower(tok) {
+	case "and", "or", "not":
+		return false
+	}
+
+	return true
+}
@@ -1,6 +1,7 @@
 package pb
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -67,3 +68,88 @@ func TestLabelSet(t *testing.T) {
 	})
 
 }
+
+func TestLabelExpr(t *testing.T) {
+	matches := func(t *testing.T, expr, labels string) bool {
+		e, err := ParseLabelExpr(expr)
+		require.NoError(t, err)
+
+		return e.Matches(ParseLabelSet(labels))
+	}
+
+	t.Run("matches a single label", func(t *testing.T) {
+		assert.True(t, matches(t, "env=prod", "env=prod,service=www"))
+		assert.False(t, matches(t, "env=prod", "env=test,service=www"))
+		assert.True(t, matches(t, "ENV=Prod", "env=prod"))
+	})
+
+	t.Run("matches a label with any value", func(t *testing.T) {
+		assert.True(t, matches(t, "region", "env=prod,region=eu"))
+		assert.False(t, matches(t, "region", "env=prod"))
+	})
+
+	t.Run("combines with and", func(t *testing.T) {
+		assert.True(t, matches(t, "env=prod and service=www", "env=prod,service=www"))
+		assert.False(t, matches(t, "env=prod and service=www", "env=prod,service=api"))
+		assert.True(t, matches(t, "env=prod && service=www", "env=prod,service=www"))
+	})
+
+	t.Run("combines with or", func(t *testing.T) {
+		assert.True(t, matches(t, "region=us or region=eu", "region=eu"))
+		assert.False(t, matches(t, "region=us or region=eu", "region=ap"))
+		assert.True(t, matches(t, "region=us || region=eu", "region=us"))
+	})
+
+	t.Run("negates with not", func(t *testing.T) {
+		assert.True(t, matches(t, "not env=test", "env=prod"))
+		assert.False(t, matches(t, "not env=test", "env=test"))
+		assert.True(t, matches(t, "!canary", "env=prod"))
+		assert.True(t, matches(t, "not not env=prod", "env=prod"))
+	})
+
+	t.Run("groups with parentheses", func(t *testing.T) {
+		expr := "env=prod AND (region=us OR region=eu)"
+
+		assert.True(t, matches(t, expr, "env=prod,region=us"))
+		assert.True(t, matches(t, expr, "env=prod,region=eu"))
+		assert.False(t, matches(t, expr, "env=prod,region=ap"))
+		assert.False(t, matches(t, expr, "env=test,region=us"))
+
+		expr = "env=prod and not (canary or region=ap)"
+
+		assert.True(t, matches(t, expr, "env=prod,region=us"))
+		assert.False(t, matches(t, expr, "env=prod,region=us,canary=yes"))
+		assert.False(t, matches(t, expr, "env=prod,region=ap"))
+	})
+
+	t.Run("binds and tighter than or", func(t *testing.T) {
+		expr := "region=us or env=prod and service=www"
+
+		assert.True(t, matches(t, expr, "region=us"))
+		assert.True(t, matches(t, expr, "env=prod,service=www"))
+		assert.False(t, matches(t, expr, "env=prod"))
+	})
+
+	t.Run("matches labels with special characters in their names", func(t *testing.T) {
+		assert.True(t, matches(t, ":deployment-order=2 and version=1.2.3", ":deployment-order=2,version=1.2.3"))
+	})
+
+	t.Run("rejects malformed expressions", func(t *testing.T) {
+		for _, expr := range []string{
+			"",
+			"env=",
+			"env=prod and",
+			"or env=prod",
+			"(env=prod",
+			"env=prod)",
+			"env=prod region=us",
+			"env=prod & region=us",
+			"env=prod,region=us",
+			"not",
+			"and=prod",
+		} {
+			_, err := ParseLabelExpr(expr)
+			assert.True(t, errors.Is(err, ErrBadLabelExpr), "expr: %q, err: %v", expr, err)
+		}
+	})
+}
@@ -30,11 +30,12 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 type Capability int32
 
 const (
-	CONNECT Capability = 0
-	SERVE   Capability = 1
-	ACCESS  Capability = 2
-	MGMT    Capability = 3
-	CONFIG  Capability = 4
+	CONNECT   Capability = 0
+	SERVE     Capability = 1
+	ACCESS    Capability = 2
+	MGMT      Capability = 3
+	CONFIG    Capability = 4
+	HUB_SCOPE Capability = 5
 )
 
 var Capability_name = map[int32]string{
@@ -43,14 +44,16 @@ var Capability_name = map[int32]string{
 	2: "ACCESS",
 	3: "MGMT",
 	4: "CONFIG",
+	5: "HUB_SCOPE",
 }
 
 var Capability_value = map[string]int32{
-	"CONNECT": 0,
-	"SERVE":   1,
-	"ACCESS":  2,
-	"MGMT":    3,
-	"CONFIG":  4,
+	"CONNECT":   0,
+	"SERVE":     1,
+	"ACCESS":    2,
+	"MGMT":      3,
+	"CONFIG":    4,
+	"HUB_SCOPE": 5,
 }
 
 func (Capability) EnumDescriptor() ([]byte, []int) {
@@ -422,47 +425,46 @@ func init() {
 func init() { proto.RegisterFile("token.proto", fileDescriptor_3aff0bcd502840ab) }
 
 var fileDescriptor_3aff0bcd502840ab = []byte{
-	// 635 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x53, 0xcf, 0x4e, 0xdb, 0x4e,
-	0x10, 0xf6, 0x3a, 0x09, 0x4e, 0xc6, 0x10, 0xac, 0xfd, 0xfd, 0x90, 0x2c, 0x54, 0xb9, 0x69, 0xd4,
-	0xaa, 0x11, 0xa8, 0xa1, 0x4d, 0xe1, 0xd0, 0x43, 0x0f, 0x8e, 0x31, 0x01, 0x41, 0x42, 0xb5, 0x09,
-	0xb4, 0x37, 0xb4, 0xc1, 0xdb, 0x74, 0x15, 0x13, 0x5b, 0xb1, 0x83, 0x94, 0x5b, 0x4f, 0x3d, 0xf7,
-	0x31, 0xfa, 0x28, 0xdc, 0xca, 0x91, 0x53, 0x55, 0xcc, 0xa5, 0x47, 0x1e, 0xa0, 0x87, 0xca, 0x6b,
-	0xc7, 0x09, 0xed, 0x6d, 0xfe, 0x7c, 0x33, 0xf3, 0xcd, 0x37, 0xbb, 0xa0, 0x86, 0xde, 0x90, 0x8d,
-	0xea, 0xfe, 0xd8, 0x0b, 0x3d, 0x2c, 0xfb, 0xfd, 0xf5, 0x55, 0x87, 0x7d, 0x0c, 0xb6, 0x06, 0xde,
-	0xc0, 0x4b, 0x82, 0xeb, 0xab, 0x21, 0xbf, 0x60, 0x41, 0x48, 0x2f, 0xfc, 0x34, 0x50, 0x1c, 0x5e,
-	0xa6, 0x16, 0x4c, 0x5c, 0xee, 0xa4, 0xf6, 0x0a, 0x3d, 0x3f, 0xf7, 0x26, 0xa3, 0x30, 0x71, 0xab,
-	0x5b, 0xa0, 0xec, 0x33, 0xea, 0xb0, 0x71, 0x80, 0x9f, 0x82, 0xf2, 0x29, 0x31, 0x75, 0x54, 0xc9,
-	0xd5, 0xd4, 0x06, 0xd4, 0xfd, 0x7e, 0xfd, 0xf0, 0xf4, 0x1d, 0xe5, 0x63, 0x32, 0x4b, 0x55, 0xbf,
-	0x23, 0x28, 0x75, 0xf9, 0x60, 0x44, 0xc3, 0xc9, 0x98, 0xe1, 0x47, 0x50, 0x0a, 0x66, 0x8e, 0x8e,
-	0x2a, 0xa8, 0xb6, 0x4c, 0xe6, 0x01, 0xfc, 0x12, 0x8a, 0x01, 0x1f, 0x9c, 0x85, 0x53, 0x9f, 0xe9,
-	0x72, 0x05, 0xd5, 0xca, 0x8d, 0xb5, 0xb8, 0x65, 0x56, 0x1e, 0x5b, 0xbd, 0xa9, 0xcf, 0x88, 0x12,
-	0x24, 0x06, 0x5e, 0x83, 0xa5, 0x21, 0x9b, 0x9e, 0x71, 0x47, 0xcf, 0x55, 0x50, 0xad, 0x44, 0x0a,
-	0x43, 0x36, 0x3d, 0x70, 0xf0, 0xb3, 0x39, 0xb5, 0x7c, 0x05, 0xd5, 0xd4, 0x86, 0x1a, 0xf7, 0x49,
-	0x89, 0xcf, 0xb9, 0x6d, 0x83, 0x92, 0x76, 0xc4, 0x65, 0x80, 0xe6, 0x91, 0x79, 0x68, 0x37, 0xf6,
-	0xdb, 0xa6, 0xa5, 0x49, 0x58, 0x05, 0xc5, 0xde, 0x6d, 0xec, 0xec, 0xbc, 0x7a, 0xa3, 0x21, 0xbc,
-	0x0c, 0x45, 0xfb, 0x43, 0xcf, 0x26, 0x1d, 0xf3, 0x48, 0x93, 0xab, 0xef, 0x61, 0xb5, 0x17, 0x8b,
-	0x6b, 0x51, 0x9f, 0xf6, 0xb9, 0xcb, 0xc3, 0x29, 0xae, 0x03, 0x9c, 0x67, 0x9e, 0xd8, 0xab, 0xdc,
-	0x28, 0xc7, 0x23, 0xe7, 0x18, 0xb2, 0x80, 0xc0, 0xff, 0x43, 0xe1, 0x92, 0xba, 0x93, 0x64, 0xcb,
-	0x12, 0x49, 0x9c, 0xea, 0x6f, 0x19, 0x0a, 0xa2, 0x33, 0xc6, 0x90, 0xef, 0x7b, 0xce, 0x34, 0x55,
-	0x48, 0xd8, 0xf8, 0x39, 0x14, 0x2f, 0x58, 0x48, 0x1d, 0x1a, 0x52, 0x51, 0xf6, 0xd7, 0x52, 0x59,
-	0x12, 0xbf, 0x00, 0xc8, 0x24, 0x0d, 0xf4, 0x9c, 0x38, 0xcd, 0xca, 0x03, 0x1d, 0xc9, 0x02, 0x60,
-	0xfd, 0x8b, 0x0c, 0xf9, 0x66, 0x3c, 0xe0, 0x09, 0xe4, 0xc7, 0x9e, 0xcb, 0x52, 0xfa, 0xa2, 0x42,
-	0xb0, 0x21, 0x9e, 0xcb, 0x88, 0x48, 0x61, 0x1d, 0x64, 0xee, 0xa4, 0xd3, 0x8b, 0x31, 0xe0, 0xe4,
-	0xe8, 0x60, 0x97, 0xc8, 0x5c, 0x28, 0x9e, 0x3e, 0x14, 0x71, 0x89, 0x94, 0x9c, 0x99, 0x84, 0xc8,
-	0x2c, 0x87, 0xeb, 0xa0, 0x5e, 0x52, 0x97, 0x3b, 0x67, 0x93, 0x51, 0xc8, 0xdd, 0xf4, 0x38, 0xc9,
-	0xa8, 0xd9, 0x6b, 0x24, 0x20, 0x10, 0x27, 0x31, 0x00, 0xbf, 0x85, 0xe5, 0x4c, 0x36, 0xce, 0x02,
-	0xbd, 0x20, 0xb6, 0xf9, 0x2f, 0xe3, 0x36, 0xd7, 0xb7, 0x99, 0xbf, 0xfa, 0xf1, 0x58, 0x22, 0x0f,
-	0xe0, 0x78, 0x13, 0x80, 0x3a, 0x0e, 0x0f, 0xb9, 0x37, 0xa2, 0xae, 0x0e, 0xff, 0xaa, 0xb6, 0x90,
-	0xde, 0xd8, 0x03, 0x58, 0x38, 0xa9, 0x0a, 0x8a, 0x75, 0xdc, 0xe9, 0xd8, 0x56, 0x4f, 0x93, 0x70,
-	0x09, 0x0a, 0x5d, 0x9b, 0x9c, 0xda, 0x1a, 0xc2, 0x00, 0x4b, 0xa6, 0x65, 0xd9, 0xdd, 0xae, 0x26,
-	0xe3, 0x22, 0xe4, 0xdb, 0xad, 0x76, 0x4f, 0xcb, 0xc5, 0x51, 0xeb, 0xb8, 0xb3, 0x77, 0xd0, 0xd2,
-	0xf2, 0x1b, 0x9b, 0x50, 0xca, 0x74, 0x8b, 0x2b, 0xcd, 0x96, 0xdd, 0x89, 0x9b, 0x28, 0x90, 0xdb,
-	0x3f, 0x69, 0x26, 0x2d, 0xda, 0x66, 0xc7, 0x6c, 0xd9, 0x9a, 0xdc, 0xdc, 0xbe, 0xbe, 0x35, 0xa4,
-	0x9b, 0x5b, 0x43, 0xba, 0xbf, 0x35, 0xd0, 0xe7, 0xc8, 0x40, 0xdf, 0x22, 0x03, 0x5d, 0x45, 0x06,
-	0xba, 0x8e, 0x0c, 0xf4, 0x33, 0x32, 0xd0, 0xaf, 0xc8, 0x90, 0xee, 0x23, 0x03, 0x7d, 0xbd, 0x33,
-	0xa4, 0xeb, 0x3b, 0x43, 0xba, 0xb9, 0x33, 0xa4, 0xfe, 0x92, 0xf8, 0x8c, 0xaf, 0xff, 0x04, 0x00,
-	0x00, 0xff, 0xff, 0xab, 0xc7, 0xc3, 0x51, 0xe6, 0x03, 0x00, 0x00,
+	// 621 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x65, 0x53, 0xcf, 0x8f, 0xd2, 0x50,
+	0x10, 0x86, 0x52, 0xb6, 0x30, 0xfc, 0x6a, 0x9e, 0x6e, 0x42, 0x88, 0x41, 0x25, 0x1a, 0x37, 0xbb,
+	0x91, 0x55, 0xdc, 0x3d, 0x78, 0xf0, 0x50, 0xba, 0x15, 0xc8, 0x02, 0xbb, 0x79, 0xc0, 0xea, 0x8d,
+	0x3c, 0x68, 0xc5, 0x86, 0x42, 0x1b, 0x28, 0x9b, 0x70, 0xf3, 0xe4, 0xd9, 0x3f, 0xc3, 0x3f, 0x65,
+	0x6f, 0x72, 0xdc, 0x93, 0x71, 0xd7, 0x8b, 0x47, 0xff, 0x00, 0x0f, 0xce, 0x6b, 0x4b, 0x61, 0xf5,
+	0x30, 0xe9, 0xcc, 0x37, 0xdf, 0x9b, 0x37, 0xf3, 0xbd, 0x29, 0xa4, 0x5c, 0x7b, 0x6c, 0x4c, 0xcb,
+	0xce, 0xcc, 0x76, 0x6d, 0x22, 0x38, 0x83, 0x42, 0x4e, 0x37, 0x3e, 0xcc, 0x0f, 0x47, 0xf6, 0xc8,
+	0xf6, 0xc1, 0x42, 0xce, 0x35, 0x27, 0xc6, 0xdc, 0x65, 0x13, 0x27, 0x00, 0x12, 0xe3, 0xcb, 0xc0,
+	0x83, 0x85, 0x65, 0xea, 0x81, 0x9f, 0x61, 0xc3, 0xa1, 0xbd, 0x98, 0xba, 0x7e, 0x58, 0x3a, 0x04,
+	0xa9, 0x6e, 0x30, 0xdd, 0x98, 0xcd, 0xc9, 0x13, 0x90, 0x3e, 0xfa, 0x6e, 0x3e, 0xfa, 0x28, 0xb6,
+	0x97, 0xaa, 0x40, 0xd9, 0x19, 0x94, 0x4f, 0x2f, 0xce, 0x99, 0x39, 0xa3, 0xeb, 0x54, 0xe9, 0x5b,
+	0x14, 0x92, 0x1d, 0x73, 0x34, 0x65, 0xee, 0x62, 0x66, 0x90, 0x07, 0x90, 0x9c, 0xaf, 0x03, 0x3c,
+	0x15, 0xdd, 0x4b, 0xd3, 0x0d, 0x40, 0x5e, 0x40, 0x02, 0x83, 0xbe, 0xbb, 0x74, 0x8c, 0xbc, 0x80,
+	0xc9, 0x6c, 0x65, 0x97, 0x97, 0x0c, 0x8f, 0x73, 0xaf, 0x8b, 0x49, 0x2a, 0xcd, 0x7d, 0x87, 0xec,
+	0xc2, 0xce, 0xd8, 0x58, 0xf6, 0x4d, 0x3d, 0x1f, 0x43, 0x7e, 0x92, 0xc6, 0x31, 0x6a, 0xe8, 0xe4,
+	0xe9, 0xa6, 0x35, 0x11, 0xf1, 0x54, 0x25, 0xc5, 0xeb, 0x04, 0x8d, 0x6f, 0x7a, 0x3b, 0x02, 0x29,
+	0xa8, 0x48, 0xb2, 0x00, 0xd5, 0xa6, 0x72, 0xaa, 0x55, 0xea, 0x2d, 0x45, 0x95, 0x23, 0x24, 0x05,
+	0x92, 0x76, 0x52, 0x39, 0x3e, 0x7e, 0xf9, 0x5a, 0x8e, 0x92, 0x34, 0x24, 0xb4, 0xf7, 0x5d, 0x8d,
+	0xb6, 0x95, 0xa6, 0x2c, 0x94, 0xde, 0x41, 0xae, 0xcb, 0xc5, 0x55, 0x99, 0xc3, 0x06, 0xa6, 0x65,
+	0xba, 0x4b, 0x52, 0x06, 0x18, 0x86, 0x91, 0x37, 0x57, 0xb6, 0x92, 0xe5, 0x57, 0x6e, 0x38, 0x74,
+	0x8b, 0x41, 0xee, 0x43, 0xfc, 0x92, 0x59, 0x0b, 0x7f, 0x4a, 0xec, 0xda, 0x0b, 0x4a, 0x7f, 0x04,
+	0x88, 0x7b, 0x95, 0x09, 0x01, 0x71, 0x60, 0xeb, 0xcb, 0x40, 0x21, 0xcf, 0x27, 0xcf, 0x20, 0x31,
+	0x31, 0x5c, 0xa6, 0x33, 0x97, 0x79, 0xc7, 0xfe, 0x19, 0x2a, 0x4c, 0x92, 0xe7, 0x00, 0xa1, 0xa4,
+	0x73, 0xd4, 0x85, 0x3f, 0x4d, 0xe6, 0x8e, 0x8e, 0x74, 0x8b, 0x50, 0xf8, 0x2c, 0x80, 0x58, 0xe5,
+	0x17, 0x3c, 0x06, 0x71, 0x66, 0x5b, 0x46, 0xd0, 0xbe, 0x77, 0xc2, 0xeb, 0x86, 0x22, 0x48, 0xbd,
+	0x14, 0xc9, 0x83, 0x80, 0x52, 0xfb, 0xb7, 0x27, 0x38, 0xa1, 0xd7, 0x6c, 0x9c, 0x50, 0xc4, 0xb8,
+	0xe2, 0xc1, 0xa2, 0x78, 0x2f, 0x11, 0x34, 0xa7, 0xf8, 0x10, 0x5d, 0xe7, 0x50, 0xa8, 0x14, 0xce,
+	0x6a, 0xea, 0x7d, 0x0c, 0x4c, 0x2b, 0x78, 0x1c, 0xff, 0xaa, 0xf5, 0x36, 0x52, 0xf0, 0x18, 0x3d,
+	0x4e, 0x20, 0x6f, 0x20, 0x1d, 0xca, 0x66, 0xe2, 0x34, 0x71, 0x6f, 0x9a, 0x7b, 0x61, 0x6f, 0x1b,
+	0x7d, 0xab, 0xe2, 0xd5, 0xf7, 0x87, 0x11, 0x7a, 0x87, 0x4e, 0x0e, 0x00, 0x98, 0xae, 0xa3, 0x6f,
+	0x4f, 0x99, 0x95, 0x87, 0xff, 0x55, 0xdb, 0x4a, 0xef, 0xf7, 0x00, 0xb6, 0x9e, 0x14, 0x17, 0x40,
+	0x3d, 0x6b, 0xb7, 0x35, 0xb5, 0x8b, 0xdb, 0x90, 0x84, 0x78, 0x47, 0xa3, 0x17, 0x1a, 0xee, 0x02,
+	0xc0, 0x8e, 0xa2, 0xaa, 0x5a, 0xa7, 0x23, 0x0b, 0x24, 0x01, 0x62, 0xab, 0xd6, 0xea, 0xca, 0x31,
+	0x8e, 0x22, 0xfb, 0x6d, 0xa3, 0x26, 0x8b, 0x24, 0x03, 0xc9, 0x7a, 0xaf, 0xda, 0xef, 0xa8, 0x67,
+	0xe7, 0x9a, 0x1c, 0xdf, 0x3f, 0x80, 0x64, 0x28, 0x23, 0x2f, 0xa4, 0xd4, 0xb4, 0x36, 0xaf, 0x29,
+	0x41, 0x0c, 0x69, 0x7e, 0xc5, 0x96, 0xd2, 0x46, 0x58, 0x16, 0xaa, 0x47, 0xab, 0x9b, 0x62, 0xe4,
+	0x1a, 0xed, 0xf7, 0x4d, 0x31, 0xfa, 0xe9, 0xb6, 0x18, 0xfd, 0x8a, 0x76, 0x85, 0xb6, 0x42, 0xfb,
+	0x81, 0xf6, 0xeb, 0x16, 0x73, 0xf8, 0xfd, 0xf2, 0xb3, 0x18, 0x59, 0xa1, 0x5d, 0xa3, 0x0d, 0x76,
+	0xbc, 0x7f, 0xf3, 0xd5, 0x5f, 0x26, 0xa0, 0xce, 0xbd, 0xf5, 0x03, 0x00, 0x00,
 }
 
 func (x Capability) String() string {
@@ -592,6 +592,7 @@ type Request struct {
 	AgentId       []byte       `protobuf:"bytes,10,opt,name=agentId,proto3" json:"agentId,omitempty"`
 	TargetService string       `protobuf:"bytes,11,opt,name=target_service,json=targetService,proto3" json:"target_service,omitempty"`
 	PivotAccount  *Account     `protobuf:"bytes,12,opt,name=pivot_account,json=pivotAccount,proto3" json:"pivot_account,omitempty"`
+	HttpVersion   string       `protobuf:"bytes,13,opt,name=http_version,json=httpVersion,proto3" json:"http_version,omitempty"`
 }
 
 func (m *Request) Reset()      { *m = Request{} }
@@ -710,6 +711,13 @@ func (m *Request) GetPivotAccount() *Account {
 	return nil
 }
 
+func (m *Request) GetHttpVersion() string {
+	if m != nil {
+		return m.HttpVersion
+	}
+	return ""
+}
+
 type Response struct {
 	Error   string    `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
 	Code    int32     `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
@@ -769,6 +777,128 @@ func (m *Response) GetHeaders() []*Header {
 	return nil
 }
 
+type StaticResponse struct {
+	// Defaults to 503 when unset.
+	Code    int32     `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Headers []*Header `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty"`
+	Body    []byte    `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+}
+
+func (m *StaticResponse) Reset()      { *m = StaticResponse{} }
+func (*StaticResponse) ProtoMessage() {}
+func (*StaticResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f2dcdddcdf68d8e0, []int{11}
+}
+func (m *StaticResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StaticResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StaticResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StaticResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StaticResponse.Merge(m, src)
+}
+func (m *StaticResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *StaticResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StaticResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StaticResponse proto.InternalMessageInfo
+
+func (m *StaticResponse) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *StaticResponse) GetHeaders() []*Header {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *StaticResponse) GetBody() []byte {
+	if m != nil {
+		return m.Body
+	}
+	return nil
+}
+
+type AccountPages struct {
+	// When set, none of the account's requests are sent to its services, and
+	// they get suspended_page instead.
+	Suspended     bool            `protobuf:"varint,1,opt,name=suspended,proto3" json:"suspended,omitempty"`
+	SuspendedPage *StaticResponse `protobuf:"bytes,2,opt,name=suspended_page,json=suspendedPage,proto3" json:"suspended_page,omitempty"`
+	// Served when none of the services a request could go to are available.
+	UnavailablePage *StaticResponse `protobuf:"bytes,3,opt,name=unavailable_page,json=unavailablePage,proto3" json:"unavailable_page,omitempty"`
+}
+
+func (m *AccountPages) Reset()      { *m = AccountPages{} }
+func (*AccountPages) ProtoMessage() {}
+func (*AccountPages) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f2dcdddcdf68d8e0, []int{12}
+}
+func (m *AccountPages) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AccountPages) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AccountPages.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AccountPages) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountPages.Merge(m, src)
+}
+func (m *AccountPages) XXX_Size() int {
+	return m.Size()
+}
+func (m *AccountPages) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountPages.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountPages proto.InternalMessageInfo
+
+func (m *AccountPages) GetSuspended() bool {
+	if m != nil {
+		return m.Suspended
+	}
+	return false
+}
+
+func (m *AccountPages) GetSuspendedPage() *StaticResponse {
+	if m != nil {
+		return m.SuspendedPage
+	}
+	return nil
+}
+
+func (m *AccountPages) GetUnavailablePage() *StaticResponse {
+	if m != nil {
+		return m.UnavailablePage
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("pb.Request_Type", Request_Type_name, Request_Type_value)
 	proto.RegisterType((*Labels)(nil), "pb.Labels")
@@ -782,65 +912,73 @@ func init() {
 	proto.RegisterType((*SessionIdentification)(nil), "pb.SessionIdentification")
 	proto.RegisterType((*Request)(nil), "pb.Request")
 	proto.RegisterType((*Response)(nil), "pb.Response")
+	proto.RegisterType((*StaticResponse)(nil), "pb.StaticResponse")
+	proto.RegisterType((*AccountPages)(nil), "pb.AccountPages")
 }
 
 func init() { proto.RegisterFile("wire.proto", fileDescriptor_f2dcdddcdf68d8e0) }
 
 var fileDescriptor_f2dcdddcdf68d8e0 = []byte{
-	// 836 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0xcd, 0x6e, 0x1b, 0x37,
-	0x10, 0xd6, 0x5a, 0x6b, 0x69, 0x35, 0x92, 0x1c, 0x95, 0x68, 0x83, 0x85, 0xd1, 0x6e, 0xd5, 0x45,
-	0xda, 0x1a, 0x28, 0x60, 0x14, 0xee, 0xcf, 0x5d, 0x51, 0x8c, 0x46, 0x48, 0xea, 0x08, 0xb4, 0xd2,
-	0x02, 0xbd, 0x08, 0xd4, 0x2e, 0x6d, 0x2d, 0xac, 0x5d, 0x6e, 0x48, 0xae, 0x03, 0xdf, 0xfa, 0x08,
-	0x3d, 0xf6, 0x09, 0x8a, 0x3e, 0x45, 0xcf, 0x39, 0xfa, 0x98, 0x63, 0x2d, 0x5f, 0x7a, 0xcc, 0x23,
-	0x14, 0x43, 0x72, 0x6d, 0x43, 0x69, 0x91, 0xdc, 0xe6, 0x9b, 0x21, 0x87, 0xdf, 0xcc, 0xf7, 0x11,
-	0xe0, 0x65, 0x26, 0xf9, 0x7e, 0x29, 0x85, 0x16, 0x64, 0xab, 0x5c, 0xec, 0xde, 0xd3, 0x59, 0xce,
-	0x95, 0x66, 0x79, 0x69, 0x93, 0xbb, 0xc1, 0xd9, 0xb9, 0x8b, 0xa0, 0x5a, 0x65, 0xa9, 0x8b, 0xfb,
-	0x2c, 0x49, 0x44, 0x55, 0x68, 0x07, 0xbb, 0x2b, 0xb6, 0xe0, 0x2b, 0x0b, 0xe2, 0x08, 0x5a, 0x4f,
-	0x11, 0x2a, 0xf2, 0x21, 0x6c, 0x9b, 0x42, 0xe8, 0x0d, 0x9b, 0x7b, 0x1d, 0x6a, 0x41, 0xfc, 0xbb,
-	0x07, 0xdd, 0x63, 0x2e, 0xcf, 0xb3, 0x84, 0x4f, 0x8a, 0x13, 0x41, 0xbe, 0x04, 0x50, 0x16, 0xce,
-	0xb3, 0x34, 0xf4, 0x86, 0xde, 0x5e, 0xf7, 0x20, 0xd8, 0x2f, 0x17, 0xfb, 0xcf, 0x9f, 0x4e, 0x1e,
-	0xd1, 0x8e, 0xab, 0x4d, 0x52, 0x42, 0xc0, 0xd7, 0x17, 0x25, 0x0f, 0xb7, 0x86, 0xde, 0x5e, 0x87,
-	0x9a, 0x98, 0x3c, 0x80, 0x96, 0xe9, 0xaa, 0xc2, 0xa6, 0xb9, 0xd8, 0xc3, 0x8b, 0xe6, 0xf9, 0x63,
-	0xae, 0xa9, 0xab, 0x91, 0x2f, 0x20, 0xc8, 0xb9, 0x66, 0x29, 0xd3, 0x2c, 0xf4, 0x87, 0xcd, 0xbd,
-	0xee, 0x01, 0xe0, 0xb9, 0x27, 0x3f, 0x4d, 0x59, 0x26, 0xe9, 0x4d, 0x2d, 0xfe, 0xc3, 0x83, 0x60,
-	0x2a, 0x39, 0xcb, 0x17, 0x2b, 0x4e, 0x3e, 0x41, 0x5e, 0x4a, 0x65, 0xa2, 0xa8, 0x79, 0x75, 0x90,
-	0x8d, 0xc9, 0x4c, 0x52, 0x1c, 0x4e, 0x8b, 0x33, 0x5e, 0x38, 0x3a, 0x16, 0x90, 0xfb, 0x77, 0xf8,
-	0xe0, 0xcc, 0x35, 0x83, 0xaf, 0x20, 0x70, 0x83, 0x28, 0xc7, 0xe0, 0x1e, 0x32, 0xb8, 0xb3, 0x07,
-	0x7a, 0x73, 0x80, 0x0c, 0xa1, 0x9b, 0x88, 0xbc, 0x94, 0xf6, 0xad, 0x70, 0xdb, 0x3c, 0x70, 0x37,
-	0x15, 0x9f, 0x41, 0x6f, 0x2c, 0x8a, 0x93, 0x4c, 0xe6, 0x4c, 0x67, 0xa2, 0x20, 0x9f, 0x81, 0x8f,
-	0xc2, 0xb9, 0xed, 0xf5, 0xb1, 0xf5, 0xac, 0x16, 0x92, 0x9a, 0x12, 0x32, 0x53, 0x9a, 0xe9, 0x4a,
-	0x39, 0xc2, 0x0e, 0x6d, 0x3e, 0xd6, 0x7c, 0xfb, 0xb1, 0x03, 0x68, 0x3d, 0xe6, 0x2c, 0xe5, 0x12,
-	0x15, 0x28, 0x98, 0x7b, 0xa6, 0x43, 0x4d, 0x8c, 0x7b, 0x38, 0x67, 0xab, 0x0a, 0x65, 0x31, 0x22,
-	0x1b, 0x10, 0x7f, 0x0f, 0xfe, 0xa8, 0xd2, 0x4b, 0xbc, 0x51, 0x29, 0x2e, 0xeb, 0x1b, 0x18, 0x93,
-	0x5d, 0x08, 0x4a, 0xa6, 0xd4, 0x4b, 0x21, 0x53, 0xc7, 0xe5, 0x06, 0xc7, 0x7f, 0x79, 0xb0, 0x33,
-	0x16, 0x45, 0xc1, 0x13, 0x4d, 0xf9, 0x8b, 0x8a, 0x2b, 0x8d, 0x12, 0x6b, 0x26, 0x4f, 0xb9, 0x76,
-	0xd3, 0x6d, 0x48, 0x6c, 0x6b, 0xff, 0x69, 0x8e, 0xaf, 0xa1, 0x5f, 0x66, 0xe7, 0x42, 0xcf, 0x9d,
-	0x5b, 0x9d, 0x47, 0xba, 0xd8, 0x60, 0x64, 0x53, 0xb4, 0x67, 0x4e, 0x38, 0x44, 0x3e, 0x85, 0xae,
-	0x31, 0x71, 0x22, 0x56, 0x28, 0xba, 0x6f, 0x9a, 0x41, 0x9d, 0x9a, 0xa4, 0x78, 0x40, 0x89, 0x4a,
-	0x26, 0x7c, 0xce, 0xd2, 0x54, 0x1a, 0x69, 0x7a, 0x14, 0x6c, 0x6a, 0x94, 0xa6, 0x32, 0xfe, 0x0e,
-	0xc0, 0xf1, 0x1f, 0x25, 0x67, 0xef, 0xed, 0xed, 0x98, 0xc1, 0x47, 0xc7, 0xb5, 0xb5, 0x78, 0xa1,
-	0xb3, 0x93, 0x2c, 0xb1, 0xca, 0xbe, 0xf7, 0xef, 0xd8, 0xa0, 0xbe, 0xb5, 0x49, 0x3d, 0x7e, 0xd5,
-	0x84, 0xf6, 0xed, 0x4e, 0xed, 0xb6, 0xb0, 0xdf, 0xce, 0xc1, 0x00, 0xfb, 0xb9, 0xd2, 0xfe, 0xec,
-	0xa2, 0xe4, 0x6e, 0x7f, 0xf7, 0xa1, 0x95, 0x73, 0xbd, 0x14, 0x75, 0x37, 0x87, 0x70, 0xd7, 0x25,
-	0xd3, 0x4b, 0xe7, 0x15, 0x13, 0xa3, 0x0d, 0x5e, 0x54, 0x5c, 0x5e, 0xb8, 0x9d, 0x59, 0x80, 0x52,
-	0x9f, 0x48, 0x76, 0x9a, 0xf3, 0x42, 0x3b, 0x1b, 0xdf, 0x60, 0xf2, 0x31, 0xf8, 0xac, 0xd2, 0xcb,
-	0xb0, 0x75, 0x3b, 0x13, 0x5a, 0x86, 0x9a, 0x2c, 0x79, 0x00, 0xed, 0xa5, 0x31, 0x9d, 0x0a, 0xdb,
-	0xb7, 0x3f, 0xd6, 0xfa, 0x90, 0xd6, 0x25, 0x1c, 0x5a, 0xf2, 0x5c, 0x68, 0x27, 0x47, 0x60, 0x87,
-	0xb6, 0x29, 0x94, 0x03, 0xa9, 0x2e, 0x85, 0xd2, 0x61, 0xc7, 0x52, 0xc5, 0x98, 0x84, 0xd0, 0x66,
-	0xa7, 0xbc, 0xd0, 0x93, 0x34, 0x04, 0xa3, 0x5f, 0x0d, 0xc9, 0xe7, 0xb0, 0x63, 0xed, 0x34, 0x77,
-	0x7b, 0x0d, 0xbb, 0xe6, 0x5e, 0xdf, 0x66, 0xdd, 0x6f, 0x7d, 0xdb, 0x57, 0xbd, 0x77, 0xf8, 0x2a,
-	0xfe, 0x11, 0x7c, 0xdc, 0x2b, 0x09, 0xc0, 0x7f, 0x3c, 0x9b, 0x4d, 0x07, 0x0d, 0xd2, 0x87, 0xce,
-	0xcf, 0x87, 0x0f, 0x8f, 0x9f, 0x8d, 0x9f, 0x1c, 0xce, 0x06, 0x1e, 0x69, 0x43, 0x73, 0x36, 0x9e,
-	0x0e, 0xb6, 0x30, 0x78, 0xfe, 0x68, 0x3a, 0x68, 0x62, 0x40, 0xa7, 0xe3, 0x81, 0x4f, 0x3e, 0x80,
-	0xfe, 0xe8, 0x87, 0xc3, 0xa3, 0xd9, 0x7c, 0xfc, 0xec, 0xe8, 0xe8, 0x70, 0x3c, 0x1b, 0x6c, 0xc7,
-	0xbf, 0x40, 0x40, 0xb9, 0x2a, 0x45, 0xa1, 0xcc, 0xff, 0xe3, 0x52, 0x8a, 0xfa, 0x8b, 0x59, 0x80,
-	0x73, 0x27, 0x22, 0xb5, 0xdf, 0x61, 0x9b, 0x9a, 0xf8, 0xee, 0x4a, 0x9b, 0xff, 0xbb, 0xd2, 0x87,
-	0xdf, 0x5e, 0x5e, 0x45, 0x8d, 0xd7, 0x57, 0x51, 0xe3, 0xcd, 0x55, 0xe4, 0xfd, 0xba, 0x8e, 0xbc,
-	0x3f, 0xd7, 0x91, 0xf7, 0x6a, 0x1d, 0x79, 0x97, 0xeb, 0xc8, 0xfb, 0x7b, 0x1d, 0x79, 0xff, 0xac,
-	0xa3, 0xc6, 0x9b, 0x75, 0xe4, 0xfd, 0x76, 0x1d, 0x35, 0x2e, 0xaf, 0xa3, 0xc6, 0xeb, 0xeb, 0xa8,
-	0xb1, 0x68, 0x19, 0xa3, 0x7d, 0xf3, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x19, 0xca, 0xf1, 0xa9,
-	0x50, 0x06, 0x00, 0x00,
+	// 937 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8d, 0x54, 0xcd, 0x8e, 0x1b, 0x45,
+	0x10, 0xde, 0xb1, 0xbd, 0xf6, 0xb8, 0xfc, 0xb3, 0xa6, 0x45, 0xd0, 0x68, 0x05, 0x26, 0x19, 0x11,
+	0x88, 0x84, 0xb4, 0x42, 0x86, 0x20, 0x71, 0xe0, 0xe0, 0x38, 0x2b, 0xb2, 0x4a, 0xd8, 0x58, 0x63,
+	0x27, 0x48, 0x39, 0x60, 0xb5, 0x67, 0x7a, 0xd7, 0xa3, 0xb5, 0xa7, 0x87, 0x99, 0x1e, 0x47, 0x7b,
+	0xe3, 0x11, 0x38, 0xf2, 0x04, 0x88, 0xa7, 0xe0, 0xcc, 0x71, 0x8f, 0x39, 0x92, 0x70, 0x41, 0xe2,
+	0xc2, 0x23, 0x50, 0xd5, 0xdd, 0xe3, 0x35, 0x9b, 0xac, 0xc8, 0xa1, 0x35, 0xf5, 0xd3, 0x55, 0xfd,
+	0x55, 0xd5, 0x57, 0x03, 0xf0, 0x3c, 0xce, 0xc4, 0x41, 0x9a, 0x49, 0x25, 0x59, 0x25, 0x9d, 0xef,
+	0xef, 0xa9, 0x78, 0x25, 0x72, 0xc5, 0x57, 0xa9, 0x31, 0xee, 0xbb, 0x67, 0x6b, 0x2b, 0x41, 0xb1,
+	0x8c, 0x23, 0x2b, 0x77, 0x78, 0x18, 0xca, 0x22, 0x51, 0x56, 0x6d, 0x2d, 0xf9, 0x5c, 0x2c, 0x8d,
+	0xe2, 0xf7, 0xa1, 0xfe, 0x88, 0xd4, 0x9c, 0xbd, 0x0b, 0xbb, 0xda, 0xe1, 0x39, 0x37, 0xab, 0x77,
+	0x9a, 0x81, 0x51, 0xfc, 0x9f, 0x1d, 0x68, 0x4d, 0x44, 0xb6, 0x8e, 0x43, 0x71, 0x94, 0x9c, 0x48,
+	0xf6, 0x09, 0x40, 0x6e, 0xd4, 0x59, 0x1c, 0xe1, 0x55, 0xe7, 0x4e, 0x6b, 0xe0, 0x1e, 0xa4, 0xf3,
+	0x83, 0x27, 0x8f, 0x8e, 0xee, 0x07, 0x4d, 0xeb, 0x3b, 0x8a, 0x18, 0x83, 0x9a, 0x3a, 0x4f, 0x85,
+	0x57, 0xc1, 0x2b, 0xcd, 0x40, 0xcb, 0xec, 0x23, 0xa8, 0xeb, 0xac, 0xb9, 0x57, 0xd5, 0x81, 0x6d,
+	0x0a, 0xd4, 0xcf, 0x4f, 0x84, 0x0a, 0xac, 0x8f, 0x7d, 0x0c, 0xee, 0x4a, 0x28, 0x1e, 0x71, 0xc5,
+	0xbd, 0x1a, 0x62, 0x69, 0x0d, 0x80, 0xee, 0x3d, 0x7c, 0x3a, 0xe6, 0x71, 0x16, 0x6c, 0x7c, 0xfe,
+	0x2f, 0x0e, 0xb8, 0xe3, 0x4c, 0xf0, 0xd5, 0x7c, 0x29, 0xd8, 0x07, 0x84, 0x2b, 0xcf, 0x63, 0x99,
+	0x94, 0xb8, 0x9a, 0x84, 0x46, 0x5b, 0x10, 0x0d, 0x16, 0xa7, 0xe4, 0x99, 0x48, 0x2c, 0x1c, 0xa3,
+	0xb0, 0xf7, 0xb6, 0xf0, 0x50, 0xcd, 0x25, 0x82, 0x4f, 0xc1, 0xb5, 0x85, 0xe4, 0x16, 0xc1, 0x1e,
+	0x21, 0xd8, 0xea, 0x43, 0xb0, 0xb9, 0xc0, 0x6e, 0x42, 0x2b, 0x94, 0xab, 0x34, 0x33, 0x6f, 0x79,
+	0xbb, 0xfa, 0x81, 0x6d, 0x93, 0x7f, 0x06, 0xed, 0x91, 0x4c, 0x4e, 0xe2, 0x6c, 0xc5, 0x15, 0xea,
+	0xec, 0x16, 0xb6, 0x06, 0x07, 0x67, 0xbb, 0xd7, 0xa1, 0xd4, 0xd3, 0x72, 0x90, 0x81, 0x76, 0x11,
+	0x32, 0x54, 0x55, 0x91, 0x5b, 0xc0, 0x56, 0xbb, 0xfa, 0x58, 0xf5, 0xf5, 0xc7, 0x06, 0x50, 0x7f,
+	0x20, 0x78, 0x24, 0x32, 0x9a, 0x40, 0xc2, 0xed, 0x33, 0x38, 0x01, 0x92, 0xa9, 0x0f, 0x6b, 0xbe,
+	0x2c, 0x68, 0x2c, 0x7a, 0xc8, 0x5a, 0xf1, 0xbf, 0x84, 0xda, 0xb0, 0x50, 0x0b, 0x8a, 0x28, 0xb0,
+	0xae, 0x32, 0x82, 0x64, 0xb6, 0x0f, 0x6e, 0xca, 0xf3, 0xfc, 0xb9, 0xcc, 0x22, 0x8b, 0x65, 0xa3,
+	0xfb, 0xbf, 0x39, 0xd0, 0xc5, 0xca, 0x12, 0x11, 0xaa, 0x40, 0xfc, 0x50, 0x60, 0x05, 0x34, 0x62,
+	0xc5, 0xb3, 0x53, 0xa1, 0x6c, 0x75, 0x57, 0x46, 0x6c, 0x7c, 0x6f, 0x24, 0xc7, 0x67, 0xd0, 0x49,
+	0xe3, 0xb5, 0x54, 0x33, 0xcb, 0x56, 0xcb, 0x91, 0x16, 0x25, 0x18, 0x1a, 0x53, 0xd0, 0xd6, 0x37,
+	0xac, 0xc6, 0x3e, 0x84, 0x96, 0x26, 0x71, 0x28, 0x97, 0x34, 0xf4, 0x9a, 0x4e, 0x06, 0xa5, 0x09,
+	0xa7, 0x8e, 0x17, 0x72, 0x59, 0x64, 0xc8, 0x55, 0x1e, 0x45, 0x99, 0x1e, 0x4d, 0x3b, 0x00, 0x63,
+	0x1a, 0xa2, 0xc5, 0xbf, 0x0b, 0x60, 0xf1, 0x0f, 0xc3, 0xb3, 0xb7, 0xe6, 0xb6, 0xcf, 0xe1, 0xc6,
+	0xa4, 0xa4, 0x96, 0x48, 0x54, 0x7c, 0x12, 0x87, 0x66, 0xb2, 0x6f, 0xbd, 0x1d, 0x57, 0xa0, 0x57,
+	0xae, 0x42, 0xf7, 0xff, 0xae, 0x42, 0xe3, 0xb2, 0xa7, 0xa6, 0x5b, 0x94, 0xaf, 0x3b, 0xe8, 0x51,
+	0x3e, 0xeb, 0x3a, 0x98, 0xa2, 0xdd, 0xf6, 0x0f, 0x29, 0x83, 0xab, 0xb1, 0x90, 0x65, 0x36, 0xab,
+	0x51, 0xaf, 0x53, 0xae, 0x16, 0x96, 0x2b, 0x5a, 0x26, 0x1a, 0x60, 0x7c, 0x76, 0x6e, 0x7b, 0x66,
+	0x14, 0x1a, 0xf5, 0x49, 0xc6, 0x4f, 0x57, 0x58, 0x92, 0xa5, 0xf1, 0x46, 0x67, 0xef, 0x43, 0x8d,
+	0x23, 0x45, 0xbc, 0xfa, 0x65, 0x4d, 0x44, 0x99, 0x40, 0x5b, 0x11, 0x61, 0x63, 0xa1, 0x49, 0x97,
+	0x7b, 0x8d, 0xcb, 0x8d, 0x35, 0x3c, 0x0c, 0x4a, 0x17, 0x15, 0x9d, 0x89, 0x95, 0x54, 0x76, 0x1c,
+	0xae, 0x29, 0xda, 0x98, 0x68, 0x1c, 0x04, 0x75, 0x21, 0x73, 0xe5, 0x35, 0x0d, 0x54, 0x92, 0x99,
+	0x07, 0x0d, 0x7e, 0x8a, 0x08, 0x8e, 0x22, 0x0f, 0xf4, 0xfc, 0x4a, 0x95, 0xdd, 0x86, 0xae, 0xa1,
+	0xd3, 0xcc, 0xf6, 0xd5, 0x6b, 0xe9, 0xb8, 0x8e, 0xb1, 0xda, 0x6d, 0x7d, 0x9d, 0x57, 0xed, 0xff,
+	0xe3, 0xd5, 0x2d, 0x68, 0x2f, 0x94, 0x4a, 0x67, 0x6b, 0x04, 0x4d, 0x5b, 0xd6, 0x31, 0x5b, 0x46,
+	0xb6, 0xa7, 0xc6, 0xe4, 0x7f, 0x0b, 0x35, 0x6a, 0x3d, 0x73, 0xa1, 0xf6, 0x60, 0x3a, 0x1d, 0xf7,
+	0x76, 0x58, 0x07, 0x9a, 0xdf, 0x1d, 0xde, 0x9b, 0x3c, 0x1e, 0x3d, 0x3c, 0x9c, 0xf6, 0x1c, 0xd6,
+	0x80, 0xea, 0x74, 0x34, 0xee, 0x55, 0x48, 0x78, 0x72, 0x7f, 0xdc, 0xab, 0x92, 0x10, 0x8c, 0x47,
+	0xbd, 0x1a, 0x7b, 0x07, 0x3a, 0xc3, 0x6f, 0x0e, 0x8f, 0xa7, 0xb3, 0xd1, 0xe3, 0xe3, 0xe3, 0xc3,
+	0xd1, 0xb4, 0xb7, 0xeb, 0x3f, 0x03, 0x37, 0x10, 0x79, 0x2a, 0x93, 0x5c, 0xaf, 0xa8, 0xc8, 0x32,
+	0x59, 0x6e, 0xa1, 0x51, 0xa8, 0x35, 0xa1, 0x8c, 0xcc, 0xc6, 0xec, 0x06, 0x5a, 0xde, 0xee, 0x7a,
+	0xf5, 0xda, 0xae, 0xfb, 0xdf, 0x43, 0x77, 0x82, 0x3f, 0x8f, 0x38, 0xdc, 0xbc, 0x50, 0xe6, 0x72,
+	0xde, 0x9c, 0xab, 0x72, 0xfd, 0x04, 0x31, 0x72, 0x2e, 0xa3, 0x73, 0xcd, 0xa5, 0x76, 0xa0, 0x65,
+	0xfa, 0x0d, 0xb7, 0x6d, 0xe7, 0xc6, 0x38, 0x9a, 0x1c, 0xa9, 0xd2, 0xcc, 0x8b, 0x3c, 0x15, 0x49,
+	0x24, 0xcc, 0x0e, 0xb8, 0xc1, 0xa5, 0x81, 0x7d, 0x05, 0xdd, 0x8d, 0x32, 0x4b, 0x31, 0x40, 0x97,
+	0xd4, 0x1a, 0x30, 0xfd, 0x87, 0xfd, 0x0f, 0xd0, 0xa0, 0xb3, 0xb9, 0x49, 0x99, 0xd9, 0xd7, 0xd0,
+	0x2b, 0x12, 0xbe, 0xe6, 0x31, 0xfe, 0xa6, 0x97, 0xc2, 0x04, 0x57, 0xaf, 0x0d, 0xde, 0xdb, 0xba,
+	0x4b, 0xe1, 0xf7, 0xbe, 0xb8, 0x78, 0xd9, 0xdf, 0x79, 0x81, 0xe7, 0x9f, 0x97, 0x7d, 0xe7, 0xc7,
+	0x57, 0x7d, 0xe7, 0x57, 0x3c, 0xbf, 0xe3, 0xb9, 0xc0, 0xf3, 0x07, 0x9e, 0xbf, 0x5e, 0xa1, 0x0f,
+	0xbf, 0x3f, 0xfd, 0xd9, 0xdf, 0xb9, 0xc0, 0xf3, 0x02, 0xcf, 0xbc, 0xae, 0x97, 0xf2, 0xf3, 0x7f,
+	0x01, 0x59, 0xc3, 0xb9, 0xd8, 0x7c, 0x07, 0x00, 0x00,
 }
 
 func (x Request_Type) String() string {
@@ -1199,6 +1337,9 @@ func (this *Request) Equal(that interface{}) bool {
 	if !this.PivotAccount.Equal(that1.PivotAccount) {
 		return false
 	}
+	if this.HttpVersion != that1.HttpVersion {
+		return false
+	}
 	return true
 }
 func (this *Response) Equal(that interface{}) bool {
@@ -1236,6 +1377,71 @@ func (this *Response) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *StaticResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*StaticResponse)
+	if !ok {
+		that2, ok := that.(StaticResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Code != that1.Code {
+		return false
+	}
+	if len(this.Headers) != len(that1.Headers) {
+		return false
+	}
+	for i := range this.Headers {
+		if !this.Headers[i].Equal(that1.Headers[i]) {
+			return false
+		}
+	}
+	if !bytes.Equal(this.Body, that1.Body) {
+		return false
+	}
+	return true
+}
+func (this *AccountPages) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*AccountPages)
+	if !ok {
+		that2, ok := that.(AccountPages)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Suspended != that1.Suspended {
+		return false
+	}
+	if !this.SuspendedPage.Equal(that1.SuspendedPage) {
+		return false
+	}
+	if !this.UnavailablePage.Equal(that1.UnavailablePage) {
+		return false
+	}
+	return true
+}
 func (this *Labels) GoString() string {
 	if this == nil {
 		return "nil"
@@ -1364,7 +1570,7 @@ func (this *Request) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 16)
+	s := make([]string, 0, 17)
 	s = append(s, "&pb.Request{")
 	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
 	s = append(s, "Method: "+fmt.Sprintf("%#v", this.Method)+",\n")
@@ -1384,6 +1590,7 @@ func (this *Request) GoString() string {
 	if this.PivotAccount != nil {
 		s = append(s, "PivotAccount: "+fmt.Sprintf("%#v", this.PivotAccount)+",\n")
 	}
+	s = append(s, "HttpVersion: "+fmt.Sprintf("%#v", this.HttpVersion)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -1401,6 +1608,36 @@ func (this *Response) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *StaticResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.StaticResponse{")
+	s = append(s, "Code: "+fmt.Sprintf("%#v", this.Code)+",\n")
+	if this.Headers != nil {
+		s = append(s, "Headers: "+fmt.Sprintf("%#v", this.Headers)+",\n")
+	}
+	s = append(s, "Body: "+fmt.Sprintf("%#v", this.Body)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AccountPages) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.AccountPages{")
+	s = append(s, "Suspended: "+fmt.Sprintf("%#v", this.Suspended)+",\n")
+	if this.SuspendedPage != nil {
+		s = append(s, "SuspendedPage: "+fmt.Sprintf("%#v", this.SuspendedPage)+",\n")
+	}
+	if this.UnavailablePage != nil {
+		s = append(s, "UnavailablePage: "+fmt.Sprintf("%#v", this.UnavailablePage)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func valueToGoStringWire(v interface{}, typ string) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -1866,6 +2103,13 @@ func (m *Request) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.HttpVersion) > 0 {
+		i -= len(m.HttpVersion)
+		copy(dAtA[i:], m.HttpVersion)
+		i = encodeVarintWire(dAtA, i, uint64(len(m.HttpVersion)))
+		i--
+		dAtA[i] = 0x6a
+	}
 	if m.PivotAccount != nil {
 		{
 			size, err := m.PivotAccount.MarshalToSizedBuffer(dAtA[:i])
@@ -2017,6 +2261,112 @@ func (m *Response) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *StaticResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StaticResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StaticResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Body) > 0 {
+		i -= len(m.Body)
+		copy(dAtA[i:], m.Body)
+		i = encodeVarintWire(dAtA, i, uint64(len(m.Body)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Headers) > 0 {
+		for iNdEx := len(m.Headers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Headers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintWire(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Code != 0 {
+		i = encodeVarintWire(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AccountPages) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AccountPages) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AccountPages) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.UnavailablePage != nil {
+		{
+			size, err := m.UnavailablePage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWire(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.SuspendedPage != nil {
+		{
+			size, err := m.SuspendedPage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWire(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Suspended {
+		i--
+		if m.Suspended {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintWire(dAtA []byte, offset int, v uint64) int {
 	offset -= sovWire(v)
 	base := offset
@@ -2274,6 +2624,10 @@ func (m *Request) Size() (n int) {
 		l = m.PivotAccount.Size()
 		n += 1 + l + sovWire(uint64(l))
 	}
+	l = len(m.HttpVersion)
+	if l > 0 {
+		n += 1 + l + sovWire(uint64(l))
+	}
 	return n
 }
 
@@ -2299,14 +2653,56 @@ func (m *Response) Size() (n int) {
 	return n
 }
 
-func sovWire(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozWire(x uint64) (n int) {
-	return sovWire(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (this *Labels) String() string {
-	if this == nil {
+func (m *StaticResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovWire(uint64(m.Code))
+	}
+	if len(m.Headers) > 0 {
+		for _, e := range m.Headers {
+			l = e.Size()
+			n += 1 + l + sovWire(uint64(l))
+		}
+	}
+	l = len(m.Body)
+	if l > 0 {
+		n += 1 + l + sovWire(uint64(l))
+	}
+	return n
+}
+
+func (m *AccountPages) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Suspended {
+		n += 2
+	}
+	if m.SuspendedPage != nil {
+		l = m.SuspendedPage.Size()
+		n += 1 + l + sovWire(uint64(l))
+	}
+	if m.UnavailablePage != nil {
+		l = m.UnavailablePage.Size()
+		n += 1 + l + sovWire(uint64(l))
+	}
+	return n
+}
+
+func sovWire(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozWire(x uint64) (n int) {
+	return sovWire(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *Labels) String() string {
+	if this == nil {
 		return "nil"
 	}
 	s := strings.Join([]string{`&Labels{`,
@@ -2443,6 +2839,7 @@ func (this *Request) String() string {
 		`AgentId:` + fmt.Sprintf("%v", this.AgentId) + `,`,
 		`TargetService:` + fmt.Sprintf("%v", this.TargetService) + `,`,
 		`PivotAccount:` + strings.Replace(fmt.Sprintf("%v", this.PivotAccount), "Account", "Account", 1) + `,`,
+		`HttpVersion:` + fmt.Sprintf("%v", this.HttpVersion) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -2464,6 +2861,35 @@ func (this *Response) String() string {
 	}, "")
 	return s
 }
+func (this *StaticResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForHeaders := "[]*Header{"
+	for _, f := range this.Headers {
+		repeatedStringForHeaders += strings.Replace(f.String(), "Header", "Header", 1) + ","
+	}
+	repeatedStringForHeaders += "}"
+	s := strings.Join([]string{`&StaticResponse{`,
+		`Code:` + fmt.Sprintf("%v", this.Code) + `,`,
+		`Headers:` + repeatedStringForHeaders + `,`,
+		`Body:` + fmt.Sprintf("%v", this.Body) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AccountPages) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AccountPages{`,
+		`Suspended:` + fmt.Sprintf("%v", this.Suspended) + `,`,
+		`SuspendedPage:` + strings.Replace(this.SuspendedPage.String(), "StaticResponse", "StaticResponse", 1) + `,`,
+		`UnavailablePage:` + strings.Replace(this.UnavailablePage.String(), "StaticResponse", "StaticResponse", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringWire(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -4195,6 +4621,38 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HttpVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HttpVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWire(dAtA[iNdEx:])
@@ -4357,6 +4815,291 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *StaticResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWire
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StaticResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StaticResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Headers = append(m.Headers, &Header{})
+			if err := m.Headers[len(m.Headers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Body = append(m.Body[:0], dAtA[iNdEx:postIndex]...)
+			if m.Body == nil {
+				m.Body = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWire(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AccountPages) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWire
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AccountPages: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AccountPages: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Suspended", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Suspended = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SuspendedPage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SuspendedPage == nil {
+				m.SuspendedPage = &StaticResponse{}
+			}
+			if err := m.SuspendedPage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnavailablePage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWire
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWire
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWire
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.UnavailablePage == nil {
+				m.UnavailablePage = &StaticResponse{}
+			}
+			if err := m.UnavailablePage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWire(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthWire
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipWire(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
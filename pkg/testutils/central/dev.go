@@ -137,7 +137,7 @@ func Dev(t testing.T, f func(setup *DevSetup)) {
 		})
 	require.NoError(t, err)
 
-	gs := grpc.NewServer()
+	gs := grpc.NewServer(s.GRPCServerOptions()...)
 	pb.RegisterControlServicesServer(gs, s)
 	pb.RegisterControlManagementServer(gs, s)
 
@@ -27,22 +27,83 @@ func (t *ValidToken) AllowAccount(ns string) bool {
 		return false
 	}
 
-	// Then if the access namespace is the same as the requestd namespace, allow
-	// it.
-	if ns == val {
+	return NamespaceIncludes(val, ns)
+}
+
+// HubScope returns the labels a hub must have on one of its locations for the
+// token to be used there, or nil if the token can be used on any hub.
+func (t *ValidToken) HubScope() *pb.LabelSet {
+	ok, val := t.HasCapability(pb.HUB_SCOPE)
+	if !ok || val == "" {
+		return nil
+	}
+
+	return pb.ParseLabelSet(val)
+}
+
+// AllowHub returns true if the token can be used on a hub with the given
+// locations.
+func (t *ValidToken) AllowHub(locs []*pb.NetworkLocation) bool {
+	return HubInScope(t.HubScope(), locs)
+}
+
+// HubInScope returns true if scope is nil or one of locs has all the labels
+// in scope.
+//
+// Tokens without a hub scope, including all those issued before scopes were
+// added, have a nil scope, so they can still be used on every hub and are
+// routed to services on every hub. Hubs that don't report location labels
+// are only in scope for those tokens, so hubs need their labels configured
+// before scoped tokens are handed out.
+func HubInScope(scope *pb.LabelSet, locs []*pb.NetworkLocation) bool {
+	if scope == nil {
 		return true
 	}
 
-	// If the access namespace is not a valid prefix of requested one, then def
-	if !strings.HasPrefix(ns, val) {
-		return false
+	for _, loc := range locs {
+		if loc.Labels != nil && hasLabels(loc.Labels, scope) {
+			return true
+		}
 	}
 
-	// Verify that after the prefix is a separater so that the access namespace
-	// doesn't accidentally match a partial namespace
-	if ns[len(val)] != '/' {
+	return false
+}
+
+// hasLabels returns true if ls has every label in want. Unlike
+// LabelSet.Matches, neither set has to be sorted, as hubs report their
+// location labels as configured.
+func hasLabels(ls, want *pb.LabelSet) bool {
+outer:
+	for _, w := range want.Labels {
+		for _, lbl := range ls.Labels {
+			if strings.EqualFold(lbl.Name, w.Name) && strings.EqualFold(lbl.Value, w.Value) {
+				continue outer
+			}
+		}
+
 		return false
 	}
 
 	return true
 }
+
+// NamespaceIncludes returns true if ns is parent or is below parent in the
+// namespace hierarchy. Namespaces are separated by '/', so "/foo" includes
+// "/foo/bar" but not "/foobar", and "/" includes every namespace.
+func NamespaceIncludes(parent, ns string) bool {
+	if ns == parent {
+		return true
+	}
+
+	return strings.HasPrefix(ns, NamespaceChildPrefix(parent))
+}
+
+// NamespaceChildPrefix returns the prefix that all namespaces below ns
+// start with.
+func NamespaceChildPrefix(ns string) string {
+	if strings.HasSuffix(ns, "/") {
+		return ns
+	}
+
+	return ns + "/"
+}
@@ -164,4 +164,136 @@ func TestToken(t *testing.T) {
 		assert.True(t, errors.Is(err, ErrNoLongerValid))
 	})
 
+	t.Run("allows access to namespaces below the access namespace", func(t *testing.T) {
+		cases := []struct {
+			access, ns string
+			allowed    bool
+		}{
+			{"/foo", "/foo", true},
+			{"/foo", "/foo/bar", true},
+			{"/foo", "/foo/bar/baz", true},
+			{"/foo/", "/foo/bar", true},
+			{"/", "/foo", true},
+			{"/foo", "/foobar", false},
+			{"/foo", "/bar", false},
+			{"/foo/bar", "/foo", false},
+			{"/foo", "", false},
+		}
+
+		for _, c := range cases {
+			var tc TokenCreator
+			tc.Role = pb.MANAGE
+			tc.Capabilities = map[pb.Capability]string{
+				pb.ACCESS: c.access,
+			}
+
+			pub, key, err := ed25519.GenerateKey(rand.Reader)
+			require.NoError(t, err)
+
+			stoken, err := tc.EncodeED25519(key, "k1")
+			require.NoError(t, err)
+
+			vt, err := CheckTokenED25519(stoken, pub)
+			require.NoError(t, err)
+
+			assert.Equal(t, c.allowed, vt.AllowAccount(c.ns), "access=%s ns=%s", c.access, c.ns)
+			assert.Equal(t, c.allowed, NamespaceIncludes(c.access, c.ns), "access=%s ns=%s", c.access, c.ns)
+		}
+	})
+
+	t.Run("requires the access capability", func(t *testing.T) {
+		var tc TokenCreator
+		tc.Role = pb.MANAGE
+
+		pub, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		stoken, err := tc.EncodeED25519(key, "k1")
+		require.NoError(t, err)
+
+		vt, err := CheckTokenED25519(stoken, pub)
+		require.NoError(t, err)
+
+		assert.False(t, vt.AllowAccount("/foo"))
+	})
+
+	t.Run("limits scoped tokens to hubs with matching locations", func(t *testing.T) {
+		loc := func(labels string) []*pb.NetworkLocation {
+			return []*pb.NetworkLocation{
+				{Labels: pb.ParseLabelSet(labels)},
+			}
+		}
+
+		cases := []struct {
+			scope   string
+			locs    []*pb.NetworkLocation
+			allowed bool
+		}{
+			{"", loc("region=us-east"), true},
+			{"", nil, true},
+			{"region=us-east", loc("region=us-east"), true},
+			{"region=us-east", loc("zone=b,region=us-east"), true},
+			{"region=us-east", append(loc("region=eu-west"), loc("region=us-east")...), true},
+			{"region=us-east", loc("region=eu-west"), false},
+			{"region=us-east,zone=a", loc("region=us-east,zone=b"), false},
+			{"region=us-east", nil, false},
+		}
+
+		for _, c := range cases {
+			var tc TokenCreator
+			tc.AccountId = pb.NewULID()
+			tc.AccuntNamespace = "/test"
+			tc.Capabilities = map[pb.Capability]string{
+				pb.CONNECT: "",
+			}
+
+			if c.scope != "" {
+				tc.Capabilities[pb.HUB_SCOPE] = c.scope
+			}
+
+			pub, key, err := ed25519.GenerateKey(rand.Reader)
+			require.NoError(t, err)
+
+			stoken, err := tc.EncodeED25519(key, "k1")
+			require.NoError(t, err)
+
+			vt, err := CheckTokenED25519(stoken, pub)
+			require.NoError(t, err)
+
+			assert.Equal(t, c.allowed, vt.AllowHub(c.locs), "scope=%s", c.scope)
+		}
+	})
+
+	t.Run("gives tokens without a hub scope every hub", func(t *testing.T) {
+		for _, scope := range []*string{nil, new(string)} {
+			var tc TokenCreator
+			tc.AccountId = pb.NewULID()
+			tc.AccuntNamespace = "/test"
+			tc.Capabilities = map[pb.Capability]string{
+				pb.CONNECT: "",
+			}
+
+			// Set but empty is the same as not set.
+			if scope != nil {
+				tc.Capabilities[pb.HUB_SCOPE] = *scope
+			}
+
+			pub, key, err := ed25519.GenerateKey(rand.Reader)
+			require.NoError(t, err)
+
+			stoken, err := tc.EncodeED25519(key, "k1")
+			require.NoError(t, err)
+
+			vt, err := CheckTokenED25519(stoken, pub)
+			require.NoError(t, err)
+
+			assert.Nil(t, vt.HubScope())
+
+			assert.True(t, vt.AllowHub(nil))
+			assert.True(t, vt.AllowHub([]*pb.NetworkLocation{{}}))
+			assert.True(t, vt.AllowHub([]*pb.NetworkLocation{
+				{Labels: pb.ParseLabelSet("region=us-east")},
+			}))
+		}
+	})
 }
@@ -0,0 +1,66 @@
+package web
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/pkg/errors"
+)
+
+// The label a service can use to limit the size of the request bodies it's
+// sent, in bytes, such as ":max-body=1048576". It overrides the Frontend's
+// MaxRequestBody, with zero meaning no limit.
+const MaxBodyLabel = ":max-body"
+
+var ErrBodyTooLarge = errors.New("request body too large")
+
+// maxRequestBody returns the largest request body rs accepts, or 0 if there's
+// no limit.
+func (f *Frontend) maxRequestBody(rs *pb.ServiceRoute) int64 {
+	if rs.Labels != nil {
+		if val, ok := rs.Labels.GetLabel(MaxBodyLabel); ok {
+			max, err := strconv.ParseInt(val, 10, 64)
+			if err == nil && max >= 0 {
+				return max
+			}
+
+			f.L.Warn("ignoring invalid max body label", "service-id", rs.Id, "value", val)
+		}
+	}
+
+	return f.MaxRequestBody
+}
+
+// bodyLimit reads at most max bytes from r, which is a request body that didn't
+// declare its length. Reading past that ends with an error and sets over, so
+// the request can be rejected rather than passed on cut short.
+type bodyLimit struct {
+	r    io.Reader
+	max  int64
+	read int64
+	over bool
+}
+
+func (b *bodyLimit) Read(p []byte) (int, error) {
+	if b.over {
+		return 0, ErrBodyTooLarge
+	}
+
+	// Read one byte past the limit, to tell a body that's exactly at the
+	// limit from one that's over it.
+	if rem := b.max - b.read + 1; int64(len(p)) > rem {
+		p = p[:rem]
+	}
+
+	n, err := b.r.Read(p)
+
+	b.read += int64(n)
+
+	if b.read > b.max {
+		b.over = true
+		return n - int(b.read-b.max), ErrBodyTooLarge
+	}
+
+	return n, err
+}
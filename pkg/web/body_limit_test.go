@@ -0,0 +1,47 @@
+package web
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBodyLimit(t *testing.T) {
+	t.Run("reads bodies up to the limit", func(t *testing.T) {
+		for _, body := range []string{"", "under", "exactly10!"} {
+			bl := &bodyLimit{r: strings.NewReader(body), max: 10}
+
+			data, err := ioutil.ReadAll(bl)
+			assert.NoError(t, err)
+			assert.Equal(t, body, string(data))
+			assert.False(t, bl.over)
+		}
+	})
+
+	t.Run("stops at the limit", func(t *testing.T) {
+		bl := &bodyLimit{r: strings.NewReader("eleven byte"), max: 10}
+
+		data, err := ioutil.ReadAll(bl)
+		assert.Equal(t, ErrBodyTooLarge, err)
+		assert.Equal(t, "eleven byt", string(data))
+		assert.True(t, bl.over)
+	})
+
+	t.Run("uses the limit from the service's label", func(t *testing.T) {
+		f := &Frontend{L: hclog.NewNullLogger(), MaxRequestBody: 100}
+
+		route := func(labels string) *pb.ServiceRoute {
+			return &pb.ServiceRoute{Labels: pb.ParseLabelSet(labels)}
+		}
+
+		assert.Equal(t, int64(100), f.maxRequestBody(&pb.ServiceRoute{}))
+		assert.Equal(t, int64(100), f.maxRequestBody(route("env=test")))
+		assert.Equal(t, int64(5), f.maxRequestBody(route("env=test,:max-body=5")))
+		assert.Equal(t, int64(0), f.maxRequestBody(route(":max-body=0")))
+		assert.Equal(t, int64(100), f.maxRequestBody(route(":max-body=lots")))
+	})
+}
@@ -0,0 +1,312 @@
+package web
+
+import (
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/horizon/pkg/clock"
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// BreakerState is the state of the circuit breaker of a service.
+type BreakerState int
+
+const (
+	// Requests go to the service as usual.
+	BreakerClosed BreakerState = iota
+
+	// The service failed too many times in a row, so it's skipped until the
+	// cooldown passes.
+	BreakerOpen
+
+	// The cooldown passed, and a single request is let through to probe
+	// whether the service has recovered.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// The circuit breaker settings used when CircuitBreakers doesn't set its own.
+const (
+	DefaultBreakerFailures = 5
+	DefaultBreakerCooldown = 30 * time.Second
+	DefaultBreakerIdle     = 10 * time.Minute
+)
+
+// CircuitBreakers keeps a circuit breaker for each service, so a service that
+// keeps failing is skipped for a while rather than every request paying for
+// trying it first. After Failures failures in a row, a service's breaker opens
+// and the service is skipped for Cooldown. Then a single request is let
+// through as a probe: if it succeeds the breaker closes, and if it fails the
+// breaker opens for another Cooldown.
+//
+// A breaker that no request has used for Idle is forgotten, so the breakers of
+// services that failed a few times and then went away don't pile up.
+//
+// The methods are safe to call on a nil *CircuitBreakers, which lets every
+// request through.
+type CircuitBreakers struct {
+	// How many failures in a row open a breaker. Zero uses
+	// DefaultBreakerFailures.
+	Failures int
+
+	// How long an open breaker stays open, and how long a probe has to
+	// report back before another one is let through. Zero uses
+	// DefaultBreakerCooldown.
+	Cooldown time.Duration
+
+	// How long a breaker is kept without being used. It's never less than
+	// the cooldown, so open breakers aren't forgotten before they'd have
+	// let a probe through anyway. Zero uses DefaultBreakerIdle.
+	Idle time.Duration
+
+	// Tells the time breakers open at. Defaults to clock.Real.
+	Clock clock.Clock
+
+	mu sync.Mutex
+
+	// Only services that have failed since they last succeeded have a
+	// breaker, so this doesn't grow with every service seen.
+	breakers map[string]*breaker
+
+	// When idle breakers were last looked for.
+	lastSweep time.Time
+}
+
+type breaker struct {
+	state    BreakerState
+	failures int
+
+	// When the breaker opened, or when the probe was let through.
+	since time.Time
+
+	// When a request last asked about or failed on the service.
+	used time.Time
+}
+
+// NewCircuitBreakers returns CircuitBreakers that open after failures
+// failures in a row and stay open for cooldown.
+func NewCircuitBreakers(failures int, cooldown time.Duration) *CircuitBreakers {
+	return &CircuitBreakers{
+		Failures: failures,
+		Cooldown: cooldown,
+	}
+}
+
+func (c *CircuitBreakers) now() time.Time {
+	if c.Clock != nil {
+		return c.Clock.Now()
+	}
+
+	return clock.Real.Now()
+}
+
+func (c *CircuitBreakers) failures() int {
+	if c.Failures > 0 {
+		return c.Failures
+	}
+
+	return DefaultBreakerFailures
+}
+
+func (c *CircuitBreakers) cooldown() time.Duration {
+	if c.Cooldown > 0 {
+		return c.Cooldown
+	}
+
+	return DefaultBreakerCooldown
+}
+
+func (c *CircuitBreakers) idle() time.Duration {
+	idle := c.Idle
+	if idle <= 0 {
+		idle = DefaultBreakerIdle
+	}
+
+	if cooldown := c.cooldown(); idle < cooldown {
+		return cooldown
+	}
+
+	return idle
+}
+
+// sweep forgets the breakers that haven't been used for the idle time. It
+// only looks once per idle time, so it's cheap to call on every failure.
+// Must be called with mu held.
+func (c *CircuitBreakers) sweep(now time.Time) {
+	idle := c.idle()
+
+	if now.Sub(c.lastSweep) < idle {
+		return
+	}
+
+	c.lastSweep = now
+
+	var removed bool
+
+	for key, b := range c.breakers {
+		if now.Sub(b.used) >= idle {
+			delete(c.breakers, key)
+			removed = removed || b.state != BreakerClosed
+		}
+	}
+
+	if removed {
+		c.reportStates()
+	}
+}
+
+// Allow returns true if a request can be sent to service. Once the cooldown
+// of an open breaker has passed, the first caller is let through as the probe
+// and the breaker becomes half-open. The caller must then report how the
+// request went with Success or Failure.
+func (c *CircuitBreakers) Allow(service *pb.ULID) bool {
+	if c == nil {
+		return true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.breakers[service.SpecString()]
+	if !ok {
+		return true
+	}
+
+	now := c.now()
+
+	b.used = now
+
+	if b.state == BreakerClosed {
+		return true
+	}
+
+	// A half-open breaker waits the same cooldown on its probe, so a probe
+	// that never reports back doesn't keep the service skipped forever.
+	if now.Sub(b.since) < c.cooldown() {
+		return false
+	}
+
+	b.state = BreakerHalfOpen
+	b.since = now
+
+	c.reportStates()
+
+	return true
+}
+
+// Success records that a request to service succeeded, closing its breaker.
+func (c *CircuitBreakers) Success(service *pb.ULID) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := service.SpecString()
+
+	b, ok := c.breakers[key]
+	if !ok {
+		return
+	}
+
+	delete(c.breakers, key)
+
+	if b.state != BreakerClosed {
+		c.reportStates()
+	}
+}
+
+// Failure records that a request to service failed, opening its breaker if
+// it's failed too many times in a row or its probe failed.
+func (c *CircuitBreakers) Failure(service *pb.ULID) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := service.SpecString()
+
+	now := c.now()
+
+	b, ok := c.breakers[key]
+	if !ok {
+		if c.breakers == nil {
+			c.breakers = make(map[string]*breaker)
+		}
+
+		c.sweep(now)
+
+		b = &breaker{}
+		c.breakers[key] = b
+	}
+
+	b.failures++
+	b.used = now
+
+	switch b.state {
+	case BreakerOpen:
+		// Requests let through before the breaker opened are still
+		// finishing, they don't extend the cooldown.
+		return
+	case BreakerClosed:
+		if b.failures < c.failures() {
+			return
+		}
+	}
+
+	b.state = BreakerOpen
+	b.since = now
+
+	metrics.IncrCounter([]string{"web", "breaker", "trips"}, 1)
+
+	c.reportStates()
+}
+
+// State returns the state of the breaker of service.
+func (c *CircuitBreakers) State(service *pb.ULID) BreakerState {
+	if c == nil {
+		return BreakerClosed
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.breakers[service.SpecString()]
+	if !ok {
+		return BreakerClosed
+	}
+
+	return b.state
+}
+
+// reportStates sets the gauges of how many breakers are open and half-open.
+// Must be called with mu held.
+func (c *CircuitBreakers) reportStates() {
+	var open, halfOpen int
+
+	for _, b := range c.breakers {
+		switch b.state {
+		case BreakerOpen:
+			open++
+		case BreakerHalfOpen:
+			halfOpen++
+		}
+	}
+
+	metrics.SetGauge([]string{"web", "breaker", "open"}, float32(open))
+	metrics.SetGauge([]string{"web", "breaker", "half_open"}, float32(halfOpen))
+}
@@ -0,0 +1,142 @@
+package web
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/clock"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreakers(t *testing.T) {
+	t.Run("opens after failures in a row", func(t *testing.T) {
+		fake := clock.NewFake(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC))
+
+		cb := NewCircuitBreakers(3, time.Minute)
+		cb.Clock = fake
+
+		svc := pb.NewULID()
+		other := pb.NewULID()
+
+		cb.Failure(svc)
+		cb.Failure(svc)
+		cb.Success(svc)
+
+		// The success reset the count.
+		cb.Failure(svc)
+		cb.Failure(svc)
+		assert.True(t, cb.Allow(svc))
+		assert.Equal(t, BreakerClosed, cb.State(svc))
+
+		cb.Failure(svc)
+		assert.Equal(t, BreakerOpen, cb.State(svc))
+		assert.False(t, cb.Allow(svc))
+
+		assert.True(t, cb.Allow(other))
+
+		fake.Advance(30 * time.Second)
+		assert.False(t, cb.Allow(svc))
+	})
+
+	t.Run("lets a single probe through once the cooldown passes", func(t *testing.T) {
+		fake := clock.NewFake(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC))
+
+		cb := NewCircuitBreakers(1, time.Minute)
+		cb.Clock = fake
+
+		svc := pb.NewULID()
+
+		cb.Failure(svc)
+		assert.False(t, cb.Allow(svc))
+
+		fake.Advance(time.Minute)
+
+		assert.True(t, cb.Allow(svc))
+		assert.Equal(t, BreakerHalfOpen, cb.State(svc))
+		assert.False(t, cb.Allow(svc))
+
+		// The probe failed, so it's another cooldown until the next one.
+		cb.Failure(svc)
+		assert.Equal(t, BreakerOpen, cb.State(svc))
+		assert.False(t, cb.Allow(svc))
+
+		fake.Advance(time.Minute)
+
+		assert.True(t, cb.Allow(svc))
+
+		cb.Success(svc)
+		assert.Equal(t, BreakerClosed, cb.State(svc))
+		assert.True(t, cb.Allow(svc))
+		assert.True(t, cb.Allow(svc))
+	})
+
+	t.Run("lets another probe through if one never reports back", func(t *testing.T) {
+		fake := clock.NewFake(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC))
+
+		cb := NewCircuitBreakers(1, time.Minute)
+		cb.Clock = fake
+
+		svc := pb.NewULID()
+
+		cb.Failure(svc)
+		fake.Advance(time.Minute)
+
+		assert.True(t, cb.Allow(svc))
+		assert.False(t, cb.Allow(svc))
+
+		fake.Advance(time.Minute)
+
+		assert.True(t, cb.Allow(svc))
+	})
+
+	t.Run("forgets breakers that go unused", func(t *testing.T) {
+		fake := clock.NewFake(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC))
+
+		cb := NewCircuitBreakers(3, time.Minute)
+		cb.Clock = fake
+		cb.Idle = 10 * time.Minute
+
+		stale := pb.NewULID()
+		gone := pb.NewULID()
+		busy := pb.NewULID()
+
+		cb.Failure(stale)
+		cb.Failure(stale)
+
+		for i := 0; i < 3; i++ {
+			cb.Failure(gone)
+			cb.Failure(busy)
+		}
+
+		assert.Equal(t, BreakerOpen, cb.State(gone))
+
+		fake.Advance(5 * time.Minute)
+
+		// Still being tried, so it's kept.
+		assert.True(t, cb.Allow(busy))
+
+		fake.Advance(6 * time.Minute)
+
+		// Idle breakers are looked for when a new one is needed.
+		cb.Failure(pb.NewULID())
+
+		assert.Equal(t, 2, len(cb.breakers))
+		assert.Equal(t, BreakerHalfOpen, cb.State(busy))
+		assert.Equal(t, BreakerClosed, cb.State(gone))
+
+		// The failures of a forgotten breaker no longer count.
+		cb.Failure(stale)
+		assert.Equal(t, BreakerClosed, cb.State(stale))
+	})
+
+	t.Run("lets everything through when nil", func(t *testing.T) {
+		var cb *CircuitBreakers
+
+		svc := pb.NewULID()
+
+		cb.Failure(svc)
+		assert.True(t, cb.Allow(svc))
+		assert.Equal(t, BreakerClosed, cb.State(svc))
+	})
+}
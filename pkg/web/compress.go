@@ -0,0 +1,176 @@
+package web
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// The smallest response, in bytes, that's compressed when
+// Frontend.CompressionMinSize isn't set. Below about this size gzip's own
+// overhead can make the response larger.
+const DefaultCompressionMinSize = 1024
+
+func (f *Frontend) compressionMinSize() int {
+	if f.CompressionMinSize > 0 {
+		return f.CompressionMinSize
+	}
+
+	return DefaultCompressionMinSize
+}
+
+// compressWriter returns a writer that gzips the body of the response to req,
+// whose headers are ready to send in w, or nil if it shouldn't be compressed.
+// When a compressWriter is returned, it sends the status and headers itself
+// and must be closed once the body is written.
+func (f *Frontend) compressWriter(w http.ResponseWriter, req *http.Request, code int) *compressWriter {
+	if !f.Compress || req.Method == "HEAD" {
+		return nil
+	}
+
+	if code < 200 || code == http.StatusNoContent || code == http.StatusNotModified {
+		return nil
+	}
+
+	hdr := w.Header()
+
+	// The service compressed it already, or encoded it some other way.
+	if hdr.Get("Content-Encoding") != "" {
+		return nil
+	}
+
+	hdr.Add("Vary", "Accept-Encoding")
+
+	if !acceptsGzip(req) {
+		return nil
+	}
+
+	min := f.compressionMinSize()
+
+	cw := &compressWriter{w: w, code: code, min: min}
+
+	if cl := hdr.Get("Content-Length"); cl != "" {
+		size, err := strconv.ParseInt(cl, 10, 64)
+		if err == nil {
+			if size < int64(min) {
+				return nil
+			}
+
+			cw.start(true)
+		}
+	}
+
+	return cw
+}
+
+// compressWriter gzips a response once it's known to be at least min bytes.
+// Until then, the body is held back, and if it ends first it's sent as is.
+type compressWriter struct {
+	w    http.ResponseWriter
+	code int
+	min  int
+
+	buf     bytes.Buffer
+	started bool
+	gz      *gzip.Writer
+}
+
+// start sends the status and headers, then whatever of the body has been held
+// back, compressed or not.
+func (c *compressWriter) start(compress bool) error {
+	c.started = true
+
+	if compress {
+		hdr := c.w.Header()
+		hdr.Set("Content-Encoding", "gzip")
+		hdr.Del("Content-Length")
+
+		c.gz = gzip.NewWriter(c.w)
+	}
+
+	c.w.WriteHeader(c.code)
+
+	if c.buf.Len() == 0 {
+		return nil
+	}
+
+	var err error
+
+	if c.gz != nil {
+		_, err = c.gz.Write(c.buf.Bytes())
+	} else {
+		_, err = c.w.Write(c.buf.Bytes())
+	}
+
+	c.buf.Reset()
+
+	return err
+}
+
+func (c *compressWriter) Write(p []byte) (int, error) {
+	if c.started {
+		if c.gz != nil {
+			return c.gz.Write(p)
+		}
+
+		return c.w.Write(p)
+	}
+
+	c.buf.Write(p)
+
+	if c.buf.Len() >= c.min {
+		err := c.start(true)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Close sends the rest of the response. A body that ended before reaching min
+// is sent uncompressed.
+func (c *compressWriter) Close() error {
+	if !c.started {
+		err := c.start(false)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.gz != nil {
+		return c.gz.Close()
+	}
+
+	return nil
+}
+
+// acceptsGzip reports if the client said it can take a gzipped response.
+func acceptsGzip(req *http.Request) bool {
+	for _, v := range req.Header["Accept-Encoding"] {
+		for _, part := range strings.Split(v, ",") {
+			coding := part
+			params := ""
+
+			if idx := strings.IndexByte(part, ';'); idx != -1 {
+				coding, params = part[:idx], part[idx+1:]
+			}
+
+			coding = strings.TrimSpace(strings.ToLower(coding))
+			if coding != "gzip" && coding != "*" {
+				continue
+			}
+
+			params = strings.ReplaceAll(params, " ", "")
+			if params == "q=0" || strings.HasPrefix(params, "q=0.") && strings.Trim(params[4:], "0") == "" {
+				continue
+			}
+
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,112 @@
+package web
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompression(t *testing.T) {
+	f := &Frontend{Compress: true, CompressionMinSize: 100}
+
+	small := `{"error": "not found"}`
+	large := strings.Repeat("a large response body ", 20)
+
+	respond := func(acceptEncoding, contentLength, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/", nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+
+		w := httptest.NewRecorder()
+		if contentLength != "" {
+			w.Header().Set("Content-Length", contentLength)
+		}
+
+		cw := f.compressWriter(w, req, 200)
+		if cw == nil {
+			w.WriteHeader(200)
+			w.WriteString(body)
+			return w
+		}
+
+		// Written in pieces, like a body copied from a service.
+		for len(body) > 0 {
+			n := 10
+			if n > len(body) {
+				n = len(body)
+			}
+
+			_, err := cw.Write([]byte(body[:n]))
+			require.NoError(t, err)
+
+			body = body[n:]
+		}
+
+		require.NoError(t, cw.Close())
+
+		return w
+	}
+
+	gunzip := func(w *httptest.ResponseRecorder) string {
+		gz, err := gzip.NewReader(w.Body)
+		require.NoError(t, err)
+
+		data, err := ioutil.ReadAll(gz)
+		require.NoError(t, err)
+
+		return string(data)
+	}
+
+	t.Run("passes responses below the threshold through", func(t *testing.T) {
+		for _, cl := range []string{strconv.Itoa(len(small)), ""} {
+			w := respond("gzip, deflate", cl, small)
+
+			assert.Equal(t, 200, w.Code)
+			assert.Equal(t, "", w.Header().Get("Content-Encoding"))
+			assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+			assert.Equal(t, small, w.Body.String())
+		}
+	})
+
+	t.Run("compresses responses above the threshold", func(t *testing.T) {
+		for _, cl := range []string{strconv.Itoa(len(large)), ""} {
+			w := respond("gzip, deflate", cl, large)
+
+			assert.Equal(t, 200, w.Code)
+			assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+			assert.Equal(t, "", w.Header().Get("Content-Length"))
+			assert.True(t, w.Body.Len() < len(large))
+			assert.Equal(t, large, gunzip(w))
+		}
+	})
+
+	t.Run("only compresses for clients that accept gzip", func(t *testing.T) {
+		for _, ae := range []string{"", "deflate", "gzip;q=0", "br, gzip; q=0.0"} {
+			w := respond(ae, "", large)
+
+			assert.Equal(t, "", w.Header().Get("Content-Encoding"), ae)
+			assert.Equal(t, large, w.Body.String())
+		}
+
+		w := respond("deflate, gzip;q=0.5", "", large)
+		assert.Equal(t, large, gunzip(w))
+	})
+
+	t.Run("leaves responses the service encoded alone", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+
+		w := httptest.NewRecorder()
+		w.Header().Set("Content-Encoding", "br")
+
+		assert.Nil(t, f.compressWriter(w, req, 200))
+		assert.Nil(t, (&Frontend{}).compressWriter(httptest.NewRecorder(), req, 200))
+	})
+}
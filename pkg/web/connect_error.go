@@ -0,0 +1,96 @@
+package web
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ConnectErrorKind classifies why a Connector couldn't connect to a service.
+type ConnectErrorKind int
+
+const (
+	// The cause isn't known.
+	ConnectFailed ConnectErrorKind = iota
+
+	// There's no way to reach the service, such as its hub having no session
+	// for it or no known address.
+	ConnectNoRoute
+
+	// The service or its hub could be reached but refused or dropped the
+	// connection.
+	ConnectRefused
+
+	// The token used to connect was rejected. Every hub checks it the same
+	// way, so trying another service won't help.
+	ConnectAuthRejected
+
+	// The account or hub is over its traffic quota.
+	ConnectQuotaExceeded
+)
+
+func (k ConnectErrorKind) String() string {
+	switch k {
+	case ConnectNoRoute:
+		return "no-route"
+	case ConnectRefused:
+		return "refused"
+	case ConnectAuthRejected:
+		return "auth-rejected"
+	case ConnectQuotaExceeded:
+		return "quota-exceeded"
+	default:
+		return "failed"
+	}
+}
+
+// ConnectError is returned by a Connector to say why it couldn't connect, so
+// the frontends can decide whether to try the next service.
+type ConnectError struct {
+	Kind ConnectErrorKind
+	Err  error
+}
+
+// NewConnectError wraps err as a ConnectError of the given kind.
+func NewConnectError(kind ConnectErrorKind, err error) error {
+	return &ConnectError{Kind: kind, Err: err}
+}
+
+func (c *ConnectError) Error() string {
+	return "connect " + c.Kind.String() + ": " + c.Err.Error()
+}
+
+func (c *ConnectError) Unwrap() error {
+	return c.Err
+}
+
+func (c *ConnectError) Cause() error {
+	return c.Err
+}
+
+// Temporary returns true if another service might accept the connection.
+func (c *ConnectError) Temporary() bool {
+	return c.Kind != ConnectAuthRejected && c.Kind != ConnectQuotaExceeded
+}
+
+// ConnectErrorKindOf returns the kind of err, or ConnectFailed if it isn't a
+// ConnectError.
+func ConnectErrorKindOf(err error) ConnectErrorKind {
+	var ce *ConnectError
+	if errors.As(err, &ce) {
+		return ce.Kind
+	}
+
+	return ConnectFailed
+}
+
+// retryConnect returns true if the next service should be tried after
+// connecting failed with err. Only a ConnectError can say not to, since other
+// errors with a Temporary method, such as net errors, describe a single
+// attempt rather than every service.
+func retryConnect(err error) bool {
+	var ce *ConnectError
+	if errors.As(err, &ce) {
+		return ce.Temporary()
+	}
+
+	return true
+}
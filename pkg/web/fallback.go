@@ -0,0 +1,255 @@
+package web
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/hashicorp/horizon/pkg/control"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/pkg/errors"
+)
+
+// ResolutionCache keeps the last resolutions a Frontend's Resolver gave, such
+// as a data.Bolt, so requests can be served from them while the Resolver is
+// failing.
+type ResolutionCache interface {
+	SetLabelLinkResolution(key string, ll *pb.LabelLink) error
+	LabelLinkResolution(key string) (*pb.LabelLink, error)
+	DeleteLabelLinkResolution(key string) error
+	SetServicesResolution(key string, as *pb.AccountServices) error
+	ServicesResolution(key string) (*pb.AccountServices, error)
+}
+
+// The status returned when the Resolver fails and there's no resolution in
+// Frontend.Fallback to serve the request from instead.
+const FallbackMissStatus = http.StatusServiceUnavailable
+
+// errNoFallback is returned by Frontend.resolveLabelLink when the Resolver
+// fails and there's no resolution saved in Frontend.Fallback either.
+var errNoFallback = errors.New("no resolution saved")
+
+// How many of the resolutions last saved to Frontend.Fallback are remembered,
+// so they're only written again when they change.
+const fallbackSavedSize = 10000
+
+// fallbackSaved is what the Frontend last saved under each key in Fallback.
+type fallbackSaved struct {
+	once  sync.Once
+	cache *lru.ARCCache
+}
+
+func (fs *fallbackSaved) init() {
+	fs.once.Do(func() {
+		fs.cache, _ = lru.NewARC(fallbackSavedSize)
+	})
+}
+
+// changed reports if data differs from what was last saved under key, and if
+// so remembers it as saved.
+func (fs *fallbackSaved) changed(key string, data []byte) bool {
+	fs.init()
+
+	if v, ok := fs.cache.Get(key); ok && bytes.Equal(v.([]byte), data) {
+		return false
+	}
+
+	fs.cache.Add(key, data)
+
+	return true
+}
+
+// forget makes the next save of key write it, such as after a write failed.
+func (fs *fallbackSaved) forget(key string) {
+	fs.init()
+	fs.cache.Remove(key)
+}
+
+// PathPrefixResolver is implemented by Resolvers that know the path prefix
+// the label link a request resolves to is limited to, such as
+// *control.Client. Label link resolutions are saved in Frontend.Fallback under
+// the label and that prefix, so there's one per label link and it serves
+// every path under the prefix. With other Resolvers, label links are assumed
+// not to be limited to a path prefix, and are saved under the label alone.
+type PathPrefixResolver interface {
+	LabelLinkPathPrefix(label *pb.LabelSet, path string) string
+}
+
+// Label links are saved by the path prefix they're limited to as well as
+// label. Prefixes are matched regardless of case, so they're saved in lower
+// case.
+func labelLinkKey(label *pb.LabelSet, prefix string) string {
+	return label.SpecString() + " " + strings.ToLower(prefix)
+}
+
+// labelLinkPrefix returns the path prefix the label link that label and path
+// resolved to with r is limited to, or "" if it isn't.
+func labelLinkPrefix(r Resolver, label *pb.LabelSet, path string) string {
+	pr, ok := r.(PathPrefixResolver)
+	if !ok {
+		return ""
+	}
+
+	return pr.LabelLinkPathPrefix(label, path)
+}
+
+// fallbackPrefixes returns the path prefixes a label link that path resolves
+// to could be limited to, longest first, ending with "" for a label link that
+// isn't limited to one. Prefixes end at a "/", with or without it, the same
+// as they're matched by the control client.
+func fallbackPrefixes(path string) []string {
+	if path == "" {
+		return []string{""}
+	}
+
+	out := []string{path}
+
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i] != '/' {
+			continue
+		}
+
+		if i+1 < len(path) {
+			out = append(out, path[:i+1])
+		}
+
+		if i > 0 {
+			out = append(out, path[:i])
+		}
+	}
+
+	return append(out, "")
+}
+
+func servicesKey(account *pb.Account, target *pb.LabelSet) string {
+	return account.SpecString() + " " + target.SpecString()
+}
+
+// saveLabelLink saves what label resolved to, for paths under prefix, in
+// f.Fallback, if it's set.
+func (f *Frontend) saveLabelLink(label *pb.LabelSet, prefix string, account *pb.Account, target *pb.LabelSet, limits *pb.Account_Limits) {
+	if f.Fallback == nil {
+		return
+	}
+
+	key := labelLinkKey(label, prefix)
+
+	ll := &pb.LabelLink{
+		Account: account,
+		Labels:  label,
+		Target:  target,
+		Limits:  limits,
+	}
+
+	data, err := ll.Marshal()
+	if err != nil || !f.fallbackSaved.changed("label-link:"+key, data) {
+		return
+	}
+
+	err = f.Fallback.SetLabelLinkResolution(key, ll)
+	if err != nil {
+		f.L.Warn("error saving label link resolution", "error", err, "key", key)
+		f.fallbackSaved.forget("label-link:" + key)
+	}
+}
+
+// fallbackLabelLink returns the label link last saved for label with the
+// longest prefix that path falls under, or nil if there isn't one, after the
+// Resolver failed with resolveErr.
+func (f *Frontend) fallbackLabelLink(label *pb.LabelSet, path string, resolveErr error) *pb.LabelLink {
+	for _, prefix := range fallbackPrefixes(path) {
+		key := labelLinkKey(label, prefix)
+
+		ll, err := f.Fallback.LabelLinkResolution(key)
+		if err != nil {
+			f.L.Error("error loading label link resolution", "error", err, "key", key)
+			return nil
+		}
+
+		if ll != nil && ll.Target != nil {
+			f.L.Warn("unable to resolve label link, using the last resolution saved", "error", resolveErr, "key", key)
+			return ll
+		}
+	}
+
+	f.L.Error("unable to resolve label link, and no resolution saved", "error", resolveErr, "label", label, "path", path)
+
+	return nil
+}
+
+// dropLabelLink removes the label links saved in f.Fallback, if it's set, that
+// label and path would be served from, after the Resolver said they no longer
+// resolve to one.
+func (f *Frontend) dropLabelLink(label *pb.LabelSet, path string) {
+	if f.Fallback == nil {
+		return
+	}
+
+	for _, prefix := range fallbackPrefixes(path) {
+		key := labelLinkKey(label, prefix)
+
+		// Looked up first, so hosts that were never saved, which is most
+		// that don't resolve, don't cost a write.
+		ll, err := f.Fallback.LabelLinkResolution(key)
+		if err != nil || ll == nil {
+			continue
+		}
+
+		f.fallbackSaved.forget("label-link:" + key)
+
+		err = f.Fallback.DeleteLabelLinkResolution(key)
+		if err != nil {
+			f.L.Warn("error removing label link resolution", "error", err, "key", key)
+		}
+	}
+}
+
+// saveServices saves the services that account and target resolved to in
+// f.Fallback, if it's set. Only calc.All is saved, so requests served from
+// it don't prefer the best services.
+func (f *Frontend) saveServices(account *pb.Account, target *pb.LabelSet, calc *control.RouteCalculation) {
+	if f.Fallback == nil {
+		return
+	}
+
+	key := servicesKey(account, target)
+
+	as := &pb.AccountServices{
+		Account:  account,
+		Services: calc.All,
+	}
+
+	data, err := as.Marshal()
+	if err != nil || !f.fallbackSaved.changed("services:"+key, data) {
+		return
+	}
+
+	err = f.Fallback.SetServicesResolution(key, as)
+	if err != nil {
+		f.L.Warn("error saving services resolution", "error", err, "key", key)
+		f.fallbackSaved.forget("services:" + key)
+	}
+}
+
+// fallbackServices returns the services last saved for account and target,
+// or nil if there aren't any, after the Resolver failed with resolveErr.
+func (f *Frontend) fallbackServices(account *pb.Account, target *pb.LabelSet, resolveErr error) *control.RouteCalculation {
+	key := servicesKey(account, target)
+
+	as, err := f.Fallback.ServicesResolution(key)
+	if err != nil {
+		f.L.Error("error loading services resolution", "error", err, "key", key)
+		return nil
+	}
+
+	if as == nil {
+		f.L.Error("unable to resolve services, and no resolution saved", "error", resolveErr, "key", key)
+		return nil
+	}
+
+	f.L.Warn("unable to resolve services, using the last resolution saved", "error", resolveErr, "key", key)
+
+	return &control.RouteCalculation{All: as.Services}
+}
@@ -0,0 +1,363 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/hashicorp/horizon/pkg/control"
+	"github.com/hashicorp/horizon/pkg/data"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// downResolver resolves every hostname to a single service of one account,
+// unless it's been set to fail, like control being unreachable.
+type downResolver struct {
+	account *pb.Account
+	service *pb.ServiceRoute
+
+	linkDown     bool
+	servicesDown bool
+
+	// Set to resolve hostnames to nothing, like when their label link is
+	// removed.
+	unlinked bool
+
+	// Set to take until the request's deadline to fail.
+	hang bool
+}
+
+var errControlDown = errors.New("control unreachable")
+
+func (r *downResolver) ResolvePathLabelLink(ctx context.Context, label *pb.LabelSet, path string) (*pb.Account, *pb.LabelSet, *pb.Account_Limits, error) {
+	if r.hang {
+		<-ctx.Done()
+		return nil, nil, nil, ctx.Err()
+	}
+
+	if r.linkDown {
+		return nil, nil, nil, errControlDown
+	}
+
+	if r.unlinked {
+		return nil, nil, nil, nil
+	}
+
+	return r.account, pb.ParseLabelSet("service=www"), nil, nil
+}
+
+func (r *downResolver) LookupService(ctx context.Context, account *pb.Account, labels *pb.LabelSet) (*control.RouteCalculation, error) {
+	if r.hang {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	if r.servicesDown {
+		return nil, errControlDown
+	}
+
+	return &control.RouteCalculation{All: []*pb.ServiceRoute{r.service}}, nil
+}
+
+// prefixResolver is a downResolver whose label links are limited to prefix.
+type prefixResolver struct {
+	*downResolver
+	prefix string
+}
+
+func (r *prefixResolver) LabelLinkPathPrefix(label *pb.LabelSet, path string) string {
+	if strings.HasPrefix(path, r.prefix) {
+		return r.prefix
+	}
+
+	return ""
+}
+
+func TestFallbackResolution(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hzn-fallback")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	openStore := func(t *testing.T, name string) *data.Bolt {
+		db, err := data.NewBolt(filepath.Join(dir, name))
+		require.NoError(t, err)
+
+		return db
+	}
+
+	newFrontend := func(r Resolver, c Connector, fallback ResolutionCache) *Frontend {
+		rates, err := lru.NewARC(10)
+		require.NoError(t, err)
+
+		return &Frontend{
+			L:        hclog.NewNullLogger(),
+			hub:      c,
+			Resolver: r,
+			rates:    rates,
+			Fallback: fallback,
+		}
+	}
+
+	newResolver := func() *downResolver {
+		return &downResolver{
+			account: &pb.Account{Namespace: "/", AccountId: pb.NewULID()},
+			service: &pb.ServiceRoute{
+				Hub:  pb.NewULID(),
+				Id:   pb.NewULID(),
+				Type: "http",
+			},
+		}
+	}
+
+	servePath := func(f *Frontend, host, path string) int {
+		w := httptest.NewRecorder()
+		f.ServeHTTP(w, httptest.NewRequest("GET", "http://"+host+path, nil))
+
+		return w.Code
+	}
+
+	serve := func(f *Frontend, host string) int {
+		return servePath(f, host, "/")
+	}
+
+	// The connector refuses every connection, so requests that get as far
+	// as connecting to a service end with a 500, and are told apart from
+	// those that don't by its count.
+
+	t.Run("fails requests without a fallback", func(t *testing.T) {
+		r := newResolver()
+		r.linkDown = true
+
+		conn := &countingConnector{}
+
+		f := newFrontend(r, conn, nil)
+
+		assert.Equal(t, http.StatusInternalServerError, serve(f, "app.example.com"))
+		assert.Equal(t, 0, conn.count(r.service))
+	})
+
+	t.Run("returns a 503 when nothing is saved", func(t *testing.T) {
+		db := openStore(t, "cold.db")
+		defer db.Close()
+
+		r := newResolver()
+		r.linkDown = true
+
+		conn := &countingConnector{}
+
+		f := newFrontend(r, conn, db)
+
+		assert.Equal(t, FallbackMissStatus, serve(f, "app.example.com"))
+		assert.Equal(t, 0, conn.count(r.service))
+
+		// The label link resolves, but the services don't.
+		r.linkDown = false
+		r.servicesDown = true
+
+		assert.Equal(t, FallbackMissStatus, serve(f, "app.example.com"))
+		assert.Equal(t, 0, conn.count(r.service))
+	})
+
+	t.Run("serves from the last resolution when the resolver fails", func(t *testing.T) {
+		db := openStore(t, "warm.db")
+		defer db.Close()
+
+		r := newResolver()
+		conn := &countingConnector{}
+
+		f := newFrontend(r, conn, db)
+
+		assert.Equal(t, http.StatusInternalServerError, serve(f, "app.example.com"))
+		assert.Equal(t, 1, conn.count(r.service))
+
+		r.servicesDown = true
+
+		assert.Equal(t, http.StatusInternalServerError, serve(f, "app.example.com"))
+		assert.Equal(t, 2, conn.count(r.service))
+
+		r.linkDown = true
+
+		assert.Equal(t, http.StatusInternalServerError, serve(f, "app.example.com"))
+		assert.Equal(t, 3, conn.count(r.service))
+
+		// Only what was resolved before is saved.
+		assert.Equal(t, FallbackMissStatus, serve(f, "other.example.com"))
+		assert.Equal(t, 3, conn.count(r.service))
+	})
+
+	t.Run("serves every path of a host from one resolution", func(t *testing.T) {
+		db := openStore(t, "paths.db")
+		defer db.Close()
+
+		r := newResolver()
+		conn := &countingConnector{}
+
+		f := newFrontend(r, conn, db)
+
+		assert.Equal(t, http.StatusInternalServerError, servePath(f, "app.example.com", "/a"))
+		assert.Equal(t, http.StatusInternalServerError, servePath(f, "app.example.com", "/b"))
+		assert.Equal(t, 2, conn.count(r.service))
+
+		// Saved once for the host, not once per path.
+		ll, err := db.LabelLinkResolution(labelLinkKey(pb.ParseLabelSet(":hostname=app.example.com"), ""))
+		require.NoError(t, err)
+		require.NotNil(t, ll)
+
+		ll, err = db.LabelLinkResolution(labelLinkKey(pb.ParseLabelSet(":hostname=app.example.com"), "/a"))
+		require.NoError(t, err)
+		assert.Nil(t, ll)
+
+		r.linkDown = true
+		r.servicesDown = true
+
+		assert.Equal(t, http.StatusInternalServerError, servePath(f, "app.example.com", "/never/seen"))
+		assert.Equal(t, 3, conn.count(r.service))
+	})
+
+	t.Run("saves label links limited to a path prefix under it", func(t *testing.T) {
+		db := openStore(t, "prefix.db")
+		defer db.Close()
+
+		r := &prefixResolver{downResolver: newResolver(), prefix: "/api"}
+		conn := &countingConnector{}
+
+		f := newFrontend(r, conn, db)
+
+		assert.Equal(t, http.StatusInternalServerError, servePath(f, "app.example.com", "/api/users"))
+		assert.Equal(t, 1, conn.count(r.service))
+
+		r.linkDown = true
+		r.servicesDown = true
+
+		assert.Equal(t, http.StatusInternalServerError, servePath(f, "app.example.com", "/API/orders"))
+		assert.Equal(t, 2, conn.count(r.service))
+
+		// Paths outside the prefix were never resolved.
+		assert.Equal(t, FallbackMissStatus, servePath(f, "app.example.com", "/apis"))
+		assert.Equal(t, FallbackMissStatus, servePath(f, "app.example.com", "/"))
+		assert.Equal(t, 2, conn.count(r.service))
+	})
+
+	t.Run("serves from the last resolution when the resolver times out", func(t *testing.T) {
+		db := openStore(t, "timeout.db")
+		defer db.Close()
+
+		r := newResolver()
+		conn := &countingConnector{}
+
+		f := newFrontend(r, conn, db)
+		f.ResolveTimeout = 50 * time.Millisecond
+
+		assert.Equal(t, http.StatusInternalServerError, serve(f, "app.example.com"))
+		assert.Equal(t, 1, conn.count(r.service))
+
+		r.hang = true
+
+		assert.Equal(t, http.StatusInternalServerError, serve(f, "app.example.com"))
+		assert.Equal(t, 2, conn.count(r.service))
+
+		// Without a saved resolution, it's still a timeout.
+		w := httptest.NewRecorder()
+		f.ServeHTTP(w, httptest.NewRequest("GET", "http://other.example.com/", nil))
+
+		assert.Equal(t, ResolveTimeoutStatus, w.Code)
+		assert.Equal(t, "resolve", w.Header().Get("X-Horizon-Timeout"))
+	})
+
+	t.Run("forgets hosts that stop resolving", func(t *testing.T) {
+		db := openStore(t, "forget.db")
+		defer db.Close()
+
+		r := newResolver()
+		conn := &countingConnector{}
+
+		f := newFrontend(r, conn, db)
+
+		assert.Equal(t, http.StatusInternalServerError, serve(f, "app.example.com"))
+		assert.Equal(t, 1, conn.count(r.service))
+
+		r.unlinked = true
+
+		assert.Equal(t, http.StatusInternalServerError, serve(f, "app.example.com"))
+		assert.Equal(t, 1, conn.count(r.service))
+
+		r.unlinked = false
+		r.linkDown = true
+
+		assert.Equal(t, FallbackMissStatus, serve(f, "app.example.com"))
+		assert.Equal(t, 1, conn.count(r.service))
+	})
+
+	t.Run("keeps resolutions across a restart", func(t *testing.T) {
+		db := openStore(t, "restart.db")
+
+		r := newResolver()
+		conn := &countingConnector{}
+
+		f := newFrontend(r, conn, db)
+
+		assert.Equal(t, http.StatusInternalServerError, serve(f, "app.example.com"))
+		assert.Equal(t, 1, conn.count(r.service))
+
+		require.NoError(t, db.Close())
+
+		db = openStore(t, "restart.db")
+		defer db.Close()
+
+		down := &downResolver{linkDown: true, servicesDown: true}
+
+		f = newFrontend(down, conn, db)
+
+		assert.Equal(t, http.StatusInternalServerError, serve(f, "app.example.com"))
+		assert.Equal(t, 2, conn.count(r.service))
+	})
+
+	t.Run("only saves resolutions that changed", func(t *testing.T) {
+		db := openStore(t, "changes.db")
+		defer db.Close()
+
+		r := newResolver()
+		conn := &countingConnector{}
+
+		f := newFrontend(r, conn, db)
+
+		serve(f, "app.example.com")
+
+		key := servicesKey(r.account, pb.ParseLabelSet("service=www"))
+
+		// Changed behind the Frontend's back, so it can be seen whether it
+		// saves it again.
+		require.NoError(t, db.SetServicesResolution(key, &pb.AccountServices{Account: r.account}))
+
+		serve(f, "app.example.com")
+
+		as, err := db.ServicesResolution(key)
+		require.NoError(t, err)
+		assert.Len(t, as.Services, 0)
+
+		r.service = &pb.ServiceRoute{
+			Hub:  pb.NewULID(),
+			Id:   pb.NewULID(),
+			Type: "http",
+		}
+
+		serve(f, "app.example.com")
+
+		as, err = db.ServicesResolution(key)
+		require.NoError(t, err)
+		require.Len(t, as.Services, 1)
+		assert.True(t, r.service.Id.Equal(as.Services[0].Id))
+	})
+}
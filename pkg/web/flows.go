@@ -0,0 +1,77 @@
+package web
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// How often the traffic proxied to each service is reported, when
+// Frontend.FlowInterval isn't set.
+var DefaultFlowInterval = 10 * time.Second
+
+// webFlows sums up the traffic proxied to each service until it's reported.
+// Each service's traffic is reported under the service's id as the flow id,
+// so a service is one flow however many requests it gets, rather than each
+// request being a flow of its own.
+type webFlows struct {
+	mu      sync.Mutex
+	streams map[string]*pb.FlowStream
+
+	// Set while a report of the streams is scheduled.
+	scheduled bool
+}
+
+// addFlow adds the traffic of a request to the flow of the service it went
+// to, and schedules the flows to be reported if they aren't already.
+func (f *Frontend) addFlow(fs *pb.FlowStream) {
+	f.flows.mu.Lock()
+	defer f.flows.mu.Unlock()
+
+	if f.flows.streams == nil {
+		f.flows.streams = make(map[string]*pb.FlowStream)
+	}
+
+	key := fs.ServiceId.SpecString()
+
+	if cur, ok := f.flows.streams[key]; ok {
+		cur.EndedAt = fs.EndedAt
+		cur.NumMessages += fs.NumMessages
+		cur.NumBytes += fs.NumBytes
+		cur.Duration += fs.Duration
+	} else {
+		fs.FlowId = fs.ServiceId
+		f.flows.streams[key] = fs
+	}
+
+	if !f.flows.scheduled {
+		f.flows.scheduled = true
+
+		interval := f.FlowInterval
+		if interval <= 0 {
+			interval = DefaultFlowInterval
+		}
+
+		time.AfterFunc(interval, f.FlushFlows)
+	}
+}
+
+// FlushFlows reports the traffic summed up since the last report right away,
+// such as before the Frontend is shut down. Otherwise it's reported every
+// FlowInterval while there's traffic.
+func (f *Frontend) FlushFlows() {
+	f.flows.mu.Lock()
+	streams := f.flows.streams
+	f.flows.streams = nil
+	f.flows.scheduled = false
+	f.flows.mu.Unlock()
+
+	if f.ReportFlow == nil {
+		return
+	}
+
+	for _, fs := range streams {
+		f.ReportFlow(&pb.FlowRecord{Stream: fs})
+	}
+}
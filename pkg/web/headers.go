@@ -0,0 +1,82 @@
+package web
+
+import (
+	"strings"
+)
+
+// HopByHopHeaders only apply to a single connection (RFC 7230, section 6.1),
+// so they're never passed between clients and services, along with any
+// headers a Connection header names.
+var HopByHopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Proxy-Connection",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// HeaderFilter decides which headers are passed between clients and services.
+// Names are matched case insensitively, and a name ending in "*" matches every
+// header starting with the rest of it, such as "X-Backend-*". Hop-by-hop
+// headers are always removed.
+type HeaderFilter struct {
+	// Headers that are removed.
+	Strip []string
+
+	// If set, only these headers are passed, less any in Strip.
+	Allow []string
+}
+
+func matchHeader(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if strings.HasSuffix(p, "*") {
+			prefix := p[:len(p)-1]
+
+			if len(name) >= len(prefix) && strings.EqualFold(name[:len(prefix)], prefix) {
+				return true
+			}
+		} else if strings.EqualFold(name, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// connectionHeaders returns the headers named by the values of the Connection
+// header, which only apply to that connection.
+func connectionHeaders(values []string) []string {
+	var names []string
+
+	for _, v := range values {
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names
+}
+
+// passes reports if the header name should be passed on. conn is the headers
+// named by the Connection header.
+func (hf *HeaderFilter) passes(name string, conn []string) bool {
+	if matchHeader(HopByHopHeaders, name) || matchHeader(conn, name) {
+		return false
+	}
+
+	if matchHeader(hf.Strip, name) {
+		return false
+	}
+
+	if len(hf.Allow) > 0 && !matchHeader(hf.Allow, name) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,130 @@
+package web
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// Hosts longer than this can't be valid DNS names, so they're never treated
+// as deployment specific.
+const maxHostLength = 255
+
+// DeployHostScheme is where a deployment specific hostname puts the deployment
+// id relative to the name, separated by "--".
+type DeployHostScheme int
+
+const (
+	// "name--deploy.domain", the default. The last "--" separates the
+	// deployment id, so names can contain "--" but ids can't.
+	DeployIdSuffix DeployHostScheme = iota
+
+	// "deploy--name.domain". The first "--" separates the deployment id, so
+	// ids can't contain "--" but names can.
+	DeployIdPrefix
+)
+
+// extractHost splits a host of the form "name--deploy.domain" into the host
+// used to look up the label link, "name.domain", and the deployment id. The
+// host comes straight from the client, so anything that doesn't have a
+// non-empty name and deployment id is returned unchanged, with false.
+func extractHost(host string) (string, string, bool) {
+	return extractDeployHost(host, DeployIdSuffix)
+}
+
+// extractDeployHost is extractHost for hosts that follow scheme.
+func extractDeployHost(host string, scheme DeployHostScheme) (string, string, bool) {
+	if len(host) > maxHostLength {
+		return host, "", false
+	}
+
+	first, domain := host, ""
+
+	if firstDot := strings.IndexByte(host, '.'); firstDot != -1 {
+		first, domain = host[:firstDot], host[firstDot:]
+	}
+
+	if scheme == DeployIdPrefix {
+		sep := strings.Index(first, "--")
+		if sep <= 0 || sep+2 >= len(first) {
+			return host, "", false
+		}
+
+		return first[sep+2:] + domain, first[:sep], true
+	}
+
+	suffixDash := strings.LastIndex(first, "--")
+	if suffixDash <= 0 || suffixDash+2 >= len(first) {
+		return host, "", false
+	}
+
+	return first[:suffixDash] + domain, first[suffixDash+2:], true
+}
+
+// extractHost splits host according to the frontend's DeployHostScheme.
+func (f *Frontend) extractHost(host string) (string, string, bool) {
+	return extractDeployHost(host, f.DeployHostScheme)
+}
+
+// deployHost is one way of reading the host of a request: the host to look up
+// the label link of, and the deployment id split out of it, if any.
+type deployHost struct {
+	host           string
+	deployId       string
+	deploySpecific bool
+}
+
+// deployHosts returns the ways of reading host that a request is resolved by,
+// in the order they're tried. Hosts in either DeployHostScheme are accepted,
+// so a frontend can move from one to the other without breaking the hosts
+// already handed out. The frontend's own scheme comes first, so it wins when
+// both readings have a label link.
+func (f *Frontend) deployHosts(host string) []deployHost {
+	name, deployId, ok := extractDeployHost(host, f.DeployHostScheme)
+
+	hosts := []deployHost{{host: name, deployId: deployId, deploySpecific: ok}}
+
+	other := DeployIdPrefix
+	if f.DeployHostScheme == DeployIdPrefix {
+		other = DeployIdSuffix
+	}
+
+	oname, odeployId, ok := extractDeployHost(host, other)
+	if ok && (oname != name || odeployId != deployId) {
+		hosts = append(hosts, deployHost{host: oname, deployId: odeployId, deploySpecific: true})
+	}
+
+	return hosts
+}
+
+// ResolveDebugRequest returns the request to pass to the control server's
+// ResolveDebug to see how this frontend would route a request for host and
+// path, with any deployment id split out of host the same way. When host can
+// be read in either DeployHostScheme, the first reading whose label link the
+// Resolver resolves is used, as it is for requests.
+func (f *Frontend) ResolveDebugRequest(ctx context.Context, host, path string) *pb.ResolveDebugRequest {
+	hosts := f.deployHosts(host)
+	dh := hosts[0]
+
+	if resolver, _ := f.backends(); resolver != nil && len(hosts) > 1 {
+		for _, h := range hosts {
+			_, target, _, err := resolver.ResolvePathLabelLink(ctx, hostnameLabels(h.host), path)
+			if err == nil && target != nil {
+				dh = h
+				break
+			}
+		}
+	}
+
+	req := &pb.ResolveDebugRequest{
+		Hostname: dh.host,
+		Path:     path,
+	}
+
+	if dh.deploySpecific {
+		req.DeploymentId = dh.deployId
+	}
+
+	return req
+}
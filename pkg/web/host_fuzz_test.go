@@ -0,0 +1,18 @@
+//go:build go1.18
+// +build go1.18
+
+package web
+
+import (
+	"testing"
+)
+
+func FuzzExtractPrefixHost(f *testing.F) {
+	for _, host := range hostSeeds {
+		f.Add(host)
+	}
+
+	f.Fuzz(func(t *testing.T, host string) {
+		checkExtractHost(t, host)
+	})
+}
@@ -0,0 +1,243 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/hashicorp/horizon/pkg/control"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hostSeeds are the tricky hosts the fuzz test starts from.
+var hostSeeds = []string{
+	"",
+	".",
+	"--",
+	"---",
+	"--.example.com",
+	".app--abc.example.com",
+	"app--.example.com",
+	"app---.example.com",
+	"app--abc",
+	"app--abc.example.com",
+	"app--abc.example.com:8080",
+	"a--b--c.example.com",
+	"app.example--abc.com",
+	"ünï--cödé.example.com",
+	"app--abc." + strings.Repeat("a", 300),
+}
+
+// checkExtractHost checks the invariants extractHost keeps for any host.
+func checkExtractHost(t *testing.T, host string) {
+	name, deployId, ok := extractHost(host)
+
+	if !ok {
+		assert.Equal(t, host, name)
+		assert.Empty(t, deployId)
+		return
+	}
+
+	require.NotEmpty(t, deployId)
+	assert.NotContains(t, deployId, ".")
+	assert.LessOrEqual(t, len(host), maxHostLength)
+
+	first := name
+	domain := ""
+
+	if dot := strings.IndexByte(name, '.'); dot != -1 {
+		first, domain = name[:dot], name[dot:]
+	}
+
+	assert.NotEmpty(t, first)
+	assert.Equal(t, host, first+"--"+deployId+domain)
+}
+
+func TestExtractHost(t *testing.T) {
+	t.Run("splits out the deployment id", func(t *testing.T) {
+		cases := []struct {
+			host, name, deployId string
+			ok                   bool
+		}{
+			{"app--abc.example.com", "app.example.com", "abc", true},
+			{"app--abc", "app", "abc", true},
+			{"a--b--c.example.com", "a--b.example.com", "c", true},
+			{"app.example.com", "app.example.com", "", false},
+			{"app.example--abc.com", "app.example--abc.com", "", false},
+			{"--abc.example.com", "--abc.example.com", "", false},
+			{"app--.example.com", "app--.example.com", "", false},
+			{"", "", "", false},
+		}
+
+		for _, c := range cases {
+			name, deployId, ok := extractHost(c.host)
+			assert.Equal(t, c.name, name, c.host)
+			assert.Equal(t, c.deployId, deployId, c.host)
+			assert.Equal(t, c.ok, ok, c.host)
+		}
+	})
+
+	t.Run("keeps its invariants for the fuzz seeds", func(t *testing.T) {
+		for _, host := range hostSeeds {
+			checkExtractHost(t, host)
+		}
+	})
+
+	t.Run("follows the frontend's deploy host scheme", func(t *testing.T) {
+		cases := []struct {
+			scheme               DeployHostScheme
+			host, name, deployId string
+			ok                   bool
+		}{
+			{DeployIdSuffix, "my-app--abcd.example.com", "my-app.example.com", "abcd", true},
+			{DeployIdSuffix, "my-app-abcd.example.com", "my-app-abcd.example.com", "", false},
+			{DeployIdSuffix, "my--app--abcd.example.com", "my--app.example.com", "abcd", true},
+			{DeployIdSuffix, "abcd--my-app.example.com", "abcd.example.com", "my-app", true},
+
+			{DeployIdPrefix, "abcd--my-app.example.com", "my-app.example.com", "abcd", true},
+			{DeployIdPrefix, "abcd--my-app-x.example.com", "my-app-x.example.com", "abcd", true},
+			{DeployIdPrefix, "abcd--my--app.example.com", "my--app.example.com", "abcd", true},
+			{DeployIdPrefix, "my-app-abcd.example.com", "my-app-abcd.example.com", "", false},
+			{DeployIdPrefix, "abcd--my-app", "my-app", "abcd", true},
+			{DeployIdPrefix, "--my-app.example.com", "--my-app.example.com", "", false},
+			{DeployIdPrefix, "abcd--.example.com", "abcd--.example.com", "", false},
+			{DeployIdPrefix, "app.abcd--example.com", "app.abcd--example.com", "", false},
+			{DeployIdPrefix, "abcd--app." + strings.Repeat("a", 300), "abcd--app." + strings.Repeat("a", 300), "", false},
+		}
+
+		for _, c := range cases {
+			f := &Frontend{DeployHostScheme: c.scheme}
+
+			name, deployId, ok := f.extractHost(c.host)
+			assert.Equal(t, c.name, name, c.host)
+			assert.Equal(t, c.deployId, deployId, c.host)
+			assert.Equal(t, c.ok, ok, c.host)
+		}
+	})
+}
+
+// hostnameResolver only resolves the label links of its hostnames, and
+// remembers the targets it looks up services for.
+type hostnameResolver struct {
+	hostnames map[string]bool
+	service   *pb.ServiceRoute
+
+	mu      sync.Mutex
+	targets []string
+}
+
+func (r *hostnameResolver) ResolvePathLabelLink(ctx context.Context, label *pb.LabelSet, path string) (*pb.Account, *pb.LabelSet, *pb.Account_Limits, error) {
+	host, _ := label.GetLabel(":hostname")
+	if !r.hostnames[host] {
+		return nil, nil, nil, nil
+	}
+
+	return &pb.Account{Namespace: "/", AccountId: pb.NewULID()}, pb.ParseLabelSet("service=www"), nil, nil
+}
+
+func (r *hostnameResolver) LookupService(ctx context.Context, account *pb.Account, labels *pb.LabelSet) (*control.RouteCalculation, error) {
+	r.mu.Lock()
+	r.targets = append(r.targets, labels.SpecString())
+	r.mu.Unlock()
+
+	return &control.RouteCalculation{All: []*pb.ServiceRoute{r.service}}, nil
+}
+
+func (r *hostnameResolver) lastTarget() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.targets) == 0 {
+		return ""
+	}
+
+	return r.targets[len(r.targets)-1]
+}
+
+func TestDeployHosts(t *testing.T) {
+	t.Run("tries the frontend's scheme first, then the other", func(t *testing.T) {
+		f := &Frontend{DeployHostScheme: DeployIdPrefix}
+
+		assert.Equal(t, []deployHost{
+			{host: "my-app.example.com", deployId: "abcd", deploySpecific: true},
+			{host: "abcd.example.com", deployId: "my-app", deploySpecific: true},
+		}, f.deployHosts("abcd--my-app.example.com"))
+
+		f.DeployHostScheme = DeployIdSuffix
+
+		assert.Equal(t, []deployHost{
+			{host: "abcd.example.com", deployId: "my-app", deploySpecific: true},
+			{host: "my-app.example.com", deployId: "abcd", deploySpecific: true},
+		}, f.deployHosts("abcd--my-app.example.com"))
+
+		// Hosts that read the same either way are only tried once.
+		assert.Equal(t, []deployHost{
+			{host: "app.example.com", deploySpecific: false},
+		}, f.deployHosts("app.example.com"))
+	})
+
+	t.Run("serves hosts in either scheme from the same frontend", func(t *testing.T) {
+		for _, scheme := range []DeployHostScheme{DeployIdSuffix, DeployIdPrefix} {
+			rates, err := lru.NewARC(10)
+			require.NoError(t, err)
+
+			r := &hostnameResolver{
+				hostnames: map[string]bool{"my-app.example.com": true},
+				service: &pb.ServiceRoute{
+					Hub:  pb.NewULID(),
+					Id:   pb.NewULID(),
+					Type: "http",
+				},
+			}
+
+			conn := &countingConnector{}
+
+			f := &Frontend{
+				L:                hclog.NewNullLogger(),
+				hub:              conn,
+				Resolver:         r,
+				rates:            rates,
+				DeployHostScheme: scheme,
+			}
+
+			for i, host := range []string{"my-app--abcd.example.com", "abcd--my-app.example.com"} {
+				w := httptest.NewRecorder()
+				f.ServeHTTP(w, httptest.NewRequest("GET", "http://"+host+"/", nil))
+
+				// The connector refuses, so getting that far is a 500.
+				assert.Equal(t, http.StatusInternalServerError, w.Code, host)
+				assert.Equal(t, i+1, conn.count(r.service), host)
+				assert.Equal(t, pb.ParseLabelSet("service=www").Add(":deployment", "abcd").SpecString(), r.lastTarget(), host)
+			}
+		}
+	})
+
+	t.Run("reads hosts for debugging the same as requests", func(t *testing.T) {
+		r := &hostnameResolver{
+			hostnames: map[string]bool{"my-app.example.com": true},
+		}
+
+		f := &Frontend{Resolver: r}
+
+		for _, host := range []string{"my-app--abcd.example.com", "abcd--my-app.example.com"} {
+			req := f.ResolveDebugRequest(context.Background(), host, "/")
+
+			assert.Equal(t, "my-app.example.com", req.Hostname, host)
+			assert.Equal(t, "abcd", req.DeploymentId, host)
+			assert.Equal(t, "/", req.Path, host)
+		}
+
+		// With neither reading resolving, the frontend's own scheme is used.
+		req := f.ResolveDebugRequest(context.Background(), "x--y.example.com", "/")
+
+		assert.Equal(t, "x.example.com", req.Hostname)
+		assert.Equal(t, "y", req.DeploymentId)
+	})
+}
@@ -0,0 +1,157 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/hashicorp/horizon/pkg/wire"
+	"github.com/pkg/errors"
+)
+
+var (
+	// The most of a request body that's buffered to mirror it when
+	// Frontend.MirrorMaxBody isn't set.
+	DefaultMirrorMaxBody int64 = 1024 * 1024
+
+	// How long a mirrored request can take when Frontend.MirrorTimeout isn't
+	// set.
+	DefaultMirrorTimeout = 30 * time.Second
+)
+
+// mirrorBuffer keeps a copy of a request body as it's sent to the primary
+// service, giving up once it's more than max.
+type mirrorBuffer struct {
+	buf  bytes.Buffer
+	max  int64
+	over bool
+}
+
+func (m *mirrorBuffer) Write(b []byte) (int, error) {
+	if m.over {
+		return len(b), nil
+	}
+
+	if int64(m.buf.Len()+len(b)) > m.max {
+		m.over = true
+		m.buf = bytes.Buffer{}
+		return len(b), nil
+	}
+
+	return m.buf.Write(b)
+}
+
+func (f *Frontend) mirrorMaxBody() int64 {
+	if f.MirrorMaxBody > 0 {
+		return f.MirrorMaxBody
+	}
+
+	return DefaultMirrorMaxBody
+}
+
+// mirrorRequest sends a copy of a request to a service matching target,
+// discarding the response. It runs in the background, so errors are only
+// logged.
+func (f *Frontend) mirrorRequest(reqId *pb.ULID, account *pb.Account, target *pb.LabelSet, wreq *pb.Request, body []byte) {
+	timeout := f.MirrorTimeout
+	if timeout <= 0 {
+		timeout = DefaultMirrorTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	code, n, err := f.sendMirror(ctx, account, target, wreq, body)
+	if err != nil {
+		f.L.Warn("error mirroring request", "id", reqId, "mirror", target, "error", err)
+		return
+	}
+
+	f.L.Debug("mirrored request", "id", reqId, "mirror", target, "code", code, "response-bytes", n)
+}
+
+func (f *Frontend) sendMirror(
+	ctx context.Context,
+	account *pb.Account,
+	target *pb.LabelSet,
+	wreq *pb.Request,
+	body []byte,
+) (int32, int64, error) {
+	resolver, connector := f.backends()
+
+	calc, err := resolver.LookupService(ctx, account, target)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "resolving mirror")
+	}
+
+	var wctx wire.Context
+
+	err = errors.New("no http services for mirror")
+
+	for _, rs := range calc.Services() {
+		if rs.Type != "http" {
+			continue
+		}
+
+		encoding := f.wireEncoding(rs)
+
+		var conn wire.Context
+
+		conn, err = connector.ConnectToService(ctx, rs, account, wire.ProtocolWithEncoding("http", encoding), f.token)
+		if err == nil {
+			wctx, err = wire.WithEncoding(conn, encoding)
+			if err == nil {
+				break
+			}
+
+			conn.Close()
+		}
+
+		if !retryConnect(err) {
+			break
+		}
+	}
+
+	if wctx == nil {
+		return 0, 0, errors.Wrapf(err, "connecting to mirror")
+	}
+
+	defer wctx.Close()
+
+	go func() {
+		<-ctx.Done()
+		wctx.Close()
+	}()
+
+	err = wctx.WriteMarshal(1, wreq)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "sending request to mirror")
+	}
+
+	w := wctx.Writer()
+
+	_, err = w.Write(body)
+	w.Close()
+
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "sending body to mirror")
+	}
+
+	var wresp pb.Response
+
+	tag, err := wctx.ReadMarshal(&wresp)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "reading response from mirror")
+	}
+
+	if tag != 1 {
+		return 0, 0, errors.Errorf("unexpected response tag from mirror: %d", tag)
+	}
+
+	n, _ := io.Copy(ioutil.Discard, wctx.Reader())
+
+	return wresp.Code, n, nil
+}
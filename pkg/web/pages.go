@@ -0,0 +1,55 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// PageSource supplies the pages served for accounts whose requests can't be
+// sent to a service, such as a data.Bolt.
+type PageSource interface {
+	AccountPages(account *pb.Account) (*pb.AccountPages, error)
+}
+
+// accountPages returns the pages of account, or nil if it has none. Failing
+// to load them isn't worth failing the request over, so errors are logged.
+func (f *Frontend) accountPages(account *pb.Account) *pb.AccountPages {
+	if f.Pages == nil {
+		return nil
+	}
+
+	pages, err := f.Pages.AccountPages(account)
+	if err != nil {
+		f.L.Error("error loading account pages", "error", err, "account", account)
+		return nil
+	}
+
+	return pages
+}
+
+// servePage writes page, or the generic error page with fallback and code if
+// page is nil.
+func servePage(w http.ResponseWriter, page *pb.StaticResponse, fallback string, code int) {
+	if page == nil {
+		renderError(w, fallback, code)
+		return
+	}
+
+	hdr := w.Header()
+
+	for _, h := range page.Headers {
+		for _, v := range h.Value {
+			hdr.Add(h.Name, v)
+		}
+	}
+
+	if page.Code != 0 {
+		code = int(page.Code)
+	} else {
+		code = http.StatusServiceUnavailable
+	}
+
+	w.WriteHeader(code)
+	w.Write(page.Body)
+}
@@ -0,0 +1,100 @@
+package web
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"golang.org/x/time/rate"
+)
+
+// RateLimiter decides whether a request may be proxied, once the account it's
+// for is known. Requests it denies get a 429 with a Retry-After header set from
+// the returned delay. It's checked before the request limits of the account.
+type RateLimiter interface {
+	Allow(account *pb.Account, clientIP string) (bool, time.Duration)
+}
+
+// AccountRateLimiter is a RateLimiter that gives each account a token bucket
+// filling at Rate and holding Burst requests. The buckets of the accounts seen
+// least recently are dropped once there are too many, which only resets them.
+type AccountRateLimiter struct {
+	rate  rate.Limit
+	burst int
+
+	mu      sync.Mutex
+	buckets *lru.ARCCache
+}
+
+// NewAccountRateLimiter returns an AccountRateLimiter letting each account make
+// r requests a second, with bursts of up to burst.
+func NewAccountRateLimiter(r rate.Limit, burst int) (*AccountRateLimiter, error) {
+	buckets, err := lru.NewARC(10000)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountRateLimiter{
+		rate:    r,
+		burst:   burst,
+		buckets: buckets,
+	}, nil
+}
+
+func (l *AccountRateLimiter) Allow(account *pb.Account, clientIP string) (bool, time.Duration) {
+	key := account.SpecString()
+
+	l.mu.Lock()
+
+	var lim *rate.Limiter
+
+	if v, ok := l.buckets.Get(key); ok {
+		lim = v.(*rate.Limiter)
+	} else {
+		lim = rate.NewLimiter(l.rate, l.burst)
+		l.buckets.Add(key, lim)
+	}
+
+	l.mu.Unlock()
+
+	res := lim.Reserve()
+	if !res.OK() {
+		return false, time.Second
+	}
+
+	delay := res.Delay()
+	if delay == 0 {
+		return true, 0
+	}
+
+	// Denied requests don't use up a token.
+	res.Cancel()
+
+	return false, delay
+}
+
+// clientIP returns the address of the client that sent req.
+func clientIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+
+	return host
+}
+
+// retryAfter formats delay for a Retry-After header, which is in whole
+// seconds, rounding up so clients don't retry too soon.
+func retryAfter(delay time.Duration) string {
+	secs := int64(math.Ceil(delay.Seconds()))
+	if secs < 1 {
+		secs = 1
+	}
+
+	return strconv.FormatInt(secs, 10)
+}
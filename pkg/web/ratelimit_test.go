@@ -0,0 +1,40 @@
+package web
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestAccountRateLimiter(t *testing.T) {
+	t.Run("gives each account its own bucket", func(t *testing.T) {
+		l, err := NewAccountRateLimiter(rate.Every(time.Minute), 2)
+		require.NoError(t, err)
+
+		a := &pb.Account{Namespace: "/", AccountId: pb.NewULID()}
+		b := &pb.Account{Namespace: "/", AccountId: pb.NewULID()}
+
+		for i := 0; i < 2; i++ {
+			ok, _ := l.Allow(a, "10.0.0.1")
+			assert.True(t, ok)
+		}
+
+		ok, delay := l.Allow(a, "10.0.0.2")
+		assert.False(t, ok)
+		assert.True(t, delay > 0 && delay <= time.Minute, "delay %s", delay)
+
+		ok, _ = l.Allow(b, "10.0.0.1")
+		assert.True(t, ok)
+	})
+
+	t.Run("rounds retry after up to whole seconds", func(t *testing.T) {
+		assert.Equal(t, "1", retryAfter(0))
+		assert.Equal(t, "1", retryAfter(10*time.Millisecond))
+		assert.Equal(t, "1", retryAfter(time.Second))
+		assert.Equal(t, "2", retryAfter(1001*time.Millisecond))
+	})
+}
@@ -0,0 +1,107 @@
+package web
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+)
+
+// SplitResolver is implemented by Resolvers that know the weighted splits of
+// label links, such as *control.Client. The Frontend only splits requests
+// between targets when its Resolver is one.
+type SplitResolver interface {
+	LabelLinkSplits(label *pb.LabelSet, path string) []*pb.TargetSplit
+}
+
+// The cookie that keeps a client on the target of a split it was first sent
+// to, when Frontend.StickySplits is set.
+const SplitCookie = "hzn-split"
+
+// splitTarget picks the target a request goes to from the splits of the label
+// link for label, as known by r, or returns target if it has none.
+func (f *Frontend) splitTarget(w http.ResponseWriter, req *http.Request, r Resolver, label, target *pb.LabelSet) *pb.LabelSet {
+	sr, ok := r.(SplitResolver)
+	if !ok {
+		return target
+	}
+
+	splits := sr.LabelLinkSplits(label, req.URL.Path)
+	if len(splits) == 0 {
+		return target
+	}
+
+	if f.StickySplits {
+		if c, err := req.Cookie(SplitCookie); err == nil {
+			for _, split := range splits {
+				// A target whose weight dropped to zero is closed to
+				// everyone, sticky or not.
+				if split.Weight > 0 && splitID(split.Target) == c.Value {
+					return split.Target
+				}
+			}
+		}
+	}
+
+	split := pickSplit(splits, rand.Float64())
+	if split == nil {
+		return target
+	}
+
+	if f.StickySplits {
+		http.SetCookie(w, &http.Cookie{
+			Name:     SplitCookie,
+			Value:    splitID(split.Target),
+			Path:     "/",
+			HttpOnly: true,
+		})
+	}
+
+	return split.Target
+}
+
+// pickSplit returns the split that u, in [0, 1), lands on when each split
+// takes up a share of the range in proportion to its weight. It returns nil
+// if none of them have a weight.
+func pickSplit(splits []*pb.TargetSplit, u float64) *pb.TargetSplit {
+	var total uint64
+
+	for _, split := range splits {
+		total += uint64(split.Weight)
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	n := uint64(u * float64(total))
+
+	for _, split := range splits {
+		if n < uint64(split.Weight) {
+			return split
+		}
+
+		n -= uint64(split.Weight)
+	}
+
+	// Only reachable through rounding with u just under 1.
+	for i := len(splits) - 1; i >= 0; i-- {
+		if splits[i].Weight > 0 {
+			return splits[i]
+		}
+	}
+
+	return nil
+}
+
+// splitID is the value of SplitCookie for target. It's a hash rather than the
+// target itself so the cookie doesn't reveal how the account's services are
+// labeled.
+func splitID(target *pb.LabelSet) string {
+	h := fnv.New64a()
+	h.Write([]byte(target.SpecString()))
+
+	return strconv.FormatUint(h.Sum64(), 36)
+}
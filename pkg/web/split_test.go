@@ -0,0 +1,122 @@
+package web
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type splitResolver struct {
+	Resolver
+	splits []*pb.TargetSplit
+}
+
+func (r *splitResolver) LabelLinkSplits(label *pb.LabelSet, path string) []*pb.TargetSplit {
+	return r.splits
+}
+
+func TestSplits(t *testing.T) {
+	stable := pb.ParseLabelSet("env=stable")
+	canary := pb.ParseLabelSet("env=canary")
+	label := pb.ParseLabelSet(":hostname=app.example.com")
+
+	t.Run("splits requests according to the weights", func(t *testing.T) {
+		f := &Frontend{
+			Resolver: &splitResolver{
+				splits: []*pb.TargetSplit{
+					{Target: stable, Weight: 90},
+					{Target: canary, Weight: 10},
+				},
+			},
+		}
+
+		const total = 10000
+
+		var canaries int
+
+		for i := 0; i < total; i++ {
+			req := httptest.NewRequest("GET", "http://app.example.com/", nil)
+
+			target := f.splitTarget(httptest.NewRecorder(), req, f.Resolver, label, nil)
+			if target.Equal(canary) {
+				canaries++
+			} else {
+				require.True(t, target.Equal(stable))
+			}
+		}
+
+		assert.InDelta(t, 0.1, float64(canaries)/total, 0.02)
+	})
+
+	t.Run("keeps clients on one side with a cookie", func(t *testing.T) {
+		splits := []*pb.TargetSplit{
+			{Target: stable, Weight: 50},
+			{Target: canary, Weight: 50},
+		}
+
+		f := &Frontend{
+			Resolver:     &splitResolver{splits: splits},
+			StickySplits: true,
+		}
+
+		w := httptest.NewRecorder()
+
+		first := f.splitTarget(w, httptest.NewRequest("GET", "http://app.example.com/", nil), f.Resolver, label, nil)
+
+		cookies := w.Result().Cookies()
+		require.Equal(t, 1, len(cookies))
+		assert.Equal(t, SplitCookie, cookies[0].Name)
+
+		for i := 0; i < 50; i++ {
+			req := httptest.NewRequest("GET", "http://app.example.com/", nil)
+			req.AddCookie(cookies[0])
+
+			target := f.splitTarget(httptest.NewRecorder(), req, f.Resolver, label, nil)
+			assert.True(t, target.Equal(first))
+		}
+
+		// Once its side is closed, the client is moved to the other one.
+		for _, split := range splits {
+			if split.Target.Equal(first) {
+				split.Weight = 0
+			}
+		}
+
+		req := httptest.NewRequest("GET", "http://app.example.com/", nil)
+		req.AddCookie(cookies[0])
+
+		w = httptest.NewRecorder()
+
+		target := f.splitTarget(w, req, f.Resolver, label, nil)
+		assert.False(t, target.Equal(first))
+		assert.NotEqual(t, cookies[0].Value, w.Result().Cookies()[0].Value)
+	})
+
+	t.Run("uses the label link's target without splits", func(t *testing.T) {
+		f := &Frontend{Resolver: &splitResolver{}}
+
+		w := httptest.NewRecorder()
+
+		target := f.splitTarget(w, httptest.NewRequest("GET", "http://app.example.com/", nil), f.Resolver, label, stable)
+		assert.True(t, target.Equal(stable))
+		assert.Equal(t, 0, len(w.Result().Cookies()))
+	})
+
+	t.Run("picks splits by their share of the weight", func(t *testing.T) {
+		splits := []*pb.TargetSplit{
+			{Target: stable, Weight: 3},
+			{Target: pb.ParseLabelSet("env=off"), Weight: 0},
+			{Target: canary, Weight: 1},
+		}
+
+		assert.Equal(t, splits[0], pickSplit(splits, 0))
+		assert.Equal(t, splits[0], pickSplit(splits, 0.74))
+		assert.Equal(t, splits[2], pickSplit(splits, 0.75))
+		assert.Equal(t, splits[2], pickSplit(splits, 0.9999999999))
+
+		assert.Nil(t, pickSplit([]*pb.TargetSplit{{Target: stable}}, 0.5))
+	})
+}
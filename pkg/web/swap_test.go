@@ -0,0 +1,200 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/hashicorp/horizon/pkg/control"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/hashicorp/horizon/pkg/wire"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// routeResolver resolves every hostname to a single service. If gate is set,
+// looking up the service waits until it's closed, after sending on entered.
+type routeResolver struct {
+	service *pb.ServiceRoute
+	entered chan struct{}
+	gate    chan struct{}
+}
+
+func (r *routeResolver) ResolvePathLabelLink(ctx context.Context, label *pb.LabelSet, path string) (*pb.Account, *pb.LabelSet, *pb.Account_Limits, error) {
+	return &pb.Account{Namespace: "/", AccountId: pb.NewULID()}, pb.ParseLabelSet("service=www"), nil, nil
+}
+
+func (r *routeResolver) LookupService(ctx context.Context, account *pb.Account, labels *pb.LabelSet) (*control.RouteCalculation, error) {
+	if r.gate != nil {
+		r.entered <- struct{}{}
+		<-r.gate
+	}
+
+	return &control.RouteCalculation{All: []*pb.ServiceRoute{r.service}}, nil
+}
+
+// countingConnector counts the connections it's asked to make to each
+// service, and refuses them all.
+type countingConnector struct {
+	mu    sync.Mutex
+	conns map[string]int
+}
+
+func (c *countingConnector) ConnectToService(
+	ctx context.Context,
+	target *pb.ServiceRoute,
+	account *pb.Account,
+	proto string,
+	token string,
+) (wire.Context, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conns == nil {
+		c.conns = make(map[string]int)
+	}
+
+	c.conns[target.Id.SpecString()]++
+
+	return nil, errors.New("refusing connection")
+}
+
+func (c *countingConnector) count(service *pb.ServiceRoute) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.conns[service.Id.SpecString()]
+}
+
+func TestSwapBackends(t *testing.T) {
+	route := func() *pb.ServiceRoute {
+		return &pb.ServiceRoute{
+			Hub:  pb.NewULID(),
+			Id:   pb.NewULID(),
+			Type: "http",
+		}
+	}
+
+	newFrontend := func(r Resolver, c Connector) *Frontend {
+		rates, err := lru.NewARC(10)
+		require.NoError(t, err)
+
+		return &Frontend{
+			L:        hclog.NewNullLogger(),
+			hub:      c,
+			Resolver: r,
+			rates:    rates,
+		}
+	}
+
+	serve := func(f *Frontend) int {
+		w := httptest.NewRecorder()
+		f.ServeHTTP(w, httptest.NewRequest("GET", "http://app.example.com/", nil))
+
+		return w.Code
+	}
+
+	t.Run("requests in flight finish with what they started with", func(t *testing.T) {
+		oldRoute, newRoute := route(), route()
+
+		oldResolver := &routeResolver{
+			service: oldRoute,
+			entered: make(chan struct{}),
+			gate:    make(chan struct{}),
+		}
+
+		oldConn := &countingConnector{}
+
+		f := newFrontend(oldResolver, oldConn)
+
+		const inflight = 10
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < inflight; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+				assert.Equal(t, http.StatusInternalServerError, serve(f))
+			}()
+		}
+
+		for i := 0; i < inflight; i++ {
+			<-oldResolver.entered
+		}
+
+		newConn := &countingConnector{}
+
+		f.SetResolver(&routeResolver{service: newRoute})
+		f.SetConnector(newConn)
+
+		close(oldResolver.gate)
+		wg.Wait()
+
+		assert.Equal(t, inflight, oldConn.count(oldRoute))
+		assert.Equal(t, 0, newConn.count(oldRoute))
+
+		// New requests use the new ones.
+		assert.Equal(t, http.StatusInternalServerError, serve(f))
+
+		assert.Equal(t, 1, newConn.count(newRoute))
+		assert.Equal(t, 0, oldConn.count(newRoute))
+	})
+
+	t.Run("can be swapped while serving", func(t *testing.T) {
+		routes := []*pb.ServiceRoute{route(), route()}
+		conns := []*countingConnector{{}, {}}
+
+		f := newFrontend(&routeResolver{service: routes[0]}, conns[0])
+
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+
+			for i := 0; i < 100; i++ {
+				f.SetResolver(&routeResolver{service: routes[i%2]})
+				f.SetConnector(conns[i%2])
+			}
+		}()
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for j := 0; j < 10; j++ {
+					assert.Equal(t, http.StatusInternalServerError, serve(f))
+				}
+			}()
+		}
+
+		wg.Wait()
+		<-done
+
+		var total int
+
+		for _, conn := range conns {
+			for _, rs := range routes {
+				total += conn.count(rs)
+			}
+		}
+
+		assert.Equal(t, 200, total)
+
+		// Once the swapping is done, the last ones set are used.
+		before := conns[1].count(routes[1])
+
+		assert.Equal(t, http.StatusInternalServerError, serve(f))
+		assert.Equal(t, before+1, conns[1].count(routes[1]))
+	})
+}
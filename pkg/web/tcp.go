@@ -0,0 +1,235 @@
+package web
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/hashicorp/horizon/pkg/wire"
+	"github.com/pkg/errors"
+)
+
+var ErrNoTCPRoute = errors.New("no route for tcp connection")
+
+// TCPFrontend accepts raw TCP connections and tunnels each one to a tcp
+// service, found the same way the Frontend finds http services: the labels
+// for the connection are resolved via a label link to an account and target,
+// and the target to services.
+type TCPFrontend struct {
+	L     hclog.Logger
+	hub   Connector
+	token string
+
+	// Used to find the services for a connection.
+	Resolver Resolver
+
+	// Generates the id of each connection, which is logged and used as the
+	// id of its flow. Defaults to pb.NewULID.
+	NewID func() *pb.ULID
+
+	// The labels used to resolve connections, typically the labels of a
+	// label link dedicated to the port being listened on. Used for every
+	// connection when TLSConfig isn't set, and for TLS connections that don't
+	// send an SNI name.
+	Labels *pb.LabelSet
+
+	// If set, connections are expected to use TLS, which is terminated here,
+	// and the SNI name is resolved as a :hostname label.
+	TLSConfig *tls.Config
+
+	// The deadline for the TLS handshake, resolving the labels, and
+	// connecting to a service. Zero means no deadline.
+	ConnectTimeout time.Duration
+}
+
+func NewTCPFrontend(L hclog.Logger, h Connector, r Resolver, token string) *TCPFrontend {
+	return &TCPFrontend{
+		L:        L,
+		hub:      h,
+		token:    token,
+		Resolver: r,
+	}
+}
+
+// Serve accepts connections on l until it's closed, tunneling each one to a
+// service.
+func (f *TCPFrontend) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+
+		go f.handleConn(conn)
+	}
+}
+
+func (f *TCPFrontend) handleConn(conn net.Conn) {
+	// conn is replaced by the TLS connection if there is one, which has to be
+	// the one closed.
+	defer func() {
+		conn.Close()
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The connect deadline only applies until we've connected. The context
+	// itself lives on with the connection, since the connector may tie the
+	// connection to it.
+	var connectTimer *time.Timer
+
+	if f.ConnectTimeout > 0 {
+		conn.SetDeadline(time.Now().Add(f.ConnectTimeout))
+		connectTimer = time.AfterFunc(f.ConnectTimeout, cancel)
+	}
+
+	conn, labels, err := f.connLabels(conn)
+	if err != nil {
+		f.L.Error("error reading connection labels", "error", err, "remote", conn.RemoteAddr())
+		return
+	}
+
+	wctx, service, err := f.connect(ctx, labels)
+
+	if connectTimer != nil && !connectTimer.Stop() {
+		if wctx != nil {
+			wctx.Close()
+		}
+
+		f.L.Error("timed out connecting tcp connection to service", "labels", labels, "remote", conn.RemoteAddr())
+		return
+	}
+
+	if err != nil {
+		f.L.Error("error connecting tcp connection to service", "error", err, "labels", labels, "remote", conn.RemoteAddr())
+		return
+	}
+
+	defer wctx.Close()
+
+	conn.SetDeadline(time.Time{})
+
+	id := newID(f.NewID)
+
+	f.L.Info("tcp connection started", "id", id, "service", service.Id, "hub", service.Hub, "remote", conn.RemoteAddr())
+
+	start := time.Now()
+
+	r := wctx.Reader()
+	w := wctx.Writer()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		defer w.Close()
+
+		io.Copy(w, conn)
+	}()
+
+	go func() {
+		defer wg.Done()
+		defer conn.Close()
+
+		io.Copy(conn, r)
+	}()
+
+	wg.Wait()
+
+	f.L.Info("tcp connection finished", "id", id, "duration", time.Since(start))
+}
+
+// connLabels returns the labels to resolve conn with. For TLS connections,
+// the returned conn is the one to use from then on.
+func (f *TCPFrontend) connLabels(conn net.Conn) (net.Conn, *pb.LabelSet, error) {
+	if f.TLSConfig == nil {
+		if f.Labels == nil {
+			return conn, nil, errors.Wrapf(ErrNoTCPRoute, "no labels configured")
+		}
+
+		return conn, f.Labels, nil
+	}
+
+	tconn := tls.Server(conn, f.TLSConfig)
+
+	err := tconn.Handshake()
+	if err != nil {
+		return conn, nil, err
+	}
+
+	name := tconn.ConnectionState().ServerName
+	if name == "" {
+		if f.Labels == nil {
+			return tconn, nil, errors.Wrapf(ErrNoTCPRoute, "no sni name sent")
+		}
+
+		return tconn, f.Labels, nil
+	}
+
+	return tconn, &pb.LabelSet{
+		Labels: []*pb.Label{
+			{
+				Name:  ":hostname",
+				Value: name,
+			},
+		},
+	}, nil
+}
+
+// connect resolves labels to tcp services and connects to the first of them
+// that it can.
+func (f *TCPFrontend) connect(ctx context.Context, labels *pb.LabelSet) (wire.Context, *pb.ServiceRoute, error) {
+	account, target, _, err := f.Resolver.ResolvePathLabelLink(ctx, labels, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if target == nil {
+		return nil, nil, errors.Wrapf(ErrNoTCPRoute, "no label link for %s", labels.SpecString())
+	}
+
+	calc, err := f.Resolver.LookupService(ctx, account, target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var services []*pb.ServiceRoute
+
+	for _, rs := range calc.Services() {
+		if rs.Type != "tcp" {
+			continue
+		}
+
+		services = append(services, rs)
+	}
+
+	if len(services) == 0 {
+		return nil, nil, errors.Wrapf(ErrNoTCPRoute, "no tcp services for %s", target.SpecString())
+	}
+
+	for _, rs := range services {
+		if ctx.Err() != nil {
+			break
+		}
+
+		wctx, err := f.hub.ConnectToService(ctx, rs, account, "tcp", f.token)
+		if err == nil {
+			return wctx, rs, nil
+		}
+
+		if !retryConnect(err) {
+			return nil, nil, err
+		}
+
+		f.L.Warn("error connecting to service", "error", err, "kind", ConnectErrorKindOf(err), "labels", target, "service", rs.Id, "hub", rs.Hub)
+	}
+
+	return nil, nil, errors.Wrapf(ErrNoTCPRoute, "unable to connect to any tcp service for %s", target.SpecString())
+}
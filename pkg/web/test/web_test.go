@@ -1,28 +1,54 @@
 package web_test
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/caddyserver/certmagic"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/horizon/pkg/agent"
+	"github.com/hashicorp/horizon/pkg/clock"
+	"github.com/hashicorp/horizon/pkg/control"
+	"github.com/hashicorp/horizon/pkg/data"
 	"github.com/hashicorp/horizon/pkg/discovery"
 	"github.com/hashicorp/horizon/pkg/hub"
 	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/hashicorp/horizon/pkg/testutils"
 	"github.com/hashicorp/horizon/pkg/testutils/central"
 	"github.com/hashicorp/horizon/pkg/web"
+	"github.com/hashicorp/horizon/pkg/wire"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/metadata"
 )
 
 type fakeHTTPService struct {
-	host string
+	host    string
+	version string
+
+	// The headers of the last request, and extra headers to respond with.
+	headers []*pb.Header
+	extra   []*pb.Header
+
+	// If set, the body of each request is sent here.
+	bodies chan string
 }
 
 func (f *fakeHTTPService) HandleRequest(ctx context.Context, L hclog.Logger, sctx agent.ServiceContext) error {
@@ -34,6 +60,8 @@ func (f *fakeHTTPService) HandleRequest(ctx context.Context, L hclog.Logger, sct
 	}
 
 	f.host = req.Host
+	f.version = req.HttpVersion
+	f.headers = req.Headers
 
 	var resp pb.Response
 
@@ -42,6 +70,10 @@ func (f *fakeHTTPService) HandleRequest(ctx context.Context, L hclog.Logger, sct
 		return err
 	}
 
+	if f.bodies != nil {
+		f.bodies <- string(data)
+	}
+
 	resp.Headers = []*pb.Header{
 		{
 			Name:  "X-Region",
@@ -49,6 +81,8 @@ func (f *fakeHTTPService) HandleRequest(ctx context.Context, L hclog.Logger, sct
 		},
 	}
 
+	resp.Headers = append(resp.Headers, f.extra...)
+
 	resp.Code = 247
 
 	err = sctx.WriteMarshal(1, &resp)
@@ -61,6 +95,322 @@ func (f *fakeHTTPService) HandleRequest(ctx context.Context, L hclog.Logger, sct
 	return w.Close()
 }
 
+// fakeSSEService sends one event, then waits to be told to send the second.
+// That way a test can only see the second event if the first was delivered
+// on its own.
+type fakeSSEService struct {
+	next chan struct{}
+}
+
+func (f *fakeSSEService) HandleRequest(ctx context.Context, L hclog.Logger, sctx agent.ServiceContext) error {
+	var req pb.Request
+
+	_, err := sctx.ReadMarshal(&req)
+	if err != nil {
+		return err
+	}
+
+	_, err = ioutil.ReadAll(sctx.Reader())
+	if err != nil {
+		return err
+	}
+
+	resp := pb.Response{
+		Code: 200,
+		Headers: []*pb.Header{
+			{
+				Name:  "Content-Type",
+				Value: []string{"text/event-stream"},
+			},
+		},
+	}
+
+	err = sctx.WriteMarshal(1, &resp)
+	if err != nil {
+		return err
+	}
+
+	w := sctx.Writer()
+
+	fmt.Fprintf(w, "data: first\n\n")
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-f.next:
+	}
+
+	fmt.Fprintf(w, "data: second\n\n")
+	return w.Close()
+}
+
+// fakeReplyConnector connects the frontend straight to an in-memory service
+// that reads the request and then replies with reply, hanging up afterwards.
+type fakeReplyConnector struct {
+	reply func(svc wire.Context)
+}
+
+func (f *fakeReplyConnector) ConnectToService(
+	ctx context.Context,
+	target *pb.ServiceRoute,
+	account *pb.Account,
+	proto string,
+	token string,
+) (wire.Context, error) {
+	reqR, reqW := io.Pipe()
+	respR, respW := io.Pipe()
+
+	fr, err := wire.NewFramingReader(respR)
+	if err != nil {
+		return nil, err
+	}
+
+	fw, err := wire.NewFramingWriter(reqW)
+	if err != nil {
+		return nil, err
+	}
+
+	sfr, err := wire.NewFramingReader(reqR)
+	if err != nil {
+		return nil, err
+	}
+
+	sfw, err := wire.NewFramingWriter(respW)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := wire.NewContext(account, sfr, sfw)
+
+	go func() {
+		defer respW.Close()
+
+		var req pb.Request
+
+		_, err := svc.ReadMarshal(&req)
+		if err != nil {
+			return
+		}
+
+		ioutil.ReadAll(svc.Reader())
+
+		f.reply(svc)
+	}()
+
+	return wire.WithCloser(wire.NewContext(account, fr, fw), func() error {
+		respR.Close()
+		reqW.Close()
+		return nil
+	}), nil
+}
+
+// fakeSSEConnector connects the frontend straight to an in-memory service
+// that emits an event each time one is requested on events, and records the
+// request it was sent.
+type fakeSSEConnector struct {
+	events chan string
+	closed chan struct{}
+
+	req pb.Request
+}
+
+func (f *fakeSSEConnector) ConnectToService(
+	ctx context.Context,
+	target *pb.ServiceRoute,
+	account *pb.Account,
+	proto string,
+	token string,
+) (wire.Context, error) {
+	reqR, reqW := io.Pipe()
+	respR, respW := io.Pipe()
+
+	fr, err := wire.NewFramingReader(respR)
+	if err != nil {
+		return nil, err
+	}
+
+	fw, err := wire.NewFramingWriter(reqW)
+	if err != nil {
+		return nil, err
+	}
+
+	sfr, err := wire.NewFramingReader(reqR)
+	if err != nil {
+		return nil, err
+	}
+
+	sfw, err := wire.NewFramingWriter(respW)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := wire.NewContext(account, sfr, sfw)
+
+	go func() {
+		defer respW.Close()
+
+		_, err := svc.ReadMarshal(&f.req)
+		if err != nil {
+			return
+		}
+
+		ioutil.ReadAll(svc.Reader())
+
+		err = svc.WriteMarshal(1, &pb.Response{
+			Code: 200,
+			Headers: []*pb.Header{
+				{
+					Name:  "Content-Type",
+					Value: []string{"text/event-stream"},
+				},
+			},
+		})
+		if err != nil {
+			return
+		}
+
+		w := svc.Writer()
+
+		for ev := range f.events {
+			_, err = fmt.Fprintf(w, "data: %s\n\n", ev)
+			if err != nil {
+				return
+			}
+		}
+
+		w.Close()
+	}()
+
+	var once sync.Once
+
+	return wire.WithCloser(wire.NewContext(account, fr, fw), func() error {
+		once.Do(func() {
+			close(f.closed)
+			respR.Close()
+			reqW.Close()
+		})
+		return nil
+	}), nil
+}
+
+// slowResolver delays resolving label links until ctx is done or linkDelay
+// passes, and looking up services until it's done or delay passes.
+type slowResolver struct {
+	web.Resolver
+	linkDelay time.Duration
+	delay     time.Duration
+}
+
+func (r *slowResolver) ResolvePathLabelLink(ctx context.Context, label *pb.LabelSet, path string) (*pb.Account, *pb.LabelSet, *pb.Account_Limits, error) {
+	select {
+	case <-ctx.Done():
+		return nil, nil, nil, ctx.Err()
+	case <-time.After(r.linkDelay):
+	}
+
+	return r.Resolver.ResolvePathLabelLink(ctx, label, path)
+}
+
+func (r *slowResolver) LookupService(ctx context.Context, account *pb.Account, labels *pb.LabelSet) (*control.RouteCalculation, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(r.delay):
+	}
+
+	return r.Resolver.LookupService(ctx, account, labels)
+}
+
+// slowConnector takes delay to connect, and then connects to a service that
+// reads the request but never responds.
+type slowConnector struct {
+	delay time.Duration
+}
+
+func (c *slowConnector) ConnectToService(
+	ctx context.Context,
+	target *pb.ServiceRoute,
+	account *pb.Account,
+	proto string,
+	token string,
+) (wire.Context, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(c.delay):
+	}
+
+	reqR, reqW := io.Pipe()
+	respR, respW := io.Pipe()
+
+	go ioutil.ReadAll(reqR)
+
+	fr, err := wire.NewFramingReader(respR)
+	if err != nil {
+		return nil, err
+	}
+
+	fw, err := wire.NewFramingWriter(reqW)
+	if err != nil {
+		return nil, err
+	}
+
+	return wire.WithCloser(wire.NewContext(account, fr, fw), func() error {
+		respW.Close()
+		reqR.Close()
+		return nil
+	}), nil
+}
+
+// staticResolver resolves every hostname to the same account and services.
+// recordingLimiter records the request it was asked about and answers with
+// allow and delay.
+type recordingLimiter struct {
+	allow bool
+	delay time.Duration
+
+	account *pb.Account
+	ip      string
+}
+
+func (r *recordingLimiter) Allow(account *pb.Account, clientIP string) (bool, time.Duration) {
+	r.account = account
+	r.ip = clientIP
+	return r.allow, r.delay
+}
+
+type staticResolver struct {
+	web.Resolver
+	services []*pb.ServiceRoute
+}
+
+func (r *staticResolver) LookupService(ctx context.Context, account *pb.Account, labels *pb.LabelSet) (*control.RouteCalculation, error) {
+	return &control.RouteCalculation{All: r.services}, nil
+}
+
+// recordingConnector records the services it's asked to connect to and fails
+// to connect to any of them, with err if it's set.
+type recordingConnector struct {
+	targets []*pb.ServiceRoute
+	err     error
+}
+
+func (c *recordingConnector) ConnectToService(
+	ctx context.Context,
+	target *pb.ServiceRoute,
+	account *pb.Account,
+	proto string,
+	token string,
+) (wire.Context, error) {
+	c.targets = append(c.targets, target)
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return nil, errors.New("refusing connection")
+}
+
 func TestWeb(t *testing.T) {
 	central.Dev(t, func(setup *central.DevSetup) {
 		L := hclog.L()
@@ -88,6 +438,24 @@ func TestWeb(t *testing.T) {
 		})
 		require.NoError(t, err)
 
+		sse := &fakeSSEService{next: make(chan struct{})}
+
+		_, err = a.AddService(&agent.Service{
+			Type:    "http",
+			Labels:  pb.ParseLabelSet("env=sse"),
+			Handler: sse,
+		})
+		require.NoError(t, err)
+
+		var vhost fakeHTTPService
+
+		_, err = a.AddService(&agent.Service{
+			Type:    "http",
+			Labels:  pb.ParseLabelSet("env=vhost,:host-header=backend.internal"),
+			Handler: &vhost,
+		})
+		require.NoError(t, err)
+
 		err = a.Start(ctx, discovery.HubConfigs(discovery.HubConfig{
 			Addr:     setup.HubAddr,
 			Insecure: true,
@@ -109,6 +477,28 @@ func TestWeb(t *testing.T) {
 
 		require.NoError(t, err)
 
+		sseName := "sse.localdomain"
+
+		_, err = setup.ControlServer.AddLabelLink(setup.MgmtCtx,
+			&pb.AddLabelLinkRequest{
+				Labels:  pb.ParseLabelSet(":hostname=" + sseName),
+				Account: setup.Account,
+				Target:  pb.ParseLabelSet("env=sse"),
+			})
+
+		require.NoError(t, err)
+
+		vhostName := "vhost.localdomain"
+
+		_, err = setup.ControlServer.AddLabelLink(setup.MgmtCtx,
+			&pb.AddLabelLinkRequest{
+				Labels:  pb.ParseLabelSet(":hostname=" + vhostName),
+				Account: setup.Account,
+				Target:  pb.ParseLabelSet("env=vhost"),
+			})
+
+		require.NoError(t, err)
+
 		time.Sleep(time.Second)
 
 		require.NoError(t, setup.ControlClient.ForceLabelLinkUpdate(ctx, L))
@@ -130,24 +520,1381 @@ func TestWeb(t *testing.T) {
 			assert.Equal(t, expected, w.Body.String())
 
 			assert.Equal(t, "fuzz.localdomain", fe.host)
+			assert.Equal(t, "HTTP/1.1", fe.version)
 		})
 
-		t.Run("supports deployment routes", func(t *testing.T) {
-			target := "fuzz--aabbcc.localdomain"
+		t.Run("filters the headers passed between clients and services", func(t *testing.T) {
+			fe.extra = []*pb.Header{
+				{Name: "Server", Value: []string{"backend/1.0"}},
+				{Name: "X-Backend-Id", Value: []string{"b1"}},
+				{Name: "Connection", Value: []string{"close, X-Conn-Only"}},
+				{Name: "X-Conn-Only", Value: []string{"1"}},
+				{Name: "Keep-Alive", Value: []string{"timeout=5"}},
+				{Name: "X-Kept", Value: []string{"1"}},
+			}
+
+			defer func() {
+				fe.extra = nil
+			}()
+
+			send := func(f *web.Frontend) *httptest.ResponseRecorder {
+				req, err := http.NewRequest("GET", "http://"+name+"/", strings.NewReader("this is a request"))
+				require.NoError(t, err)
+
+				req.Header.Set("Connection", "X-Req-Conn")
+				req.Header.Set("X-Req-Conn", "1")
+				req.Header.Set("Keep-Alive", "timeout=5")
+				req.Header.Set("Proxy-Authorization", "Basic Zm9vOmJhcg==")
+				req.Header.Set("X-Internal", "secret")
+				req.Header.Set("X-Keep", "1")
+
+				w := httptest.NewRecorder()
+
+				f.ServeHTTP(w, req)
+
+				require.Equal(t, 247, w.Code)
+
+				return w
+			}
+
+			sent := func() map[string]bool {
+				names := map[string]bool{}
+
+				for _, h := range fe.headers {
+					names[http.CanonicalHeaderKey(h.Name)] = true
+				}
+
+				return names
+			}
 
 			f, err := web.NewFrontend(L, hub, setup.ControlClient, setup.HubServToken)
 			require.NoError(t, err)
 
-			req, err := http.NewRequest("GET", "http://"+target+"/", strings.NewReader("this is a request"))
+			f.RequestHeaders.Strip = []string{"x-internal"}
+			f.ResponseHeaders.Strip = []string{"Server", "X-Backend-*"}
+
+			w := send(f)
+
+			for _, h := range []string{"Server", "X-Backend-Id", "Connection", "X-Conn-Only", "Keep-Alive"} {
+				assert.Empty(t, w.Header().Get(h), h)
+			}
+
+			assert.Equal(t, "1", w.Header().Get("X-Kept"))
+			assert.Equal(t, "test", w.Header().Get("X-Region"))
+
+			names := sent()
+
+			for _, h := range []string{"Connection", "X-Req-Conn", "Keep-Alive", "Proxy-Authorization", "X-Internal"} {
+				assert.False(t, names[h], h)
+			}
+
+			assert.True(t, names["X-Keep"])
+
+			// With an allow list, only the allowed headers are passed, but
+			// the ones the frontend adds itself are still there.
+			f.RequestHeaders = web.HeaderFilter{Allow: []string{"X-Internal"}}
+			f.ResponseHeaders = web.HeaderFilter{Allow: []string{"X-Region", "X-Backend-*"}}
+
+			w = send(f)
+
+			assert.Equal(t, "test", w.Header().Get("X-Region"))
+			assert.Equal(t, "b1", w.Header().Get("X-Backend-Id"))
+			assert.Empty(t, w.Header().Get("X-Kept"))
+			assert.Empty(t, w.Header().Get("Server"))
+			assert.NotEmpty(t, w.Header().Get("X-Horizon-Endpoint"))
+
+			names = sent()
+
+			assert.True(t, names["X-Internal"])
+			assert.False(t, names["X-Keep"])
+		})
+
+		t.Run("drops hop-by-hop request headers before proxying", func(t *testing.T) {
+			f, err := web.NewFrontend(L, hub, setup.ControlClient, setup.HubServToken)
 			require.NoError(t, err)
 
-			w := httptest.NewRecorder()
+			cases := []struct {
+				name, value string
+			}{
+				{"Connection", "keep-alive"},
+				{"Keep-Alive", "timeout=5"},
+				{"Proxy-Authenticate", "Basic"},
+				{"Proxy-Authorization", "Basic Zm9vOmJhcg=="},
+				{"Proxy-Connection", "keep-alive"},
+				{"Te", "trailers"},
+				{"Trailer", "Expires"},
+				{"Transfer-Encoding", "chunked"},
+				{"Upgrade", "websocket"},
+				{"X-Custom", "1"},
+			}
 
-			f.ServeHTTP(w, req)
+			for _, c := range cases {
+				t.Run(c.name, func(t *testing.T) {
+					req, err := http.NewRequest("GET", "http://"+name+"/", strings.NewReader("this is a request"))
+					require.NoError(t, err)
 
-			assert.Equal(t, 247, w.Code)
-			expected := "this is from the fake service: this is a request"
-			assert.Equal(t, expected, w.Body.String())
+					req.Header.Set(c.name, c.value)
+					req.Header.Add("Connection", "X-Custom")
+					req.Header.Set("X-Other", "1")
+
+					w := httptest.NewRecorder()
+
+					f.ServeHTTP(w, req)
+
+					require.Equal(t, 247, w.Code)
+
+					var seen []string
+
+					for _, h := range fe.headers {
+						seen = append(seen, http.CanonicalHeaderKey(h.Name))
+					}
+
+					assert.NotContains(t, seen, c.name)
+					assert.Contains(t, seen, "X-Other")
+				})
+			}
 		})
+
+		t.Run("sends services the Host header when asked to", func(t *testing.T) {
+			hostHeader := func(hdrs []*pb.Header) []string {
+				for _, h := range hdrs {
+					if http.CanonicalHeaderKey(h.Name) == "Host" {
+						return h.Value
+					}
+				}
+
+				return nil
+			}
+
+			send := func(f *web.Frontend, host string) {
+				req, err := http.NewRequest("GET", "http://"+host+"/", strings.NewReader("this is a request"))
+				require.NoError(t, err)
+
+				w := httptest.NewRecorder()
+
+				f.ServeHTTP(w, req)
+
+				require.Equal(t, 247, w.Code)
+			}
+
+			f, err := web.NewFrontend(L, hub, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			send(f, name)
+
+			assert.Equal(t, name, fe.host)
+			assert.Nil(t, hostHeader(fe.headers))
+
+			f.PreserveHost = true
+
+			send(f, name)
+
+			assert.Equal(t, name, fe.host)
+			assert.Equal(t, []string{name}, hostHeader(fe.headers))
+
+			// The label overrides the client's Host, with or without
+			// PreserveHost.
+			for _, preserve := range []bool{true, false} {
+				f.PreserveHost = preserve
+
+				send(f, vhostName)
+
+				assert.Equal(t, "backend.internal", vhost.host)
+				assert.Equal(t, []string{"backend.internal"}, hostHeader(vhost.headers))
+			}
+		})
+
+		t.Run("mirrors requests without changing the response", func(t *testing.T) {
+			vhost.bodies = make(chan string, 1)
+
+			defer func() {
+				vhost.bodies = nil
+			}()
+
+			f, err := web.NewFrontend(L, hub, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			f.Mirrors = map[string]*pb.LabelSet{
+				name: pb.ParseLabelSet("env=vhost"),
+			}
+
+			send := func(body string) {
+				req, err := http.NewRequest("POST", "http://"+name+"/", strings.NewReader(body))
+				require.NoError(t, err)
+
+				w := httptest.NewRecorder()
+
+				f.ServeHTTP(w, req)
+
+				assert.Equal(t, 247, w.Code)
+				assert.Equal(t, "this is from the fake service: "+body, w.Body.String())
+				assert.Equal(t, name, fe.host)
+			}
+
+			send("this is a mirrored request")
+
+			select {
+			case body := <-vhost.bodies:
+				assert.Equal(t, "this is a mirrored request", body)
+			case <-time.After(5 * time.Second):
+				t.Fatal("mirror never received the request")
+			}
+
+			// Bodies over the limit aren't mirrored, but still reach the
+			// primary service.
+			f.MirrorMaxBody = 4
+
+			send("this is too large to mirror")
+
+			select {
+			case body := <-vhost.bodies:
+				t.Fatalf("mirror received a request over the limit: %s", body)
+			case <-time.After(time.Second):
+			}
+		})
+
+		t.Run("rejects request bodies over the limit", func(t *testing.T) {
+			vhost.bodies = make(chan string, 1)
+
+			defer func() {
+				vhost.bodies = nil
+			}()
+
+			f, err := web.NewFrontend(L, hub, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			f.MaxRequestBody = 16
+
+			send := func(body string, declared bool) int {
+				req, err := http.NewRequest("POST", "http://"+vhostName+"/", strings.NewReader(body))
+				require.NoError(t, err)
+
+				if !declared {
+					req.ContentLength = -1
+				}
+
+				w := httptest.NewRecorder()
+
+				f.ServeHTTP(w, req)
+
+				return w.Code
+			}
+
+			expectBody := func(body string) {
+				select {
+				case got := <-vhost.bodies:
+					assert.Equal(t, body, got)
+				case <-time.After(5 * time.Second):
+					t.Fatal("service never received the request")
+				}
+			}
+
+			expectNoBody := func() {
+				select {
+				case got := <-vhost.bodies:
+					t.Fatalf("service received a request over the limit: %s", got)
+				case <-time.After(500 * time.Millisecond):
+				}
+			}
+
+			for _, declared := range []bool{true, false} {
+				assert.Equal(t, 247, send("sixteen bytes ok", declared))
+				expectBody("sixteen bytes ok")
+
+				assert.Equal(t, http.StatusRequestEntityTooLarge, send("this is over the sixteen byte limit", declared))
+				expectNoBody()
+			}
+		})
+
+		t.Run("flushes streaming responses as they arrive", func(t *testing.T) {
+			f, err := web.NewFrontend(L, hub, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			ts := httptest.NewServer(f)
+			defer ts.Close()
+
+			req, err := http.NewRequest("GET", ts.URL+"/", nil)
+			require.NoError(t, err)
+
+			req.Host = sseName
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+			br := bufio.NewReader(resp.Body)
+
+			line, err := br.ReadString('\n')
+			require.NoError(t, err)
+
+			assert.Equal(t, "data: first\n", line)
+
+			// The service only sends the second event once we've seen the first.
+			close(sse.next)
+
+			rest, err := ioutil.ReadAll(br)
+			require.NoError(t, err)
+
+			assert.Equal(t, "\ndata: second\n\n", string(rest))
+		})
+
+		t.Run("streams server-sent events until the client disconnects", func(t *testing.T) {
+			conn := &fakeSSEConnector{
+				events: make(chan string),
+				closed: make(chan struct{}),
+			}
+
+			defer close(conn.events)
+
+			f, err := web.NewFrontend(L, conn, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			ts := httptest.NewServer(f)
+			defer ts.Close()
+
+			reqCtx, reqCancel := context.WithCancel(ctx)
+			defer reqCancel()
+
+			req, err := http.NewRequestWithContext(reqCtx, "GET", ts.URL+"/", nil)
+			require.NoError(t, err)
+
+			req.Host = sseName
+			req.Header.Set("Accept", "text/event-stream")
+			req.Header.Set("Accept-Encoding", "gzip")
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			assert.Equal(t, "no", resp.Header.Get("X-Accel-Buffering"))
+			assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+			for _, h := range conn.req.Headers {
+				assert.NotEqual(t, "Accept-Encoding", h.Name)
+			}
+
+			br := bufio.NewReader(resp.Body)
+
+			for _, ev := range []string{"one", "two", "three"} {
+				conn.events <- ev
+
+				line, err := br.ReadString('\n')
+				require.NoError(t, err)
+
+				assert.Equal(t, "data: "+ev+"\n", line)
+
+				line, err = br.ReadString('\n')
+				require.NoError(t, err)
+
+				assert.Equal(t, "\n", line)
+			}
+
+			reqCancel()
+
+			select {
+			case <-conn.closed:
+				// ok
+			case <-time.After(5 * time.Second):
+				t.Fatal("connection to the service was not closed after the client disconnected")
+			}
+		})
+
+		t.Run("reports the request and response sizes as a flow", func(t *testing.T) {
+			f, err := web.NewFrontend(L, hub, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			var records []*pb.FlowRecord
+
+			f.ReportFlow = func(rec *pb.FlowRecord) {
+				records = append(records, rec)
+			}
+
+			body := "this is a request"
+
+			req, err := http.NewRequest("GET", "http://"+name+"/", strings.NewReader(body))
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+
+			f.ServeHTTP(w, req)
+
+			expected := "this is from the fake service: " + body
+			assert.Equal(t, expected, w.Body.String())
+
+			f.FlushFlows()
+
+			require.Equal(t, 1, len(records))
+
+			stream := records[0].Stream
+			require.NotNil(t, stream)
+
+			assert.Equal(t, int64(1), stream.NumMessages)
+			assert.Equal(t, int64(len(body)+len(expected)), stream.NumBytes)
+			assert.Equal(t, setup.Account, stream.Account)
+			assert.NotNil(t, stream.ServiceId)
+		})
+
+		t.Run("reports the flow from its hub to the service's", func(t *testing.T) {
+			route := &pb.ServiceRoute{
+				Hub:  pb.NewULID(),
+				Id:   pb.NewULID(),
+				Type: "http",
+			}
+
+			reply := func(svc wire.Context) {
+				svc.WriteMarshal(1, &pb.Response{Code: 200})
+			}
+
+			f, err := web.NewFrontend(L, &fakeReplyConnector{reply: reply}, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			f.Resolver = &staticResolver{
+				Resolver: setup.ControlClient,
+				services: []*pb.ServiceRoute{route},
+			}
+
+			var records []*pb.FlowRecord
+
+			f.ReportFlow = func(rec *pb.FlowRecord) {
+				records = append(records, rec)
+			}
+
+			for i := 0; i < 2; i++ {
+				req, err := http.NewRequest("GET", "http://"+name+"/", nil)
+				require.NoError(t, err)
+
+				w := httptest.NewRecorder()
+
+				f.ServeHTTP(w, req)
+
+				assert.Equal(t, 200, w.Code)
+			}
+
+			// Nothing is reported until the interval is up.
+			assert.Equal(t, 0, len(records))
+
+			f.FlushFlows()
+
+			// The requests to a service are reported as one flow, under the
+			// service's id, so the flows don't grow with the requests.
+			require.Equal(t, 1, len(records))
+
+			stream := records[0].Stream
+			require.NotNil(t, stream)
+
+			assert.Equal(t, route.Id, stream.FlowId)
+			assert.Equal(t, int64(2), stream.NumMessages)
+			assert.Equal(t, setup.ControlClient.Id(), stream.HubId)
+			assert.Equal(t, route.Hub, stream.AgentId)
+			assert.Equal(t, route.Id, stream.ServiceId)
+
+			f.FlushFlows()
+
+			assert.Equal(t, 1, len(records))
+		})
+
+		t.Run("reports flows every interval while there's traffic", func(t *testing.T) {
+			f, err := web.NewFrontend(L, hub, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			f.FlowInterval = 10 * time.Millisecond
+
+			records := make(chan *pb.FlowRecord, 10)
+
+			f.ReportFlow = func(rec *pb.FlowRecord) {
+				records <- rec
+			}
+
+			req, err := http.NewRequest("GET", "http://"+name+"/", strings.NewReader("hello"))
+			require.NoError(t, err)
+
+			f.ServeHTTP(httptest.NewRecorder(), req)
+
+			select {
+			case rec := <-records:
+				assert.Equal(t, int64(1), rec.Stream.NumMessages)
+			case <-time.After(5 * time.Second):
+				t.Fatal("flow was not reported")
+			}
+		})
+
+		t.Run("doesn't report flows without a control client", func(t *testing.T) {
+			f, err := web.NewFrontend(L, hub, nil, setup.HubServToken)
+			require.NoError(t, err)
+
+			assert.Nil(t, f.ReportFlow)
+			assert.Nil(t, f.Resolver)
+		})
+
+		t.Run("uses the configured id source for request ids", func(t *testing.T) {
+			f, err := web.NewFrontend(L, hub, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			id := pb.NewULID()
+
+			f.NewID = func() *pb.ULID {
+				return id
+			}
+
+			var logs bytes.Buffer
+
+			f.L = hclog.New(&hclog.LoggerOptions{
+				Output: &logs,
+			})
+
+			req, err := http.NewRequest("GET", "http://"+name+"/", strings.NewReader("hello"))
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+
+			f.ServeHTTP(w, req)
+
+			assert.Contains(t, logs.String(), "id="+id.SpecString())
+		})
+
+		t.Run("throttles requests with the rate limiter", func(t *testing.T) {
+			f, err := web.NewFrontend(L, hub, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			send := func() *httptest.ResponseRecorder {
+				req, err := http.NewRequest("GET", "http://"+name+"/", strings.NewReader("hello"))
+				require.NoError(t, err)
+
+				req.RemoteAddr = "10.1.2.3:4567"
+
+				w := httptest.NewRecorder()
+
+				f.ServeHTTP(w, req)
+
+				return w
+			}
+
+			// Without a limiter, requests go through.
+			for i := 0; i < 3; i++ {
+				assert.Equal(t, 247, send().Code)
+			}
+
+			rec := &recordingLimiter{allow: true}
+			f.RateLimiter = rec
+
+			assert.Equal(t, 247, send().Code)
+			assert.True(t, setup.Account.Equal(rec.account))
+			assert.Equal(t, "10.1.2.3", rec.ip)
+
+			rec.allow = false
+			rec.delay = 1500 * time.Millisecond
+
+			w := send()
+			assert.Equal(t, http.StatusTooManyRequests, w.Code)
+			assert.Equal(t, "2", w.Header().Get("Retry-After"))
+
+			limiter, err := web.NewAccountRateLimiter(rate.Every(time.Minute), 1)
+			require.NoError(t, err)
+
+			f.RateLimiter = limiter
+
+			assert.Equal(t, 247, send().Code)
+
+			w = send()
+			assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+			secs, err := strconv.Atoi(w.Header().Get("Retry-After"))
+			require.NoError(t, err)
+
+			assert.True(t, secs > 0 && secs <= 60, "retry after %d seconds", secs)
+		})
+
+		t.Run("times out each phase of a request separately", func(t *testing.T) {
+			short := 50 * time.Millisecond
+			long := 5 * time.Second
+
+			cases := []struct {
+				name     string
+				phase    string
+				code     int
+				link     time.Duration
+				resolve  time.Duration
+				connect  time.Duration
+				timeouts [3]time.Duration
+			}{
+				{"label link", "resolve", web.ResolveTimeoutStatus, time.Second, 0, 0, [3]time.Duration{short, long, long}},
+				{"resolve", "resolve", web.ResolveTimeoutStatus, 0, time.Second, 0, [3]time.Duration{short, long, long}},
+				{"connect", "connect", web.ConnectTimeoutStatus, 0, 0, time.Second, [3]time.Duration{long, short, long}},
+				{"proxy", "proxy", web.ProxyTimeoutStatus, 0, 0, 0, [3]time.Duration{long, long, short}},
+			}
+
+			for _, c := range cases {
+				t.Run(c.name, func(t *testing.T) {
+					f, err := web.NewFrontend(L, &slowConnector{delay: c.connect}, setup.ControlClient, setup.HubServToken)
+					require.NoError(t, err)
+
+					f.Resolver = &slowResolver{Resolver: setup.ControlClient, linkDelay: c.link, delay: c.resolve}
+
+					f.ResolveTimeout = c.timeouts[0]
+					f.ConnectTimeout = c.timeouts[1]
+					f.ProxyTimeout = c.timeouts[2]
+
+					req, err := http.NewRequest("GET", "http://"+name+"/", strings.NewReader("this is a request"))
+					require.NoError(t, err)
+
+					w := httptest.NewRecorder()
+
+					start := time.Now()
+
+					f.ServeHTTP(w, req)
+
+					assert.True(t, time.Since(start) < time.Second, "took: %s", time.Since(start))
+
+					assert.Equal(t, c.code, w.Code)
+					assert.Equal(t, c.phase, w.Header().Get("X-Horizon-Timeout"))
+				})
+			}
+		})
+
+		t.Run("uses the http services among services of other types", func(t *testing.T) {
+			route := func(typ string) *pb.ServiceRoute {
+				return &pb.ServiceRoute{
+					Hub:    pb.NewULID(),
+					Id:     pb.NewULID(),
+					Type:   typ,
+					Labels: pb.ParseLabelSet("env=test"),
+				}
+			}
+
+			httpRoute := route("http")
+
+			// Services are shuffled before use, so try enough times that the
+			// http service is very likely not to be first at least once.
+			for i := 0; i < 10; i++ {
+				conn := &recordingConnector{}
+
+				f, err := web.NewFrontend(L, conn, setup.ControlClient, setup.HubServToken)
+				require.NoError(t, err)
+
+				f.Resolver = &staticResolver{
+					Resolver: setup.ControlClient,
+					services: []*pb.ServiceRoute{route("tcp"), route("tcp"), route("tcp"), httpRoute},
+				}
+
+				req, err := http.NewRequest("GET", "http://"+name+"/", nil)
+				require.NoError(t, err)
+
+				w := httptest.NewRecorder()
+
+				f.ServeHTTP(w, req)
+
+				require.Equal(t, 1, len(conn.targets))
+				assert.Equal(t, httpRoute.Id, conn.targets[0].Id)
+
+				// The connector refuses, so it's a server error rather than
+				// not found.
+				assert.Equal(t, http.StatusInternalServerError, w.Code)
+			}
+
+			conn := &recordingConnector{}
+
+			f, err := web.NewFrontend(L, conn, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			f.Resolver = &staticResolver{
+				Resolver: setup.ControlClient,
+				services: []*pb.ServiceRoute{route("tcp"), route("tcp")},
+			}
+
+			req, err := http.NewRequest("GET", "http://"+name+"/", nil)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+
+			f.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Equal(t, 0, len(conn.targets))
+		})
+
+		t.Run("tries the next service only when connecting might work there", func(t *testing.T) {
+			var services []*pb.ServiceRoute
+
+			for i := 0; i < 3; i++ {
+				services = append(services, &pb.ServiceRoute{
+					Hub:    pb.NewULID(),
+					Id:     pb.NewULID(),
+					Type:   "http",
+					Labels: pb.ParseLabelSet("env=test"),
+				})
+			}
+
+			cases := []struct {
+				name  string
+				err   error
+				tries int
+			}{
+				{"unclassified", errors.New("refusing connection"), 3},
+				{"no route", web.NewConnectError(web.ConnectNoRoute, errors.New("no session")), 3},
+				{"refused", web.NewConnectError(web.ConnectRefused, errors.New("refused")), 3},
+				{"auth rejected", web.NewConnectError(web.ConnectAuthRejected, errors.New("bad token")), 1},
+			}
+
+			for _, c := range cases {
+				t.Run(c.name, func(t *testing.T) {
+					conn := &recordingConnector{err: c.err}
+
+					f, err := web.NewFrontend(L, conn, setup.ControlClient, setup.HubServToken)
+					require.NoError(t, err)
+
+					f.Resolver = &staticResolver{
+						Resolver: setup.ControlClient,
+						services: services,
+					}
+
+					req, err := http.NewRequest("GET", "http://"+name+"/", nil)
+					require.NoError(t, err)
+
+					w := httptest.NewRecorder()
+
+					f.ServeHTTP(w, req)
+
+					assert.Equal(t, c.tries, len(conn.targets))
+					assert.Equal(t, http.StatusInternalServerError, w.Code)
+				})
+			}
+		})
+
+		t.Run("skips services whose circuit breaker is open", func(t *testing.T) {
+			var services []*pb.ServiceRoute
+
+			for i := 0; i < 2; i++ {
+				services = append(services, &pb.ServiceRoute{
+					Hub:    pb.NewULID(),
+					Id:     pb.NewULID(),
+					Type:   "http",
+					Labels: pb.ParseLabelSet("env=test"),
+				})
+			}
+
+			conn := &recordingConnector{err: web.NewConnectError(web.ConnectRefused, errors.New("refused"))}
+
+			f, err := web.NewFrontend(L, conn, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			f.Resolver = &staticResolver{
+				Resolver: setup.ControlClient,
+				services: services,
+			}
+
+			fake := clock.NewFake(time.Now())
+
+			f.Breakers = web.NewCircuitBreakers(2, time.Minute)
+			f.Breakers.Clock = fake
+
+			serve := func() int {
+				req, err := http.NewRequest("GET", "http://"+name+"/", nil)
+				require.NoError(t, err)
+
+				w := httptest.NewRecorder()
+
+				f.ServeHTTP(w, req)
+
+				return w.Code
+			}
+
+			assert.Equal(t, http.StatusInternalServerError, serve())
+			assert.Equal(t, http.StatusInternalServerError, serve())
+			assert.Equal(t, 4, len(conn.targets))
+
+			for _, rs := range services {
+				assert.Equal(t, web.BreakerOpen, f.Breakers.State(rs.Id))
+			}
+
+			assert.Equal(t, http.StatusServiceUnavailable, serve())
+			assert.Equal(t, 4, len(conn.targets))
+
+			fake.Advance(time.Minute)
+
+			// Each service gets a probe, which fails and opens its breaker
+			// again.
+			assert.Equal(t, http.StatusInternalServerError, serve())
+			assert.Equal(t, 6, len(conn.targets))
+
+			assert.Equal(t, http.StatusServiceUnavailable, serve())
+			assert.Equal(t, 6, len(conn.targets))
+		})
+
+		t.Run("tells bad responses from services apart", func(t *testing.T) {
+			route := &pb.ServiceRoute{
+				Hub:    pb.NewULID(),
+				Id:     pb.NewULID(),
+				Type:   "http",
+				Labels: pb.ParseLabelSet("env=test"),
+			}
+
+			cases := []struct {
+				name  string
+				reply func(svc wire.Context)
+				log   string
+			}{
+				{
+					name: "read error",
+					reply: func(svc wire.Context) {
+						// Hang up without responding.
+					},
+					log: "error reading response from service",
+				},
+				{
+					name: "wrong tag",
+					reply: func(svc wire.Context) {
+						svc.WriteMarshal(2, &pb.Response{Code: 200})
+					},
+					log: "tag=2",
+				},
+			}
+
+			for _, c := range cases {
+				t.Run(c.name, func(t *testing.T) {
+					var logs bytes.Buffer
+
+					f, err := web.NewFrontend(L, &fakeReplyConnector{reply: c.reply}, setup.ControlClient, setup.HubServToken)
+					require.NoError(t, err)
+
+					f.L = hclog.New(&hclog.LoggerOptions{
+						Output: &logs,
+					})
+
+					f.Resolver = &staticResolver{
+						Resolver: setup.ControlClient,
+						services: []*pb.ServiceRoute{route},
+					}
+
+					req, err := http.NewRequest("GET", "http://"+name+"/", nil)
+					require.NoError(t, err)
+
+					w := httptest.NewRecorder()
+
+					f.ServeHTTP(w, req)
+
+					assert.Equal(t, http.StatusBadGateway, w.Code)
+					assert.Contains(t, logs.String(), c.log)
+				})
+			}
+		})
+
+		t.Run("serves the account's pages when it can't proxy", func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "hzn-pages")
+			require.NoError(t, err)
+
+			defer os.RemoveAll(dir)
+
+			db, err := data.NewBolt(filepath.Join(dir, "data.db"))
+			require.NoError(t, err)
+
+			unavailable := &pb.StaticResponse{
+				Headers: []*pb.Header{
+					{Name: "Retry-After", Value: []string{"120"}},
+				},
+				Body: []byte("back soon"),
+			}
+
+			send := func(f *web.Frontend) *httptest.ResponseRecorder {
+				req, err := http.NewRequest("GET", "http://"+name+"/", nil)
+				require.NoError(t, err)
+
+				w := httptest.NewRecorder()
+
+				f.ServeHTTP(w, req)
+
+				return w
+			}
+
+			t.Run("when no service is available", func(t *testing.T) {
+				require.NoError(t, db.SetAccountPages(setup.Account, &pb.AccountPages{
+					UnavailablePage: unavailable,
+				}))
+
+				conn := &recordingConnector{}
+
+				f, err := web.NewFrontend(L, conn, setup.ControlClient, setup.HubServToken)
+				require.NoError(t, err)
+
+				f.Pages = db
+
+				f.Resolver = &staticResolver{
+					Resolver: setup.ControlClient,
+					services: []*pb.ServiceRoute{
+						{
+							Hub:    pb.NewULID(),
+							Id:     pb.NewULID(),
+							Type:   "http",
+							Labels: pb.ParseLabelSet("env=test"),
+						},
+					},
+				}
+
+				w := send(f)
+
+				assert.Equal(t, 1, len(conn.targets))
+				assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+				assert.Equal(t, "120", w.Header().Get("Retry-After"))
+				assert.Equal(t, "back soon", w.Body.String())
+
+				// The same goes for there being no services at all.
+				f.Resolver = &staticResolver{Resolver: setup.ControlClient}
+
+				w = send(f)
+
+				assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+				assert.Equal(t, "back soon", w.Body.String())
+
+				// Without a page, it's the generic error.
+				require.NoError(t, db.SetAccountPages(setup.Account, nil))
+
+				w = send(f)
+
+				assert.Equal(t, http.StatusNotFound, w.Code)
+				assert.NotEqual(t, "back soon", w.Body.String())
+			})
+
+			t.Run("when the account is suspended", func(t *testing.T) {
+				require.NoError(t, db.SetAccountPages(setup.Account, &pb.AccountPages{
+					Suspended: true,
+					SuspendedPage: &pb.StaticResponse{
+						Code: http.StatusForbidden,
+						Body: []byte("this account is suspended"),
+					},
+					UnavailablePage: unavailable,
+				}))
+
+				defer db.SetAccountPages(setup.Account, nil)
+
+				f, err := web.NewFrontend(L, hub, setup.ControlClient, setup.HubServToken)
+				require.NoError(t, err)
+
+				f.Pages = db
+
+				fe.host = ""
+
+				w := send(f)
+
+				assert.Equal(t, http.StatusForbidden, w.Code)
+				assert.Equal(t, "this account is suspended", w.Body.String())
+
+				// The request never reached the service.
+				assert.Equal(t, "", fe.host)
+			})
+		})
+
+		t.Run("supports deployment routes", func(t *testing.T) {
+			target := "fuzz--aabbcc.localdomain"
+
+			f, err := web.NewFrontend(L, hub, setup.ControlClient, setup.HubServToken)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest("GET", "http://"+target+"/", strings.NewReader("this is a request"))
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+
+			f.ServeHTTP(w, req)
+
+			assert.Equal(t, 247, w.Code)
+			expected := "this is from the fake service: this is a request"
+			assert.Equal(t, expected, w.Body.String())
+		})
+
+		t.Run("resolves hostnames for debugging the same as requests", func(t *testing.T) {
+			setup.ControlServer.ReloadTokens("aabbcc", "opsrocks")
+			defer setup.ControlServer.ReloadTokens("aabbcc", "")
+
+			md := make(metadata.MD)
+			md.Set("authorization", "opsrocks")
+
+			opsCtx := metadata.NewIncomingContext(ctx, md)
+
+			for _, host := range []string{name, "fuzz--aabbcc.localdomain", sseName, vhostName} {
+				t.Run(host, func(t *testing.T) {
+					conn := &recordingConnector{err: web.NewConnectError(web.ConnectRefused, errors.New("refused"))}
+
+					f, err := web.NewFrontend(L, conn, setup.ControlClient, setup.HubServToken)
+					require.NoError(t, err)
+
+					req, err := http.NewRequest("GET", "http://"+host+"/", nil)
+					require.NoError(t, err)
+
+					f.ServeHTTP(httptest.NewRecorder(), req)
+
+					resp, err := setup.ControlServer.ResolveDebug(opsCtx, f.ResolveDebugRequest(ctx, host, "/"))
+					require.NoError(t, err)
+
+					assert.True(t, setup.Account.Equal(resp.Account))
+
+					var tried, resolved []string
+
+					for _, rs := range conn.targets {
+						tried = append(tried, rs.Id.SpecString())
+					}
+
+					for _, rs := range resp.Services {
+						resolved = append(resolved, rs.Id.SpecString())
+					}
+
+					require.NotEmpty(t, resolved)
+					assert.ElementsMatch(t, resolved, tried)
+				})
+			}
+		})
+	})
+}
+
+type staticChecker map[string]bool
+
+func (s staticChecker) HandlingHostname(name string) bool {
+	return s[name]
+}
+
+func TestTLS(t *testing.T) {
+	t.Run("only obtains certificates for handled hostnames", func(t *testing.T) {
+		f := &web.Frontend{
+			Checker: staticChecker{"fuzz.localdomain": true},
+		}
+
+		assert.NoError(t, f.CertDecision("fuzz.localdomain"))
+		assert.NoError(t, f.CertDecision("fuzz--aabbcc.localdomain"))
+
+		// Deployment hosts in the other scheme are served too.
+		assert.NoError(t, f.CertDecision("aabbcc--fuzz.localdomain"))
+
+		err := f.CertDecision("other.localdomain")
+		assert.True(t, errors.Is(err, web.ErrUnhandledHostname))
+
+		var nf web.Frontend
+
+		err = nf.CertDecision("fuzz.localdomain")
+		assert.True(t, errors.Is(err, web.ErrUnhandledHostname))
+	})
+
+	t.Run("redirects plaintext requests to https", func(t *testing.T) {
+		var f web.Frontend
+
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		defer l.Close()
+
+		go f.ServeRedirect(l)
+
+		client := &http.Client{
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+
+		req, err := http.NewRequest("GET", "http://"+l.Addr().String()+"/a/b?c=d", nil)
+		require.NoError(t, err)
+
+		req.Host = "fuzz.localdomain:80"
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusMovedPermanently, resp.StatusCode)
+		assert.Equal(t, "https://fuzz.localdomain/a/b?c=d", resp.Header.Get("Location"))
+	})
+}
+
+func TestSNI(t *testing.T) {
+	t.Run("rejects handshakes for unhandled hostnames", func(t *testing.T) {
+		f := &web.Frontend{
+			L:       hclog.L(),
+			Checker: staticChecker{"fuzz.localdomain": true},
+		}
+
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		defer l.Close()
+
+		go f.ServeTLS(l, certmagic.NewDefault())
+
+		conn, err := tls.Dial("tcp", l.Addr().String(), &tls.Config{
+			ServerName:         "other.localdomain",
+			InsecureSkipVerify: true,
+		})
+
+		if conn != nil {
+			conn.Close()
+		}
+
+		require.Error(t, err)
+	})
+}
+
+func TestServe(t *testing.T) {
+	t.Run("cuts off clients that are slow to send their headers", func(t *testing.T) {
+		f := &web.Frontend{
+			L:                 hclog.L(),
+			ReadHeaderTimeout: 100 * time.Millisecond,
+		}
+
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		defer l.Close()
+
+		go f.Serve(l)
+
+		conn, err := net.Dial("tcp", l.Addr().String())
+		require.NoError(t, err)
+
+		defer conn.Close()
+
+		_, err = conn.Write([]byte("GET / HTTP/1.1\r\nHost: fuzz.localdomain\r\n"))
+		require.NoError(t, err)
+
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+		start := time.Now()
+
+		_, err = io.Copy(ioutil.Discard, conn)
+		require.NoError(t, err)
+
+		assert.True(t, time.Since(start) < 5*time.Second)
+	})
+
+	t.Run("rejects headers over the limit", func(t *testing.T) {
+		f := &web.Frontend{
+			L:              hclog.L(),
+			MaxHeaderBytes: 1024,
+		}
+
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		defer l.Close()
+
+		go f.Serve(l)
+
+		req, err := http.NewRequest("GET", "http://"+l.Addr().String()+"/", nil)
+		require.NoError(t, err)
+
+		req.Host = "fuzz.localdomain"
+		req.Header.Set("X-Big", strings.Repeat("a", 8192))
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusRequestHeaderFieldsTooLarge, resp.StatusCode)
+	})
+}
+
+// echoConnector connects to an in-memory tcp service that echos back
+// whatever it's sent, and records the protocols it was asked for.
+type echoConnector struct {
+	mu     sync.Mutex
+	protos []string
+}
+
+func (c *echoConnector) ConnectToService(
+	ctx context.Context,
+	target *pb.ServiceRoute,
+	account *pb.Account,
+	proto string,
+	token string,
+) (wire.Context, error) {
+	c.mu.Lock()
+	c.protos = append(c.protos, proto)
+	c.mu.Unlock()
+
+	reqR, reqW := io.Pipe()
+	respR, respW := io.Pipe()
+
+	fr, err := wire.NewFramingReader(respR)
+	if err != nil {
+		return nil, err
+	}
+
+	fw, err := wire.NewFramingWriter(reqW)
+	if err != nil {
+		return nil, err
+	}
+
+	sfr, err := wire.NewFramingReader(reqR)
+	if err != nil {
+		return nil, err
+	}
+
+	sfw, err := wire.NewFramingWriter(respW)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := wire.NewContext(account, sfr, sfw)
+
+	go func() {
+		defer respW.Close()
+
+		w := svc.Writer()
+		io.Copy(w, svc.Reader())
+		w.Close()
+	}()
+
+	return wire.NewContext(account, fr, fw), nil
+}
+
+// hostResolver resolves the labels of its links to its services.
+type hostResolver struct {
+	links    map[string]*pb.LabelSet
+	services []*pb.ServiceRoute
+}
+
+func (r *hostResolver) ResolvePathLabelLink(ctx context.Context, labels *pb.LabelSet, path string) (*pb.Account, *pb.LabelSet, *pb.Account_Limits, error) {
+	target, ok := r.links[labels.SpecString()]
+	if !ok {
+		return nil, nil, nil, nil
+	}
+
+	return &pb.Account{Namespace: "/", AccountId: pb.NewULID()}, target, &pb.Account_Limits{}, nil
+}
+
+func (r *hostResolver) LookupService(ctx context.Context, account *pb.Account, labels *pb.LabelSet) (*control.RouteCalculation, error) {
+	return &control.RouteCalculation{All: r.services}, nil
+}
+
+func TestTCPFrontend(t *testing.T) {
+	route := func(typ string) *pb.ServiceRoute {
+		return &pb.ServiceRoute{
+			Hub:    pb.NewULID(),
+			Id:     pb.NewULID(),
+			Type:   typ,
+			Labels: pb.ParseLabelSet("service=echo"),
+		}
+	}
+
+	resolver := &hostResolver{
+		links: map[string]*pb.LabelSet{
+			"port=7000":                  pb.ParseLabelSet("service=echo"),
+			":hostname=echo.localdomain": pb.ParseLabelSet("service=echo"),
+		},
+		services: []*pb.ServiceRoute{route("http"), route("tcp")},
+	}
+
+	serve := func(t *testing.T, f *web.TCPFrontend) net.Listener {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		go f.Serve(l)
+
+		return l
+	}
+
+	echo := func(t *testing.T, conn net.Conn) string {
+		_, err := conn.Write([]byte("hello hzn"))
+		require.NoError(t, err)
+
+		buf := make([]byte, len("hello hzn"))
+
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+		_, err = io.ReadFull(conn, buf)
+		require.NoError(t, err)
+
+		return string(buf)
+	}
+
+	t.Run("tunnels connections using the configured labels", func(t *testing.T) {
+		conn := &echoConnector{}
+
+		f := web.NewTCPFrontend(hclog.L(), conn, resolver, "token")
+		f.Labels = pb.ParseLabelSet("port=7000")
+
+		l := serve(t, f)
+		defer l.Close()
+
+		c, err := net.Dial("tcp", l.Addr().String())
+		require.NoError(t, err)
+
+		defer c.Close()
+
+		assert.Equal(t, "hello hzn", echo(t, c))
+
+		conn.mu.Lock()
+		defer conn.mu.Unlock()
+
+		assert.Equal(t, []string{"tcp"}, conn.protos)
+	})
+
+	t.Run("routes tls connections by their sni name", func(t *testing.T) {
+		certPEM, keyPEM, err := testutils.SelfSignedCert()
+		require.NoError(t, err)
+
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		require.NoError(t, err)
+
+		f := web.NewTCPFrontend(hclog.L(), &echoConnector{}, resolver, "token")
+		f.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+		f.ConnectTimeout = 5 * time.Second
+
+		l := serve(t, f)
+		defer l.Close()
+
+		addr := l.Addr().String()
+
+		c, err := tls.Dial("tcp", addr, &tls.Config{
+			ServerName:         "echo.localdomain",
+			InsecureSkipVerify: true,
+		})
+		require.NoError(t, err)
+
+		defer c.Close()
+
+		assert.Equal(t, "hello hzn", echo(t, c))
+
+		// Hostnames without a label link get their connection closed.
+		c2, err := tls.Dial("tcp", addr, &tls.Config{
+			ServerName:         "other.localdomain",
+			InsecureSkipVerify: true,
+		})
+		require.NoError(t, err)
+
+		defer c2.Close()
+
+		c2.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+		_, err = c2.Read(make([]byte, 1))
+		assert.Error(t, err)
+	})
+
+	t.Run("closes connections with no tcp services", func(t *testing.T) {
+		conn := &echoConnector{}
+
+		f := web.NewTCPFrontend(hclog.L(), conn, &hostResolver{
+			links:    resolver.links,
+			services: []*pb.ServiceRoute{route("http")},
+		}, "token")
+		f.Labels = pb.ParseLabelSet("port=7000")
+
+		l := serve(t, f)
+		defer l.Close()
+
+		c, err := net.Dial("tcp", l.Addr().String())
+		require.NoError(t, err)
+
+		defer c.Close()
+
+		c.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+		_, err = c.Read(make([]byte, 1))
+		assert.Equal(t, io.EOF, err)
+
+		conn.mu.Lock()
+		defer conn.mu.Unlock()
+
+		assert.Empty(t, conn.protos)
 	})
 }
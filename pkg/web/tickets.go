@@ -0,0 +1,164 @@
+package web
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/pkg/errors"
+)
+
+// TicketKeyStore is where the keys for TLS session tickets are kept, such as
+// the config of a data.Bolt. Frontends sharing a store share keys, so a client
+// can resume its session with any of them.
+type TicketKeyStore interface {
+	GetConfig(key string) ([]byte, error)
+	SetConfig(key string, val []byte) error
+}
+
+// The session ticket settings used when SessionTickets doesn't set its own.
+// With the defaults a ticket can be resumed for 24 to 36 hours after it was
+// issued.
+const (
+	DefaultTicketKeyRotation = 12 * time.Hour
+	DefaultTicketKeysKept    = 3
+)
+
+const ticketKeysConfigKey = "tls-session-ticket-keys"
+
+// SessionTickets manages the keys used to encrypt TLS session tickets, which let
+// returning clients resume their session without a full handshake. A new key
+// is rotated in every Rotation, and the newest Keep keys are used, the newest
+// to encrypt new tickets and the rest only to decrypt those issued before.
+type SessionTickets struct {
+	Store    TicketKeyStore
+	Rotation time.Duration
+	Keep     int
+
+	mu sync.Mutex
+}
+
+type storedTicketKeys struct {
+	Rotated time.Time `json:"rotated"`
+	Keys    [][]byte  `json:"keys"`
+}
+
+func (st *SessionTickets) rotation() time.Duration {
+	if st.Rotation > 0 {
+		return st.Rotation
+	}
+
+	return DefaultTicketKeyRotation
+}
+
+func (st *SessionTickets) keep() int {
+	if st.Keep > 0 {
+		return st.Keep
+	}
+
+	return DefaultTicketKeysKept
+}
+
+// Keys returns the session ticket keys, newest first. If the newest is older
+// than Rotation as of now, or there are none, a new one is rotated in first.
+func (st *SessionTickets) Keys(now time.Time) ([][32]byte, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	var stored storedTicketKeys
+
+	data, err := st.Store.GetConfig(ticketKeysConfigKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading session ticket keys")
+	}
+
+	if data != nil {
+		err = json.Unmarshal(data, &stored)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decoding session ticket keys")
+		}
+	}
+
+	if len(stored.Keys) == 0 || now.Sub(stored.Rotated) >= st.rotation() {
+		key := make([]byte, 32)
+
+		_, err = rand.Read(key)
+		if err != nil {
+			return nil, err
+		}
+
+		stored.Keys = append([][]byte{key}, stored.Keys...)
+		if len(stored.Keys) > st.keep() {
+			stored.Keys = stored.Keys[:st.keep()]
+		}
+
+		stored.Rotated = now
+
+		data, err = json.Marshal(&stored)
+		if err != nil {
+			return nil, err
+		}
+
+		err = st.Store.SetConfig(ticketKeysConfigKey, data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "saving session ticket keys")
+		}
+	}
+
+	var keys [][32]byte
+
+	for _, k := range stored.Keys {
+		if len(k) != 32 {
+			continue
+		}
+
+		var key [32]byte
+		copy(key[:], k)
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// Apply sets the session ticket keys of cfg to the current ones.
+func (st *SessionTickets) Apply(cfg *tls.Config) error {
+	keys, err := st.Keys(time.Now())
+	if err != nil {
+		return err
+	}
+
+	cfg.SetSessionTicketKeys(keys)
+
+	return nil
+}
+
+// Run keeps the session ticket keys of cfg current until ctx is done. Keys are
+// checked more often than they're rotated so that keys rotated in by another
+// frontend sharing the store are picked up promptly.
+func (st *SessionTickets) Run(ctx context.Context, L hclog.Logger, cfg *tls.Config) {
+	every := st.rotation() / 10
+	if every > time.Minute {
+		every = time.Minute
+	}
+
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := st.Apply(cfg)
+		if err != nil {
+			L.Error("error updating session ticket keys", "error", err)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package web
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/horizon/pkg/data"
+	"github.com/hashicorp/horizon/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type hostnames map[string]bool
+
+func (h hostnames) HandlingHostname(name string) bool {
+	return h[name]
+}
+
+func TestSessionTickets(t *testing.T) {
+	certPEM, keyPEM, err := testutils.SelfSignedCert()
+	require.NoError(t, err)
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	openStore := func(t *testing.T) (*data.Bolt, string) {
+		dir, err := ioutil.TempDir("", "hzn-tickets")
+		require.NoError(t, err)
+
+		db, err := data.NewBolt(filepath.Join(dir, "db"))
+		require.NoError(t, err)
+
+		return db, dir
+	}
+
+	// serve runs a tls server with cfg, returning its address.
+	serve := func(t *testing.T, cfg *tls.Config) (string, func()) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		go func() {
+			for {
+				conn, err := l.Accept()
+				if err != nil {
+					return
+				}
+
+				go func() {
+					defer conn.Close()
+
+					tconn := tls.Server(conn, cfg)
+					tconn.Handshake()
+				}()
+			}
+		}()
+
+		return l.Addr().String(), func() { l.Close() }
+	}
+
+	t.Run("rotates keys, keeping the newest", func(t *testing.T) {
+		db, dir := openStore(t)
+		defer os.RemoveAll(dir)
+
+		st := &SessionTickets{Store: db, Rotation: time.Hour, Keep: 2}
+
+		now := time.Now()
+
+		first, err := st.Keys(now)
+		require.NoError(t, err)
+		require.Len(t, first, 1)
+
+		same, err := st.Keys(now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, first, same)
+
+		second, err := st.Keys(now.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, second, 2)
+		assert.Equal(t, first[0], second[1])
+
+		third, err := st.Keys(now.Add(2 * time.Hour))
+		require.NoError(t, err)
+		require.Len(t, third, 2)
+		assert.Equal(t, second[0], third[1])
+		assert.NotContains(t, third, first[0])
+	})
+
+	t.Run("resumes sessions across frontends sharing a store", func(t *testing.T) {
+		db, dir := openStore(t)
+		defer os.RemoveAll(dir)
+
+		edge := func(st *SessionTickets) (string, func()) {
+			cfg := &tls.Config{Certificates: []tls.Certificate{cert}}
+			require.NoError(t, st.Apply(cfg))
+
+			return serve(t, cfg)
+		}
+
+		a, closeA := edge(&SessionTickets{Store: db})
+		defer closeA()
+
+		b, closeB := edge(&SessionTickets{Store: db})
+		defer closeB()
+
+		other, otherDir := openStore(t)
+		defer os.RemoveAll(otherDir)
+
+		c, closeC := edge(&SessionTickets{Store: other})
+		defer closeC()
+
+		cache := tls.NewLRUClientSessionCache(10)
+
+		dial := func(addr string) bool {
+			conn, err := tls.Dial("tcp", addr, &tls.Config{
+				ServerName:         "hub.test",
+				InsecureSkipVerify: true,
+				ClientSessionCache: cache,
+				// TLS 1.2 delivers the ticket during the handshake, so it's
+				// cached as soon as the handshake is done.
+				MaxVersion: tls.VersionTLS12,
+			})
+			require.NoError(t, err)
+
+			defer conn.Close()
+
+			return conn.ConnectionState().DidResume
+		}
+
+		assert.False(t, dial(a))
+		assert.True(t, dial(b))
+
+		// A frontend with its own keys can't decrypt the ticket.
+		assert.False(t, dial(c))
+	})
+
+	t.Run("passes along stapled ocsp responses", func(t *testing.T) {
+		staple := []byte("ocsp response")
+
+		stapled := cert
+		stapled.OCSPStaple = staple
+
+		f := &Frontend{Checker: hostnames{"hub.test": true}}
+
+		cfg := f.checkedTLSConfig(&tls.Config{
+			GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+				return &stapled, nil
+			},
+		})
+
+		addr, closeL := serve(t, cfg)
+		defer closeL()
+
+		conn, err := tls.Dial("tcp", addr, &tls.Config{
+			ServerName:         "hub.test",
+			InsecureSkipVerify: true,
+		})
+		require.NoError(t, err)
+
+		defer conn.Close()
+
+		assert.Equal(t, staple, conn.ConnectionState().OCSPResponse)
+	})
+}
@@ -1,11 +1,14 @@
 package web
 
 import (
+	"context"
 	"crypto/tls"
+	"net"
 	"net/http"
 
 	"github.com/caddyserver/certmagic"
 	"github.com/hashicorp/go-hclog"
+	"github.com/pkg/errors"
 )
 
 type TLS struct {
@@ -33,6 +36,12 @@ func NewTLS(L hclog.Logger, path, email string, test bool, storage certmagic.Sto
 	}, nil
 }
 
+// Config returns the certmagic configuration, suitable for passing to
+// Frontend.ServeTLS.
+func (t *TLS) Config() *certmagic.Config {
+	return t.cfg
+}
+
 func (t *TLS) ListenAndServe(addr string, h http.Handler) error {
 	listener, err := tls.Listen("tcp", addr, t.cfg.TLSConfig())
 	if err != nil {
@@ -41,3 +50,111 @@ func (t *TLS) ListenAndServe(addr string, h http.Handler) error {
 
 	return http.Serve(listener, h)
 }
+
+var ErrUnhandledHostname = errors.New("hostname not handled")
+
+// CertDecision reports if a certificate should be obtained for name. Only
+// hostnames that the Checker says we're handling are allowed, which keeps us
+// from requesting certificates for arbitrary names sent by clients.
+func (f *Frontend) CertDecision(name string) error {
+	if f.Checker == nil {
+		return errors.Wrapf(ErrUnhandledHostname, "no hostname checker configured: %s", name)
+	}
+
+	// Deployment specific hostnames are served under the same label link
+	// as the base hostname, read in either scheme the same as requests.
+	for _, dh := range f.deployHosts(name) {
+		if f.Checker.HandlingHostname(dh.host) {
+			return nil
+		}
+	}
+
+	return errors.Wrapf(ErrUnhandledHostname, "unknown hostname: %s", name)
+}
+
+// ServeTLS serves requests over TLS on l, obtaining certificates on demand via
+// cfg. If cfg doesn't provide an on demand decision function, CertDecision is
+// used so that only handled hostnames get certificates.
+func (f *Frontend) ServeTLS(l net.Listener, cfg *certmagic.Config) error {
+	if cfg.OnDemand == nil {
+		cfg.OnDemand = &certmagic.OnDemandConfig{}
+	}
+
+	if cfg.OnDemand.DecisionFunc == nil {
+		cfg.OnDemand.DecisionFunc = f.CertDecision
+	}
+
+	tlsCfg := f.TLSConfig(cfg)
+
+	if f.SessionTickets != nil {
+		err := f.SessionTickets.Apply(tlsCfg)
+		if err != nil {
+			return err
+		}
+
+		L := f.L
+		if L == nil {
+			L = hclog.L()
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go f.SessionTickets.Run(ctx, L, tlsCfg)
+	}
+
+	return f.httpServer(f).Serve(tls.NewListener(l, tlsCfg))
+}
+
+// TLSConfig returns the tls configuration for cfg, adjusted to reject
+// handshakes for hostnames we don't handle. Checking the SNI name up front means
+// scanners and stale DNS entries fail fast, rather than completing a handshake
+// (and possibly an ACME issuance) only to get an error from ServeHTTP.
+//
+// certmagic staples the OCSP responses of the certificates it manages, which
+// are passed along with the certificates, so clients don't have to ask the CA
+// whether they're revoked.
+func (f *Frontend) TLSConfig(cfg *certmagic.Config) *tls.Config {
+	return f.checkedTLSConfig(cfg.TLSConfig())
+}
+
+// checkedTLSConfig adjusts tlsCfg to only hand out certificates for the
+// hostnames allowed by CertDecision.
+func (f *Frontend) checkedTLSConfig(tlsCfg *tls.Config) *tls.Config {
+	getCert := tlsCfg.GetCertificate
+
+	tlsCfg.GetCertificate = func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		err := f.CertDecision(hello.ServerName)
+		if err != nil {
+			if f.L != nil {
+				f.L.Debug("rejecting tls handshake", "server-name", hello.ServerName)
+			}
+
+			return nil, err
+		}
+
+		return getCert(hello)
+	}
+
+	return tlsCfg
+}
+
+// ServeRedirect serves plaintext requests on l by permanently redirecting them
+// to the same URL over https.
+func (f *Frontend) ServeRedirect(l net.Listener) error {
+	return f.httpServer(http.HandlerFunc(redirectHTTPS)).Serve(l)
+}
+
+func redirectHTTPS(w http.ResponseWriter, req *http.Request) {
+	host := req.Host
+
+	// Any port on the request is for the plaintext listener, so drop it and
+	// let the https default apply.
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	target := "https://" + host + req.URL.RequestURI()
+
+	http.Redirect(w, req, target, http.StatusMovedPermanently)
+}
@@ -41,6 +41,9 @@ type HostnameChecker interface {
 	HandlingHostname(name string) bool
 }
 
+// Connector connects to a service. When it fails, it should return a
+// ConnectError saying why, so the frontends know whether it's worth trying
+// another service. Other errors are treated as ConnectFailed and retried.
 type Connector interface {
 	ConnectToService(
 		ctx context.Context,
@@ -51,6 +54,31 @@ type Connector interface {
 	) (wire.Context, error)
 }
 
+// Resolver maps a request's hostname to an account and the services that can
+// handle it. It's satisfied by *control.Client. Both methods are passed the
+// context of the resolve phase, and should give up once it's done.
+type Resolver interface {
+	ResolvePathLabelLink(ctx context.Context, label *pb.LabelSet, path string) (*pb.Account, *pb.LabelSet, *pb.Account_Limits, error)
+	LookupService(ctx context.Context, account *pb.Account, labels *pb.LabelSet) (*control.RouteCalculation, error)
+}
+
+// The label a service can use to pick the encoding of the request and
+// response messages it's sent, such as ":wire-encoding=json".
+const WireEncodingLabel = ":wire-encoding"
+
+// The label a service can use to have requests sent to it with a fixed Host
+// header, such as ":host-header=internal.example.com", rather than the one
+// the client used.
+const HostHeaderLabel = ":host-header"
+
+// The status codes returned when a phase of a request runs past its deadline,
+// so it's clear from the response which phase it was.
+const (
+	ResolveTimeoutStatus = http.StatusServiceUnavailable
+	ConnectTimeoutStatus = http.StatusBadGateway
+	ProxyTimeoutStatus   = http.StatusGatewayTimeout
+)
+
 type ratesPerAccount struct {
 	bandwidth *rate.Limiter
 	requests  *rate.Limiter
@@ -68,8 +96,138 @@ type Frontend struct {
 	token      string
 	endpointId string
 
+	// Called with a flow record for each service requests were proxied to,
+	// summing up the size of the request and response bodies over each
+	// FlowInterval. With a control client, defaults to sending the record to
+	// control, the same as the flows generated by hubs, dropping it if the
+	// queue to control is full. Without one, flows aren't reported.
+	ReportFlow func(rec *pb.FlowRecord)
+
+	// How often flows are reported. Defaults to DefaultFlowInterval.
+	FlowInterval time.Duration
+
+	// The traffic waiting to be reported.
+	flows webFlows
+
+	// Used to find the services for a request. Defaults to the control client,
+	// if there is one.
+	// Once the Frontend is serving, replace it with SetResolver.
+	Resolver Resolver
+
+	// Generates the id of each request, which is logged. Defaults to
+	// pb.NewULID.
+	NewID func() *pb.ULID
+
+	// Where deployment specific hostnames put the deployment id. Defaults to
+	// DeployIdSuffix, "name--deploy.domain". Hosts in the other scheme are
+	// still accepted, but only used if there's no label link for the host
+	// read this way.
+	DeployHostScheme DeployHostScheme
+
+	// Deadlines for each phase of a request: resolving the hostname to
+	// services, connecting to one of them, and proxying the request and
+	// response. Each phase gets its own context derived from the request's,
+	// so a slow phase can't use up the time meant for the next one. Zero
+	// means no deadline.
+	ResolveTimeout time.Duration
+	ConnectTimeout time.Duration
+	ProxyTimeout   time.Duration
+
+	// The encoding of the request and response messages sent to services,
+	// see wire.WithEncoding. A service can ask for a different one with the
+	// WireEncodingLabel label. Defaults to protobuf.
+	WireEncoding string
+
+	// Limits applied to client connections by the http server, see
+	// http.Server. Zero uses the defaults below. Proxied requests and
+	// responses stream for as long as the service is sending them, so there's
+	// no default ReadTimeout or WriteTimeout, but clients have to send their
+	// headers promptly and can only hold an idle connection open for so long.
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+
+	// Which headers are passed from clients to services, and from services
+	// back to clients. Hop-by-hop headers are never passed.
+	RequestHeaders  HeaderFilter
+	ResponseHeaders HeaderFilter
+
+	// Labels of services, by hostname, that get a copy of each request to
+	// that hostname, for trying them out against real traffic. The copy is
+	// sent in the background once the request has been sent to the primary
+	// service, and the mirror's response is discarded, so it never changes
+	// what the client gets. Requests with bodies over MirrorMaxBody aren't
+	// mirrored. Zero MirrorMaxBody and MirrorTimeout use the defaults.
+	Mirrors       map[string]*pb.LabelSet
+	MirrorMaxBody int64
+	MirrorTimeout time.Duration
+
+	// Where to find the pages served for suspended accounts, and for
+	// requests with no service available to take them. When unset, or an
+	// account has no pages, the generic error page is served instead.
+	Pages PageSource
+
+	// Forward the Host the client used as a Host header, for services that
+	// pick a virtual host from their headers. Services with the
+	// HostHeaderLabel label get the value of the label instead, whether or
+	// not this is set.
+	PreserveHost bool
+
+	// The largest request body, in bytes, passed on to services. Requests
+	// that declare a larger body get a 413 without a service being connected
+	// to, and those that don't declare their length are cut off with a 413
+	// once they go over. Services can set their own limit with the
+	// MaxBodyLabel label. Zero means no limit.
+	MaxRequestBody int64
+
+	// If set, consulted for each request once the account it's for is known,
+	// to throttle accounts or clients on top of the request limits of their
+	// accounts.
+	RateLimiter RateLimiter
+
+	// If set, the keys for TLS session tickets are taken from it by ServeTLS
+	// and rotated, rather than each process using its own, so clients can
+	// resume their sessions with any frontend sharing the store.
+	SessionTickets *SessionTickets
+
+	// Keep each client on the target of a label link's split that it was
+	// first sent to, using the SplitCookie cookie, rather than picking one for
+	// every request. See SplitResolver.
+	StickySplits bool
+
+	// Gzip the responses of services for clients that accept it, unless the
+	// service already encoded them. Only responses of at least
+	// CompressionMinSize bytes are compressed, judged by their
+	// Content-Length or, without one, by holding back the start of the body
+	// until it's that long. Defaults to DefaultCompressionMinSize.
+	Compress           bool
+	CompressionMinSize int
+
+	// If set, services that keep failing to connect or respond are skipped
+	// until their circuit breaker lets a probe through, see CircuitBreakers.
+	// Services are still tried in the order the balancer picked, with the
+	// open ones left out.
+	Breakers *CircuitBreakers
+
+	// If set, each resolution the Resolver gives is saved in it, and while
+	// the Resolver is failing, such as when control can't be reached,
+	// requests are served from the last resolution saved for them instead.
+	// Requests with nothing saved get FallbackMissStatus. Use a store that
+	// survives a restart, such as a data.Bolt, so a frontend that starts
+	// while control is down can still serve.
+	Fallback ResolutionCache
+
+	// What was last saved to Fallback, so it's only written when it changes.
+	fallbackSaved fallbackSaved
+
 	mu    sync.Mutex
 	rates *lru.ARCCache
+
+	// Guards Resolver and hub, so they can be swapped while requests are
+	// being served.
+	swapMu sync.RWMutex
 }
 
 func NewFrontend(L hclog.Logger, h Connector, cl *control.Client, token string) (*Frontend, error) {
@@ -78,38 +236,92 @@ func NewFrontend(L hclog.Logger, h Connector, cl *control.Client, token string)
 		return nil, err
 	}
 
-	return &Frontend{
-		L:          L,
-		client:     cl,
-		hub:        h,
-		token:      token,
-		rates:      lr,
-		endpointId: cl.Id().SpecString(),
-	}, nil
+	f := &Frontend{
+		L:      L,
+		client: cl,
+		hub:    h,
+		token:  token,
+		rates:  lr,
+	}
+
+	// Without a control client, the Resolver has to be set by the caller.
+	if cl != nil {
+		f.Resolver = cl
+		f.endpointId = cl.Id().SpecString()
+
+		f.ReportFlow = func(rec *pb.FlowRecord) {
+			cl.TrySendFlow(rec)
+		}
+	}
+
+	return f, nil
+}
+
+// SetResolver replaces the Resolver used to find the services for requests,
+// such as to point at a new control endpoint, without restarting the
+// Frontend. Requests already being handled finish with the one they started
+// with.
+func (f *Frontend) SetResolver(r Resolver) {
+	f.swapMu.Lock()
+	defer f.swapMu.Unlock()
+
+	f.Resolver = r
+}
+
+// SetConnector replaces the Connector used to connect to services, the same
+// way as SetResolver.
+func (f *Frontend) SetConnector(c Connector) {
+	f.swapMu.Lock()
+	defer f.swapMu.Unlock()
+
+	f.hub = c
 }
 
+// backends returns the Resolver and Connector for a request to use from start
+// to finish.
+func (f *Frontend) backends() (Resolver, Connector) {
+	f.swapMu.RLock()
+	defer f.swapMu.RUnlock()
+
+	return f.Resolver, f.hub
+}
+
+// The connection limits used when the Frontend doesn't set its own.
+const (
+	DefaultReadHeaderTimeout = 10 * time.Second
+	DefaultIdleTimeout       = 2 * time.Minute
+	DefaultMaxHeaderBytes    = 64 * 1024
+)
+
 func (f *Frontend) Serve(l net.Listener) error {
-	return http.Serve(l, f)
+	return f.httpServer(f).Serve(l)
 }
 
-func (f *Frontend) extractHost(host string) (string, string, bool) {
-	var first, domain string
+// httpServer returns an http.Server for h configured with the connection
+// limits of f.
+func (f *Frontend) httpServer(h http.Handler) *http.Server {
+	hs := &http.Server{
+		Handler:           h,
+		ReadHeaderTimeout: f.ReadHeaderTimeout,
+		ReadTimeout:       f.ReadTimeout,
+		WriteTimeout:      f.WriteTimeout,
+		IdleTimeout:       f.IdleTimeout,
+		MaxHeaderBytes:    f.MaxHeaderBytes,
+	}
 
-	firstDot := strings.IndexByte(host, '.')
-	if firstDot != -1 {
-		first = host[:firstDot]
-		domain = host[firstDot:]
-	} else {
-		first = host
-		domain = ""
+	if hs.ReadHeaderTimeout == 0 {
+		hs.ReadHeaderTimeout = DefaultReadHeaderTimeout
+	}
+
+	if hs.IdleTimeout == 0 {
+		hs.IdleTimeout = DefaultIdleTimeout
 	}
 
-	suffixDash := strings.LastIndex(first, "--")
-	if suffixDash == -1 {
-		return host, "", false
+	if hs.MaxHeaderBytes == 0 {
+		hs.MaxHeaderBytes = DefaultMaxHeaderBytes
 	}
 
-	return first[:suffixDash] + domain, first[suffixDash+2:], true
+	return hs
 }
 
 func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
@@ -120,11 +332,15 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 	ctx := timing.WithTracker(req.Context(), &tr)
 
+	resolver, connector := f.backends()
+
 	start := time.Now()
 
 	rm := th.NewMetric("resolve").Start()
 
-	host, deployId, deploySpecific := f.extractHost(req.Host)
+	hosts := f.deployHosts(req.Host)
+
+	host, deployId, deploySpecific := hosts[0].host, hosts[0].deployId, hosts[0].deploySpecific
 
 	// If we're requesting the root, show our root page.
 	if host == "waypoint.run" {
@@ -138,16 +354,39 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	ll := &pb.LabelSet{
-		Labels: []*pb.Label{
-			{
-				Name:  ":hostname",
-				Value: host,
-			},
-		},
+	rctx, rcancel := phaseContext(ctx, f.ResolveTimeout)
+	defer rcancel()
+
+	var (
+		ll      *pb.LabelSet
+		account *pb.Account
+		target  *pb.LabelSet
+		limits  *pb.Account_Limits
+		err     error
+	)
+
+	for _, dh := range hosts {
+		ll = hostnameLabels(dh.host)
+
+		account, target, limits, err = f.resolveLabelLink(rctx, resolver, ll, req.URL.Path)
+		if target != nil || (err != nil && err != errNoFallback) {
+			host, deployId, deploySpecific = dh.host, dh.deployId, dh.deploySpecific
+			break
+		}
+	}
+
+	if target == nil && rctx.Err() == context.DeadlineExceeded {
+		f.phaseTimedOut(w, "resolve", ResolveTimeoutStatus, req.Host)
+		return
+	}
+
+	if err == errNoFallback {
+		renderError(w, fmt.Sprintf(
+			"unable to resolve host: %s", req.Host),
+			FallbackMissStatus)
+		return
 	}
 
-	account, target, limits, err := f.client.ResolveLabelLink(ll)
 	if err != nil || target == nil {
 		if deploySpecific {
 			f.L.Error("unable to resolve label link", "error", err, "http-host", req.Host, "lookup-host", host, "deploy-id", deployId)
@@ -164,6 +403,8 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	target = f.splitTarget(w, req, resolver, ll, target)
+
 	if deploySpecific {
 		target = target.Add(":deployment", deployId)
 	}
@@ -174,8 +415,30 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		limits = &pb.Account_Limits{}
 	}
 
+	pages := f.accountPages(account)
+
+	if pages != nil && pages.Suspended {
+		f.L.Info("request for suspended account", "account", account, "hostname", req.Host)
+		servePage(w, pages.SuspendedPage, "account suspended", http.StatusServiceUnavailable)
+		return
+	}
+
 	rm.Stop()
 
+	if f.RateLimiter != nil {
+		ip := clientIP(req)
+
+		if ok, delay := f.RateLimiter.Allow(account, ip); !ok {
+			f.L.Info("request rate limited", "account", account.SpecString(), "client", ip, "retry-after", delay)
+
+			w.Header().Set("Retry-After", retryAfter(delay))
+			renderError(w,
+				"too many requests",
+				http.StatusTooManyRequests)
+			return
+		}
+	}
+
 	var rates *ratesPerAccount
 
 	rv, ok := f.rates.Get(account.SpecString())
@@ -246,7 +509,7 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 	lu := th.NewMetric("lookup").Start()
 
-	reqId := pb.NewULID()
+	reqId := newID(f.NewID)
 
 	f.L.Info("request",
 		"id", reqId,
@@ -260,7 +523,32 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		f.L.Info("request finished", "id", reqId, "duration", time.Since(start))
 	}()
 
-	calc, err := f.client.LookupService(ctx, account, target)
+	calc, err := resolver.LookupService(rctx, account, target)
+
+	// A resolution saved before is used when the Resolver fails, including
+	// by running out of time.
+	if err != nil && f.Fallback != nil {
+		if fb := f.fallbackServices(account, target, err); fb != nil {
+			calc, err = fb, nil
+		}
+	} else if err == nil {
+		f.saveServices(account, target, calc)
+	}
+
+	if err != nil && rctx.Err() == context.DeadlineExceeded {
+		f.phaseTimedOut(w, "resolve", ResolveTimeoutStatus, req.Host)
+		return
+	}
+
+	rcancel()
+
+	if err != nil && f.Fallback != nil {
+		renderError(w, fmt.Sprintf(
+			"unable to find services for host: %s", req.Host),
+			FallbackMissStatus)
+		return
+	}
+
 	if err != nil {
 		f.L.Error("error resolving labels to services", "error", err, "labels", target)
 		renderError(w,
@@ -274,7 +562,7 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			"account", account,
 			"target", target,
 		)
-		renderError(w,
+		servePage(w, pages.GetUnavailablePage(),
 			"no deployments for service",
 			http.StatusNotFound)
 		return
@@ -282,28 +570,129 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 	lu.Stop()
 
-	var wctx wire.Context
+	var (
+		wctx    wire.Context
+		service *pb.ServiceRoute
+	)
 
-	services := calc.Services()
+	// Targets can match a mix of service types, so pick out the http ones
+	// wherever they are in the list.
+	var services []*pb.ServiceRoute
 
-	for _, rs := range services {
+	for _, rs := range calc.Services() {
 		if rs.Type != "http" {
 			f.L.Warn("service was not type http", "service-id", rs.Id, "type", rs.Type)
 			continue
 		}
 
-		wctx, err = f.hub.ConnectToService(ctx, rs, account, "http", f.token)
+		services = append(services, rs)
+	}
+
+	if len(services) == 0 {
+		f.L.Error("no http services for target",
+			"account", account,
+			"target", target,
+		)
+		servePage(w, pages.GetUnavailablePage(),
+			"no http services for target",
+			http.StatusNotFound)
+		return
+	}
+
+	// The connect deadline only applies until we've connected. The context
+	// itself lives on with the connection, since the connector may tie the
+	// connection to it.
+	cctx, ccancel := context.WithCancel(ctx)
+	defer ccancel()
+
+	var connectTimer *time.Timer
+
+	if f.ConnectTimeout > 0 {
+		connectTimer = time.AfterFunc(f.ConnectTimeout, ccancel)
+	}
+
+	var tooLarge, broken bool
+
+	for _, rs := range services {
+		if cctx.Err() != nil {
+			break
+		}
+
+		if max := f.maxRequestBody(rs); max > 0 && req.ContentLength > max {
+			f.L.Debug("request body too large for service", "id", reqId, "service-id", rs.Id, "content-length", req.ContentLength, "max", max)
+			tooLarge = true
+			continue
+		}
+
+		if !f.Breakers.Allow(rs.Id) {
+			f.L.Debug("circuit breaker open, skipping service", "id", reqId, "service-id", rs.Id, "hub", rs.Hub)
+			broken = true
+			continue
+		}
+
+		encoding := f.wireEncoding(rs)
+
+		var conn wire.Context
+
+		conn, err = connector.ConnectToService(cctx, rs, account, wire.ProtocolWithEncoding("http", encoding), f.token)
+		if err == nil {
+			wctx, err = wire.WithEncoding(conn, encoding)
+			if err != nil {
+				conn.Close()
+			}
+		}
+
 		if err == nil {
+			if rs.Unhealthy {
+				f.L.Warn("using service on unhealthy hub as a last resort", "service-id", rs.Id, "hub", rs.Hub)
+			}
+
+			service = rs
+			break
+		}
+
+		if !retryConnect(err) {
+			f.L.Error("connection to service rejected, not trying other services",
+				"error", err, "kind", ConnectErrorKindOf(err), "labels", target, "service", rs.Id, "hub", rs.Hub)
 			break
 		}
 
-		f.L.Warn("error connecting to service", "error", err, "labels", target, "service", rs.Id, "hub", rs.Hub)
-		continue
+		// Only failures another service might not have count against the
+		// service's breaker, and not ones from giving up on connecting.
+		if cctx.Err() == nil {
+			f.Breakers.Failure(rs.Id)
+		}
+
+		f.L.Warn("error connecting to service", "error", err, "kind", ConnectErrorKindOf(err), "labels", target, "service", rs.Id, "hub", rs.Hub)
+	}
+
+	if connectTimer != nil && !connectTimer.Stop() {
+		if wctx != nil {
+			wctx.Close()
+		}
+
+		f.phaseTimedOut(w, "connect", ConnectTimeoutStatus, req.Host)
+		return
+	}
+
+	if wctx == nil && tooLarge && err == nil {
+		renderError(w,
+			ErrBodyTooLarge.Error(),
+			http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if wctx == nil && broken && err == nil {
+		f.L.Error("circuit breakers open for every service", "labels", target, "candidates", len(services))
+		servePage(w, pages.GetUnavailablePage(),
+			"no service is currently available",
+			http.StatusServiceUnavailable)
+		return
 	}
 
 	if wctx == nil {
 		f.L.Error("no viable service found", "labels", target, "candidates", len(services))
-		renderError(w,
+		servePage(w, pages.GetUnavailablePage(),
 			"unable to find viable endpoint",
 			http.StatusInternalServerError)
 		return
@@ -313,12 +702,32 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 	defer wctx.Close()
 
+	pctx, pcancel := phaseContext(ctx, f.ProxyTimeout)
+	defer pcancel()
+
+	// Requests can sit idle for a long time, streaming ones especially, so
+	// rather than waiting for the next read or write to fail, close the
+	// connection to the service as soon as the client goes away or the proxy
+	// deadline passes.
+	go func() {
+		<-pctx.Done()
+
+		if pctx.Err() == context.DeadlineExceeded {
+			f.L.Trace("proxy deadline exceeded", "id", reqId)
+		} else if req.Context().Err() != nil {
+			f.L.Trace("client disconnected", "id", reqId)
+		}
+
+		wctx.Close()
+	}()
+
 	var wreq pb.Request
 	wreq.Host = req.Host
 	wreq.Method = req.Method
 	wreq.Path = req.URL.EscapedPath()
 	wreq.Query = req.URL.RawQuery
 	wreq.Fragment = req.URL.Fragment
+	wreq.HttpVersion = req.Proto
 	if user, pass, ok := req.BasicAuth(); ok {
 		wreq.Auth = &pb.Auth{
 			User:     user,
@@ -326,15 +735,46 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		}
 	}
 
+	// Event streams are passed through untouched, so don't ask the service
+	// to compress them.
+	eventStream := acceptsEventStream(req)
+
+	reqConn := connectionHeaders(req.Header["Connection"])
+
 	for k, v := range req.Header {
+		if eventStream && k == "Accept-Encoding" {
+			continue
+		}
+
+		if !f.RequestHeaders.passes(k, reqConn) {
+			continue
+		}
+
 		wreq.Headers = append(wreq.Headers, &pb.Header{
 			Name:  k,
 			Value: v,
 		})
 	}
 
+	if host, ok := f.hostHeader(service, req); ok {
+		wreq.Host = host
+		wreq.Headers = append(wreq.Headers, &pb.Header{
+			Name:  "Host",
+			Value: []string{host},
+		})
+	}
+
 	err = wctx.WriteMarshal(1, &wreq)
+	if pctx.Err() == context.DeadlineExceeded {
+		f.phaseTimedOut(w, "proxy", ProxyTimeoutStatus, req.Host)
+		return
+	}
+
 	if err != nil {
+		if req.Context().Err() == nil {
+			f.Breakers.Failure(service.Id)
+		}
+
 		f.L.Error("error connecting to service", "error", err, "labels", target)
 		renderError(w,
 			err.Error(),
@@ -342,10 +782,49 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	mirror := f.Mirrors[host]
+
+	var (
+		body   io.Reader = req.Body
+		limit  *bodyLimit
+		mirBuf *mirrorBuffer
+	)
+
+	// Bodies that declared their length were checked before connecting.
+	if max := f.maxRequestBody(service); max > 0 && req.ContentLength < 0 {
+		limit = &bodyLimit{r: body, max: max}
+		body = limit
+	}
+
+	if mirror != nil {
+		mirBuf = &mirrorBuffer{max: f.mirrorMaxBody()}
+		body = io.TeeReader(body, mirBuf)
+	}
+
 	adapter := wctx.Writer()
-	io.Copy(adapter, req.Body)
+	reqBytes, _ := io.Copy(adapter, body)
+
+	if limit != nil && limit.over {
+		// Closing the connection without finishing the body means the
+		// service never sees a request that was cut short.
+		f.L.Debug("request body too large for service", "id", reqId, "service-id", service.Id, "max", limit.max)
+		renderError(w,
+			ErrBodyTooLarge.Error(),
+			http.StatusRequestEntityTooLarge)
+		return
+	}
+
 	adapter.Close()
 
+	if mirBuf != nil {
+		if mirBuf.over {
+			f.L.Debug("request body too large to mirror", "id", reqId, "request-bytes", reqBytes)
+		} else {
+			mreq := wreq
+			go f.mirrorRequest(reqId, account, mirror, &mreq, mirBuf.buf.Bytes())
+		}
+	}
+
 	bt.Stop()
 
 	rt := th.NewMetric("response-header").Start()
@@ -353,16 +832,54 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	var wresp pb.Response
 
 	tag, err := wctx.ReadMarshal(&wresp)
-	if err != nil || tag != 1 {
+	if pctx.Err() == context.DeadlineExceeded {
+		f.phaseTimedOut(w, "proxy", ProxyTimeoutStatus, req.Host)
+		return
+	}
+
+	// Both failures are the service's doing, but keep them apart so a
+	// service speaking the wrong protocol can be told from a broken
+	// connection.
+	if err != nil {
+		// When the client went away the connection was closed under the
+		// read, which says nothing about the service.
+		if req.Context().Err() == nil {
+			f.Breakers.Failure(service.Id)
+		}
+
+		f.L.Error("error reading response from service", "id", reqId, "error", err, "service-id", service.Id, "hub", service.Hub)
 		renderError(w,
-			err.Error(),
-			http.StatusInternalServerError)
+			"error reading response from service: "+err.Error(),
+			http.StatusBadGateway)
+		return
+	}
+
+	if tag != 1 {
+		f.Breakers.Failure(service.Id)
+		f.L.Error("service sent an unexpected message instead of a response", "id", reqId, "tag", tag, "service-id", service.Id, "hub", service.Hub)
+		renderError(w,
+			fmt.Sprintf("service sent an unexpected message (tag %d) instead of a response", tag),
+			http.StatusBadGateway)
 		return
 	}
 
+	f.Breakers.Success(service.Id)
+
 	hdr := w.Header()
 
+	var respConn []string
+
 	for _, h := range wresp.Headers {
+		if strings.EqualFold(h.Name, "Connection") {
+			respConn = append(respConn, connectionHeaders(h.Value)...)
+		}
+	}
+
+	for _, h := range wresp.Headers {
+		if !f.ResponseHeaders.passes(h.Name, respConn) {
+			continue
+		}
+
 		for _, v := range h.Value {
 			hdr.Add(h.Name, v)
 		}
@@ -385,10 +902,178 @@ func (f *Frontend) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		hdr.Add("X-Horizon-Warn", "This account is experiencing rate limiting.")
 	}
 
-	w.WriteHeader(int(wresp.Code))
+	fl, canFlush := w.(http.Flusher)
+
+	streaming := canFlush && (eventStream || isStreamingResponse(hdr))
+
+	if streaming {
+		// Make sure nothing between us and the client holds on to the
+		// response waiting for more of it.
+		hdr.Del("Content-Length")
+		hdr.Set("X-Accel-Buffering", "no")
+
+		if hdr.Get("Cache-Control") == "" {
+			hdr.Set("Cache-Control", "no-cache")
+		}
+	}
+
+	var cw *compressWriter
+
+	// Streaming responses have to get to the client as they arrive, so
+	// they're never held back to see if they're worth compressing.
+	if !streaming {
+		cw = f.compressWriter(w, req, int(wresp.Code))
+	}
+
+	var out io.Writer = w
+
+	if cw != nil {
+		out = cw
+	} else {
+		w.WriteHeader(int(wresp.Code))
+	}
+
+	// Streaming responses are flushed as each chunk arrives from the service
+	// rather than whenever the server's buffer fills.
+	if streaming {
+		f.L.Trace("flushing streaming response", "id", reqId, "content-type", hdr.Get("Content-Type"))
+
+		fl.Flush()
+		out = &flushWriter{w: w, f: fl}
+	}
 
 	f.L.Trace("copying request body", "id", reqId)
-	io.Copy(w, &ratedReader{f: f, r: wctx.Reader(), acc: rates})
+	respBytes, _ := io.Copy(out, &ratedReader{f: f, r: wctx.Reader(), acc: rates})
+
+	if cw != nil {
+		err = cw.Close()
+		if err != nil {
+			f.L.Debug("error finishing compressed response", "id", reqId, "error", err)
+		}
+	}
+
+	// The status has already been sent, so all we can do is note that the
+	// response was cut short.
+	if pctx.Err() == context.DeadlineExceeded {
+		f.L.Error("request phase timed out", "phase", "proxy", "id", reqId, "response-bytes", respBytes)
+	}
+
+	f.L.Trace("request body sizes", "id", reqId, "request", reqBytes, "response", respBytes)
+
+	if f.ReportFlow != nil {
+		now := time.Now()
+
+		// The flow went from this frontend's hub, if it has one, to the one
+		// the service is connected to.
+		var hubId *pb.ULID
+
+		if f.client != nil {
+			hubId = f.client.Id()
+		}
+
+		f.addFlow(&pb.FlowStream{
+			HubId:       hubId,
+			AgentId:     service.Hub,
+			ServiceId:   service.Id,
+			Account:     account,
+			Labels:      target,
+			StartedAt:   pb.NewTimestamp(start),
+			EndedAt:     pb.NewTimestamp(now),
+			NumMessages: 1,
+			NumBytes:    reqBytes + respBytes,
+			Duration:    int64(now.Sub(start)),
+		})
+	}
+}
+
+// newID calls gen if it's set, otherwise pb.NewULID.
+func newID(gen func() *pb.ULID) *pb.ULID {
+	if gen != nil {
+		return gen()
+	}
+
+	return pb.NewULID()
+}
+
+// wireEncoding returns the encoding to use for the messages sent to rs.
+func (f *Frontend) wireEncoding(rs *pb.ServiceRoute) string {
+	if rs.Labels != nil {
+		if encoding, ok := rs.Labels.GetLabel(WireEncodingLabel); ok {
+			return encoding
+		}
+	}
+
+	return f.WireEncoding
+}
+
+// hostHeader returns the Host header to send to rs, if one should be sent.
+func (f *Frontend) hostHeader(rs *pb.ServiceRoute, req *http.Request) (string, bool) {
+	if rs.Labels != nil {
+		if host, ok := rs.Labels.GetLabel(HostHeaderLabel); ok && host != "" {
+			return host, true
+		}
+	}
+
+	if f.PreserveHost {
+		return req.Host, true
+	}
+
+	return "", false
+}
+
+func hostnameLabels(host string) *pb.LabelSet {
+	return &pb.LabelSet{
+		Labels: []*pb.Label{
+			{
+				Name:  ":hostname",
+				Value: host,
+			},
+		},
+	}
+}
+
+// resolveLabelLink resolves ll and path with resolver. If that fails, including
+// by running out of time, and f.Fallback is set, the last resolution saved
+// there is used instead, and if there isn't one, errNoFallback is returned.
+func (f *Frontend) resolveLabelLink(ctx context.Context, resolver Resolver, ll *pb.LabelSet, path string) (*pb.Account, *pb.LabelSet, *pb.Account_Limits, error) {
+	account, target, limits, err := resolver.ResolvePathLabelLink(ctx, ll, path)
+	if err != nil {
+		if f.Fallback == nil {
+			return nil, nil, nil, err
+		}
+
+		fb := f.fallbackLabelLink(ll, path, err)
+		if fb == nil {
+			return nil, nil, nil, errNoFallback
+		}
+
+		return fb.Account, fb.Target, fb.Limits, nil
+	}
+
+	if target != nil {
+		f.saveLabelLink(ll, labelLinkPrefix(resolver, ll, path), account, target, limits)
+	} else {
+		f.dropLabelLink(ll, path)
+	}
+
+	return account, target, limits, nil
+}
+
+func phaseContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+func (f *Frontend) phaseTimedOut(w http.ResponseWriter, phase string, code int, host string) {
+	f.L.Error("request phase timed out", "phase", phase, "hostname", host)
+
+	w.Header().Add("X-Horizon-Endpoint", f.endpointId)
+	w.Header().Set("X-Horizon-Timeout", phase)
+
+	renderError(w, fmt.Sprintf("request timed out during %s", phase), code)
 }
 
 func renderError(w http.ResponseWriter, fallback string, code int) {
@@ -402,6 +1087,62 @@ func renderError(w http.ResponseWriter, fallback string, code int) {
 	fmt.Fprintf(w, string(data))
 }
 
+// Responses with these content types are flushed to the client as the
+// service writes them.
+var StreamingContentTypes = []string{
+	"text/event-stream",
+	"application/x-ndjson",
+}
+
+func isStreamingResponse(hdr http.Header) bool {
+	ct := hdr.Get("Content-Type")
+
+	// Ignore any parameters, such as charset.
+	if idx := strings.IndexByte(ct, ';'); idx != -1 {
+		ct = ct[:idx]
+	}
+
+	ct = strings.TrimSpace(strings.ToLower(ct))
+
+	for _, st := range StreamingContentTypes {
+		if ct == st {
+			return true
+		}
+	}
+
+	return false
+}
+
+func acceptsEventStream(req *http.Request) bool {
+	for _, v := range req.Header["Accept"] {
+		for _, part := range strings.Split(v, ",") {
+			if idx := strings.IndexByte(part, ';'); idx != -1 {
+				part = part[:idx]
+			}
+
+			if strings.TrimSpace(strings.ToLower(part)) == "text/event-stream" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+type flushWriter struct {
+	w io.Writer
+	f http.Flusher
+}
+
+func (fw *flushWriter) Write(b []byte) (int, error) {
+	n, err := fw.w.Write(b)
+	if n > 0 {
+		fw.f.Flush()
+	}
+
+	return n, err
+}
+
 type ratedReader struct {
 	f   *Frontend
 	r   io.Reader
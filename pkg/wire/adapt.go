@@ -55,6 +55,13 @@ func (f *ReadAdapter) Read(b []byte) (int, error) {
 
 	tag, sz, err := f.FR.Next()
 	if err != nil {
+		// The writer ends the data with an EOF frame, so the stream ending
+		// first means the data was cut short, such as by the writer giving
+		// up part way through. Don't let readers mistake it for the end.
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+
 		return 0, err
 	}
 
@@ -0,0 +1,80 @@
+package wire
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// The encodings that the structured messages sent with WriteMarshal, such as
+// the pb.Request and pb.Response of an http request, can use. Data sent with
+// Writer and Reader is never encoded. Protobuf is the default; JSON is for
+// debugging and for services that aren't written in Go.
+const (
+	ProtobufEncoding = ""
+	JSONEncoding     = "json"
+)
+
+var ErrUnknownEncoding = errors.New("unknown wire encoding")
+
+// ProtocolWithEncoding returns the protocol id that asks a service to use
+// encoding for the structured messages of proto, for instance "http+json".
+func ProtocolWithEncoding(proto, encoding string) string {
+	if encoding == ProtobufEncoding {
+		return proto
+	}
+
+	return proto + "+" + encoding
+}
+
+// SplitProtocol splits a protocol id from ProtocolWithEncoding back into the
+// protocol and encoding.
+func SplitProtocol(id string) (string, string) {
+	pos := strings.LastIndexByte(id, '+')
+	if pos == -1 {
+		return id, ProtobufEncoding
+	}
+
+	return id[:pos], id[pos+1:]
+}
+
+// WithEncoding returns a Context whose ReadMarshal and WriteMarshal use
+// encoding for the messages. Both ends of a connection need to use the same
+// encoding.
+func WithEncoding(c Context, encoding string) (Context, error) {
+	switch encoding {
+	case ProtobufEncoding:
+		return c, nil
+	case JSONEncoding:
+		return &jsonContext{Context: c}, nil
+	default:
+		return nil, errors.Wrapf(ErrUnknownEncoding, "%s", encoding)
+	}
+}
+
+type jsonContext struct {
+	Context
+}
+
+func (j *jsonContext) WriteMarshal(tag byte, v Marshaller) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	mb := MarshalBytes(data)
+
+	return j.Context.WriteMarshal(tag, &mb)
+}
+
+func (j *jsonContext) ReadMarshal(v Unmarshaller) (byte, error) {
+	var mb MarshalBytes
+
+	tag, err := j.Context.ReadMarshal(&mb)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag, json.Unmarshal(mb, v)
+}
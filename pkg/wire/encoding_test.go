@@ -0,0 +1,91 @@
+package wire
+
+import (
+	bytes "bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/horizon/pkg/pb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoding(t *testing.T) {
+	roundTrip := func(t *testing.T, encoding string) []byte {
+		var buf bytes.Buffer
+
+		fw, err := NewFramingWriter(&buf)
+		require.NoError(t, err)
+
+		wctx, err := WithEncoding(NewContext(nil, nil, fw), encoding)
+		require.NoError(t, err)
+
+		req := &pb.Request{
+			Type:   pb.HTTP,
+			Method: "GET",
+			Path:   "/blah",
+			Headers: []*pb.Header{
+				{Name: "X-Test", Value: []string{"a", "b"}},
+			},
+		}
+
+		err = wctx.WriteMarshal(1, req)
+		require.NoError(t, err)
+
+		frame := append([]byte(nil), buf.Bytes()...)
+
+		fr, err := NewFramingReader(bytes.NewReader(frame))
+		require.NoError(t, err)
+
+		rctx, err := WithEncoding(NewContext(nil, fr, nil), encoding)
+		require.NoError(t, err)
+
+		var out pb.Request
+
+		tag, err := rctx.ReadMarshal(&out)
+		require.NoError(t, err)
+
+		assert.Equal(t, byte(1), tag)
+		assert.Equal(t, req.Method, out.Method)
+		assert.Equal(t, req.Path, out.Path)
+		assert.Equal(t, req.Headers[0].Value, out.Headers[0].Value)
+
+		return frame
+	}
+
+	t.Run("round trips messages as protobuf", func(t *testing.T) {
+		roundTrip(t, ProtobufEncoding)
+	})
+
+	t.Run("round trips messages as json", func(t *testing.T) {
+		frame := roundTrip(t, JSONEncoding)
+
+		start := bytes.IndexByte(frame, '{')
+		require.True(t, start >= 0)
+
+		var m map[string]interface{}
+		err := json.Unmarshal(frame[start:], &m)
+		require.NoError(t, err)
+
+		assert.Equal(t, "GET", m["method"])
+	})
+
+	t.Run("rejects unknown encodings", func(t *testing.T) {
+		_, err := WithEncoding(NewContext(nil, nil, nil), "xml")
+		assert.True(t, errors.Cause(err) == ErrUnknownEncoding)
+	})
+
+	t.Run("puts the encoding in the protocol id", func(t *testing.T) {
+		assert.Equal(t, "http", ProtocolWithEncoding("http", ProtobufEncoding))
+		assert.Equal(t, "http+json", ProtocolWithEncoding("http", JSONEncoding))
+
+		proto, enc := SplitProtocol("http+json")
+		assert.Equal(t, "http", proto)
+		assert.Equal(t, JSONEncoding, enc)
+
+		proto, enc = SplitProtocol("http")
+		assert.Equal(t, "http", proto)
+		assert.Equal(t, ProtobufEncoding, enc)
+	})
+}
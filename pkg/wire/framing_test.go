@@ -48,4 +48,33 @@ func TestFraming(t *testing.T) {
 
 		assert.Equal(t, byte(30), tag)
 	})
+
+	t.Run("adapters only end cleanly with an eof frame", func(t *testing.T) {
+		var out bytes.Buffer
+
+		fw, err := NewFramingWriter(&out)
+		require.NoError(t, err)
+
+		w := fw.WriteAdapter()
+
+		_, err = w.Write([]byte("hello hzn!"))
+		require.NoError(t, err)
+
+		// Cut the data off without closing the adapter.
+		fr, err := NewFramingReader(bytes.NewReader(out.Bytes()))
+		require.NoError(t, err)
+
+		data, err := ioutil.ReadAll(fr.ReadAdapter())
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+		assert.Equal(t, "hello hzn!", string(data))
+
+		require.NoError(t, w.Close())
+
+		fr, err = NewFramingReader(bytes.NewReader(out.Bytes()))
+		require.NoError(t, err)
+
+		data, err = ioutil.ReadAll(fr.ReadAdapter())
+		require.NoError(t, err)
+		assert.Equal(t, "hello hzn!", string(data))
+	})
 }